@@ -79,6 +79,60 @@ export declare type ClientEnvelope = Message<"holdem.v1.ClientEnvelope"> & {
      */
     value: StartStoryRequest;
     case: "startStory";
+  } | {
+    /**
+     * @generated from field: holdem.v1.StraddleRequest straddle = 16;
+     */
+    value: StraddleRequest;
+    case: "straddle";
+  } | {
+    /**
+     * @generated from field: holdem.v1.CashOutRequest cash_out = 17;
+     */
+    value: CashOutRequest;
+    case: "cashOut";
+  } | {
+    /**
+     * @generated from field: holdem.v1.SitOutRequest sit_out = 18;
+     */
+    value: SitOutRequest;
+    case: "sitOut";
+  } | {
+    /**
+     * @generated from field: holdem.v1.SitInRequest sit_in = 19;
+     */
+    value: SitInRequest;
+    case: "sitIn";
+  } | {
+    /**
+     * @generated from field: holdem.v1.ListHandsRequest list_hands = 20;
+     */
+    value: ListHandsRequest;
+    case: "listHands";
+  } | {
+    /**
+     * @generated from field: holdem.v1.RunItTwiceRequest run_it_twice = 21;
+     */
+    value: RunItTwiceRequest;
+    case: "runItTwice";
+  } | {
+    /**
+     * @generated from field: holdem.v1.RequestSnapshotRequest request_snapshot = 22;
+     */
+    value: RequestSnapshotRequest;
+    case: "requestSnapshot";
+  } | {
+    /**
+     * @generated from field: holdem.v1.RematchRequest rematch = 23;
+     */
+    value: RematchRequest;
+    case: "rematch";
+  } | {
+    /**
+     * @generated from field: holdem.v1.RunItTwiceAcceptRequest run_it_twice_accept = 24;
+     */
+    value: RunItTwiceAcceptRequest;
+    case: "runItTwiceAccept";
   } | { case: undefined; value?: undefined };
 };
 
@@ -210,6 +264,48 @@ export declare type ServerEnvelope = Message<"holdem.v1.ServerEnvelope"> & {
      */
     value: StoryProgressState;
     case: "storyProgress";
+  } | {
+    /**
+     * @generated from field: holdem.v1.SessionEnd session_end = 26;
+     */
+    value: SessionEnd;
+    case: "sessionEnd";
+  } | {
+    /**
+     * @generated from field: holdem.v1.HandList hand_list = 27;
+     */
+    value: HandList;
+    case: "handList";
+  } | {
+    /**
+     * @generated from field: holdem.v1.RabbitHunt rabbit_hunt = 28;
+     */
+    value: RabbitHunt;
+    case: "rabbitHunt";
+  } | {
+    /**
+     * @generated from field: holdem.v1.DealerDraw dealer_draw = 29;
+     */
+    value: DealerDraw;
+    case: "dealerDraw";
+  } | {
+    /**
+     * @generated from field: holdem.v1.UncalledReturn uncalled_return = 30;
+     */
+    value: UncalledReturn;
+    case: "uncalledReturn";
+  } | {
+    /**
+     * @generated from field: holdem.v1.RunItTwiceOffer run_it_twice_offer = 31;
+     */
+    value: RunItTwiceOffer;
+    case: "runItTwiceOffer";
+  } | {
+    /**
+     * @generated from field: holdem.v1.ChapterScoreboard chapter_scoreboard = 32;
+     */
+    value: ChapterScoreboard;
+    case: "chapterScoreboard";
   } | { case: undefined; value?: undefined };
 };
 
@@ -241,11 +337,25 @@ export declare type LoginResponse = Message<"holdem.v1.LoginResponse"> & {
 export declare const LoginResponseSchema: GenMessage<LoginResponse>;
 
 /**
- * Empty for now, table_id is in envelope
- *
  * @generated from message holdem.v1.JoinTableRequest
  */
 export declare type JoinTableRequest = Message<"holdem.v1.JoinTableRequest"> & {
+  /**
+   * table_id is in the envelope.
+   *
+   * watch without taking a seat
+   *
+   * @generated from field: bool observe = 1;
+   */
+  observe: boolean;
+
+  /**
+   * Named stake from the server's table config; empty picks the default.
+   * Ignored when resuming a seat the player already holds.
+   *
+   * @generated from field: string stake = 2;
+   */
+  stake: string;
 };
 
 /**
@@ -303,6 +413,159 @@ export declare type BuyInRequest = Message<"holdem.v1.BuyInRequest"> & {
  */
 export declare const BuyInRequestSchema: GenMessage<BuyInRequest>;
 
+/**
+ * Keep the seat but skip the following hands.
+ *
+ * @generated from message holdem.v1.SitOutRequest
+ */
+export declare type SitOutRequest = Message<"holdem.v1.SitOutRequest"> & {
+};
+
+/**
+ * Describes the message holdem.v1.SitOutRequest.
+ * Use `create(SitOutRequestSchema)` to create a new message.
+ */
+export declare const SitOutRequestSchema: GenMessage<SitOutRequest>;
+
+/**
+ * @generated from message holdem.v1.SitInRequest
+ */
+export declare type SitInRequest = Message<"holdem.v1.SitInRequest"> & {
+};
+
+/**
+ * Describes the message holdem.v1.SitInRequest.
+ * Use `create(SitInRequestSchema)` to create a new message.
+ */
+export declare const SitInRequestSchema: GenMessage<SitInRequest>;
+
+/**
+ * Opt in to straddle the next hand from the given chair (UTG or button,
+ * depending on table config).
+ *
+ * @generated from message holdem.v1.StraddleRequest
+ */
+export declare type StraddleRequest = Message<"holdem.v1.StraddleRequest"> & {
+  /**
+   * @generated from field: uint32 chair = 1;
+   */
+  chair: number;
+};
+
+/**
+ * Describes the message holdem.v1.StraddleRequest.
+ * Use `create(StraddleRequestSchema)` to create a new message.
+ */
+export declare const StraddleRequestSchema: GenMessage<StraddleRequest>;
+
+/**
+ * Opt in to cash out at equity if this hand runs out with the player all-in.
+ * Covers the current hand only.
+ *
+ * @generated from message holdem.v1.CashOutRequest
+ */
+export declare type CashOutRequest = Message<"holdem.v1.CashOutRequest"> & {
+};
+
+/**
+ * Describes the message holdem.v1.CashOutRequest.
+ * Use `create(CashOutRequestSchema)` to create a new message.
+ */
+export declare const CashOutRequestSchema: GenMessage<CashOutRequest>;
+
+/**
+ * Agree to run the rest of the board twice if this hand runs out all-in. It
+ * only happens when every player still in the hand agrees; covers the current
+ * hand only.
+ *
+ * @generated from message holdem.v1.RunItTwiceRequest
+ */
+export declare type RunItTwiceRequest = Message<"holdem.v1.RunItTwiceRequest"> & {
+};
+
+/**
+ * Describes the message holdem.v1.RunItTwiceRequest.
+ * Use `create(RunItTwiceRequestSchema)` to create a new message.
+ */
+export declare const RunItTwiceRequestSchema: GenMessage<RunItTwiceRequest>;
+
+/**
+ * Answer a RunItTwiceOffer for the current hand. The board is run twice only
+ * if every offered player accepts before the deadline.
+ *
+ * @generated from message holdem.v1.RunItTwiceAcceptRequest
+ */
+export declare type RunItTwiceAcceptRequest = Message<"holdem.v1.RunItTwiceAcceptRequest"> & {
+  /**
+   * @generated from field: bool accept = 1;
+   */
+  accept: boolean;
+};
+
+/**
+ * Describes the message holdem.v1.RunItTwiceAcceptRequest.
+ * Use `create(RunItTwiceAcceptRequestSchema)` to create a new message.
+ */
+export declare const RunItTwiceAcceptRequestSchema: GenMessage<RunItTwiceAcceptRequest>;
+
+/**
+ * Ask for a fresh TableSnapshot, e.g. from a refresh button, without
+ * reconnecting. Rate-limited per user.
+ *
+ * @generated from message holdem.v1.RequestSnapshotRequest
+ */
+export declare type RequestSnapshotRequest = Message<"holdem.v1.RequestSnapshotRequest"> & {
+};
+
+/**
+ * Describes the message holdem.v1.RequestSnapshotRequest.
+ * Use `create(RequestSnapshotRequestSchema)` to create a new message.
+ */
+export declare const RequestSnapshotRequestSchema: GenMessage<RequestSnapshotRequest>;
+
+/**
+ * Replay the caller's heads-up session or story chapter that just ended: the
+ * same config and NPC opponents, or the same chapter. A human opponent joins
+ * the new table if they also ask.
+ *
+ * @generated from message holdem.v1.RematchRequest
+ */
+export declare type RematchRequest = Message<"holdem.v1.RematchRequest"> & {
+};
+
+/**
+ * Describes the message holdem.v1.RematchRequest.
+ * Use `create(RematchRequestSchema)` to create a new message.
+ */
+export declare const RematchRequestSchema: GenMessage<RematchRequest>;
+
+/**
+ * Ask for the caller's recent hand history, as served by the audit API.
+ *
+ * @generated from message holdem.v1.ListHandsRequest
+ */
+export declare type ListHandsRequest = Message<"holdem.v1.ListHandsRequest"> & {
+  /**
+   * "live" (default) or "replay"
+   *
+   * @generated from field: string source = 1;
+   */
+  source: string;
+
+  /**
+   * 0 uses the server default
+   *
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message holdem.v1.ListHandsRequest.
+ * Use `create(ListHandsRequestSchema)` to create a new message.
+ */
+export declare const ListHandsRequestSchema: GenMessage<ListHandsRequest>;
+
 /**
  * @generated from message holdem.v1.ActionRequest
  */
@@ -318,6 +581,13 @@ export declare type ActionRequest = Message<"holdem.v1.ActionRequest"> & {
    * @generated from field: int64 amount = 2;
    */
   amount: bigint;
+
+  /**
+   * When set on BET/RAISE, the server resolves the amount from pot context and ignores amount.
+   *
+   * @generated from field: holdem.v1.SizingPreset sizing_preset = 3;
+   */
+  sizingPreset: SizingPreset;
 };
 
 /**
@@ -475,6 +745,106 @@ export declare type StoryProgressState = Message<"holdem.v1.StoryProgressState">
  */
 export declare const StoryProgressStateSchema: GenMessage<StoryProgressState>;
 
+/**
+ * Running standings of a story chapter, sent to the player after every hand.
+ *
+ * @generated from message holdem.v1.ChapterScoreboard
+ */
+export declare type ChapterScoreboard = Message<"holdem.v1.ChapterScoreboard"> & {
+  /**
+   * @generated from field: int32 chapter_id = 1;
+   */
+  chapterId: number;
+
+  /**
+   * @generated from field: int32 hands_played = 2;
+   */
+  handsPlayed: number;
+
+  /**
+   * Every seat bought in for start_stack when the chapter began.
+   *
+   * @generated from field: int64 start_stack = 3;
+   */
+  startStack: bigint;
+
+  /**
+   * by stack, chip leader first
+   *
+   * @generated from field: repeated holdem.v1.ChapterStanding standings = 4;
+   */
+  standings: ChapterStanding[];
+
+  /**
+   * How far the player is toward the chapter objective, in its own unit
+   * (big blinds won, hands, pots or chips); complete at objective_target.
+   *
+   * @generated from field: int64 objective_progress = 5;
+   */
+  objectiveProgress: bigint;
+
+  /**
+   * @generated from field: int64 objective_target = 6;
+   */
+  objectiveTarget: bigint;
+};
+
+/**
+ * Describes the message holdem.v1.ChapterScoreboard.
+ * Use `create(ChapterScoreboardSchema)` to create a new message.
+ */
+export declare const ChapterScoreboardSchema: GenMessage<ChapterScoreboard>;
+
+/**
+ * @generated from message holdem.v1.ChapterStanding
+ */
+export declare type ChapterStanding = Message<"holdem.v1.ChapterStanding"> & {
+  /**
+   * @generated from field: uint32 chair = 1;
+   */
+  chair: number;
+
+  /**
+   * @generated from field: uint64 user_id = 2;
+   */
+  userId: bigint;
+
+  /**
+   * @generated from field: int64 stack = 3;
+   */
+  stack: bigint;
+
+  /**
+   * stack - start_stack
+   *
+   * @generated from field: int64 net = 4;
+   */
+  net: bigint;
+
+  /**
+   * 1 for the chip leader; equal stacks share a rank
+   *
+   * @generated from field: int32 rank = 5;
+   */
+  rank: number;
+
+  /**
+   * @generated from field: bool is_hero = 6;
+   */
+  isHero: boolean;
+
+  /**
+   * @generated from field: bool busted = 7;
+   */
+  busted: boolean;
+};
+
+/**
+ * Describes the message holdem.v1.ChapterStanding.
+ * Use `create(ChapterStandingSchema)` to create a new message.
+ */
+export declare const ChapterStandingSchema: GenMessage<ChapterStanding>;
+
 /**
  * @generated from message holdem.v1.ErrorResponse
  */
@@ -488,6 +858,13 @@ export declare type ErrorResponse = Message<"holdem.v1.ErrorResponse"> & {
    * @generated from field: string message = 2;
    */
   message: string;
+
+  /**
+   * Set when an action is rejected so the client can correct itself.
+   *
+   * @generated from field: holdem.v1.ActionOptions action_options = 3;
+   */
+  actionOptions?: ActionOptions;
 };
 
 /**
@@ -496,6 +873,43 @@ export declare type ErrorResponse = Message<"holdem.v1.ErrorResponse"> & {
  */
 export declare const ErrorResponseSchema: GenMessage<ErrorResponse>;
 
+/**
+ * What a player may do at the moment an action of theirs was rejected.
+ *
+ * @generated from message holdem.v1.ActionOptions
+ */
+export declare type ActionOptions = Message<"holdem.v1.ActionOptions"> & {
+  /**
+   * chair whose turn it is; not the player's own when they acted out of turn.
+   *
+   * @generated from field: uint32 action_chair = 1;
+   */
+  actionChair: number;
+
+  /**
+   * empty unless it is the player's turn.
+   *
+   * @generated from field: repeated holdem.v1.ActionType legal_actions = 2;
+   */
+  legalActions: ActionType[];
+
+  /**
+   * @generated from field: int64 min_raise_to = 3;
+   */
+  minRaiseTo: bigint;
+
+  /**
+   * @generated from field: int64 call_amount = 4;
+   */
+  callAmount: bigint;
+};
+
+/**
+ * Describes the message holdem.v1.ActionOptions.
+ * Use `create(ActionOptionsSchema)` to create a new message.
+ */
+export declare const ActionOptionsSchema: GenMessage<ActionOptions>;
+
 /**
  * @generated from message holdem.v1.TableSnapshot
  */
@@ -559,6 +973,11 @@ export declare type TableSnapshot = Message<"holdem.v1.TableSnapshot"> & {
    * @generated from field: repeated holdem.v1.PlayerState players = 12;
    */
   players: PlayerState[];
+
+  /**
+   * @generated from field: uint32 spectator_count = 13;
+   */
+  spectatorCount: number;
 };
 
 /**
@@ -670,6 +1089,27 @@ export declare type PlayerState = Message<"holdem.v1.PlayerState"> & {
    * @generated from field: string avatar_key = 11;
    */
   avatarKey: string;
+
+  /**
+   * color tag the viewer put on this player; private to the viewer.
+   *
+   * @generated from field: string color_tag = 12;
+   */
+  colorTag: string;
+
+  /**
+   * chips this player must add to call the current bet; 0 when folded or all-in.
+   *
+   * @generated from field: int64 to_call = 13;
+   */
+  toCall: bigint;
+
+  /**
+   * seat kept but dealt out of hands until the player sits back in.
+   *
+   * @generated from field: bool sitting_out = 14;
+   */
+  sittingOut: boolean;
 };
 
 /**
@@ -738,6 +1178,52 @@ export declare type SeatUpdate = Message<"holdem.v1.SeatUpdate"> & {
  */
 export declare const SeatUpdateSchema: GenMessage<SeatUpdate>;
 
+/**
+ * DealerDraw shows the cards dealt face up to place the first button when the
+ * table draws for it; the highest card, suits breaking ties, takes the button.
+ * It precedes that hand's HandStart.
+ *
+ * @generated from message holdem.v1.DealerDraw
+ */
+export declare type DealerDraw = Message<"holdem.v1.DealerDraw"> & {
+  /**
+   * @generated from field: repeated holdem.v1.DealerDrawCard cards = 1;
+   */
+  cards: DealerDrawCard[];
+
+  /**
+   * @generated from field: uint32 dealer_chair = 2;
+   */
+  dealerChair: number;
+};
+
+/**
+ * Describes the message holdem.v1.DealerDraw.
+ * Use `create(DealerDrawSchema)` to create a new message.
+ */
+export declare const DealerDrawSchema: GenMessage<DealerDraw>;
+
+/**
+ * @generated from message holdem.v1.DealerDrawCard
+ */
+export declare type DealerDrawCard = Message<"holdem.v1.DealerDrawCard"> & {
+  /**
+   * @generated from field: uint32 chair = 1;
+   */
+  chair: number;
+
+  /**
+   * @generated from field: holdem.v1.Card card = 2;
+   */
+  card?: Card;
+};
+
+/**
+ * Describes the message holdem.v1.DealerDrawCard.
+ * Use `create(DealerDrawCardSchema)` to create a new message.
+ */
+export declare const DealerDrawCardSchema: GenMessage<DealerDrawCard>;
+
 /**
  * @generated from message holdem.v1.HandStart
  */
@@ -771,6 +1257,39 @@ export declare type HandStart = Message<"holdem.v1.HandStart"> & {
    * @generated from field: int64 big_blind_amount = 6;
    */
   bigBlindAmount: bigint;
+
+  /**
+   * hex sha256 of "<hand_id>:<seed>", committing to the deck before any card
+   * is dealt; the seed is revealed in HandEnd. Empty for fixed test decks.
+   *
+   * @generated from field: string seed_commitment = 7;
+   */
+  seedCommitment: string;
+
+  /**
+   * Forced money posted before the first action: ante_amount is the ante each
+   * player was asked for, straddle_amount the straddle posted by
+   * straddle_chair (0 when nobody straddled), and forced_total everything in
+   * the pot and in front of players once antes, blinds and straddles are in.
+   *
+   * @generated from field: int64 ante_amount = 8;
+   */
+  anteAmount: bigint;
+
+  /**
+   * @generated from field: uint32 straddle_chair = 9;
+   */
+  straddleChair: number;
+
+  /**
+   * @generated from field: int64 straddle_amount = 10;
+   */
+  straddleAmount: bigint;
+
+  /**
+   * @generated from field: int64 forced_total = 11;
+   */
+  forcedTotal: bigint;
 };
 
 /**
@@ -986,6 +1505,14 @@ export declare type Showdown = Message<"holdem.v1.Showdown"> & {
    * @generated from field: repeated holdem.v1.NetResult net_results = 4;
    */
   netResults: NetResult[];
+
+  /**
+   * Set when the board was run more than once; pot_results then total
+   * every run.
+   *
+   * @generated from field: repeated holdem.v1.BoardRun runs = 5;
+   */
+  runs: BoardRun[];
 };
 
 /**
@@ -994,6 +1521,30 @@ export declare type Showdown = Message<"holdem.v1.Showdown"> & {
  */
 export declare const ShowdownSchema: GenMessage<Showdown>;
 
+/**
+ * BoardRun is one board of a multi-run showdown and the share of each pot,
+ * after rake, it paid.
+ *
+ * @generated from message holdem.v1.BoardRun
+ */
+export declare type BoardRun = Message<"holdem.v1.BoardRun"> & {
+  /**
+   * @generated from field: repeated holdem.v1.Card board = 1;
+   */
+  board: Card[];
+
+  /**
+   * @generated from field: repeated holdem.v1.PotResult pot_results = 2;
+   */
+  potResults: PotResult[];
+};
+
+/**
+ * Describes the message holdem.v1.BoardRun.
+ * Use `create(BoardRunSchema)` to create a new message.
+ */
+export declare const BoardRunSchema: GenMessage<BoardRun>;
+
 /**
  * @generated from message holdem.v1.ShowdownHand
  */
@@ -1017,6 +1568,13 @@ export declare type ShowdownHand = Message<"holdem.v1.ShowdownHand"> & {
    * @generated from field: holdem.v1.HandRank rank = 4;
    */
   rank: HandRank;
+
+  /**
+   * Finishing order among shown hands: 1 = best, tied hands share a rank.
+   *
+   * @generated from field: uint32 showdown_rank = 5;
+   */
+  showdownRank: number;
 };
 
 /**
@@ -1038,6 +1596,13 @@ export declare type PotResult = Message<"holdem.v1.PotResult"> & {
    * @generated from field: repeated holdem.v1.Winner winners = 2;
    */
   winners: Winner[];
+
+  /**
+   * house rake taken from pot_amount before the winners were paid.
+   *
+   * @generated from field: int64 rake = 3;
+   */
+  rake: bigint;
 };
 
 /**
@@ -1092,6 +1657,25 @@ export declare type HandEnd = Message<"holdem.v1.HandEnd"> & {
    * @generated from field: repeated holdem.v1.NetResult net_results = 4;
    */
   netResults: NetResult[];
+
+  /**
+   * @generated from field: repeated holdem.v1.CashOutResult cash_outs = 5;
+   */
+  cashOuts: CashOutResult[];
+
+  /**
+   * total rake kept by the house this hand.
+   *
+   * @generated from field: int64 rake_amount = 6;
+   */
+  rakeAmount: bigint;
+
+  /**
+   * seed the deck was shuffled from; matches HandStart.seed_commitment.
+   *
+   * @generated from field: int64 deck_seed = 7;
+   */
+  deckSeed: bigint;
 };
 
 /**
@@ -1100,6 +1684,152 @@ export declare type HandEnd = Message<"holdem.v1.HandEnd"> & {
  */
 export declare const HandEndSchema: GenMessage<HandEnd>;
 
+/**
+ * An all-in player paid their equity instead of the runout; stack_deltas
+ * already include it.
+ *
+ * @generated from message holdem.v1.CashOutResult
+ */
+export declare type CashOutResult = Message<"holdem.v1.CashOutResult"> & {
+  /**
+   * @generated from field: uint32 chair = 1;
+   */
+  chair: number;
+
+  /**
+   * @generated from field: int64 payout = 2;
+   */
+  payout: bigint;
+
+  /**
+   * what the runout actually paid the player.
+   *
+   * @generated from field: int64 runout_amount = 3;
+   */
+  runoutAmount: bigint;
+};
+
+/**
+ * Describes the message holdem.v1.CashOutResult.
+ * Use `create(CashOutResultSchema)` to create a new message.
+ */
+export declare const CashOutResultSchema: GenMessage<CashOutResult>;
+
+/**
+ * Sent when a table reaches its hand limit: everyone is cashed out and the
+ * table closes, so clients should return to the lobby.
+ *
+ * @generated from message holdem.v1.SessionEnd
+ */
+export declare type SessionEnd = Message<"holdem.v1.SessionEnd"> & {
+  /**
+   * @generated from field: uint32 hands_played = 1;
+   */
+  handsPlayed: number;
+
+  /**
+   * @generated from field: repeated holdem.v1.SessionStack stacks = 2;
+   */
+  stacks: SessionStack[];
+};
+
+/**
+ * Describes the message holdem.v1.SessionEnd.
+ * Use `create(SessionEndSchema)` to create a new message.
+ */
+export declare const SessionEndSchema: GenMessage<SessionEnd>;
+
+/**
+ * Recent hands of the requesting user, newest first.
+ *
+ * @generated from message holdem.v1.HandList
+ */
+export declare type HandList = Message<"holdem.v1.HandList"> & {
+  /**
+   * @generated from field: string source = 1;
+   */
+  source: string;
+
+  /**
+   * @generated from field: repeated holdem.v1.HandListItem items = 2;
+   */
+  items: HandListItem[];
+};
+
+/**
+ * Describes the message holdem.v1.HandList.
+ * Use `create(HandListSchema)` to create a new message.
+ */
+export declare const HandListSchema: GenMessage<HandList>;
+
+/**
+ * @generated from message holdem.v1.HandListItem
+ */
+export declare type HandListItem = Message<"holdem.v1.HandListItem"> & {
+  /**
+   * @generated from field: string hand_id = 1;
+   */
+  handId: string;
+
+  /**
+   * @generated from field: int64 played_at_ms = 2;
+   */
+  playedAtMs: bigint;
+
+  /**
+   * @generated from field: bool is_saved = 3;
+   */
+  isSaved: boolean;
+
+  /**
+   * 0 when not saved
+   *
+   * @generated from field: int64 saved_at_ms = 4;
+   */
+  savedAtMs: bigint;
+
+  /**
+   * the stored hand summary as a JSON object
+   *
+   * @generated from field: string summary_json = 5;
+   */
+  summaryJson: string;
+};
+
+/**
+ * Describes the message holdem.v1.HandListItem.
+ * Use `create(HandListItemSchema)` to create a new message.
+ */
+export declare const HandListItemSchema: GenMessage<HandListItem>;
+
+/**
+ * @generated from message holdem.v1.SessionStack
+ */
+export declare type SessionStack = Message<"holdem.v1.SessionStack"> & {
+  /**
+   * @generated from field: uint64 user_id = 1;
+   */
+  userId: bigint;
+
+  /**
+   * @generated from field: uint32 chair = 2;
+   */
+  chair: number;
+
+  /**
+   * chips cashed out at the end of the session.
+   *
+   * @generated from field: int64 stack = 3;
+   */
+  stack: bigint;
+};
+
+/**
+ * Describes the message holdem.v1.SessionStack.
+ * Use `create(SessionStackSchema)` to create a new message.
+ */
+export declare const SessionStackSchema: GenMessage<SessionStack>;
+
 /**
  * @generated from message holdem.v1.StackDelta
  */
@@ -1152,6 +1882,25 @@ export declare type WinByFold = Message<"holdem.v1.WinByFold"> & {
  */
 export declare const WinByFoldSchema: GenMessage<WinByFold>;
 
+/**
+ * RabbitHunt shows the board cards a fold win left undealt. It is for display
+ * only and follows WinByFold when the table enables it.
+ *
+ * @generated from message holdem.v1.RabbitHunt
+ */
+export declare type RabbitHunt = Message<"holdem.v1.RabbitHunt"> & {
+  /**
+   * @generated from field: repeated holdem.v1.Card cards = 1;
+   */
+  cards: Card[];
+};
+
+/**
+ * Describes the message holdem.v1.RabbitHunt.
+ * Use `create(RabbitHuntSchema)` to create a new message.
+ */
+export declare const RabbitHuntSchema: GenMessage<RabbitHunt>;
+
 /**
  * @generated from message holdem.v1.ExcessRefund
  */
@@ -1173,6 +1922,57 @@ export declare type ExcessRefund = Message<"holdem.v1.ExcessRefund"> & {
  */
 export declare const ExcessRefundSchema: GenMessage<ExcessRefund>;
 
+/**
+ * The part of a bet no one matched, handed back to chair as soon as the
+ * betting that left it uncalled closes; it is already in the chair's stack
+ * and is not part of the pots or winnings that follow.
+ *
+ * @generated from message holdem.v1.UncalledReturn
+ */
+export declare type UncalledReturn = Message<"holdem.v1.UncalledReturn"> & {
+  /**
+   * @generated from field: uint32 chair = 1;
+   */
+  chair: number;
+
+  /**
+   * @generated from field: int64 amount = 2;
+   */
+  amount: bigint;
+};
+
+/**
+ * Describes the message holdem.v1.UncalledReturn.
+ * Use `create(UncalledReturnSchema)` to create a new message.
+ */
+export declare const UncalledReturnSchema: GenMessage<UncalledReturn>;
+
+/**
+ * Sent to the players of an all-in hand, before the rest of the board is
+ * dealt, when the table negotiates running it twice. chairs lists everyone
+ * who must accept (see RunItTwiceAcceptRequest); the board runs once if any
+ * declines or deadline_ms passes first.
+ *
+ * @generated from message holdem.v1.RunItTwiceOffer
+ */
+export declare type RunItTwiceOffer = Message<"holdem.v1.RunItTwiceOffer"> & {
+  /**
+   * @generated from field: repeated uint32 chairs = 1;
+   */
+  chairs: number[];
+
+  /**
+   * @generated from field: int64 deadline_ms = 2;
+   */
+  deadlineMs: bigint;
+};
+
+/**
+ * Describes the message holdem.v1.RunItTwiceOffer.
+ * Use `create(RunItTwiceOfferSchema)` to create a new message.
+ */
+export declare const RunItTwiceOfferSchema: GenMessage<RunItTwiceOffer>;
+
 /**
  * @generated from message holdem.v1.NetResult
  */
@@ -1375,6 +2175,36 @@ export enum HandRank {
  */
 export declare const HandRankSchema: GenEnum<HandRank>;
 
+/**
+ * @generated from enum holdem.v1.SizingPreset
+ */
+export enum SizingPreset {
+  /**
+   * @generated from enum value: SIZING_PRESET_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: SIZING_PRESET_HALF_POT = 1;
+   */
+  HALF_POT = 1,
+
+  /**
+   * @generated from enum value: SIZING_PRESET_THREE_QUARTER_POT = 2;
+   */
+  THREE_QUARTER_POT = 2,
+
+  /**
+   * @generated from enum value: SIZING_PRESET_POT = 3;
+   */
+  POT = 3,
+}
+
+/**
+ * Describes the enum holdem.v1.SizingPreset.
+ */
+export declare const SizingPresetSchema: GenEnum<SizingPreset>;
+
 /**
  * @generated from enum holdem.v1.Suit
  */
@@ -8,7 +8,7 @@ import { enumDesc, fileDesc, messageDesc, tsEnum } from "@bufbuild/protobuf/code
  * Describes the file messages.proto.
  */
 export const file_messages = /*@__PURE__*/
-  fileDesc("Cg5tZXNzYWdlcy5wcm90bxIJaG9sZGVtLnYxIvUCCg5DbGllbnRFbnZlbG9wZRIQCgh0YWJsZV9pZBgBIAEoCRIPCgd1c2VyX2lkGAIgASgEEgsKA3NlcRgDIAEoBBILCgNhY2sYBCABKAQSMQoKam9pbl90YWJsZRgKIAEoCzIbLmhvbGRlbS52MS5Kb2luVGFibGVSZXF1ZXN0SAASLQoIc2l0X2Rvd24YCyABKAsyGS5ob2xkZW0udjEuU2l0RG93blJlcXVlc3RIABItCghzdGFuZF91cBgMIAEoCzIZLmhvbGRlbS52MS5TdGFuZFVwUmVxdWVzdEgAEikKBmJ1eV9pbhgNIAEoCzIXLmhvbGRlbS52MS5CdXlJblJlcXVlc3RIABIqCgZhY3Rpb24YDiABKAsyGC5ob2xkZW0udjEuQWN0aW9uUmVxdWVzdEgAEjMKC3N0YXJ0X3N0b3J5GA8gASgLMhwuaG9sZGVtLnYxLlN0YXJ0U3RvcnlSZXF1ZXN0SABCCQoHcGF5bG9hZCLXBgoOU2VydmVyRW52ZWxvcGUSEAoIdGFibGVfaWQYASABKAkSEgoKc2VydmVyX3NlcRgCIAEoBBIUCgxzZXJ2ZXJfdHNfbXMYAyABKAMSKQoFZXJyb3IYCiABKAsyGC5ob2xkZW0udjEuRXJyb3JSZXNwb25zZUgAEjIKDnRhYmxlX3NuYXBzaG90GAsgASgLMhguaG9sZGVtLnYxLlRhYmxlU25hcHNob3RIABIsCgtzZWF0X3VwZGF0ZRgMIAEoCzIVLmhvbGRlbS52MS5TZWF0VXBkYXRlSAASKgoKaGFuZF9zdGFydBgNIAEoCzIULmhvbGRlbS52MS5IYW5kU3RhcnRIABIzCg9kZWFsX2hvbGVfY2FyZHMYDiABKAsyGC5ob2xkZW0udjEuRGVhbEhvbGVDYXJkc0gAEioKCmRlYWxfYm9hcmQYDyABKAsyFC5ob2xkZW0udjEuRGVhbEJvYXJkSAASMAoNYWN0aW9uX3Byb21wdBgQIAEoCzIXLmhvbGRlbS52MS5BY3Rpb25Qcm9tcHRIABIwCg1hY3Rpb25fcmVzdWx0GBEgASgLMhcuaG9sZGVtLnYxLkFjdGlvblJlc3VsdEgAEioKCnBvdF91cGRhdGUYEiABKAsyFC5ob2xkZW0udjEuUG90VXBkYXRlSAASJwoIc2hvd2Rvd24YEyABKAsyEy5ob2xkZW0udjEuU2hvd2Rvd25IABImCghoYW5kX2VuZBgUIAEoCzISLmhvbGRlbS52MS5IYW5kRW5kSAASLgoMcGhhc2VfY2hhbmdlGBUgASgLMhYuaG9sZGVtLnYxLlBoYXNlQ2hhbmdlSAASKwoLd2luX2J5X2ZvbGQYFiABKAsyFC5ob2xkZW0udjEuV2luQnlGb2xkSAASMgoObG9naW5fcmVzcG9uc2UYFyABKAsyGC5ob2xkZW0udjEuTG9naW5SZXNwb25zZUgAEjkKEnN0b3J5X2NoYXB0ZXJfaW5mbxgYIAEoCzIbLmhvbGRlbS52MS5TdG9yeUNoYXB0ZXJJbmZvSAASNwoOc3RvcnlfcHJvZ3Jlc3MYGSABKAsyHS5ob2xkZW0udjEuU3RvcnlQcm9ncmVzc1N0YXRlSABCCQoHcGF5bG9hZCI3Cg1Mb2dpblJlc3BvbnNlEg8KB3VzZXJfaWQYASABKAQSFQoNc2Vzc2lvbl90b2tlbhgCIAEoCSISChBKb2luVGFibGVSZXF1ZXN0IjYKDlNpdERvd25SZXF1ZXN0Eg0KBWNoYWlyGAEgASgNEhUKDWJ1eV9pbl9hbW91bnQYAiABKAMiEAoOU3RhbmRVcFJlcXVlc3QiHgoMQnV5SW5SZXF1ZXN0Eg4KBmFtb3VudBgBIAEoAyJGCg1BY3Rpb25SZXF1ZXN0EiUKBmFjdGlvbhgBIAEoDjIVLmhvbGRlbS52MS5BY3Rpb25UeXBlEg4KBmFtb3VudBgCIAEoAyInChFTdGFydFN0b3J5UmVxdWVzdBISCgpjaGFwdGVyX2lkGAEgASgFIpMBCgxTdG9yeU5wY0luZm8SDgoGbnBjX2lkGAEgASgJEgwKBG5hbWUYAiABKAkSEQoJcmVpX2ludHJvGAMgASgJEhEKCXJlaV9zdHlsZRgEIAEoCRIPCgdpc19ib3NzGAUgASgIEhoKEmZpcnN0X3NlZW5fY2hhcHRlchgGIAEoBRISCgphdmF0YXJfa2V5GAcgASgJItsBChBTdG9yeUNoYXB0ZXJJbmZvEhIKCmNoYXB0ZXJfaWQYASABKAUSDQoFdGl0bGUYAiABKAkSEAoIc3VidGl0bGUYAyABKAkSFgoOb2JqZWN0aXZlX2Rlc2MYBCABKAkSEQoJcmVpX2ludHJvGAUgASgJEhUKDXJlaV9ib3NzX25vdGUYBiABKAkSEQoJYm9zc19uYW1lGAcgASgJEhAKCHRhYmxlX2lkGAggASgJEisKCm5wY19yb3N0ZXIYCSADKAsyFy5ob2xkZW0udjEuU3RvcnlOcGNJbmZvIpABChJTdG9yeVByb2dyZXNzU3RhdGUSIQoZaGlnaGVzdF9jb21wbGV0ZWRfY2hhcHRlchgBIAEoBRIgChhoaWdoZXN0X3VubG9ja2VkX2NoYXB0ZXIYAiABKAUSGgoSY29tcGxldGVkX2NoYXB0ZXJzGAMgAygFEhkKEXVubG9ja2VkX2ZlYXR1cmVzGAQgAygJIi4KDUVycm9yUmVzcG9uc2USDAoEY29kZRgBIAEoBRIPCgdtZXNzYWdlGAIgASgJIuICCg1UYWJsZVNuYXBzaG90EiYKBmNvbmZpZxgBIAEoCzIWLmhvbGRlbS52MS5UYWJsZUNvbmZpZxIfCgVwaGFzZRgCIAEoDjIQLmhvbGRlbS52MS5QaGFzZRINCgVyb3VuZBgDIAEoDRIUCgxkZWFsZXJfY2hhaXIYBCABKA0SGQoRc21hbGxfYmxpbmRfY2hhaXIYBSABKA0SFwoPYmlnX2JsaW5kX2NoYWlyGAYgASgNEhQKDGFjdGlvbl9jaGFpchgHIAEoDRIPCgdjdXJfYmV0GAggASgDEhcKD21pbl9yYWlzZV9kZWx0YRgJIAEoAxIoCg9jb21tdW5pdHlfY2FyZHMYCiADKAsyDy5ob2xkZW0udjEuQ2FyZBIcCgRwb3RzGAsgAygLMg4uaG9sZGVtLnYxLlBvdBInCgdwbGF5ZXJzGAwgAygLMhYuaG9sZGVtLnYxLlBsYXllclN0YXRlIoABCgtUYWJsZUNvbmZpZxITCgttYXhfcGxheWVycxgBIAEoDRITCgtzbWFsbF9ibGluZBgCIAEoAxIRCgliaWdfYmxpbmQYAyABKAMSDAoEYW50ZRgEIAEoAxISCgptaW5fYnV5X2luGAUgASgDEhIKCm1heF9idXlfaW4YBiABKAMi8wEKC1BsYXllclN0YXRlEg8KB3VzZXJfaWQYASABKAQSDQoFY2hhaXIYAiABKA0SEAoIbmlja25hbWUYAyABKAkSDQoFc3RhY2sYBCABKAMSCwoDYmV0GAUgASgDEg4KBmZvbGRlZBgGIAEoCBIOCgZhbGxfaW4YByABKAgSKgoLbGFzdF9hY3Rpb24YCCABKA4yFS5ob2xkZW0udjEuQWN0aW9uVHlwZRIjCgpoYW5kX2NhcmRzGAkgAygLMg8uaG9sZGVtLnYxLkNhcmQSEQoJaGFzX2NhcmRzGAogASgIEhIKCmF2YXRhcl9rZXkYCyABKAkiLgoDUG90Eg4KBmFtb3VudBgBIAEoAxIXCg9lbGlnaWJsZV9jaGFpcnMYAiADKA0ijQEKClNlYXRVcGRhdGUSDQoFY2hhaXIYASABKA0SLwoNcGxheWVyX2pvaW5lZBgCIAEoCzIWLmhvbGRlbS52MS5QbGF5ZXJTdGF0ZUgAEh0KE3BsYXllcl9sZWZ0X3VzZXJfaWQYAyABKARIABIWCgxzdGFja19jaGFuZ2UYBCABKANIAEIICgZ1cGRhdGUimgEKCUhhbmRTdGFydBINCgVyb3VuZBgBIAEoDRIUCgxkZWFsZXJfY2hhaXIYAiABKA0SGQoRc21hbGxfYmxpbmRfY2hhaXIYAyABKA0SFwoPYmlnX2JsaW5kX2NoYWlyGAQgASgNEhoKEnNtYWxsX2JsaW5kX2Ftb3VudBgFIAEoAxIYChBiaWdfYmxpbmRfYW1vdW50GAYgASgDIi8KDURlYWxIb2xlQ2FyZHMSHgoFY2FyZHMYASADKAsyDy5ob2xkZW0udjEuQ2FyZCJMCglEZWFsQm9hcmQSHwoFcGhhc2UYASABKA4yEC5ob2xkZW0udjEuUGhhc2USHgoFY2FyZHMYAiADKAsyDy5ob2xkZW0udjEuQ2FyZCLlAQoLUGhhc2VDaGFuZ2USHwoFcGhhc2UYASABKA4yEC5ob2xkZW0udjEuUGhhc2USKAoPY29tbXVuaXR5X2NhcmRzGAIgAygLMg8uaG9sZGVtLnYxLkNhcmQSHAoEcG90cxgDIAMoCzIOLmhvbGRlbS52MS5Qb3QSLgoMbXlfaGFuZF9yYW5rGAQgASgOMhMuaG9sZGVtLnYxLkhhbmRSYW5rSACIAQESGgoNbXlfaGFuZF92YWx1ZRgFIAEoDUgBiAEBQg8KDV9teV9oYW5kX3JhbmtCEAoOX215X2hhbmRfdmFsdWUiqgEKDEFjdGlvblByb21wdBINCgVjaGFpchgBIAEoDRIsCg1sZWdhbF9hY3Rpb25zGAIgAygOMhUuaG9sZGVtLnYxLkFjdGlvblR5cGUSFAoMbWluX3JhaXNlX3RvGAMgASgDEhMKC2NhbGxfYW1vdW50GAQgASgDEhYKDnRpbWVfbGltaXRfc2VjGAUgASgFEhoKEmFjdGlvbl9kZWFkbGluZV9tcxgGIAEoAyJ+CgxBY3Rpb25SZXN1bHQSDQoFY2hhaXIYASABKA0SJQoGYWN0aW9uGAIgASgOMhUuaG9sZGVtLnYxLkFjdGlvblR5cGUSDgoGYW1vdW50GAMgASgDEhEKCW5ld19zdGFjaxgEIAEoAxIVCg1uZXdfcG90X3RvdGFsGAUgASgDIikKCVBvdFVwZGF0ZRIcCgRwb3RzGAEgAygLMg4uaG9sZGVtLnYxLlBvdCK4AQoIU2hvd2Rvd24SJgoFaGFuZHMYASADKAsyFy5ob2xkZW0udjEuU2hvd2Rvd25IYW5kEikKC3BvdF9yZXN1bHRzGAIgAygLMhQuaG9sZGVtLnYxLlBvdFJlc3VsdBIuCg1leGNlc3NfcmVmdW5kGAMgASgLMhcuaG9sZGVtLnYxLkV4Y2Vzc1JlZnVuZBIpCgtuZXRfcmVzdWx0cxgEIAMoCzIULmhvbGRlbS52MS5OZXRSZXN1bHQiiQEKDFNob3dkb3duSGFuZBINCgVjaGFpchgBIAEoDRIjCgpob2xlX2NhcmRzGAIgAygLMg8uaG9sZGVtLnYxLkNhcmQSIgoJYmVzdF9maXZlGAMgAygLMg8uaG9sZGVtLnYxLkNhcmQSIQoEcmFuaxgEIAEoDjITLmhvbGRlbS52MS5IYW5kUmFuayJDCglQb3RSZXN1bHQSEgoKcG90X2Ftb3VudBgBIAEoAxIiCgd3aW5uZXJzGAIgAygLMhEuaG9sZGVtLnYxLldpbm5lciIrCgZXaW5uZXISDQoFY2hhaXIYASABKA0SEgoKd2luX2Ftb3VudBgCIAEoAyKgAQoHSGFuZEVuZBINCgVyb3VuZBgBIAEoDRIrCgxzdGFja19kZWx0YXMYAiADKAsyFS5ob2xkZW0udjEuU3RhY2tEZWx0YRIuCg1leGNlc3NfcmVmdW5kGAMgASgLMhcuaG9sZGVtLnYxLkV4Y2Vzc1JlZnVuZBIpCgtuZXRfcmVzdWx0cxgEIAMoCzIULmhvbGRlbS52MS5OZXRSZXN1bHQiPQoKU3RhY2tEZWx0YRINCgVjaGFpchgBIAEoDRINCgVkZWx0YRgCIAEoAxIRCgluZXdfc3RhY2sYAyABKAMiZAoJV2luQnlGb2xkEhQKDHdpbm5lcl9jaGFpchgBIAEoDRIRCglwb3RfdG90YWwYAiABKAMSLgoNZXhjZXNzX3JlZnVuZBgDIAEoCzIXLmhvbGRlbS52MS5FeGNlc3NSZWZ1bmQiLQoMRXhjZXNzUmVmdW5kEg0KBWNoYWlyGAEgASgNEg4KBmFtb3VudBgCIAEoAyJBCglOZXRSZXN1bHQSDQoFY2hhaXIYASABKA0SEgoKd2luX2Ftb3VudBgCIAEoAxIRCglpc193aW5uZXIYAyABKAgiRAoEQ2FyZBIdCgRzdWl0GAEgASgOMg8uaG9sZGVtLnYxLlN1aXQSHQoEcmFuaxgCIAEoDjIPLmhvbGRlbS52MS5SYW5rKoYBCgVQaGFzZRIVChFQSEFTRV9VTlNQRUNJRklFRBAAEg4KClBIQVNFX0FOVEUQARIRCg1QSEFTRV9QUkVGTE9QEAISDgoKUEhBU0VfRkxPUBADEg4KClBIQVNFX1RVUk4QBBIPCgtQSEFTRV9SSVZFUhAFEhIKDlBIQVNFX1NIT1dET1dOEAYqjAEKCkFjdGlvblR5cGUSFgoSQUNUSU9OX1VOU1BFQ0lGSUVEEAASEAoMQUNUSU9OX0NIRUNLEAESDgoKQUNUSU9OX0JFVBACEg8KC0FDVElPTl9DQUxMEAMSEAoMQUNUSU9OX1JBSVNFEAQSDwoLQUNUSU9OX0ZPTEQQBRIQCgxBQ1RJT05fQUxMSU4QBiqnAgoISGFuZFJhbmsSGQoVSEFORF9SQU5LX1VOU1BFQ0lGSUVEEAASFwoTSEFORF9SQU5LX0hJR0hfQ0FSRBABEhYKEkhBTkRfUkFOS19PTkVfUEFJUhACEhYKEkhBTkRfUkFOS19UV09fUEFJUhADEhsKF0hBTkRfUkFOS19USFJFRV9PRl9LSU5EEAQSFgoSSEFORF9SQU5LX1NUUkFJR0hUEAUSEwoPSEFORF9SQU5LX0ZMVVNIEAYSGAoUSEFORF9SQU5LX0ZVTExfSE9VU0UQBxIaChZIQU5EX1JBTktfRk9VUl9PRl9LSU5EEAgSHAoYSEFORF9SQU5LX1NUUkFJR0hUX0ZMVVNIEAkSGQoVSEFORF9SQU5LX1JPWUFMX0ZMVVNIEAoqXQoEU3VpdBIUChBTVUlUX1VOU1BFQ0lGSUVEEAASDgoKU1VJVF9TUEFERRABEg4KClNVSVRfSEVBUlQQAhINCglTVUlUX0NMVUIQAxIQCgxTVUlUX0RJQU1PTkQQBCq5AQoEUmFuaxIUChBSQU5LX1VOU1BFQ0lGSUVEEAASCgoGUkFOS18yEAISCgoGUkFOS18zEAMSCgoGUkFOS180EAQSCgoGUkFOS181EAUSCgoGUkFOS182EAYSCgoGUkFOS183EAcSCgoGUkFOS184EAgSCgoGUkFOS185EAkSCwoHUkFOS18xMBAKEgoKBlJBTktfShALEgoKBlJBTktfURAMEgoKBlJBTktfSxANEgoKBlJBTktfQRAOQokBCg1jb20uaG9sZGVtLnYxQg1NZXNzYWdlc1Byb3RvUAFaJGhvbGRlbS1saXRlL2FwcHMvc2VydmVyL2dlbjtob2xkZW12MaICA0hYWKoCCUhvbGRlbS5WMcoCCUhvbGRlbVxWMeICFUhvbGRlbVxWMVxHUEJNZXRhZGF0YeoCCkhvbGRlbTo6VjFiBnByb3RvMw");
+  fileDesc("Cg5tZXNzYWdlcy5wcm90bxIJaG9sZGVtLnYxIsUGCg5DbGllbnRFbnZlbG9wZRIQCgh0YWJsZV9pZBgBIAEoCRIPCgd1c2VyX2lkGAIgASgEEgsKA3NlcRgDIAEoBBILCgNhY2sYBCABKAQSMQoKam9pbl90YWJsZRgKIAEoCzIbLmhvbGRlbS52MS5Kb2luVGFibGVSZXF1ZXN0SAASLQoIc2l0X2Rvd24YCyABKAsyGS5ob2xkZW0udjEuU2l0RG93blJlcXVlc3RIABItCghzdGFuZF91cBgMIAEoCzIZLmhvbGRlbS52MS5TdGFuZFVwUmVxdWVzdEgAEikKBmJ1eV9pbhgNIAEoCzIXLmhvbGRlbS52MS5CdXlJblJlcXVlc3RIABIqCgZhY3Rpb24YDiABKAsyGC5ob2xkZW0udjEuQWN0aW9uUmVxdWVzdEgAEjMKC3N0YXJ0X3N0b3J5GA8gASgLMhwuaG9sZGVtLnYxLlN0YXJ0U3RvcnlSZXF1ZXN0SAASLgoIc3RyYWRkbGUYECABKAsyGi5ob2xkZW0udjEuU3RyYWRkbGVSZXF1ZXN0SAASLQoIY2FzaF9vdXQYESABKAsyGS5ob2xkZW0udjEuQ2FzaE91dFJlcXVlc3RIABIrCgdzaXRfb3V0GBIgASgLMhguaG9sZGVtLnYxLlNpdE91dFJlcXVlc3RIABIpCgZzaXRfaW4YEyABKAsyFy5ob2xkZW0udjEuU2l0SW5SZXF1ZXN0SAASMQoKbGlzdF9oYW5kcxgUIAEoCzIbLmhvbGRlbS52MS5MaXN0SGFuZHNSZXF1ZXN0SAASNAoMcnVuX2l0X3R3aWNlGBUgASgLMhwuaG9sZGVtLnYxLlJ1bkl0VHdpY2VSZXF1ZXN0SAASPQoQcmVxdWVzdF9zbmFwc2hvdBgWIAEoCzIhLmhvbGRlbS52MS5SZXF1ZXN0U25hcHNob3RSZXF1ZXN0SAASLAoHcmVtYXRjaBgXIAEoCzIZLmhvbGRlbS52MS5SZW1hdGNoUmVxdWVzdEgAEkEKE3J1bl9pdF90d2ljZV9hY2NlcHQYGCABKAsyIi5ob2xkZW0udjEuUnVuSXRUd2ljZUFjY2VwdFJlcXVlc3RIAEIJCgdwYXlsb2FkIrcJCg5TZXJ2ZXJFbnZlbG9wZRIQCgh0YWJsZV9pZBgBIAEoCRISCgpzZXJ2ZXJfc2VxGAIgASgEEhQKDHNlcnZlcl90c19tcxgDIAEoAxIpCgVlcnJvchgKIAEoCzIYLmhvbGRlbS52MS5FcnJvclJlc3BvbnNlSAASMgoOdGFibGVfc25hcHNob3QYCyABKAsyGC5ob2xkZW0udjEuVGFibGVTbmFwc2hvdEgAEiwKC3NlYXRfdXBkYXRlGAwgASgLMhUuaG9sZGVtLnYxLlNlYXRVcGRhdGVIABIqCgpoYW5kX3N0YXJ0GA0gASgLMhQuaG9sZGVtLnYxLkhhbmRTdGFydEgAEjMKD2RlYWxfaG9sZV9jYXJkcxgOIAEoCzIYLmhvbGRlbS52MS5EZWFsSG9sZUNhcmRzSAASKgoKZGVhbF9ib2FyZBgPIAEoCzIULmhvbGRlbS52MS5EZWFsQm9hcmRIABIwCg1hY3Rpb25fcHJvbXB0GBAgASgLMhcuaG9sZGVtLnYxLkFjdGlvblByb21wdEgAEjAKDWFjdGlvbl9yZXN1bHQYESABKAsyFy5ob2xkZW0udjEuQWN0aW9uUmVzdWx0SAASKgoKcG90X3VwZGF0ZRgSIAEoCzIULmhvbGRlbS52MS5Qb3RVcGRhdGVIABInCghzaG93ZG93bhgTIAEoCzITLmhvbGRlbS52MS5TaG93ZG93bkgAEiYKCGhhbmRfZW5kGBQgASgLMhIuaG9sZGVtLnYxLkhhbmRFbmRIABIuCgxwaGFzZV9jaGFuZ2UYFSABKAsyFi5ob2xkZW0udjEuUGhhc2VDaGFuZ2VIABIrCgt3aW5fYnlfZm9sZBgWIAEoCzIULmhvbGRlbS52MS5XaW5CeUZvbGRIABIyCg5sb2dpbl9yZXNwb25zZRgXIAEoCzIYLmhvbGRlbS52MS5Mb2dpblJlc3BvbnNlSAASOQoSc3RvcnlfY2hhcHRlcl9pbmZvGBggASgLMhsuaG9sZGVtLnYxLlN0b3J5Q2hhcHRlckluZm9IABI3Cg5zdG9yeV9wcm9ncmVzcxgZIAEoCzIdLmhvbGRlbS52MS5TdG9yeVByb2dyZXNzU3RhdGVIABIsCgtzZXNzaW9uX2VuZBgaIAEoCzIVLmhvbGRlbS52MS5TZXNzaW9uRW5kSAASKAoJaGFuZF9saXN0GBsgASgLMhMuaG9sZGVtLnYxLkhhbmRMaXN0SAASLAoLcmFiYml0X2h1bnQYHCABKAsyFS5ob2xkZW0udjEuUmFiYml0SHVudEgAEiwKC2RlYWxlcl9kcmF3GB0gASgLMhUuaG9sZGVtLnYxLkRlYWxlckRyYXdIABI0Cg91bmNhbGxlZF9yZXR1cm4YHiABKAsyGS5ob2xkZW0udjEuVW5jYWxsZWRSZXR1cm5IABI4ChJydW5faXRfdHdpY2Vfb2ZmZXIYHyABKAsyGi5ob2xkZW0udjEuUnVuSXRUd2ljZU9mZmVySAASOgoSY2hhcHRlcl9zY29yZWJvYXJkGCAgASgLMhwuaG9sZGVtLnYxLkNoYXB0ZXJTY29yZWJvYXJkSABCCQoHcGF5bG9hZCI3Cg1Mb2dpblJlc3BvbnNlEg8KB3VzZXJfaWQYASABKAQSFQoNc2Vzc2lvbl90b2tlbhgCIAEoCSIyChBKb2luVGFibGVSZXF1ZXN0Eg8KB29ic2VydmUYASABKAgSDQoFc3Rha2UYAiABKAkiNgoOU2l0RG93blJlcXVlc3QSDQoFY2hhaXIYASABKA0SFQoNYnV5X2luX2Ftb3VudBgCIAEoAyIQCg5TdGFuZFVwUmVxdWVzdCIeCgxCdXlJblJlcXVlc3QSDgoGYW1vdW50GAEgASgDIg8KDVNpdE91dFJlcXVlc3QiDgoMU2l0SW5SZXF1ZXN0IiAKD1N0cmFkZGxlUmVxdWVzdBINCgVjaGFpchgBIAEoDSIQCg5DYXNoT3V0UmVxdWVzdCITChFSdW5JdFR3aWNlUmVxdWVzdCIpChdSdW5JdFR3aWNlQWNjZXB0UmVxdWVzdBIOCgZhY2NlcHQYASABKAgiGAoWUmVxdWVzdFNuYXBzaG90UmVxdWVzdCIQCg5SZW1hdGNoUmVxdWVzdCIxChBMaXN0SGFuZHNSZXF1ZXN0Eg4KBnNvdXJjZRgBIAEoCRINCgVsaW1pdBgCIAEoBSJ2Cg1BY3Rpb25SZXF1ZXN0EiUKBmFjdGlvbhgBIAEoDjIVLmhvbGRlbS52MS5BY3Rpb25UeXBlEg4KBmFtb3VudBgCIAEoAxIuCg1zaXppbmdfcHJlc2V0GAMgASgOMhcuaG9sZGVtLnYxLlNpemluZ1ByZXNldCInChFTdGFydFN0b3J5UmVxdWVzdBISCgpjaGFwdGVyX2lkGAEgASgFIpMBCgxTdG9yeU5wY0luZm8SDgoGbnBjX2lkGAEgASgJEgwKBG5hbWUYAiABKAkSEQoJcmVpX2ludHJvGAMgASgJEhEKCXJlaV9zdHlsZRgEIAEoCRIPCgdpc19ib3NzGAUgASgIEhoKEmZpcnN0X3NlZW5fY2hhcHRlchgGIAEoBRISCgphdmF0YXJfa2V5GAcgASgJItsBChBTdG9yeUNoYXB0ZXJJbmZvEhIKCmNoYXB0ZXJfaWQYASABKAUSDQoFdGl0bGUYAiABKAkSEAoIc3VidGl0bGUYAyABKAkSFgoOb2JqZWN0aXZlX2Rlc2MYBCABKAkSEQoJcmVpX2ludHJvGAUgASgJEhUKDXJlaV9ib3NzX25vdGUYBiABKAkSEQoJYm9zc19uYW1lGAcgASgJEhAKCHRhYmxlX2lkGAggASgJEisKCm5wY19yb3N0ZXIYCSADKAsyFy5ob2xkZW0udjEuU3RvcnlOcGNJbmZvIpABChJTdG9yeVByb2dyZXNzU3RhdGUSIQoZaGlnaGVzdF9jb21wbGV0ZWRfY2hhcHRlchgBIAEoBRIgChhoaWdoZXN0X3VubG9ja2VkX2NoYXB0ZXIYAiABKAUSGgoSY29tcGxldGVkX2NoYXB0ZXJzGAMgAygFEhkKEXVubG9ja2VkX2ZlYXR1cmVzGAQgAygJIrcBChFDaGFwdGVyU2NvcmVib2FyZBISCgpjaGFwdGVyX2lkGAEgASgFEhQKDGhhbmRzX3BsYXllZBgCIAEoBRITCgtzdGFydF9zdGFjaxgDIAEoAxItCglzdGFuZGluZ3MYBCADKAsyGi5ob2xkZW0udjEuQ2hhcHRlclN0YW5kaW5nEhoKEm9iamVjdGl2ZV9wcm9ncmVzcxgFIAEoAxIYChBvYmplY3RpdmVfdGFyZ2V0GAYgASgDInwKD0NoYXB0ZXJTdGFuZGluZxINCgVjaGFpchgBIAEoDRIPCgd1c2VyX2lkGAIgASgEEg0KBXN0YWNrGAMgASgDEgsKA25ldBgEIAEoAxIMCgRyYW5rGAUgASgFEg8KB2lzX2hlcm8YBiABKAgSDgoGYnVzdGVkGAcgASgIImAKDUVycm9yUmVzcG9uc2USDAoEY29kZRgBIAEoBRIPCgdtZXNzYWdlGAIgASgJEjAKDmFjdGlvbl9vcHRpb25zGAMgASgLMhguaG9sZGVtLnYxLkFjdGlvbk9wdGlvbnMifgoNQWN0aW9uT3B0aW9ucxIUCgxhY3Rpb25fY2hhaXIYASABKA0SLAoNbGVnYWxfYWN0aW9ucxgCIAMoDjIVLmhvbGRlbS52MS5BY3Rpb25UeXBlEhQKDG1pbl9yYWlzZV90bxgDIAEoAxITCgtjYWxsX2Ftb3VudBgEIAEoAyL7AgoNVGFibGVTbmFwc2hvdBImCgZjb25maWcYASABKAsyFi5ob2xkZW0udjEuVGFibGVDb25maWcSHwoFcGhhc2UYAiABKA4yEC5ob2xkZW0udjEuUGhhc2USDQoFcm91bmQYAyABKA0SFAoMZGVhbGVyX2NoYWlyGAQgASgNEhkKEXNtYWxsX2JsaW5kX2NoYWlyGAUgASgNEhcKD2JpZ19ibGluZF9jaGFpchgGIAEoDRIUCgxhY3Rpb25fY2hhaXIYByABKA0SDwoHY3VyX2JldBgIIAEoAxIXCg9taW5fcmFpc2VfZGVsdGEYCSABKAMSKAoPY29tbXVuaXR5X2NhcmRzGAogAygLMg8uaG9sZGVtLnYxLkNhcmQSHAoEcG90cxgLIAMoCzIOLmhvbGRlbS52MS5Qb3QSJwoHcGxheWVycxgMIAMoCzIWLmhvbGRlbS52MS5QbGF5ZXJTdGF0ZRIXCg9zcGVjdGF0b3JfY291bnQYDSABKA0igAEKC1RhYmxlQ29uZmlnEhMKC21heF9wbGF5ZXJzGAEgASgNEhMKC3NtYWxsX2JsaW5kGAIgASgDEhEKCWJpZ19ibGluZBgDIAEoAxIMCgRhbnRlGAQgASgDEhIKCm1pbl9idXlfaW4YBSABKAMSEgoKbWF4X2J1eV9pbhgGIAEoAyKsAgoLUGxheWVyU3RhdGUSDwoHdXNlcl9pZBgBIAEoBBINCgVjaGFpchgCIAEoDRIQCghuaWNrbmFtZRgDIAEoCRINCgVzdGFjaxgEIAEoAxILCgNiZXQYBSABKAMSDgoGZm9sZGVkGAYgASgIEg4KBmFsbF9pbhgHIAEoCBIqCgtsYXN0X2FjdGlvbhgIIAEoDjIVLmhvbGRlbS52MS5BY3Rpb25UeXBlEiMKCmhhbmRfY2FyZHMYCSADKAsyDy5ob2xkZW0udjEuQ2FyZBIRCgloYXNfY2FyZHMYCiABKAgSEgoKYXZhdGFyX2tleRgLIAEoCRIRCgljb2xvcl90YWcYDCABKAkSDwoHdG9fY2FsbBgNIAEoAxITCgtzaXR0aW5nX291dBgOIAEoCCIuCgNQb3QSDgoGYW1vdW50GAEgASgDEhcKD2VsaWdpYmxlX2NoYWlycxgCIAMoDSKNAQoKU2VhdFVwZGF0ZRINCgVjaGFpchgBIAEoDRIvCg1wbGF5ZXJfam9pbmVkGAIgASgLMhYuaG9sZGVtLnYxLlBsYXllclN0YXRlSAASHQoTcGxheWVyX2xlZnRfdXNlcl9pZBgDIAEoBEgAEhYKDHN0YWNrX2NoYW5nZRgEIAEoA0gAQggKBnVwZGF0ZSJMCgpEZWFsZXJEcmF3EigKBWNhcmRzGAEgAygLMhkuaG9sZGVtLnYxLkRlYWxlckRyYXdDYXJkEhQKDGRlYWxlcl9jaGFpchgCIAEoDSI+Cg5EZWFsZXJEcmF3Q2FyZBINCgVjaGFpchgBIAEoDRIdCgRjYXJkGAIgASgLMg8uaG9sZGVtLnYxLkNhcmQijwIKCUhhbmRTdGFydBINCgVyb3VuZBgBIAEoDRIUCgxkZWFsZXJfY2hhaXIYAiABKA0SGQoRc21hbGxfYmxpbmRfY2hhaXIYAyABKA0SFwoPYmlnX2JsaW5kX2NoYWlyGAQgASgNEhoKEnNtYWxsX2JsaW5kX2Ftb3VudBgFIAEoAxIYChBiaWdfYmxpbmRfYW1vdW50GAYgASgDEhcKD3NlZWRfY29tbWl0bWVudBgHIAEoCRITCgthbnRlX2Ftb3VudBgIIAEoAxIWCg5zdHJhZGRsZV9jaGFpchgJIAEoDRIXCg9zdHJhZGRsZV9hbW91bnQYCiABKAMSFAoMZm9yY2VkX3RvdGFsGAsgASgDIi8KDURlYWxIb2xlQ2FyZHMSHgoFY2FyZHMYASADKAsyDy5ob2xkZW0udjEuQ2FyZCJMCglEZWFsQm9hcmQSHwoFcGhhc2UYASABKA4yEC5ob2xkZW0udjEuUGhhc2USHgoFY2FyZHMYAiADKAsyDy5ob2xkZW0udjEuQ2FyZCLlAQoLUGhhc2VDaGFuZ2USHwoFcGhhc2UYASABKA4yEC5ob2xkZW0udjEuUGhhc2USKAoPY29tbXVuaXR5X2NhcmRzGAIgAygLMg8uaG9sZGVtLnYxLkNhcmQSHAoEcG90cxgDIAMoCzIOLmhvbGRlbS52MS5Qb3QSLgoMbXlfaGFuZF9yYW5rGAQgASgOMhMuaG9sZGVtLnYxLkhhbmRSYW5rSACIAQESGgoNbXlfaGFuZF92YWx1ZRgFIAEoDUgBiAEBQg8KDV9teV9oYW5kX3JhbmtCEAoOX215X2hhbmRfdmFsdWUiqgEKDEFjdGlvblByb21wdBINCgVjaGFpchgBIAEoDRIsCg1sZWdhbF9hY3Rpb25zGAIgAygOMhUuaG9sZGVtLnYxLkFjdGlvblR5cGUSFAoMbWluX3JhaXNlX3RvGAMgASgDEhMKC2NhbGxfYW1vdW50GAQgASgDEhYKDnRpbWVfbGltaXRfc2VjGAUgASgFEhoKEmFjdGlvbl9kZWFkbGluZV9tcxgGIAEoAyJ+CgxBY3Rpb25SZXN1bHQSDQoFY2hhaXIYASABKA0SJQoGYWN0aW9uGAIgASgOMhUuaG9sZGVtLnYxLkFjdGlvblR5cGUSDgoGYW1vdW50GAMgASgDEhEKCW5ld19zdGFjaxgEIAEoAxIVCg1uZXdfcG90X3RvdGFsGAUgASgDIikKCVBvdFVwZGF0ZRIcCgRwb3RzGAEgAygLMg4uaG9sZGVtLnYxLlBvdCLbAQoIU2hvd2Rvd24SJgoFaGFuZHMYASADKAsyFy5ob2xkZW0udjEuU2hvd2Rvd25IYW5kEikKC3BvdF9yZXN1bHRzGAIgAygLMhQuaG9sZGVtLnYxLlBvdFJlc3VsdBIuCg1leGNlc3NfcmVmdW5kGAMgASgLMhcuaG9sZGVtLnYxLkV4Y2Vzc1JlZnVuZBIpCgtuZXRfcmVzdWx0cxgEIAMoCzIULmhvbGRlbS52MS5OZXRSZXN1bHQSIQoEcnVucxgFIAMoCzITLmhvbGRlbS52MS5Cb2FyZFJ1biJVCghCb2FyZFJ1bhIeCgVib2FyZBgBIAMoCzIPLmhvbGRlbS52MS5DYXJkEikKC3BvdF9yZXN1bHRzGAIgAygLMhQuaG9sZGVtLnYxLlBvdFJlc3VsdCKgAQoMU2hvd2Rvd25IYW5kEg0KBWNoYWlyGAEgASgNEiMKCmhvbGVfY2FyZHMYAiADKAsyDy5ob2xkZW0udjEuQ2FyZBIiCgliZXN0X2ZpdmUYAyADKAsyDy5ob2xkZW0udjEuQ2FyZBIhCgRyYW5rGAQgASgOMhMuaG9sZGVtLnYxLkhhbmRSYW5rEhUKDXNob3dkb3duX3JhbmsYBSABKA0iUQoJUG90UmVzdWx0EhIKCnBvdF9hbW91bnQYASABKAMSIgoHd2lubmVycxgCIAMoCzIRLmhvbGRlbS52MS5XaW5uZXISDAoEcmFrZRgDIAEoAyIrCgZXaW5uZXISDQoFY2hhaXIYASABKA0SEgoKd2luX2Ftb3VudBgCIAEoAyL1AQoHSGFuZEVuZBINCgVyb3VuZBgBIAEoDRIrCgxzdGFja19kZWx0YXMYAiADKAsyFS5ob2xkZW0udjEuU3RhY2tEZWx0YRIuCg1leGNlc3NfcmVmdW5kGAMgASgLMhcuaG9sZGVtLnYxLkV4Y2Vzc1JlZnVuZBIpCgtuZXRfcmVzdWx0cxgEIAMoCzIULmhvbGRlbS52MS5OZXRSZXN1bHQSKwoJY2FzaF9vdXRzGAUgAygLMhguaG9sZGVtLnYxLkNhc2hPdXRSZXN1bHQSEwoLcmFrZV9hbW91bnQYBiABKAMSEQoJZGVja19zZWVkGAcgASgDIkUKDUNhc2hPdXRSZXN1bHQSDQoFY2hhaXIYASABKA0SDgoGcGF5b3V0GAIgASgDEhUKDXJ1bm91dF9hbW91bnQYAyABKAMiSwoKU2Vzc2lvbkVuZBIUCgxoYW5kc19wbGF5ZWQYASABKA0SJwoGc3RhY2tzGAIgAygLMhcuaG9sZGVtLnYxLlNlc3Npb25TdGFjayJCCghIYW5kTGlzdBIOCgZzb3VyY2UYASABKAkSJgoFaXRlbXMYAiADKAsyFy5ob2xkZW0udjEuSGFuZExpc3RJdGVtInIKDEhhbmRMaXN0SXRlbRIPCgdoYW5kX2lkGAEgASgJEhQKDHBsYXllZF9hdF9tcxgCIAEoAxIQCghpc19zYXZlZBgDIAEoCBITCgtzYXZlZF9hdF9tcxgEIAEoAxIUCgxzdW1tYXJ5X2pzb24YBSABKAkiPQoMU2Vzc2lvblN0YWNrEg8KB3VzZXJfaWQYASABKAQSDQoFY2hhaXIYAiABKA0SDQoFc3RhY2sYAyABKAMiPQoKU3RhY2tEZWx0YRINCgVjaGFpchgBIAEoDRINCgVkZWx0YRgCIAEoAxIRCgluZXdfc3RhY2sYAyABKAMiZAoJV2luQnlGb2xkEhQKDHdpbm5lcl9jaGFpchgBIAEoDRIRCglwb3RfdG90YWwYAiABKAMSLgoNZXhjZXNzX3JlZnVuZBgDIAEoCzIXLmhvbGRlbS52MS5FeGNlc3NSZWZ1bmQiLAoKUmFiYml0SHVudBIeCgVjYXJkcxgBIAMoCzIPLmhvbGRlbS52MS5DYXJkIi0KDEV4Y2Vzc1JlZnVuZBINCgVjaGFpchgBIAEoDRIOCgZhbW91bnQYAiABKAMiLwoOVW5jYWxsZWRSZXR1cm4SDQoFY2hhaXIYASABKA0SDgoGYW1vdW50GAIgASgDIjYKD1J1bkl0VHdpY2VPZmZlchIOCgZjaGFpcnMYASADKA0SEwoLZGVhZGxpbmVfbXMYAiABKAMiQQoJTmV0UmVzdWx0Eg0KBWNoYWlyGAEgASgNEhIKCndpbl9hbW91bnQYAiABKAMSEQoJaXNfd2lubmVyGAMgASgIIkQKBENhcmQSHQoEc3VpdBgBIAEoDjIPLmhvbGRlbS52MS5TdWl0Eh0KBHJhbmsYAiABKA4yDy5ob2xkZW0udjEuUmFuayqGAQoFUGhhc2USFQoRUEhBU0VfVU5TUEVDSUZJRUQQABIOCgpQSEFTRV9BTlRFEAESEQoNUEhBU0VfUFJFRkxPUBACEg4KClBIQVNFX0ZMT1AQAxIOCgpQSEFTRV9UVVJOEAQSDwoLUEhBU0VfUklWRVIQBRISCg5QSEFTRV9TSE9XRE9XThAGKowBCgpBY3Rpb25UeXBlEhYKEkFDVElPTl9VTlNQRUNJRklFRBAAEhAKDEFDVElPTl9DSEVDSxABEg4KCkFDVElPTl9CRVQQAhIPCgtBQ1RJT05fQ0FMTBADEhAKDEFDVElPTl9SQUlTRRAEEg8KC0FDVElPTl9GT0xEEAUSEAoMQUNUSU9OX0FMTElOEAYqpwIKCEhhbmRSYW5rEhkKFUhBTkRfUkFOS19VTlNQRUNJRklFRBAAEhcKE0hBTkRfUkFOS19ISUdIX0NBUkQQARIWChJIQU5EX1JBTktfT05FX1BBSVIQAhIWChJIQU5EX1JBTktfVFdPX1BBSVIQAxIbChdIQU5EX1JBTktfVEhSRUVfT0ZfS0lORBAEEhYKEkhBTkRfUkFOS19TVFJBSUdIVBAFEhMKD0hBTkRfUkFOS19GTFVTSBAGEhgKFEhBTkRfUkFOS19GVUxMX0hPVVNFEAcSGgoWSEFORF9SQU5LX0ZPVVJfT0ZfS0lORBAIEhwKGEhBTkRfUkFOS19TVFJBSUdIVF9GTFVTSBAJEhkKFUhBTkRfUkFOS19ST1lBTF9GTFVTSBAKKoUBCgxTaXppbmdQcmVzZXQSHQoZU0laSU5HX1BSRVNFVF9VTlNQRUNJRklFRBAAEhoKFlNJWklOR19QUkVTRVRfSEFMRl9QT1QQARIjCh9TSVpJTkdfUFJFU0VUX1RIUkVFX1FVQVJURVJfUE9UEAISFQoRU0laSU5HX1BSRVNFVF9QT1QQAypdCgRTdWl0EhQKEFNVSVRfVU5TUEVDSUZJRUQQABIOCgpTVUlUX1NQQURFEAESDgoKU1VJVF9IRUFSVBACEg0KCVNVSVRfQ0xVQhADEhAKDFNVSVRfRElBTU9ORBAEKrkBCgRSYW5rEhQKEFJBTktfVU5TUEVDSUZJRUQQABIKCgZSQU5LXzIQAhIKCgZSQU5LXzMQAxIKCgZSQU5LXzQQBBIKCgZSQU5LXzUQBRIKCgZSQU5LXzYQBhIKCgZSQU5LXzcQBxIKCgZSQU5LXzgQCBIKCgZSQU5LXzkQCRILCgdSQU5LXzEwEAoSCgoGUkFOS19KEAsSCgoGUkFOS19REAwSCgoGUkFOS19LEA0SCgoGUkFOS19BEA5CiQEKDWNvbS5ob2xkZW0udjFCDU1lc3NhZ2VzUHJvdG9QAVokaG9sZGVtLWxpdGUvYXBwcy9zZXJ2ZXIvZ2VuO2hvbGRlbXYxogIDSFhYqgIJSG9sZGVtLlYxygIJSG9sZGVtXFYx4gIVSG9sZGVtXFYxXEdQQk1ldGFkYXRh6gIKSG9sZGVtOjpWMWIGcHJvdG8z");
 
 /**
  * Describes the message holdem.v1.ClientEnvelope.
@@ -59,201 +59,362 @@ export const StandUpRequestSchema = /*@__PURE__*/
 export const BuyInRequestSchema = /*@__PURE__*/
   messageDesc(file_messages, 6);
 
+/**
+ * Describes the message holdem.v1.SitOutRequest.
+ * Use `create(SitOutRequestSchema)` to create a new message.
+ */
+export const SitOutRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 7);
+
+/**
+ * Describes the message holdem.v1.SitInRequest.
+ * Use `create(SitInRequestSchema)` to create a new message.
+ */
+export const SitInRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 8);
+
+/**
+ * Describes the message holdem.v1.StraddleRequest.
+ * Use `create(StraddleRequestSchema)` to create a new message.
+ */
+export const StraddleRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 9);
+
+/**
+ * Describes the message holdem.v1.CashOutRequest.
+ * Use `create(CashOutRequestSchema)` to create a new message.
+ */
+export const CashOutRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 10);
+
+/**
+ * Describes the message holdem.v1.RunItTwiceRequest.
+ * Use `create(RunItTwiceRequestSchema)` to create a new message.
+ */
+export const RunItTwiceRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 11);
+
+/**
+ * Describes the message holdem.v1.RunItTwiceAcceptRequest.
+ * Use `create(RunItTwiceAcceptRequestSchema)` to create a new message.
+ */
+export const RunItTwiceAcceptRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 12);
+
+/**
+ * Describes the message holdem.v1.RequestSnapshotRequest.
+ * Use `create(RequestSnapshotRequestSchema)` to create a new message.
+ */
+export const RequestSnapshotRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 13);
+
+/**
+ * Describes the message holdem.v1.RematchRequest.
+ * Use `create(RematchRequestSchema)` to create a new message.
+ */
+export const RematchRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 14);
+
+/**
+ * Describes the message holdem.v1.ListHandsRequest.
+ * Use `create(ListHandsRequestSchema)` to create a new message.
+ */
+export const ListHandsRequestSchema = /*@__PURE__*/
+  messageDesc(file_messages, 15);
+
 /**
  * Describes the message holdem.v1.ActionRequest.
  * Use `create(ActionRequestSchema)` to create a new message.
  */
 export const ActionRequestSchema = /*@__PURE__*/
-  messageDesc(file_messages, 7);
+  messageDesc(file_messages, 16);
 
 /**
  * Describes the message holdem.v1.StartStoryRequest.
  * Use `create(StartStoryRequestSchema)` to create a new message.
  */
 export const StartStoryRequestSchema = /*@__PURE__*/
-  messageDesc(file_messages, 8);
+  messageDesc(file_messages, 17);
 
 /**
  * Describes the message holdem.v1.StoryNpcInfo.
  * Use `create(StoryNpcInfoSchema)` to create a new message.
  */
 export const StoryNpcInfoSchema = /*@__PURE__*/
-  messageDesc(file_messages, 9);
+  messageDesc(file_messages, 18);
 
 /**
  * Describes the message holdem.v1.StoryChapterInfo.
  * Use `create(StoryChapterInfoSchema)` to create a new message.
  */
 export const StoryChapterInfoSchema = /*@__PURE__*/
-  messageDesc(file_messages, 10);
+  messageDesc(file_messages, 19);
 
 /**
  * Describes the message holdem.v1.StoryProgressState.
  * Use `create(StoryProgressStateSchema)` to create a new message.
  */
 export const StoryProgressStateSchema = /*@__PURE__*/
-  messageDesc(file_messages, 11);
+  messageDesc(file_messages, 20);
+
+/**
+ * Describes the message holdem.v1.ChapterScoreboard.
+ * Use `create(ChapterScoreboardSchema)` to create a new message.
+ */
+export const ChapterScoreboardSchema = /*@__PURE__*/
+  messageDesc(file_messages, 21);
+
+/**
+ * Describes the message holdem.v1.ChapterStanding.
+ * Use `create(ChapterStandingSchema)` to create a new message.
+ */
+export const ChapterStandingSchema = /*@__PURE__*/
+  messageDesc(file_messages, 22);
 
 /**
  * Describes the message holdem.v1.ErrorResponse.
  * Use `create(ErrorResponseSchema)` to create a new message.
  */
 export const ErrorResponseSchema = /*@__PURE__*/
-  messageDesc(file_messages, 12);
+  messageDesc(file_messages, 23);
+
+/**
+ * Describes the message holdem.v1.ActionOptions.
+ * Use `create(ActionOptionsSchema)` to create a new message.
+ */
+export const ActionOptionsSchema = /*@__PURE__*/
+  messageDesc(file_messages, 24);
 
 /**
  * Describes the message holdem.v1.TableSnapshot.
  * Use `create(TableSnapshotSchema)` to create a new message.
  */
 export const TableSnapshotSchema = /*@__PURE__*/
-  messageDesc(file_messages, 13);
+  messageDesc(file_messages, 25);
 
 /**
  * Describes the message holdem.v1.TableConfig.
  * Use `create(TableConfigSchema)` to create a new message.
  */
 export const TableConfigSchema = /*@__PURE__*/
-  messageDesc(file_messages, 14);
+  messageDesc(file_messages, 26);
 
 /**
  * Describes the message holdem.v1.PlayerState.
  * Use `create(PlayerStateSchema)` to create a new message.
  */
 export const PlayerStateSchema = /*@__PURE__*/
-  messageDesc(file_messages, 15);
+  messageDesc(file_messages, 27);
 
 /**
  * Describes the message holdem.v1.Pot.
  * Use `create(PotSchema)` to create a new message.
  */
 export const PotSchema = /*@__PURE__*/
-  messageDesc(file_messages, 16);
+  messageDesc(file_messages, 28);
 
 /**
  * Describes the message holdem.v1.SeatUpdate.
  * Use `create(SeatUpdateSchema)` to create a new message.
  */
 export const SeatUpdateSchema = /*@__PURE__*/
-  messageDesc(file_messages, 17);
+  messageDesc(file_messages, 29);
+
+/**
+ * Describes the message holdem.v1.DealerDraw.
+ * Use `create(DealerDrawSchema)` to create a new message.
+ */
+export const DealerDrawSchema = /*@__PURE__*/
+  messageDesc(file_messages, 30);
+
+/**
+ * Describes the message holdem.v1.DealerDrawCard.
+ * Use `create(DealerDrawCardSchema)` to create a new message.
+ */
+export const DealerDrawCardSchema = /*@__PURE__*/
+  messageDesc(file_messages, 31);
 
 /**
  * Describes the message holdem.v1.HandStart.
  * Use `create(HandStartSchema)` to create a new message.
  */
 export const HandStartSchema = /*@__PURE__*/
-  messageDesc(file_messages, 18);
+  messageDesc(file_messages, 32);
 
 /**
  * Describes the message holdem.v1.DealHoleCards.
  * Use `create(DealHoleCardsSchema)` to create a new message.
  */
 export const DealHoleCardsSchema = /*@__PURE__*/
-  messageDesc(file_messages, 19);
+  messageDesc(file_messages, 33);
 
 /**
  * Describes the message holdem.v1.DealBoard.
  * Use `create(DealBoardSchema)` to create a new message.
  */
 export const DealBoardSchema = /*@__PURE__*/
-  messageDesc(file_messages, 20);
+  messageDesc(file_messages, 34);
 
 /**
  * Describes the message holdem.v1.PhaseChange.
  * Use `create(PhaseChangeSchema)` to create a new message.
  */
 export const PhaseChangeSchema = /*@__PURE__*/
-  messageDesc(file_messages, 21);
+  messageDesc(file_messages, 35);
 
 /**
  * Describes the message holdem.v1.ActionPrompt.
  * Use `create(ActionPromptSchema)` to create a new message.
  */
 export const ActionPromptSchema = /*@__PURE__*/
-  messageDesc(file_messages, 22);
+  messageDesc(file_messages, 36);
 
 /**
  * Describes the message holdem.v1.ActionResult.
  * Use `create(ActionResultSchema)` to create a new message.
  */
 export const ActionResultSchema = /*@__PURE__*/
-  messageDesc(file_messages, 23);
+  messageDesc(file_messages, 37);
 
 /**
  * Describes the message holdem.v1.PotUpdate.
  * Use `create(PotUpdateSchema)` to create a new message.
  */
 export const PotUpdateSchema = /*@__PURE__*/
-  messageDesc(file_messages, 24);
+  messageDesc(file_messages, 38);
 
 /**
  * Describes the message holdem.v1.Showdown.
  * Use `create(ShowdownSchema)` to create a new message.
  */
 export const ShowdownSchema = /*@__PURE__*/
-  messageDesc(file_messages, 25);
+  messageDesc(file_messages, 39);
+
+/**
+ * Describes the message holdem.v1.BoardRun.
+ * Use `create(BoardRunSchema)` to create a new message.
+ */
+export const BoardRunSchema = /*@__PURE__*/
+  messageDesc(file_messages, 40);
 
 /**
  * Describes the message holdem.v1.ShowdownHand.
  * Use `create(ShowdownHandSchema)` to create a new message.
  */
 export const ShowdownHandSchema = /*@__PURE__*/
-  messageDesc(file_messages, 26);
+  messageDesc(file_messages, 41);
 
 /**
  * Describes the message holdem.v1.PotResult.
  * Use `create(PotResultSchema)` to create a new message.
  */
 export const PotResultSchema = /*@__PURE__*/
-  messageDesc(file_messages, 27);
+  messageDesc(file_messages, 42);
 
 /**
  * Describes the message holdem.v1.Winner.
  * Use `create(WinnerSchema)` to create a new message.
  */
 export const WinnerSchema = /*@__PURE__*/
-  messageDesc(file_messages, 28);
+  messageDesc(file_messages, 43);
 
 /**
  * Describes the message holdem.v1.HandEnd.
  * Use `create(HandEndSchema)` to create a new message.
  */
 export const HandEndSchema = /*@__PURE__*/
-  messageDesc(file_messages, 29);
+  messageDesc(file_messages, 44);
+
+/**
+ * Describes the message holdem.v1.CashOutResult.
+ * Use `create(CashOutResultSchema)` to create a new message.
+ */
+export const CashOutResultSchema = /*@__PURE__*/
+  messageDesc(file_messages, 45);
+
+/**
+ * Describes the message holdem.v1.SessionEnd.
+ * Use `create(SessionEndSchema)` to create a new message.
+ */
+export const SessionEndSchema = /*@__PURE__*/
+  messageDesc(file_messages, 46);
+
+/**
+ * Describes the message holdem.v1.HandList.
+ * Use `create(HandListSchema)` to create a new message.
+ */
+export const HandListSchema = /*@__PURE__*/
+  messageDesc(file_messages, 47);
+
+/**
+ * Describes the message holdem.v1.HandListItem.
+ * Use `create(HandListItemSchema)` to create a new message.
+ */
+export const HandListItemSchema = /*@__PURE__*/
+  messageDesc(file_messages, 48);
+
+/**
+ * Describes the message holdem.v1.SessionStack.
+ * Use `create(SessionStackSchema)` to create a new message.
+ */
+export const SessionStackSchema = /*@__PURE__*/
+  messageDesc(file_messages, 49);
 
 /**
  * Describes the message holdem.v1.StackDelta.
  * Use `create(StackDeltaSchema)` to create a new message.
  */
 export const StackDeltaSchema = /*@__PURE__*/
-  messageDesc(file_messages, 30);
+  messageDesc(file_messages, 50);
 
 /**
  * Describes the message holdem.v1.WinByFold.
  * Use `create(WinByFoldSchema)` to create a new message.
  */
 export const WinByFoldSchema = /*@__PURE__*/
-  messageDesc(file_messages, 31);
+  messageDesc(file_messages, 51);
+
+/**
+ * Describes the message holdem.v1.RabbitHunt.
+ * Use `create(RabbitHuntSchema)` to create a new message.
+ */
+export const RabbitHuntSchema = /*@__PURE__*/
+  messageDesc(file_messages, 52);
 
 /**
  * Describes the message holdem.v1.ExcessRefund.
  * Use `create(ExcessRefundSchema)` to create a new message.
  */
 export const ExcessRefundSchema = /*@__PURE__*/
-  messageDesc(file_messages, 32);
+  messageDesc(file_messages, 53);
+
+/**
+ * Describes the message holdem.v1.UncalledReturn.
+ * Use `create(UncalledReturnSchema)` to create a new message.
+ */
+export const UncalledReturnSchema = /*@__PURE__*/
+  messageDesc(file_messages, 54);
+
+/**
+ * Describes the message holdem.v1.RunItTwiceOffer.
+ * Use `create(RunItTwiceOfferSchema)` to create a new message.
+ */
+export const RunItTwiceOfferSchema = /*@__PURE__*/
+  messageDesc(file_messages, 55);
 
 /**
  * Describes the message holdem.v1.NetResult.
  * Use `create(NetResultSchema)` to create a new message.
  */
 export const NetResultSchema = /*@__PURE__*/
-  messageDesc(file_messages, 33);
+  messageDesc(file_messages, 56);
 
 /**
  * Describes the message holdem.v1.Card.
  * Use `create(CardSchema)` to create a new message.
  */
 export const CardSchema = /*@__PURE__*/
-  messageDesc(file_messages, 34);
+  messageDesc(file_messages, 57);
 
 /**
  * Describes the enum holdem.v1.Phase.
@@ -291,11 +452,23 @@ export const HandRankSchema = /*@__PURE__*/
 export const HandRank = /*@__PURE__*/
   tsEnum(HandRankSchema);
 
+/**
+ * Describes the enum holdem.v1.SizingPreset.
+ */
+export const SizingPresetSchema = /*@__PURE__*/
+  enumDesc(file_messages, 3);
+
+/**
+ * @generated from enum holdem.v1.SizingPreset
+ */
+export const SizingPreset = /*@__PURE__*/
+  tsEnum(SizingPresetSchema);
+
 /**
  * Describes the enum holdem.v1.Suit.
  */
 export const SuitSchema = /*@__PURE__*/
-  enumDesc(file_messages, 3);
+  enumDesc(file_messages, 4);
 
 /**
  * @generated from enum holdem.v1.Suit
@@ -307,7 +480,7 @@ export const Suit = /*@__PURE__*/
  * Describes the enum holdem.v1.Rank.
  */
 export const RankSchema = /*@__PURE__*/
-  enumDesc(file_messages, 4);
+  enumDesc(file_messages, 5);
 
 /**
  * @generated from enum holdem.v1.Rank
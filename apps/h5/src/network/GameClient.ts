@@ -427,10 +427,10 @@ export class GameClient {
 
     // Public API
 
-    joinTable(): void {
+    joinTable(observe = false, stake = ''): void {
         this.send({
             case: 'joinTable',
-            value: create(JoinTableRequestSchema, {}),
+            value: create(JoinTableRequestSchema, { observe, stake }),
         });
     }
 
@@ -12,6 +12,7 @@ export type ReplayServerEvent = {
 
 export type ReplayServerTape = {
     tapeVersion: number;
+    schemaVersion?: number;
     tableId: string;
     heroChair: number;
     events: ReplayServerEvent[];
@@ -19,6 +20,7 @@ export type ReplayServerTape = {
 
 export type ReplayServerInitResponse = {
     ok: boolean;
+    schemaVersion?: number;
     tape?: ReplayServerTape;
     error?: {
         step_index?: number;
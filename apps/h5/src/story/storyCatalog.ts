@@ -3,7 +3,7 @@ export type StoryChapterMeta = {
     roman: string;
     name: string;
     objective: {
-        type: 'win_bb' | 'survive' | 'win_pots' | 'eliminate' | 'most_chips';
+        type: 'win_bb' | 'survive' | 'win_pots' | 'eliminate' | 'most_chips' | 'double_up';
         target: number;
     };
 };
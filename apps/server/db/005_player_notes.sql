@@ -0,0 +1,20 @@
+-- 005_player_notes.sql
+-- Private notes players keep on opponents.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS player_notes (
+    user_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    target_user_id BIGINT NOT NULL,
+    note TEXT NOT NULL CHECK (char_length(note) <= 500),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, target_user_id)
+);
+
+DROP TRIGGER IF EXISTS trg_player_notes_updated_at ON player_notes;
+CREATE TRIGGER trg_player_notes_updated_at
+BEFORE UPDATE ON player_notes
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
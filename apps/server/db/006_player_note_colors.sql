@@ -0,0 +1,9 @@
+-- 006_player_note_colors.sql
+-- Color tags on player notes.
+
+BEGIN;
+
+ALTER TABLE player_notes
+    ADD COLUMN IF NOT EXISTS color TEXT NOT NULL DEFAULT '';
+
+COMMIT;
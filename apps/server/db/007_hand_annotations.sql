@@ -0,0 +1,9 @@
+-- 007_hand_annotations.sql
+-- Per-event study annotations on stored hands.
+
+BEGIN;
+
+ALTER TABLE audit_user_hand_history
+    ADD COLUMN IF NOT EXISTS annotations_json JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+COMMIT;
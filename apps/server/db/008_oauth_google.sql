@@ -0,0 +1,5 @@
+-- 008_oauth_google.sql
+-- Google sign-in identities. ALTER TYPE ... ADD VALUE cannot share a
+-- transaction with statements that use the new value, so this runs bare.
+
+ALTER TYPE auth_provider ADD VALUE IF NOT EXISTS 'google';
@@ -0,0 +1,17 @@
+-- 009_password_resets.sql
+-- Single-use password reset tokens for local accounts.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS auth_password_resets (
+    token TEXT PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_password_resets_account
+    ON auth_password_resets (account_id);
+
+COMMIT;
@@ -10,7 +10,7 @@ BEGIN;
 DO $$
 BEGIN
     IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'auth_provider') THEN
-        CREATE TYPE auth_provider AS ENUM ('local', 'steam', 'guest');
+        CREATE TYPE auth_provider AS ENUM ('local', 'steam', 'guest', 'google');
     END IF;
 END
 $$;
@@ -141,6 +141,17 @@ CREATE INDEX IF NOT EXISTS idx_auth_sessions_active
     ON auth_sessions (expires_at)
     WHERE revoked_at IS NULL;
 
+CREATE TABLE IF NOT EXISTS auth_password_resets (
+    token TEXT PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_password_resets_account
+    ON auth_password_resets (account_id);
+
 -- ============================================================================
 -- wallets
 -- ============================================================================
@@ -357,6 +368,7 @@ CREATE TABLE IF NOT EXISTS audit_user_hand_history (
     played_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     summary_json JSONB NOT NULL DEFAULT '{}'::jsonb,
     tape_blob BYTEA,
+    annotations_json JSONB NOT NULL DEFAULT '[]'::jsonb,
     is_saved BOOLEAN NOT NULL DEFAULT FALSE,
     saved_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
@@ -401,6 +413,19 @@ CREATE TABLE IF NOT EXISTS story_progress (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+-- ============================================================================
+-- player notes
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS player_notes (
+    user_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    target_user_id BIGINT NOT NULL,
+    note TEXT NOT NULL CHECK (char_length(note) <= 500),
+    color TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, target_user_id)
+);
+
 -- ============================================================================
 -- updated_at triggers
 -- ============================================================================
@@ -447,4 +472,10 @@ BEFORE UPDATE ON story_progress
 FOR EACH ROW
 EXECUTE FUNCTION set_updated_at();
 
+DROP TRIGGER IF EXISTS trg_player_notes_updated_at ON player_notes;
+CREATE TRIGGER trg_player_notes_updated_at
+BEFORE UPDATE ON player_notes
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
 COMMIT;
@@ -216,6 +216,58 @@ func (HandRank) EnumDescriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{2}
 }
 
+type SizingPreset int32
+
+const (
+	SizingPreset_SIZING_PRESET_UNSPECIFIED       SizingPreset = 0
+	SizingPreset_SIZING_PRESET_HALF_POT          SizingPreset = 1
+	SizingPreset_SIZING_PRESET_THREE_QUARTER_POT SizingPreset = 2
+	SizingPreset_SIZING_PRESET_POT               SizingPreset = 3
+)
+
+// Enum value maps for SizingPreset.
+var (
+	SizingPreset_name = map[int32]string{
+		0: "SIZING_PRESET_UNSPECIFIED",
+		1: "SIZING_PRESET_HALF_POT",
+		2: "SIZING_PRESET_THREE_QUARTER_POT",
+		3: "SIZING_PRESET_POT",
+	}
+	SizingPreset_value = map[string]int32{
+		"SIZING_PRESET_UNSPECIFIED":       0,
+		"SIZING_PRESET_HALF_POT":          1,
+		"SIZING_PRESET_THREE_QUARTER_POT": 2,
+		"SIZING_PRESET_POT":               3,
+	}
+)
+
+func (x SizingPreset) Enum() *SizingPreset {
+	p := new(SizingPreset)
+	*p = x
+	return p
+}
+
+func (x SizingPreset) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SizingPreset) Descriptor() protoreflect.EnumDescriptor {
+	return file_messages_proto_enumTypes[3].Descriptor()
+}
+
+func (SizingPreset) Type() protoreflect.EnumType {
+	return &file_messages_proto_enumTypes[3]
+}
+
+func (x SizingPreset) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SizingPreset.Descriptor instead.
+func (SizingPreset) EnumDescriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{3}
+}
+
 type Suit int32
 
 const (
@@ -255,11 +307,11 @@ func (x Suit) String() string {
 }
 
 func (Suit) Descriptor() protoreflect.EnumDescriptor {
-	return file_messages_proto_enumTypes[3].Descriptor()
+	return file_messages_proto_enumTypes[4].Descriptor()
 }
 
 func (Suit) Type() protoreflect.EnumType {
-	return &file_messages_proto_enumTypes[3]
+	return &file_messages_proto_enumTypes[4]
 }
 
 func (x Suit) Number() protoreflect.EnumNumber {
@@ -268,7 +320,7 @@ func (x Suit) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Suit.Descriptor instead.
 func (Suit) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{3}
+	return file_messages_proto_rawDescGZIP(), []int{4}
 }
 
 type Rank int32
@@ -337,11 +389,11 @@ func (x Rank) String() string {
 }
 
 func (Rank) Descriptor() protoreflect.EnumDescriptor {
-	return file_messages_proto_enumTypes[4].Descriptor()
+	return file_messages_proto_enumTypes[5].Descriptor()
 }
 
 func (Rank) Type() protoreflect.EnumType {
-	return &file_messages_proto_enumTypes[4]
+	return &file_messages_proto_enumTypes[5]
 }
 
 func (x Rank) Number() protoreflect.EnumNumber {
@@ -350,7 +402,7 @@ func (x Rank) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Rank.Descriptor instead.
 func (Rank) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{4}
+	return file_messages_proto_rawDescGZIP(), []int{5}
 }
 
 type ClientEnvelope struct {
@@ -368,6 +420,15 @@ type ClientEnvelope struct {
 	//	*ClientEnvelope_BuyIn
 	//	*ClientEnvelope_Action
 	//	*ClientEnvelope_StartStory
+	//	*ClientEnvelope_Straddle
+	//	*ClientEnvelope_CashOut
+	//	*ClientEnvelope_SitOut
+	//	*ClientEnvelope_SitIn
+	//	*ClientEnvelope_ListHands
+	//	*ClientEnvelope_RunItTwice
+	//	*ClientEnvelope_RequestSnapshot
+	//	*ClientEnvelope_Rematch
+	//	*ClientEnvelope_RunItTwiceAccept
 	Payload       isClientEnvelope_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -492,6 +553,87 @@ func (x *ClientEnvelope) GetStartStory() *StartStoryRequest {
 	return nil
 }
 
+func (x *ClientEnvelope) GetStraddle() *StraddleRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_Straddle); ok {
+			return x.Straddle
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetCashOut() *CashOutRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_CashOut); ok {
+			return x.CashOut
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetSitOut() *SitOutRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_SitOut); ok {
+			return x.SitOut
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetSitIn() *SitInRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_SitIn); ok {
+			return x.SitIn
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetListHands() *ListHandsRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_ListHands); ok {
+			return x.ListHands
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetRunItTwice() *RunItTwiceRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_RunItTwice); ok {
+			return x.RunItTwice
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetRequestSnapshot() *RequestSnapshotRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_RequestSnapshot); ok {
+			return x.RequestSnapshot
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetRematch() *RematchRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_Rematch); ok {
+			return x.Rematch
+		}
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetRunItTwiceAccept() *RunItTwiceAcceptRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ClientEnvelope_RunItTwiceAccept); ok {
+			return x.RunItTwiceAccept
+		}
+	}
+	return nil
+}
+
 type isClientEnvelope_Payload interface {
 	isClientEnvelope_Payload()
 }
@@ -520,6 +662,42 @@ type ClientEnvelope_StartStory struct {
 	StartStory *StartStoryRequest `protobuf:"bytes,15,opt,name=start_story,json=startStory,proto3,oneof"`
 }
 
+type ClientEnvelope_Straddle struct {
+	Straddle *StraddleRequest `protobuf:"bytes,16,opt,name=straddle,proto3,oneof"`
+}
+
+type ClientEnvelope_CashOut struct {
+	CashOut *CashOutRequest `protobuf:"bytes,17,opt,name=cash_out,json=cashOut,proto3,oneof"`
+}
+
+type ClientEnvelope_SitOut struct {
+	SitOut *SitOutRequest `protobuf:"bytes,18,opt,name=sit_out,json=sitOut,proto3,oneof"`
+}
+
+type ClientEnvelope_SitIn struct {
+	SitIn *SitInRequest `protobuf:"bytes,19,opt,name=sit_in,json=sitIn,proto3,oneof"`
+}
+
+type ClientEnvelope_ListHands struct {
+	ListHands *ListHandsRequest `protobuf:"bytes,20,opt,name=list_hands,json=listHands,proto3,oneof"`
+}
+
+type ClientEnvelope_RunItTwice struct {
+	RunItTwice *RunItTwiceRequest `protobuf:"bytes,21,opt,name=run_it_twice,json=runItTwice,proto3,oneof"`
+}
+
+type ClientEnvelope_RequestSnapshot struct {
+	RequestSnapshot *RequestSnapshotRequest `protobuf:"bytes,22,opt,name=request_snapshot,json=requestSnapshot,proto3,oneof"`
+}
+
+type ClientEnvelope_Rematch struct {
+	Rematch *RematchRequest `protobuf:"bytes,23,opt,name=rematch,proto3,oneof"`
+}
+
+type ClientEnvelope_RunItTwiceAccept struct {
+	RunItTwiceAccept *RunItTwiceAcceptRequest `protobuf:"bytes,24,opt,name=run_it_twice_accept,json=runItTwiceAccept,proto3,oneof"`
+}
+
 func (*ClientEnvelope_JoinTable) isClientEnvelope_Payload() {}
 
 func (*ClientEnvelope_SitDown) isClientEnvelope_Payload() {}
@@ -532,6 +710,24 @@ func (*ClientEnvelope_Action) isClientEnvelope_Payload() {}
 
 func (*ClientEnvelope_StartStory) isClientEnvelope_Payload() {}
 
+func (*ClientEnvelope_Straddle) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_CashOut) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_SitOut) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_SitIn) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_ListHands) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_RunItTwice) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_RequestSnapshot) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_Rematch) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_RunItTwiceAccept) isClientEnvelope_Payload() {}
+
 type ServerEnvelope struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
 	TableId    string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
@@ -555,6 +751,13 @@ type ServerEnvelope struct {
 	//	*ServerEnvelope_LoginResponse
 	//	*ServerEnvelope_StoryChapterInfo
 	//	*ServerEnvelope_StoryProgress
+	//	*ServerEnvelope_SessionEnd
+	//	*ServerEnvelope_HandList
+	//	*ServerEnvelope_RabbitHunt
+	//	*ServerEnvelope_DealerDraw
+	//	*ServerEnvelope_UncalledReturn
+	//	*ServerEnvelope_RunItTwiceOffer
+	//	*ServerEnvelope_ChapterScoreboard
 	Payload       isServerEnvelope_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -762,6 +965,69 @@ func (x *ServerEnvelope) GetStoryProgress() *StoryProgressState {
 	return nil
 }
 
+func (x *ServerEnvelope) GetSessionEnd() *SessionEnd {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_SessionEnd); ok {
+			return x.SessionEnd
+		}
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetHandList() *HandList {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_HandList); ok {
+			return x.HandList
+		}
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetRabbitHunt() *RabbitHunt {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_RabbitHunt); ok {
+			return x.RabbitHunt
+		}
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetDealerDraw() *DealerDraw {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_DealerDraw); ok {
+			return x.DealerDraw
+		}
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetUncalledReturn() *UncalledReturn {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_UncalledReturn); ok {
+			return x.UncalledReturn
+		}
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetRunItTwiceOffer() *RunItTwiceOffer {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_RunItTwiceOffer); ok {
+			return x.RunItTwiceOffer
+		}
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetChapterScoreboard() *ChapterScoreboard {
+	if x != nil {
+		if x, ok := x.Payload.(*ServerEnvelope_ChapterScoreboard); ok {
+			return x.ChapterScoreboard
+		}
+	}
+	return nil
+}
+
 type isServerEnvelope_Payload interface {
 	isServerEnvelope_Payload()
 }
@@ -830,6 +1096,34 @@ type ServerEnvelope_StoryProgress struct {
 	StoryProgress *StoryProgressState `protobuf:"bytes,25,opt,name=story_progress,json=storyProgress,proto3,oneof"`
 }
 
+type ServerEnvelope_SessionEnd struct {
+	SessionEnd *SessionEnd `protobuf:"bytes,26,opt,name=session_end,json=sessionEnd,proto3,oneof"`
+}
+
+type ServerEnvelope_HandList struct {
+	HandList *HandList `protobuf:"bytes,27,opt,name=hand_list,json=handList,proto3,oneof"`
+}
+
+type ServerEnvelope_RabbitHunt struct {
+	RabbitHunt *RabbitHunt `protobuf:"bytes,28,opt,name=rabbit_hunt,json=rabbitHunt,proto3,oneof"`
+}
+
+type ServerEnvelope_DealerDraw struct {
+	DealerDraw *DealerDraw `protobuf:"bytes,29,opt,name=dealer_draw,json=dealerDraw,proto3,oneof"`
+}
+
+type ServerEnvelope_UncalledReturn struct {
+	UncalledReturn *UncalledReturn `protobuf:"bytes,30,opt,name=uncalled_return,json=uncalledReturn,proto3,oneof"`
+}
+
+type ServerEnvelope_RunItTwiceOffer struct {
+	RunItTwiceOffer *RunItTwiceOffer `protobuf:"bytes,31,opt,name=run_it_twice_offer,json=runItTwiceOffer,proto3,oneof"`
+}
+
+type ServerEnvelope_ChapterScoreboard struct {
+	ChapterScoreboard *ChapterScoreboard `protobuf:"bytes,32,opt,name=chapter_scoreboard,json=chapterScoreboard,proto3,oneof"`
+}
+
 func (*ServerEnvelope_Error) isServerEnvelope_Payload() {}
 
 func (*ServerEnvelope_TableSnapshot) isServerEnvelope_Payload() {}
@@ -862,6 +1156,20 @@ func (*ServerEnvelope_StoryChapterInfo) isServerEnvelope_Payload() {}
 
 func (*ServerEnvelope_StoryProgress) isServerEnvelope_Payload() {}
 
+func (*ServerEnvelope_SessionEnd) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_HandList) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_RabbitHunt) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_DealerDraw) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_UncalledReturn) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_RunItTwiceOffer) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_ChapterScoreboard) isServerEnvelope_Payload() {}
+
 type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -915,7 +1223,12 @@ func (x *LoginResponse) GetSessionToken() string {
 }
 
 type JoinTableRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// table_id is in the envelope.
+	Observe bool `protobuf:"varint,1,opt,name=observe,proto3" json:"observe,omitempty"` // watch without taking a seat
+	// Named stake from the server's table config; empty picks the default.
+	// Ignored when resuming a seat the player already holds.
+	Stake         string `protobuf:"bytes,2,opt,name=stake,proto3" json:"stake,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -950,6 +1263,20 @@ func (*JoinTableRequest) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *JoinTableRequest) GetObserve() bool {
+	if x != nil {
+		return x.Observe
+	}
+	return false
+}
+
+func (x *JoinTableRequest) GetStake() string {
+	if x != nil {
+		return x.Stake
+	}
+	return ""
+}
+
 type SitDownRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
@@ -1082,28 +1409,27 @@ func (x *BuyInRequest) GetAmount() int64 {
 	return 0
 }
 
-type ActionRequest struct {
+// Keep the seat but skip the following hands.
+type SitOutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Action        ActionType             `protobuf:"varint,1,opt,name=action,proto3,enum=holdem.v1.ActionType" json:"action,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"` // Total bet amount for this round (for RAISE/BET)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ActionRequest) Reset() {
-	*x = ActionRequest{}
+func (x *SitOutRequest) Reset() {
+	*x = SitOutRequest{}
 	mi := &file_messages_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ActionRequest) String() string {
+func (x *SitOutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionRequest) ProtoMessage() {}
+func (*SitOutRequest) ProtoMessage() {}
 
-func (x *ActionRequest) ProtoReflect() protoreflect.Message {
+func (x *SitOutRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1115,46 +1441,31 @@ func (x *ActionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionRequest.ProtoReflect.Descriptor instead.
-func (*ActionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SitOutRequest.ProtoReflect.Descriptor instead.
+func (*SitOutRequest) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ActionRequest) GetAction() ActionType {
-	if x != nil {
-		return x.Action
-	}
-	return ActionType_ACTION_UNSPECIFIED
-}
-
-func (x *ActionRequest) GetAmount() int64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
-type StartStoryRequest struct {
+type SitInRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChapterId     int32                  `protobuf:"varint,1,opt,name=chapter_id,json=chapterId,proto3" json:"chapter_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartStoryRequest) Reset() {
-	*x = StartStoryRequest{}
+func (x *SitInRequest) Reset() {
+	*x = SitInRequest{}
 	mi := &file_messages_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartStoryRequest) String() string {
+func (x *SitInRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartStoryRequest) ProtoMessage() {}
+func (*SitInRequest) ProtoMessage() {}
 
-func (x *StartStoryRequest) ProtoReflect() protoreflect.Message {
+func (x *SitInRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1166,45 +1477,34 @@ func (x *StartStoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartStoryRequest.ProtoReflect.Descriptor instead.
-func (*StartStoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SitInRequest.ProtoReflect.Descriptor instead.
+func (*SitInRequest) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *StartStoryRequest) GetChapterId() int32 {
-	if x != nil {
-		return x.ChapterId
-	}
-	return 0
-}
-
-type StoryNpcInfo struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	NpcId            string                 `protobuf:"bytes,1,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
-	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ReiIntro         string                 `protobuf:"bytes,3,opt,name=rei_intro,json=reiIntro,proto3" json:"rei_intro,omitempty"`
-	ReiStyle         string                 `protobuf:"bytes,4,opt,name=rei_style,json=reiStyle,proto3" json:"rei_style,omitempty"`
-	IsBoss           bool                   `protobuf:"varint,5,opt,name=is_boss,json=isBoss,proto3" json:"is_boss,omitempty"`
-	FirstSeenChapter int32                  `protobuf:"varint,6,opt,name=first_seen_chapter,json=firstSeenChapter,proto3" json:"first_seen_chapter,omitempty"`
-	AvatarKey        string                 `protobuf:"bytes,7,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// Opt in to straddle the next hand from the given chair (UTG or button,
+// depending on table config).
+type StraddleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoryNpcInfo) Reset() {
-	*x = StoryNpcInfo{}
+func (x *StraddleRequest) Reset() {
+	*x = StraddleRequest{}
 	mi := &file_messages_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoryNpcInfo) String() string {
+func (x *StraddleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoryNpcInfo) ProtoMessage() {}
+func (*StraddleRequest) ProtoMessage() {}
 
-func (x *StoryNpcInfo) ProtoReflect() protoreflect.Message {
+func (x *StraddleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1216,90 +1516,80 @@ func (x *StoryNpcInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoryNpcInfo.ProtoReflect.Descriptor instead.
-func (*StoryNpcInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use StraddleRequest.ProtoReflect.Descriptor instead.
+func (*StraddleRequest) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *StoryNpcInfo) GetNpcId() string {
+func (x *StraddleRequest) GetChair() uint32 {
 	if x != nil {
-		return x.NpcId
+		return x.Chair
 	}
-	return ""
+	return 0
 }
 
-func (x *StoryNpcInfo) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Opt in to cash out at equity if this hand runs out with the player all-in.
+// Covers the current hand only.
+type CashOutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoryNpcInfo) GetReiIntro() string {
-	if x != nil {
-		return x.ReiIntro
-	}
-	return ""
+func (x *CashOutRequest) Reset() {
+	*x = CashOutRequest{}
+	mi := &file_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoryNpcInfo) GetReiStyle() string {
-	if x != nil {
-		return x.ReiStyle
-	}
-	return ""
+func (x *CashOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *StoryNpcInfo) GetIsBoss() bool {
-	if x != nil {
-		return x.IsBoss
-	}
-	return false
-}
+func (*CashOutRequest) ProtoMessage() {}
 
-func (x *StoryNpcInfo) GetFirstSeenChapter() int32 {
+func (x *CashOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[10]
 	if x != nil {
-		return x.FirstSeenChapter
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *StoryNpcInfo) GetAvatarKey() string {
-	if x != nil {
-		return x.AvatarKey
-	}
-	return ""
+// Deprecated: Use CashOutRequest.ProtoReflect.Descriptor instead.
+func (*CashOutRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{10}
 }
 
-type StoryChapterInfo struct {
+// Agree to run the rest of the board twice if this hand runs out all-in. It
+// only happens when every player still in the hand agrees; covers the current
+// hand only.
+type RunItTwiceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChapterId     int32                  `protobuf:"varint,1,opt,name=chapter_id,json=chapterId,proto3" json:"chapter_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Subtitle      string                 `protobuf:"bytes,3,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
-	ObjectiveDesc string                 `protobuf:"bytes,4,opt,name=objective_desc,json=objectiveDesc,proto3" json:"objective_desc,omitempty"`
-	ReiIntro      string                 `protobuf:"bytes,5,opt,name=rei_intro,json=reiIntro,proto3" json:"rei_intro,omitempty"`
-	ReiBossNote   string                 `protobuf:"bytes,6,opt,name=rei_boss_note,json=reiBossNote,proto3" json:"rei_boss_note,omitempty"`
-	BossName      string                 `protobuf:"bytes,7,opt,name=boss_name,json=bossName,proto3" json:"boss_name,omitempty"`
-	TableId       string                 `protobuf:"bytes,8,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
-	NpcRoster     []*StoryNpcInfo        `protobuf:"bytes,9,rep,name=npc_roster,json=npcRoster,proto3" json:"npc_roster,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoryChapterInfo) Reset() {
-	*x = StoryChapterInfo{}
-	mi := &file_messages_proto_msgTypes[10]
+func (x *RunItTwiceRequest) Reset() {
+	*x = RunItTwiceRequest{}
+	mi := &file_messages_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoryChapterInfo) String() string {
+func (x *RunItTwiceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoryChapterInfo) ProtoMessage() {}
+func (*RunItTwiceRequest) ProtoMessage() {}
 
-func (x *StoryChapterInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[10]
+func (x *RunItTwiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1310,99 +1600,80 @@ func (x *StoryChapterInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoryChapterInfo.ProtoReflect.Descriptor instead.
-func (*StoryChapterInfo) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *StoryChapterInfo) GetChapterId() int32 {
-	if x != nil {
-		return x.ChapterId
-	}
-	return 0
-}
-
-func (x *StoryChapterInfo) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+// Deprecated: Use RunItTwiceRequest.ProtoReflect.Descriptor instead.
+func (*RunItTwiceRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *StoryChapterInfo) GetSubtitle() string {
-	if x != nil {
-		return x.Subtitle
-	}
-	return ""
+// Answer a RunItTwiceOffer for the current hand. The board is run twice only
+// if every offered player accepts before the deadline.
+type RunItTwiceAcceptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accept        bool                   `protobuf:"varint,1,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoryChapterInfo) GetObjectiveDesc() string {
-	if x != nil {
-		return x.ObjectiveDesc
-	}
-	return ""
+func (x *RunItTwiceAcceptRequest) Reset() {
+	*x = RunItTwiceAcceptRequest{}
+	mi := &file_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoryChapterInfo) GetReiIntro() string {
-	if x != nil {
-		return x.ReiIntro
-	}
-	return ""
+func (x *RunItTwiceAcceptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *StoryChapterInfo) GetReiBossNote() string {
-	if x != nil {
-		return x.ReiBossNote
-	}
-	return ""
-}
+func (*RunItTwiceAcceptRequest) ProtoMessage() {}
 
-func (x *StoryChapterInfo) GetBossName() string {
+func (x *RunItTwiceAcceptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[12]
 	if x != nil {
-		return x.BossName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *StoryChapterInfo) GetTableId() string {
-	if x != nil {
-		return x.TableId
-	}
-	return ""
+// Deprecated: Use RunItTwiceAcceptRequest.ProtoReflect.Descriptor instead.
+func (*RunItTwiceAcceptRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *StoryChapterInfo) GetNpcRoster() []*StoryNpcInfo {
+func (x *RunItTwiceAcceptRequest) GetAccept() bool {
 	if x != nil {
-		return x.NpcRoster
+		return x.Accept
 	}
-	return nil
+	return false
 }
 
-type StoryProgressState struct {
-	state                   protoimpl.MessageState `protogen:"open.v1"`
-	HighestCompletedChapter int32                  `protobuf:"varint,1,opt,name=highest_completed_chapter,json=highestCompletedChapter,proto3" json:"highest_completed_chapter,omitempty"`
-	HighestUnlockedChapter  int32                  `protobuf:"varint,2,opt,name=highest_unlocked_chapter,json=highestUnlockedChapter,proto3" json:"highest_unlocked_chapter,omitempty"`
-	CompletedChapters       []int32                `protobuf:"varint,3,rep,packed,name=completed_chapters,json=completedChapters,proto3" json:"completed_chapters,omitempty"`
-	UnlockedFeatures        []string               `protobuf:"bytes,4,rep,name=unlocked_features,json=unlockedFeatures,proto3" json:"unlocked_features,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+// Ask for a fresh TableSnapshot, e.g. from a refresh button, without
+// reconnecting. Rate-limited per user.
+type RequestSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoryProgressState) Reset() {
-	*x = StoryProgressState{}
-	mi := &file_messages_proto_msgTypes[11]
+func (x *RequestSnapshotRequest) Reset() {
+	*x = RequestSnapshotRequest{}
+	mi := &file_messages_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoryProgressState) String() string {
+func (x *RequestSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoryProgressState) ProtoMessage() {}
+func (*RequestSnapshotRequest) ProtoMessage() {}
 
-func (x *StoryProgressState) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[11]
+func (x *RequestSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1413,62 +1684,74 @@ func (x *StoryProgressState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoryProgressState.ProtoReflect.Descriptor instead.
-func (*StoryProgressState) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RequestSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RequestSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *StoryProgressState) GetHighestCompletedChapter() int32 {
-	if x != nil {
-		return x.HighestCompletedChapter
-	}
-	return 0
+// Replay the caller's heads-up session or story chapter that just ended: the
+// same config and NPC opponents, or the same chapter. A human opponent joins
+// the new table if they also ask.
+type RematchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoryProgressState) GetHighestUnlockedChapter() int32 {
-	if x != nil {
-		return x.HighestUnlockedChapter
-	}
-	return 0
+func (x *RematchRequest) Reset() {
+	*x = RematchRequest{}
+	mi := &file_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoryProgressState) GetCompletedChapters() []int32 {
-	if x != nil {
-		return x.CompletedChapters
-	}
-	return nil
+func (x *RematchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *StoryProgressState) GetUnlockedFeatures() []string {
+func (*RematchRequest) ProtoMessage() {}
+
+func (x *RematchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[14]
 	if x != nil {
-		return x.UnlockedFeatures
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type ErrorResponse struct {
+// Deprecated: Use RematchRequest.ProtoReflect.Descriptor instead.
+func (*RematchRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{14}
+}
+
+// Ask for the caller's recent hand history, as served by the audit API.
+type ListHandsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // "live" (default) or "replay"
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 0 uses the server default
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ErrorResponse) Reset() {
-	*x = ErrorResponse{}
-	mi := &file_messages_proto_msgTypes[12]
+func (x *ListHandsRequest) Reset() {
+	*x = ListHandsRequest{}
+	mi := &file_messages_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ErrorResponse) String() string {
+func (x *ListHandsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ErrorResponse) ProtoMessage() {}
+func (*ListHandsRequest) ProtoMessage() {}
 
-func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[12]
+func (x *ListHandsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1479,58 +1762,50 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
-func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListHandsRequest.ProtoReflect.Descriptor instead.
+func (*ListHandsRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ErrorResponse) GetCode() int32 {
+func (x *ListHandsRequest) GetSource() string {
 	if x != nil {
-		return x.Code
+		return x.Source
 	}
-	return 0
+	return ""
 }
 
-func (x *ErrorResponse) GetMessage() string {
+func (x *ListHandsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Message
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type TableSnapshot struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Config          *TableConfig           `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
-	Phase           Phase                  `protobuf:"varint,2,opt,name=phase,proto3,enum=holdem.v1.Phase" json:"phase,omitempty"`
-	Round           uint32                 `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
-	DealerChair     uint32                 `protobuf:"varint,4,opt,name=dealer_chair,json=dealerChair,proto3" json:"dealer_chair,omitempty"`
-	SmallBlindChair uint32                 `protobuf:"varint,5,opt,name=small_blind_chair,json=smallBlindChair,proto3" json:"small_blind_chair,omitempty"`
-	BigBlindChair   uint32                 `protobuf:"varint,6,opt,name=big_blind_chair,json=bigBlindChair,proto3" json:"big_blind_chair,omitempty"`
-	ActionChair     uint32                 `protobuf:"varint,7,opt,name=action_chair,json=actionChair,proto3" json:"action_chair,omitempty"`
-	CurBet          int64                  `protobuf:"varint,8,opt,name=cur_bet,json=curBet,proto3" json:"cur_bet,omitempty"`
-	MinRaiseDelta   int64                  `protobuf:"varint,9,opt,name=min_raise_delta,json=minRaiseDelta,proto3" json:"min_raise_delta,omitempty"`
-	CommunityCards  []*Card                `protobuf:"bytes,10,rep,name=community_cards,json=communityCards,proto3" json:"community_cards,omitempty"`
-	Pots            []*Pot                 `protobuf:"bytes,11,rep,name=pots,proto3" json:"pots,omitempty"`
-	Players         []*PlayerState         `protobuf:"bytes,12,rep,name=players,proto3" json:"players,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ActionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Action ActionType             `protobuf:"varint,1,opt,name=action,proto3,enum=holdem.v1.ActionType" json:"action,omitempty"`
+	Amount int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"` // Total bet amount for this round (for RAISE/BET)
+	// When set on BET/RAISE, the server resolves the amount from pot context and ignores amount.
+	SizingPreset  SizingPreset `protobuf:"varint,3,opt,name=sizing_preset,json=sizingPreset,proto3,enum=holdem.v1.SizingPreset" json:"sizing_preset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TableSnapshot) Reset() {
-	*x = TableSnapshot{}
-	mi := &file_messages_proto_msgTypes[13]
+func (x *ActionRequest) Reset() {
+	*x = ActionRequest{}
+	mi := &file_messages_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TableSnapshot) String() string {
+func (x *ActionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TableSnapshot) ProtoMessage() {}
+func (*ActionRequest) ProtoMessage() {}
 
-func (x *TableSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[13]
+func (x *ActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1541,122 +1816,104 @@ func (x *TableSnapshot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TableSnapshot.ProtoReflect.Descriptor instead.
-func (*TableSnapshot) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ActionRequest.ProtoReflect.Descriptor instead.
+func (*ActionRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *TableSnapshot) GetConfig() *TableConfig {
+func (x *ActionRequest) GetAction() ActionType {
 	if x != nil {
-		return x.Config
+		return x.Action
 	}
-	return nil
-}
-
-func (x *TableSnapshot) GetPhase() Phase {
-	if x != nil {
-		return x.Phase
-	}
-	return Phase_PHASE_UNSPECIFIED
-}
-
-func (x *TableSnapshot) GetRound() uint32 {
-	if x != nil {
-		return x.Round
-	}
-	return 0
+	return ActionType_ACTION_UNSPECIFIED
 }
 
-func (x *TableSnapshot) GetDealerChair() uint32 {
+func (x *ActionRequest) GetAmount() int64 {
 	if x != nil {
-		return x.DealerChair
+		return x.Amount
 	}
 	return 0
 }
 
-func (x *TableSnapshot) GetSmallBlindChair() uint32 {
+func (x *ActionRequest) GetSizingPreset() SizingPreset {
 	if x != nil {
-		return x.SmallBlindChair
+		return x.SizingPreset
 	}
-	return 0
+	return SizingPreset_SIZING_PRESET_UNSPECIFIED
 }
 
-func (x *TableSnapshot) GetBigBlindChair() uint32 {
-	if x != nil {
-		return x.BigBlindChair
-	}
-	return 0
+type StartStoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChapterId     int32                  `protobuf:"varint,1,opt,name=chapter_id,json=chapterId,proto3" json:"chapter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TableSnapshot) GetActionChair() uint32 {
-	if x != nil {
-		return x.ActionChair
-	}
-	return 0
+func (x *StartStoryRequest) Reset() {
+	*x = StartStoryRequest{}
+	mi := &file_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *TableSnapshot) GetCurBet() int64 {
-	if x != nil {
-		return x.CurBet
-	}
-	return 0
+func (x *StartStoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *TableSnapshot) GetMinRaiseDelta() int64 {
-	if x != nil {
-		return x.MinRaiseDelta
-	}
-	return 0
-}
+func (*StartStoryRequest) ProtoMessage() {}
 
-func (x *TableSnapshot) GetCommunityCards() []*Card {
+func (x *StartStoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[17]
 	if x != nil {
-		return x.CommunityCards
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TableSnapshot) GetPots() []*Pot {
-	if x != nil {
-		return x.Pots
-	}
-	return nil
+// Deprecated: Use StartStoryRequest.ProtoReflect.Descriptor instead.
+func (*StartStoryRequest) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *TableSnapshot) GetPlayers() []*PlayerState {
+func (x *StartStoryRequest) GetChapterId() int32 {
 	if x != nil {
-		return x.Players
+		return x.ChapterId
 	}
-	return nil
+	return 0
 }
 
-type TableConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxPlayers    uint32                 `protobuf:"varint,1,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
-	SmallBlind    int64                  `protobuf:"varint,2,opt,name=small_blind,json=smallBlind,proto3" json:"small_blind,omitempty"`
-	BigBlind      int64                  `protobuf:"varint,3,opt,name=big_blind,json=bigBlind,proto3" json:"big_blind,omitempty"`
-	Ante          int64                  `protobuf:"varint,4,opt,name=ante,proto3" json:"ante,omitempty"`
-	MinBuyIn      int64                  `protobuf:"varint,5,opt,name=min_buy_in,json=minBuyIn,proto3" json:"min_buy_in,omitempty"`
-	MaxBuyIn      int64                  `protobuf:"varint,6,opt,name=max_buy_in,json=maxBuyIn,proto3" json:"max_buy_in,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StoryNpcInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NpcId            string                 `protobuf:"bytes,1,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReiIntro         string                 `protobuf:"bytes,3,opt,name=rei_intro,json=reiIntro,proto3" json:"rei_intro,omitempty"`
+	ReiStyle         string                 `protobuf:"bytes,4,opt,name=rei_style,json=reiStyle,proto3" json:"rei_style,omitempty"`
+	IsBoss           bool                   `protobuf:"varint,5,opt,name=is_boss,json=isBoss,proto3" json:"is_boss,omitempty"`
+	FirstSeenChapter int32                  `protobuf:"varint,6,opt,name=first_seen_chapter,json=firstSeenChapter,proto3" json:"first_seen_chapter,omitempty"`
+	AvatarKey        string                 `protobuf:"bytes,7,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *TableConfig) Reset() {
-	*x = TableConfig{}
-	mi := &file_messages_proto_msgTypes[14]
+func (x *StoryNpcInfo) Reset() {
+	*x = StoryNpcInfo{}
+	mi := &file_messages_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TableConfig) String() string {
+func (x *StoryNpcInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TableConfig) ProtoMessage() {}
+func (*StoryNpcInfo) ProtoMessage() {}
 
-func (x *TableConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[14]
+func (x *StoryNpcInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1667,87 +1924,90 @@ func (x *TableConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TableConfig.ProtoReflect.Descriptor instead.
-func (*TableConfig) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use StoryNpcInfo.ProtoReflect.Descriptor instead.
+func (*StoryNpcInfo) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *TableConfig) GetMaxPlayers() uint32 {
+func (x *StoryNpcInfo) GetNpcId() string {
 	if x != nil {
-		return x.MaxPlayers
+		return x.NpcId
 	}
-	return 0
+	return ""
 }
 
-func (x *TableConfig) GetSmallBlind() int64 {
+func (x *StoryNpcInfo) GetName() string {
 	if x != nil {
-		return x.SmallBlind
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *TableConfig) GetBigBlind() int64 {
+func (x *StoryNpcInfo) GetReiIntro() string {
 	if x != nil {
-		return x.BigBlind
+		return x.ReiIntro
 	}
-	return 0
+	return ""
 }
 
-func (x *TableConfig) GetAnte() int64 {
+func (x *StoryNpcInfo) GetReiStyle() string {
 	if x != nil {
-		return x.Ante
+		return x.ReiStyle
 	}
-	return 0
+	return ""
 }
 
-func (x *TableConfig) GetMinBuyIn() int64 {
+func (x *StoryNpcInfo) GetIsBoss() bool {
 	if x != nil {
-		return x.MinBuyIn
+		return x.IsBoss
 	}
-	return 0
+	return false
 }
 
-func (x *TableConfig) GetMaxBuyIn() int64 {
+func (x *StoryNpcInfo) GetFirstSeenChapter() int32 {
 	if x != nil {
-		return x.MaxBuyIn
+		return x.FirstSeenChapter
 	}
 	return 0
 }
 
-type PlayerState struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	UserId     uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Chair      uint32                 `protobuf:"varint,2,opt,name=chair,proto3" json:"chair,omitempty"`
-	Nickname   string                 `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
-	Stack      int64                  `protobuf:"varint,4,opt,name=stack,proto3" json:"stack,omitempty"`
-	Bet        int64                  `protobuf:"varint,5,opt,name=bet,proto3" json:"bet,omitempty"`
-	Folded     bool                   `protobuf:"varint,6,opt,name=folded,proto3" json:"folded,omitempty"`
-	AllIn      bool                   `protobuf:"varint,7,opt,name=all_in,json=allIn,proto3" json:"all_in,omitempty"`
-	LastAction ActionType             `protobuf:"varint,8,opt,name=last_action,json=lastAction,proto3,enum=holdem.v1.ActionType" json:"last_action,omitempty"`
-	// hand_cards only sent to the player themselves
-	HandCards []*Card `protobuf:"bytes,9,rep,name=hand_cards,json=handCards,proto3" json:"hand_cards,omitempty"`
-	// true when this player has been dealt hole cards in the current hand.
-	HasCards      bool   `protobuf:"varint,10,opt,name=has_cards,json=hasCards,proto3" json:"has_cards,omitempty"`
-	AvatarKey     string `protobuf:"bytes,11,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`
+func (x *StoryNpcInfo) GetAvatarKey() string {
+	if x != nil {
+		return x.AvatarKey
+	}
+	return ""
+}
+
+type StoryChapterInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChapterId     int32                  `protobuf:"varint,1,opt,name=chapter_id,json=chapterId,proto3" json:"chapter_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Subtitle      string                 `protobuf:"bytes,3,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
+	ObjectiveDesc string                 `protobuf:"bytes,4,opt,name=objective_desc,json=objectiveDesc,proto3" json:"objective_desc,omitempty"`
+	ReiIntro      string                 `protobuf:"bytes,5,opt,name=rei_intro,json=reiIntro,proto3" json:"rei_intro,omitempty"`
+	ReiBossNote   string                 `protobuf:"bytes,6,opt,name=rei_boss_note,json=reiBossNote,proto3" json:"rei_boss_note,omitempty"`
+	BossName      string                 `protobuf:"bytes,7,opt,name=boss_name,json=bossName,proto3" json:"boss_name,omitempty"`
+	TableId       string                 `protobuf:"bytes,8,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	NpcRoster     []*StoryNpcInfo        `protobuf:"bytes,9,rep,name=npc_roster,json=npcRoster,proto3" json:"npc_roster,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerState) Reset() {
-	*x = PlayerState{}
-	mi := &file_messages_proto_msgTypes[15]
+func (x *StoryChapterInfo) Reset() {
+	*x = StoryChapterInfo{}
+	mi := &file_messages_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerState) String() string {
+func (x *StoryChapterInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerState) ProtoMessage() {}
+func (*StoryChapterInfo) ProtoMessage() {}
 
-func (x *PlayerState) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[15]
+func (x *StoryChapterInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1758,111 +2018,347 @@ func (x *PlayerState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerState.ProtoReflect.Descriptor instead.
-func (*PlayerState) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use StoryChapterInfo.ProtoReflect.Descriptor instead.
+func (*StoryChapterInfo) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *PlayerState) GetUserId() uint64 {
+func (x *StoryChapterInfo) GetChapterId() int32 {
 	if x != nil {
-		return x.UserId
+		return x.ChapterId
 	}
 	return 0
 }
 
-func (x *PlayerState) GetChair() uint32 {
+func (x *StoryChapterInfo) GetTitle() string {
 	if x != nil {
-		return x.Chair
+		return x.Title
 	}
-	return 0
+	return ""
 }
 
-func (x *PlayerState) GetNickname() string {
+func (x *StoryChapterInfo) GetSubtitle() string {
 	if x != nil {
-		return x.Nickname
+		return x.Subtitle
 	}
 	return ""
 }
 
-func (x *PlayerState) GetStack() int64 {
+func (x *StoryChapterInfo) GetObjectiveDesc() string {
 	if x != nil {
-		return x.Stack
+		return x.ObjectiveDesc
 	}
-	return 0
+	return ""
 }
 
-func (x *PlayerState) GetBet() int64 {
+func (x *StoryChapterInfo) GetReiIntro() string {
 	if x != nil {
-		return x.Bet
+		return x.ReiIntro
 	}
-	return 0
+	return ""
 }
 
-func (x *PlayerState) GetFolded() bool {
+func (x *StoryChapterInfo) GetReiBossNote() string {
 	if x != nil {
-		return x.Folded
+		return x.ReiBossNote
 	}
-	return false
+	return ""
 }
 
-func (x *PlayerState) GetAllIn() bool {
+func (x *StoryChapterInfo) GetBossName() string {
 	if x != nil {
-		return x.AllIn
+		return x.BossName
 	}
-	return false
+	return ""
 }
 
-func (x *PlayerState) GetLastAction() ActionType {
+func (x *StoryChapterInfo) GetTableId() string {
 	if x != nil {
-		return x.LastAction
+		return x.TableId
 	}
-	return ActionType_ACTION_UNSPECIFIED
+	return ""
 }
 
-func (x *PlayerState) GetHandCards() []*Card {
+func (x *StoryChapterInfo) GetNpcRoster() []*StoryNpcInfo {
 	if x != nil {
-		return x.HandCards
+		return x.NpcRoster
 	}
 	return nil
 }
 
-func (x *PlayerState) GetHasCards() bool {
+type StoryProgressState struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	HighestCompletedChapter int32                  `protobuf:"varint,1,opt,name=highest_completed_chapter,json=highestCompletedChapter,proto3" json:"highest_completed_chapter,omitempty"`
+	HighestUnlockedChapter  int32                  `protobuf:"varint,2,opt,name=highest_unlocked_chapter,json=highestUnlockedChapter,proto3" json:"highest_unlocked_chapter,omitempty"`
+	CompletedChapters       []int32                `protobuf:"varint,3,rep,packed,name=completed_chapters,json=completedChapters,proto3" json:"completed_chapters,omitempty"`
+	UnlockedFeatures        []string               `protobuf:"bytes,4,rep,name=unlocked_features,json=unlockedFeatures,proto3" json:"unlocked_features,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *StoryProgressState) Reset() {
+	*x = StoryProgressState{}
+	mi := &file_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoryProgressState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoryProgressState) ProtoMessage() {}
+
+func (x *StoryProgressState) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[20]
 	if x != nil {
-		return x.HasCards
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoryProgressState.ProtoReflect.Descriptor instead.
+func (*StoryProgressState) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StoryProgressState) GetHighestCompletedChapter() int32 {
+	if x != nil {
+		return x.HighestCompletedChapter
+	}
+	return 0
+}
+
+func (x *StoryProgressState) GetHighestUnlockedChapter() int32 {
+	if x != nil {
+		return x.HighestUnlockedChapter
+	}
+	return 0
+}
+
+func (x *StoryProgressState) GetCompletedChapters() []int32 {
+	if x != nil {
+		return x.CompletedChapters
+	}
+	return nil
+}
+
+func (x *StoryProgressState) GetUnlockedFeatures() []string {
+	if x != nil {
+		return x.UnlockedFeatures
+	}
+	return nil
+}
+
+// Running standings of a story chapter, sent to the player after every hand.
+type ChapterScoreboard struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChapterId   int32                  `protobuf:"varint,1,opt,name=chapter_id,json=chapterId,proto3" json:"chapter_id,omitempty"`
+	HandsPlayed int32                  `protobuf:"varint,2,opt,name=hands_played,json=handsPlayed,proto3" json:"hands_played,omitempty"`
+	// Every seat bought in for start_stack when the chapter began.
+	StartStack int64              `protobuf:"varint,3,opt,name=start_stack,json=startStack,proto3" json:"start_stack,omitempty"`
+	Standings  []*ChapterStanding `protobuf:"bytes,4,rep,name=standings,proto3" json:"standings,omitempty"` // by stack, chip leader first
+	// How far the player is toward the chapter objective, in its own unit
+	// (big blinds won, hands, pots or chips); complete at objective_target.
+	ObjectiveProgress int64 `protobuf:"varint,5,opt,name=objective_progress,json=objectiveProgress,proto3" json:"objective_progress,omitempty"`
+	ObjectiveTarget   int64 `protobuf:"varint,6,opt,name=objective_target,json=objectiveTarget,proto3" json:"objective_target,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ChapterScoreboard) Reset() {
+	*x = ChapterScoreboard{}
+	mi := &file_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChapterScoreboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChapterScoreboard) ProtoMessage() {}
+
+func (x *ChapterScoreboard) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChapterScoreboard.ProtoReflect.Descriptor instead.
+func (*ChapterScoreboard) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChapterScoreboard) GetChapterId() int32 {
+	if x != nil {
+		return x.ChapterId
+	}
+	return 0
+}
+
+func (x *ChapterScoreboard) GetHandsPlayed() int32 {
+	if x != nil {
+		return x.HandsPlayed
+	}
+	return 0
+}
+
+func (x *ChapterScoreboard) GetStartStack() int64 {
+	if x != nil {
+		return x.StartStack
+	}
+	return 0
+}
+
+func (x *ChapterScoreboard) GetStandings() []*ChapterStanding {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+func (x *ChapterScoreboard) GetObjectiveProgress() int64 {
+	if x != nil {
+		return x.ObjectiveProgress
+	}
+	return 0
+}
+
+func (x *ChapterScoreboard) GetObjectiveTarget() int64 {
+	if x != nil {
+		return x.ObjectiveTarget
+	}
+	return 0
+}
+
+type ChapterStanding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Stack         int64                  `protobuf:"varint,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	Net           int64                  `protobuf:"varint,4,opt,name=net,proto3" json:"net,omitempty"`   // stack - start_stack
+	Rank          int32                  `protobuf:"varint,5,opt,name=rank,proto3" json:"rank,omitempty"` // 1 for the chip leader; equal stacks share a rank
+	IsHero        bool                   `protobuf:"varint,6,opt,name=is_hero,json=isHero,proto3" json:"is_hero,omitempty"`
+	Busted        bool                   `protobuf:"varint,7,opt,name=busted,proto3" json:"busted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChapterStanding) Reset() {
+	*x = ChapterStanding{}
+	mi := &file_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChapterStanding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChapterStanding) ProtoMessage() {}
+
+func (x *ChapterStanding) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChapterStanding.ProtoReflect.Descriptor instead.
+func (*ChapterStanding) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChapterStanding) GetChair() uint32 {
+	if x != nil {
+		return x.Chair
+	}
+	return 0
+}
+
+func (x *ChapterStanding) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ChapterStanding) GetStack() int64 {
+	if x != nil {
+		return x.Stack
+	}
+	return 0
+}
+
+func (x *ChapterStanding) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+func (x *ChapterStanding) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ChapterStanding) GetIsHero() bool {
+	if x != nil {
+		return x.IsHero
 	}
 	return false
 }
 
-func (x *PlayerState) GetAvatarKey() string {
+func (x *ChapterStanding) GetBusted() bool {
 	if x != nil {
-		return x.AvatarKey
+		return x.Busted
 	}
-	return ""
+	return false
 }
 
-type Pot struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Amount         int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	EligibleChairs []uint32               `protobuf:"varint,2,rep,packed,name=eligible_chairs,json=eligibleChairs,proto3" json:"eligible_chairs,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ErrorResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Code    int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Set when an action is rejected so the client can correct itself.
+	ActionOptions *ActionOptions `protobuf:"bytes,3,opt,name=action_options,json=actionOptions,proto3" json:"action_options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Pot) Reset() {
-	*x = Pot{}
-	mi := &file_messages_proto_msgTypes[16]
+func (x *ErrorResponse) Reset() {
+	*x = ErrorResponse{}
+	mi := &file_messages_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Pot) String() string {
+func (x *ErrorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Pot) ProtoMessage() {}
+func (*ErrorResponse) ProtoMessage() {}
 
-func (x *Pot) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[16]
+func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1873,53 +2369,1082 @@ func (x *Pot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Pot.ProtoReflect.Descriptor instead.
-func (*Pot) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
+func (*ErrorResponse) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *Pot) GetAmount() int64 {
+func (x *ErrorResponse) GetCode() int32 {
 	if x != nil {
-		return x.Amount
+		return x.Code
 	}
 	return 0
 }
 
-func (x *Pot) GetEligibleChairs() []uint32 {
+func (x *ErrorResponse) GetMessage() string {
 	if x != nil {
-		return x.EligibleChairs
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorResponse) GetActionOptions() *ActionOptions {
+	if x != nil {
+		return x.ActionOptions
 	}
 	return nil
 }
 
-type SeatUpdate struct {
+// What a player may do at the moment an action of theirs was rejected.
+type ActionOptions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	Chair uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
-	// Types that are valid to be assigned to Update:
-	//
-	//	*SeatUpdate_PlayerJoined
-	//	*SeatUpdate_PlayerLeftUserId
-	//	*SeatUpdate_StackChange
-	Update        isSeatUpdate_Update `protobuf_oneof:"update"`
+	// chair whose turn it is; not the player's own when they acted out of turn.
+	ActionChair uint32 `protobuf:"varint,1,opt,name=action_chair,json=actionChair,proto3" json:"action_chair,omitempty"`
+	// empty unless it is the player's turn.
+	LegalActions  []ActionType `protobuf:"varint,2,rep,packed,name=legal_actions,json=legalActions,proto3,enum=holdem.v1.ActionType" json:"legal_actions,omitempty"`
+	MinRaiseTo    int64        `protobuf:"varint,3,opt,name=min_raise_to,json=minRaiseTo,proto3" json:"min_raise_to,omitempty"`
+	CallAmount    int64        `protobuf:"varint,4,opt,name=call_amount,json=callAmount,proto3" json:"call_amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SeatUpdate) Reset() {
-	*x = SeatUpdate{}
-	mi := &file_messages_proto_msgTypes[17]
+func (x *ActionOptions) Reset() {
+	*x = ActionOptions{}
+	mi := &file_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionOptions) ProtoMessage() {}
+
+func (x *ActionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionOptions.ProtoReflect.Descriptor instead.
+func (*ActionOptions) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ActionOptions) GetActionChair() uint32 {
+	if x != nil {
+		return x.ActionChair
+	}
+	return 0
+}
+
+func (x *ActionOptions) GetLegalActions() []ActionType {
+	if x != nil {
+		return x.LegalActions
+	}
+	return nil
+}
+
+func (x *ActionOptions) GetMinRaiseTo() int64 {
+	if x != nil {
+		return x.MinRaiseTo
+	}
+	return 0
+}
+
+func (x *ActionOptions) GetCallAmount() int64 {
+	if x != nil {
+		return x.CallAmount
+	}
+	return 0
+}
+
+type TableSnapshot struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Config          *TableConfig           `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Phase           Phase                  `protobuf:"varint,2,opt,name=phase,proto3,enum=holdem.v1.Phase" json:"phase,omitempty"`
+	Round           uint32                 `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	DealerChair     uint32                 `protobuf:"varint,4,opt,name=dealer_chair,json=dealerChair,proto3" json:"dealer_chair,omitempty"`
+	SmallBlindChair uint32                 `protobuf:"varint,5,opt,name=small_blind_chair,json=smallBlindChair,proto3" json:"small_blind_chair,omitempty"`
+	BigBlindChair   uint32                 `protobuf:"varint,6,opt,name=big_blind_chair,json=bigBlindChair,proto3" json:"big_blind_chair,omitempty"`
+	ActionChair     uint32                 `protobuf:"varint,7,opt,name=action_chair,json=actionChair,proto3" json:"action_chair,omitempty"`
+	CurBet          int64                  `protobuf:"varint,8,opt,name=cur_bet,json=curBet,proto3" json:"cur_bet,omitempty"`
+	MinRaiseDelta   int64                  `protobuf:"varint,9,opt,name=min_raise_delta,json=minRaiseDelta,proto3" json:"min_raise_delta,omitempty"`
+	CommunityCards  []*Card                `protobuf:"bytes,10,rep,name=community_cards,json=communityCards,proto3" json:"community_cards,omitempty"`
+	Pots            []*Pot                 `protobuf:"bytes,11,rep,name=pots,proto3" json:"pots,omitempty"`
+	Players         []*PlayerState         `protobuf:"bytes,12,rep,name=players,proto3" json:"players,omitempty"`
+	SpectatorCount  uint32                 `protobuf:"varint,13,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TableSnapshot) Reset() {
+	*x = TableSnapshot{}
+	mi := &file_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableSnapshot) ProtoMessage() {}
+
+func (x *TableSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableSnapshot.ProtoReflect.Descriptor instead.
+func (*TableSnapshot) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TableSnapshot) GetConfig() *TableConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *TableSnapshot) GetPhase() Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return Phase_PHASE_UNSPECIFIED
+}
+
+func (x *TableSnapshot) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetDealerChair() uint32 {
+	if x != nil {
+		return x.DealerChair
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetSmallBlindChair() uint32 {
+	if x != nil {
+		return x.SmallBlindChair
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetBigBlindChair() uint32 {
+	if x != nil {
+		return x.BigBlindChair
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetActionChair() uint32 {
+	if x != nil {
+		return x.ActionChair
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetCurBet() int64 {
+	if x != nil {
+		return x.CurBet
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetMinRaiseDelta() int64 {
+	if x != nil {
+		return x.MinRaiseDelta
+	}
+	return 0
+}
+
+func (x *TableSnapshot) GetCommunityCards() []*Card {
+	if x != nil {
+		return x.CommunityCards
+	}
+	return nil
+}
+
+func (x *TableSnapshot) GetPots() []*Pot {
+	if x != nil {
+		return x.Pots
+	}
+	return nil
+}
+
+func (x *TableSnapshot) GetPlayers() []*PlayerState {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *TableSnapshot) GetSpectatorCount() uint32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+type TableConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxPlayers    uint32                 `protobuf:"varint,1,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	SmallBlind    int64                  `protobuf:"varint,2,opt,name=small_blind,json=smallBlind,proto3" json:"small_blind,omitempty"`
+	BigBlind      int64                  `protobuf:"varint,3,opt,name=big_blind,json=bigBlind,proto3" json:"big_blind,omitempty"`
+	Ante          int64                  `protobuf:"varint,4,opt,name=ante,proto3" json:"ante,omitempty"`
+	MinBuyIn      int64                  `protobuf:"varint,5,opt,name=min_buy_in,json=minBuyIn,proto3" json:"min_buy_in,omitempty"`
+	MaxBuyIn      int64                  `protobuf:"varint,6,opt,name=max_buy_in,json=maxBuyIn,proto3" json:"max_buy_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableConfig) Reset() {
+	*x = TableConfig{}
+	mi := &file_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableConfig) ProtoMessage() {}
+
+func (x *TableConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableConfig.ProtoReflect.Descriptor instead.
+func (*TableConfig) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TableConfig) GetMaxPlayers() uint32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *TableConfig) GetSmallBlind() int64 {
+	if x != nil {
+		return x.SmallBlind
+	}
+	return 0
+}
+
+func (x *TableConfig) GetBigBlind() int64 {
+	if x != nil {
+		return x.BigBlind
+	}
+	return 0
+}
+
+func (x *TableConfig) GetAnte() int64 {
+	if x != nil {
+		return x.Ante
+	}
+	return 0
+}
+
+func (x *TableConfig) GetMinBuyIn() int64 {
+	if x != nil {
+		return x.MinBuyIn
+	}
+	return 0
+}
+
+func (x *TableConfig) GetMaxBuyIn() int64 {
+	if x != nil {
+		return x.MaxBuyIn
+	}
+	return 0
+}
+
+type PlayerState struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Chair      uint32                 `protobuf:"varint,2,opt,name=chair,proto3" json:"chair,omitempty"`
+	Nickname   string                 `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Stack      int64                  `protobuf:"varint,4,opt,name=stack,proto3" json:"stack,omitempty"`
+	Bet        int64                  `protobuf:"varint,5,opt,name=bet,proto3" json:"bet,omitempty"`
+	Folded     bool                   `protobuf:"varint,6,opt,name=folded,proto3" json:"folded,omitempty"`
+	AllIn      bool                   `protobuf:"varint,7,opt,name=all_in,json=allIn,proto3" json:"all_in,omitempty"`
+	LastAction ActionType             `protobuf:"varint,8,opt,name=last_action,json=lastAction,proto3,enum=holdem.v1.ActionType" json:"last_action,omitempty"`
+	// hand_cards only sent to the player themselves
+	HandCards []*Card `protobuf:"bytes,9,rep,name=hand_cards,json=handCards,proto3" json:"hand_cards,omitempty"`
+	// true when this player has been dealt hole cards in the current hand.
+	HasCards  bool   `protobuf:"varint,10,opt,name=has_cards,json=hasCards,proto3" json:"has_cards,omitempty"`
+	AvatarKey string `protobuf:"bytes,11,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`
+	// color tag the viewer put on this player; private to the viewer.
+	ColorTag string `protobuf:"bytes,12,opt,name=color_tag,json=colorTag,proto3" json:"color_tag,omitempty"`
+	// chips this player must add to call the current bet; 0 when folded or all-in.
+	ToCall int64 `protobuf:"varint,13,opt,name=to_call,json=toCall,proto3" json:"to_call,omitempty"`
+	// seat kept but dealt out of hands until the player sits back in.
+	SittingOut    bool `protobuf:"varint,14,opt,name=sitting_out,json=sittingOut,proto3" json:"sitting_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerState) Reset() {
+	*x = PlayerState{}
+	mi := &file_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerState) ProtoMessage() {}
+
+func (x *PlayerState) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerState.ProtoReflect.Descriptor instead.
+func (*PlayerState) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PlayerState) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PlayerState) GetChair() uint32 {
+	if x != nil {
+		return x.Chair
+	}
+	return 0
+}
+
+func (x *PlayerState) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *PlayerState) GetStack() int64 {
+	if x != nil {
+		return x.Stack
+	}
+	return 0
+}
+
+func (x *PlayerState) GetBet() int64 {
+	if x != nil {
+		return x.Bet
+	}
+	return 0
+}
+
+func (x *PlayerState) GetFolded() bool {
+	if x != nil {
+		return x.Folded
+	}
+	return false
+}
+
+func (x *PlayerState) GetAllIn() bool {
+	if x != nil {
+		return x.AllIn
+	}
+	return false
+}
+
+func (x *PlayerState) GetLastAction() ActionType {
+	if x != nil {
+		return x.LastAction
+	}
+	return ActionType_ACTION_UNSPECIFIED
+}
+
+func (x *PlayerState) GetHandCards() []*Card {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+func (x *PlayerState) GetHasCards() bool {
+	if x != nil {
+		return x.HasCards
+	}
+	return false
+}
+
+func (x *PlayerState) GetAvatarKey() string {
+	if x != nil {
+		return x.AvatarKey
+	}
+	return ""
+}
+
+func (x *PlayerState) GetColorTag() string {
+	if x != nil {
+		return x.ColorTag
+	}
+	return ""
+}
+
+func (x *PlayerState) GetToCall() int64 {
+	if x != nil {
+		return x.ToCall
+	}
+	return 0
+}
+
+func (x *PlayerState) GetSittingOut() bool {
+	if x != nil {
+		return x.SittingOut
+	}
+	return false
+}
+
+type Pot struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Amount         int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	EligibleChairs []uint32               `protobuf:"varint,2,rep,packed,name=eligible_chairs,json=eligibleChairs,proto3" json:"eligible_chairs,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Pot) Reset() {
+	*x = Pot{}
+	mi := &file_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pot) ProtoMessage() {}
+
+func (x *Pot) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pot.ProtoReflect.Descriptor instead.
+func (*Pot) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Pot) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Pot) GetEligibleChairs() []uint32 {
+	if x != nil {
+		return x.EligibleChairs
+	}
+	return nil
+}
+
+type SeatUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chair uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*SeatUpdate_PlayerJoined
+	//	*SeatUpdate_PlayerLeftUserId
+	//	*SeatUpdate_StackChange
+	Update        isSeatUpdate_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatUpdate) Reset() {
+	*x = SeatUpdate{}
+	mi := &file_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatUpdate) ProtoMessage() {}
+
+func (x *SeatUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatUpdate.ProtoReflect.Descriptor instead.
+func (*SeatUpdate) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SeatUpdate) GetChair() uint32 {
+	if x != nil {
+		return x.Chair
+	}
+	return 0
+}
+
+func (x *SeatUpdate) GetUpdate() isSeatUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *SeatUpdate) GetPlayerJoined() *PlayerState {
+	if x != nil {
+		if x, ok := x.Update.(*SeatUpdate_PlayerJoined); ok {
+			return x.PlayerJoined
+		}
+	}
+	return nil
+}
+
+func (x *SeatUpdate) GetPlayerLeftUserId() uint64 {
+	if x != nil {
+		if x, ok := x.Update.(*SeatUpdate_PlayerLeftUserId); ok {
+			return x.PlayerLeftUserId
+		}
+	}
+	return 0
+}
+
+func (x *SeatUpdate) GetStackChange() int64 {
+	if x != nil {
+		if x, ok := x.Update.(*SeatUpdate_StackChange); ok {
+			return x.StackChange
+		}
+	}
+	return 0
+}
+
+type isSeatUpdate_Update interface {
+	isSeatUpdate_Update()
+}
+
+type SeatUpdate_PlayerJoined struct {
+	PlayerJoined *PlayerState `protobuf:"bytes,2,opt,name=player_joined,json=playerJoined,proto3,oneof"`
+}
+
+type SeatUpdate_PlayerLeftUserId struct {
+	PlayerLeftUserId uint64 `protobuf:"varint,3,opt,name=player_left_user_id,json=playerLeftUserId,proto3,oneof"`
+}
+
+type SeatUpdate_StackChange struct {
+	StackChange int64 `protobuf:"varint,4,opt,name=stack_change,json=stackChange,proto3,oneof"`
+}
+
+func (*SeatUpdate_PlayerJoined) isSeatUpdate_Update() {}
+
+func (*SeatUpdate_PlayerLeftUserId) isSeatUpdate_Update() {}
+
+func (*SeatUpdate_StackChange) isSeatUpdate_Update() {}
+
+// DealerDraw shows the cards dealt face up to place the first button when the
+// table draws for it; the highest card, suits breaking ties, takes the button.
+// It precedes that hand's HandStart.
+type DealerDraw struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*DealerDrawCard      `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	DealerChair   uint32                 `protobuf:"varint,2,opt,name=dealer_chair,json=dealerChair,proto3" json:"dealer_chair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DealerDraw) Reset() {
+	*x = DealerDraw{}
+	mi := &file_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DealerDraw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealerDraw) ProtoMessage() {}
+
+func (x *DealerDraw) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealerDraw.ProtoReflect.Descriptor instead.
+func (*DealerDraw) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DealerDraw) GetCards() []*DealerDrawCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *DealerDraw) GetDealerChair() uint32 {
+	if x != nil {
+		return x.DealerChair
+	}
+	return 0
+}
+
+type DealerDrawCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	Card          *Card                  `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DealerDrawCard) Reset() {
+	*x = DealerDrawCard{}
+	mi := &file_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DealerDrawCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealerDrawCard) ProtoMessage() {}
+
+func (x *DealerDrawCard) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealerDrawCard.ProtoReflect.Descriptor instead.
+func (*DealerDrawCard) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DealerDrawCard) GetChair() uint32 {
+	if x != nil {
+		return x.Chair
+	}
+	return 0
+}
+
+func (x *DealerDrawCard) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type HandStart struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Round            uint32                 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	DealerChair      uint32                 `protobuf:"varint,2,opt,name=dealer_chair,json=dealerChair,proto3" json:"dealer_chair,omitempty"`
+	SmallBlindChair  uint32                 `protobuf:"varint,3,opt,name=small_blind_chair,json=smallBlindChair,proto3" json:"small_blind_chair,omitempty"`
+	BigBlindChair    uint32                 `protobuf:"varint,4,opt,name=big_blind_chair,json=bigBlindChair,proto3" json:"big_blind_chair,omitempty"`
+	SmallBlindAmount int64                  `protobuf:"varint,5,opt,name=small_blind_amount,json=smallBlindAmount,proto3" json:"small_blind_amount,omitempty"`
+	BigBlindAmount   int64                  `protobuf:"varint,6,opt,name=big_blind_amount,json=bigBlindAmount,proto3" json:"big_blind_amount,omitempty"`
+	// hex sha256 of "<hand_id>:<seed>", committing to the deck before any card
+	// is dealt; the seed is revealed in HandEnd. Empty for fixed test decks.
+	SeedCommitment string `protobuf:"bytes,7,opt,name=seed_commitment,json=seedCommitment,proto3" json:"seed_commitment,omitempty"`
+	// Forced money posted before the first action: ante_amount is the ante each
+	// player was asked for, straddle_amount the straddle posted by
+	// straddle_chair (0 when nobody straddled), and forced_total everything in
+	// the pot and in front of players once antes, blinds and straddles are in.
+	AnteAmount     int64  `protobuf:"varint,8,opt,name=ante_amount,json=anteAmount,proto3" json:"ante_amount,omitempty"`
+	StraddleChair  uint32 `protobuf:"varint,9,opt,name=straddle_chair,json=straddleChair,proto3" json:"straddle_chair,omitempty"`
+	StraddleAmount int64  `protobuf:"varint,10,opt,name=straddle_amount,json=straddleAmount,proto3" json:"straddle_amount,omitempty"`
+	ForcedTotal    int64  `protobuf:"varint,11,opt,name=forced_total,json=forcedTotal,proto3" json:"forced_total,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HandStart) Reset() {
+	*x = HandStart{}
+	mi := &file_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandStart) ProtoMessage() {}
+
+func (x *HandStart) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandStart.ProtoReflect.Descriptor instead.
+func (*HandStart) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *HandStart) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *HandStart) GetDealerChair() uint32 {
+	if x != nil {
+		return x.DealerChair
+	}
+	return 0
+}
+
+func (x *HandStart) GetSmallBlindChair() uint32 {
+	if x != nil {
+		return x.SmallBlindChair
+	}
+	return 0
+}
+
+func (x *HandStart) GetBigBlindChair() uint32 {
+	if x != nil {
+		return x.BigBlindChair
+	}
+	return 0
+}
+
+func (x *HandStart) GetSmallBlindAmount() int64 {
+	if x != nil {
+		return x.SmallBlindAmount
+	}
+	return 0
+}
+
+func (x *HandStart) GetBigBlindAmount() int64 {
+	if x != nil {
+		return x.BigBlindAmount
+	}
+	return 0
+}
+
+func (x *HandStart) GetSeedCommitment() string {
+	if x != nil {
+		return x.SeedCommitment
+	}
+	return ""
+}
+
+func (x *HandStart) GetAnteAmount() int64 {
+	if x != nil {
+		return x.AnteAmount
+	}
+	return 0
+}
+
+func (x *HandStart) GetStraddleChair() uint32 {
+	if x != nil {
+		return x.StraddleChair
+	}
+	return 0
+}
+
+func (x *HandStart) GetStraddleAmount() int64 {
+	if x != nil {
+		return x.StraddleAmount
+	}
+	return 0
+}
+
+func (x *HandStart) GetForcedTotal() int64 {
+	if x != nil {
+		return x.ForcedTotal
+	}
+	return 0
+}
+
+type DealHoleCards struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cards are only sent to the receiving player
+	Cards         []*Card `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DealHoleCards) Reset() {
+	*x = DealHoleCards{}
+	mi := &file_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DealHoleCards) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealHoleCards) ProtoMessage() {}
+
+func (x *DealHoleCards) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealHoleCards.ProtoReflect.Descriptor instead.
+func (*DealHoleCards) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DealHoleCards) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type DealBoard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         Phase                  `protobuf:"varint,1,opt,name=phase,proto3,enum=holdem.v1.Phase" json:"phase,omitempty"` // FLOP, TURN, or RIVER
+	Cards         []*Card                `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DealBoard) Reset() {
+	*x = DealBoard{}
+	mi := &file_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DealBoard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealBoard) ProtoMessage() {}
+
+func (x *DealBoard) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealBoard.ProtoReflect.Descriptor instead.
+func (*DealBoard) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DealBoard) GetPhase() Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return Phase_PHASE_UNSPECIFIED
+}
+
+func (x *DealBoard) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+// PhaseChange is a full street-state event for clients that need old-protocol semantics.
+type PhaseChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Current phase after transition.
+	Phase Phase `protobuf:"varint,1,opt,name=phase,proto3,enum=holdem.v1.Phase" json:"phase,omitempty"`
+	// Full board cards visible at this phase.
+	CommunityCards []*Card `protobuf:"bytes,2,rep,name=community_cards,json=communityCards,proto3" json:"community_cards,omitempty"`
+	// Current pot layout (main/side pots).
+	Pots []*Pot `protobuf:"bytes,3,rep,name=pots,proto3" json:"pots,omitempty"`
+	// Only sent to the target player; maps old MyHandType.
+	MyHandRank *HandRank `protobuf:"varint,4,opt,name=my_hand_rank,json=myHandRank,proto3,enum=holdem.v1.HandRank,oneof" json:"my_hand_rank,omitempty"`
+	// Only sent to the target player; maps old MyHandValue.
+	MyHandValue   *uint32 `protobuf:"varint,5,opt,name=my_hand_value,json=myHandValue,proto3,oneof" json:"my_hand_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseChange) Reset() {
+	*x = PhaseChange{}
+	mi := &file_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseChange) ProtoMessage() {}
+
+func (x *PhaseChange) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseChange.ProtoReflect.Descriptor instead.
+func (*PhaseChange) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PhaseChange) GetPhase() Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return Phase_PHASE_UNSPECIFIED
+}
+
+func (x *PhaseChange) GetCommunityCards() []*Card {
+	if x != nil {
+		return x.CommunityCards
+	}
+	return nil
+}
+
+func (x *PhaseChange) GetPots() []*Pot {
+	if x != nil {
+		return x.Pots
+	}
+	return nil
+}
+
+func (x *PhaseChange) GetMyHandRank() HandRank {
+	if x != nil && x.MyHandRank != nil {
+		return *x.MyHandRank
+	}
+	return HandRank_HAND_RANK_UNSPECIFIED
+}
+
+func (x *PhaseChange) GetMyHandValue() uint32 {
+	if x != nil && x.MyHandValue != nil {
+		return *x.MyHandValue
+	}
+	return 0
+}
+
+type ActionPrompt struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Chair        uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	LegalActions []ActionType           `protobuf:"varint,2,rep,packed,name=legal_actions,json=legalActions,proto3,enum=holdem.v1.ActionType" json:"legal_actions,omitempty"`
+	MinRaiseTo   int64                  `protobuf:"varint,3,opt,name=min_raise_to,json=minRaiseTo,proto3" json:"min_raise_to,omitempty"`
+	CallAmount   int64                  `protobuf:"varint,4,opt,name=call_amount,json=callAmount,proto3" json:"call_amount,omitempty"`
+	TimeLimitSec int32                  `protobuf:"varint,5,opt,name=time_limit_sec,json=timeLimitSec,proto3" json:"time_limit_sec,omitempty"`
+	// Absolute server-side deadline timestamp in Unix milliseconds.
+	ActionDeadlineMs int64 `protobuf:"varint,6,opt,name=action_deadline_ms,json=actionDeadlineMs,proto3" json:"action_deadline_ms,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ActionPrompt) Reset() {
+	*x = ActionPrompt{}
+	mi := &file_messages_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SeatUpdate) String() string {
+func (x *ActionPrompt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SeatUpdate) ProtoMessage() {}
+func (*ActionPrompt) ProtoMessage() {}
 
-func (x *SeatUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[17]
+func (x *ActionPrompt) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,101 +3455,79 @@ func (x *SeatUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SeatUpdate.ProtoReflect.Descriptor instead.
-func (*SeatUpdate) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ActionPrompt.ProtoReflect.Descriptor instead.
+func (*ActionPrompt) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *SeatUpdate) GetChair() uint32 {
+func (x *ActionPrompt) GetChair() uint32 {
 	if x != nil {
 		return x.Chair
 	}
 	return 0
 }
 
-func (x *SeatUpdate) GetUpdate() isSeatUpdate_Update {
+func (x *ActionPrompt) GetLegalActions() []ActionType {
 	if x != nil {
-		return x.Update
+		return x.LegalActions
 	}
 	return nil
 }
 
-func (x *SeatUpdate) GetPlayerJoined() *PlayerState {
+func (x *ActionPrompt) GetMinRaiseTo() int64 {
 	if x != nil {
-		if x, ok := x.Update.(*SeatUpdate_PlayerJoined); ok {
-			return x.PlayerJoined
-		}
+		return x.MinRaiseTo
 	}
-	return nil
+	return 0
 }
 
-func (x *SeatUpdate) GetPlayerLeftUserId() uint64 {
+func (x *ActionPrompt) GetCallAmount() int64 {
 	if x != nil {
-		if x, ok := x.Update.(*SeatUpdate_PlayerLeftUserId); ok {
-			return x.PlayerLeftUserId
-		}
+		return x.CallAmount
 	}
 	return 0
 }
 
-func (x *SeatUpdate) GetStackChange() int64 {
+func (x *ActionPrompt) GetTimeLimitSec() int32 {
 	if x != nil {
-		if x, ok := x.Update.(*SeatUpdate_StackChange); ok {
-			return x.StackChange
-		}
+		return x.TimeLimitSec
 	}
 	return 0
 }
 
-type isSeatUpdate_Update interface {
-	isSeatUpdate_Update()
-}
-
-type SeatUpdate_PlayerJoined struct {
-	PlayerJoined *PlayerState `protobuf:"bytes,2,opt,name=player_joined,json=playerJoined,proto3,oneof"`
-}
-
-type SeatUpdate_PlayerLeftUserId struct {
-	PlayerLeftUserId uint64 `protobuf:"varint,3,opt,name=player_left_user_id,json=playerLeftUserId,proto3,oneof"`
-}
-
-type SeatUpdate_StackChange struct {
-	StackChange int64 `protobuf:"varint,4,opt,name=stack_change,json=stackChange,proto3,oneof"`
+func (x *ActionPrompt) GetActionDeadlineMs() int64 {
+	if x != nil {
+		return x.ActionDeadlineMs
+	}
+	return 0
 }
 
-func (*SeatUpdate_PlayerJoined) isSeatUpdate_Update() {}
-
-func (*SeatUpdate_PlayerLeftUserId) isSeatUpdate_Update() {}
-
-func (*SeatUpdate_StackChange) isSeatUpdate_Update() {}
-
-type HandStart struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Round            uint32                 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
-	DealerChair      uint32                 `protobuf:"varint,2,opt,name=dealer_chair,json=dealerChair,proto3" json:"dealer_chair,omitempty"`
-	SmallBlindChair  uint32                 `protobuf:"varint,3,opt,name=small_blind_chair,json=smallBlindChair,proto3" json:"small_blind_chair,omitempty"`
-	BigBlindChair    uint32                 `protobuf:"varint,4,opt,name=big_blind_chair,json=bigBlindChair,proto3" json:"big_blind_chair,omitempty"`
-	SmallBlindAmount int64                  `protobuf:"varint,5,opt,name=small_blind_amount,json=smallBlindAmount,proto3" json:"small_blind_amount,omitempty"`
-	BigBlindAmount   int64                  `protobuf:"varint,6,opt,name=big_blind_amount,json=bigBlindAmount,proto3" json:"big_blind_amount,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type ActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	Action        ActionType             `protobuf:"varint,2,opt,name=action,proto3,enum=holdem.v1.ActionType" json:"action,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	NewStack      int64                  `protobuf:"varint,4,opt,name=new_stack,json=newStack,proto3" json:"new_stack,omitempty"`
+	NewPotTotal   int64                  `protobuf:"varint,5,opt,name=new_pot_total,json=newPotTotal,proto3" json:"new_pot_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HandStart) Reset() {
-	*x = HandStart{}
-	mi := &file_messages_proto_msgTypes[18]
+func (x *ActionResult) Reset() {
+	*x = ActionResult{}
+	mi := &file_messages_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HandStart) String() string {
+func (x *ActionResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HandStart) ProtoMessage() {}
+func (*ActionResult) ProtoMessage() {}
 
-func (x *HandStart) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[18]
+func (x *ActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2035,76 +3538,118 @@ func (x *HandStart) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HandStart.ProtoReflect.Descriptor instead.
-func (*HandStart) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *HandStart) GetRound() uint32 {
+func (x *ActionResult) GetChair() uint32 {
 	if x != nil {
-		return x.Round
+		return x.Chair
 	}
 	return 0
 }
 
-func (x *HandStart) GetDealerChair() uint32 {
+func (x *ActionResult) GetAction() ActionType {
 	if x != nil {
-		return x.DealerChair
+		return x.Action
 	}
-	return 0
+	return ActionType_ACTION_UNSPECIFIED
 }
 
-func (x *HandStart) GetSmallBlindChair() uint32 {
+func (x *ActionResult) GetAmount() int64 {
 	if x != nil {
-		return x.SmallBlindChair
+		return x.Amount
 	}
 	return 0
 }
 
-func (x *HandStart) GetBigBlindChair() uint32 {
+func (x *ActionResult) GetNewStack() int64 {
 	if x != nil {
-		return x.BigBlindChair
+		return x.NewStack
 	}
 	return 0
 }
 
-func (x *HandStart) GetSmallBlindAmount() int64 {
+func (x *ActionResult) GetNewPotTotal() int64 {
 	if x != nil {
-		return x.SmallBlindAmount
+		return x.NewPotTotal
 	}
 	return 0
 }
 
-func (x *HandStart) GetBigBlindAmount() int64 {
+type PotUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pots          []*Pot                 `protobuf:"bytes,1,rep,name=pots,proto3" json:"pots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PotUpdate) Reset() {
+	*x = PotUpdate{}
+	mi := &file_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PotUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PotUpdate) ProtoMessage() {}
+
+func (x *PotUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[38]
 	if x != nil {
-		return x.BigBlindAmount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type DealHoleCards struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Cards are only sent to the receiving player
-	Cards         []*Card `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+// Deprecated: Use PotUpdate.ProtoReflect.Descriptor instead.
+func (*PotUpdate) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PotUpdate) GetPots() []*Pot {
+	if x != nil {
+		return x.Pots
+	}
+	return nil
+}
+
+type Showdown struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Hands        []*ShowdownHand        `protobuf:"bytes,1,rep,name=hands,proto3" json:"hands,omitempty"`
+	PotResults   []*PotResult           `protobuf:"bytes,2,rep,name=pot_results,json=potResults,proto3" json:"pot_results,omitempty"`
+	ExcessRefund *ExcessRefund          `protobuf:"bytes,3,opt,name=excess_refund,json=excessRefund,proto3" json:"excess_refund,omitempty"`
+	NetResults   []*NetResult           `protobuf:"bytes,4,rep,name=net_results,json=netResults,proto3" json:"net_results,omitempty"`
+	// Set when the board was run more than once; pot_results then total
+	// every run.
+	Runs          []*BoardRun `protobuf:"bytes,5,rep,name=runs,proto3" json:"runs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DealHoleCards) Reset() {
-	*x = DealHoleCards{}
-	mi := &file_messages_proto_msgTypes[19]
+func (x *Showdown) Reset() {
+	*x = Showdown{}
+	mi := &file_messages_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DealHoleCards) String() string {
+func (x *Showdown) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DealHoleCards) ProtoMessage() {}
+func (*Showdown) ProtoMessage() {}
 
-func (x *DealHoleCards) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[19]
+func (x *Showdown) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2115,41 +3660,71 @@ func (x *DealHoleCards) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DealHoleCards.ProtoReflect.Descriptor instead.
-func (*DealHoleCards) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use Showdown.ProtoReflect.Descriptor instead.
+func (*Showdown) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *DealHoleCards) GetCards() []*Card {
+func (x *Showdown) GetHands() []*ShowdownHand {
 	if x != nil {
-		return x.Cards
+		return x.Hands
 	}
 	return nil
 }
 
-type DealBoard struct {
+func (x *Showdown) GetPotResults() []*PotResult {
+	if x != nil {
+		return x.PotResults
+	}
+	return nil
+}
+
+func (x *Showdown) GetExcessRefund() *ExcessRefund {
+	if x != nil {
+		return x.ExcessRefund
+	}
+	return nil
+}
+
+func (x *Showdown) GetNetResults() []*NetResult {
+	if x != nil {
+		return x.NetResults
+	}
+	return nil
+}
+
+func (x *Showdown) GetRuns() []*BoardRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+// BoardRun is one board of a multi-run showdown and the share of each pot,
+// after rake, it paid.
+type BoardRun struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Phase         Phase                  `protobuf:"varint,1,opt,name=phase,proto3,enum=holdem.v1.Phase" json:"phase,omitempty"` // FLOP, TURN, or RIVER
-	Cards         []*Card                `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	Board         []*Card                `protobuf:"bytes,1,rep,name=board,proto3" json:"board,omitempty"`
+	PotResults    []*PotResult           `protobuf:"bytes,2,rep,name=pot_results,json=potResults,proto3" json:"pot_results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DealBoard) Reset() {
-	*x = DealBoard{}
-	mi := &file_messages_proto_msgTypes[20]
+func (x *BoardRun) Reset() {
+	*x = BoardRun{}
+	mi := &file_messages_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DealBoard) String() string {
+func (x *BoardRun) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DealBoard) ProtoMessage() {}
+func (*BoardRun) ProtoMessage() {}
 
-func (x *DealBoard) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[20]
+func (x *BoardRun) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2160,57 +3735,52 @@ func (x *DealBoard) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DealBoard.ProtoReflect.Descriptor instead.
-func (*DealBoard) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use BoardRun.ProtoReflect.Descriptor instead.
+func (*BoardRun) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *DealBoard) GetPhase() Phase {
+func (x *BoardRun) GetBoard() []*Card {
 	if x != nil {
-		return x.Phase
+		return x.Board
 	}
-	return Phase_PHASE_UNSPECIFIED
+	return nil
 }
 
-func (x *DealBoard) GetCards() []*Card {
+func (x *BoardRun) GetPotResults() []*PotResult {
 	if x != nil {
-		return x.Cards
+		return x.PotResults
 	}
 	return nil
 }
 
-// PhaseChange is a full street-state event for clients that need old-protocol semantics.
-type PhaseChange struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Current phase after transition.
-	Phase Phase `protobuf:"varint,1,opt,name=phase,proto3,enum=holdem.v1.Phase" json:"phase,omitempty"`
-	// Full board cards visible at this phase.
-	CommunityCards []*Card `protobuf:"bytes,2,rep,name=community_cards,json=communityCards,proto3" json:"community_cards,omitempty"`
-	// Current pot layout (main/side pots).
-	Pots []*Pot `protobuf:"bytes,3,rep,name=pots,proto3" json:"pots,omitempty"`
-	// Only sent to the target player; maps old MyHandType.
-	MyHandRank *HandRank `protobuf:"varint,4,opt,name=my_hand_rank,json=myHandRank,proto3,enum=holdem.v1.HandRank,oneof" json:"my_hand_rank,omitempty"`
-	// Only sent to the target player; maps old MyHandValue.
-	MyHandValue   *uint32 `protobuf:"varint,5,opt,name=my_hand_value,json=myHandValue,proto3,oneof" json:"my_hand_value,omitempty"`
+type ShowdownHand struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Chair     uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	HoleCards []*Card                `protobuf:"bytes,2,rep,name=hole_cards,json=holeCards,proto3" json:"hole_cards,omitempty"`
+	BestFive  []*Card                `protobuf:"bytes,3,rep,name=best_five,json=bestFive,proto3" json:"best_five,omitempty"`
+	Rank      HandRank               `protobuf:"varint,4,opt,name=rank,proto3,enum=holdem.v1.HandRank" json:"rank,omitempty"`
+	// Finishing order among shown hands: 1 = best, tied hands share a rank.
+	ShowdownRank  uint32 `protobuf:"varint,5,opt,name=showdown_rank,json=showdownRank,proto3" json:"showdown_rank,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PhaseChange) Reset() {
-	*x = PhaseChange{}
-	mi := &file_messages_proto_msgTypes[21]
+func (x *ShowdownHand) Reset() {
+	*x = ShowdownHand{}
+	mi := &file_messages_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PhaseChange) String() string {
+func (x *ShowdownHand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PhaseChange) ProtoMessage() {}
+func (*ShowdownHand) ProtoMessage() {}
 
-func (x *PhaseChange) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[21]
+func (x *ShowdownHand) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2221,74 +3791,71 @@ func (x *PhaseChange) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PhaseChange.ProtoReflect.Descriptor instead.
-func (*PhaseChange) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ShowdownHand.ProtoReflect.Descriptor instead.
+func (*ShowdownHand) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *PhaseChange) GetPhase() Phase {
+func (x *ShowdownHand) GetChair() uint32 {
 	if x != nil {
-		return x.Phase
+		return x.Chair
 	}
-	return Phase_PHASE_UNSPECIFIED
+	return 0
 }
 
-func (x *PhaseChange) GetCommunityCards() []*Card {
+func (x *ShowdownHand) GetHoleCards() []*Card {
 	if x != nil {
-		return x.CommunityCards
+		return x.HoleCards
 	}
 	return nil
 }
 
-func (x *PhaseChange) GetPots() []*Pot {
+func (x *ShowdownHand) GetBestFive() []*Card {
 	if x != nil {
-		return x.Pots
+		return x.BestFive
 	}
 	return nil
 }
 
-func (x *PhaseChange) GetMyHandRank() HandRank {
-	if x != nil && x.MyHandRank != nil {
-		return *x.MyHandRank
+func (x *ShowdownHand) GetRank() HandRank {
+	if x != nil {
+		return x.Rank
 	}
 	return HandRank_HAND_RANK_UNSPECIFIED
 }
 
-func (x *PhaseChange) GetMyHandValue() uint32 {
-	if x != nil && x.MyHandValue != nil {
-		return *x.MyHandValue
+func (x *ShowdownHand) GetShowdownRank() uint32 {
+	if x != nil {
+		return x.ShowdownRank
 	}
 	return 0
 }
 
-type ActionPrompt struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	Chair        uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
-	LegalActions []ActionType           `protobuf:"varint,2,rep,packed,name=legal_actions,json=legalActions,proto3,enum=holdem.v1.ActionType" json:"legal_actions,omitempty"`
-	MinRaiseTo   int64                  `protobuf:"varint,3,opt,name=min_raise_to,json=minRaiseTo,proto3" json:"min_raise_to,omitempty"`
-	CallAmount   int64                  `protobuf:"varint,4,opt,name=call_amount,json=callAmount,proto3" json:"call_amount,omitempty"`
-	TimeLimitSec int32                  `protobuf:"varint,5,opt,name=time_limit_sec,json=timeLimitSec,proto3" json:"time_limit_sec,omitempty"`
-	// Absolute server-side deadline timestamp in Unix milliseconds.
-	ActionDeadlineMs int64 `protobuf:"varint,6,opt,name=action_deadline_ms,json=actionDeadlineMs,proto3" json:"action_deadline_ms,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type PotResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PotAmount int64                  `protobuf:"varint,1,opt,name=pot_amount,json=potAmount,proto3" json:"pot_amount,omitempty"`
+	Winners   []*Winner              `protobuf:"bytes,2,rep,name=winners,proto3" json:"winners,omitempty"`
+	// house rake taken from pot_amount before the winners were paid.
+	Rake          int64 `protobuf:"varint,3,opt,name=rake,proto3" json:"rake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ActionPrompt) Reset() {
-	*x = ActionPrompt{}
-	mi := &file_messages_proto_msgTypes[22]
+func (x *PotResult) Reset() {
+	*x = PotResult{}
+	mi := &file_messages_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ActionPrompt) String() string {
+func (x *PotResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionPrompt) ProtoMessage() {}
+func (*PotResult) ProtoMessage() {}
 
-func (x *ActionPrompt) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[22]
+func (x *PotResult) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2299,79 +3866,115 @@ func (x *ActionPrompt) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionPrompt.ProtoReflect.Descriptor instead.
-func (*ActionPrompt) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use PotResult.ProtoReflect.Descriptor instead.
+func (*PotResult) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ActionPrompt) GetChair() uint32 {
+func (x *PotResult) GetPotAmount() int64 {
 	if x != nil {
-		return x.Chair
+		return x.PotAmount
 	}
 	return 0
 }
 
-func (x *ActionPrompt) GetLegalActions() []ActionType {
+func (x *PotResult) GetWinners() []*Winner {
 	if x != nil {
-		return x.LegalActions
+		return x.Winners
 	}
 	return nil
 }
 
-func (x *ActionPrompt) GetMinRaiseTo() int64 {
+func (x *PotResult) GetRake() int64 {
 	if x != nil {
-		return x.MinRaiseTo
+		return x.Rake
 	}
 	return 0
 }
 
-func (x *ActionPrompt) GetCallAmount() int64 {
+type Winner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	WinAmount     int64                  `protobuf:"varint,2,opt,name=win_amount,json=winAmount,proto3" json:"win_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Winner) Reset() {
+	*x = Winner{}
+	mi := &file_messages_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Winner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Winner) ProtoMessage() {}
+
+func (x *Winner) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[43]
 	if x != nil {
-		return x.CallAmount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ActionPrompt) GetTimeLimitSec() int32 {
+// Deprecated: Use Winner.ProtoReflect.Descriptor instead.
+func (*Winner) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Winner) GetChair() uint32 {
 	if x != nil {
-		return x.TimeLimitSec
+		return x.Chair
 	}
 	return 0
 }
 
-func (x *ActionPrompt) GetActionDeadlineMs() int64 {
+func (x *Winner) GetWinAmount() int64 {
 	if x != nil {
-		return x.ActionDeadlineMs
+		return x.WinAmount
 	}
 	return 0
 }
 
-type ActionResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
-	Action        ActionType             `protobuf:"varint,2,opt,name=action,proto3,enum=holdem.v1.ActionType" json:"action,omitempty"`
-	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	NewStack      int64                  `protobuf:"varint,4,opt,name=new_stack,json=newStack,proto3" json:"new_stack,omitempty"`
-	NewPotTotal   int64                  `protobuf:"varint,5,opt,name=new_pot_total,json=newPotTotal,proto3" json:"new_pot_total,omitempty"`
+type HandEnd struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Round uint32                 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// Summary of stack changes
+	StackDeltas  []*StackDelta    `protobuf:"bytes,2,rep,name=stack_deltas,json=stackDeltas,proto3" json:"stack_deltas,omitempty"`
+	ExcessRefund *ExcessRefund    `protobuf:"bytes,3,opt,name=excess_refund,json=excessRefund,proto3" json:"excess_refund,omitempty"`
+	NetResults   []*NetResult     `protobuf:"bytes,4,rep,name=net_results,json=netResults,proto3" json:"net_results,omitempty"`
+	CashOuts     []*CashOutResult `protobuf:"bytes,5,rep,name=cash_outs,json=cashOuts,proto3" json:"cash_outs,omitempty"`
+	// total rake kept by the house this hand.
+	RakeAmount int64 `protobuf:"varint,6,opt,name=rake_amount,json=rakeAmount,proto3" json:"rake_amount,omitempty"`
+	// seed the deck was shuffled from; matches HandStart.seed_commitment.
+	DeckSeed      int64 `protobuf:"varint,7,opt,name=deck_seed,json=deckSeed,proto3" json:"deck_seed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ActionResult) Reset() {
-	*x = ActionResult{}
-	mi := &file_messages_proto_msgTypes[23]
+func (x *HandEnd) Reset() {
+	*x = HandEnd{}
+	mi := &file_messages_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ActionResult) String() string {
+func (x *HandEnd) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionResult) ProtoMessage() {}
+func (*HandEnd) ProtoMessage() {}
 
-func (x *ActionResult) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[23]
+func (x *HandEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2382,115 +3985,87 @@ func (x *ActionResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
-func (*ActionResult) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use HandEnd.ProtoReflect.Descriptor instead.
+func (*HandEnd) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *ActionResult) GetChair() uint32 {
+func (x *HandEnd) GetRound() uint32 {
 	if x != nil {
-		return x.Chair
+		return x.Round
 	}
 	return 0
 }
 
-func (x *ActionResult) GetAction() ActionType {
+func (x *HandEnd) GetStackDeltas() []*StackDelta {
 	if x != nil {
-		return x.Action
+		return x.StackDeltas
 	}
-	return ActionType_ACTION_UNSPECIFIED
+	return nil
 }
 
-func (x *ActionResult) GetAmount() int64 {
+func (x *HandEnd) GetExcessRefund() *ExcessRefund {
 	if x != nil {
-		return x.Amount
+		return x.ExcessRefund
 	}
-	return 0
+	return nil
 }
 
-func (x *ActionResult) GetNewStack() int64 {
+func (x *HandEnd) GetNetResults() []*NetResult {
 	if x != nil {
-		return x.NewStack
+		return x.NetResults
 	}
-	return 0
+	return nil
 }
 
-func (x *ActionResult) GetNewPotTotal() int64 {
+func (x *HandEnd) GetCashOuts() []*CashOutResult {
 	if x != nil {
-		return x.NewPotTotal
+		return x.CashOuts
 	}
-	return 0
-}
-
-type PotUpdate struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pots          []*Pot                 `protobuf:"bytes,1,rep,name=pots,proto3" json:"pots,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PotUpdate) Reset() {
-	*x = PotUpdate{}
-	mi := &file_messages_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *PotUpdate) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return nil
 }
 
-func (*PotUpdate) ProtoMessage() {}
-
-func (x *PotUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[24]
+func (x *HandEnd) GetRakeAmount() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RakeAmount
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PotUpdate.ProtoReflect.Descriptor instead.
-func (*PotUpdate) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{24}
+	return 0
 }
 
-func (x *PotUpdate) GetPots() []*Pot {
+func (x *HandEnd) GetDeckSeed() int64 {
 	if x != nil {
-		return x.Pots
+		return x.DeckSeed
 	}
-	return nil
+	return 0
 }
 
-type Showdown struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hands         []*ShowdownHand        `protobuf:"bytes,1,rep,name=hands,proto3" json:"hands,omitempty"`
-	PotResults    []*PotResult           `protobuf:"bytes,2,rep,name=pot_results,json=potResults,proto3" json:"pot_results,omitempty"`
-	ExcessRefund  *ExcessRefund          `protobuf:"bytes,3,opt,name=excess_refund,json=excessRefund,proto3" json:"excess_refund,omitempty"`
-	NetResults    []*NetResult           `protobuf:"bytes,4,rep,name=net_results,json=netResults,proto3" json:"net_results,omitempty"`
+// An all-in player paid their equity instead of the runout; stack_deltas
+// already include it.
+type CashOutResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Chair  uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	Payout int64                  `protobuf:"varint,2,opt,name=payout,proto3" json:"payout,omitempty"`
+	// what the runout actually paid the player.
+	RunoutAmount  int64 `protobuf:"varint,3,opt,name=runout_amount,json=runoutAmount,proto3" json:"runout_amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Showdown) Reset() {
-	*x = Showdown{}
-	mi := &file_messages_proto_msgTypes[25]
+func (x *CashOutResult) Reset() {
+	*x = CashOutResult{}
+	mi := &file_messages_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Showdown) String() string {
+func (x *CashOutResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Showdown) ProtoMessage() {}
+func (*CashOutResult) ProtoMessage() {}
 
-func (x *Showdown) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[25]
+func (x *CashOutResult) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2501,64 +4076,57 @@ func (x *Showdown) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Showdown.ProtoReflect.Descriptor instead.
-func (*Showdown) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *Showdown) GetHands() []*ShowdownHand {
-	if x != nil {
-		return x.Hands
-	}
-	return nil
+// Deprecated: Use CashOutResult.ProtoReflect.Descriptor instead.
+func (*CashOutResult) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *Showdown) GetPotResults() []*PotResult {
+func (x *CashOutResult) GetChair() uint32 {
 	if x != nil {
-		return x.PotResults
+		return x.Chair
 	}
-	return nil
+	return 0
 }
 
-func (x *Showdown) GetExcessRefund() *ExcessRefund {
+func (x *CashOutResult) GetPayout() int64 {
 	if x != nil {
-		return x.ExcessRefund
+		return x.Payout
 	}
-	return nil
+	return 0
 }
 
-func (x *Showdown) GetNetResults() []*NetResult {
+func (x *CashOutResult) GetRunoutAmount() int64 {
 	if x != nil {
-		return x.NetResults
+		return x.RunoutAmount
 	}
-	return nil
+	return 0
 }
 
-type ShowdownHand struct {
+// Sent when a table reaches its hand limit: everyone is cashed out and the
+// table closes, so clients should return to the lobby.
+type SessionEnd struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
-	HoleCards     []*Card                `protobuf:"bytes,2,rep,name=hole_cards,json=holeCards,proto3" json:"hole_cards,omitempty"`
-	BestFive      []*Card                `protobuf:"bytes,3,rep,name=best_five,json=bestFive,proto3" json:"best_five,omitempty"`
-	Rank          HandRank               `protobuf:"varint,4,opt,name=rank,proto3,enum=holdem.v1.HandRank" json:"rank,omitempty"`
+	HandsPlayed   uint32                 `protobuf:"varint,1,opt,name=hands_played,json=handsPlayed,proto3" json:"hands_played,omitempty"`
+	Stacks        []*SessionStack        `protobuf:"bytes,2,rep,name=stacks,proto3" json:"stacks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShowdownHand) Reset() {
-	*x = ShowdownHand{}
-	mi := &file_messages_proto_msgTypes[26]
+func (x *SessionEnd) Reset() {
+	*x = SessionEnd{}
+	mi := &file_messages_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowdownHand) String() string {
+func (x *SessionEnd) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowdownHand) ProtoMessage() {}
+func (*SessionEnd) ProtoMessage() {}
 
-func (x *ShowdownHand) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[26]
+func (x *SessionEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2569,62 +4137,49 @@ func (x *ShowdownHand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowdownHand.ProtoReflect.Descriptor instead.
-func (*ShowdownHand) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use SessionEnd.ProtoReflect.Descriptor instead.
+func (*SessionEnd) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *ShowdownHand) GetChair() uint32 {
+func (x *SessionEnd) GetHandsPlayed() uint32 {
 	if x != nil {
-		return x.Chair
+		return x.HandsPlayed
 	}
 	return 0
 }
 
-func (x *ShowdownHand) GetHoleCards() []*Card {
-	if x != nil {
-		return x.HoleCards
-	}
-	return nil
-}
-
-func (x *ShowdownHand) GetBestFive() []*Card {
+func (x *SessionEnd) GetStacks() []*SessionStack {
 	if x != nil {
-		return x.BestFive
+		return x.Stacks
 	}
 	return nil
 }
 
-func (x *ShowdownHand) GetRank() HandRank {
-	if x != nil {
-		return x.Rank
-	}
-	return HandRank_HAND_RANK_UNSPECIFIED
-}
-
-type PotResult struct {
+// Recent hands of the requesting user, newest first.
+type HandList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PotAmount     int64                  `protobuf:"varint,1,opt,name=pot_amount,json=potAmount,proto3" json:"pot_amount,omitempty"`
-	Winners       []*Winner              `protobuf:"bytes,2,rep,name=winners,proto3" json:"winners,omitempty"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Items         []*HandListItem        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PotResult) Reset() {
-	*x = PotResult{}
-	mi := &file_messages_proto_msgTypes[27]
+func (x *HandList) Reset() {
+	*x = HandList{}
+	mi := &file_messages_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PotResult) String() string {
+func (x *HandList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PotResult) ProtoMessage() {}
+func (*HandList) ProtoMessage() {}
 
-func (x *PotResult) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[27]
+func (x *HandList) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2635,48 +4190,51 @@ func (x *PotResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PotResult.ProtoReflect.Descriptor instead.
-func (*PotResult) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use HandList.ProtoReflect.Descriptor instead.
+func (*HandList) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *PotResult) GetPotAmount() int64 {
+func (x *HandList) GetSource() string {
 	if x != nil {
-		return x.PotAmount
+		return x.Source
 	}
-	return 0
+	return ""
 }
 
-func (x *PotResult) GetWinners() []*Winner {
+func (x *HandList) GetItems() []*HandListItem {
 	if x != nil {
-		return x.Winners
+		return x.Items
 	}
 	return nil
 }
 
-type Winner struct {
+type HandListItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
-	WinAmount     int64                  `protobuf:"varint,2,opt,name=win_amount,json=winAmount,proto3" json:"win_amount,omitempty"`
+	HandId        string                 `protobuf:"bytes,1,opt,name=hand_id,json=handId,proto3" json:"hand_id,omitempty"`
+	PlayedAtMs    int64                  `protobuf:"varint,2,opt,name=played_at_ms,json=playedAtMs,proto3" json:"played_at_ms,omitempty"`
+	IsSaved       bool                   `protobuf:"varint,3,opt,name=is_saved,json=isSaved,proto3" json:"is_saved,omitempty"`
+	SavedAtMs     int64                  `protobuf:"varint,4,opt,name=saved_at_ms,json=savedAtMs,proto3" json:"saved_at_ms,omitempty"`    // 0 when not saved
+	SummaryJson   string                 `protobuf:"bytes,5,opt,name=summary_json,json=summaryJson,proto3" json:"summary_json,omitempty"` // the stored hand summary as a JSON object
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Winner) Reset() {
-	*x = Winner{}
-	mi := &file_messages_proto_msgTypes[28]
+func (x *HandListItem) Reset() {
+	*x = HandListItem{}
+	mi := &file_messages_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Winner) String() string {
+func (x *HandListItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Winner) ProtoMessage() {}
+func (*HandListItem) ProtoMessage() {}
 
-func (x *Winner) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[28]
+func (x *HandListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2687,51 +4245,71 @@ func (x *Winner) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Winner.ProtoReflect.Descriptor instead.
-func (*Winner) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use HandListItem.ProtoReflect.Descriptor instead.
+func (*HandListItem) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *Winner) GetChair() uint32 {
+func (x *HandListItem) GetHandId() string {
 	if x != nil {
-		return x.Chair
+		return x.HandId
+	}
+	return ""
+}
+
+func (x *HandListItem) GetPlayedAtMs() int64 {
+	if x != nil {
+		return x.PlayedAtMs
 	}
 	return 0
 }
 
-func (x *Winner) GetWinAmount() int64 {
+func (x *HandListItem) GetIsSaved() bool {
 	if x != nil {
-		return x.WinAmount
+		return x.IsSaved
+	}
+	return false
+}
+
+func (x *HandListItem) GetSavedAtMs() int64 {
+	if x != nil {
+		return x.SavedAtMs
 	}
 	return 0
 }
 
-type HandEnd struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Round uint32                 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
-	// Summary of stack changes
-	StackDeltas   []*StackDelta `protobuf:"bytes,2,rep,name=stack_deltas,json=stackDeltas,proto3" json:"stack_deltas,omitempty"`
-	ExcessRefund  *ExcessRefund `protobuf:"bytes,3,opt,name=excess_refund,json=excessRefund,proto3" json:"excess_refund,omitempty"`
-	NetResults    []*NetResult  `protobuf:"bytes,4,rep,name=net_results,json=netResults,proto3" json:"net_results,omitempty"`
+func (x *HandListItem) GetSummaryJson() string {
+	if x != nil {
+		return x.SummaryJson
+	}
+	return ""
+}
+
+type SessionStack struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Chair  uint32                 `protobuf:"varint,2,opt,name=chair,proto3" json:"chair,omitempty"`
+	// chips cashed out at the end of the session.
+	Stack         int64 `protobuf:"varint,3,opt,name=stack,proto3" json:"stack,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HandEnd) Reset() {
-	*x = HandEnd{}
-	mi := &file_messages_proto_msgTypes[29]
+func (x *SessionStack) Reset() {
+	*x = SessionStack{}
+	mi := &file_messages_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HandEnd) String() string {
+func (x *SessionStack) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HandEnd) ProtoMessage() {}
+func (*SessionStack) ProtoMessage() {}
 
-func (x *HandEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[29]
+func (x *SessionStack) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2742,37 +4320,30 @@ func (x *HandEnd) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HandEnd.ProtoReflect.Descriptor instead.
-func (*HandEnd) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use SessionStack.ProtoReflect.Descriptor instead.
+func (*SessionStack) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *HandEnd) GetRound() uint32 {
+func (x *SessionStack) GetUserId() uint64 {
 	if x != nil {
-		return x.Round
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *HandEnd) GetStackDeltas() []*StackDelta {
-	if x != nil {
-		return x.StackDeltas
-	}
-	return nil
-}
-
-func (x *HandEnd) GetExcessRefund() *ExcessRefund {
+func (x *SessionStack) GetChair() uint32 {
 	if x != nil {
-		return x.ExcessRefund
+		return x.Chair
 	}
-	return nil
+	return 0
 }
 
-func (x *HandEnd) GetNetResults() []*NetResult {
+func (x *SessionStack) GetStack() int64 {
 	if x != nil {
-		return x.NetResults
+		return x.Stack
 	}
-	return nil
+	return 0
 }
 
 type StackDelta struct {
@@ -2786,7 +4357,7 @@ type StackDelta struct {
 
 func (x *StackDelta) Reset() {
 	*x = StackDelta{}
-	mi := &file_messages_proto_msgTypes[30]
+	mi := &file_messages_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2798,7 +4369,7 @@ func (x *StackDelta) String() string {
 func (*StackDelta) ProtoMessage() {}
 
 func (x *StackDelta) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[30]
+	mi := &file_messages_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2811,7 +4382,7 @@ func (x *StackDelta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackDelta.ProtoReflect.Descriptor instead.
 func (*StackDelta) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{30}
+	return file_messages_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *StackDelta) GetChair() uint32 {
@@ -2846,7 +4417,7 @@ type WinByFold struct {
 
 func (x *WinByFold) Reset() {
 	*x = WinByFold{}
-	mi := &file_messages_proto_msgTypes[31]
+	mi := &file_messages_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2858,7 +4429,7 @@ func (x *WinByFold) String() string {
 func (*WinByFold) ProtoMessage() {}
 
 func (x *WinByFold) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[31]
+	mi := &file_messages_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2871,7 +4442,7 @@ func (x *WinByFold) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WinByFold.ProtoReflect.Descriptor instead.
 func (*WinByFold) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{31}
+	return file_messages_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *WinByFold) GetWinnerChair() uint32 {
@@ -2895,6 +4466,52 @@ func (x *WinByFold) GetExcessRefund() *ExcessRefund {
 	return nil
 }
 
+// RabbitHunt shows the board cards a fold win left undealt. It is for display
+// only and follows WinByFold when the table enables it.
+type RabbitHunt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RabbitHunt) Reset() {
+	*x = RabbitHunt{}
+	mi := &file_messages_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RabbitHunt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RabbitHunt) ProtoMessage() {}
+
+func (x *RabbitHunt) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RabbitHunt.ProtoReflect.Descriptor instead.
+func (*RabbitHunt) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RabbitHunt) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
 type ExcessRefund struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
@@ -2905,7 +4522,7 @@ type ExcessRefund struct {
 
 func (x *ExcessRefund) Reset() {
 	*x = ExcessRefund{}
-	mi := &file_messages_proto_msgTypes[32]
+	mi := &file_messages_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2917,7 +4534,7 @@ func (x *ExcessRefund) String() string {
 func (*ExcessRefund) ProtoMessage() {}
 
 func (x *ExcessRefund) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[32]
+	mi := &file_messages_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2930,7 +4547,7 @@ func (x *ExcessRefund) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExcessRefund.ProtoReflect.Descriptor instead.
 func (*ExcessRefund) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{32}
+	return file_messages_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *ExcessRefund) GetChair() uint32 {
@@ -2947,6 +4564,117 @@ func (x *ExcessRefund) GetAmount() int64 {
 	return 0
 }
 
+// The part of a bet no one matched, handed back to chair as soon as the
+// betting that left it uncalled closes; it is already in the chair's stack
+// and is not part of the pots or winnings that follow.
+type UncalledReturn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UncalledReturn) Reset() {
+	*x = UncalledReturn{}
+	mi := &file_messages_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncalledReturn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncalledReturn) ProtoMessage() {}
+
+func (x *UncalledReturn) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncalledReturn.ProtoReflect.Descriptor instead.
+func (*UncalledReturn) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UncalledReturn) GetChair() uint32 {
+	if x != nil {
+		return x.Chair
+	}
+	return 0
+}
+
+func (x *UncalledReturn) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Sent to the players of an all-in hand, before the rest of the board is
+// dealt, when the table negotiates running it twice. chairs lists everyone
+// who must accept (see RunItTwiceAcceptRequest); the board runs once if any
+// declines or deadline_ms passes first.
+type RunItTwiceOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chairs        []uint32               `protobuf:"varint,1,rep,packed,name=chairs,proto3" json:"chairs,omitempty"`
+	DeadlineMs    int64                  `protobuf:"varint,2,opt,name=deadline_ms,json=deadlineMs,proto3" json:"deadline_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunItTwiceOffer) Reset() {
+	*x = RunItTwiceOffer{}
+	mi := &file_messages_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunItTwiceOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunItTwiceOffer) ProtoMessage() {}
+
+func (x *RunItTwiceOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunItTwiceOffer.ProtoReflect.Descriptor instead.
+func (*RunItTwiceOffer) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RunItTwiceOffer) GetChairs() []uint32 {
+	if x != nil {
+		return x.Chairs
+	}
+	return nil
+}
+
+func (x *RunItTwiceOffer) GetDeadlineMs() int64 {
+	if x != nil {
+		return x.DeadlineMs
+	}
+	return 0
+}
+
 type NetResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chair         uint32                 `protobuf:"varint,1,opt,name=chair,proto3" json:"chair,omitempty"`
@@ -2958,7 +4686,7 @@ type NetResult struct {
 
 func (x *NetResult) Reset() {
 	*x = NetResult{}
-	mi := &file_messages_proto_msgTypes[33]
+	mi := &file_messages_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2970,7 +4698,7 @@ func (x *NetResult) String() string {
 func (*NetResult) ProtoMessage() {}
 
 func (x *NetResult) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[33]
+	mi := &file_messages_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2983,7 +4711,7 @@ func (x *NetResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetResult.ProtoReflect.Descriptor instead.
 func (*NetResult) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{33}
+	return file_messages_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *NetResult) GetChair() uint32 {
@@ -3017,7 +4745,7 @@ type Card struct {
 
 func (x *Card) Reset() {
 	*x = Card{}
-	mi := &file_messages_proto_msgTypes[34]
+	mi := &file_messages_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3029,7 +4757,7 @@ func (x *Card) String() string {
 func (*Card) ProtoMessage() {}
 
 func (x *Card) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[34]
+	mi := &file_messages_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3042,7 +4770,7 @@ func (x *Card) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Card.ProtoReflect.Descriptor instead.
 func (*Card) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{34}
+	return file_messages_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *Card) GetSuit() Suit {
@@ -3063,7 +4791,7 @@ var File_messages_proto protoreflect.FileDescriptor
 
 const file_messages_proto_rawDesc = "" +
 	"\n" +
-	"\x0emessages.proto\x12\tholdem.v1\"\xc8\x03\n" +
+	"\x0emessages.proto\x12\tholdem.v1\"\xfd\a\n" +
 	"\x0eClientEnvelope\x12\x19\n" +
 	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x10\n" +
@@ -3077,8 +4805,19 @@ const file_messages_proto_rawDesc = "" +
 	"\x06buy_in\x18\r \x01(\v2\x17.holdem.v1.BuyInRequestH\x00R\x05buyIn\x122\n" +
 	"\x06action\x18\x0e \x01(\v2\x18.holdem.v1.ActionRequestH\x00R\x06action\x12?\n" +
 	"\vstart_story\x18\x0f \x01(\v2\x1c.holdem.v1.StartStoryRequestH\x00R\n" +
-	"startStoryB\t\n" +
-	"\apayload\"\xc0\b\n" +
+	"startStory\x128\n" +
+	"\bstraddle\x18\x10 \x01(\v2\x1a.holdem.v1.StraddleRequestH\x00R\bstraddle\x126\n" +
+	"\bcash_out\x18\x11 \x01(\v2\x19.holdem.v1.CashOutRequestH\x00R\acashOut\x123\n" +
+	"\asit_out\x18\x12 \x01(\v2\x18.holdem.v1.SitOutRequestH\x00R\x06sitOut\x120\n" +
+	"\x06sit_in\x18\x13 \x01(\v2\x17.holdem.v1.SitInRequestH\x00R\x05sitIn\x12<\n" +
+	"\n" +
+	"list_hands\x18\x14 \x01(\v2\x1b.holdem.v1.ListHandsRequestH\x00R\tlistHands\x12@\n" +
+	"\frun_it_twice\x18\x15 \x01(\v2\x1c.holdem.v1.RunItTwiceRequestH\x00R\n" +
+	"runItTwice\x12N\n" +
+	"\x10request_snapshot\x18\x16 \x01(\v2!.holdem.v1.RequestSnapshotRequestH\x00R\x0frequestSnapshot\x125\n" +
+	"\arematch\x18\x17 \x01(\v2\x19.holdem.v1.RematchRequestH\x00R\arematch\x12S\n" +
+	"\x13run_it_twice_accept\x18\x18 \x01(\v2\".holdem.v1.RunItTwiceAcceptRequestH\x00R\x10runItTwiceAcceptB\t\n" +
+	"\apayload\"\x82\f\n" +
 	"\x0eServerEnvelope\x12\x19\n" +
 	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x1d\n" +
 	"\n" +
@@ -3105,21 +4844,47 @@ const file_messages_proto_rawDesc = "" +
 	"\vwin_by_fold\x18\x16 \x01(\v2\x14.holdem.v1.WinByFoldH\x00R\twinByFold\x12A\n" +
 	"\x0elogin_response\x18\x17 \x01(\v2\x18.holdem.v1.LoginResponseH\x00R\rloginResponse\x12K\n" +
 	"\x12story_chapter_info\x18\x18 \x01(\v2\x1b.holdem.v1.StoryChapterInfoH\x00R\x10storyChapterInfo\x12F\n" +
-	"\x0estory_progress\x18\x19 \x01(\v2\x1d.holdem.v1.StoryProgressStateH\x00R\rstoryProgressB\t\n" +
+	"\x0estory_progress\x18\x19 \x01(\v2\x1d.holdem.v1.StoryProgressStateH\x00R\rstoryProgress\x128\n" +
+	"\vsession_end\x18\x1a \x01(\v2\x15.holdem.v1.SessionEndH\x00R\n" +
+	"sessionEnd\x122\n" +
+	"\thand_list\x18\x1b \x01(\v2\x13.holdem.v1.HandListH\x00R\bhandList\x128\n" +
+	"\vrabbit_hunt\x18\x1c \x01(\v2\x15.holdem.v1.RabbitHuntH\x00R\n" +
+	"rabbitHunt\x128\n" +
+	"\vdealer_draw\x18\x1d \x01(\v2\x15.holdem.v1.DealerDrawH\x00R\n" +
+	"dealerDraw\x12D\n" +
+	"\x0funcalled_return\x18\x1e \x01(\v2\x19.holdem.v1.UncalledReturnH\x00R\x0euncalledReturn\x12I\n" +
+	"\x12run_it_twice_offer\x18\x1f \x01(\v2\x1a.holdem.v1.RunItTwiceOfferH\x00R\x0frunItTwiceOffer\x12M\n" +
+	"\x12chapter_scoreboard\x18  \x01(\v2\x1c.holdem.v1.ChapterScoreboardH\x00R\x11chapterScoreboardB\t\n" +
 	"\apayload\"M\n" +
 	"\rLoginResponse\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12#\n" +
-	"\rsession_token\x18\x02 \x01(\tR\fsessionToken\"\x12\n" +
-	"\x10JoinTableRequest\"J\n" +
+	"\rsession_token\x18\x02 \x01(\tR\fsessionToken\"B\n" +
+	"\x10JoinTableRequest\x12\x18\n" +
+	"\aobserve\x18\x01 \x01(\bR\aobserve\x12\x14\n" +
+	"\x05stake\x18\x02 \x01(\tR\x05stake\"J\n" +
 	"\x0eSitDownRequest\x12\x14\n" +
 	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\"\n" +
 	"\rbuy_in_amount\x18\x02 \x01(\x03R\vbuyInAmount\"\x10\n" +
 	"\x0eStandUpRequest\"&\n" +
 	"\fBuyInRequest\x12\x16\n" +
-	"\x06amount\x18\x01 \x01(\x03R\x06amount\"V\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\"\x0f\n" +
+	"\rSitOutRequest\"\x0e\n" +
+	"\fSitInRequest\"'\n" +
+	"\x0fStraddleRequest\x12\x14\n" +
+	"\x05chair\x18\x01 \x01(\rR\x05chair\"\x10\n" +
+	"\x0eCashOutRequest\"\x13\n" +
+	"\x11RunItTwiceRequest\"1\n" +
+	"\x17RunItTwiceAcceptRequest\x12\x16\n" +
+	"\x06accept\x18\x01 \x01(\bR\x06accept\"\x18\n" +
+	"\x16RequestSnapshotRequest\"\x10\n" +
+	"\x0eRematchRequest\"@\n" +
+	"\x10ListHandsRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x94\x01\n" +
 	"\rActionRequest\x12-\n" +
 	"\x06action\x18\x01 \x01(\x0e2\x15.holdem.v1.ActionTypeR\x06action\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x03R\x06amount\"2\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12<\n" +
+	"\rsizing_preset\x18\x03 \x01(\x0e2\x17.holdem.v1.SizingPresetR\fsizingPreset\"2\n" +
 	"\x11StartStoryRequest\x12\x1d\n" +
 	"\n" +
 	"chapter_id\x18\x01 \x01(\x05R\tchapterId\"\xd9\x01\n" +
@@ -3148,10 +4913,35 @@ const file_messages_proto_rawDesc = "" +
 	"\x19highest_completed_chapter\x18\x01 \x01(\x05R\x17highestCompletedChapter\x128\n" +
 	"\x18highest_unlocked_chapter\x18\x02 \x01(\x05R\x16highestUnlockedChapter\x12-\n" +
 	"\x12completed_chapters\x18\x03 \x03(\x05R\x11completedChapters\x12+\n" +
-	"\x11unlocked_features\x18\x04 \x03(\tR\x10unlockedFeatures\"=\n" +
+	"\x11unlocked_features\x18\x04 \x03(\tR\x10unlockedFeatures\"\x8a\x02\n" +
+	"\x11ChapterScoreboard\x12\x1d\n" +
+	"\n" +
+	"chapter_id\x18\x01 \x01(\x05R\tchapterId\x12!\n" +
+	"\fhands_played\x18\x02 \x01(\x05R\vhandsPlayed\x12\x1f\n" +
+	"\vstart_stack\x18\x03 \x01(\x03R\n" +
+	"startStack\x128\n" +
+	"\tstandings\x18\x04 \x03(\v2\x1a.holdem.v1.ChapterStandingR\tstandings\x12-\n" +
+	"\x12objective_progress\x18\x05 \x01(\x03R\x11objectiveProgress\x12)\n" +
+	"\x10objective_target\x18\x06 \x01(\x03R\x0fobjectiveTarget\"\xad\x01\n" +
+	"\x0fChapterStanding\x12\x14\n" +
+	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x14\n" +
+	"\x05stack\x18\x03 \x01(\x03R\x05stack\x12\x10\n" +
+	"\x03net\x18\x04 \x01(\x03R\x03net\x12\x12\n" +
+	"\x04rank\x18\x05 \x01(\x05R\x04rank\x12\x17\n" +
+	"\ais_hero\x18\x06 \x01(\bR\x06isHero\x12\x16\n" +
+	"\x06busted\x18\a \x01(\bR\x06busted\"~\n" +
 	"\rErrorResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xe8\x03\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12?\n" +
+	"\x0eaction_options\x18\x03 \x01(\v2\x18.holdem.v1.ActionOptionsR\ractionOptions\"\xb1\x01\n" +
+	"\rActionOptions\x12!\n" +
+	"\faction_chair\x18\x01 \x01(\rR\vactionChair\x12:\n" +
+	"\rlegal_actions\x18\x02 \x03(\x0e2\x15.holdem.v1.ActionTypeR\flegalActions\x12 \n" +
+	"\fmin_raise_to\x18\x03 \x01(\x03R\n" +
+	"minRaiseTo\x12\x1f\n" +
+	"\vcall_amount\x18\x04 \x01(\x03R\n" +
+	"callAmount\"\x91\x04\n" +
 	"\rTableSnapshot\x12.\n" +
 	"\x06config\x18\x01 \x01(\v2\x16.holdem.v1.TableConfigR\x06config\x12&\n" +
 	"\x05phase\x18\x02 \x01(\x0e2\x10.holdem.v1.PhaseR\x05phase\x12\x14\n" +
@@ -3165,7 +4955,8 @@ const file_messages_proto_rawDesc = "" +
 	"\x0fcommunity_cards\x18\n" +
 	" \x03(\v2\x0f.holdem.v1.CardR\x0ecommunityCards\x12\"\n" +
 	"\x04pots\x18\v \x03(\v2\x0e.holdem.v1.PotR\x04pots\x120\n" +
-	"\aplayers\x18\f \x03(\v2\x16.holdem.v1.PlayerStateR\aplayers\"\xbc\x01\n" +
+	"\aplayers\x18\f \x03(\v2\x16.holdem.v1.PlayerStateR\aplayers\x12'\n" +
+	"\x0fspectator_count\x18\r \x01(\rR\x0espectatorCount\"\xbc\x01\n" +
 	"\vTableConfig\x12\x1f\n" +
 	"\vmax_players\x18\x01 \x01(\rR\n" +
 	"maxPlayers\x12\x1f\n" +
@@ -3176,7 +4967,7 @@ const file_messages_proto_rawDesc = "" +
 	"\n" +
 	"min_buy_in\x18\x05 \x01(\x03R\bminBuyIn\x12\x1c\n" +
 	"\n" +
-	"max_buy_in\x18\x06 \x01(\x03R\bmaxBuyIn\"\xd3\x02\n" +
+	"max_buy_in\x18\x06 \x01(\x03R\bmaxBuyIn\"\xaa\x03\n" +
 	"\vPlayerState\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x14\n" +
 	"\x05chair\x18\x02 \x01(\rR\x05chair\x12\x1a\n" +
@@ -3192,7 +4983,11 @@ const file_messages_proto_rawDesc = "" +
 	"\thas_cards\x18\n" +
 	" \x01(\bR\bhasCards\x12\x1d\n" +
 	"\n" +
-	"avatar_key\x18\v \x01(\tR\tavatarKey\"F\n" +
+	"avatar_key\x18\v \x01(\tR\tavatarKey\x12\x1b\n" +
+	"\tcolor_tag\x18\f \x01(\tR\bcolorTag\x12\x17\n" +
+	"\ato_call\x18\r \x01(\x03R\x06toCall\x12\x1f\n" +
+	"\vsitting_out\x18\x0e \x01(\bR\n" +
+	"sittingOut\"F\n" +
 	"\x03Pot\x12\x16\n" +
 	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12'\n" +
 	"\x0feligible_chairs\x18\x02 \x03(\rR\x0eeligibleChairs\"\xc1\x01\n" +
@@ -3202,14 +4997,28 @@ const file_messages_proto_rawDesc = "" +
 	"\rplayer_joined\x18\x02 \x01(\v2\x16.holdem.v1.PlayerStateH\x00R\fplayerJoined\x12/\n" +
 	"\x13player_left_user_id\x18\x03 \x01(\x04H\x00R\x10playerLeftUserId\x12#\n" +
 	"\fstack_change\x18\x04 \x01(\x03H\x00R\vstackChangeB\b\n" +
-	"\x06update\"\xf0\x01\n" +
+	"\x06update\"`\n" +
+	"\n" +
+	"DealerDraw\x12/\n" +
+	"\x05cards\x18\x01 \x03(\v2\x19.holdem.v1.DealerDrawCardR\x05cards\x12!\n" +
+	"\fdealer_chair\x18\x02 \x01(\rR\vdealerChair\"K\n" +
+	"\x0eDealerDrawCard\x12\x14\n" +
+	"\x05chair\x18\x01 \x01(\rR\x05chair\x12#\n" +
+	"\x04card\x18\x02 \x01(\v2\x0f.holdem.v1.CardR\x04card\"\xad\x03\n" +
 	"\tHandStart\x12\x14\n" +
 	"\x05round\x18\x01 \x01(\rR\x05round\x12!\n" +
 	"\fdealer_chair\x18\x02 \x01(\rR\vdealerChair\x12*\n" +
 	"\x11small_blind_chair\x18\x03 \x01(\rR\x0fsmallBlindChair\x12&\n" +
 	"\x0fbig_blind_chair\x18\x04 \x01(\rR\rbigBlindChair\x12,\n" +
 	"\x12small_blind_amount\x18\x05 \x01(\x03R\x10smallBlindAmount\x12(\n" +
-	"\x10big_blind_amount\x18\x06 \x01(\x03R\x0ebigBlindAmount\"6\n" +
+	"\x10big_blind_amount\x18\x06 \x01(\x03R\x0ebigBlindAmount\x12'\n" +
+	"\x0fseed_commitment\x18\a \x01(\tR\x0eseedCommitment\x12\x1f\n" +
+	"\vante_amount\x18\b \x01(\x03R\n" +
+	"anteAmount\x12%\n" +
+	"\x0estraddle_chair\x18\t \x01(\rR\rstraddleChair\x12'\n" +
+	"\x0fstraddle_amount\x18\n" +
+	" \x01(\x03R\x0estraddleAmount\x12!\n" +
+	"\fforced_total\x18\v \x01(\x03R\vforcedTotal\"6\n" +
 	"\rDealHoleCards\x12%\n" +
 	"\x05cards\x18\x01 \x03(\v2\x0f.holdem.v1.CardR\x05cards\"Z\n" +
 	"\tDealBoard\x12&\n" +
@@ -3240,34 +5049,67 @@ const file_messages_proto_rawDesc = "" +
 	"\tnew_stack\x18\x04 \x01(\x03R\bnewStack\x12\"\n" +
 	"\rnew_pot_total\x18\x05 \x01(\x03R\vnewPotTotal\"/\n" +
 	"\tPotUpdate\x12\"\n" +
-	"\x04pots\x18\x01 \x03(\v2\x0e.holdem.v1.PotR\x04pots\"\xe5\x01\n" +
+	"\x04pots\x18\x01 \x03(\v2\x0e.holdem.v1.PotR\x04pots\"\x8e\x02\n" +
 	"\bShowdown\x12-\n" +
 	"\x05hands\x18\x01 \x03(\v2\x17.holdem.v1.ShowdownHandR\x05hands\x125\n" +
 	"\vpot_results\x18\x02 \x03(\v2\x14.holdem.v1.PotResultR\n" +
 	"potResults\x12<\n" +
 	"\rexcess_refund\x18\x03 \x01(\v2\x17.holdem.v1.ExcessRefundR\fexcessRefund\x125\n" +
 	"\vnet_results\x18\x04 \x03(\v2\x14.holdem.v1.NetResultR\n" +
-	"netResults\"\xab\x01\n" +
+	"netResults\x12'\n" +
+	"\x04runs\x18\x05 \x03(\v2\x13.holdem.v1.BoardRunR\x04runs\"h\n" +
+	"\bBoardRun\x12%\n" +
+	"\x05board\x18\x01 \x03(\v2\x0f.holdem.v1.CardR\x05board\x125\n" +
+	"\vpot_results\x18\x02 \x03(\v2\x14.holdem.v1.PotResultR\n" +
+	"potResults\"\xd0\x01\n" +
 	"\fShowdownHand\x12\x14\n" +
 	"\x05chair\x18\x01 \x01(\rR\x05chair\x12.\n" +
 	"\n" +
 	"hole_cards\x18\x02 \x03(\v2\x0f.holdem.v1.CardR\tholeCards\x12,\n" +
 	"\tbest_five\x18\x03 \x03(\v2\x0f.holdem.v1.CardR\bbestFive\x12'\n" +
-	"\x04rank\x18\x04 \x01(\x0e2\x13.holdem.v1.HandRankR\x04rank\"W\n" +
+	"\x04rank\x18\x04 \x01(\x0e2\x13.holdem.v1.HandRankR\x04rank\x12#\n" +
+	"\rshowdown_rank\x18\x05 \x01(\rR\fshowdownRank\"k\n" +
 	"\tPotResult\x12\x1d\n" +
 	"\n" +
 	"pot_amount\x18\x01 \x01(\x03R\tpotAmount\x12+\n" +
-	"\awinners\x18\x02 \x03(\v2\x11.holdem.v1.WinnerR\awinners\"=\n" +
+	"\awinners\x18\x02 \x03(\v2\x11.holdem.v1.WinnerR\awinners\x12\x12\n" +
+	"\x04rake\x18\x03 \x01(\x03R\x04rake\"=\n" +
 	"\x06Winner\x12\x14\n" +
 	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x1d\n" +
 	"\n" +
-	"win_amount\x18\x02 \x01(\x03R\twinAmount\"\xce\x01\n" +
+	"win_amount\x18\x02 \x01(\x03R\twinAmount\"\xc3\x02\n" +
 	"\aHandEnd\x12\x14\n" +
 	"\x05round\x18\x01 \x01(\rR\x05round\x128\n" +
 	"\fstack_deltas\x18\x02 \x03(\v2\x15.holdem.v1.StackDeltaR\vstackDeltas\x12<\n" +
 	"\rexcess_refund\x18\x03 \x01(\v2\x17.holdem.v1.ExcessRefundR\fexcessRefund\x125\n" +
 	"\vnet_results\x18\x04 \x03(\v2\x14.holdem.v1.NetResultR\n" +
-	"netResults\"U\n" +
+	"netResults\x125\n" +
+	"\tcash_outs\x18\x05 \x03(\v2\x18.holdem.v1.CashOutResultR\bcashOuts\x12\x1f\n" +
+	"\vrake_amount\x18\x06 \x01(\x03R\n" +
+	"rakeAmount\x12\x1b\n" +
+	"\tdeck_seed\x18\a \x01(\x03R\bdeckSeed\"b\n" +
+	"\rCashOutResult\x12\x14\n" +
+	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x16\n" +
+	"\x06payout\x18\x02 \x01(\x03R\x06payout\x12#\n" +
+	"\rrunout_amount\x18\x03 \x01(\x03R\frunoutAmount\"`\n" +
+	"\n" +
+	"SessionEnd\x12!\n" +
+	"\fhands_played\x18\x01 \x01(\rR\vhandsPlayed\x12/\n" +
+	"\x06stacks\x18\x02 \x03(\v2\x17.holdem.v1.SessionStackR\x06stacks\"Q\n" +
+	"\bHandList\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12-\n" +
+	"\x05items\x18\x02 \x03(\v2\x17.holdem.v1.HandListItemR\x05items\"\xa7\x01\n" +
+	"\fHandListItem\x12\x17\n" +
+	"\ahand_id\x18\x01 \x01(\tR\x06handId\x12 \n" +
+	"\fplayed_at_ms\x18\x02 \x01(\x03R\n" +
+	"playedAtMs\x12\x19\n" +
+	"\bis_saved\x18\x03 \x01(\bR\aisSaved\x12\x1e\n" +
+	"\vsaved_at_ms\x18\x04 \x01(\x03R\tsavedAtMs\x12!\n" +
+	"\fsummary_json\x18\x05 \x01(\tR\vsummaryJson\"S\n" +
+	"\fSessionStack\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x14\n" +
+	"\x05chair\x18\x02 \x01(\rR\x05chair\x12\x14\n" +
+	"\x05stack\x18\x03 \x01(\x03R\x05stack\"U\n" +
 	"\n" +
 	"StackDelta\x12\x14\n" +
 	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x14\n" +
@@ -3276,10 +5118,20 @@ const file_messages_proto_rawDesc = "" +
 	"\tWinByFold\x12!\n" +
 	"\fwinner_chair\x18\x01 \x01(\rR\vwinnerChair\x12\x1b\n" +
 	"\tpot_total\x18\x02 \x01(\x03R\bpotTotal\x12<\n" +
-	"\rexcess_refund\x18\x03 \x01(\v2\x17.holdem.v1.ExcessRefundR\fexcessRefund\"<\n" +
+	"\rexcess_refund\x18\x03 \x01(\v2\x17.holdem.v1.ExcessRefundR\fexcessRefund\"3\n" +
+	"\n" +
+	"RabbitHunt\x12%\n" +
+	"\x05cards\x18\x01 \x03(\v2\x0f.holdem.v1.CardR\x05cards\"<\n" +
 	"\fExcessRefund\x12\x14\n" +
 	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x03R\x06amount\"]\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\">\n" +
+	"\x0eUncalledReturn\x12\x14\n" +
+	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"J\n" +
+	"\x0fRunItTwiceOffer\x12\x16\n" +
+	"\x06chairs\x18\x01 \x03(\rR\x06chairs\x12\x1f\n" +
+	"\vdeadline_ms\x18\x02 \x01(\x03R\n" +
+	"deadlineMs\"]\n" +
 	"\tNetResult\x12\x14\n" +
 	"\x05chair\x18\x01 \x01(\rR\x05chair\x12\x1d\n" +
 	"\n" +
@@ -3321,7 +5173,12 @@ const file_messages_proto_rawDesc = "" +
 	"\x16HAND_RANK_FOUR_OF_KIND\x10\b\x12\x1c\n" +
 	"\x18HAND_RANK_STRAIGHT_FLUSH\x10\t\x12\x19\n" +
 	"\x15HAND_RANK_ROYAL_FLUSH\x10\n" +
-	"*]\n" +
+	"*\x85\x01\n" +
+	"\fSizingPreset\x12\x1d\n" +
+	"\x19SIZING_PRESET_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16SIZING_PRESET_HALF_POT\x10\x01\x12#\n" +
+	"\x1fSIZING_PRESET_THREE_QUARTER_POT\x10\x02\x12\x15\n" +
+	"\x11SIZING_PRESET_POT\x10\x03*]\n" +
 	"\x04Suit\x12\x14\n" +
 	"\x10SUIT_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
@@ -3373,112 +5230,165 @@ func file_messages_proto_rawDescGZIP() []byte {
 	return file_messages_proto_rawDescData
 }
 
-var file_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_messages_proto_goTypes = []any{
-	(Phase)(0),                 // 0: holdem.v1.Phase
-	(ActionType)(0),            // 1: holdem.v1.ActionType
-	(HandRank)(0),              // 2: holdem.v1.HandRank
-	(Suit)(0),                  // 3: holdem.v1.Suit
-	(Rank)(0),                  // 4: holdem.v1.Rank
-	(*ClientEnvelope)(nil),     // 5: holdem.v1.ClientEnvelope
-	(*ServerEnvelope)(nil),     // 6: holdem.v1.ServerEnvelope
-	(*LoginResponse)(nil),      // 7: holdem.v1.LoginResponse
-	(*JoinTableRequest)(nil),   // 8: holdem.v1.JoinTableRequest
-	(*SitDownRequest)(nil),     // 9: holdem.v1.SitDownRequest
-	(*StandUpRequest)(nil),     // 10: holdem.v1.StandUpRequest
-	(*BuyInRequest)(nil),       // 11: holdem.v1.BuyInRequest
-	(*ActionRequest)(nil),      // 12: holdem.v1.ActionRequest
-	(*StartStoryRequest)(nil),  // 13: holdem.v1.StartStoryRequest
-	(*StoryNpcInfo)(nil),       // 14: holdem.v1.StoryNpcInfo
-	(*StoryChapterInfo)(nil),   // 15: holdem.v1.StoryChapterInfo
-	(*StoryProgressState)(nil), // 16: holdem.v1.StoryProgressState
-	(*ErrorResponse)(nil),      // 17: holdem.v1.ErrorResponse
-	(*TableSnapshot)(nil),      // 18: holdem.v1.TableSnapshot
-	(*TableConfig)(nil),        // 19: holdem.v1.TableConfig
-	(*PlayerState)(nil),        // 20: holdem.v1.PlayerState
-	(*Pot)(nil),                // 21: holdem.v1.Pot
-	(*SeatUpdate)(nil),         // 22: holdem.v1.SeatUpdate
-	(*HandStart)(nil),          // 23: holdem.v1.HandStart
-	(*DealHoleCards)(nil),      // 24: holdem.v1.DealHoleCards
-	(*DealBoard)(nil),          // 25: holdem.v1.DealBoard
-	(*PhaseChange)(nil),        // 26: holdem.v1.PhaseChange
-	(*ActionPrompt)(nil),       // 27: holdem.v1.ActionPrompt
-	(*ActionResult)(nil),       // 28: holdem.v1.ActionResult
-	(*PotUpdate)(nil),          // 29: holdem.v1.PotUpdate
-	(*Showdown)(nil),           // 30: holdem.v1.Showdown
-	(*ShowdownHand)(nil),       // 31: holdem.v1.ShowdownHand
-	(*PotResult)(nil),          // 32: holdem.v1.PotResult
-	(*Winner)(nil),             // 33: holdem.v1.Winner
-	(*HandEnd)(nil),            // 34: holdem.v1.HandEnd
-	(*StackDelta)(nil),         // 35: holdem.v1.StackDelta
-	(*WinByFold)(nil),          // 36: holdem.v1.WinByFold
-	(*ExcessRefund)(nil),       // 37: holdem.v1.ExcessRefund
-	(*NetResult)(nil),          // 38: holdem.v1.NetResult
-	(*Card)(nil),               // 39: holdem.v1.Card
+	(Phase)(0),                      // 0: holdem.v1.Phase
+	(ActionType)(0),                 // 1: holdem.v1.ActionType
+	(HandRank)(0),                   // 2: holdem.v1.HandRank
+	(SizingPreset)(0),               // 3: holdem.v1.SizingPreset
+	(Suit)(0),                       // 4: holdem.v1.Suit
+	(Rank)(0),                       // 5: holdem.v1.Rank
+	(*ClientEnvelope)(nil),          // 6: holdem.v1.ClientEnvelope
+	(*ServerEnvelope)(nil),          // 7: holdem.v1.ServerEnvelope
+	(*LoginResponse)(nil),           // 8: holdem.v1.LoginResponse
+	(*JoinTableRequest)(nil),        // 9: holdem.v1.JoinTableRequest
+	(*SitDownRequest)(nil),          // 10: holdem.v1.SitDownRequest
+	(*StandUpRequest)(nil),          // 11: holdem.v1.StandUpRequest
+	(*BuyInRequest)(nil),            // 12: holdem.v1.BuyInRequest
+	(*SitOutRequest)(nil),           // 13: holdem.v1.SitOutRequest
+	(*SitInRequest)(nil),            // 14: holdem.v1.SitInRequest
+	(*StraddleRequest)(nil),         // 15: holdem.v1.StraddleRequest
+	(*CashOutRequest)(nil),          // 16: holdem.v1.CashOutRequest
+	(*RunItTwiceRequest)(nil),       // 17: holdem.v1.RunItTwiceRequest
+	(*RunItTwiceAcceptRequest)(nil), // 18: holdem.v1.RunItTwiceAcceptRequest
+	(*RequestSnapshotRequest)(nil),  // 19: holdem.v1.RequestSnapshotRequest
+	(*RematchRequest)(nil),          // 20: holdem.v1.RematchRequest
+	(*ListHandsRequest)(nil),        // 21: holdem.v1.ListHandsRequest
+	(*ActionRequest)(nil),           // 22: holdem.v1.ActionRequest
+	(*StartStoryRequest)(nil),       // 23: holdem.v1.StartStoryRequest
+	(*StoryNpcInfo)(nil),            // 24: holdem.v1.StoryNpcInfo
+	(*StoryChapterInfo)(nil),        // 25: holdem.v1.StoryChapterInfo
+	(*StoryProgressState)(nil),      // 26: holdem.v1.StoryProgressState
+	(*ChapterScoreboard)(nil),       // 27: holdem.v1.ChapterScoreboard
+	(*ChapterStanding)(nil),         // 28: holdem.v1.ChapterStanding
+	(*ErrorResponse)(nil),           // 29: holdem.v1.ErrorResponse
+	(*ActionOptions)(nil),           // 30: holdem.v1.ActionOptions
+	(*TableSnapshot)(nil),           // 31: holdem.v1.TableSnapshot
+	(*TableConfig)(nil),             // 32: holdem.v1.TableConfig
+	(*PlayerState)(nil),             // 33: holdem.v1.PlayerState
+	(*Pot)(nil),                     // 34: holdem.v1.Pot
+	(*SeatUpdate)(nil),              // 35: holdem.v1.SeatUpdate
+	(*DealerDraw)(nil),              // 36: holdem.v1.DealerDraw
+	(*DealerDrawCard)(nil),          // 37: holdem.v1.DealerDrawCard
+	(*HandStart)(nil),               // 38: holdem.v1.HandStart
+	(*DealHoleCards)(nil),           // 39: holdem.v1.DealHoleCards
+	(*DealBoard)(nil),               // 40: holdem.v1.DealBoard
+	(*PhaseChange)(nil),             // 41: holdem.v1.PhaseChange
+	(*ActionPrompt)(nil),            // 42: holdem.v1.ActionPrompt
+	(*ActionResult)(nil),            // 43: holdem.v1.ActionResult
+	(*PotUpdate)(nil),               // 44: holdem.v1.PotUpdate
+	(*Showdown)(nil),                // 45: holdem.v1.Showdown
+	(*BoardRun)(nil),                // 46: holdem.v1.BoardRun
+	(*ShowdownHand)(nil),            // 47: holdem.v1.ShowdownHand
+	(*PotResult)(nil),               // 48: holdem.v1.PotResult
+	(*Winner)(nil),                  // 49: holdem.v1.Winner
+	(*HandEnd)(nil),                 // 50: holdem.v1.HandEnd
+	(*CashOutResult)(nil),           // 51: holdem.v1.CashOutResult
+	(*SessionEnd)(nil),              // 52: holdem.v1.SessionEnd
+	(*HandList)(nil),                // 53: holdem.v1.HandList
+	(*HandListItem)(nil),            // 54: holdem.v1.HandListItem
+	(*SessionStack)(nil),            // 55: holdem.v1.SessionStack
+	(*StackDelta)(nil),              // 56: holdem.v1.StackDelta
+	(*WinByFold)(nil),               // 57: holdem.v1.WinByFold
+	(*RabbitHunt)(nil),              // 58: holdem.v1.RabbitHunt
+	(*ExcessRefund)(nil),            // 59: holdem.v1.ExcessRefund
+	(*UncalledReturn)(nil),          // 60: holdem.v1.UncalledReturn
+	(*RunItTwiceOffer)(nil),         // 61: holdem.v1.RunItTwiceOffer
+	(*NetResult)(nil),               // 62: holdem.v1.NetResult
+	(*Card)(nil),                    // 63: holdem.v1.Card
 }
 var file_messages_proto_depIdxs = []int32{
-	8,  // 0: holdem.v1.ClientEnvelope.join_table:type_name -> holdem.v1.JoinTableRequest
-	9,  // 1: holdem.v1.ClientEnvelope.sit_down:type_name -> holdem.v1.SitDownRequest
-	10, // 2: holdem.v1.ClientEnvelope.stand_up:type_name -> holdem.v1.StandUpRequest
-	11, // 3: holdem.v1.ClientEnvelope.buy_in:type_name -> holdem.v1.BuyInRequest
-	12, // 4: holdem.v1.ClientEnvelope.action:type_name -> holdem.v1.ActionRequest
-	13, // 5: holdem.v1.ClientEnvelope.start_story:type_name -> holdem.v1.StartStoryRequest
-	17, // 6: holdem.v1.ServerEnvelope.error:type_name -> holdem.v1.ErrorResponse
-	18, // 7: holdem.v1.ServerEnvelope.table_snapshot:type_name -> holdem.v1.TableSnapshot
-	22, // 8: holdem.v1.ServerEnvelope.seat_update:type_name -> holdem.v1.SeatUpdate
-	23, // 9: holdem.v1.ServerEnvelope.hand_start:type_name -> holdem.v1.HandStart
-	24, // 10: holdem.v1.ServerEnvelope.deal_hole_cards:type_name -> holdem.v1.DealHoleCards
-	25, // 11: holdem.v1.ServerEnvelope.deal_board:type_name -> holdem.v1.DealBoard
-	27, // 12: holdem.v1.ServerEnvelope.action_prompt:type_name -> holdem.v1.ActionPrompt
-	28, // 13: holdem.v1.ServerEnvelope.action_result:type_name -> holdem.v1.ActionResult
-	29, // 14: holdem.v1.ServerEnvelope.pot_update:type_name -> holdem.v1.PotUpdate
-	30, // 15: holdem.v1.ServerEnvelope.showdown:type_name -> holdem.v1.Showdown
-	34, // 16: holdem.v1.ServerEnvelope.hand_end:type_name -> holdem.v1.HandEnd
-	26, // 17: holdem.v1.ServerEnvelope.phase_change:type_name -> holdem.v1.PhaseChange
-	36, // 18: holdem.v1.ServerEnvelope.win_by_fold:type_name -> holdem.v1.WinByFold
-	7,  // 19: holdem.v1.ServerEnvelope.login_response:type_name -> holdem.v1.LoginResponse
-	15, // 20: holdem.v1.ServerEnvelope.story_chapter_info:type_name -> holdem.v1.StoryChapterInfo
-	16, // 21: holdem.v1.ServerEnvelope.story_progress:type_name -> holdem.v1.StoryProgressState
-	1,  // 22: holdem.v1.ActionRequest.action:type_name -> holdem.v1.ActionType
-	14, // 23: holdem.v1.StoryChapterInfo.npc_roster:type_name -> holdem.v1.StoryNpcInfo
-	19, // 24: holdem.v1.TableSnapshot.config:type_name -> holdem.v1.TableConfig
-	0,  // 25: holdem.v1.TableSnapshot.phase:type_name -> holdem.v1.Phase
-	39, // 26: holdem.v1.TableSnapshot.community_cards:type_name -> holdem.v1.Card
-	21, // 27: holdem.v1.TableSnapshot.pots:type_name -> holdem.v1.Pot
-	20, // 28: holdem.v1.TableSnapshot.players:type_name -> holdem.v1.PlayerState
-	1,  // 29: holdem.v1.PlayerState.last_action:type_name -> holdem.v1.ActionType
-	39, // 30: holdem.v1.PlayerState.hand_cards:type_name -> holdem.v1.Card
-	20, // 31: holdem.v1.SeatUpdate.player_joined:type_name -> holdem.v1.PlayerState
-	39, // 32: holdem.v1.DealHoleCards.cards:type_name -> holdem.v1.Card
-	0,  // 33: holdem.v1.DealBoard.phase:type_name -> holdem.v1.Phase
-	39, // 34: holdem.v1.DealBoard.cards:type_name -> holdem.v1.Card
-	0,  // 35: holdem.v1.PhaseChange.phase:type_name -> holdem.v1.Phase
-	39, // 36: holdem.v1.PhaseChange.community_cards:type_name -> holdem.v1.Card
-	21, // 37: holdem.v1.PhaseChange.pots:type_name -> holdem.v1.Pot
-	2,  // 38: holdem.v1.PhaseChange.my_hand_rank:type_name -> holdem.v1.HandRank
-	1,  // 39: holdem.v1.ActionPrompt.legal_actions:type_name -> holdem.v1.ActionType
-	1,  // 40: holdem.v1.ActionResult.action:type_name -> holdem.v1.ActionType
-	21, // 41: holdem.v1.PotUpdate.pots:type_name -> holdem.v1.Pot
-	31, // 42: holdem.v1.Showdown.hands:type_name -> holdem.v1.ShowdownHand
-	32, // 43: holdem.v1.Showdown.pot_results:type_name -> holdem.v1.PotResult
-	37, // 44: holdem.v1.Showdown.excess_refund:type_name -> holdem.v1.ExcessRefund
-	38, // 45: holdem.v1.Showdown.net_results:type_name -> holdem.v1.NetResult
-	39, // 46: holdem.v1.ShowdownHand.hole_cards:type_name -> holdem.v1.Card
-	39, // 47: holdem.v1.ShowdownHand.best_five:type_name -> holdem.v1.Card
-	2,  // 48: holdem.v1.ShowdownHand.rank:type_name -> holdem.v1.HandRank
-	33, // 49: holdem.v1.PotResult.winners:type_name -> holdem.v1.Winner
-	35, // 50: holdem.v1.HandEnd.stack_deltas:type_name -> holdem.v1.StackDelta
-	37, // 51: holdem.v1.HandEnd.excess_refund:type_name -> holdem.v1.ExcessRefund
-	38, // 52: holdem.v1.HandEnd.net_results:type_name -> holdem.v1.NetResult
-	37, // 53: holdem.v1.WinByFold.excess_refund:type_name -> holdem.v1.ExcessRefund
-	3,  // 54: holdem.v1.Card.suit:type_name -> holdem.v1.Suit
-	4,  // 55: holdem.v1.Card.rank:type_name -> holdem.v1.Rank
-	56, // [56:56] is the sub-list for method output_type
-	56, // [56:56] is the sub-list for method input_type
-	56, // [56:56] is the sub-list for extension type_name
-	56, // [56:56] is the sub-list for extension extendee
-	0,  // [0:56] is the sub-list for field type_name
+	9,  // 0: holdem.v1.ClientEnvelope.join_table:type_name -> holdem.v1.JoinTableRequest
+	10, // 1: holdem.v1.ClientEnvelope.sit_down:type_name -> holdem.v1.SitDownRequest
+	11, // 2: holdem.v1.ClientEnvelope.stand_up:type_name -> holdem.v1.StandUpRequest
+	12, // 3: holdem.v1.ClientEnvelope.buy_in:type_name -> holdem.v1.BuyInRequest
+	22, // 4: holdem.v1.ClientEnvelope.action:type_name -> holdem.v1.ActionRequest
+	23, // 5: holdem.v1.ClientEnvelope.start_story:type_name -> holdem.v1.StartStoryRequest
+	15, // 6: holdem.v1.ClientEnvelope.straddle:type_name -> holdem.v1.StraddleRequest
+	16, // 7: holdem.v1.ClientEnvelope.cash_out:type_name -> holdem.v1.CashOutRequest
+	13, // 8: holdem.v1.ClientEnvelope.sit_out:type_name -> holdem.v1.SitOutRequest
+	14, // 9: holdem.v1.ClientEnvelope.sit_in:type_name -> holdem.v1.SitInRequest
+	21, // 10: holdem.v1.ClientEnvelope.list_hands:type_name -> holdem.v1.ListHandsRequest
+	17, // 11: holdem.v1.ClientEnvelope.run_it_twice:type_name -> holdem.v1.RunItTwiceRequest
+	19, // 12: holdem.v1.ClientEnvelope.request_snapshot:type_name -> holdem.v1.RequestSnapshotRequest
+	20, // 13: holdem.v1.ClientEnvelope.rematch:type_name -> holdem.v1.RematchRequest
+	18, // 14: holdem.v1.ClientEnvelope.run_it_twice_accept:type_name -> holdem.v1.RunItTwiceAcceptRequest
+	29, // 15: holdem.v1.ServerEnvelope.error:type_name -> holdem.v1.ErrorResponse
+	31, // 16: holdem.v1.ServerEnvelope.table_snapshot:type_name -> holdem.v1.TableSnapshot
+	35, // 17: holdem.v1.ServerEnvelope.seat_update:type_name -> holdem.v1.SeatUpdate
+	38, // 18: holdem.v1.ServerEnvelope.hand_start:type_name -> holdem.v1.HandStart
+	39, // 19: holdem.v1.ServerEnvelope.deal_hole_cards:type_name -> holdem.v1.DealHoleCards
+	40, // 20: holdem.v1.ServerEnvelope.deal_board:type_name -> holdem.v1.DealBoard
+	42, // 21: holdem.v1.ServerEnvelope.action_prompt:type_name -> holdem.v1.ActionPrompt
+	43, // 22: holdem.v1.ServerEnvelope.action_result:type_name -> holdem.v1.ActionResult
+	44, // 23: holdem.v1.ServerEnvelope.pot_update:type_name -> holdem.v1.PotUpdate
+	45, // 24: holdem.v1.ServerEnvelope.showdown:type_name -> holdem.v1.Showdown
+	50, // 25: holdem.v1.ServerEnvelope.hand_end:type_name -> holdem.v1.HandEnd
+	41, // 26: holdem.v1.ServerEnvelope.phase_change:type_name -> holdem.v1.PhaseChange
+	57, // 27: holdem.v1.ServerEnvelope.win_by_fold:type_name -> holdem.v1.WinByFold
+	8,  // 28: holdem.v1.ServerEnvelope.login_response:type_name -> holdem.v1.LoginResponse
+	25, // 29: holdem.v1.ServerEnvelope.story_chapter_info:type_name -> holdem.v1.StoryChapterInfo
+	26, // 30: holdem.v1.ServerEnvelope.story_progress:type_name -> holdem.v1.StoryProgressState
+	52, // 31: holdem.v1.ServerEnvelope.session_end:type_name -> holdem.v1.SessionEnd
+	53, // 32: holdem.v1.ServerEnvelope.hand_list:type_name -> holdem.v1.HandList
+	58, // 33: holdem.v1.ServerEnvelope.rabbit_hunt:type_name -> holdem.v1.RabbitHunt
+	36, // 34: holdem.v1.ServerEnvelope.dealer_draw:type_name -> holdem.v1.DealerDraw
+	60, // 35: holdem.v1.ServerEnvelope.uncalled_return:type_name -> holdem.v1.UncalledReturn
+	61, // 36: holdem.v1.ServerEnvelope.run_it_twice_offer:type_name -> holdem.v1.RunItTwiceOffer
+	27, // 37: holdem.v1.ServerEnvelope.chapter_scoreboard:type_name -> holdem.v1.ChapterScoreboard
+	1,  // 38: holdem.v1.ActionRequest.action:type_name -> holdem.v1.ActionType
+	3,  // 39: holdem.v1.ActionRequest.sizing_preset:type_name -> holdem.v1.SizingPreset
+	24, // 40: holdem.v1.StoryChapterInfo.npc_roster:type_name -> holdem.v1.StoryNpcInfo
+	28, // 41: holdem.v1.ChapterScoreboard.standings:type_name -> holdem.v1.ChapterStanding
+	30, // 42: holdem.v1.ErrorResponse.action_options:type_name -> holdem.v1.ActionOptions
+	1,  // 43: holdem.v1.ActionOptions.legal_actions:type_name -> holdem.v1.ActionType
+	32, // 44: holdem.v1.TableSnapshot.config:type_name -> holdem.v1.TableConfig
+	0,  // 45: holdem.v1.TableSnapshot.phase:type_name -> holdem.v1.Phase
+	63, // 46: holdem.v1.TableSnapshot.community_cards:type_name -> holdem.v1.Card
+	34, // 47: holdem.v1.TableSnapshot.pots:type_name -> holdem.v1.Pot
+	33, // 48: holdem.v1.TableSnapshot.players:type_name -> holdem.v1.PlayerState
+	1,  // 49: holdem.v1.PlayerState.last_action:type_name -> holdem.v1.ActionType
+	63, // 50: holdem.v1.PlayerState.hand_cards:type_name -> holdem.v1.Card
+	33, // 51: holdem.v1.SeatUpdate.player_joined:type_name -> holdem.v1.PlayerState
+	37, // 52: holdem.v1.DealerDraw.cards:type_name -> holdem.v1.DealerDrawCard
+	63, // 53: holdem.v1.DealerDrawCard.card:type_name -> holdem.v1.Card
+	63, // 54: holdem.v1.DealHoleCards.cards:type_name -> holdem.v1.Card
+	0,  // 55: holdem.v1.DealBoard.phase:type_name -> holdem.v1.Phase
+	63, // 56: holdem.v1.DealBoard.cards:type_name -> holdem.v1.Card
+	0,  // 57: holdem.v1.PhaseChange.phase:type_name -> holdem.v1.Phase
+	63, // 58: holdem.v1.PhaseChange.community_cards:type_name -> holdem.v1.Card
+	34, // 59: holdem.v1.PhaseChange.pots:type_name -> holdem.v1.Pot
+	2,  // 60: holdem.v1.PhaseChange.my_hand_rank:type_name -> holdem.v1.HandRank
+	1,  // 61: holdem.v1.ActionPrompt.legal_actions:type_name -> holdem.v1.ActionType
+	1,  // 62: holdem.v1.ActionResult.action:type_name -> holdem.v1.ActionType
+	34, // 63: holdem.v1.PotUpdate.pots:type_name -> holdem.v1.Pot
+	47, // 64: holdem.v1.Showdown.hands:type_name -> holdem.v1.ShowdownHand
+	48, // 65: holdem.v1.Showdown.pot_results:type_name -> holdem.v1.PotResult
+	59, // 66: holdem.v1.Showdown.excess_refund:type_name -> holdem.v1.ExcessRefund
+	62, // 67: holdem.v1.Showdown.net_results:type_name -> holdem.v1.NetResult
+	46, // 68: holdem.v1.Showdown.runs:type_name -> holdem.v1.BoardRun
+	63, // 69: holdem.v1.BoardRun.board:type_name -> holdem.v1.Card
+	48, // 70: holdem.v1.BoardRun.pot_results:type_name -> holdem.v1.PotResult
+	63, // 71: holdem.v1.ShowdownHand.hole_cards:type_name -> holdem.v1.Card
+	63, // 72: holdem.v1.ShowdownHand.best_five:type_name -> holdem.v1.Card
+	2,  // 73: holdem.v1.ShowdownHand.rank:type_name -> holdem.v1.HandRank
+	49, // 74: holdem.v1.PotResult.winners:type_name -> holdem.v1.Winner
+	56, // 75: holdem.v1.HandEnd.stack_deltas:type_name -> holdem.v1.StackDelta
+	59, // 76: holdem.v1.HandEnd.excess_refund:type_name -> holdem.v1.ExcessRefund
+	62, // 77: holdem.v1.HandEnd.net_results:type_name -> holdem.v1.NetResult
+	51, // 78: holdem.v1.HandEnd.cash_outs:type_name -> holdem.v1.CashOutResult
+	55, // 79: holdem.v1.SessionEnd.stacks:type_name -> holdem.v1.SessionStack
+	54, // 80: holdem.v1.HandList.items:type_name -> holdem.v1.HandListItem
+	59, // 81: holdem.v1.WinByFold.excess_refund:type_name -> holdem.v1.ExcessRefund
+	63, // 82: holdem.v1.RabbitHunt.cards:type_name -> holdem.v1.Card
+	4,  // 83: holdem.v1.Card.suit:type_name -> holdem.v1.Suit
+	5,  // 84: holdem.v1.Card.rank:type_name -> holdem.v1.Rank
+	85, // [85:85] is the sub-list for method output_type
+	85, // [85:85] is the sub-list for method input_type
+	85, // [85:85] is the sub-list for extension type_name
+	85, // [85:85] is the sub-list for extension extendee
+	0,  // [0:85] is the sub-list for field type_name
 }
 
 func init() { file_messages_proto_init() }
@@ -3493,6 +5403,15 @@ func file_messages_proto_init() {
 		(*ClientEnvelope_BuyIn)(nil),
 		(*ClientEnvelope_Action)(nil),
 		(*ClientEnvelope_StartStory)(nil),
+		(*ClientEnvelope_Straddle)(nil),
+		(*ClientEnvelope_CashOut)(nil),
+		(*ClientEnvelope_SitOut)(nil),
+		(*ClientEnvelope_SitIn)(nil),
+		(*ClientEnvelope_ListHands)(nil),
+		(*ClientEnvelope_RunItTwice)(nil),
+		(*ClientEnvelope_RequestSnapshot)(nil),
+		(*ClientEnvelope_Rematch)(nil),
+		(*ClientEnvelope_RunItTwiceAccept)(nil),
 	}
 	file_messages_proto_msgTypes[1].OneofWrappers = []any{
 		(*ServerEnvelope_Error)(nil),
@@ -3511,20 +5430,27 @@ func file_messages_proto_init() {
 		(*ServerEnvelope_LoginResponse)(nil),
 		(*ServerEnvelope_StoryChapterInfo)(nil),
 		(*ServerEnvelope_StoryProgress)(nil),
-	}
-	file_messages_proto_msgTypes[17].OneofWrappers = []any{
+		(*ServerEnvelope_SessionEnd)(nil),
+		(*ServerEnvelope_HandList)(nil),
+		(*ServerEnvelope_RabbitHunt)(nil),
+		(*ServerEnvelope_DealerDraw)(nil),
+		(*ServerEnvelope_UncalledReturn)(nil),
+		(*ServerEnvelope_RunItTwiceOffer)(nil),
+		(*ServerEnvelope_ChapterScoreboard)(nil),
+	}
+	file_messages_proto_msgTypes[29].OneofWrappers = []any{
 		(*SeatUpdate_PlayerJoined)(nil),
 		(*SeatUpdate_PlayerLeftUserId)(nil),
 		(*SeatUpdate_StackChange)(nil),
 	}
-	file_messages_proto_msgTypes[21].OneofWrappers = []any{}
+	file_messages_proto_msgTypes[35].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_messages_proto_rawDesc), len(file_messages_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   35,
+			NumEnums:      6,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
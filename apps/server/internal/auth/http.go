@@ -1,14 +1,19 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type HTTPHandler struct {
 	manager Service
+	google  TokenVerifier
+	resets  ResetSender
 }
 
 type credentialsRequest struct {
@@ -16,6 +21,19 @@ type credentialsRequest struct {
 	Password string `json:"password"`
 }
 
+type oauthRequest struct {
+	IDToken string `json:"id_token"`
+}
+
+type resetRequest struct {
+	Username string `json:"username"`
+}
+
+type resetPasswordRequest struct {
+	ResetToken  string `json:"reset_token"`
+	NewPassword string `json:"new_password"`
+}
+
 type authResponse struct {
 	UserID       uint64 `json:"user_id"`
 	SessionToken string `json:"session_token"`
@@ -26,6 +44,25 @@ type meResponse struct {
 	Username string `json:"username"`
 }
 
+type sessionResponse struct {
+	TokenPrefix  string `json:"token_prefix"`
+	IssuedAtMs   int64  `json:"issued_at_ms"`
+	LastSeenAtMs int64  `json:"last_seen_at_ms"`
+	UserAgent    string `json:"user_agent"`
+	IP           string `json:"ip"`
+	Current      bool   `json:"current"`
+}
+
+type sessionExpiryResponse struct {
+	ExpiresAtMs int64 `json:"expires_at_ms"`
+	RemainingMs int64 `json:"remaining_ms"`
+	TTLMs       int64 `json:"ttl_ms"`
+}
+
+type sessionsResponse struct {
+	Sessions []sessionResponse `json:"sessions"`
+}
+
 type errorResponse struct {
 	Error string `json:"error"`
 }
@@ -34,11 +71,29 @@ func NewHTTPHandler(manager Service) *HTTPHandler {
 	return &HTTPHandler{manager: manager}
 }
 
+// SetGoogleVerifier enables /api/auth/oauth/google; without a verifier the
+// endpoint reports that Google sign-in is not configured.
+func (h *HTTPHandler) SetGoogleVerifier(verifier TokenVerifier) {
+	h.google = verifier
+}
+
+// SetResetSender enables /api/auth/password/request; without a sender there
+// is no way to hand out reset tokens and the endpoint reports so.
+func (h *HTTPHandler) SetResetSender(sender ResetSender) {
+	h.resets = sender
+}
+
 func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/auth/register", h.handleRegister)
 	mux.HandleFunc("/api/auth/login", h.handleLogin)
 	mux.HandleFunc("/api/auth/logout", h.handleLogout)
 	mux.HandleFunc("/api/auth/me", h.handleMe)
+	mux.HandleFunc("/api/auth/oauth/google", h.handleGoogle)
+	mux.HandleFunc("/api/auth/password/request", h.handlePasswordRequest)
+	mux.HandleFunc("/api/auth/password/reset", h.handlePasswordReset)
+	mux.HandleFunc("/api/auth/session/expiry", h.handleSessionExpiry)
+	mux.HandleFunc("/api/auth/sessions", h.handleSessions)
+	mux.HandleFunc("/api/auth/sessions/revoke-all", h.handleRevokeAllSessions)
 }
 
 func (h *HTTPHandler) handleRegister(w http.ResponseWriter, r *http.Request) {
@@ -53,7 +108,7 @@ func (h *HTTPHandler) handleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, sessionToken, err := h.manager.Register(req.Username, req.Password)
+	userID, sessionToken, err := h.manager.Register(req.Username, req.Password, clientInfo(r))
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrInvalidUsername), errors.Is(err, ErrInvalidPassword):
@@ -84,7 +139,7 @@ func (h *HTTPHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, sessionToken, err := h.manager.Login(req.Username, req.Password)
+	userID, sessionToken, err := h.manager.Login(req.Username, req.Password, clientInfo(r))
 	if err != nil {
 		if errors.Is(err, ErrInvalidCredentials) {
 			writeError(w, http.StatusUnauthorized, "invalid username or password")
@@ -100,6 +155,106 @@ func (h *HTTPHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *HTTPHandler) handleGoogle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.google == nil {
+		writeError(w, http.StatusNotImplemented, "google sign-in not configured")
+		return
+	}
+
+	var req oauthRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	identity, err := h.google.Verify(ctx, req.IDToken)
+	if err != nil {
+		if errors.Is(err, ErrInvalidIDToken) {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		writeError(w, http.StatusBadGateway, "id token verification failed")
+		return
+	}
+
+	userID, sessionToken, err := h.manager.LoginOAuth(ProviderGoogle, identity, clientInfo(r))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "login failed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, authResponse{
+		UserID:       userID,
+		SessionToken: sessionToken,
+	})
+}
+
+// handlePasswordRequest answers 202 whether or not the username exists, so
+// it cannot be used to probe for accounts.
+func (h *HTTPHandler) handlePasswordRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.resets == nil {
+		writeError(w, http.StatusNotImplemented, "password reset not configured")
+		return
+	}
+
+	var req resetRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resetToken, err := h.manager.RequestPasswordReset(req.Username)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "password reset failed")
+		return
+	}
+	if err := h.resets.SendPasswordReset(normalizeUsername(req.Username), resetToken); err != nil {
+		writeError(w, http.StatusBadGateway, "reset delivery failed")
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HTTPHandler) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req resetPasswordRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.manager.ResetPassword(req.ResetToken, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidPassword):
+			writeError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrInvalidResetToken):
+			writeError(w, http.StatusUnauthorized, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "password reset failed")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *HTTPHandler) handleLogout(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -140,6 +295,122 @@ func (h *HTTPHandler) handleMe(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleSessionExpiry reports how long the caller's session stays valid if
+// left idle, so the client can prompt a re-login before it lapses. It
+// deliberately skips authenticate: resolving the session would renew it and
+// always report the full TTL.
+func (h *HTTPHandler) handleSessionExpiry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	token := bearerToken(r.Header.Get("Authorization"))
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, "missing session token")
+		return
+	}
+	expiresAt, ttl, ok := h.manager.SessionExpiry(token)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid session token")
+		return
+	}
+
+	remaining := time.Until(expiresAt)
+	if remaining < 0 {
+		remaining = 0
+	}
+	writeJSON(w, http.StatusOK, sessionExpiryResponse{
+		ExpiresAtMs: expiresAt.UnixMilli(),
+		RemainingMs: remaining.Milliseconds(),
+		TTLMs:       ttl.Milliseconds(),
+	})
+}
+
+func (h *HTTPHandler) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	token, userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	sessions, err := h.manager.ListSessions(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "list sessions failed")
+		return
+	}
+
+	resp := sessionsResponse{Sessions: make([]sessionResponse, 0, len(sessions))}
+	for _, s := range sessions {
+		resp.Sessions = append(resp.Sessions, sessionResponse{
+			TokenPrefix:  s.TokenPrefix,
+			IssuedAtMs:   s.IssuedAt.UnixMilli(),
+			LastSeenAtMs: s.LastSeenAt.UnixMilli(),
+			UserAgent:    s.UserAgent,
+			IP:           s.IP,
+			Current:      s.TokenPrefix == sessionTokenPrefix(token),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleRevokeAllSessions signs out every other session of the caller; the
+// session making the request stays valid.
+func (h *HTTPHandler) handleRevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	token, userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if err := h.manager.RevokeAllSessions(userID, token); err != nil {
+		writeError(w, http.StatusInternalServerError, "revoke sessions failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate resolves the request's bearer token, writing a 401 when it is
+// missing or invalid.
+func (h *HTTPHandler) authenticate(w http.ResponseWriter, r *http.Request) (token string, userID uint64, ok bool) {
+	token = bearerToken(r.Header.Get("Authorization"))
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, "missing session token")
+		return "", 0, false
+	}
+	userID, _, ok = h.manager.ResolveSession(token)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid session token")
+		return "", 0, false
+	}
+	return token, userID, true
+}
+
+const maxUserAgentLen = 512
+
+// clientInfo takes the user agent and the connecting peer's address. Proxy
+// headers are not trusted.
+func clientInfo(r *http.Request) ClientInfo {
+	info := ClientInfo{UserAgent: strings.TrimSpace(r.UserAgent())}
+	if len(info.UserAgent) > maxUserAgentLen {
+		info.UserAgent = info.UserAgent[:maxUserAgentLen]
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		info.IP = ip.String()
+	}
+	return info
+}
+
 func decodeJSON(r *http.Request, dst any) error {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
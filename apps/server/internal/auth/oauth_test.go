@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// stubVerifier accepts "token-<sub>" and rejects anything else.
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(_ context.Context, idToken string) (ExternalIdentity, error) {
+	var sub string
+	if _, err := fmt.Sscanf(idToken, "token-%s", &sub); err != nil {
+		return ExternalIdentity{}, ErrInvalidIDToken
+	}
+	return ExternalIdentity{Subject: sub, Email: sub + "@example.com", Name: "Player " + sub}, nil
+}
+
+func googleLogin(t *testing.T, mux *http.ServeMux, idToken string) (int, authResponse) {
+	t.Helper()
+	body, _ := json.Marshal(oauthRequest{IDToken: idToken})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/oauth/google", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var resp authResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec.Code, resp
+}
+
+// testGoogleLogin checks that the first Google login creates an account and
+// later logins with the same subject reuse it.
+func testGoogleLogin(t *testing.T, svc Service) {
+	t.Helper()
+	h := NewHTTPHandler(svc)
+	h.SetGoogleVerifier(stubVerifier{})
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	code, first := googleLogin(t, mux, "token-alice")
+	if code != http.StatusOK {
+		t.Fatalf("first login: expected 200, got %d", code)
+	}
+	if first.UserID == 0 || first.SessionToken == "" {
+		t.Fatalf("first login: expected account and session, got %+v", first)
+	}
+	accountID, username, ok := svc.ResolveSession(first.SessionToken)
+	if !ok || accountID != first.UserID {
+		t.Fatalf("first login session did not resolve to account %d", first.UserID)
+	}
+	if username != "Player alice" {
+		t.Fatalf("expected display name from token, got %q", username)
+	}
+
+	code, again := googleLogin(t, mux, "token-alice")
+	if code != http.StatusOK {
+		t.Fatalf("second login: expected 200, got %d", code)
+	}
+	if again.UserID != first.UserID {
+		t.Fatalf("expected same account on second login, got %d and %d", first.UserID, again.UserID)
+	}
+	if again.SessionToken == first.SessionToken {
+		t.Fatalf("expected a fresh session on second login")
+	}
+
+	_, other := googleLogin(t, mux, "token-bob")
+	if other.UserID == 0 || other.UserID == first.UserID {
+		t.Fatalf("expected a separate account for another subject, got %d", other.UserID)
+	}
+
+	if code, _ := googleLogin(t, mux, "forged"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for rejected token, got %d", code)
+	}
+}
+
+func TestManagerGoogleLogin(t *testing.T) {
+	testGoogleLogin(t, NewManager())
+}
+
+func TestSQLiteManagerGoogleLogin(t *testing.T) {
+	m, err := NewSQLiteManager(filepath.Join(t.TempDir(), "auth.db"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLiteManager failed: %v", err)
+	}
+	defer m.Close()
+	testGoogleLogin(t, m)
+}
+
+func TestGoogleLoginNotConfigured(t *testing.T) {
+	mux := http.NewServeMux()
+	NewHTTPHandler(NewManager()).RegisterRoutes(mux)
+	if code, _ := googleLogin(t, mux, "token-alice"); code != http.StatusNotImplemented {
+		t.Fatalf("expected 501 without a verifier, got %d", code)
+	}
+}
+
+func TestGoogleVerifierChecksAudienceAndExpiry(t *testing.T) {
+	exp := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	tokens := map[string]googleTokenInfo{
+		"good":    {Issuer: "https://accounts.google.com", Audience: "client-1", Subject: "42", Expiry: exp},
+		"foreign": {Issuer: "https://accounts.google.com", Audience: "client-2", Subject: "42", Expiry: exp},
+		"expired": {Issuer: "accounts.google.com", Audience: "client-1", Subject: "42", Expiry: "1"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, ok := tokens[r.URL.Query().Get("id_token")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(info)
+	}))
+	defer srv.Close()
+
+	v := &GoogleVerifier{ClientID: "client-1", TokenInfoURL: srv.URL, Client: srv.Client()}
+	identity, err := v.Verify(context.Background(), "good")
+	if err != nil || identity.Subject != "42" {
+		t.Fatalf("expected subject 42, got %+v err=%v", identity, err)
+	}
+	for _, token := range []string{"foreign", "expired", "unknown"} {
+		if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrInvalidIDToken) {
+			t.Fatalf("%s: expected ErrInvalidIDToken, got %v", token, err)
+		}
+	}
+}
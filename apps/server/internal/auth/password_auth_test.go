@@ -8,7 +8,7 @@ import (
 func TestRegisterAndLogin(t *testing.T) {
 	m := NewManager()
 
-	accountID, token, err := m.Register("alice_01", "secret12")
+	accountID, token, err := m.Register("alice_01", "secret12", ClientInfo{})
 	if err != nil {
 		t.Fatalf("register failed: %v", err)
 	}
@@ -30,7 +30,7 @@ func TestRegisterAndLogin(t *testing.T) {
 		t.Fatalf("expected username alice_01, got %s", username)
 	}
 
-	loginID, loginToken, err := m.Login("alice_01", "secret12")
+	loginID, loginToken, err := m.Login("alice_01", "secret12", ClientInfo{})
 	if err != nil {
 		t.Fatalf("login failed: %v", err)
 	}
@@ -44,27 +44,27 @@ func TestRegisterAndLogin(t *testing.T) {
 
 func TestRegisterRejectsDuplicateUsername(t *testing.T) {
 	m := NewManager()
-	if _, _, err := m.Register("alice_01", "secret12"); err != nil {
+	if _, _, err := m.Register("alice_01", "secret12", ClientInfo{}); err != nil {
 		t.Fatalf("register failed: %v", err)
 	}
-	if _, _, err := m.Register("Alice_01", "secret12"); !errors.Is(err, ErrUsernameTaken) {
+	if _, _, err := m.Register("Alice_01", "secret12", ClientInfo{}); !errors.Is(err, ErrUsernameTaken) {
 		t.Fatalf("expected ErrUsernameTaken, got %v", err)
 	}
 }
 
 func TestLoginRejectsWrongPassword(t *testing.T) {
 	m := NewManager()
-	if _, _, err := m.Register("alice_01", "secret12"); err != nil {
+	if _, _, err := m.Register("alice_01", "secret12", ClientInfo{}); err != nil {
 		t.Fatalf("register failed: %v", err)
 	}
-	if _, _, err := m.Login("alice_01", "wrong-password"); !errors.Is(err, ErrInvalidCredentials) {
+	if _, _, err := m.Login("alice_01", "wrong-password", ClientInfo{}); !errors.Is(err, ErrInvalidCredentials) {
 		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
 	}
 }
 
 func TestLogoutInvalidatesSession(t *testing.T) {
 	m := NewManager()
-	_, token, err := m.Register("alice_01", "secret12")
+	_, token, err := m.Register("alice_01", "secret12", ClientInfo{})
 	if err != nil {
 		t.Fatalf("register failed: %v", err)
 	}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"log"
+	"os"
+	"strings"
+)
+
+// ResetSender delivers a password reset token to the account's owner out of
+// band; the token is never returned to the HTTP caller that requested it.
+type ResetSender interface {
+	SendPasswordReset(username, resetToken string) error
+}
+
+// LogResetSender writes reset tokens to the server log, for self-hosted
+// deployments where the operator hands them to players.
+type LogResetSender struct{}
+
+func (LogResetSender) SendPasswordReset(username, resetToken string) error {
+	log.Printf("[Auth] password reset requested user=%s token=%s", username, resetToken)
+	return nil
+}
+
+// NewResetSenderFromEnv returns LogResetSender when AUTH_PASSWORD_RESET_LOG is
+// set, and nil otherwise, which leaves password reset disabled.
+func NewResetSenderFromEnv() ResetSender {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("AUTH_PASSWORD_RESET_LOG"))) {
+	case "1", "true", "yes":
+		return LogResetSender{}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testPasswordReset checks that a reset token sets a new password once,
+// signs out existing sessions, and is refused afterwards.
+func testPasswordReset(t *testing.T, svc Service) {
+	t.Helper()
+	_, oldSession, err := svc.Register("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if _, err := svc.RequestPasswordReset("nobody_01"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials for unknown user, got %v", err)
+	}
+
+	resetToken, err := svc.RequestPasswordReset("Alice_01")
+	if err != nil || resetToken == "" {
+		t.Fatalf("request reset failed: token=%q err=%v", resetToken, err)
+	}
+	if err := svc.ResetPassword(resetToken, "short"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected ErrInvalidPassword, got %v", err)
+	}
+	if err := svc.ResetPassword(resetToken, "newsecret34"); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+
+	if _, _, err := svc.Login("alice_01", "secret12", ClientInfo{}); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected old password to be rejected, got %v", err)
+	}
+	if _, _, err := svc.Login("alice_01", "newsecret34", ClientInfo{}); err != nil {
+		t.Fatalf("login with new password failed: %v", err)
+	}
+	if _, _, ok := svc.ResolveSession(oldSession); ok {
+		t.Fatalf("expected sessions from before the reset to be revoked")
+	}
+	if err := svc.ResetPassword(resetToken, "another56"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("expected a used token to be rejected, got %v", err)
+	}
+}
+
+func TestManagerPasswordReset(t *testing.T) {
+	testPasswordReset(t, NewManager())
+}
+
+func TestSQLiteManagerPasswordReset(t *testing.T) {
+	testPasswordReset(t, newTestSQLiteManager(t))
+}
+
+func TestPasswordResetTokenExpires(t *testing.T) {
+	mem := NewManager()
+	mem.resetTTL = time.Millisecond
+	db := newTestSQLiteManager(t)
+	db.resetTTL = time.Millisecond
+
+	for name, svc := range map[string]Service{"memory": mem, "sqlite": db} {
+		if _, _, err := svc.Register("alice_01", "secret12", ClientInfo{}); err != nil {
+			t.Fatalf("%s: register failed: %v", name, err)
+		}
+		resetToken, err := svc.RequestPasswordReset("alice_01")
+		if err != nil {
+			t.Fatalf("%s: request reset failed: %v", name, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if err := svc.ResetPassword(resetToken, "newsecret34"); !errors.Is(err, ErrInvalidResetToken) {
+			t.Fatalf("%s: expected expired token to be rejected, got %v", name, err)
+		}
+		if _, _, err := svc.Login("alice_01", "secret12", ClientInfo{}); err != nil {
+			t.Fatalf("%s: expected old password to still work, got %v", name, err)
+		}
+	}
+}
+
+// captureSender records the tokens it is asked to deliver.
+type captureSender map[string]string
+
+func (c captureSender) SendPasswordReset(username, resetToken string) error {
+	c[username] = resetToken
+	return nil
+}
+
+func postJSON(mux *http.ServeMux, path string, payload any) int {
+	body, _ := json.Marshal(payload)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+	return rec.Code
+}
+
+func TestPasswordResetHTTP(t *testing.T) {
+	m := NewManager()
+	if _, _, err := m.Register("alice_01", "secret12", ClientInfo{}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	h := NewHTTPHandler(m)
+	h.RegisterRoutes(mux)
+	if code := postJSON(mux, "/api/auth/password/request", resetRequest{Username: "alice_01"}); code != http.StatusNotImplemented {
+		t.Fatalf("expected 501 without a sender, got %d", code)
+	}
+
+	sent := captureSender{}
+	h.SetResetSender(sent)
+	for _, username := range []string{"alice_01", "nobody_01"} {
+		if code := postJSON(mux, "/api/auth/password/request", resetRequest{Username: username}); code != http.StatusAccepted {
+			t.Fatalf("%s: expected 202, got %d", username, code)
+		}
+	}
+	if len(sent) != 1 || sent["alice_01"] == "" {
+		t.Fatalf("expected one token sent to alice_01, got %v", sent)
+	}
+
+	reset := resetPasswordRequest{ResetToken: sent["alice_01"], NewPassword: "newsecret34"}
+	if code := postJSON(mux, "/api/auth/password/reset", reset); code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", code)
+	}
+	if code := postJSON(mux, "/api/auth/password/reset", reset); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 on reuse, got %d", code)
+	}
+	if code := postJSON(mux, "/api/auth/login", credentialsRequest{Username: "alice_01", Password: "newsecret34"}); code != http.StatusOK {
+		t.Fatalf("expected login with new password, got %d", code)
+	}
+}
+
+func newTestSQLiteManager(t *testing.T) *SQLiteManager {
+	t.Helper()
+	m, err := NewSQLiteManager(filepath.Join(t.TempDir(), "auth.db"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLiteManager failed: %v", err)
+	}
+	t.Cleanup(func() { _ = m.Close() })
+	return m
+}
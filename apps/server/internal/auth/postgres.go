@@ -20,6 +20,7 @@ const (
 type PostgresManager struct {
 	db         *sql.DB
 	sessionTTL time.Duration
+	resetTTL   time.Duration
 }
 
 func authDSNFromEnv() string {
@@ -90,6 +91,7 @@ SELECT EXISTS (
 	return &PostgresManager{
 		db:         db,
 		sessionTTL: sessionTTL,
+		resetTTL:   defaultPasswordResetTTL,
 	}, nil
 }
 
@@ -100,7 +102,7 @@ func (m *PostgresManager) Close() error {
 	return m.db.Close()
 }
 
-func (m *PostgresManager) Register(username, password string) (accountID uint64, sessionToken string, err error) {
+func (m *PostgresManager) Register(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error) {
 	if err = validateUsername(username); err != nil {
 		return 0, "", err
 	}
@@ -144,7 +146,7 @@ VALUES ($1, 'local', $2, $3)
 		return 0, "", err
 	}
 
-	sessionToken, err = m.issueSessionTx(ctx, tx, accountID)
+	sessionToken, err = m.issueSessionTx(ctx, tx, accountID, client)
 	if err != nil {
 		return 0, "", err
 	}
@@ -155,7 +157,7 @@ VALUES ($1, 'local', $2, $3)
 	return accountID, sessionToken, nil
 }
 
-func (m *PostgresManager) Login(username, password string) (accountID uint64, sessionToken string, err error) {
+func (m *PostgresManager) Login(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error) {
 	normalized := normalizeUsername(username)
 	if normalized == "" || password == "" {
 		return 0, "", ErrInvalidCredentials
@@ -196,7 +198,7 @@ WHERE id = $1
 		return 0, "", err
 	}
 
-	sessionToken, err = m.issueSessionTx(ctx, tx, accountID)
+	sessionToken, err = m.issueSessionTx(ctx, tx, accountID, client)
 	if err != nil {
 		return 0, "", err
 	}
@@ -207,6 +209,188 @@ WHERE id = $1
 	return accountID, sessionToken, nil
 }
 
+func (m *PostgresManager) LoginOAuth(provider string, identity ExternalIdentity, client ClientInfo) (accountID uint64, sessionToken string, err error) {
+	subject := strings.TrimSpace(identity.Subject)
+	if provider == "" || subject == "" {
+		return 0, "", ErrInvalidCredentials
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A unique violation means a generated username collided or a concurrent
+	// first login linked the identity; either way the next attempt resolves it.
+	for i := 0; i < 5; i++ {
+		tx, err := m.db.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, "", err
+		}
+
+		accountID, err = m.upsertOAuthAccountTx(ctx, tx, provider, subject, identity)
+		if err != nil {
+			_ = tx.Rollback()
+			if isUniqueViolation(err) {
+				continue
+			}
+			return 0, "", err
+		}
+
+		sessionToken, err = m.issueSessionTx(ctx, tx, accountID, client)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, "", err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, "", err
+		}
+		return accountID, sessionToken, nil
+	}
+
+	return 0, "", fmt.Errorf("failed to link %s identity", provider)
+}
+
+func (m *PostgresManager) upsertOAuthAccountTx(ctx context.Context, tx *sql.Tx, provider, subject string, identity ExternalIdentity) (uint64, error) {
+	var accountID uint64
+	err := tx.QueryRowContext(ctx, `
+SELECT account_id
+FROM auth_identities
+WHERE provider = $1
+  AND provider_subject = $2
+`, provider, subject).Scan(&accountID)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `
+UPDATE accounts
+SET last_login_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`, accountID)
+		return accountID, err
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	username := oauthUsername(provider)
+	if err := tx.QueryRowContext(ctx, `
+INSERT INTO accounts (username, display_name, status, last_login_at)
+VALUES ($1, $2, 1, NOW())
+RETURNING id
+`, username, oauthDisplayName(identity, username)).Scan(&accountID); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO auth_identities (account_id, provider, provider_subject)
+VALUES ($1, $2, $3)
+`, accountID, provider, subject); err != nil {
+		return 0, err
+	}
+	return accountID, nil
+}
+
+func (m *PostgresManager) RequestPasswordReset(username string) (resetToken string, err error) {
+	normalized := normalizeUsername(username)
+	if normalized == "" {
+		return "", ErrInvalidCredentials
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var accountID uint64
+	if err := m.db.QueryRowContext(ctx, `
+SELECT account_id
+FROM auth_identities
+WHERE provider = 'local'
+  AND provider_subject = $1
+`, normalized).Scan(&accountID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrInvalidCredentials
+		}
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(m.resetTTL)
+	for i := 0; i < 5; i++ {
+		resetToken = mustToken()
+		if _, err := m.db.ExecContext(ctx, `
+INSERT INTO auth_password_resets (token, account_id, expires_at)
+VALUES ($1, $2, $3)
+`, resetToken, accountID, expiresAt); err != nil {
+			if isUniqueViolation(err) {
+				continue
+			}
+			return "", err
+		}
+		return resetToken, nil
+	}
+	return "", fmt.Errorf("failed to generate unique reset token")
+}
+
+func (m *PostgresManager) ResetPassword(resetToken, newPassword string) error {
+	resetToken = strings.TrimSpace(resetToken)
+	if resetToken == "" {
+		return ErrInvalidResetToken
+	}
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var accountID uint64
+	if err := tx.QueryRowContext(ctx, `
+UPDATE auth_password_resets
+SET used_at = NOW()
+WHERE token = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING account_id
+`, resetToken).Scan(&accountID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+UPDATE auth_identities
+SET password_hash = $1,
+    updated_at = NOW()
+WHERE account_id = $2
+  AND provider = 'local'
+`, string(passwordHash), accountID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE auth_password_resets
+SET used_at = NOW()
+WHERE account_id = $1
+  AND used_at IS NULL
+`, accountID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE auth_sessions
+SET revoked_at = NOW()
+WHERE account_id = $1
+  AND revoked_at IS NULL
+`, accountID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func (m *PostgresManager) ResolveSession(token string) (accountID uint64, username string, ok bool) {
 	token = strings.TrimSpace(token)
 	if token == "" {
@@ -253,7 +437,73 @@ WHERE token = $1
 `, token)
 }
 
-func (m *PostgresManager) ResolveOrCreateAccount(token string) (accountID uint64, sessionToken string, reused bool) {
+func (m *PostgresManager) ListSessions(accountID uint64) ([]SessionInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, `
+SELECT token, issued_at, last_seen_at, COALESCE(user_agent, ''), COALESCE(host(ip), '')
+FROM auth_sessions
+WHERE account_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+ORDER BY last_seen_at DESC
+`, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]SessionInfo, 0)
+	for rows.Next() {
+		var (
+			token string
+			info  SessionInfo
+		)
+		if err := rows.Scan(&token, &info.IssuedAt, &info.LastSeenAt, &info.UserAgent, &info.IP); err != nil {
+			return nil, err
+		}
+		info.TokenPrefix = sessionTokenPrefix(token)
+		sessions = append(sessions, info)
+	}
+	return sessions, rows.Err()
+}
+
+func (m *PostgresManager) SessionExpiry(token string) (expiresAt time.Time, ttl time.Duration, ok bool) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return time.Time{}, 0, false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := m.db.QueryRowContext(ctx, `
+SELECT expires_at
+FROM auth_sessions
+WHERE token = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+`, token).Scan(&expiresAt)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return expiresAt, m.sessionTTL, true
+}
+
+func (m *PostgresManager) RevokeAllSessions(accountID uint64, exceptToken string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := m.db.ExecContext(ctx, `
+UPDATE auth_sessions
+SET revoked_at = NOW()
+WHERE account_id = $1
+  AND token <> $2
+  AND revoked_at IS NULL
+`, accountID, strings.TrimSpace(exceptToken))
+	return err
+}
+
+func (m *PostgresManager) ResolveOrCreateAccount(token string, client ClientInfo) (accountID uint64, sessionToken string, reused bool) {
 	token = strings.TrimSpace(token)
 	if token != "" {
 		if accountID, _, ok := m.ResolveSession(token); ok {
@@ -294,7 +544,7 @@ VALUES ($1, 'guest', $2)
 			return 0, "", false
 		}
 
-		sessionToken, err = m.issueSessionTx(ctx, tx, accountID)
+		sessionToken, err = m.issueSessionTx(ctx, tx, accountID, client)
 		if err != nil {
 			_ = tx.Rollback()
 			return 0, "", false
@@ -309,14 +559,14 @@ VALUES ($1, 'guest', $2)
 	return 0, "", false
 }
 
-func (m *PostgresManager) issueSessionTx(ctx context.Context, tx *sql.Tx, accountID uint64) (string, error) {
+func (m *PostgresManager) issueSessionTx(ctx context.Context, tx *sql.Tx, accountID uint64, client ClientInfo) (string, error) {
 	expiresAt := time.Now().Add(m.sessionTTL)
 	for i := 0; i < 5; i++ {
 		token := mustToken()
 		if _, err := tx.ExecContext(ctx, `
-INSERT INTO auth_sessions (token, account_id, expires_at)
-VALUES ($1, $2, $3)
-`, token, accountID, expiresAt); err != nil {
+INSERT INTO auth_sessions (token, account_id, expires_at, user_agent, ip)
+VALUES ($1, $2, $3, $4, $5)
+`, token, accountID, expiresAt, nullIfEmpty(client.UserAgent), nullIfEmpty(client.IP)); err != nil {
 			if isUniqueViolation(err) {
 				continue
 			}
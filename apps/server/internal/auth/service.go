@@ -1,13 +1,64 @@
 package auth
 
+import "time"
+
 // Service is the auth/session contract consumed by gateway and HTTP handlers.
 type Service interface {
-	Register(username, password string) (accountID uint64, sessionToken string, err error)
-	Login(username, password string) (accountID uint64, sessionToken string, err error)
+	// Register, Login and LoginOAuth record client on the session they issue.
+	Register(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error)
+	Login(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error)
+	// LoginOAuth signs in the account linked to a verified provider identity,
+	// creating it on first login.
+	LoginOAuth(provider string, identity ExternalIdentity, client ClientInfo) (accountID uint64, sessionToken string, err error)
+	// RequestPasswordReset issues a single-use reset token for a password
+	// account; ResetPassword spends it to set a new password and signs the
+	// account out everywhere.
+	RequestPasswordReset(username string) (resetToken string, err error)
+	ResetPassword(resetToken, newPassword string) error
 	ResolveSession(token string) (accountID uint64, username string, ok bool)
+	// SessionExpiry reports when token expires unless it is used again, and
+	// the sliding TTL each use renews it to. Unlike ResolveSession it does
+	// not count as a use.
+	SessionExpiry(token string) (expiresAt time.Time, ttl time.Duration, ok bool)
 	Logout(token string)
+	// ListSessions returns the account's live sessions, most recently used
+	// first. RevokeAllSessions signs out all of them but exceptToken.
+	ListSessions(accountID uint64) ([]SessionInfo, error)
+	RevokeAllSessions(accountID uint64, exceptToken string) error
 	Close() error
 
 	// Deprecated compatibility API.
-	ResolveOrCreateAccount(token string) (accountID uint64, sessionToken string, reused bool)
+	ResolveOrCreateAccount(token string, client ClientInfo) (accountID uint64, sessionToken string, reused bool)
+}
+
+// ClientInfo is the request metadata stored with a new session. Empty
+// fields are stored as unknown.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// SessionInfo describes a live session without exposing its token.
+type SessionInfo struct {
+	TokenPrefix string
+	IssuedAt    time.Time
+	LastSeenAt  time.Time
+	UserAgent   string
+	IP          string
+}
+
+const sessionTokenPrefixLen = 8
+
+func sessionTokenPrefix(token string) string {
+	if len(token) > sessionTokenPrefixLen {
+		return token[:sessionTokenPrefixLen]
+	}
+	return token
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
 }
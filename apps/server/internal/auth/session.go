@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,8 +14,9 @@ import (
 )
 
 const (
-	defaultSessionTTL = 30 * 24 * time.Hour
-	tokenBytes        = 32
+	defaultSessionTTL       = 30 * 24 * time.Hour
+	defaultPasswordResetTTL = 30 * time.Minute
+	tokenBytes              = 32
 )
 
 var (
@@ -22,6 +24,7 @@ var (
 	ErrInvalidPassword    = errors.New("invalid password")
 	ErrUsernameTaken      = errors.New("username already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidResetToken  = errors.New("invalid or expired reset token")
 )
 
 var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{2,31}$`)
@@ -33,14 +36,21 @@ type Manager struct {
 
 	nextAccountID uint64
 	sessionTTL    time.Duration
+	resetTTL      time.Duration
 	sessions      map[string]sessionRecord // token -> account
+	resets        map[string]sessionRecord // reset token -> account, removed once used
 	accountsByID  map[uint64]accountRecord // account -> profile
 	accountsByKey map[string]uint64        // normalized username -> account
+	identities    map[string]uint64        // provider:subject -> account
 }
 
 type sessionRecord struct {
-	AccountID uint64
-	ExpiresAt time.Time
+	AccountID  uint64
+	ExpiresAt  time.Time
+	IssuedAt   time.Time
+	LastSeenAt time.Time
+	UserAgent  string
+	IP         string
 }
 
 type accountRecord struct {
@@ -55,9 +65,12 @@ func NewManager() *Manager {
 	return &Manager{
 		nextAccountID: 100000, // start from a readable non-trivial range
 		sessionTTL:    defaultSessionTTL,
+		resetTTL:      defaultPasswordResetTTL,
 		sessions:      make(map[string]sessionRecord),
+		resets:        make(map[string]sessionRecord),
 		accountsByID:  make(map[uint64]accountRecord),
 		accountsByKey: make(map[string]uint64),
+		identities:    make(map[string]uint64),
 	}
 }
 
@@ -84,11 +97,15 @@ func validatePassword(password string) error {
 	return nil
 }
 
-func (m *Manager) issueSessionLocked(accountID uint64, now time.Time) string {
+func (m *Manager) issueSessionLocked(accountID uint64, now time.Time, client ClientInfo) string {
 	sessionToken := mustToken()
 	m.sessions[sessionToken] = sessionRecord{
-		AccountID: accountID,
-		ExpiresAt: now.Add(m.sessionTTL),
+		AccountID:  accountID,
+		ExpiresAt:  now.Add(m.sessionTTL),
+		IssuedAt:   now,
+		LastSeenAt: now,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
 	}
 	return sessionToken
 }
@@ -106,6 +123,7 @@ func (m *Manager) resolveSessionLocked(token string, now time.Time) (accountID u
 		return 0, "", false
 	}
 	rec.ExpiresAt = now.Add(m.sessionTTL)
+	rec.LastSeenAt = now
 	m.sessions[token] = rec
 
 	profile := m.accountsByID[rec.AccountID]
@@ -113,7 +131,7 @@ func (m *Manager) resolveSessionLocked(token string, now time.Time) (accountID u
 }
 
 // Register creates a new account and returns an authenticated session token.
-func (m *Manager) Register(username, password string) (accountID uint64, sessionToken string, err error) {
+func (m *Manager) Register(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error) {
 	if err = validateUsername(username); err != nil {
 		return 0, "", err
 	}
@@ -146,12 +164,12 @@ func (m *Manager) Register(username, password string) (accountID uint64, session
 	}
 	m.accountsByKey[normalized] = accountID
 
-	sessionToken = m.issueSessionLocked(accountID, now)
+	sessionToken = m.issueSessionLocked(accountID, now, client)
 	return accountID, sessionToken, nil
 }
 
 // Login validates account credentials and returns a fresh authenticated session.
-func (m *Manager) Login(username, password string) (accountID uint64, sessionToken string, err error) {
+func (m *Manager) Login(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error) {
 	normalized := normalizeUsername(username)
 	if normalized == "" || password == "" {
 		return 0, "", ErrInvalidCredentials
@@ -176,10 +194,98 @@ func (m *Manager) Login(username, password string) (accountID uint64, sessionTok
 	now := time.Now()
 	profile.LastLoginTime = now
 	m.accountsByID[accountID] = profile
-	sessionToken = m.issueSessionLocked(accountID, now)
+	sessionToken = m.issueSessionLocked(accountID, now, client)
+	return accountID, sessionToken, nil
+}
+
+// LoginOAuth signs in the account linked to identity, creating it on first login.
+func (m *Manager) LoginOAuth(provider string, identity ExternalIdentity, client ClientInfo) (accountID uint64, sessionToken string, err error) {
+	subject := strings.TrimSpace(identity.Subject)
+	if provider == "" || subject == "" {
+		return 0, "", ErrInvalidCredentials
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	key := provider + ":" + subject
+	accountID, exists := m.identities[key]
+	if !exists {
+		m.nextAccountID++
+		accountID = m.nextAccountID
+		m.accountsByID[accountID] = accountRecord{
+			AccountID: accountID,
+			Username:  oauthDisplayName(identity, oauthUsername(provider)),
+		}
+		m.identities[key] = accountID
+	}
+
+	profile := m.accountsByID[accountID]
+	profile.LastLoginTime = now
+	m.accountsByID[accountID] = profile
+	sessionToken = m.issueSessionLocked(accountID, now, client)
 	return accountID, sessionToken, nil
 }
 
+// RequestPasswordReset issues a reset token for a registered account.
+func (m *Manager) RequestPasswordReset(username string) (resetToken string, err error) {
+	normalized := normalizeUsername(username)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	accountID, exists := m.accountsByKey[normalized]
+	if !exists || !m.accountsByID[accountID].Registered {
+		return "", ErrInvalidCredentials
+	}
+	resetToken = mustToken()
+	m.resets[resetToken] = sessionRecord{
+		AccountID: accountID,
+		ExpiresAt: time.Now().Add(m.resetTTL),
+	}
+	return resetToken, nil
+}
+
+// ResetPassword sets a new password with a reset token and revokes the
+// account's sessions and other reset tokens.
+func (m *Manager) ResetPassword(resetToken, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, exists := m.resets[resetToken]
+	if !exists {
+		return ErrInvalidResetToken
+	}
+	delete(m.resets, resetToken)
+	if !time.Now().Before(rec.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	profile := m.accountsByID[rec.AccountID]
+	profile.PasswordHash = passwordHash
+	m.accountsByID[rec.AccountID] = profile
+	for token, other := range m.resets {
+		if other.AccountID == rec.AccountID {
+			delete(m.resets, token)
+		}
+	}
+	for token, session := range m.sessions {
+		if session.AccountID == rec.AccountID {
+			delete(m.sessions, token)
+		}
+	}
+	return nil
+}
+
 // ResolveSession validates and refreshes a session token.
 func (m *Manager) ResolveSession(token string) (accountID uint64, username string, ok bool) {
 	m.mu.Lock()
@@ -197,10 +303,59 @@ func (m *Manager) Logout(token string) {
 	delete(m.sessions, token)
 }
 
+// ListSessions returns the account's unexpired sessions, most recently used first.
+func (m *Manager) ListSessions(accountID uint64) ([]SessionInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]SessionInfo, 0)
+	for token, rec := range m.sessions {
+		if rec.AccountID != accountID || !now.Before(rec.ExpiresAt) {
+			continue
+		}
+		sessions = append(sessions, SessionInfo{
+			TokenPrefix: sessionTokenPrefix(token),
+			IssuedAt:    rec.IssuedAt,
+			LastSeenAt:  rec.LastSeenAt,
+			UserAgent:   rec.UserAgent,
+			IP:          rec.IP,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// SessionExpiry reports the stored expiry of an unexpired session without
+// extending it.
+func (m *Manager) SessionExpiry(token string) (expiresAt time.Time, ttl time.Duration, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, exists := m.sessions[token]
+	if token == "" || !exists || !time.Now().Before(rec.ExpiresAt) {
+		return time.Time{}, 0, false
+	}
+	return rec.ExpiresAt, m.sessionTTL, true
+}
+
+// RevokeAllSessions invalidates every session of the account except exceptToken.
+func (m *Manager) RevokeAllSessions(accountID uint64, exceptToken string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for token, rec := range m.sessions {
+		if rec.AccountID == accountID && token != exceptToken {
+			delete(m.sessions, token)
+		}
+	}
+	return nil
+}
+
 // ResolveOrCreateAccount returns an account ID bound to token if valid;
 // otherwise it creates a new guest account and new token.
 // Deprecated: keep for compatibility with legacy flows/tests.
-func (m *Manager) ResolveOrCreateAccount(token string) (accountID uint64, sessionToken string, reused bool) {
+func (m *Manager) ResolveOrCreateAccount(token string, client ClientInfo) (accountID uint64, sessionToken string, reused bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -214,7 +369,7 @@ func (m *Manager) ResolveOrCreateAccount(token string) (accountID uint64, sessio
 	m.accountsByID[accountID] = accountRecord{
 		AccountID: accountID,
 	}
-	sessionToken = m.issueSessionLocked(accountID, now)
+	sessionToken = m.issueSessionLocked(accountID, now, client)
 	return accountID, sessionToken, false
 }
 
@@ -4,7 +4,7 @@ import "testing"
 
 func TestResolveOrCreateAccount_ReusesValidToken(t *testing.T) {
 	m := NewManager()
-	accountID1, token, reused := m.ResolveOrCreateAccount("")
+	accountID1, token, reused := m.ResolveOrCreateAccount("", ClientInfo{})
 	if accountID1 == 0 {
 		t.Fatalf("expected non-zero account id")
 	}
@@ -15,7 +15,7 @@ func TestResolveOrCreateAccount_ReusesValidToken(t *testing.T) {
 		t.Fatalf("new account should not be marked reused")
 	}
 
-	accountID2, token2, reused2 := m.ResolveOrCreateAccount(token)
+	accountID2, token2, reused2 := m.ResolveOrCreateAccount(token, ClientInfo{})
 	if !reused2 {
 		t.Fatalf("expected reused account for valid token")
 	}
@@ -29,8 +29,8 @@ func TestResolveOrCreateAccount_ReusesValidToken(t *testing.T) {
 
 func TestResolveOrCreateAccount_CreatesNewForUnknownToken(t *testing.T) {
 	m := NewManager()
-	accountID1, _, _ := m.ResolveOrCreateAccount("")
-	accountID2, token2, reused2 := m.ResolveOrCreateAccount("invalid-token")
+	accountID1, _, _ := m.ResolveOrCreateAccount("", ClientInfo{})
+	accountID2, token2, reused2 := m.ResolveOrCreateAccount("invalid-token", ClientInfo{})
 	if reused2 {
 		t.Fatalf("unknown token should not be reused")
 	}
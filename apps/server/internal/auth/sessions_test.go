@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testRevokeAllSessions checks that revoking all sessions but one leaves only
+// that one resolvable and listed.
+func testRevokeAllSessions(t *testing.T, svc Service) {
+	t.Helper()
+	accountID, first, err := svc.Register("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	_, second, err := svc.Login("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	_, kept, err := svc.Login("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	otherID, other, err := svc.Register("bob_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	sessions, err := svc.ListSessions(accountID)
+	if err != nil || len(sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %d err=%v", len(sessions), err)
+	}
+	if sessions[0].TokenPrefix != sessionTokenPrefix(kept) {
+		t.Fatalf("expected the latest session first, got %q", sessions[0].TokenPrefix)
+	}
+
+	if err := svc.RevokeAllSessions(accountID, kept); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	for _, token := range []string{first, second} {
+		if _, _, ok := svc.ResolveSession(token); ok {
+			t.Fatalf("expected revoked session %s to be invalid", sessionTokenPrefix(token))
+		}
+	}
+	if id, _, ok := svc.ResolveSession(kept); !ok || id != accountID {
+		t.Fatalf("expected the excepted session to stay valid")
+	}
+	if id, _, ok := svc.ResolveSession(other); !ok || id != otherID {
+		t.Fatalf("expected another account's session to stay valid")
+	}
+	sessions, err = svc.ListSessions(accountID)
+	if err != nil || len(sessions) != 1 || sessions[0].TokenPrefix != sessionTokenPrefix(kept) {
+		t.Fatalf("expected only the kept session listed, got %+v err=%v", sessions, err)
+	}
+}
+
+func TestManagerRevokeAllSessions(t *testing.T) {
+	testRevokeAllSessions(t, NewManager())
+}
+
+func TestSQLiteManagerRevokeAllSessions(t *testing.T) {
+	testRevokeAllSessions(t, newTestSQLiteManager(t))
+}
+
+func TestSessionsHTTP(t *testing.T) {
+	m := NewManager()
+	_, other, err := m.Register("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	_, current, err := m.Login("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewHTTPHandler(m).RegisterRoutes(mux)
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/api/auth/sessions", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", rec.Code)
+	}
+	rec := do(http.MethodGet, "/api/auth/sessions", current)
+	var listed sessionsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("list failed: code=%d err=%v", rec.Code, err)
+	}
+	if len(listed.Sessions) != 2 || !listed.Sessions[0].Current || listed.Sessions[1].Current {
+		t.Fatalf("expected the current session first and flagged, got %+v", listed.Sessions)
+	}
+
+	if rec := do(http.MethodPost, "/api/auth/sessions/revoke-all", current); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if _, _, ok := m.ResolveSession(other); ok {
+		t.Fatalf("expected the other session to be revoked")
+	}
+	if rec := do(http.MethodGet, "/api/auth/me", current); rec.Code != http.StatusOK {
+		t.Fatalf("expected the calling session to survive, got %d", rec.Code)
+	}
+}
+
+func TestSessionRecordsClientInfo(t *testing.T) {
+	db := newTestSQLiteManager(t)
+	for name, svc := range map[string]Service{"memory": NewManager(), "sqlite": db} {
+		mux := http.NewServeMux()
+		NewHTTPHandler(svc).RegisterRoutes(mux)
+		body, _ := json.Marshal(credentialsRequest{Username: "alice_01", Password: "secret12"})
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
+		req.Header.Set("User-Agent", "HoldemTest/1.0")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		var resp authResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: register failed: code=%d err=%v", name, rec.Code, err)
+		}
+
+		sessions, err := svc.ListSessions(resp.UserID)
+		if err != nil || len(sessions) != 1 {
+			t.Fatalf("%s: expected one session, got %d err=%v", name, len(sessions), err)
+		}
+		// httptest requests come from 192.0.2.1.
+		if sessions[0].UserAgent != "HoldemTest/1.0" || sessions[0].IP != "192.0.2.1" {
+			t.Fatalf("%s: expected client info on the session, got %+v", name, sessions[0])
+		}
+	}
+
+	var userAgent string
+	if err := db.db.QueryRow(`SELECT user_agent FROM auth_sessions`).Scan(&userAgent); err != nil {
+		t.Fatalf("read session row: %v", err)
+	}
+	if userAgent != "HoldemTest/1.0" {
+		t.Fatalf("expected stored user agent, got %q", userAgent)
+	}
+}
+
+// testSessionExpiry checks that a fresh session reports the full TTL, one
+// close to lapsing reports what is left, and asking does not renew it.
+func testSessionExpiry(t *testing.T, svc Service, ttl time.Duration, setExpiry func(token string, at time.Time)) {
+	t.Helper()
+	_, fresh, err := svc.Register("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	_, stale, err := svc.Login("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	expiresAt, gotTTL, ok := svc.SessionExpiry(fresh)
+	if !ok || gotTTL != ttl {
+		t.Fatalf("expected a valid session with ttl %v, got ttl=%v ok=%v", ttl, gotTTL, ok)
+	}
+	if remaining := time.Until(expiresAt); remaining < ttl-time.Minute || remaining > ttl {
+		t.Fatalf("expected a fresh session to report about %v, got %v", ttl, remaining)
+	}
+
+	setExpiry(stale, time.Now().Add(2*time.Minute))
+	for i := 0; i < 2; i++ {
+		expiresAt, _, ok = svc.SessionExpiry(stale)
+		if remaining := time.Until(expiresAt); !ok || remaining <= 0 || remaining > 2*time.Minute {
+			t.Fatalf("expected about 2m left on the stale session, got %v ok=%v", remaining, ok)
+		}
+	}
+
+	setExpiry(stale, time.Now().Add(-time.Second))
+	if _, _, ok := svc.SessionExpiry(stale); ok {
+		t.Fatalf("expected an expired session to report invalid")
+	}
+	if _, _, ok := svc.SessionExpiry("nope"); ok {
+		t.Fatalf("expected an unknown token to report invalid")
+	}
+}
+
+func TestManagerSessionExpiry(t *testing.T) {
+	m := NewManager()
+	testSessionExpiry(t, m, defaultSessionTTL, func(token string, at time.Time) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		rec := m.sessions[token]
+		rec.ExpiresAt = at
+		m.sessions[token] = rec
+	})
+}
+
+func TestSQLiteManagerSessionExpiry(t *testing.T) {
+	m := newTestSQLiteManager(t)
+	testSessionExpiry(t, m, time.Hour, func(token string, at time.Time) {
+		if _, err := m.db.Exec(`UPDATE auth_sessions SET expires_at_ms = ? WHERE token = ?`, at.UnixMilli(), token); err != nil {
+			t.Fatalf("set expiry: %v", err)
+		}
+	})
+}
+
+func TestSessionExpiryHTTP(t *testing.T) {
+	m := NewManager()
+	_, token, err := m.Register("alice_01", "secret12", ClientInfo{})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewHTTPHandler(m).RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/auth/session/expiry", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/session/expiry", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var resp sessionExpiryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("expiry failed: code=%d err=%v", rec.Code, err)
+	}
+	if resp.TTLMs != defaultSessionTTL.Milliseconds() || resp.RemainingMs <= 0 || resp.RemainingMs > resp.TTLMs {
+		t.Fatalf("unexpected expiry response %+v", resp)
+	}
+}
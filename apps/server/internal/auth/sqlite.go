@@ -20,6 +20,7 @@ const defaultLocalDBName = "holdem_local.db"
 type SQLiteManager struct {
 	db         *sql.DB
 	sessionTTL time.Duration
+	resetTTL   time.Duration
 }
 
 func NewSQLiteManagerFromEnv() (*SQLiteManager, error) {
@@ -81,6 +82,7 @@ func NewSQLiteManager(dbPath string, sessionTTL time.Duration) (*SQLiteManager,
 	return &SQLiteManager{
 		db:         db,
 		sessionTTL: sessionTTL,
+		resetTTL:   defaultPasswordResetTTL,
 	}, nil
 }
 
@@ -91,7 +93,7 @@ func (m *SQLiteManager) Close() error {
 	return m.db.Close()
 }
 
-func (m *SQLiteManager) Register(username, password string) (accountID uint64, sessionToken string, err error) {
+func (m *SQLiteManager) Register(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error) {
 	if err = validateUsername(username); err != nil {
 		return 0, "", err
 	}
@@ -144,7 +146,7 @@ VALUES (?, 'local', ?, ?, ?, ?)
 		return 0, "", err
 	}
 
-	sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs)
+	sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs, client)
 	if err != nil {
 		return 0, "", err
 	}
@@ -154,7 +156,7 @@ VALUES (?, 'local', ?, ?, ?, ?)
 	return accountID, sessionToken, nil
 }
 
-func (m *SQLiteManager) Login(username, password string) (accountID uint64, sessionToken string, err error) {
+func (m *SQLiteManager) Login(username, password string, client ClientInfo) (accountID uint64, sessionToken string, err error) {
 	normalized := normalizeUsername(username)
 	if normalized == "" || password == "" {
 		return 0, "", ErrInvalidCredentials
@@ -196,7 +198,7 @@ WHERE id = ?
 		return 0, "", err
 	}
 
-	sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs)
+	sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs, client)
 	if err != nil {
 		return 0, "", err
 	}
@@ -206,6 +208,211 @@ WHERE id = ?
 	return accountID, sessionToken, nil
 }
 
+func (m *SQLiteManager) LoginOAuth(provider string, identity ExternalIdentity, client ClientInfo) (accountID uint64, sessionToken string, err error) {
+	subject := strings.TrimSpace(identity.Subject)
+	if provider == "" || subject == "" {
+		return 0, "", ErrInvalidCredentials
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A unique violation means a generated username collided or a concurrent
+	// first login linked the identity; either way the next attempt resolves it.
+	for i := 0; i < 5; i++ {
+		tx, err := m.db.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, "", err
+		}
+
+		nowMs := time.Now().UTC().UnixMilli()
+		accountID, err = m.upsertOAuthAccountTx(ctx, tx, provider, subject, identity, nowMs)
+		if err != nil {
+			_ = tx.Rollback()
+			if isSQLiteUniqueViolation(err) {
+				continue
+			}
+			return 0, "", err
+		}
+
+		sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs, client)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, "", err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, "", err
+		}
+		return accountID, sessionToken, nil
+	}
+
+	return 0, "", fmt.Errorf("failed to link %s identity", provider)
+}
+
+func (m *SQLiteManager) upsertOAuthAccountTx(ctx context.Context, tx *sql.Tx, provider, subject string, identity ExternalIdentity, nowMs int64) (uint64, error) {
+	var accountID uint64
+	err := tx.QueryRowContext(ctx, `
+SELECT account_id
+FROM auth_identities
+WHERE provider = ?
+  AND provider_subject = ?
+`, provider, subject).Scan(&accountID)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `
+UPDATE accounts
+SET last_login_at_ms = ?,
+    updated_at_ms = ?
+WHERE id = ?
+`, nowMs, nowMs, accountID)
+		return accountID, err
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	username := oauthUsername(provider)
+	res, err := tx.ExecContext(ctx, `
+INSERT INTO accounts (
+    username, display_name, status, created_at_ms, updated_at_ms, last_login_at_ms
+)
+VALUES (?, ?, 1, ?, ?, ?)
+`, username, oauthDisplayName(identity, username), nowMs, nowMs, nowMs)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	accountID = uint64(id)
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO auth_identities (
+    account_id, provider, provider_subject, created_at_ms, updated_at_ms
+)
+VALUES (?, ?, ?, ?, ?)
+`, accountID, provider, subject, nowMs, nowMs); err != nil {
+		return 0, err
+	}
+	return accountID, nil
+}
+
+func (m *SQLiteManager) RequestPasswordReset(username string) (resetToken string, err error) {
+	normalized := normalizeUsername(username)
+	if normalized == "" {
+		return "", ErrInvalidCredentials
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var accountID uint64
+	err = m.db.QueryRowContext(ctx, `
+SELECT account_id
+FROM auth_identities
+WHERE provider = 'local'
+  AND provider_subject = ?
+`, normalized).Scan(&accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrInvalidCredentials
+		}
+		return "", err
+	}
+
+	nowMs := time.Now().UTC().UnixMilli()
+	expiresAtMs := nowMs + m.resetTTL.Milliseconds()
+	for i := 0; i < 5; i++ {
+		resetToken = mustToken()
+		if _, err := m.db.ExecContext(ctx, `
+INSERT INTO auth_password_resets (
+    token, account_id, created_at_ms, expires_at_ms
+)
+VALUES (?, ?, ?, ?)
+`, resetToken, accountID, nowMs, expiresAtMs); err != nil {
+			if isSQLiteUniqueViolation(err) {
+				continue
+			}
+			return "", err
+		}
+		return resetToken, nil
+	}
+	return "", fmt.Errorf("failed to generate unique reset token")
+}
+
+func (m *SQLiteManager) ResetPassword(resetToken, newPassword string) error {
+	resetToken = strings.TrimSpace(resetToken)
+	if resetToken == "" {
+		return ErrInvalidResetToken
+	}
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	nowMs := time.Now().UTC().UnixMilli()
+	res, err := tx.ExecContext(ctx, `
+UPDATE auth_password_resets
+SET used_at_ms = ?
+WHERE token = ?
+  AND used_at_ms IS NULL
+  AND expires_at_ms > ?
+`, nowMs, resetToken, nowMs)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrInvalidResetToken
+	}
+
+	var accountID uint64
+	if err := tx.QueryRowContext(ctx, `
+SELECT account_id
+FROM auth_password_resets
+WHERE token = ?
+`, resetToken).Scan(&accountID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+UPDATE auth_identities
+SET password_hash = ?,
+    updated_at_ms = ?
+WHERE account_id = ?
+  AND provider = 'local'
+`, string(passwordHash), nowMs, accountID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE auth_password_resets
+SET used_at_ms = ?
+WHERE account_id = ?
+  AND used_at_ms IS NULL
+`, nowMs, accountID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE auth_sessions
+SET revoked_at_ms = ?
+WHERE account_id = ?
+  AND revoked_at_ms IS NULL
+`, nowMs, accountID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func (m *SQLiteManager) ResolveSession(token string) (accountID uint64, username string, ok bool) {
 	token = strings.TrimSpace(token)
 	if token == "" {
@@ -270,7 +477,77 @@ WHERE token = ?
 `, nowMs, token)
 }
 
-func (m *SQLiteManager) ResolveOrCreateAccount(token string) (accountID uint64, sessionToken string, reused bool) {
+func (m *SQLiteManager) ListSessions(accountID uint64) ([]SessionInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, `
+SELECT token, issued_at_ms, last_seen_at_ms, COALESCE(user_agent, ''), COALESCE(ip, '')
+FROM auth_sessions
+WHERE account_id = ?
+  AND revoked_at_ms IS NULL
+  AND expires_at_ms > ?
+ORDER BY last_seen_at_ms DESC
+`, accountID, time.Now().UTC().UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]SessionInfo, 0)
+	for rows.Next() {
+		var (
+			token                  string
+			issuedAtMs, lastSeenMs int64
+			info                   SessionInfo
+		)
+		if err := rows.Scan(&token, &issuedAtMs, &lastSeenMs, &info.UserAgent, &info.IP); err != nil {
+			return nil, err
+		}
+		info.TokenPrefix = sessionTokenPrefix(token)
+		info.IssuedAt = time.UnixMilli(issuedAtMs).UTC()
+		info.LastSeenAt = time.UnixMilli(lastSeenMs).UTC()
+		sessions = append(sessions, info)
+	}
+	return sessions, rows.Err()
+}
+
+func (m *SQLiteManager) SessionExpiry(token string) (expiresAt time.Time, ttl time.Duration, ok bool) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return time.Time{}, 0, false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var expiresAtMs int64
+	err := m.db.QueryRowContext(ctx, `
+SELECT expires_at_ms
+FROM auth_sessions
+WHERE token = ?
+  AND revoked_at_ms IS NULL
+  AND expires_at_ms > ?
+`, token, time.Now().UTC().UnixMilli()).Scan(&expiresAtMs)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return time.UnixMilli(expiresAtMs).UTC(), m.sessionTTL, true
+}
+
+func (m *SQLiteManager) RevokeAllSessions(accountID uint64, exceptToken string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := m.db.ExecContext(ctx, `
+UPDATE auth_sessions
+SET revoked_at_ms = ?
+WHERE account_id = ?
+  AND token <> ?
+  AND revoked_at_ms IS NULL
+`, time.Now().UTC().UnixMilli(), accountID, strings.TrimSpace(exceptToken))
+	return err
+}
+
+func (m *SQLiteManager) ResolveOrCreateAccount(token string, client ClientInfo) (accountID uint64, sessionToken string, reused bool) {
 	token = strings.TrimSpace(token)
 	if token != "" {
 		if accountID, _, ok := m.ResolveSession(token); ok {
@@ -322,7 +599,7 @@ VALUES (?, 'guest', ?, ?, ?)
 			return 0, "", false
 		}
 
-		sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs)
+		sessionToken, err = m.issueSessionTx(ctx, tx, accountID, nowMs, client)
 		if err != nil {
 			_ = tx.Rollback()
 			return 0, "", false
@@ -336,16 +613,16 @@ VALUES (?, 'guest', ?, ?, ?)
 	return 0, "", false
 }
 
-func (m *SQLiteManager) issueSessionTx(ctx context.Context, tx *sql.Tx, accountID uint64, nowMs int64) (string, error) {
+func (m *SQLiteManager) issueSessionTx(ctx context.Context, tx *sql.Tx, accountID uint64, nowMs int64, client ClientInfo) (string, error) {
 	expiresAtMs := nowMs + m.sessionTTL.Milliseconds()
 	for i := 0; i < 5; i++ {
 		token := mustToken()
 		if _, err := tx.ExecContext(ctx, `
 INSERT INTO auth_sessions (
-    token, account_id, issued_at_ms, expires_at_ms, last_seen_at_ms
+    token, account_id, issued_at_ms, expires_at_ms, last_seen_at_ms, user_agent, ip
 )
-VALUES (?, ?, ?, ?, ?)
-`, token, accountID, nowMs, expiresAtMs, nowMs); err != nil {
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`, token, accountID, nowMs, expiresAtMs, nowMs, nullIfEmpty(client.UserAgent), nullIfEmpty(client.IP)); err != nil {
 			if isSQLiteUniqueViolation(err) {
 				continue
 			}
@@ -396,6 +673,16 @@ CREATE TABLE IF NOT EXISTS auth_sessions (
 )`,
 		`CREATE INDEX IF NOT EXISTS idx_auth_sessions_account ON auth_sessions(account_id, expires_at_ms DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_auth_sessions_active ON auth_sessions(expires_at_ms, revoked_at_ms)`,
+		`
+CREATE TABLE IF NOT EXISTS auth_password_resets (
+    token TEXT PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    created_at_ms INTEGER NOT NULL,
+    expires_at_ms INTEGER NOT NULL,
+    used_at_ms INTEGER,
+    FOREIGN KEY(account_id) REFERENCES accounts(id) ON DELETE CASCADE
+)`,
+		`CREATE INDEX IF NOT EXISTS idx_auth_password_resets_account ON auth_password_resets(account_id)`,
 	}
 
 	for _, stmt := range statements {
@@ -0,0 +1,96 @@
+package gateway
+
+import "fmt"
+
+// MultiDevicePolicy decides what happens when an account that is already
+// connected logs in from another device.
+type MultiDevicePolicy uint8
+
+const (
+	// MultiDeviceReplace closes the older connection; the new device takes
+	// over the seat.
+	MultiDeviceReplace MultiDevicePolicy = iota
+	// MultiDeviceMirror keeps every device connected and mirrors table
+	// broadcasts to all of them, but only the active device may act. A device
+	// becomes active by connecting or by (re)joining the table.
+	MultiDeviceMirror
+)
+
+// ParseMultiDevicePolicy maps a config value ("replace" or "mirror") to a
+// policy.
+func ParseMultiDevicePolicy(s string) (MultiDevicePolicy, error) {
+	switch s {
+	case "", "replace":
+		return MultiDeviceReplace, nil
+	case "mirror":
+		return MultiDeviceMirror, nil
+	}
+	return MultiDeviceReplace, fmt.Errorf("unknown multi-device policy %q", s)
+}
+
+// SetMultiDevicePolicy sets how later logins from a second device are
+// handled.
+func (g *Gateway) SetMultiDevicePolicy(p MultiDevicePolicy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.multiDevice = p
+}
+
+// addDeviceLocked records c as one more live connection of its user.
+// Caller must hold g.mu.
+func (g *Gateway) addDeviceLocked(c *Connection) {
+	if g.devices == nil {
+		g.devices = make(map[uint64][]*Connection)
+	}
+	g.devices[c.UserID] = append(g.devices[c.UserID], c)
+}
+
+// removeDeviceLocked forgets c and returns the user's most recently connected
+// remaining device, or nil. Caller must hold g.mu.
+func (g *Gateway) removeDeviceLocked(c *Connection) *Connection {
+	conns := g.devices[c.UserID]
+	kept := conns[:0]
+	for _, other := range conns {
+		if other != c {
+			kept = append(kept, other)
+		}
+	}
+	if len(kept) == 0 {
+		delete(g.devices, c.UserID)
+		return nil
+	}
+	g.devices[c.UserID] = kept
+	return kept[len(kept)-1]
+}
+
+// activate makes c the device its user acts from.
+func (g *Gateway) activate(c *Connection) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.multiDevice == MultiDeviceMirror && g.userConns[c.UserID] != nil {
+		g.userConns[c.UserID] = c
+	}
+}
+
+// isActive reports whether actions from c should reach the table. Under
+// MultiDeviceReplace there is only ever one connection per user.
+func (g *Gateway) isActive(c *Connection) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.multiDevice != MultiDeviceMirror {
+		return true
+	}
+	active := g.userConns[c.UserID]
+	return active == nil || active == c
+}
+
+// staleDevice reports whether another device has taken over c's account, in
+// which case it answers c with an error of code: only the active connection
+// may change table state.
+func (c *Connection) staleDevice(code int32) bool {
+	if c.Gateway.isActive(c) {
+		return false
+	}
+	c.sendError(code, "account is playing on another device; rejoin the table to play here")
+	return true
+}
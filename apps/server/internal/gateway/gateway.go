@@ -1,6 +1,8 @@
 package gateway
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -11,6 +13,7 @@ import (
 
 	pb "holdem-lite/apps/server/gen"
 	"holdem-lite/apps/server/internal/auth"
+	"holdem-lite/apps/server/internal/ledger"
 	"holdem-lite/apps/server/internal/lobby"
 	"holdem-lite/apps/server/internal/table"
 	"holdem-lite/holdem"
@@ -51,6 +54,14 @@ type Gateway struct {
 	nextConnID  uint64
 	lobby       *lobby.Lobby
 	auth        auth.Service
+	ledger      ledger.Service
+
+	// multiDevice picks how a second login of the same account is handled;
+	// devices holds every live connection per user, oldest first.
+	multiDevice MultiDevicePolicy
+	devices     map[uint64][]*Connection
+
+	payloadPolicy PayloadPolicy
 }
 
 // New creates a new Gateway instance
@@ -61,11 +72,19 @@ func New(lby *lobby.Lobby, authManager auth.Service) *Gateway {
 	return &Gateway{
 		connections: make(map[string]*Connection),
 		userConns:   make(map[uint64]*Connection),
+		devices:     make(map[uint64][]*Connection),
 		lobby:       lby,
 		auth:        authManager,
 	}
 }
 
+// SetLedger gives the gateway the hand-history store that ListHands reads.
+func (g *Gateway) SetLedger(svc ledger.Service) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ledger = svc
+}
+
 // HandleWebSocket handles WebSocket upgrade and connection
 func (g *Gateway) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	providedToken := r.URL.Query().Get("session_token")
@@ -81,14 +100,7 @@ func (g *Gateway) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var oldConn *Connection
-	var resumeTable *table.Table
-	g.mu.Lock()
-	g.nextConnID++
-	connID := fmt.Sprintf("conn_%d", g.nextConnID)
-
 	c := &Connection{
-		ID:           connID,
 		UserID:       userID,
 		DisplayName:  displayName,
 		SessionToken: providedToken,
@@ -97,18 +109,9 @@ func (g *Gateway) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		Gateway:      g,
 		LastPing:     time.Now(),
 	}
-	if existing := g.userConns[userID]; existing != nil && existing != c {
-		oldConn = existing
-		resumeTable = existing.Table
-		c.TableID = existing.TableID
-		c.Table = existing.Table
-	}
-	g.connections[connID] = c
-	g.userConns[userID] = c
-	g.mu.Unlock()
-
-	if oldConn != nil {
-		_ = oldConn.Conn.Close()
+	replaced, resumeTable := g.attach(c)
+	if replaced != nil {
+		_ = replaced.Conn.Close()
 	}
 	if resumeTable != nil {
 		if err := resumeTable.SubmitEvent(table.Event{
@@ -120,7 +123,7 @@ func (g *Gateway) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	log.Printf("[Gateway] Client connected: %s (userID=%d), total: %d", connID, userID, len(g.connections))
+	log.Printf("[Gateway] Client connected: %s (userID=%d), total: %d", c.ID, userID, len(g.connections))
 
 	// Send initial LoginResponse
 	c.SendLoginResponse()
@@ -130,6 +133,28 @@ func (g *Gateway) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	go c.writePump()
 }
 
+// attach registers c as its user's active connection, taking over the table
+// of the previous one. replaced is the older connection to close under
+// MultiDeviceReplace; under MultiDeviceMirror it stays open.
+func (g *Gateway) attach(c *Connection) (replaced *Connection, resumeTable *table.Table) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nextConnID++
+	c.ID = fmt.Sprintf("conn_%d", g.nextConnID)
+	if existing := g.userConns[c.UserID]; existing != nil && existing != c {
+		if g.multiDevice != MultiDeviceMirror {
+			replaced = existing
+		}
+		resumeTable = existing.Table
+		c.TableID = existing.TableID
+		c.Table = existing.Table
+	}
+	g.connections[c.ID] = c
+	g.userConns[c.UserID] = c
+	g.addDeviceLocked(c)
+	return replaced, resumeTable
+}
+
 func (c *Connection) SendLoginResponse() {
 	env := &pb.ServerEnvelope{
 		ServerSeq:  0, // Special seq for handshake
@@ -196,6 +221,9 @@ func (c *Connection) handleMessage(data []byte) {
 	}
 
 	log.Printf("[Gateway] Received from user %d: table=%s, payload=%T", c.UserID, env.TableId, env.Payload)
+	if !c.checkPayload(&env) {
+		return
+	}
 
 	switch payload := env.Payload.(type) {
 	case *pb.ClientEnvelope_JoinTable:
@@ -204,21 +232,43 @@ func (c *Connection) handleMessage(data []byte) {
 		c.handleSitDown(&env, payload.SitDown)
 	case *pb.ClientEnvelope_StandUp:
 		c.handleStandUp(&env, payload.StandUp)
+	case *pb.ClientEnvelope_BuyIn:
+		c.handleBuyIn(&env, payload.BuyIn)
 	case *pb.ClientEnvelope_Action:
 		c.handleAction(&env, payload.Action)
 	case *pb.ClientEnvelope_StartStory:
 		c.handleStartStory(&env, payload.StartStory)
+	case *pb.ClientEnvelope_Straddle:
+		c.handleStraddle(&env, payload.Straddle)
+	case *pb.ClientEnvelope_CashOut:
+		c.handleCashOut(&env, payload.CashOut)
+	case *pb.ClientEnvelope_RunItTwice:
+		c.handleRunItTwice(&env, payload.RunItTwice)
+	case *pb.ClientEnvelope_RunItTwiceAccept:
+		c.handleRunItTwiceAccept(&env, payload.RunItTwiceAccept)
+	case *pb.ClientEnvelope_SitOut:
+		c.handleSitOut(table.EventSitOut)
+	case *pb.ClientEnvelope_SitIn:
+		c.handleSitOut(table.EventSitIn)
+	case *pb.ClientEnvelope_ListHands:
+		c.handleListHands(&env, payload.ListHands)
+	case *pb.ClientEnvelope_RequestSnapshot:
+		c.handleRequestSnapshot(&env, payload.RequestSnapshot)
+	case *pb.ClientEnvelope_Rematch:
+		c.handleRematch(&env, payload.Rematch)
 	default:
 		log.Printf("[Gateway] Unknown payload type: %T", env.Payload)
 	}
 }
 
 func (c *Connection) handleJoinTable(env *pb.ClientEnvelope, req *pb.JoinTableRequest) {
+	c.Gateway.activate(c)
 	t := c.Table
-	if t == nil {
-		// Quick start: find or create a table
+	if t == nil || t.IsClosed() {
+		// Quick start: find or create a table (the previous one may have
+		// closed, e.g. at the end of its session)
 		var err error
-		t, err = c.Gateway.lobby.QuickStart(c.UserID, c.Gateway.broadcastToUser)
+		t, err = c.Gateway.lobby.QuickStart(c.UserID, req.GetStake(), c.Gateway.broadcastToUser)
 		if err != nil {
 			c.sendError(2, err.Error())
 			return
@@ -228,9 +278,13 @@ func (c *Connection) handleJoinTable(env *pb.ClientEnvelope, req *pb.JoinTableRe
 	c.TableID = t.ID
 	c.Table = t
 
-	// Join the table
+	// Join the table, or just watch it
+	eventType := table.EventJoinTable
+	if req.GetObserve() {
+		eventType = table.EventObserve
+	}
 	if err := t.SubmitEvent(table.Event{
-		Type:     table.EventJoinTable,
+		Type:     eventType,
 		UserID:   c.UserID,
 		Nickname: c.DisplayName,
 	}); err != nil {
@@ -240,7 +294,36 @@ func (c *Connection) handleJoinTable(env *pb.ClientEnvelope, req *pb.JoinTableRe
 		return
 	}
 
-	log.Printf("[Gateway] User %d joined table %s", c.UserID, t.ID)
+	log.Printf("[Gateway] User %d joined table %s (observe=%v)", c.UserID, t.ID, req.GetObserve())
+}
+
+// handleRematch seats the user at a rematch of their last heads-up session,
+// or restarts their last story chapter.
+func (c *Connection) handleRematch(env *pb.ClientEnvelope, req *pb.RematchRequest) {
+	t, chapterID, err := c.Gateway.lobby.Rematch(c.UserID, c.Gateway.broadcastToUser)
+	if err != nil {
+		c.sendError(2, err.Error())
+		return
+	}
+	if chapterID > 0 {
+		c.handleStartStory(env, &pb.StartStoryRequest{ChapterId: int32(chapterID)})
+		return
+	}
+
+	c.Gateway.activate(c)
+	c.TableID = t.ID
+	c.Table = t
+	if err := t.SubmitEvent(table.Event{
+		Type:     table.EventJoinTable,
+		UserID:   c.UserID,
+		Nickname: c.DisplayName,
+	}); err != nil {
+		c.sendError(2, err.Error())
+		c.TableID = ""
+		c.Table = nil
+		return
+	}
+	log.Printf("[Gateway] User %d joined rematch table %s", c.UserID, t.ID)
 }
 
 func (c *Connection) handleStartStory(env *pb.ClientEnvelope, req *pb.StartStoryRequest) {
@@ -339,6 +422,9 @@ func (c *Connection) handleSitDown(env *pb.ClientEnvelope, req *pb.SitDownReques
 		c.sendError(3, "not in a table")
 		return
 	}
+	if c.staleDevice(4) {
+		return
+	}
 
 	err := c.Table.SubmitEvent(table.Event{
 		Type:   table.EventSitDown,
@@ -355,6 +441,9 @@ func (c *Connection) handleStandUp(env *pb.ClientEnvelope, req *pb.StandUpReques
 	if c.Table == nil {
 		return
 	}
+	if c.staleDevice(4) {
+		return
+	}
 
 	if err := c.Table.SubmitEvent(table.Event{
 		Type:   table.EventStandUp,
@@ -364,11 +453,187 @@ func (c *Connection) handleStandUp(env *pb.ClientEnvelope, req *pb.StandUpReques
 	}
 }
 
+func (c *Connection) handleStraddle(env *pb.ClientEnvelope, req *pb.StraddleRequest) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+	if c.staleDevice(4) {
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   table.EventStraddle,
+		UserID: c.UserID,
+		Chair:  uint16(req.Chair),
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+func (c *Connection) handleBuyIn(env *pb.ClientEnvelope, req *pb.BuyInRequest) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+	if c.staleDevice(4) {
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   table.EventBuyIn,
+		UserID: c.UserID,
+		Amount: req.Amount,
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+// handleSitOut forwards a sit-out (EventSitOut) or sit-in (EventSitIn).
+func (c *Connection) handleSitOut(eventType table.EventType) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+	if c.staleDevice(4) {
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   eventType,
+		UserID: c.UserID,
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+func (c *Connection) handleCashOut(env *pb.ClientEnvelope, req *pb.CashOutRequest) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+	if c.staleDevice(4) {
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   table.EventCashOut,
+		UserID: c.UserID,
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+func (c *Connection) handleRunItTwice(env *pb.ClientEnvelope, req *pb.RunItTwiceRequest) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+	if c.staleDevice(4) {
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   table.EventRunItTwice,
+		UserID: c.UserID,
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+func (c *Connection) handleRunItTwiceAccept(env *pb.ClientEnvelope, req *pb.RunItTwiceAcceptRequest) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+	if c.staleDevice(4) {
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   table.EventRunItTwiceAccept,
+		UserID: c.UserID,
+		Accept: req.GetAccept(),
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+func (c *Connection) handleRequestSnapshot(env *pb.ClientEnvelope, req *pb.RequestSnapshotRequest) {
+	if c.Table == nil {
+		c.sendError(3, "not in a table")
+		return
+	}
+
+	if err := c.Table.SubmitEvent(table.Event{
+		Type:   table.EventRequestSnapshot,
+		UserID: c.UserID,
+	}); err != nil {
+		c.sendError(4, err.Error())
+	}
+}
+
+// handleListHands answers with the connection user's recent hands, the same
+// list GET /api/audit/{source}/recent serves.
+func (c *Connection) handleListHands(env *pb.ClientEnvelope, req *pb.ListHandsRequest) {
+	c.Gateway.mu.RLock()
+	svc := c.Gateway.ledger
+	c.Gateway.mu.RUnlock()
+	if svc == nil {
+		c.sendError(11, "hand history not available")
+		return
+	}
+	source := ledger.Source(req.Source)
+	if source == "" {
+		source = ledger.SourceLive
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	items, err := svc.ListRecent(ctx, c.UserID, source, int(req.Limit), ledger.HistoryFilter{})
+	if err != nil {
+		c.sendError(11, fmt.Sprintf("list hands: %v", err))
+		return
+	}
+
+	list := &pb.HandList{Source: string(source), Items: make([]*pb.HandListItem, 0, len(items))}
+	for _, item := range items {
+		summary, err := json.Marshal(item.Summary)
+		if err != nil {
+			log.Printf("[Gateway] Failed to marshal summary of hand %s: %v", item.HandID, err)
+			summary = []byte("{}")
+		}
+		out := &pb.HandListItem{
+			HandId:      item.HandID,
+			PlayedAtMs:  item.PlayedAt.UnixMilli(),
+			IsSaved:     item.IsSaved,
+			SummaryJson: string(summary),
+		}
+		if item.SavedAt != nil {
+			out.SavedAtMs = item.SavedAt.UnixMilli()
+		}
+		list.Items = append(list.Items, out)
+	}
+	data, err := proto.Marshal(&pb.ServerEnvelope{
+		TableId:    env.TableId,
+		ServerTsMs: time.Now().UnixMilli(),
+		Payload:    &pb.ServerEnvelope_HandList{HandList: list},
+	})
+	if err != nil {
+		log.Printf("[Gateway] Failed to marshal hand list: %v", err)
+		return
+	}
+	c.Send <- data
+}
+
 func (c *Connection) handleAction(env *pb.ClientEnvelope, req *pb.ActionRequest) {
 	if c.Table == nil {
 		c.sendError(3, "not in a table")
 		return
 	}
+	if c.staleDevice(5) {
+		return
+	}
 
 	// Convert proto action to holdem action
 	action := protoToAction(req.Action)
@@ -378,9 +643,14 @@ func (c *Connection) handleAction(env *pb.ClientEnvelope, req *pb.ActionRequest)
 		UserID: c.UserID,
 		Action: action,
 		Amount: req.Amount,
+		Preset: protoToSizingPreset(req.SizingPreset),
 	})
 	if err != nil {
-		c.sendError(5, err.Error())
+		c.sendErrorResponse(&pb.ErrorResponse{
+			Code:          5,
+			Message:       err.Error(),
+			ActionOptions: c.Table.ActionOptions(c.UserID),
+		})
 	}
 }
 
@@ -403,16 +673,30 @@ func protoToAction(a pb.ActionType) holdem.ActionType {
 	}
 }
 
+func protoToSizingPreset(p pb.SizingPreset) table.SizingPreset {
+	switch p {
+	case pb.SizingPreset_SIZING_PRESET_HALF_POT:
+		return table.SizingPresetHalfPot
+	case pb.SizingPreset_SIZING_PRESET_THREE_QUARTER_POT:
+		return table.SizingPresetThreeQuarterPot
+	case pb.SizingPreset_SIZING_PRESET_POT:
+		return table.SizingPresetPot
+	default:
+		return table.SizingPresetNone
+	}
+}
+
 func (c *Connection) sendError(code int32, msg string) {
+	c.sendErrorResponse(&pb.ErrorResponse{Code: code, Message: msg})
+}
+
+func (c *Connection) sendErrorResponse(resp *pb.ErrorResponse) {
 	env := &pb.ServerEnvelope{
 		TableId:    c.TableID,
 		ServerSeq:  atomic.AddUint64(&c.Gateway.nextConnID, 1), // Use as simple seq
 		ServerTsMs: time.Now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_Error{
-			Error: &pb.ErrorResponse{
-				Code:    code,
-				Message: msg,
-			},
+			Error: resp,
 		},
 	}
 	data, _ := proto.Marshal(env)
@@ -449,10 +733,19 @@ func (c *Connection) writePump() {
 }
 
 func (g *Gateway) removeConnection(c *Connection) {
-	g.mu.RLock()
-	current := g.userConns[c.UserID]
-	isCurrent := current == c
-	g.mu.RUnlock()
+	g.mu.Lock()
+	next := g.removeDeviceLocked(c)
+	isCurrent := g.userConns[c.UserID] == c
+	if isCurrent && next != nil && g.multiDevice == MultiDeviceMirror {
+		// Another device of the account is still connected and takes over,
+		// so the table never sees the player drop.
+		if next.Table == nil {
+			next.TableID, next.Table = c.TableID, c.Table
+		}
+		g.userConns[c.UserID] = next
+		isCurrent = false
+	}
+	g.mu.Unlock()
 
 	if isCurrent && c.Table != nil {
 		if g.lobby != nil && c.TableID != "" {
@@ -475,13 +768,19 @@ func (g *Gateway) removeConnection(c *Connection) {
 	log.Printf("[Gateway] Client disconnected: %s, total: %d", c.ID, len(g.connections))
 }
 
-// broadcastToUser sends a message to a specific user
+// broadcastToUser sends a message to a specific user, on every device under
+// MultiDeviceMirror.
 func (g *Gateway) broadcastToUser(userID uint64, data []byte) {
 	g.mu.RLock()
-	c := g.userConns[userID]
+	var targets []*Connection
+	if g.multiDevice == MultiDeviceMirror {
+		targets = append(targets, g.devices[userID]...)
+	} else if c := g.userConns[userID]; c != nil {
+		targets = append(targets, c)
+	}
 	g.mu.RUnlock()
 
-	if c != nil {
+	for _, c := range targets {
 		select {
 		case c.Send <- data:
 		default:
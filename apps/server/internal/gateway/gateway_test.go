@@ -0,0 +1,359 @@
+package gateway
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/apps/server/internal/ledger"
+	"holdem-lite/apps/server/internal/table"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandleAction_RejectionCarriesLegalActions(t *testing.T) {
+	tbl, err := table.NewTableForTest(table.TableConfig{
+		MaxPlayers: 6,
+		SmallBlind: 50,
+		BigBlind:   100,
+		MinBuyIn:   1000,
+		MaxBuyIn:   1000,
+	}, nil, nil, table.NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(table.Event{Type: table.EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.Snapshot()
+	var actor, waiting uint64
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			actor = ps.ID
+		} else {
+			waiting = ps.ID
+		}
+	}
+
+	rejected := func(userID uint64, req *pb.ActionRequest) *pb.ErrorResponse {
+		t.Helper()
+		conn := &Connection{UserID: userID, Send: make(chan []byte, 4), Gateway: &Gateway{}, Table: tbl}
+		conn.handleAction(&pb.ClientEnvelope{}, req)
+		select {
+		case data := <-conn.Send:
+			var env pb.ServerEnvelope
+			if err := proto.Unmarshal(data, &env); err != nil {
+				t.Fatalf("unmarshal err: %v", err)
+			}
+			if env.GetError() == nil {
+				t.Fatalf("expected an error envelope, got %T", env.GetPayload())
+			}
+			return env.GetError()
+		default:
+			t.Fatalf("expected the action to be rejected")
+			return nil
+		}
+	}
+
+	// The small blind faces the big blind, so checking is illegal.
+	resp := rejected(actor, &pb.ActionRequest{Action: pb.ActionType_ACTION_CHECK})
+	opts := resp.GetActionOptions()
+	if opts == nil || opts.GetActionChair() != uint32(snap.ActionChair) {
+		t.Fatalf("expected action options for chair %d, got %v", snap.ActionChair, opts)
+	}
+	legal := make(map[pb.ActionType]bool)
+	for _, a := range opts.GetLegalActions() {
+		legal[a] = true
+	}
+	if legal[pb.ActionType_ACTION_CHECK] || !legal[pb.ActionType_ACTION_CALL] || !legal[pb.ActionType_ACTION_FOLD] {
+		t.Fatalf("expected fold/call without check, got %v", opts.GetLegalActions())
+	}
+	if opts.GetCallAmount() != 50 || opts.GetMinRaiseTo() <= 100 {
+		t.Fatalf("expected call 50 and a raise above the big blind, got call=%d minRaiseTo=%d", opts.GetCallAmount(), opts.GetMinRaiseTo())
+	}
+
+	// Out of turn: the options name the acting chair but offer nothing.
+	resp = rejected(waiting, &pb.ActionRequest{Action: pb.ActionType_ACTION_FOLD})
+	if opts := resp.GetActionOptions(); opts == nil || opts.GetActionChair() != uint32(snap.ActionChair) || len(opts.GetLegalActions()) != 0 {
+		t.Fatalf("expected acting chair without legal actions, got %v", opts)
+	}
+}
+
+func TestHandleAction_KeepsUserIDsAboveUint32(t *testing.T) {
+	tbl, err := table.NewTableForTest(table.TableConfig{
+		MaxPlayers: 6,
+		SmallBlind: 50,
+		BigBlind:   100,
+		MinBuyIn:   1000,
+		MaxBuyIn:   1000,
+	}, nil, nil, table.NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	users := []uint64{math.MaxUint32 + 7, math.MaxUint32 + 8}
+	for _, userID := range users {
+		if err := tbl.SubmitEvent(table.Event{Type: table.EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.Snapshot()
+	var actor uint64
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			actor = ps.ID
+		}
+	}
+	if actor != users[0] && actor != users[1] {
+		t.Fatalf("expected a large user ID to act, got %d", actor)
+	}
+
+	conn := &Connection{UserID: actor, Send: make(chan []byte, 4), Gateway: &Gateway{}, Table: tbl}
+	conn.handleAction(&pb.ClientEnvelope{}, &pb.ActionRequest{Action: pb.ActionType_ACTION_FOLD})
+	if len(conn.Send) != 0 {
+		t.Fatalf("expected the fold from user %d to be accepted", actor)
+	}
+	folded := false
+	for _, ps := range tbl.Snapshot().Players {
+		if ps.ID == actor {
+			folded = ps.Folded
+		}
+	}
+	if !folded {
+		t.Fatalf("expected the table to fold user %d", actor)
+	}
+
+	// Table broadcasts for the large ID must not reach its truncated twin.
+	g := &Gateway{userConns: make(map[uint64]*Connection)}
+	for _, userID := range []uint64{actor, uint64(uint32(actor))} {
+		g.userConns[userID] = &Connection{UserID: userID, Send: make(chan []byte, 1), Gateway: g}
+	}
+	g.broadcastToUser(actor, []byte{1})
+	if len(g.userConns[actor].Send) != 1 || len(g.userConns[uint64(uint32(actor))].Send) != 0 {
+		t.Fatalf("expected the message to reach only user %d", actor)
+	}
+}
+
+func TestMultiDeviceMirror_StaleDeviceCannotActButSeesBroadcasts(t *testing.T) {
+	tbl, err := table.NewTableForTest(table.TableConfig{
+		MaxPlayers: 6,
+		SmallBlind: 50,
+		BigBlind:   100,
+		MinBuyIn:   1000,
+		MaxBuyIn:   1000,
+	}, nil, nil, table.NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(table.Event{Type: table.EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.Snapshot()
+	var actor uint64
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			actor = ps.ID
+		}
+	}
+
+	g := New(nil, nil)
+	g.SetMultiDevicePolicy(MultiDeviceMirror)
+	phone := &Connection{UserID: actor, Send: make(chan []byte, 4), Gateway: g, Table: tbl, TableID: tbl.ID}
+	if replaced, _ := g.attach(phone); replaced != nil {
+		t.Fatalf("expected nothing to replace on first login")
+	}
+	laptop := &Connection{UserID: actor, Send: make(chan []byte, 4), Gateway: g}
+	if replaced, resume := g.attach(laptop); replaced != nil || resume != tbl {
+		t.Fatalf("expected the laptop to mirror the phone's table without closing it, got replaced=%v resume=%v", replaced, resume)
+	}
+
+	g.broadcastToUser(actor, []byte{1})
+	if len(phone.Send) != 1 || len(laptop.Send) != 1 {
+		t.Fatalf("expected the broadcast on both devices, got phone=%d laptop=%d", len(phone.Send), len(laptop.Send))
+	}
+	<-phone.Send
+	<-laptop.Send
+
+	// The phone is now the stale device: its fold must not reach the table.
+	phone.handleAction(&pb.ClientEnvelope{}, &pb.ActionRequest{Action: pb.ActionType_ACTION_FOLD})
+	select {
+	case data := <-phone.Send:
+		var env pb.ServerEnvelope
+		if err := proto.Unmarshal(data, &env); err != nil || env.GetError() == nil {
+			t.Fatalf("expected an error for the stale device, got %v / %T", err, env.GetPayload())
+		}
+	default:
+		t.Fatalf("expected the stale device's action to be rejected")
+	}
+	if got := tbl.Snapshot().ActionChair; got != snap.ActionChair {
+		t.Fatalf("expected the stale fold to leave chair %d to act, got %d", snap.ActionChair, got)
+	}
+
+	laptop.handleAction(&pb.ClientEnvelope{}, &pb.ActionRequest{Action: pb.ActionType_ACTION_FOLD})
+	if len(laptop.Send) != 0 {
+		t.Fatalf("expected the active device's fold to be accepted")
+	}
+
+	// Between hands a stand-up would go through, but not from the stale device.
+	phone.handleStandUp(&pb.ClientEnvelope{}, &pb.StandUpRequest{})
+	select {
+	case data := <-phone.Send:
+		var env pb.ServerEnvelope
+		if err := proto.Unmarshal(data, &env); err != nil || env.GetError() == nil {
+			t.Fatalf("expected an error for the stale stand-up, got %v / %T", err, env.GetPayload())
+		}
+	default:
+		t.Fatalf("expected the stale device's stand-up to be rejected")
+	}
+	seated := false
+	for _, ps := range tbl.Snapshot().Players {
+		seated = seated || ps.ID == actor
+	}
+	if !seated {
+		t.Fatalf("expected the stale stand-up to leave user %d seated", actor)
+	}
+
+	// Closing the active device hands control back to the phone.
+	g.removeConnection(laptop)
+	if !g.isActive(phone) {
+		t.Fatalf("expected the remaining device to become active")
+	}
+}
+
+func TestHandleListHands_SendsRecentHandsOfConnectionUser(t *testing.T) {
+	svc, err := ledger.NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService err: %v", err)
+	}
+	defer svc.Close()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.UpsertLiveHistory(7, "hand-1", base, map[string]any{"delta": 150})
+	svc.UpsertLiveHistory(7, "hand-2", base.Add(time.Minute), map[string]any{"delta": -50})
+	svc.UpsertLiveHistory(8, "other-user", base.Add(2*time.Minute), nil)
+
+	g := New(nil, nil)
+	g.SetLedger(svc)
+	conn := &Connection{UserID: 7, Send: make(chan []byte, 4), Gateway: g}
+	conn.handleMessage(mustMarshal(t, &pb.ClientEnvelope{
+		Payload: &pb.ClientEnvelope_ListHands{ListHands: &pb.ListHandsRequest{Limit: 10}},
+	}))
+
+	var env pb.ServerEnvelope
+	select {
+	case data := <-conn.Send:
+		if err := proto.Unmarshal(data, &env); err != nil {
+			t.Fatalf("unmarshal err: %v", err)
+		}
+	default:
+		t.Fatalf("expected a reply to ListHands")
+	}
+	list := env.GetHandList()
+	if list == nil || list.GetSource() != string(ledger.SourceLive) {
+		t.Fatalf("expected a live hand list, got %v", env.GetPayload())
+	}
+	items := list.GetItems()
+	if len(items) != 2 || items[0].GetHandId() != "hand-2" || items[1].GetHandId() != "hand-1" {
+		t.Fatalf("expected the user's two hands newest first, got %v", items)
+	}
+	if items[1].GetPlayedAtMs() != base.UnixMilli() || items[1].GetSummaryJson() != `{"delta":150}` {
+		t.Fatalf("unexpected item %v", items[1])
+	}
+
+	conn.handleListHands(&pb.ClientEnvelope{}, &pb.ListHandsRequest{Source: "bogus"})
+	if err := proto.Unmarshal(<-conn.Send, &env); err != nil || env.GetError() == nil {
+		t.Fatalf("expected an error for an unknown source, got %v / %T", err, env.GetPayload())
+	}
+}
+
+func mustMarshal(t *testing.T, msg proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal err: %v", err)
+	}
+	return data
+}
+
+func TestHandleMessage_RejectsInvalidPayloads(t *testing.T) {
+	tbl, err := table.NewTableForTest(table.TableConfig{
+		MaxPlayers: 6,
+		SmallBlind: 50,
+		BigBlind:   100,
+		MinBuyIn:   1000,
+		MaxBuyIn:   1000,
+	}, nil, nil, table.NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(table.Event{Type: table.EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.Snapshot()
+	var actor uint64
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			actor = ps.ID
+		}
+	}
+
+	g := New(nil, nil)
+	reply := func(userID uint64, env *pb.ClientEnvelope) *pb.ErrorResponse {
+		t.Helper()
+		conn := &Connection{UserID: userID, Send: make(chan []byte, 4), Gateway: g, Table: tbl}
+		conn.handleMessage(mustMarshal(t, env))
+		select {
+		case data := <-conn.Send:
+			var out pb.ServerEnvelope
+			if err := proto.Unmarshal(data, &out); err != nil {
+				t.Fatalf("unmarshal err: %v", err)
+			}
+			return out.GetError()
+		default:
+			return nil
+		}
+	}
+
+	sitDown := &pb.ClientEnvelope{Payload: &pb.ClientEnvelope_SitDown{
+		SitDown: &pb.SitDownRequest{Chair: 9, BuyInAmount: 1000},
+	}}
+	if resp := reply(3, sitDown); resp.GetCode() != errInvalidPayload || !strings.Contains(resp.GetMessage(), "sit_down.chair") {
+		t.Fatalf("expected an invalid payload error for chair 9, got %v", resp)
+	}
+	negative := &pb.ClientEnvelope{Payload: &pb.ClientEnvelope_Action{
+		Action: &pb.ActionRequest{Action: pb.ActionType_ACTION_RAISE, Amount: -500},
+	}}
+	if resp := reply(actor, negative); resp.GetCode() != errInvalidPayload || !strings.Contains(resp.GetMessage(), "action.amount") {
+		t.Fatalf("expected an invalid payload error for a negative amount, got %v", resp)
+	}
+	if got := tbl.Snapshot(); got.ActionChair != snap.ActionChair || len(got.Players) != 2 {
+		t.Fatalf("expected the table to be untouched by rejected payloads")
+	}
+	if resp := reply(actor, &pb.ClientEnvelope{}); resp.GetCode() != errInvalidPayload {
+		t.Fatalf("expected an envelope without a payload to be rejected, got %v", resp)
+	}
+
+	// Log-only mode hands the payload on; the table refuses the chair itself.
+	g.SetPayloadPolicy(PayloadLogOnly)
+	if resp := reply(3, sitDown); resp == nil || resp.GetCode() == errInvalidPayload {
+		t.Fatalf("expected the table's own rejection in log-only mode, got %v", resp)
+	}
+}
+
+func TestParsePayloadPolicy(t *testing.T) {
+	for in, want := range map[string]PayloadPolicy{"": PayloadReject, "reject": PayloadReject, "log": PayloadLogOnly} {
+		if got, err := ParsePayloadPolicy(in); err != nil || got != want {
+			t.Fatalf("ParsePayloadPolicy(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParsePayloadPolicy("ignore"); err == nil {
+		t.Fatalf("expected an unknown policy to be rejected")
+	}
+}
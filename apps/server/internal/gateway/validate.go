@@ -0,0 +1,142 @@
+package gateway
+
+import (
+	"fmt"
+	"log"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+// errInvalidPayload is the error code sent for a ClientEnvelope that decoded
+// but carries content no handler can act on.
+const errInvalidPayload int32 = 12
+
+// PayloadPolicy decides what happens to a client message that fails
+// validation.
+type PayloadPolicy uint8
+
+const (
+	// PayloadReject logs the message and answers with an error instead of
+	// handling it.
+	PayloadReject PayloadPolicy = iota
+	// PayloadLogOnly logs the message and handles it anyway, for clients
+	// that still send payloads the server used to accept.
+	PayloadLogOnly
+)
+
+// ParsePayloadPolicy maps a config value ("reject" or "log") to a policy.
+func ParsePayloadPolicy(s string) (PayloadPolicy, error) {
+	switch s {
+	case "", "reject":
+		return PayloadReject, nil
+	case "log":
+		return PayloadLogOnly, nil
+	}
+	return PayloadReject, fmt.Errorf("unknown payload policy %q", s)
+}
+
+// SetPayloadPolicy sets how messages that fail validation are handled.
+func (g *Gateway) SetPayloadPolicy(p PayloadPolicy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.payloadPolicy = p
+}
+
+// validatePayload checks what the handlers take on trust: the payload and
+// its nested message are present, chairs fit the current table, and amounts
+// and enums are in range. It reports the first problem found.
+func (c *Connection) validatePayload(env *pb.ClientEnvelope) error {
+	maxPlayers := uint32(0)
+	if c.Table != nil {
+		maxPlayers = uint32(c.Table.Config.MaxPlayers)
+	}
+	checkChair := func(field string, chair uint32) error {
+		if maxPlayers > 0 && chair >= maxPlayers {
+			return fmt.Errorf("%s %d out of range [0, %d)", field, chair, maxPlayers)
+		}
+		return nil
+	}
+	checkAmount := func(field string, amount int64) error {
+		if amount < 0 {
+			return fmt.Errorf("%s must be >= 0, got %d", field, amount)
+		}
+		return nil
+	}
+
+	switch payload := env.Payload.(type) {
+	case nil:
+		return fmt.Errorf("missing payload")
+	case *pb.ClientEnvelope_JoinTable:
+		if payload.JoinTable == nil {
+			return fmt.Errorf("missing join_table")
+		}
+	case *pb.ClientEnvelope_SitDown:
+		req := payload.SitDown
+		if req == nil {
+			return fmt.Errorf("missing sit_down")
+		}
+		if err := checkChair("sit_down.chair", req.Chair); err != nil {
+			return err
+		}
+		return checkAmount("sit_down.buy_in_amount", req.BuyInAmount)
+	case *pb.ClientEnvelope_BuyIn:
+		if payload.BuyIn == nil {
+			return fmt.Errorf("missing buy_in")
+		}
+		return checkAmount("buy_in.amount", payload.BuyIn.Amount)
+	case *pb.ClientEnvelope_Action:
+		req := payload.Action
+		if req == nil {
+			return fmt.Errorf("missing action")
+		}
+		if _, ok := pb.ActionType_name[int32(req.Action)]; !ok || req.Action == pb.ActionType_ACTION_UNSPECIFIED {
+			return fmt.Errorf("action.action %d is not a valid action", req.Action)
+		}
+		if _, ok := pb.SizingPreset_name[int32(req.SizingPreset)]; !ok {
+			return fmt.Errorf("action.sizing_preset %d is not a valid preset", req.SizingPreset)
+		}
+		return checkAmount("action.amount", req.Amount)
+	case *pb.ClientEnvelope_Straddle:
+		if payload.Straddle == nil {
+			return fmt.Errorf("missing straddle")
+		}
+		return checkChair("straddle.chair", payload.Straddle.Chair)
+	case *pb.ClientEnvelope_RunItTwiceAccept:
+		if payload.RunItTwiceAccept == nil {
+			return fmt.Errorf("missing run_it_twice_accept")
+		}
+	case *pb.ClientEnvelope_StartStory:
+		if payload.StartStory == nil {
+			return fmt.Errorf("missing start_story")
+		}
+	case *pb.ClientEnvelope_ListHands:
+		if payload.ListHands == nil {
+			return fmt.Errorf("missing list_hands")
+		}
+		if payload.ListHands.Limit < 0 {
+			return fmt.Errorf("list_hands.limit must be >= 0, got %d", payload.ListHands.Limit)
+		}
+	}
+	return nil
+}
+
+// checkPayload validates env and reports whether it should be handled.
+func (c *Connection) checkPayload(env *pb.ClientEnvelope) bool {
+	err := c.validatePayload(env)
+	if err == nil {
+		return true
+	}
+	log.Printf("[Gateway] Invalid payload from user %d (%T): %v", c.UserID, env.Payload, err)
+
+	policy := PayloadReject
+	if c.Gateway != nil {
+		c.Gateway.mu.RLock()
+		policy = c.Gateway.payloadPolicy
+		c.Gateway.mu.RUnlock()
+	}
+	if policy == PayloadLogOnly {
+		return true
+	}
+	c.sendError(errInvalidPayload, fmt.Sprintf("invalid payload: %v", err))
+	return false
+}
@@ -0,0 +1,136 @@
+package ledger
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AdminHTTPHandler serves support lookups that are not scoped to the caller.
+// Requests must carry the configured admin token as a Bearer token; an empty
+// token disables the endpoints.
+type AdminHTTPHandler struct {
+	token  string
+	ledger Service
+}
+
+func NewAdminHTTPHandler(adminToken string, ledgerService Service) *AdminHTTPHandler {
+	return &AdminHTTPHandler{
+		token:  strings.TrimSpace(adminToken),
+		ledger: ledgerService,
+	}
+}
+
+func (h *AdminHTTPHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/admin/hands/", h.handleHand)
+	mux.HandleFunc("/api/admin/summaries/rebuild", h.handleRebuildSummaries)
+}
+
+type rebuildSummariesRequest struct {
+	UserID uint64 `json:"user_id"`
+	Source Source `json:"source"`
+	// HandIDs picks the hands to rebuild; empty means the user's most recent
+	// Limit hands of Source.
+	HandIDs []string `json:"hand_ids,omitempty"`
+	Limit   int      `json:"limit,omitempty"`
+}
+
+type rebuildFailure struct {
+	HandID string `json:"hand_id"`
+	Error  string `json:"error"`
+}
+
+// handleRebuildSummaries serves POST /api/admin/summaries/rebuild, the bulk
+// backfill for stored summaries. One bad hand does not stop the batch; each
+// failure is reported by hand ID.
+func (h *AdminHTTPHandler) handleRebuildSummaries(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		writeError(w, http.StatusForbidden, "admin token required")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req rebuildSummariesRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil || req.UserID == 0 || !isAuditSource(req.Source) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+	handIDs := req.HandIDs
+	if len(handIDs) == 0 {
+		items, err := h.ledger.ListRecent(ctx, req.UserID, req.Source, req.Limit, HistoryFilter{})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "list hands failed")
+			return
+		}
+		for _, item := range items {
+			handIDs = append(handIDs, item.HandID)
+		}
+	}
+	rebuilt := 0
+	failed := make([]rebuildFailure, 0)
+	for _, handID := range handIDs {
+		if err := h.ledger.RebuildSummary(ctx, req.UserID, req.Source, handID); err != nil {
+			failed = append(failed, rebuildFailure{HandID: handID, Error: err.Error()})
+			continue
+		}
+		rebuilt++
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"rebuilt": rebuilt,
+		"failed":  failed,
+	})
+}
+
+// handleHand serves GET /api/admin/hands/{handID} from the live event stream,
+// whoever played the hand.
+func (h *AdminHTTPHandler) handleHand(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		writeError(w, http.StatusForbidden, "admin token required")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	handID := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/api/admin/hands/"))
+	if handID == "" || strings.Contains(handID, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	events, err := h.ledger.GetLiveStreamEvents(ctx, handID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			writeError(w, http.StatusNotFound, "hand not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "query hand events failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"hand_id": handID,
+		"source":  SourceLive,
+		"events":  events,
+	})
+}
+
+func (h *AdminHTTPHandler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return false
+	}
+	token := bearerToken(r.Header.Get("Authorization"))
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
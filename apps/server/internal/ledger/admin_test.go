@@ -0,0 +1,126 @@
+package ledger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/apps/server/internal/auth"
+)
+
+func TestAdminHTTPHandler_FetchesAnyHandAndRejectsNonAdmins(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	for seq := uint64(1); seq <= 3; seq++ {
+		svc.AppendLiveEvent("hand-42", &pb.ServerEnvelope{
+			TableId:   "t1",
+			ServerSeq: seq,
+			Payload:   &pb.ServerEnvelope_HandStart{HandStart: &pb.HandStart{Round: 1}},
+		}, nil)
+	}
+
+	authService := auth.NewManager()
+	_, userToken, err := authService.Register("alice_01", "secret12", auth.ClientInfo{})
+	if err != nil {
+		t.Fatalf("register err: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewAdminHTTPHandler("s3cret-admin", svc).RegisterRoutes(mux)
+	do := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The hand has no per-user history row; only the admin path can see it.
+	rec := do("/api/admin/hands/hand-42", "s3cret-admin")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin GET status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		HandID string      `json:"hand_id"`
+		Events []EventItem `json:"events"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.HandID != "hand-42" || len(body.Events) != 3 || body.Events[0].Seq != 1 || body.Events[2].Seq != 3 {
+		t.Fatalf("unexpected admin body %s", rec.Body.String())
+	}
+	if rec := do("/api/admin/hands/missing", "s3cret-admin"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected missing hand to 404, status=%d", rec.Code)
+	}
+
+	for _, token := range []string{"", "wrong-token", userToken} {
+		if rec := do("/api/admin/hands/hand-42", token); rec.Code != http.StatusForbidden {
+			t.Fatalf("token %q: expected 403, status=%d", token, rec.Code)
+		}
+	}
+
+	disabled := http.NewServeMux()
+	NewAdminHTTPHandler("", svc).RegisterRoutes(disabled)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/hands/hand-42", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec = httptest.NewRecorder()
+	disabled.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected an unset admin token to disable the endpoint, status=%d", rec.Code)
+	}
+}
+
+func TestAdminHTTPHandler_RebuildSummariesBackfillsRecentHands(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	events := encodeTestEvents(t,
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandStart{HandStart: &pb.HandStart{Round: 1}}},
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandEnd{HandEnd: &pb.HandEnd{Round: 1}}},
+	)
+	svc.UpsertLiveHistoryWithEvents(7, "good", time.Now(), map[string]any{"chair": 1}, events)
+	svc.UpsertLiveHistoryWithEvents(7, "cut", time.Now(), map[string]any{"chair": 1}, events[:1])
+
+	mux := http.NewServeMux()
+	NewAdminHTTPHandler("s3cret-admin", svc).RegisterRoutes(mux)
+	post := func(body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/summaries/rebuild", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(`{"user_id":7,"source":"live"}`, "s3cret-admin")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rebuild status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Rebuilt int              `json:"rebuilt"`
+		Failed  []rebuildFailure `json:"failed"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Rebuilt != 1 || len(body.Failed) != 1 || body.Failed[0].HandID != "cut" {
+		t.Fatalf("expected one rebuilt hand and the cut one reported, got %s", rec.Body.String())
+	}
+	if rec := post(`{"user_id":7,"source":"live"}`, "wrong-token"); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without the admin token, status=%d", rec.Code)
+	}
+	if rec := post(`{"user_id":7,"source":"sandbox"}`, "s3cret-admin"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected a non-audit source to be rejected, status=%d", rec.Code)
+	}
+}
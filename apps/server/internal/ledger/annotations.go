@@ -0,0 +1,71 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxAnnotationLength = 500
+	MaxHandAnnotations  = 100
+)
+
+var ErrInvalidAnnotation = errors.New("invalid annotation")
+
+// Annotation is a user's note on one event of a stored hand, keyed by the
+// event's Seq.
+type Annotation struct {
+	Seq  uint64 `json:"seq"`
+	Text string `json:"text"`
+}
+
+// normalizeAnnotations trims texts, drops empty ones and orders the rest by
+// seq. Every seq must belong to one of the hand's events.
+func normalizeAnnotations(annotations []Annotation, events []EventItem) ([]Annotation, error) {
+	if len(annotations) > MaxHandAnnotations {
+		return nil, fmt.Errorf("%w: at most %d annotations per hand", ErrInvalidAnnotation, MaxHandAnnotations)
+	}
+	known := make(map[uint64]struct{}, len(events))
+	for _, e := range events {
+		known[e.Seq] = struct{}{}
+	}
+	out := make([]Annotation, 0, len(annotations))
+	for _, a := range annotations {
+		text := strings.TrimSpace(a.Text)
+		if text == "" {
+			continue
+		}
+		if utf8.RuneCountInString(text) > MaxAnnotationLength {
+			return nil, fmt.Errorf("%w: text longer than %d characters", ErrInvalidAnnotation, MaxAnnotationLength)
+		}
+		if _, ok := known[a.Seq]; !ok {
+			return nil, fmt.Errorf("%w: no event with seq %d", ErrInvalidAnnotation, a.Seq)
+		}
+		out = append(out, Annotation{Seq: a.Seq, Text: text})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Seq < out[j].Seq })
+	return out, nil
+}
+
+// attachAnnotations copies stored annotation texts onto the matching events.
+func attachAnnotations(events []EventItem, raw []byte) []EventItem {
+	if len(raw) == 0 {
+		return events
+	}
+	var annotations []Annotation
+	if err := json.Unmarshal(raw, &annotations); err != nil || len(annotations) == 0 {
+		return events
+	}
+	bySeq := make(map[uint64][]string, len(annotations))
+	for _, a := range annotations {
+		bySeq[a.Seq] = append(bySeq[a.Seq], a.Text)
+	}
+	for i := range events {
+		events[i].Annotations = bySeq[events[i].Seq]
+	}
+	return events
+}
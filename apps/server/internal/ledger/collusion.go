@@ -0,0 +1,253 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+// FlagKind names the pattern a collusion Flag matched.
+type FlagKind string
+
+const (
+	// FlagChipDumping: one seat repeatedly lost all-ins to the same seat,
+	// which never lost an all-in back.
+	FlagChipDumping FlagKind = "chip_dumping"
+	// FlagSoftPlay: two seats kept checking down heads-up pots to showdown.
+	FlagSoftPlay FlagKind = "soft_play"
+)
+
+// CollusionConfig tunes AnalyzeCollusion. Zero fields take the defaults.
+type CollusionConfig struct {
+	// ChipDumpMinHands is how many one-sided all-in losses between the same
+	// two seats raise a chip-dumping flag (default 3).
+	ChipDumpMinHands int
+	// SoftPlayMinHands is how many checked-down heads-up showdowns between
+	// the same two seats raise a soft-play flag (default 5), provided they
+	// make up at least SoftPlayMinRatio of the pair's heads-up showdowns
+	// (default 0.8).
+	SoftPlayMinHands int
+	SoftPlayMinRatio float64
+}
+
+func (c CollusionConfig) withDefaults() CollusionConfig {
+	if c.ChipDumpMinHands <= 0 {
+		c.ChipDumpMinHands = 3
+	}
+	if c.SoftPlayMinHands <= 0 {
+		c.SoftPlayMinHands = 5
+	}
+	if c.SoftPlayMinRatio <= 0 {
+		c.SoftPlayMinRatio = 0.8
+	}
+	return c
+}
+
+// Flag is a suspicious pattern between two seats of a table, for a human to
+// review. Live streams carry no user ids, so seats are table chairs; the
+// per-user hand history maps them back to accounts. For chip dumping Chairs
+// is {loser, winner} and Chips what the loser gave up.
+type Flag struct {
+	Kind    FlagKind  `json:"kind"`
+	TableID string    `json:"table_id"`
+	Chairs  [2]uint32 `json:"chairs"`
+	Hands   int       `json:"hands"`
+	Chips   int64     `json:"chips,omitempty"`
+	HandIDs []string  `json:"hand_ids"`
+	Detail  string    `json:"detail"`
+}
+
+// AnalyzeCollusion reads the live event streams of handIDs and flags seat
+// pairs whose play looks coordinated. It only reports; nothing is acted on.
+// Hands whose stream cannot be read are logged and skipped.
+func AnalyzeCollusion(ctx context.Context, svc Service, handIDs []string, cfg CollusionConfig) []Flag {
+	cfg = cfg.withDefaults()
+	type pairStats struct {
+		dumps      []string
+		dumpChips  int64
+		headsUp    int
+		checked    []string
+		reverseHit bool
+	}
+	// Chip-dump pairs are directed {loser, winner}; soft-play pairs are
+	// ordered low chair first.
+	pairs := make(map[pairKey]*pairStats)
+	stats := func(k pairKey) *pairStats {
+		if pairs[k] == nil {
+			pairs[k] = &pairStats{}
+		}
+		return pairs[k]
+	}
+
+	for _, handID := range handIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		events, err := svc.GetLiveStreamEvents(ctx, handID)
+		if err != nil {
+			log.Printf("[Ledger] collusion scan skipped hand=%s err=%v", handID, err)
+			continue
+		}
+		envs, bad := decodeEventEnvelopes(events)
+		if len(bad) > 0 {
+			log.Printf("[Ledger] collusion scan skipped hand=%s: event %d is undecodable", handID, bad[0].Index)
+			continue
+		}
+		facts := collectHandFacts(envs)
+		if !facts.ended {
+			continue
+		}
+
+		if loser, winner, chips, ok := facts.allInLoss(); ok {
+			st := stats(pairKey{facts.tableID, loser, winner})
+			st.dumps = append(st.dumps, handID)
+			st.dumpChips += chips
+			stats(pairKey{facts.tableID, winner, loser}).reverseHit = true
+		}
+		if a, b, ok := facts.headsUpShowdown(); ok {
+			st := stats(pairKey{facts.tableID, a, b})
+			st.headsUp++
+			if !facts.postflopAggression {
+				st.checked = append(st.checked, handID)
+			}
+		}
+	}
+
+	var flags []Flag
+	for k, st := range pairs {
+		if len(st.dumps) >= cfg.ChipDumpMinHands && !st.reverseHit {
+			flags = append(flags, Flag{
+				Kind:    FlagChipDumping,
+				TableID: k.tableID,
+				Chairs:  [2]uint32{k.a, k.b},
+				Hands:   len(st.dumps),
+				Chips:   st.dumpChips,
+				HandIDs: st.dumps,
+				Detail:  fmt.Sprintf("chair %d lost %d all-ins to chair %d, which lost none back", k.a, len(st.dumps), k.b),
+			})
+		}
+		if n := len(st.checked); n >= cfg.SoftPlayMinHands && float64(n) >= cfg.SoftPlayMinRatio*float64(st.headsUp) {
+			flags = append(flags, Flag{
+				Kind:    FlagSoftPlay,
+				TableID: k.tableID,
+				Chairs:  [2]uint32{k.a, k.b},
+				Hands:   n,
+				HandIDs: st.checked,
+				Detail:  fmt.Sprintf("%d of %d heads-up showdowns checked down after the flop", n, st.headsUp),
+			})
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		fi, fj := flags[i], flags[j]
+		if fi.TableID != fj.TableID {
+			return fi.TableID < fj.TableID
+		}
+		if fi.Kind != fj.Kind {
+			return fi.Kind < fj.Kind
+		}
+		if fi.Chairs[0] != fj.Chairs[0] {
+			return fi.Chairs[0] < fj.Chairs[0]
+		}
+		return fi.Chairs[1] < fj.Chairs[1]
+	})
+	return flags
+}
+
+type pairKey struct {
+	tableID string
+	a, b    uint32
+}
+
+// handFacts is what the collusion heuristics need from one hand's stream.
+type handFacts struct {
+	tableID            string
+	ended              bool
+	allIn              map[uint32]bool
+	shown              []uint32
+	postflopAggression bool
+	deltas             map[uint32]int64
+}
+
+func collectHandFacts(envs []*pb.ServerEnvelope) handFacts {
+	f := handFacts{allIn: map[uint32]bool{}, deltas: map[uint32]int64{}}
+	postflop := false
+	for _, env := range envs {
+		if f.tableID == "" {
+			f.tableID = env.GetTableId()
+		}
+		switch {
+		case env.GetDealBoard() != nil:
+			postflop = true
+		case env.GetPhaseChange() != nil:
+			if env.GetPhaseChange().GetPhase() >= pb.Phase_PHASE_FLOP {
+				postflop = true
+			}
+		case env.GetActionResult() != nil:
+			ar := env.GetActionResult()
+			switch ar.GetAction() {
+			case pb.ActionType_ACTION_ALLIN:
+				f.allIn[ar.GetChair()] = true
+			case pb.ActionType_ACTION_BET, pb.ActionType_ACTION_RAISE, pb.ActionType_ACTION_CALL:
+				if ar.GetNewStack() == 0 {
+					f.allIn[ar.GetChair()] = true
+				}
+			}
+			switch ar.GetAction() {
+			case pb.ActionType_ACTION_BET, pb.ActionType_ACTION_RAISE, pb.ActionType_ACTION_ALLIN:
+				if postflop {
+					f.postflopAggression = true
+				}
+			}
+		case env.GetShowdown() != nil:
+			for _, h := range env.GetShowdown().GetHands() {
+				f.shown = append(f.shown, h.GetChair())
+			}
+		case env.GetHandEnd() != nil:
+			f.ended = true
+			for _, sd := range env.GetHandEnd().GetStackDeltas() {
+				f.deltas[sd.GetChair()] = sd.GetDelta()
+			}
+		}
+	}
+	return f
+}
+
+// allInLoss reports a hand where one all-in player lost chips to the hand's
+// only winner.
+func (f handFacts) allInLoss() (loser, winner uint32, chips int64, ok bool) {
+	winners := 0
+	for chair, d := range f.deltas {
+		if d > 0 {
+			winner = chair
+			winners++
+		}
+	}
+	if winners != 1 {
+		return 0, 0, 0, false
+	}
+	losers := 0
+	for chair, d := range f.deltas {
+		if d < 0 && f.allIn[chair] {
+			loser, chips = chair, -d
+			losers++
+		}
+	}
+	return loser, winner, chips, losers == 1
+}
+
+// headsUpShowdown reports the two chairs of a showdown nobody was all-in
+// for, low chair first. All-in hands are skipped: their boards run out
+// without betting whatever the players intend.
+func (f handFacts) headsUpShowdown() (a, b uint32, ok bool) {
+	if len(f.shown) != 2 || len(f.allIn) > 0 {
+		return 0, 0, false
+	}
+	a, b = f.shown[0], f.shown[1]
+	if a > b {
+		a, b = b, a
+	}
+	return a, b, true
+}
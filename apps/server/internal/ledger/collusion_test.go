@@ -0,0 +1,161 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+// collusionHand writes one synthetic hand at table t1 to the live stream.
+type collusionHand struct {
+	svc    *SQLiteService
+	handID string
+	seq    uint64
+}
+
+func newCollusionHand(svc *SQLiteService, handID string) *collusionHand {
+	h := &collusionHand{svc: svc, handID: handID}
+	h.emit(&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandStart{HandStart: &pb.HandStart{Round: 1}}})
+	return h
+}
+
+func (h *collusionHand) emit(env *pb.ServerEnvelope) *collusionHand {
+	h.seq++
+	env.TableId = "t1"
+	env.ServerSeq = h.seq
+	h.svc.AppendLiveEvent(h.handID, env, nil)
+	return h
+}
+
+func (h *collusionHand) act(chair uint32, action pb.ActionType, newStack int64) *collusionHand {
+	return h.emit(&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_ActionResult{ActionResult: &pb.ActionResult{
+		Chair: chair, Action: action, NewStack: newStack,
+	}}})
+}
+
+func (h *collusionHand) flop() *collusionHand {
+	return h.emit(&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_DealBoard{DealBoard: &pb.DealBoard{Phase: pb.Phase_PHASE_FLOP}}})
+}
+
+// showdown ends the hand with both chairs shown and winner taking amount
+// from loser.
+func (h *collusionHand) showdown(winner, loser uint32, amount int64) {
+	h.emit(&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_Showdown{Showdown: &pb.Showdown{
+		Hands: []*pb.ShowdownHand{{Chair: winner}, {Chair: loser}},
+	}}})
+	h.emit(&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandEnd{HandEnd: &pb.HandEnd{
+		Round: 1,
+		StackDeltas: []*pb.StackDelta{
+			{Chair: winner, Delta: amount},
+			{Chair: loser, Delta: -amount},
+		},
+	}}})
+}
+
+func allInHand(svc *SQLiteService, handID string, loser, winner uint32, amount int64) {
+	newCollusionHand(svc, handID).
+		act(loser, pb.ActionType_ACTION_ALLIN, 0).
+		act(winner, pb.ActionType_ACTION_CALL, 4000).
+		flop().
+		showdown(winner, loser, amount)
+}
+
+func checkDownHand(svc *SQLiteService, handID string, a, b uint32) {
+	newCollusionHand(svc, handID).
+		act(a, pb.ActionType_ACTION_CALL, 4900).
+		act(b, pb.ActionType_ACTION_CHECK, 4900).
+		flop().
+		act(a, pb.ActionType_ACTION_CHECK, 4900).
+		act(b, pb.ActionType_ACTION_CHECK, 4900).
+		showdown(a, b, 100)
+}
+
+func bettingHand(svc *SQLiteService, handID string, a, b uint32) {
+	newCollusionHand(svc, handID).
+		act(a, pb.ActionType_ACTION_CALL, 4900).
+		act(b, pb.ActionType_ACTION_CHECK, 4900).
+		flop().
+		act(a, pb.ActionType_ACTION_BET, 4700).
+		act(b, pb.ActionType_ACTION_CALL, 4700).
+		showdown(b, a, 300)
+}
+
+func newCollusionTestService(t *testing.T) *SQLiteService {
+	t.Helper()
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestAnalyzeCollusion_FlagsChipDumping(t *testing.T) {
+	svc := newCollusionTestService(t)
+	var handIDs []string
+	for i := 0; i < 3; i++ {
+		handID := fmt.Sprintf("dump-%d", i)
+		allInHand(svc, handID, 1, 2, 5000)
+		handIDs = append(handIDs, handID)
+	}
+	bettingHand(svc, "normal", 1, 2)
+	handIDs = append(handIDs, "normal", "missing")
+
+	flags := AnalyzeCollusion(context.Background(), svc, handIDs, CollusionConfig{})
+	want := []Flag{{
+		Kind:    FlagChipDumping,
+		TableID: "t1",
+		Chairs:  [2]uint32{1, 2},
+		Hands:   3,
+		Chips:   15000,
+		HandIDs: []string{"dump-0", "dump-1", "dump-2"},
+		Detail:  "chair 1 lost 3 all-ins to chair 2, which lost none back",
+	}}
+	if !reflect.DeepEqual(flags, want) {
+		t.Fatalf("expected one chip-dumping flag\n got %+v\nwant %+v", flags, want)
+	}
+}
+
+func TestAnalyzeCollusion_FlagsSoftPlay(t *testing.T) {
+	svc := newCollusionTestService(t)
+	var handIDs []string
+	for i := 0; i < 5; i++ {
+		handID := fmt.Sprintf("soft-%d", i)
+		checkDownHand(svc, handID, 3, 4)
+		handIDs = append(handIDs, handID)
+	}
+	bettingHand(svc, "bet-0", 3, 4)
+	handIDs = append(handIDs, "bet-0")
+
+	flags := AnalyzeCollusion(context.Background(), svc, handIDs, CollusionConfig{})
+	if len(flags) != 1 || flags[0].Kind != FlagSoftPlay || flags[0].Chairs != [2]uint32{3, 4} || flags[0].Hands != 5 {
+		t.Fatalf("expected one soft-play flag for chairs 3 and 4, got %+v", flags)
+	}
+
+	// A stricter ratio clears the pair: 5 of 6 is below 0.9.
+	if flags := AnalyzeCollusion(context.Background(), svc, handIDs, CollusionConfig{SoftPlayMinRatio: 0.9}); len(flags) != 0 {
+		t.Fatalf("expected no flags at a 0.9 ratio, got %+v", flags)
+	}
+}
+
+func TestAnalyzeCollusion_NormalSessionRaisesNoFlags(t *testing.T) {
+	svc := newCollusionTestService(t)
+	handIDs := []string{"a1", "a2", "a3", "a4", "c1", "c2", "b1", "b2", "b3"}
+	allInHand(svc, "a1", 1, 2, 5000)
+	allInHand(svc, "a2", 2, 1, 5000)
+	allInHand(svc, "a3", 1, 2, 3000)
+	allInHand(svc, "a4", 1, 2, 2000)
+	checkDownHand(svc, "c1", 1, 2)
+	checkDownHand(svc, "c2", 1, 2)
+	bettingHand(svc, "b1", 1, 2)
+	bettingHand(svc, "b2", 2, 1)
+	bettingHand(svc, "b3", 1, 2)
+
+	if flags := AnalyzeCollusion(context.Background(), svc, handIDs, CollusionConfig{}); len(flags) != 0 {
+		t.Fatalf("expected a normal session to raise no flags, got %+v", flags)
+	}
+}
@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HistoryFilter narrows a recent-history query. The zero value filters
+// nothing. Time bounds apply to played_at, From inclusive and To exclusive,
+// so adjacent windows never share a hand. MinDelta and OnlyWins read the
+// summary's delta and is_winner fields; a hand whose summary lacks the field
+// never matches.
+type HistoryFilter struct {
+	From     time.Time
+	To       time.Time
+	MinDelta *int64
+	OnlyWins bool
+}
+
+// sqliteClauses returns the filter's predicates as " AND ..." lines with ?
+// placeholders, and their arguments in order.
+func (f HistoryFilter) sqliteClauses() (string, []any) {
+	var b strings.Builder
+	var args []any
+	if !f.From.IsZero() {
+		b.WriteString("\n  AND played_at_ms >= ?")
+		args = append(args, f.From.UnixMilli())
+	}
+	if !f.To.IsZero() {
+		b.WriteString("\n  AND played_at_ms < ?")
+		args = append(args, f.To.UnixMilli())
+	}
+	if f.MinDelta != nil {
+		b.WriteString("\n  AND json_type(summary_json, '$.delta') IN ('integer', 'real')\n  AND json_extract(summary_json, '$.delta') >= ?")
+		args = append(args, *f.MinDelta)
+	}
+	if f.OnlyWins {
+		b.WriteString("\n  AND json_type(summary_json, '$.is_winner') = 'true'")
+	}
+	return b.String(), args
+}
+
+// postgresClauses is sqliteClauses for Postgres, numbering placeholders from
+// $next.
+func (f HistoryFilter) postgresClauses(next int) (string, []any) {
+	var b strings.Builder
+	var args []any
+	if !f.From.IsZero() {
+		args = append(args, f.From.UTC())
+		fmt.Fprintf(&b, "\n  AND played_at >= $%d", next+len(args)-1)
+	}
+	if !f.To.IsZero() {
+		args = append(args, f.To.UTC())
+		fmt.Fprintf(&b, "\n  AND played_at < $%d", next+len(args)-1)
+	}
+	if f.MinDelta != nil {
+		args = append(args, *f.MinDelta)
+		// CASE keeps the cast away from non-numeric deltas.
+		fmt.Fprintf(&b, "\n  AND CASE WHEN jsonb_typeof(summary_json->'delta') = 'number' THEN (summary_json->>'delta')::numeric END >= $%d", next+len(args)-1)
+	}
+	if f.OnlyWins {
+		b.WriteString("\n  AND summary_json->'is_winner' = 'true'::jsonb")
+	}
+	return b.String(), args
+}
+
+// mysqlClauses is sqliteClauses for MySQL's JSON functions.
+func (f HistoryFilter) mysqlClauses() (string, []any) {
+	var b strings.Builder
+	var args []any
+	if !f.From.IsZero() {
+		b.WriteString("\n  AND played_at_ms >= ?")
+		args = append(args, f.From.UnixMilli())
+	}
+	if !f.To.IsZero() {
+		b.WriteString("\n  AND played_at_ms < ?")
+		args = append(args, f.To.UnixMilli())
+	}
+	if f.MinDelta != nil {
+		b.WriteString("\n  AND JSON_TYPE(JSON_EXTRACT(summary_json, '$.delta')) IN " + mysqlJSONNumberTypes + "\n  AND JSON_EXTRACT(summary_json, '$.delta') >= ?")
+		args = append(args, *f.MinDelta)
+	}
+	if f.OnlyWins {
+		b.WriteString("\n  AND JSON_EXTRACT(summary_json, '$.is_winner') = CAST('true' AS JSON)")
+	}
+	return b.String(), args
+}
+
+// mysqlJSONNumberTypes lists the JSON_TYPE results of a JSON number.
+const mysqlJSONNumberTypes = "('INTEGER', 'UNSIGNED INTEGER', 'DECIMAL', 'DOUBLE')"
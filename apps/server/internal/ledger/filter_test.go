@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testHistoryFilters seeds live hands for userID and checks every filter
+// case against svc, so both backends are held to the same results.
+func testHistoryFilters(t *testing.T, svc Service, userID uint64) {
+	t.Helper()
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	seed := []struct {
+		handID  string
+		summary map[string]any
+	}{
+		{"h1", map[string]any{"delta": -50, "is_winner": false}},
+		{"h2", map[string]any{"delta": 120, "is_winner": true}},
+		{"h3", map[string]any{"delta": 0, "is_winner": false}},
+		{"h4", map[string]any{"ended_phase": "flop"}},
+		{"h5", map[string]any{"delta": 300, "is_winner": true}},
+	}
+	for i, hand := range seed {
+		svc.UpsertLiveHistory(userID, hand.handID, base.Add(time.Duration(i)*time.Minute), hand.summary)
+	}
+
+	minDelta := func(n int64) *int64 { return &n }
+	cases := []struct {
+		name   string
+		filter HistoryFilter
+		want   []string
+	}{
+		{"none", HistoryFilter{}, []string{"h5", "h4", "h3", "h2", "h1"}},
+		{"window", HistoryFilter{From: base.Add(time.Minute), To: base.Add(4 * time.Minute)}, []string{"h4", "h3", "h2"}},
+		{"min delta", HistoryFilter{MinDelta: minDelta(0)}, []string{"h5", "h3", "h2"}},
+		{"only wins", HistoryFilter{OnlyWins: true}, []string{"h5", "h2"}},
+		{"from and wins", HistoryFilter{From: base.Add(2 * time.Minute), OnlyWins: true}, []string{"h5"}},
+		{"to and min delta", HistoryFilter{To: base.Add(2 * time.Minute), MinDelta: minDelta(-100)}, []string{"h2", "h1"}},
+	}
+	for _, tc := range cases {
+		items, err := svc.ListRecent(ctx, userID, SourceLive, 10, tc.filter)
+		if err != nil {
+			t.Fatalf("%s: ListRecent failed: %v", tc.name, err)
+		}
+		got := make([]string, 0, len(items))
+		for _, item := range items {
+			got = append(got, item.HandID)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSQLiteListRecent_Filters(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	testHistoryFilters(t, svc, 7)
+}
+
+// Set LEDGER_TEST_DATABASE_DSN to a database with the db/ schema applied to
+// run the same cases against Postgres.
+func TestPostgresListRecent_Filters(t *testing.T) {
+	dsn := os.Getenv("LEDGER_TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("LEDGER_TEST_DATABASE_DSN not set")
+	}
+	t.Setenv("LEDGER_DATABASE_DSN", dsn)
+	svc, _, err := NewServiceFromEnv("postgres")
+	if err != nil {
+		t.Fatalf("NewServiceFromEnv failed: %v", err)
+	}
+	defer svc.Close()
+	db := svc.(*PostgresService).db
+
+	ctx := context.Background()
+	var userID uint64
+	username := fmt.Sprintf("ledger-filter-%d", time.Now().UnixNano()%1e9)
+	if err := db.QueryRowContext(ctx, `INSERT INTO accounts (username) VALUES ($1) RETURNING id`, username).Scan(&userID); err != nil {
+		t.Fatalf("create account failed: %v", err)
+	}
+	defer db.ExecContext(ctx, `DELETE FROM accounts WHERE id = $1`, userID)
+	testHistoryFilters(t, svc, userID)
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"holdem-lite/apps/server/internal/auth"
+	"holdem-lite/replay"
 )
 
 type HTTPHandler struct {
@@ -21,6 +24,10 @@ type errorResponse struct {
 	Error string `json:"error"`
 }
 
+type setAnnotationsRequest struct {
+	Annotations []Annotation `json:"annotations"`
+}
+
 type upsertReplayHandRequest struct {
 	Events  []EventItem    `json:"events"`
 	Summary map[string]any `json:"summary"`
@@ -34,10 +41,13 @@ func NewHTTPHandler(authService auth.Service, ledgerService Service) *HTTPHandle
 }
 
 func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/audit/recent", h.handleRecentMulti)
 	mux.HandleFunc("/api/audit/live/recent", h.handleRecent(SourceLive))
 	mux.HandleFunc("/api/audit/replay/recent", h.handleRecent(SourceReplay))
 	mux.HandleFunc("/api/audit/live/hands/", h.handleHands(SourceLive))
 	mux.HandleFunc("/api/audit/replay/hands/", h.handleHands(SourceReplay))
+	mux.HandleFunc("/api/audit/live/stats", h.handleStats(SourceLive))
+	mux.HandleFunc("/api/audit/replay/stats", h.handleStats(SourceReplay))
 }
 
 func (h *HTTPHandler) handleRecent(source Source) http.HandlerFunc {
@@ -52,10 +62,15 @@ func (h *HTTPHandler) handleRecent(source Source) http.HandlerFunc {
 			return
 		}
 
+		filter, err := parseHistoryFilter(r.URL.Query())
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		limit := parseLimit(r.URL.Query().Get("limit"))
 		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 		defer cancel()
-		items, err := h.ledger.ListRecent(ctx, userID, source, limit)
+		items, err := h.ledger.ListRecent(ctx, userID, source, limit, filter)
 		if err != nil {
 			writeError(w, http.StatusInternalServerError, "query recent hands failed")
 			return
@@ -67,6 +82,61 @@ func (h *HTTPHandler) handleRecent(source Source) http.HandlerFunc {
 	}
 }
 
+func (h *HTTPHandler) handleStats(source Source) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		userID, ok := h.resolveUserID(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid session token")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+		stats, err := h.ledger.GetPlayerStats(ctx, userID, source)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "query player stats failed")
+			return
+		}
+		writeJSON(w, http.StatusOK, stats)
+	}
+}
+
+// handleRecentMulti serves GET /api/audit/recent?sources=live,replay; without
+// sources it merges live and replay.
+func (h *HTTPHandler) handleRecentMulti(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID, ok := h.resolveUserID(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid session token")
+		return
+	}
+
+	sources, ok := parseSources(r.URL.Query().Get("sources"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid sources")
+		return
+	}
+	limit := parseLimit(r.URL.Query().Get("limit"))
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	items, err := h.ledger.ListRecentMulti(ctx, userID, sources, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "query recent hands failed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items": items,
+	})
+}
+
 func (h *HTTPHandler) handleHands(source Source) http.HandlerFunc {
 	prefix := "/api/audit/" + string(source) + "/hands/"
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -103,6 +173,24 @@ func (h *HTTPHandler) handleHands(source Source) http.HandlerFunc {
 			return
 		}
 
+		if len(parts) == 2 && parts[1] == "verify" && source == SourceLive {
+			if r.Method != http.MethodGet {
+				writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+				return
+			}
+			h.handleVerifyHand(w, r, userID, source, handID)
+			return
+		}
+
+		if len(parts) == 2 && parts[1] == "annotations" {
+			if r.Method != http.MethodPut {
+				writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+				return
+			}
+			h.handleSetAnnotations(w, r, userID, source, handID)
+			return
+		}
+
 		if len(parts) == 2 && parts[1] == "save" {
 			switch r.Method {
 			case http.MethodPost:
@@ -138,6 +226,51 @@ func (h *HTTPHandler) handleGetHand(w http.ResponseWriter, r *http.Request, user
 	})
 }
 
+// handleVerifyHand replays a stored hand through the generator and reports
+// semantic differences, catching ledger corruption or engine drift.
+func (h *HTTPHandler) handleVerifyHand(w http.ResponseWriter, r *http.Request, userID uint64, source Source, handID string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	events, err := h.ledger.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			writeError(w, http.StatusNotFound, "hand not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "query hand events failed")
+		return
+	}
+
+	envs, discrepancies := decodeEventEnvelopes(events)
+	if len(discrepancies) == 0 {
+		found, err := replay.VerifyEvents(envs, userID)
+		if err != nil {
+			var replayErr *replay.ReplayError
+			if !errors.As(err, &replayErr) {
+				writeError(w, http.StatusInternalServerError, "verify hand failed")
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{
+				"hand_id":      handID,
+				"source":       source,
+				"verified":     false,
+				"replay_error": replayErr,
+			})
+			return
+		}
+		discrepancies = found
+	}
+	if discrepancies == nil {
+		discrepancies = []replay.TapeDiscrepancy{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"hand_id":       handID,
+		"source":        source,
+		"verified":      len(discrepancies) == 0,
+		"discrepancies": discrepancies,
+	})
+}
+
 func (h *HTTPHandler) handleSetSaved(w http.ResponseWriter, r *http.Request, userID uint64, source Source, handID string, saved bool) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -160,6 +293,34 @@ func (h *HTTPHandler) handleSetSaved(w http.ResponseWriter, r *http.Request, use
 	})
 }
 
+func (h *HTTPHandler) handleSetAnnotations(w http.ResponseWriter, r *http.Request, userID uint64, source Source, handID string) {
+	var req setAnnotationsRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := h.ledger.SetHandAnnotations(ctx, userID, source, handID, req.Annotations); err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			writeError(w, http.StatusNotFound, "hand not found")
+		case errors.Is(err, ErrInvalidAnnotation):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "update annotations failed")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"hand_id": handID,
+		"source":  source,
+		"saved":   true,
+	})
+}
+
 func (h *HTTPHandler) handleUpsertReplayHand(w http.ResponseWriter, r *http.Request, userID uint64, handID string) {
 	var req upsertReplayHandRequest
 	dec := json.NewDecoder(r.Body)
@@ -208,6 +369,63 @@ func parseLimit(raw string) int {
 	return n
 }
 
+// parseHistoryFilter reads the optional from/to (RFC 3339), min_delta and
+// only_wins query parameters.
+func parseHistoryFilter(q url.Values) (HistoryFilter, error) {
+	var f HistoryFilter
+	for _, bound := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &f.From}, {"to", &f.To}} {
+		raw := strings.TrimSpace(q.Get(bound.name))
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return HistoryFilter{}, fmt.Errorf("invalid %s: want an RFC 3339 time", bound.name)
+		}
+		*bound.dst = t
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return HistoryFilter{}, errors.New("invalid time range: from must be before to")
+	}
+	if raw := strings.TrimSpace(q.Get("min_delta")); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return HistoryFilter{}, errors.New("invalid min_delta")
+		}
+		f.MinDelta = &n
+	}
+	if raw := strings.TrimSpace(q.Get("only_wins")); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return HistoryFilter{}, errors.New("invalid only_wins")
+		}
+		f.OnlyWins = b
+	}
+	return f, nil
+}
+
+func parseSources(raw string) ([]Source, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return []Source{SourceLive, SourceReplay}, true
+	}
+	var sources []Source
+	for _, part := range strings.Split(raw, ",") {
+		source := Source(strings.ToLower(strings.TrimSpace(part)))
+		if source == "" {
+			continue
+		}
+		if !isAuditSource(source) {
+			return nil, false
+		}
+		sources = append(sources, source)
+	}
+	return sources, len(sources) > 0
+}
+
 func bearerToken(raw string) string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -0,0 +1,670 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQLService is the ledger on MySQL 8. Its schema mirrors the SQLite one:
+// timestamps are epoch milliseconds and summaries live in JSON columns, so
+// history rows scan with scanSQLiteHistory.
+type MySQLService struct {
+	db     *sql.DB
+	limits historyLimits
+}
+
+// NewMySQLServiceFromEnv connects to LEDGER_MYSQL_DSN, falling back to
+// LEDGER_DATABASE_DSN, in go-sql-driver form (user:pass@tcp(host:3306)/db).
+func NewMySQLServiceFromEnv() (*MySQLService, error) {
+	dsn := strings.TrimSpace(os.Getenv("LEDGER_MYSQL_DSN"))
+	if dsn == "" {
+		dsn = strings.TrimSpace(os.Getenv("LEDGER_DATABASE_DSN"))
+	}
+	if dsn == "" {
+		return nil, fmt.Errorf("LEDGER_MYSQL_DSN is required for the mysql ledger")
+	}
+	return NewMySQLService(dsn)
+}
+
+func NewMySQLService(dsn string) (*MySQLService, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	// Report matched rather than changed rows, so an UPDATE that rewrites
+	// the same value is not mistaken for a missing hand.
+	cfg.ClientFoundRows = true
+
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(20)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(30 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := ensureMySQLLedgerSchema(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &MySQLService{
+		db:     db,
+		limits: historyLimitsFromEnv(),
+	}, nil
+}
+
+func (s *MySQLService) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *MySQLService) AppendLiveEvent(handID string, env *pb.ServerEnvelope, encoded []byte) {
+	if strings.TrimSpace(handID) == "" || env == nil {
+		return
+	}
+	if encoded == nil {
+		raw, err := envMarshal(env)
+		if err != nil {
+			log.Printf("[Ledger] marshal live event failed: hand=%s err=%v", handID, err)
+			return
+		}
+		encoded = raw
+	}
+
+	payloadB64 := base64.StdEncoding.EncodeToString(encoded)
+	eventType := envelopePayloadType(env)
+	nowMs := time.Now().UTC().UnixMilli()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	// The no-op update is MySQL's ON CONFLICT DO NOTHING; INSERT IGNORE
+	// would also swallow unrelated errors.
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO ledger_event_stream (
+    source, scenario_id, hand_id, seq, event_type, envelope_b64, server_ts_ms, created_at_ms
+)
+VALUES ('live', '', ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE id = id
+`, handID, env.GetServerSeq(), eventType, payloadB64, nullableInt64(env.GetServerTsMs()), nowMs)
+	if err != nil {
+		log.Printf("[Ledger] append live event failed: hand=%s seq=%d err=%v", handID, env.GetServerSeq(), err)
+	}
+}
+
+func (s *MySQLService) UpsertLiveHistory(userID uint64, handID string, playedAt time.Time, summary map[string]any) {
+	s.upsertLiveHistoryInternal(userID, handID, playedAt, summary, nil)
+}
+
+func (s *MySQLService) UpsertLiveHistoryWithEvents(
+	userID uint64,
+	handID string,
+	playedAt time.Time,
+	summary map[string]any,
+	events []EventItem,
+) {
+	var tapeBlob []byte
+	if len(events) > 0 {
+		raw, err := json.Marshal(events)
+		if err != nil {
+			log.Printf("[Ledger] marshal live tape events failed: user=%d hand=%s err=%v", userID, handID, err)
+		} else {
+			tapeBlob = raw
+		}
+	}
+	s.upsertLiveHistoryInternal(userID, handID, playedAt, summary, tapeBlob)
+}
+
+func (s *MySQLService) upsertLiveHistoryInternal(
+	userID uint64,
+	handID string,
+	playedAt time.Time,
+	summary map[string]any,
+	tapeBlob []byte,
+) {
+	if userID == 0 || strings.TrimSpace(handID) == "" {
+		return
+	}
+	if playedAt.IsZero() {
+		playedAt = time.Now().UTC()
+	}
+	if summary == nil {
+		summary = map[string]any{}
+	}
+	summaryRaw, err := json.Marshal(summary)
+	if err != nil {
+		log.Printf("[Ledger] marshal hand summary failed: user=%d hand=%s err=%v", userID, handID, err)
+		return
+	}
+
+	playedAtMs := playedAt.UTC().UnixMilli()
+	nowMs := time.Now().UTC().UnixMilli()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("[Ledger] begin upsert live history tx failed: user=%d hand=%s err=%v", userID, handID, err)
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO audit_user_hand_history (
+    user_id, source, hand_id, played_at_ms, summary_json, tape_blob, annotations_json, is_saved, saved_at_ms, created_at_ms, updated_at_ms
+)
+VALUES (?, 'live', ?, ?, ?, ?, '[]', 0, NULL, ?, ?)
+ON DUPLICATE KEY UPDATE
+    played_at_ms = VALUES(played_at_ms),
+    summary_json = VALUES(summary_json),
+    tape_blob = COALESCE(VALUES(tape_blob), tape_blob),
+    updated_at_ms = VALUES(updated_at_ms)
+`, userID, handID, playedAtMs, string(summaryRaw), nullableBytes(tapeBlob), nowMs, nowMs)
+	if err != nil {
+		log.Printf("[Ledger] upsert live history failed: user=%d hand=%s err=%v", userID, handID, err)
+		return
+	}
+
+	if limit := s.limits.recentFor(SourceLive); limit > 0 {
+		if err := trimMySQLHistory(ctx, tx, userID, SourceLive, limit); err != nil {
+			log.Printf("[Ledger] trim live history failed: user=%d err=%v", userID, err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[Ledger] commit live history failed: user=%d hand=%s err=%v", userID, handID, err)
+	}
+}
+
+func (s *MySQLService) UpsertReplayHand(
+	ctx context.Context,
+	userID uint64,
+	handID string,
+	events []EventItem,
+	summary map[string]any,
+) error {
+	if userID == 0 || strings.TrimSpace(handID) == "" {
+		return ErrNotFound
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("events is required")
+	}
+	if summary == nil {
+		summary = map[string]any{}
+	}
+	if _, ok := summary["event_count"]; !ok {
+		summary["event_count"] = len(events)
+	}
+	summaryRaw, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	nowMs := time.Now().UTC().UnixMilli()
+	for _, e := range events {
+		if e.EventType == "" {
+			e.EventType = "unknown"
+		}
+		_, err := tx.ExecContext(ctx, `
+INSERT INTO ledger_event_stream (
+    source, scenario_id, hand_id, seq, event_type, envelope_b64, server_ts_ms, created_at_ms
+)
+VALUES ('replay', '', ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    event_type = VALUES(event_type),
+    envelope_b64 = VALUES(envelope_b64),
+    server_ts_ms = VALUES(server_ts_ms)
+`, handID, e.Seq, e.EventType, e.EnvelopeB64, nullableInt64Ptr(e.ServerTsMs), nowMs)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO audit_user_hand_history (
+    user_id, source, hand_id, played_at_ms, summary_json, annotations_json, is_saved, saved_at_ms, created_at_ms, updated_at_ms
+)
+VALUES (?, 'replay', ?, ?, ?, '[]', 0, NULL, ?, ?)
+ON DUPLICATE KEY UPDATE
+    played_at_ms = VALUES(played_at_ms),
+    summary_json = VALUES(summary_json),
+    updated_at_ms = VALUES(updated_at_ms)
+`, userID, handID, nowMs, string(summaryRaw), nowMs, nowMs)
+	if err != nil {
+		return err
+	}
+
+	if limit := s.limits.recentFor(SourceReplay); limit > 0 {
+		if err := trimMySQLHistory(ctx, tx, userID, SourceReplay, limit); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// trimMySQLHistory deletes a user's unsaved hands of source beyond the newest
+// limit. MySQL rejects LIMIT inside IN and a subquery on the table being
+// deleted from, so the stale ids come from a materialized derived table.
+func trimMySQLHistory(ctx context.Context, tx *sql.Tx, userID uint64, source Source, limit int) error {
+	_, err := tx.ExecContext(ctx, `
+DELETE h
+FROM audit_user_hand_history h
+JOIN (
+    SELECT id
+    FROM audit_user_hand_history
+    WHERE user_id = ?
+      AND source = ?
+      AND is_saved = 0
+    ORDER BY played_at_ms DESC, id DESC
+    LIMIT 18446744073709551615 OFFSET ?
+) stale ON stale.id = h.id
+`, userID, string(source), limit)
+	return err
+}
+
+func (s *MySQLService) ListRecent(ctx context.Context, userID uint64, source Source, limit int, filter HistoryFilter) ([]HistoryItem, error) {
+	if userID == 0 {
+		return []HistoryItem{}, nil
+	}
+	if !isAuditSource(source) {
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	clauses, filterArgs := filter.mysqlClauses()
+	args := append([]any{userID, string(source)}, filterArgs...)
+	args = append(args, limit)
+	rows, err := s.db.QueryContext(ctx, `
+SELECT hand_id, source, played_at_ms, summary_json, is_saved, saved_at_ms, updated_at_ms
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?`+clauses+`
+ORDER BY played_at_ms DESC, id DESC
+LIMIT ?
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSQLiteHistory(rows, limit)
+}
+
+func (s *MySQLService) ListRecentMulti(ctx context.Context, userID uint64, sources []Source, limit int) ([]HistoryItem, error) {
+	if userID == 0 {
+		return []HistoryItem{}, nil
+	}
+	sources, err := normalizeAuditSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	args := make([]any, 0, 3*len(sources)+1)
+	branches := make([]string, 0, len(sources))
+	for _, source := range sources {
+		args = append(args, userID, string(source), limit)
+		branches = append(branches, `(
+    SELECT id, hand_id, source, played_at_ms, summary_json, is_saved, saved_at_ms, updated_at_ms
+    FROM audit_user_hand_history
+    WHERE user_id = ?
+      AND source = ?
+    ORDER BY played_at_ms DESC, id DESC
+    LIMIT ?
+)`)
+	}
+	args = append(args, limit)
+	rows, err := s.db.QueryContext(ctx, `
+SELECT hand_id, source, played_at_ms, summary_json, is_saved, saved_at_ms, updated_at_ms
+FROM (
+`+strings.Join(branches, "\nUNION ALL\n")+`
+) merged
+ORDER BY played_at_ms DESC, id DESC
+LIMIT ?
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSQLiteHistory(rows, limit)
+}
+
+func (s *MySQLService) GetHandEvents(ctx context.Context, userID uint64, source Source, handID string) ([]EventItem, error) {
+	if userID == 0 || strings.TrimSpace(handID) == "" {
+		return nil, ErrNotFound
+	}
+	if !isAuditSource(source) {
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var tapeBlob, annotationsRaw []byte
+	err := s.db.QueryRowContext(ctx, `
+SELECT tape_blob, annotations_json
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, userID, string(source), handID).Scan(&tapeBlob, &annotationsRaw)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if len(tapeBlob) > 0 {
+		var events []EventItem
+		if err := json.Unmarshal(tapeBlob, &events); err == nil && len(events) > 0 {
+			return attachAnnotations(events, annotationsRaw), nil
+		}
+	}
+	events, err := s.streamEvents(ctx, source, handID)
+	if err != nil {
+		return nil, err
+	}
+	return attachAnnotations(events, annotationsRaw), nil
+}
+
+func (s *MySQLService) SetHandAnnotations(ctx context.Context, userID uint64, source Source, handID string, annotations []Annotation) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	events, err := s.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeAnnotations(annotations, events)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET annotations_json = ?
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, string(raw), userID, string(source), handID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *MySQLService) RebuildSummary(ctx context.Context, userID uint64, source Source, handID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	events, err := s.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		return err
+	}
+	var prevRaw []byte
+	if err := s.db.QueryRowContext(ctx, `
+SELECT summary_json
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, userID, string(source), handID).Scan(&prevRaw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	prev := map[string]any{}
+	_ = json.Unmarshal(prevRaw, &prev)
+	summary, err := rebuildSummary(userID, events, prev)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET summary_json = ?, updated_at_ms = ?
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, string(raw), time.Now().UnixMilli(), userID, string(source), handID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *MySQLService) GetLiveStreamEvents(ctx context.Context, handID string) ([]EventItem, error) {
+	if strings.TrimSpace(handID) == "" {
+		return nil, ErrNotFound
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return s.streamEvents(ctx, SourceLive, handID)
+}
+
+func (s *MySQLService) streamEvents(ctx context.Context, source Source, handID string) ([]EventItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT seq, event_type, envelope_b64, server_ts_ms
+FROM ledger_event_stream
+WHERE source = ?
+  AND scenario_id = ''
+  AND hand_id = ?
+ORDER BY seq ASC
+`, string(source), handID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]EventItem, 0, 128)
+	for rows.Next() {
+		var e EventItem
+		var serverTs sql.NullInt64
+		if err := rows.Scan(&e.Seq, &e.EventType, &e.EnvelopeB64, &serverTs); err != nil {
+			return nil, err
+		}
+		if serverTs.Valid {
+			v := serverTs.Int64
+			e.ServerTsMs = &v
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, ErrNotFound
+	}
+	return events, nil
+}
+
+func (s *MySQLService) SetSaved(ctx context.Context, userID uint64, source Source, handID string, saved bool) error {
+	if userID == 0 || strings.TrimSpace(handID) == "" {
+		return ErrNotFound
+	}
+	if !isAuditSource(source) {
+		return fmt.Errorf("invalid source %q", source)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current int64
+	err = tx.QueryRowContext(ctx, `
+SELECT is_saved
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+FOR UPDATE
+`, userID, string(source), handID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if (current == 1) == saved {
+		return tx.Commit()
+	}
+
+	nowMs := time.Now().UTC().UnixMilli()
+	if saved {
+		var savedCount int
+		if err := tx.QueryRowContext(ctx, `
+SELECT COUNT(1)
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+  AND is_saved = 1
+`, userID, string(source)).Scan(&savedCount); err != nil {
+			return err
+		}
+		if savedCount >= s.limits.savedFor(source) {
+			return ErrSavedLimitReach
+		}
+		_, err := tx.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET is_saved = 1,
+    saved_at_ms = ?,
+    updated_at_ms = ?
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, nowMs, nowMs, userID, string(source), handID)
+		if err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET is_saved = 0,
+    saved_at_ms = NULL,
+    updated_at_ms = ?
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, nowMs, userID, string(source), handID)
+	if err != nil {
+		return err
+	}
+
+	if limit := s.limits.recentFor(source); limit > 0 {
+		if err := trimMySQLHistory(ctx, tx, userID, source, limit); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ensureMySQLLedgerSchema creates the SQLite schema's tables in MySQL terms:
+// JSON columns for summaries and annotations, and indexes declared inline
+// because MySQL has no CREATE INDEX IF NOT EXISTS.
+func ensureMySQLLedgerSchema(ctx context.Context, db *sql.DB) error {
+	statements := []string{
+		`
+CREATE TABLE IF NOT EXISTS ledger_event_stream (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    source VARCHAR(16) NOT NULL,
+    scenario_id VARCHAR(128) NOT NULL DEFAULT '',
+    hand_id VARCHAR(128) NOT NULL,
+    seq BIGINT UNSIGNED NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    envelope_b64 MEDIUMTEXT NOT NULL,
+    server_ts_ms BIGINT,
+    created_at_ms BIGINT NOT NULL,
+    UNIQUE KEY uq_ledger_event_stream (source, scenario_id, hand_id, seq),
+    KEY idx_ledger_event_stream_hand_seq (source, hand_id, seq),
+    KEY idx_ledger_event_stream_created_at (created_at_ms)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+		`
+CREATE TABLE IF NOT EXISTS audit_user_hand_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    hand_id VARCHAR(128) NOT NULL,
+    played_at_ms BIGINT NOT NULL,
+    summary_json JSON NOT NULL,
+    tape_blob LONGBLOB,
+    annotations_json JSON NOT NULL,
+    is_saved TINYINT(1) NOT NULL DEFAULT 0,
+    saved_at_ms BIGINT,
+    created_at_ms BIGINT NOT NULL,
+    updated_at_ms BIGINT NOT NULL,
+    UNIQUE KEY uq_audit_user_hand_history (user_id, source, hand_id),
+    KEY idx_audit_user_hand_history_recent (user_id, source, played_at_ms DESC),
+    KEY idx_audit_user_hand_history_saved (user_id, source, is_saved, saved_at_ms DESC),
+    KEY idx_audit_user_hand_history_trim (user_id, source, played_at_ms ASC, id ASC)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestMySQLService connects to LEDGER_TEST_MYSQL_DSN, a scratch MySQL 8
+// database the ledger may create its tables in, and skips when it is unset.
+// Each test picks user ids from the clock so reruns never collide.
+func newTestMySQLService(t *testing.T) (*MySQLService, uint64) {
+	t.Helper()
+	dsn := os.Getenv("LEDGER_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("LEDGER_TEST_MYSQL_DSN not set")
+	}
+	t.Setenv("LEDGER_MODE", "mysql")
+	t.Setenv("LEDGER_MYSQL_DSN", dsn)
+	svc, mode, err := NewServiceFromEnv("db")
+	if err != nil {
+		t.Fatalf("NewServiceFromEnv failed: %v", err)
+	}
+	if mode != "mysql" {
+		t.Fatalf("expected the mysql ledger, got %q", mode)
+	}
+	t.Cleanup(func() { svc.Close() })
+	return svc.(*MySQLService), uint64(time.Now().UnixNano() % 1e12)
+}
+
+func TestNewServiceFromEnv_LedgerModeOverridesAuthMode(t *testing.T) {
+	t.Setenv("LEDGER_MODE", "mysql")
+	t.Setenv("LEDGER_MYSQL_DSN", "")
+	t.Setenv("LEDGER_DATABASE_DSN", "")
+	// Without a DSN the MySQL backend refuses to start, which shows it was
+	// picked over the auth mode's sqlite ledger.
+	if _, _, err := NewServiceFromEnv("local"); err == nil || !strings.Contains(err.Error(), "LEDGER_MYSQL_DSN") {
+		t.Fatalf("expected LEDGER_MODE=mysql to pick the MySQL ledger, got %v", err)
+	}
+
+	t.Setenv("LEDGER_MODE", "memory")
+	if _, mode, err := NewServiceFromEnv("db"); err != nil || mode != "memory-noop" {
+		t.Fatalf("expected LEDGER_MODE=memory to pick the no-op ledger, got %q (err %v)", mode, err)
+	}
+	t.Setenv("LEDGER_MODE", "oracle")
+	if _, _, err := NewServiceFromEnv("memory"); err == nil {
+		t.Fatalf("expected an unknown LEDGER_MODE to be rejected")
+	}
+}
+
+func TestMySQLListRecent_Filters(t *testing.T) {
+	svc, userID := newTestMySQLService(t)
+	testHistoryFilters(t, svc, userID)
+}
+
+func TestMySQLGetPlayerStats(t *testing.T) {
+	svc, userID := newTestMySQLService(t)
+	testPlayerStats(t, svc, userID)
+}
+
+func TestMySQLHistoryRoundTrip(t *testing.T) {
+	svc, userID := newTestMySQLService(t)
+	svc.limits = historyLimits{
+		recent: map[Source]int{SourceLive: 2, SourceReplay: 2},
+		saved:  map[Source]int{SourceLive: 1, SourceReplay: 1},
+	}
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tape := []EventItem{{Seq: 1, EventType: "handStart", EnvelopeB64: "AA=="}, {Seq: 2, EventType: "handEnd", EnvelopeB64: "AA=="}}
+	svc.UpsertLiveHistoryWithEvents(userID, "m1", base, map[string]any{"delta": 10}, tape)
+	// A second write without events keeps the stored tape.
+	svc.UpsertLiveHistory(userID, "m1", base, map[string]any{"delta": 20})
+	events, err := svc.GetHandEvents(ctx, userID, SourceLive, "m1")
+	if err != nil || len(events) != 2 {
+		t.Fatalf("expected the stored tape back, got %v err=%v", events, err)
+	}
+	if err := svc.SetHandAnnotations(ctx, userID, SourceLive, "m1", []Annotation{{Seq: 2, Text: "river"}}); err != nil {
+		t.Fatalf("SetHandAnnotations failed: %v", err)
+	}
+	// Writing the same annotations again changes no row but still succeeds.
+	if err := svc.SetHandAnnotations(ctx, userID, SourceLive, "m1", []Annotation{{Seq: 2, Text: "river"}}); err != nil {
+		t.Fatalf("SetHandAnnotations (unchanged) failed: %v", err)
+	}
+	events, _ = svc.GetHandEvents(ctx, userID, SourceLive, "m1")
+	if len(events[1].Annotations) != 1 || events[1].Annotations[0] != "river" {
+		t.Fatalf("expected the annotation on seq 2, got %+v", events)
+	}
+
+	if err := svc.SetSaved(ctx, userID, SourceLive, "m1", true); err != nil {
+		t.Fatalf("SetSaved failed: %v", err)
+	}
+	svc.UpsertLiveHistory(userID, "m2", base.Add(time.Minute), nil)
+	svc.UpsertLiveHistory(userID, "m3", base.Add(2*time.Minute), nil)
+	svc.UpsertLiveHistory(userID, "m4", base.Add(3*time.Minute), nil)
+	if err := svc.SetSaved(ctx, userID, SourceLive, "m4", true); !errors.Is(err, ErrSavedLimitReach) {
+		t.Fatalf("expected the saved limit, got %v", err)
+	}
+
+	items, err := svc.ListRecent(ctx, userID, SourceLive, 10, HistoryFilter{})
+	if err != nil {
+		t.Fatalf("ListRecent failed: %v", err)
+	}
+	got := make([]string, 0, len(items))
+	for _, item := range items {
+		got = append(got, item.HandID)
+	}
+	// m2 is trimmed: two unsaved hands are kept beside the saved m1.
+	if len(got) != 3 || got[0] != "m4" || got[1] != "m3" || got[2] != "m1" || !items[2].IsSaved {
+		t.Fatalf("expected [m4 m3 m1] with m1 saved, got %v", got)
+	}
+
+	if err := svc.UpsertReplayHand(ctx, userID, "r1", tape, nil); err != nil {
+		t.Fatalf("UpsertReplayHand failed: %v", err)
+	}
+	merged, err := svc.ListRecentMulti(ctx, userID, []Source{SourceLive, SourceReplay}, 10)
+	if err != nil {
+		t.Fatalf("ListRecentMulti failed: %v", err)
+	}
+	if len(merged) != 4 || merged[0].HandID != "r1" {
+		t.Fatalf("expected the replay hand first of four, got %+v", merged)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/replay"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/protobuf/proto"
@@ -50,9 +51,24 @@ type Service interface {
 		events []EventItem,
 	)
 	UpsertReplayHand(ctx context.Context, userID uint64, handID string, events []EventItem, summary map[string]any) error
-	ListRecent(ctx context.Context, userID uint64, source Source, limit int) ([]HistoryItem, error)
+	// ListRecent returns a source's history newest first, narrowed by filter.
+	ListRecent(ctx context.Context, userID uint64, source Source, limit int, filter HistoryFilter) ([]HistoryItem, error)
+	// ListRecentMulti merges the recent history of several sources into one
+	// list, newest first.
+	ListRecentMulti(ctx context.Context, userID uint64, sources []Source, limit int) ([]HistoryItem, error)
 	GetHandEvents(ctx context.Context, userID uint64, source Source, handID string) ([]EventItem, error)
+	// GetLiveStreamEvents returns the canonical live event stream of a hand
+	// without any per-user scoping; it backs the admin lookup.
+	GetLiveStreamEvents(ctx context.Context, handID string) ([]EventItem, error)
+	// SetHandAnnotations replaces the caller's annotations on a stored hand;
+	// GetHandEvents returns them on the matching events.
+	SetHandAnnotations(ctx context.Context, userID uint64, source Source, handID string, annotations []Annotation) error
 	SetSaved(ctx context.Context, userID uint64, source Source, handID string, saved bool) error
+	// RebuildSummary recomputes a stored hand's summary from its event
+	// stream, for rows written before the summary schema grew a field.
+	RebuildSummary(ctx context.Context, userID uint64, source Source, handID string) error
+	// GetPlayerStats aggregates every stored hand of a source for the user.
+	GetPlayerStats(ctx context.Context, userID uint64, source Source) (*PlayerStats, error)
 }
 
 type HistoryItem struct {
@@ -66,10 +82,11 @@ type HistoryItem struct {
 }
 
 type EventItem struct {
-	Seq         uint64 `json:"seq"`
-	EventType   string `json:"event_type"`
-	EnvelopeB64 string `json:"envelope_b64"`
-	ServerTsMs  *int64 `json:"server_ts_ms,omitempty"`
+	Seq         uint64   `json:"seq"`
+	EventType   string   `json:"event_type"`
+	EnvelopeB64 string   `json:"envelope_b64"`
+	ServerTsMs  *int64   `json:"server_ts_ms,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
 }
 
 type noopService struct{}
@@ -93,7 +110,11 @@ func (n *noopService) UpsertReplayHand(_ context.Context, _ uint64, _ string, _
 	return nil
 }
 
-func (n *noopService) ListRecent(_ context.Context, _ uint64, _ Source, _ int) ([]HistoryItem, error) {
+func (n *noopService) ListRecent(_ context.Context, _ uint64, _ Source, _ int, _ HistoryFilter) ([]HistoryItem, error) {
+	return []HistoryItem{}, nil
+}
+
+func (n *noopService) ListRecentMulti(_ context.Context, _ uint64, _ []Source, _ int) ([]HistoryItem, error) {
 	return []HistoryItem{}, nil
 }
 
@@ -101,18 +122,42 @@ func (n *noopService) GetHandEvents(_ context.Context, _ uint64, _ Source, _ str
 	return []EventItem{}, nil
 }
 
+func (n *noopService) GetLiveStreamEvents(_ context.Context, _ string) ([]EventItem, error) {
+	return nil, ErrNotFound
+}
+
+func (n *noopService) SetHandAnnotations(_ context.Context, _ uint64, _ Source, _ string, _ []Annotation) error {
+	return nil
+}
+
 func (n *noopService) SetSaved(_ context.Context, _ uint64, _ Source, _ string, _ bool) error {
 	return nil
 }
 
+func (n *noopService) RebuildSummary(_ context.Context, _ uint64, _ Source, _ string) error {
+	return nil
+}
+
+func (n *noopService) GetPlayerStats(_ context.Context, _ uint64, source Source) (*PlayerStats, error) {
+	return &PlayerStats{Source: source}, nil
+}
+
 type PostgresService struct {
-	db          *sql.DB
-	recentLimit int
-	savedLimit  int
+	db     *sql.DB
+	limits historyLimits
 }
 
+// NewServiceFromEnv opens the ledger backend named by LEDGER_MODE (memory,
+// local/sqlite, mysql, or db/postgres), or by authMode when it is unset.
 func NewServiceFromEnv(authMode string) (Service, string, error) {
-	mode := strings.ToLower(strings.TrimSpace(authMode))
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("LEDGER_MODE")))
+	switch mode {
+	case "":
+		mode = strings.ToLower(strings.TrimSpace(authMode))
+	case "memory", "local", "sqlite", "mysql", "db", "postgres":
+	default:
+		return nil, "", fmt.Errorf("unknown LEDGER_MODE %q", mode)
+	}
 	if mode == "memory" {
 		return &noopService{}, "memory-noop", nil
 	}
@@ -123,6 +168,13 @@ func NewServiceFromEnv(authMode string) (Service, string, error) {
 		}
 		return service, "sqlite", nil
 	}
+	if mode == "mysql" {
+		service, err := NewMySQLServiceFromEnv()
+		if err != nil {
+			return nil, "", err
+		}
+		return service, "mysql", nil
+	}
 
 	dsn := ledgerDSNFromEnv()
 	db, err := sql.Open("postgres", dsn)
@@ -156,9 +208,8 @@ SELECT EXISTS (
 	}
 
 	return &PostgresService{
-		db:          db,
-		recentLimit: envIntOrDefault("AUDIT_RECENT_LIMIT_X", defaultRecentLimit),
-		savedLimit:  envIntOrDefault("AUDIT_SAVED_LIMIT_Y", defaultSavedLimit),
+		db:     db,
+		limits: historyLimitsFromEnv(),
 	}, "postgres", nil
 }
 
@@ -268,7 +319,7 @@ SET
 		return
 	}
 
-	if s.recentLimit > 0 {
+	if limit := s.limits.recentFor(SourceLive); limit > 0 {
 		if _, err := tx.ExecContext(ctx, `
 DELETE FROM audit_user_hand_history
 WHERE user_id = $1
@@ -283,7 +334,7 @@ WHERE user_id = $1
       ORDER BY played_at DESC, id DESC
       OFFSET $2
   )
-`, userID, s.recentLimit); err != nil {
+`, userID, limit); err != nil {
 			log.Printf("[Ledger] trim live history failed: user=%d err=%v", userID, err)
 			return
 		}
@@ -360,7 +411,7 @@ SET
 		return err
 	}
 
-	if s.recentLimit > 0 {
+	if limit := s.limits.recentFor(SourceReplay); limit > 0 {
 		_, err = tx.ExecContext(ctx, `
 DELETE FROM audit_user_hand_history
 WHERE user_id = $1
@@ -375,7 +426,7 @@ WHERE user_id = $1
       ORDER BY played_at DESC, id DESC
       OFFSET $2
   )
-`, userID, s.recentLimit)
+`, userID, limit)
 		if err != nil {
 			return err
 		}
@@ -384,7 +435,7 @@ WHERE user_id = $1
 	return tx.Commit()
 }
 
-func (s *PostgresService) ListRecent(ctx context.Context, userID uint64, source Source, limit int) ([]HistoryItem, error) {
+func (s *PostgresService) ListRecent(ctx context.Context, userID uint64, source Source, limit int, filter HistoryFilter) ([]HistoryItem, error) {
 	if userID == 0 {
 		return []HistoryItem{}, nil
 	}
@@ -395,19 +446,66 @@ func (s *PostgresService) ListRecent(ctx context.Context, userID uint64, source
 		limit = 20
 	}
 
+	clauses, filterArgs := filter.postgresClauses(4)
+	args := append([]any{userID, string(source), limit}, filterArgs...)
 	rows, err := s.db.QueryContext(ctx, `
 SELECT hand_id, source::text, played_at, summary_json, is_saved, saved_at, updated_at
 FROM audit_user_hand_history
 WHERE user_id = $1
-  AND source = $2
+  AND source = $2`+clauses+`
 ORDER BY played_at DESC, id DESC
 LIMIT $3
-`, userID, string(source), limit)
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPostgresHistory(rows, limit)
+}
+
+// ListRecentMulti runs one index-backed branch per source and merges them with
+// UNION ALL, so each branch reads at most limit rows.
+func (s *PostgresService) ListRecentMulti(ctx context.Context, userID uint64, sources []Source, limit int) ([]HistoryItem, error) {
+	if userID == 0 {
+		return []HistoryItem{}, nil
+	}
+	sources, err := normalizeAuditSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	args := []any{userID, limit}
+	branches := make([]string, 0, len(sources))
+	for _, source := range sources {
+		args = append(args, string(source))
+		branches = append(branches, fmt.Sprintf(`(
+    SELECT id, hand_id, source::text AS source, played_at, summary_json, is_saved, saved_at, updated_at
+    FROM audit_user_hand_history
+    WHERE user_id = $1
+      AND source = $%d
+    ORDER BY played_at DESC, id DESC
+    LIMIT $2
+)`, len(args)))
+	}
+	rows, err := s.db.QueryContext(ctx, `
+SELECT hand_id, source, played_at, summary_json, is_saved, saved_at, updated_at
+FROM (
+`+strings.Join(branches, "\nUNION ALL\n")+`
+) merged
+ORDER BY played_at DESC, id DESC
+LIMIT $2
+`, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanPostgresHistory(rows, limit)
+}
 
+func scanPostgresHistory(rows *sql.Rows, limit int) ([]HistoryItem, error) {
 	items := make([]HistoryItem, 0, limit)
 	for rows.Next() {
 		var item HistoryItem
@@ -441,7 +539,7 @@ func (s *PostgresService) GetHandEvents(ctx context.Context, userID uint64, sour
 		return nil, fmt.Errorf("invalid source %q", source)
 	}
 
-	var tapeBlob []byte
+	var tapeBlob, annotationsRaw []byte
 	var historyExists bool
 	if err := s.db.QueryRowContext(ctx, `
 SELECT EXISTS (
@@ -457,8 +555,15 @@ SELECT EXISTS (
       AND source = $2
       AND hand_id = $3
     LIMIT 1
+), (
+    SELECT annotations_json::text
+    FROM audit_user_hand_history
+    WHERE user_id = $1
+      AND source = $2
+      AND hand_id = $3
+    LIMIT 1
 )
-`, userID, string(source), handID).Scan(&historyExists, &tapeBlob); err != nil {
+`, userID, string(source), handID).Scan(&historyExists, &tapeBlob, &annotationsRaw); err != nil {
 		return nil, err
 	}
 	if !historyExists {
@@ -467,10 +572,98 @@ SELECT EXISTS (
 	if len(tapeBlob) > 0 {
 		var events []EventItem
 		if err := json.Unmarshal(tapeBlob, &events); err == nil && len(events) > 0 {
-			return events, nil
+			return attachAnnotations(events, annotationsRaw), nil
+		}
+	}
+
+	events, err := s.streamEvents(ctx, source, handID)
+	if err != nil {
+		return nil, err
+	}
+	return attachAnnotations(events, annotationsRaw), nil
+}
+
+func (s *PostgresService) SetHandAnnotations(ctx context.Context, userID uint64, source Source, handID string, annotations []Annotation) error {
+	events, err := s.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeAnnotations(annotations, events)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET annotations_json = $4::jsonb
+WHERE user_id = $1
+  AND source = $2
+  AND hand_id = $3
+`, userID, string(source), handID, string(raw))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresService) RebuildSummary(ctx context.Context, userID uint64, source Source, handID string) error {
+	events, err := s.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		return err
+	}
+	var prevRaw []byte
+	if err := s.db.QueryRowContext(ctx, `
+SELECT summary_json
+FROM audit_user_hand_history
+WHERE user_id = $1
+  AND source = $2
+  AND hand_id = $3
+`, userID, string(source), handID).Scan(&prevRaw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
 		}
+		return err
+	}
+	prev := map[string]any{}
+	_ = json.Unmarshal(prevRaw, &prev)
+	summary, err := rebuildSummary(userID, events, prev)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET summary_json = $4::jsonb, updated_at = NOW()
+WHERE user_id = $1
+  AND source = $2
+  AND hand_id = $3
+`, userID, string(source), handID, string(raw))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
 	}
+	return nil
+}
 
+func (s *PostgresService) GetLiveStreamEvents(ctx context.Context, handID string) ([]EventItem, error) {
+	if strings.TrimSpace(handID) == "" {
+		return nil, ErrNotFound
+	}
+	return s.streamEvents(ctx, SourceLive, handID)
+}
+
+func (s *PostgresService) streamEvents(ctx context.Context, source Source, handID string) ([]EventItem, error) {
 	rows, err := s.db.QueryContext(ctx, `
 SELECT seq, event_type, envelope_b64, server_ts_ms
 FROM ledger_event_stream
@@ -549,7 +742,7 @@ WHERE user_id = $1
 `, userID, string(source)).Scan(&savedCount); err != nil {
 			return err
 		}
-		if savedCount >= s.savedLimit {
+		if savedCount >= s.limits.savedFor(source) {
 			return ErrSavedLimitReach
 		}
 		if _, err := tx.ExecContext(ctx, `
@@ -577,7 +770,7 @@ WHERE user_id = $1
 `, userID, string(source), handID); err != nil {
 		return err
 	}
-	if s.recentLimit > 0 {
+	if limit := s.limits.recentFor(source); limit > 0 {
 		if _, err := tx.ExecContext(ctx, `
 DELETE FROM audit_user_hand_history
 WHERE user_id = $1
@@ -592,7 +785,7 @@ WHERE user_id = $1
       ORDER BY played_at DESC, id DESC
       OFFSET $3
   )
-`, userID, string(source), s.recentLimit); err != nil {
+`, userID, string(source), limit); err != nil {
 			return err
 		}
 	}
@@ -612,6 +805,46 @@ func ledgerDSNFromEnv() string {
 	return defaultDatabaseDSN
 }
 
+// historyLimits caps how many unsaved (recent) and saved hands each user
+// keeps per source.
+type historyLimits struct {
+	recent map[Source]int
+	saved  map[Source]int
+}
+
+// historyLimitsFromEnv reads AUDIT_RECENT_LIMIT_X and AUDIT_SAVED_LIMIT_Y for
+// every source, overridden per source by AUDIT_RECENT_LIMIT_LIVE,
+// AUDIT_RECENT_LIMIT_REPLAY, AUDIT_SAVED_LIMIT_LIVE and
+// AUDIT_SAVED_LIMIT_REPLAY.
+func historyLimitsFromEnv() historyLimits {
+	recent := envIntOrDefault("AUDIT_RECENT_LIMIT_X", defaultRecentLimit)
+	saved := envIntOrDefault("AUDIT_SAVED_LIMIT_Y", defaultSavedLimit)
+	limits := historyLimits{
+		recent: make(map[Source]int, 2),
+		saved:  make(map[Source]int, 2),
+	}
+	for _, source := range []Source{SourceLive, SourceReplay} {
+		suffix := strings.ToUpper(string(source))
+		limits.recent[source] = envIntOrDefault("AUDIT_RECENT_LIMIT_"+suffix, recent)
+		limits.saved[source] = envIntOrDefault("AUDIT_SAVED_LIMIT_"+suffix, saved)
+	}
+	return limits
+}
+
+func (l historyLimits) recentFor(source Source) int {
+	if n, ok := l.recent[source]; ok {
+		return n
+	}
+	return defaultRecentLimit
+}
+
+func (l historyLimits) savedFor(source Source) int {
+	if n, ok := l.saved[source]; ok {
+		return n
+	}
+	return defaultSavedLimit
+}
+
 func envIntOrDefault(key string, fallback int) int {
 	v := strings.TrimSpace(os.Getenv(key))
 	if v == "" {
@@ -663,6 +896,27 @@ func isAuditSource(source Source) bool {
 	return source == SourceLive || source == SourceReplay
 }
 
+// normalizeAuditSources validates and de-duplicates a source list, keeping the
+// caller's order.
+func normalizeAuditSources(sources []Source) ([]Source, error) {
+	out := make([]Source, 0, len(sources))
+	seen := make(map[Source]struct{}, len(sources))
+	for _, source := range sources {
+		if !isAuditSource(source) {
+			return nil, fmt.Errorf("invalid source %q", source)
+		}
+		if _, ok := seen[source]; ok {
+			continue
+		}
+		seen[source] = struct{}{}
+		out = append(out, source)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one source is required")
+	}
+	return out, nil
+}
+
 func nullableInt64(v int64) any {
 	if v == 0 {
 		return nil
@@ -677,6 +931,31 @@ func nullableBytes(v []byte) any {
 	return v
 }
 
+// decodeEventEnvelopes decodes stored events; undecodable rows are reported as
+// discrepancies instead of failing the whole hand.
+func decodeEventEnvelopes(events []EventItem) ([]*pb.ServerEnvelope, []replay.TapeDiscrepancy) {
+	envs := make([]*pb.ServerEnvelope, 0, len(events))
+	var bad []replay.TapeDiscrepancy
+	for i, e := range events {
+		raw, err := base64.StdEncoding.DecodeString(e.EnvelopeB64)
+		env := &pb.ServerEnvelope{}
+		if err == nil {
+			err = proto.Unmarshal(raw, env)
+		}
+		if err != nil {
+			bad = append(bad, replay.TapeDiscrepancy{
+				Index:     i,
+				EventType: e.EventType,
+				Reason:    "undecodable",
+				Actual:    err.Error(),
+			})
+			continue
+		}
+		envs = append(envs, env)
+	}
+	return envs, bad
+}
+
 func envMarshal(env *pb.ServerEnvelope) ([]byte, error) {
 	return proto.Marshal(env)
 }
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	pb "holdem-lite/apps/server/gen"
@@ -21,9 +22,12 @@ import (
 const defaultLocalDBName = "holdem_local.db"
 
 type SQLiteService struct {
-	db          *sql.DB
-	recentLimit int
-	savedLimit  int
+	db     *sql.DB
+	limits historyLimits
+
+	// done stops the maintenance loop (see maintenanceLoop).
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
 func NewSQLiteServiceFromEnv() (*SQLiteService, error) {
@@ -79,17 +83,22 @@ func NewSQLiteService(dbPath string) (*SQLiteService, error) {
 		return nil, err
 	}
 
-	return &SQLiteService{
-		db:          db,
-		recentLimit: envIntOrDefault("AUDIT_RECENT_LIMIT_X", defaultRecentLimit),
-		savedLimit:  envIntOrDefault("AUDIT_SAVED_LIMIT_Y", defaultSavedLimit),
-	}, nil
+	svc := &SQLiteService{
+		db:     db,
+		limits: historyLimitsFromEnv(),
+		done:   make(chan struct{}),
+	}
+	if interval := sqliteMaintenanceIntervalFromEnv(); interval > 0 {
+		go svc.maintenanceLoop(interval, sqliteVacuumFreeRatioFromEnv())
+	}
+	return svc, nil
 }
 
 func (s *SQLiteService) Close() error {
 	if s == nil || s.db == nil {
 		return nil
 	}
+	s.stopOnce.Do(func() { close(s.done) })
 	return s.db.Close()
 }
 
@@ -198,7 +207,7 @@ SET
 		return
 	}
 
-	if s.recentLimit > 0 {
+	if limit := s.limits.recentFor(SourceLive); limit > 0 {
 		_, err = tx.ExecContext(ctx, `
 DELETE FROM audit_user_hand_history
 WHERE user_id = ?
@@ -213,7 +222,7 @@ WHERE user_id = ?
       ORDER BY played_at_ms DESC, id DESC
       LIMIT -1 OFFSET ?
   )
-`, userID, userID, s.recentLimit)
+`, userID, userID, limit)
 		if err != nil {
 			log.Printf("[Ledger] trim live history failed: user=%d err=%v", userID, err)
 			return
@@ -294,7 +303,7 @@ SET
 		return err
 	}
 
-	if s.recentLimit > 0 {
+	if limit := s.limits.recentFor(SourceReplay); limit > 0 {
 		_, err = tx.ExecContext(ctx, `
 DELETE FROM audit_user_hand_history
 WHERE user_id = ?
@@ -309,7 +318,7 @@ WHERE user_id = ?
       ORDER BY played_at_ms DESC, id DESC
       LIMIT -1 OFFSET ?
   )
-`, userID, userID, s.recentLimit)
+`, userID, userID, limit)
 		if err != nil {
 			return err
 		}
@@ -318,7 +327,7 @@ WHERE user_id = ?
 	return tx.Commit()
 }
 
-func (s *SQLiteService) ListRecent(ctx context.Context, userID uint64, source Source, limit int) ([]HistoryItem, error) {
+func (s *SQLiteService) ListRecent(ctx context.Context, userID uint64, source Source, limit int, filter HistoryFilter) ([]HistoryItem, error) {
 	if userID == 0 {
 		return []HistoryItem{}, nil
 	}
@@ -332,19 +341,72 @@ func (s *SQLiteService) ListRecent(ctx context.Context, userID uint64, source So
 		ctx = context.Background()
 	}
 
+	clauses, filterArgs := filter.sqliteClauses()
+	args := append([]any{userID, string(source)}, filterArgs...)
+	args = append(args, limit)
 	rows, err := s.db.QueryContext(ctx, `
 SELECT hand_id, source, played_at_ms, summary_json, is_saved, saved_at_ms, updated_at_ms
 FROM audit_user_hand_history
 WHERE user_id = ?
-  AND source = ?
+  AND source = ?`+clauses+`
+ORDER BY played_at_ms DESC, id DESC
+LIMIT ?
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSQLiteHistory(rows, limit)
+}
+
+// ListRecentMulti merges per-source branches with UNION ALL. SQLite only
+// allows ORDER BY/LIMIT on a compound member inside a subquery, hence the
+// wrapping selects.
+func (s *SQLiteService) ListRecentMulti(ctx context.Context, userID uint64, sources []Source, limit int) ([]HistoryItem, error) {
+	if userID == 0 {
+		return []HistoryItem{}, nil
+	}
+	sources, err := normalizeAuditSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	args := make([]any, 0, 3*len(sources)+1)
+	branches := make([]string, 0, len(sources))
+	for _, source := range sources {
+		args = append(args, userID, string(source), limit)
+		branches = append(branches, `SELECT * FROM (
+    SELECT id, hand_id, source, played_at_ms, summary_json, is_saved, saved_at_ms, updated_at_ms
+    FROM audit_user_hand_history
+    WHERE user_id = ?
+      AND source = ?
+    ORDER BY played_at_ms DESC, id DESC
+    LIMIT ?
+)`)
+	}
+	args = append(args, limit)
+	rows, err := s.db.QueryContext(ctx, `
+SELECT hand_id, source, played_at_ms, summary_json, is_saved, saved_at_ms, updated_at_ms
+FROM (
+`+strings.Join(branches, "\nUNION ALL\n")+`
+)
 ORDER BY played_at_ms DESC, id DESC
 LIMIT ?
-`, userID, string(source), limit)
+`, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanSQLiteHistory(rows, limit)
+}
 
+func scanSQLiteHistory(rows *sql.Rows, limit int) ([]HistoryItem, error) {
 	items := make([]HistoryItem, 0, limit)
 	for rows.Next() {
 		var item HistoryItem
@@ -387,14 +449,14 @@ func (s *SQLiteService) GetHandEvents(ctx context.Context, userID uint64, source
 		ctx = context.Background()
 	}
 
-	var tapeBlob []byte
+	var tapeBlob, annotationsRaw []byte
 	err := s.db.QueryRowContext(ctx, `
-SELECT tape_blob
+SELECT tape_blob, annotations_json
 FROM audit_user_hand_history
 WHERE user_id = ?
   AND source = ?
   AND hand_id = ?
-`, userID, string(source), handID).Scan(&tapeBlob)
+`, userID, string(source), handID).Scan(&tapeBlob, &annotationsRaw)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -404,10 +466,106 @@ WHERE user_id = ?
 	if len(tapeBlob) > 0 {
 		var events []EventItem
 		if err := json.Unmarshal(tapeBlob, &events); err == nil && len(events) > 0 {
-			return events, nil
+			return attachAnnotations(events, annotationsRaw), nil
+		}
+	}
+	events, err := s.streamEvents(ctx, source, handID)
+	if err != nil {
+		return nil, err
+	}
+	return attachAnnotations(events, annotationsRaw), nil
+}
+
+func (s *SQLiteService) SetHandAnnotations(ctx context.Context, userID uint64, source Source, handID string, annotations []Annotation) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	events, err := s.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeAnnotations(annotations, events)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET annotations_json = ?
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, string(raw), userID, string(source), handID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteService) RebuildSummary(ctx context.Context, userID uint64, source Source, handID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	events, err := s.GetHandEvents(ctx, userID, source, handID)
+	if err != nil {
+		return err
+	}
+	var prevRaw []byte
+	if err := s.db.QueryRowContext(ctx, `
+SELECT summary_json
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, userID, string(source), handID).Scan(&prevRaw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
 		}
+		return err
+	}
+	prev := map[string]any{}
+	_ = json.Unmarshal(prevRaw, &prev)
+	summary, err := rebuildSummary(userID, events, prev)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+UPDATE audit_user_hand_history
+SET summary_json = ?, updated_at_ms = ?
+WHERE user_id = ?
+  AND source = ?
+  AND hand_id = ?
+`, string(raw), time.Now().UnixMilli(), userID, string(source), handID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteService) GetLiveStreamEvents(ctx context.Context, handID string) ([]EventItem, error) {
+	if strings.TrimSpace(handID) == "" {
+		return nil, ErrNotFound
 	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return s.streamEvents(ctx, SourceLive, handID)
+}
 
+func (s *SQLiteService) streamEvents(ctx context.Context, source Source, handID string) ([]EventItem, error) {
 	rows, err := s.db.QueryContext(ctx, `
 SELECT seq, event_type, envelope_b64, server_ts_ms
 FROM ledger_event_stream
@@ -492,7 +650,7 @@ WHERE user_id = ?
 `, userID, string(source)).Scan(&savedCount); err != nil {
 			return err
 		}
-		if savedCount >= s.savedLimit {
+		if savedCount >= s.limits.savedFor(source) {
 			return ErrSavedLimitReach
 		}
 		_, err := tx.ExecContext(ctx, `
@@ -523,7 +681,7 @@ WHERE user_id = ?
 		return err
 	}
 
-	if s.recentLimit > 0 {
+	if limit := s.limits.recentFor(source); limit > 0 {
 		_, err = tx.ExecContext(ctx, `
 DELETE FROM audit_user_hand_history
 WHERE user_id = ?
@@ -538,7 +696,7 @@ WHERE user_id = ?
       ORDER BY played_at_ms DESC, id DESC
       LIMIT -1 OFFSET ?
   )
-`, userID, string(source), userID, string(source), s.recentLimit)
+`, userID, string(source), userID, string(source), limit)
 		if err != nil {
 			return err
 		}
@@ -573,6 +731,7 @@ CREATE TABLE IF NOT EXISTS audit_user_hand_history (
     played_at_ms INTEGER NOT NULL,
     summary_json TEXT NOT NULL DEFAULT '{}',
     tape_blob BLOB,
+    annotations_json TEXT NOT NULL DEFAULT '[]',
     is_saved INTEGER NOT NULL DEFAULT 0,
     saved_at_ms INTEGER,
     created_at_ms INTEGER NOT NULL,
@@ -589,6 +748,19 @@ CREATE TABLE IF NOT EXISTS audit_user_hand_history (
 			return err
 		}
 	}
+
+	// Databases created before annotations lack the column.
+	var hasAnnotations int
+	if err := db.QueryRowContext(ctx, `
+SELECT COUNT(*) FROM pragma_table_info('audit_user_hand_history') WHERE name = 'annotations_json'
+`).Scan(&hasAnnotations); err != nil {
+		return err
+	}
+	if hasAnnotations == 0 {
+		if _, err := db.ExecContext(ctx, `ALTER TABLE audit_user_hand_history ADD COLUMN annotations_json TEXT NOT NULL DEFAULT '[]'`); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,110 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSQLiteMaintenanceInterval = 10 * time.Minute
+	defaultSQLiteVacuumFreeRatio     = 0.25
+	// sqliteVacuumTimeout bounds a VACUUM, which holds the only connection
+	// and so queues every write behind it.
+	sqliteVacuumTimeout = 30 * time.Second
+)
+
+// sqliteMaintenanceIntervalFromEnv reads LEDGER_SQLITE_MAINTENANCE_INTERVAL, a
+// Go duration; "0" turns maintenance off.
+func sqliteMaintenanceIntervalFromEnv() time.Duration {
+	raw := strings.TrimSpace(os.Getenv("LEDGER_SQLITE_MAINTENANCE_INTERVAL"))
+	if raw == "" {
+		return defaultSQLiteMaintenanceInterval
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return defaultSQLiteMaintenanceInterval
+	}
+	return d
+}
+
+// sqliteVacuumFreeRatioFromEnv reads LEDGER_SQLITE_VACUUM_FREE_RATIO, the
+// share of free pages that makes a maintenance pass VACUUM.
+func sqliteVacuumFreeRatioFromEnv() float64 {
+	raw := strings.TrimSpace(os.Getenv("LEDGER_SQLITE_VACUUM_FREE_RATIO"))
+	if raw == "" {
+		return defaultSQLiteVacuumFreeRatio
+	}
+	ratio, err := strconv.ParseFloat(raw, 64)
+	if err != nil || ratio <= 0 || ratio > 1 {
+		return defaultSQLiteVacuumFreeRatio
+	}
+	return ratio
+}
+
+func (s *SQLiteService) maintenanceLoop(interval time.Duration, vacuumFreeRatio float64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Only compact while nothing holds the connection, so a pass
+			// never lands in the middle of a burst of hand writes.
+			if s.db.Stats().InUse > 0 {
+				continue
+			}
+			if err := s.Compact(context.Background(), vacuumFreeRatio); err != nil {
+				log.Printf("[Ledger] sqlite maintenance failed: %v", err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Compact folds the write-ahead log back into the database file and
+// truncates it. When at least vacuumFreeRatio of the file's pages are free,
+// e.g. after old hands were trimmed, it also VACUUMs to give the space
+// back, bounded by sqliteVacuumTimeout.
+func (s *SQLiteService) Compact(ctx context.Context, vacuumFreeRatio float64) error {
+	if err := s.checkpoint(ctx); err != nil {
+		return err
+	}
+
+	var pages, free int64
+	if err := s.db.QueryRowContext(ctx, `PRAGMA page_count;`).Scan(&pages); err != nil {
+		return fmt.Errorf("page count: %w", err)
+	}
+	if err := s.db.QueryRowContext(ctx, `PRAGMA freelist_count;`).Scan(&free); err != nil {
+		return fmt.Errorf("freelist count: %w", err)
+	}
+	if pages == 0 || float64(free)/float64(pages) < vacuumFreeRatio {
+		return nil
+	}
+
+	vacuumCtx, cancel := context.WithTimeout(ctx, sqliteVacuumTimeout)
+	defer cancel()
+	start := time.Now()
+	if _, err := s.db.ExecContext(vacuumCtx, `VACUUM;`); err != nil {
+		return fmt.Errorf("vacuum: %w", err)
+	}
+	log.Printf("[Ledger] sqlite vacuum freed %d of %d pages in %s", free, pages, time.Since(start).Round(time.Millisecond))
+	// VACUUM rewrites the database through the log.
+	return s.checkpoint(ctx)
+}
+
+func (s *SQLiteService) checkpoint(ctx context.Context) error {
+	var busy, logFrames, checkpointed int
+	if err := s.db.QueryRowContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE);`).Scan(&busy, &logFrames, &checkpointed); err != nil {
+		return fmt.Errorf("wal checkpoint: %w", err)
+	}
+	if busy != 0 {
+		return fmt.Errorf("wal checkpoint: database busy")
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package ledger
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSQLiteListRecentMulti_MergesSourcesByTime(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	ctx := context.Background()
+	const userID = 7
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.UpsertLiveHistory(userID, "live-1", base.Add(1*time.Minute), nil)
+	svc.UpsertLiveHistory(userID, "live-2", base.Add(4*time.Minute), nil)
+	svc.UpsertLiveHistory(userID, "live-3", base.Add(5*time.Minute), nil)
+	replayAt := map[string]time.Time{
+		"replay-1": base.Add(2 * time.Minute),
+		"replay-2": base.Add(3 * time.Minute),
+		"replay-3": base.Add(6 * time.Minute),
+	}
+	for handID, at := range replayAt {
+		events := []EventItem{{Seq: 1, EventType: "handStart", EnvelopeB64: "AA=="}}
+		if err := svc.UpsertReplayHand(ctx, userID, handID, events, nil); err != nil {
+			t.Fatalf("UpsertReplayHand(%s) failed: %v", handID, err)
+		}
+		// Replay uploads are stamped with the upload time; pin them instead.
+		if _, err := svc.db.ExecContext(ctx, `UPDATE audit_user_hand_history SET played_at_ms = ? WHERE hand_id = ?`, at.UnixMilli(), handID); err != nil {
+			t.Fatalf("pin played_at for %s failed: %v", handID, err)
+		}
+	}
+
+	items, err := svc.ListRecentMulti(ctx, userID, []Source{SourceLive, SourceReplay}, 5)
+	if err != nil {
+		t.Fatalf("ListRecentMulti failed: %v", err)
+	}
+	want := []struct {
+		handID string
+		source Source
+	}{
+		{"replay-3", SourceReplay},
+		{"live-3", SourceLive},
+		{"live-2", SourceLive},
+		{"replay-2", SourceReplay},
+		{"replay-1", SourceReplay},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d items, got %d: %+v", len(want), len(items), items)
+	}
+	for i, w := range want {
+		if items[i].HandID != w.handID || items[i].Source != w.source {
+			t.Fatalf("item %d: expected %s/%s, got %s/%s", i, w.source, w.handID, items[i].Source, items[i].HandID)
+		}
+		if i > 0 && items[i].PlayedAt.After(items[i-1].PlayedAt) {
+			t.Fatalf("items out of order at %d: %v after %v", i, items[i].PlayedAt, items[i-1].PlayedAt)
+		}
+	}
+
+	single, err := svc.ListRecentMulti(ctx, userID, []Source{SourceReplay, SourceReplay}, 10)
+	if err != nil {
+		t.Fatalf("ListRecentMulti(replay) failed: %v", err)
+	}
+	if len(single) != 3 || single[0].HandID != "replay-3" {
+		t.Fatalf("expected the three replay hands newest first, got %+v", single)
+	}
+
+	if _, err := svc.ListRecentMulti(ctx, userID, []Source{SourceSandbox}, 10); err == nil {
+		t.Fatalf("expected an error for a non-audit source")
+	}
+}
+
+func TestSQLiteHandAnnotations_PersistKeyedBySeq(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "ledger.db")
+	svc, err := NewSQLiteService(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	ctx := context.Background()
+	const userID = 7
+
+	events := []EventItem{
+		{Seq: 1, EventType: "handStart", EnvelopeB64: "AA=="},
+		{Seq: 2, EventType: "actionPrompt", EnvelopeB64: "AA=="},
+		{Seq: 3, EventType: "actionResult", EnvelopeB64: "AA=="},
+	}
+	if err := svc.UpsertReplayHand(ctx, userID, "replay-1", events, nil); err != nil {
+		t.Fatalf("UpsertReplayHand failed: %v", err)
+	}
+	svc.UpsertLiveHistoryWithEvents(userID, "live-1", time.Now(), nil, events)
+
+	annotations := []Annotation{
+		{Seq: 3, Text: "I should have folded here"},
+		{Seq: 1, Text: "  tight open  "},
+		{Seq: 2, Text: "   "},
+	}
+	for _, target := range []struct {
+		source Source
+		handID string
+	}{{SourceReplay, "replay-1"}, {SourceLive, "live-1"}} {
+		if err := svc.SetHandAnnotations(ctx, userID, target.source, target.handID, annotations); err != nil {
+			t.Fatalf("SetHandAnnotations(%s) failed: %v", target.handID, err)
+		}
+	}
+	if err := svc.SetHandAnnotations(ctx, userID, SourceReplay, "replay-1", []Annotation{{Seq: 9, Text: "nope"}}); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Fatalf("expected an unknown seq to be rejected, got %v", err)
+	}
+	if err := svc.SetHandAnnotations(ctx, userID+1, SourceReplay, "replay-1", annotations); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected another user's hand to be not found, got %v", err)
+	}
+	svc.Close()
+
+	reopened, err := NewSQLiteService(dbPath)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer reopened.Close()
+	for _, target := range []struct {
+		source Source
+		handID string
+	}{{SourceReplay, "replay-1"}, {SourceLive, "live-1"}} {
+		got, err := reopened.GetHandEvents(ctx, userID, target.source, target.handID)
+		if err != nil {
+			t.Fatalf("GetHandEvents(%s) failed: %v", target.handID, err)
+		}
+		want := map[uint64][]string{
+			1: {"tight open"},
+			3: {"I should have folded here"},
+		}
+		if len(got) != 3 {
+			t.Fatalf("%s: expected 3 events, got %d", target.handID, len(got))
+		}
+		for _, e := range got {
+			if !reflect.DeepEqual(e.Annotations, want[e.Seq]) {
+				t.Fatalf("%s seq %d: expected annotations %v, got %v", target.handID, e.Seq, want[e.Seq], e.Annotations)
+			}
+		}
+	}
+}
+
+func encodeTestEvents(t *testing.T, envs ...*pb.ServerEnvelope) []EventItem {
+	t.Helper()
+	events := make([]EventItem, 0, len(envs))
+	for i, env := range envs {
+		raw, err := proto.Marshal(env)
+		if err != nil {
+			t.Fatalf("marshal event %d: %v", i, err)
+		}
+		events = append(events, EventItem{Seq: uint64(i + 1), EventType: fmt.Sprintf("%T", env.GetPayload()), EnvelopeB64: base64.StdEncoding.EncodeToString(raw)})
+	}
+	return events
+}
+
+func TestSQLiteRebuildSummary_FillsStaleSummaryFromEvents(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	ctx := context.Background()
+	const userID = 7
+
+	events := encodeTestEvents(t,
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_TableSnapshot{TableSnapshot: &pb.TableSnapshot{
+			Players: []*pb.PlayerState{{UserId: 9, Chair: 0}, {UserId: userID, Chair: 2}},
+		}}},
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandStart{HandStart: &pb.HandStart{Round: 3}}},
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_PhaseChange{PhaseChange: &pb.PhaseChange{Phase: pb.Phase_PHASE_FLOP}}},
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_WinByFold{WinByFold: &pb.WinByFold{WinnerChair: 2, PotTotal: 300}}},
+		&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandEnd{HandEnd: &pb.HandEnd{
+			Round:       3,
+			StackDeltas: []*pb.StackDelta{{Chair: 0, Delta: -150, NewStack: 850}, {Chair: 2, Delta: 150, NewStack: 1150}},
+			NetResults:  []*pb.NetResult{{Chair: 2, WinAmount: 300, IsWinner: true}},
+		}}},
+	)
+	// An early row: only the table was recorded in its summary.
+	svc.UpsertLiveHistoryWithEvents(userID, "live-1", time.Now(), map[string]any{"table_id": "t1"}, events)
+	svc.UpsertLiveHistoryWithEvents(userID, "live-cut", time.Now(), nil, events[:3])
+
+	if err := svc.RebuildSummary(ctx, userID, SourceLive, "live-1"); err != nil {
+		t.Fatalf("RebuildSummary failed: %v", err)
+	}
+	items, err := svc.ListRecent(ctx, userID, SourceLive, 10, HistoryFilter{})
+	if err != nil {
+		t.Fatalf("ListRecent failed: %v", err)
+	}
+	var got map[string]any
+	for _, item := range items {
+		if item.HandID == "live-1" {
+			got = item.Summary
+		}
+	}
+	want := map[string]any{
+		"table_id":    "t1",
+		"round":       float64(3),
+		"chair":       float64(2),
+		"ended_phase": "flop",
+		"pot_total":   float64(300),
+		"event_count": float64(5),
+		"is_winner":   true,
+		"win_amount":  float64(300),
+		"delta":       float64(150),
+		"stack_start": float64(1000),
+		"stack_end":   float64(1150),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rebuilt summary mismatch:\nwant %v\n got %v", want, got)
+	}
+
+	if err := svc.RebuildSummary(ctx, userID, SourceLive, "live-cut"); !errors.Is(err, ErrIncompleteHand) {
+		t.Fatalf("expected a hand without HandEnd to be incomplete, got %v", err)
+	}
+	if err := svc.RebuildSummary(ctx, userID+1, SourceLive, "live-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected another user's hand to be not found, got %v", err)
+	}
+}
+
+func TestSQLiteHistoryLimits_PerSourceRecentLimits(t *testing.T) {
+	t.Setenv("AUDIT_RECENT_LIMIT_X", "10")
+	t.Setenv("AUDIT_RECENT_LIMIT_LIVE", "3")
+	t.Setenv("AUDIT_RECENT_LIMIT_REPLAY", "2")
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	ctx := context.Background()
+	const userID = 7
+
+	events := encodeTestEvents(t, &pb.ServerEnvelope{Payload: &pb.ServerEnvelope_HandStart{HandStart: &pb.HandStart{Round: 1}}})
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		svc.UpsertLiveHistoryWithEvents(userID, fmt.Sprintf("live-%d", i), start.Add(time.Duration(i)*time.Second), nil, events)
+		if err := svc.UpsertReplayHand(ctx, userID, fmt.Sprintf("replay-%d", i), events, nil); err != nil {
+			t.Fatalf("UpsertReplayHand failed: %v", err)
+		}
+	}
+
+	for source, want := range map[Source]int{SourceLive: 3, SourceReplay: 2} {
+		items, err := svc.ListRecent(ctx, userID, source, 50, HistoryFilter{})
+		if err != nil {
+			t.Fatalf("ListRecent %s failed: %v", source, err)
+		}
+		if len(items) != want {
+			t.Fatalf("%s: expected %d recent hands kept, got %d", source, want, len(items))
+		}
+	}
+	if got := svc.limits.savedFor(SourceReplay); got != defaultSavedLimit {
+		t.Fatalf("expected the saved limit to fall back to the default, got %d", got)
+	}
+}
+
+func TestSQLiteCompact_ShrinksFileAfterPruning(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "ledger.db")
+	svc, err := NewSQLiteService(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	ctx := context.Background()
+
+	blob := base64.StdEncoding.EncodeToString(make([]byte, 4096))
+	for hand := 0; hand < 200; hand++ {
+		events := make([]EventItem, 0, 10)
+		for seq := 1; seq <= 10; seq++ {
+			events = append(events, EventItem{Seq: uint64(seq), EventType: "handStart", EnvelopeB64: blob})
+		}
+		if err := svc.UpsertReplayHand(ctx, 7, fmt.Sprintf("replay-%d", hand), events, nil); err != nil {
+			t.Fatalf("UpsertReplayHand failed: %v", err)
+		}
+	}
+	if _, err := svc.db.ExecContext(ctx, `DELETE FROM ledger_event_stream;`); err != nil {
+		t.Fatalf("prune events failed: %v", err)
+	}
+
+	onDisk := func() int64 {
+		var total int64
+		for _, path := range []string{dbPath, dbPath + "-wal"} {
+			if info, err := os.Stat(path); err == nil {
+				total += info.Size()
+			}
+		}
+		return total
+	}
+	before := onDisk()
+	if err := svc.Compact(ctx, defaultSQLiteVacuumFreeRatio); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if after := onDisk(); after*4 > before {
+		t.Fatalf("expected compaction to give back most of %d bytes, still %d", before, after)
+	}
+	if err := svc.Compact(ctx, defaultSQLiteVacuumFreeRatio); err != nil {
+		t.Fatalf("Compact on a compact file failed: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+)
+
+// PlayerStats aggregates a user's stored hands of one source. It reads the
+// summary's delta, is_winner, win_amount and ended_phase fields; a hand whose
+// summary lacks a field counts toward HandsPlayed but not toward that total.
+type PlayerStats struct {
+	Source        Source  `json:"source"`
+	HandsPlayed   int64   `json:"hands_played"`
+	NetChips      int64   `json:"net_chips"`
+	HandsWon      int64   `json:"hands_won"`
+	WinRate       float64 `json:"win_rate"`
+	BiggestPotWon int64   `json:"biggest_pot_won"`
+	Showdowns     int64   `json:"showdowns"`
+	ShowdownRate  float64 `json:"showdown_rate"`
+}
+
+// finish fills the rates from the counts.
+func (st *PlayerStats) finish() *PlayerStats {
+	if st.HandsPlayed > 0 {
+		st.WinRate = float64(st.HandsWon) / float64(st.HandsPlayed)
+		st.ShowdownRate = float64(st.Showdowns) / float64(st.HandsPlayed)
+	}
+	return st
+}
+
+func (s *SQLiteService) GetPlayerStats(ctx context.Context, userID uint64, source Source) (*PlayerStats, error) {
+	if !isAuditSource(source) {
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
+	st := &PlayerStats{Source: source}
+	if userID == 0 {
+		return st, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := s.db.QueryRowContext(ctx, `
+SELECT
+    COUNT(*),
+    COALESCE(SUM(CASE WHEN json_type(summary_json, '$.delta') IN ('integer', 'real')
+        THEN CAST(json_extract(summary_json, '$.delta') AS INTEGER) END), 0),
+    COALESCE(SUM(CASE WHEN json_type(summary_json, '$.is_winner') = 'true' THEN 1 ELSE 0 END), 0),
+    COALESCE(MAX(CASE WHEN json_type(summary_json, '$.is_winner') = 'true'
+        AND json_type(summary_json, '$.win_amount') IN ('integer', 'real')
+        THEN CAST(json_extract(summary_json, '$.win_amount') AS INTEGER) END), 0),
+    COALESCE(SUM(CASE WHEN json_extract(summary_json, '$.ended_phase') = 'showdown' THEN 1 ELSE 0 END), 0)
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+`, userID, string(source)).Scan(&st.HandsPlayed, &st.NetChips, &st.HandsWon, &st.BiggestPotWon, &st.Showdowns)
+	if err != nil {
+		return nil, err
+	}
+	return st.finish(), nil
+}
+
+func (s *PostgresService) GetPlayerStats(ctx context.Context, userID uint64, source Source) (*PlayerStats, error) {
+	if !isAuditSource(source) {
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
+	st := &PlayerStats{Source: source}
+	if userID == 0 {
+		return st, nil
+	}
+
+	// CASE keeps the casts away from non-numeric fields.
+	err := s.db.QueryRowContext(ctx, `
+SELECT
+    COUNT(*),
+    COALESCE(SUM(CASE WHEN jsonb_typeof(summary_json->'delta') = 'number'
+        THEN (summary_json->>'delta')::numeric END), 0)::bigint,
+    COUNT(*) FILTER (WHERE summary_json->'is_winner' = 'true'::jsonb),
+    COALESCE(MAX(CASE WHEN summary_json->'is_winner' = 'true'::jsonb
+        AND jsonb_typeof(summary_json->'win_amount') = 'number'
+        THEN (summary_json->>'win_amount')::numeric END), 0)::bigint,
+    COUNT(*) FILTER (WHERE summary_json->>'ended_phase' = 'showdown')
+FROM audit_user_hand_history
+WHERE user_id = $1
+  AND source = $2
+`, userID, string(source)).Scan(&st.HandsPlayed, &st.NetChips, &st.HandsWon, &st.BiggestPotWon, &st.Showdowns)
+	if err != nil {
+		return nil, err
+	}
+	return st.finish(), nil
+}
+
+func (s *MySQLService) GetPlayerStats(ctx context.Context, userID uint64, source Source) (*PlayerStats, error) {
+	if !isAuditSource(source) {
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
+	st := &PlayerStats{Source: source}
+	if userID == 0 {
+		return st, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := s.db.QueryRowContext(ctx, `
+SELECT
+    COUNT(*),
+    CAST(COALESCE(SUM(CASE WHEN JSON_TYPE(JSON_EXTRACT(summary_json, '$.delta')) IN `+mysqlJSONNumberTypes+`
+        THEN CAST(JSON_EXTRACT(summary_json, '$.delta') AS SIGNED) END), 0) AS SIGNED),
+    COALESCE(SUM(CASE WHEN JSON_EXTRACT(summary_json, '$.is_winner') = CAST('true' AS JSON) THEN 1 ELSE 0 END), 0),
+    COALESCE(MAX(CASE WHEN JSON_EXTRACT(summary_json, '$.is_winner') = CAST('true' AS JSON)
+        AND JSON_TYPE(JSON_EXTRACT(summary_json, '$.win_amount')) IN `+mysqlJSONNumberTypes+`
+        THEN CAST(JSON_EXTRACT(summary_json, '$.win_amount') AS SIGNED) END), 0),
+    COALESCE(SUM(CASE WHEN JSON_UNQUOTE(JSON_EXTRACT(summary_json, '$.ended_phase')) = 'showdown' THEN 1 ELSE 0 END), 0)
+FROM audit_user_hand_history
+WHERE user_id = ?
+  AND source = ?
+`, userID, string(source)).Scan(&st.HandsPlayed, &st.NetChips, &st.HandsWon, &st.BiggestPotWon, &st.Showdowns)
+	if err != nil {
+		return nil, err
+	}
+	return st.finish(), nil
+}
@@ -0,0 +1,148 @@
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"holdem-lite/apps/server/internal/auth"
+)
+
+// testPlayerStats seeds live hands for userID with known summaries and checks
+// the aggregates against svc, so both backends are held to the same results.
+func testPlayerStats(t *testing.T, svc Service, userID uint64) {
+	t.Helper()
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	seed := []map[string]any{
+		{"delta": -100, "is_winner": false, "win_amount": 0, "ended_phase": "flop"},
+		{"delta": 250, "is_winner": true, "win_amount": 450, "ended_phase": "showdown"},
+		{"delta": -400, "is_winner": false, "win_amount": 0, "ended_phase": "showdown"},
+		{"delta": 75, "is_winner": true, "win_amount": 150, "ended_phase": "preflop"},
+		{"ended_phase": "river"},
+	}
+	for i, summary := range seed {
+		svc.UpsertLiveHistory(userID, fmt.Sprintf("h%d", i+1), base.Add(time.Duration(i)*time.Minute), summary)
+	}
+	events := []EventItem{{Seq: 1, EventType: "handStart", EnvelopeB64: "AA=="}}
+	if err := svc.UpsertReplayHand(ctx, userID, "r1", events, map[string]any{"delta": 9000, "is_winner": true}); err != nil {
+		t.Fatalf("UpsertReplayHand failed: %v", err)
+	}
+
+	got, err := svc.GetPlayerStats(ctx, userID, SourceLive)
+	if err != nil {
+		t.Fatalf("GetPlayerStats failed: %v", err)
+	}
+	want := PlayerStats{
+		Source:        SourceLive,
+		HandsPlayed:   5,
+		NetChips:      -175,
+		HandsWon:      2,
+		WinRate:       0.4,
+		BiggestPotWon: 450,
+		Showdowns:     2,
+		ShowdownRate:  0.4,
+	}
+	if *got != want {
+		t.Fatalf("expected %+v, got %+v", want, *got)
+	}
+
+	replayStats, err := svc.GetPlayerStats(ctx, userID, SourceReplay)
+	if err != nil {
+		t.Fatalf("GetPlayerStats(replay) failed: %v", err)
+	}
+	if replayStats.HandsPlayed != 1 || replayStats.NetChips != 9000 || replayStats.WinRate != 1 {
+		t.Fatalf("expected the replay hand alone in replay stats, got %+v", *replayStats)
+	}
+
+	empty, err := svc.GetPlayerStats(ctx, userID+1, SourceLive)
+	if err != nil {
+		t.Fatalf("GetPlayerStats(empty) failed: %v", err)
+	}
+	if *empty != (PlayerStats{Source: SourceLive}) {
+		t.Fatalf("expected zero stats for a user without hands, got %+v", *empty)
+	}
+	if _, err := svc.GetPlayerStats(ctx, userID, Source("bogus")); err == nil {
+		t.Fatal("expected an invalid source to fail")
+	}
+}
+
+func TestSQLiteGetPlayerStats(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+	testPlayerStats(t, svc, 7)
+}
+
+func TestHTTPHandler_ServesPlayerStats(t *testing.T) {
+	svc, err := NewSQLiteService(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService failed: %v", err)
+	}
+	defer svc.Close()
+
+	authService := auth.NewManager()
+	userID, token, err := authService.Register("alice_01", "secret12", auth.ClientInfo{})
+	if err != nil {
+		t.Fatalf("register err: %v", err)
+	}
+	svc.UpsertLiveHistory(userID, "h1", time.Now(), map[string]any{"delta": 120, "is_winner": true, "win_amount": 220})
+	svc.UpsertLiveHistory(userID, "h2", time.Now(), map[string]any{"delta": -20, "is_winner": false})
+
+	mux := http.NewServeMux()
+	NewHTTPHandler(authService, svc).RegisterRoutes(mux)
+	req := httptest.NewRequest(http.MethodGet, "/api/audit/live/stats", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET stats status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var stats PlayerStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if stats.HandsPlayed != 2 || stats.NetChips != 100 || stats.BiggestPotWon != 220 || stats.WinRate != 0.5 {
+		t.Fatalf("unexpected stats %s", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/audit/live/stats", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a missing token to 401, status=%d", rec.Code)
+	}
+}
+
+// Set LEDGER_TEST_DATABASE_DSN to a database with the db/ schema applied to
+// run the same cases against Postgres.
+func TestPostgresGetPlayerStats(t *testing.T) {
+	dsn := os.Getenv("LEDGER_TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("LEDGER_TEST_DATABASE_DSN not set")
+	}
+	t.Setenv("LEDGER_DATABASE_DSN", dsn)
+	svc, _, err := NewServiceFromEnv("postgres")
+	if err != nil {
+		t.Fatalf("NewServiceFromEnv failed: %v", err)
+	}
+	defer svc.Close()
+	db := svc.(*PostgresService).db
+
+	ctx := context.Background()
+	var userID uint64
+	username := fmt.Sprintf("ledger-stats-%d", time.Now().UnixNano()%1e9)
+	if err := db.QueryRowContext(ctx, `INSERT INTO accounts (username) VALUES ($1) RETURNING id`, username).Scan(&userID); err != nil {
+		t.Fatalf("create account failed: %v", err)
+	}
+	defer db.ExecContext(ctx, `DELETE FROM accounts WHERE id = $1`, userID)
+	testPlayerStats(t, svc, userID)
+}
@@ -0,0 +1,106 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+// ErrIncompleteHand marks a stored hand whose events cannot rebuild a
+// summary, e.g. because the stream stops before HandEnd.
+var ErrIncompleteHand = errors.New("incomplete hand events")
+
+// rebuildSummary recomputes the summary fields derivable from a hand's event
+// stream and lays them over prev, which keeps keys the events do not carry
+// (table_id, cash-out details). ended_phase is the last street dealt, or
+// "showdown" when hands were shown. The player's chair comes from prev when
+// it is recorded there, otherwise from the bootstrap snapshot.
+func rebuildSummary(userID uint64, events []EventItem, prev map[string]any) (map[string]any, error) {
+	envs, bad := decodeEventEnvelopes(events)
+	if len(bad) > 0 {
+		return nil, fmt.Errorf("%w: event %d is undecodable", ErrIncompleteHand, bad[0].Index)
+	}
+
+	chair, haveChair := summaryChair(prev)
+	var (
+		round    uint32
+		phase    = pb.Phase_PHASE_PREFLOP
+		showdown bool
+		potTotal int64
+		handEnd  *pb.HandEnd
+	)
+	for _, env := range envs {
+		switch {
+		case env.GetTableSnapshot() != nil && !haveChair:
+			for _, ps := range env.GetTableSnapshot().GetPlayers() {
+				if ps.GetUserId() == userID {
+					chair, haveChair = ps.GetChair(), true
+				}
+			}
+		case env.GetHandStart() != nil:
+			round = env.GetHandStart().GetRound()
+		case env.GetPhaseChange() != nil:
+			phase = env.GetPhaseChange().GetPhase()
+		case env.GetShowdown() != nil:
+			showdown, potTotal = true, 0
+			for _, pot := range env.GetShowdown().GetPotResults() {
+				potTotal += pot.GetPotAmount()
+			}
+		case env.GetWinByFold() != nil:
+			potTotal = env.GetWinByFold().GetPotTotal()
+		case env.GetHandEnd() != nil:
+			handEnd = env.GetHandEnd()
+		}
+	}
+	if handEnd == nil {
+		return nil, fmt.Errorf("%w: no hand end", ErrIncompleteHand)
+	}
+	if !haveChair {
+		return nil, fmt.Errorf("%w: user %d has no seat in the hand", ErrIncompleteHand, userID)
+	}
+	if handEnd.GetRound() != 0 {
+		round = handEnd.GetRound()
+	}
+
+	summary := make(map[string]any, len(prev)+10)
+	for k, v := range prev {
+		summary[k] = v
+	}
+	endedPhase := strings.ToLower(strings.TrimPrefix(phase.String(), "PHASE_"))
+	if showdown {
+		endedPhase = "showdown"
+	}
+	summary["round"] = round
+	summary["chair"] = chair
+	summary["ended_phase"] = endedPhase
+	summary["pot_total"] = potTotal
+	summary["event_count"] = len(events)
+	summary["is_winner"] = false
+	summary["win_amount"] = int64(0)
+	for _, nr := range handEnd.GetNetResults() {
+		if nr.GetChair() == chair {
+			summary["is_winner"] = nr.GetIsWinner()
+			summary["win_amount"] = nr.GetWinAmount()
+		}
+	}
+	for _, sd := range handEnd.GetStackDeltas() {
+		if sd.GetChair() == chair {
+			summary["delta"] = sd.GetDelta()
+			summary["stack_end"] = sd.GetNewStack()
+			summary["stack_start"] = sd.GetNewStack() - sd.GetDelta()
+		}
+	}
+	return summary, nil
+}
+
+// summaryChair reads the chair a stored summary recorded. JSON numbers come
+// back as float64.
+func summaryChair(prev map[string]any) (uint32, bool) {
+	v, ok := prev["chair"].(float64)
+	if !ok || v < 0 {
+		return 0, false
+	}
+	return uint32(v), true
+}
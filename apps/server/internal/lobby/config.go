@@ -0,0 +1,153 @@
+package lobby
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+const maxConfigPlayers = 10
+
+// ErrUnknownStake is returned by QuickStart for a stake the table config file
+// does not define.
+var ErrUnknownStake = errors.New("unknown stake")
+
+// tableConfigFile is the on-disk form of the lobby table defaults: one
+// default config plus optional named stakes. Fields left out of a stake
+// inherit from the default.
+type tableConfigFile struct {
+	Default tableConfigEntry            `json:"default"`
+	Stakes  map[string]tableConfigEntry `json:"stakes,omitempty"`
+}
+
+type tableConfigEntry struct {
+	MaxPlayers *uint16 `json:"max_players,omitempty"`
+	SmallBlind *int64  `json:"small_blind,omitempty"`
+	BigBlind   *int64  `json:"big_blind,omitempty"`
+	Ante       *int64  `json:"ante,omitempty"`
+	MinBuyIn   *int64  `json:"min_buy_in,omitempty"`
+	MaxBuyIn   *int64  `json:"max_buy_in,omitempty"`
+
+	MaxHandsPerSession    *uint32 `json:"max_hands_per_session,omitempty"`
+	SpectatorDelaySeconds *uint32 `json:"spectator_delay_seconds,omitempty"`
+	MaxSpectators         *int    `json:"max_spectators,omitempty"`
+}
+
+// LoadTableConfigFile loads the default table config and per-stakes defaults
+// from a JSON file. Errors from a missing file wrap fs.ErrNotExist so callers
+// can fall back to the built-in defaults.
+func (l *Lobby) LoadTableConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read table config file: %w", err)
+	}
+	return l.LoadTableConfigJSON(data)
+}
+
+// LoadTableConfigJSON loads table defaults from raw JSON bytes. Nothing is
+// applied unless the whole file validates.
+func (l *Lobby) LoadTableConfigJSON(data []byte) error {
+	var file tableConfigFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return fmt.Errorf("parse table config JSON: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	def := file.Default.apply(l.defaultConfig)
+	if err := validateTableConfig(def); err != nil {
+		return fmt.Errorf("table config default: %w", err)
+	}
+	stakes := make(map[string]table.TableConfig, len(file.Stakes))
+	names := make([]string, 0, len(file.Stakes))
+	for name := range file.Stakes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := strings.TrimSpace(name)
+		if key == "" {
+			return fmt.Errorf("table config stakes: empty stake name")
+		}
+		cfg := file.Stakes[name].apply(def)
+		if err := validateTableConfig(cfg); err != nil {
+			return fmt.Errorf("table config stake %q: %w", key, err)
+		}
+		stakes[key] = cfg
+	}
+
+	l.defaultConfig = def
+	l.stakeConfigs = stakes
+	return nil
+}
+
+// StakeConfig returns the table config for a named stake from the loaded
+// config file.
+func (l *Lobby) StakeConfig(name string) (table.TableConfig, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	cfg, ok := l.stakeConfigs[strings.TrimSpace(name)]
+	return cfg, ok
+}
+
+// apply overlays the entry's set fields on base.
+func (e tableConfigEntry) apply(base table.TableConfig) table.TableConfig {
+	if e.MaxPlayers != nil {
+		base.MaxPlayers = *e.MaxPlayers
+	}
+	if e.SmallBlind != nil {
+		base.SmallBlind = *e.SmallBlind
+	}
+	if e.BigBlind != nil {
+		base.BigBlind = *e.BigBlind
+	}
+	if e.Ante != nil {
+		base.Ante = *e.Ante
+	}
+	if e.MinBuyIn != nil {
+		base.MinBuyIn = *e.MinBuyIn
+	}
+	if e.MaxBuyIn != nil {
+		base.MaxBuyIn = *e.MaxBuyIn
+	}
+	if e.MaxHandsPerSession != nil {
+		base.MaxHandsPerSession = *e.MaxHandsPerSession
+	}
+	if e.SpectatorDelaySeconds != nil {
+		base.SpectatorDelay = time.Duration(*e.SpectatorDelaySeconds) * time.Second
+	}
+	if e.MaxSpectators != nil {
+		base.MaxSpectators = *e.MaxSpectators
+	}
+	return base
+}
+
+func validateTableConfig(cfg table.TableConfig) error {
+	switch {
+	case cfg.MaxPlayers < 2 || cfg.MaxPlayers > maxConfigPlayers:
+		return fmt.Errorf("max_players must be between 2 and %d, got %d", maxConfigPlayers, cfg.MaxPlayers)
+	case cfg.BigBlind <= 0:
+		return fmt.Errorf("big_blind must be > 0, got %d", cfg.BigBlind)
+	case cfg.SmallBlind < 0 || cfg.SmallBlind > cfg.BigBlind:
+		return fmt.Errorf("small_blind must be between 0 and big_blind (%d), got %d", cfg.BigBlind, cfg.SmallBlind)
+	case cfg.Ante < 0:
+		return fmt.Errorf("ante must be >= 0, got %d", cfg.Ante)
+	case cfg.MinBuyIn < cfg.BigBlind:
+		return fmt.Errorf("min_buy_in must be at least big_blind (%d), got %d", cfg.BigBlind, cfg.MinBuyIn)
+	case cfg.MaxBuyIn < cfg.MinBuyIn:
+		return fmt.Errorf("max_buy_in must be >= min_buy_in (%d), got %d", cfg.MinBuyIn, cfg.MaxBuyIn)
+	case cfg.MaxSpectators < 0:
+		return fmt.Errorf("max_spectators must be >= 0, got %d", cfg.MaxSpectators)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package lobby
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+func TestLoadTableConfigFile_OverridesDefaults(t *testing.T) {
+	l := New(nil, nil)
+	t.Cleanup(l.Stop)
+
+	path := filepath.Join(t.TempDir(), "table_config.json")
+	data := `{
+		"default": {"small_blind": 100, "big_blind": 200, "min_buy_in": 10000, "max_buy_in": 40000},
+		"stakes": {"micro": {"small_blind": 5, "big_blind": 10, "min_buy_in": 500, "max_buy_in": 2000}}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := l.LoadTableConfigFile(path); err != nil {
+		t.Fatalf("LoadTableConfigFile err: %v", err)
+	}
+
+	def := l.defaultConfig
+	if def.SmallBlind != 100 || def.BigBlind != 200 || def.MinBuyIn != 10000 || def.MaxBuyIn != 40000 {
+		t.Fatalf("expected file blinds/buy-ins, got %+v", def)
+	}
+	if def.MaxPlayers != 6 || def.LonePlayerPolicy != table.LonePlayerRefill {
+		t.Fatalf("expected fields absent from the file to keep their defaults, got %+v", def)
+	}
+
+	micro, ok := l.StakeConfig("micro")
+	if !ok {
+		t.Fatalf("expected the micro stake to be loaded")
+	}
+	if micro.BigBlind != 10 || micro.MaxBuyIn != 2000 || micro.MaxPlayers != 6 {
+		t.Fatalf("unexpected micro stake config %+v", micro)
+	}
+}
+
+func TestLoadTableConfigFile_RejectsInvalidFile(t *testing.T) {
+	l := New(nil, nil)
+	t.Cleanup(l.Stop)
+	before := l.defaultConfig
+
+	cases := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"malformed", `{"default": {`, "parse table config JSON"},
+		{"unknown field", `{"default": {"big_blnd": 200}}`, "unknown field"},
+		{"small blind above big", `{"default": {"small_blind": 300, "big_blind": 200}}`, "small_blind must be between 0 and big_blind"},
+		{"bad stake", `{"default": {}, "stakes": {"high": {"max_buy_in": 10}}}`, `stake "high": max_buy_in must be >= min_buy_in`},
+	}
+	for _, tc := range cases {
+		err := l.LoadTableConfigJSON([]byte(tc.data))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+	if !reflect.DeepEqual(l.defaultConfig, before) {
+		t.Fatalf("expected rejected files to leave the defaults untouched")
+	}
+
+	err := l.LoadTableConfigFile(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected a missing file to report fs.ErrNotExist, got %v", err)
+	}
+}
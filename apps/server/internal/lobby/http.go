@@ -0,0 +1,76 @@
+package lobby
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type HTTPHandler struct {
+	lobby *Lobby
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type tableInfoResponse struct {
+	TableID        string `json:"table_id"`
+	Kind           string `json:"kind"`
+	Stake          string `json:"stake"`
+	SmallBlind     int64  `json:"small_blind"`
+	BigBlind       int64  `json:"big_blind"`
+	Ante           int64  `json:"ante"`
+	Seated         int    `json:"seated"`
+	MaxPlayers     int    `json:"max_players"`
+	HandInProgress bool   `json:"hand_in_progress"`
+	AveragePot     int64  `json:"average_pot"`
+}
+
+type tablesResponse struct {
+	Tables []tableInfoResponse `json:"tables"`
+}
+
+func NewHTTPHandler(l *Lobby) *HTTPHandler {
+	return &HTTPHandler{lobby: l}
+}
+
+func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/lobby/tables", h.handleTables)
+}
+
+// handleTables lists the open Quick Join tables. Private tables stay off the
+// listing; they are reached only by join code.
+func (h *HTTPHandler) handleTables(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	infos := h.lobby.ListTableInfo(false)
+	resp := tablesResponse{Tables: make([]tableInfoResponse, 0, len(infos))}
+	for _, info := range infos {
+		resp.Tables = append(resp.Tables, tableInfoResponse{
+			TableID:        info.ID,
+			Kind:           info.Kind,
+			Stake:          info.Stake,
+			SmallBlind:     info.SmallBlind,
+			BigBlind:       info.BigBlind,
+			Ante:           info.Ante,
+			Seated:         info.Seated,
+			MaxPlayers:     info.MaxPlayers,
+			HandInProgress: info.HandInProgress,
+			AveragePot:     info.AveragePot,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
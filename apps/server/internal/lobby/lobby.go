@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +21,8 @@ const (
 
 	// NPC auto-fill: how many NPC seats to add for Quick Join
 	npcFillSeats = 4
+	// How long a Quick Join player may sit alone before NPCs are refilled.
+	lonePlayerGrace = 10 * time.Second
 )
 
 // Lobby manages all tables and player assignments
@@ -27,8 +31,10 @@ type Lobby struct {
 	tables map[string]*table.Table
 	nextID uint64
 
-	// Default table config
+	// Default table config, optionally replaced from a config file along
+	// with named per-stakes configs.
 	defaultConfig table.TableConfig
+	stakeConfigs  map[string]table.TableConfig
 
 	idleTableTTL    time.Duration
 	cleanupInterval time.Duration
@@ -38,9 +44,36 @@ type Lobby struct {
 	storyService    story.Service
 	npcManager      *npc.Manager
 	chapterRegistry *npc.ChapterRegistry
+	tagger          table.OpponentTagger
 	storySessions   map[string]*storySession
 	pausedStories   map[uint64]*pausedStoryRef
-	rng             *rand.Rand
+	// activeStories maps a user to their one story table; starting another
+	// chapter replaces it.
+	activeStories map[uint64]string
+	rng           *rand.Rand
+
+	// Swap one Quick Join NPC for a fresh persona every this many hands (0 = never).
+	npcRotateHands int
+	// Humans plus NPCs a Quick Join table is kept at (0 = npcFillSeats+1).
+	targetPlayers int
+
+	// Optional skill/bankroll matchmaking; tableBuckets records the bucket
+	// each Quick Join table was created for.
+	profiles     ProfileSource
+	matchBucket  MatchBucket
+	tableBuckets map[string]string
+	// tableStakes records the named stake each Quick Join table was created
+	// at; tables at the default config have no entry.
+	tableStakes map[string]string
+
+	// Private tables by table ID, and the join codes that lead to them.
+	privateTables map[string]*privateTable
+	joinCodes     map[string]string
+
+	// Rematch offers by user, kept for rematchWindow after a heads-up
+	// session or story chapter ends.
+	rematches     map[uint64]*rematchOffer
+	rematchWindow time.Duration
 }
 
 type pausedStoryRef struct {
@@ -60,6 +93,9 @@ func New(ledgerService ledger.Service, storyService story.Service, npcMgr ...*np
 			Ante:       0,
 			MinBuyIn:   5000,
 			MaxBuyIn:   20000,
+
+			LonePlayerGrace:  lonePlayerGrace,
+			LonePlayerPolicy: table.LonePlayerRefill,
 		},
 		idleTableTTL:    defaultIdleTableTTL,
 		cleanupInterval: defaultCleanupInterval,
@@ -68,6 +104,13 @@ func New(ledgerService ledger.Service, storyService story.Service, npcMgr ...*np
 		storyService:    storyService,
 		storySessions:   make(map[string]*storySession),
 		pausedStories:   make(map[uint64]*pausedStoryRef),
+		activeStories:   make(map[uint64]string),
+		tableBuckets:    make(map[string]string),
+		tableStakes:     make(map[string]string),
+		privateTables:   make(map[string]*privateTable),
+		joinCodes:       make(map[string]string),
+		rematches:       make(map[uint64]*rematchOffer),
+		rematchWindow:   defaultRematchWindow,
 		rng:             rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	if len(npcMgr) > 0 && npcMgr[0] != nil {
@@ -82,11 +125,42 @@ func (l *Lobby) SetChapterRegistry(cr *npc.ChapterRegistry) {
 	l.chapterRegistry = cr
 }
 
-// QuickStart finds or creates a table for the player
-func (l *Lobby) QuickStart(userID uint64, broadcastFn func(userID uint64, data []byte)) (*table.Table, error) {
+// SetOpponentTagger sets the source of per-viewer color tags for new tables.
+func (l *Lobby) SetOpponentTagger(tagger table.OpponentTagger) {
+	l.tagger = tagger
+}
+
+// RefreshOpponentTags has every table viewerID is at reload their color
+// tags.
+func (l *Lobby) RefreshOpponentTags(viewerID uint64) {
+	l.mu.RLock()
+	tables := make([]*table.Table, 0, len(l.tables))
+	for _, t := range l.tables {
+		tables = append(tables, t)
+	}
+	l.mu.RUnlock()
+	for _, t := range tables {
+		t.RefreshOpponentTags(viewerID)
+	}
+}
+
+// QuickStart finds or creates a table for the player at the named stake
+// from the table config file; "" is the default config. A player already
+// seated somewhere is sent back to that table whatever the stake.
+func (l *Lobby) QuickStart(userID uint64, stake string, broadcastFn func(userID uint64, data []byte)) (*table.Table, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	stake = strings.TrimSpace(stake)
+	cfg := l.defaultConfig
+	if stake != "" {
+		stakeCfg, ok := l.stakeConfigs[stake]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownStake, stake)
+		}
+		cfg = stakeCfg
+	}
+
 	pausedStoryTableID := ""
 	if ref := l.pausedStories[userID]; ref != nil {
 		pausedStoryTableID = ref.TableID
@@ -96,6 +170,9 @@ func (l *Lobby) QuickStart(userID uint64, broadcastFn func(userID uint64, data [
 	for tableID, t := range l.tables {
 		if t.IsClosed() {
 			delete(l.tables, tableID)
+			delete(l.tableBuckets, tableID)
+			delete(l.tableStakes, tableID)
+			l.dropPrivateTableLocked(tableID)
 			continue
 		}
 		if pausedStoryTableID != "" && tableID == pausedStoryTableID {
@@ -110,17 +187,24 @@ func (l *Lobby) QuickStart(userID uint64, broadcastFn func(userID uint64, data [
 		}
 	}
 
-	// Find a table with available seats
+	// Find a table with available seats at the stake, in the player's bucket
+	bucket := l.matchBucketLocked(userID)
 	for tableID, t := range l.tables {
 		if t.IsClosed() {
 			delete(l.tables, tableID)
+			delete(l.tableBuckets, tableID)
+			delete(l.tableStakes, tableID)
+			l.dropPrivateTableLocked(tableID)
 			continue
 		}
 		if pausedStoryTableID != "" && tableID == pausedStoryTableID {
 			continue
 		}
+		if l.tableBuckets[tableID] != bucket || l.tableStakes[tableID] != stake || l.isPrivateLocked(tableID) {
+			continue
+		}
 		snap := t.Snapshot()
-		if len(snap.Players) < int(l.defaultConfig.MaxPlayers) {
+		if len(snap.Players) < int(t.Config.MaxPlayers) {
 			log.Printf("[Lobby] QuickStart: user %d joining existing table %s", userID, t.ID)
 			return t, nil
 		}
@@ -129,21 +213,49 @@ func (l *Lobby) QuickStart(userID uint64, broadcastFn func(userID uint64, data [
 	// Create new table (with NPC manager if available)
 	l.nextID++
 	tableID := fmt.Sprintf("table_%d", l.nextID)
-	t := table.New(tableID, l.defaultConfig, broadcastFn, l.ledger, l.npcManager)
+	t := table.New(tableID, cfg, broadcastFn, l.ledger, l.npcManager)
 	if t == nil {
 		return nil, fmt.Errorf("failed to create table")
 	}
+	if l.tagger != nil {
+		t.SetOpponentTagger(l.tagger)
+	}
 	l.tables[tableID] = t
+	if bucket != "" {
+		l.tableBuckets[tableID] = bucket
+	}
+	if stake != "" {
+		l.tableStakes[tableID] = stake
+	}
+	l.watchSessionEndLocked(t)
 
-	// Auto-fill with NPCs so the table always has opponents
-	l.fillTableWithNPCs(t)
+	// Auto-fill with NPCs so the table always has opponents, leaving room
+	// for the arriving human
+	l.fillTableWithNPCs(t, l.targetPlayersLocked(t)-1)
+	rebalance := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.tables[tableID] == t {
+			l.rebalanceNPCsLocked(t)
+		}
+	}
+	t.AddHandEndHook(func(info table.HandEndInfo) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.tables[tableID] == t {
+			l.rebalanceNPCsLocked(t)
+			l.maybeRotateNPCLocked(t, info.Round)
+		}
+	})
+	t.SetLonePlayerHook(func(*table.Table) { rebalance() })
 
-	log.Printf("[Lobby] QuickStart: user %d created new table %s", userID, tableID)
+	log.Printf("[Lobby] QuickStart: user %d created new table %s (stake %q, bucket %q)", userID, tableID, stake, bucket)
 	return t, nil
 }
 
-// fillTableWithNPCs seats NPCs at empty chairs until the table has enough players.
-func (l *Lobby) fillTableWithNPCs(t *table.Table) {
+// fillTableWithNPCs seats NPCs at empty chairs until the table holds target NPCs.
+// Caller must hold l.mu (l.rng is shared).
+func (l *Lobby) fillTableWithNPCs(t *table.Table, target int) {
 	if l.npcManager == nil {
 		return
 	}
@@ -157,34 +269,126 @@ func (l *Lobby) fillTableWithNPCs(t *table.Table) {
 		return
 	}
 
-	// Shuffle personas for variety
+	_, npcChairs := t.SeatComposition()
+	if len(npcChairs) >= target {
+		return
+	}
+	occupied := make(map[uint16]bool)
+	seated := make(map[string]int)
+	nicknames := make(map[string]bool)
+	for _, p := range t.Snapshot().Players {
+		occupied[p.Chair] = true
+		nicknames[t.PlayerNickname(p.ID)] = true
+		if inst := l.npcManager.GetInstance(p.ID); inst != nil && inst.Persona != nil {
+			seated[inst.Persona.ID]++
+		}
+	}
+
+	// Shuffle personas for variety, those seated least often first, so a
+	// persona only repeats when the pack is smaller than the seats to fill.
 	shuffled := make([]*npc.NPCPersona, len(allPersonas))
 	copy(shuffled, allPersonas)
 	l.rng.Shuffle(len(shuffled), func(i, j int) {
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	})
+	sort.SliceStable(shuffled, func(i, j int) bool {
+		return seated[shuffled[i].ID] < seated[shuffled[j].ID]
+	})
 
-	buyIn := l.defaultConfig.MaxBuyIn
+	buyIn := t.Config.MaxBuyIn
 	filled := 0
 	personaIdx := 0
+	need := target - len(npcChairs)
 
-	// Fill chairs 1–5 (leave chair 0 for the human player)
-	for chair := uint16(1); chair < t.Config.MaxPlayers && filled < npcFillSeats; chair++ {
+	// Fill empty chairs from 1 up (leave chair 0 for the human player)
+	for chair := uint16(1); chair < t.Config.MaxPlayers && filled < need; chair++ {
+		if occupied[chair] {
+			continue
+		}
 		if personaIdx >= len(shuffled) {
 			personaIdx = 0 // wrap around if we have fewer personas than seats
 		}
 		persona := shuffled[personaIdx]
 		personaIdx++
 
-		if err := t.SeatNPC(persona, chair, buyIn); err != nil {
-			log.Printf("[Lobby] Failed to seat NPC %s at chair %d: %v", persona.Name, chair, err)
+		nickname := uniqueNickname(persona.Name, nicknames)
+		if err := t.SeatNPCAs(persona, chair, buyIn, nickname); err != nil {
+			log.Printf("[Lobby] Failed to seat NPC %s at chair %d: %v", nickname, chair, err)
 			continue
 		}
+		nicknames[nickname] = true
 		filled++
 	}
 	log.Printf("[Lobby] Filled table %s with %d NPCs", t.ID, filled)
 }
 
+// uniqueNickname returns name, or "name (2)", "name (3)", ... when the
+// plain name is already taken at the table.
+func uniqueNickname(name string, taken map[string]bool) string {
+	nickname := name
+	for n := 2; taken[nickname]; n++ {
+		nickname = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return nickname
+}
+
+// SetTargetPlayers sets how many players, humans and NPCs together, Quick
+// Join tables are kept at. NPCs make up the difference and give way as
+// humans arrive. 0 restores the default of npcFillSeats+1.
+func (l *Lobby) SetTargetPlayers(n int) {
+	if n < 0 {
+		n = 0
+	}
+	l.mu.Lock()
+	l.targetPlayers = n
+	l.mu.Unlock()
+}
+
+// targetPlayersLocked is the player count t is kept at, leaving at least one
+// chair open for the next arrival. Caller must hold l.mu.
+func (l *Lobby) targetPlayersLocked(t *table.Table) int {
+	target := l.targetPlayers
+	if target <= 0 {
+		target = npcFillSeats + 1
+	}
+	if limit := int(t.Config.MaxPlayers) - 1; target > limit {
+		target = limit
+	}
+	return target
+}
+
+// rebalanceNPCsLocked keeps a Quick Join table at its target player count
+// between hands: NPCs are topped back up when humans leave, and surplus NPCs
+// are despawned (highest chair first) when humans arrive, so one chair stays
+// open for the next arrival. Tables with no humans are left alone for idle
+// cleanup. Caller must hold l.mu.
+func (l *Lobby) rebalanceNPCsLocked(t *table.Table) {
+	if l.npcManager == nil || t.IsClosed() {
+		return
+	}
+	humans, npcChairs := t.SeatComposition()
+	if humans == 0 {
+		return
+	}
+	want := l.targetPlayersLocked(t) - humans
+	if want < 0 {
+		want = 0
+	}
+
+	if len(npcChairs) < want {
+		l.fillTableWithNPCs(t, want)
+		return
+	}
+	surplus := len(npcChairs) - want
+	for i := len(npcChairs) - 1; i >= 0 && surplus > 0; i-- {
+		if err := t.UnseatNPC(npcChairs[i]); err != nil {
+			log.Printf("[Lobby] Failed to unseat NPC at chair %d on table %s: %v", npcChairs[i], t.ID, err)
+			continue
+		}
+		surplus--
+	}
+}
+
 // GetTable returns a table by ID
 func (l *Lobby) GetTable(tableID string) *table.Table {
 	l.mu.RLock()
@@ -224,11 +428,15 @@ func (l *Lobby) CleanupIdleTables() int {
 	for tableID, t := range l.tables {
 		if t.IsClosed() || t.IsIdleFor(l.idleTableTTL) {
 			delete(l.tables, tableID)
-			delete(l.storySessions, tableID)
+			delete(l.tableBuckets, tableID)
+			delete(l.tableStakes, tableID)
+			l.dropPrivateTableLocked(tableID)
+			l.dropStorySessionLocked(tableID)
 			l.removePausedStoryByTableLocked(tableID)
 			idleTables = append(idleTables, t)
 		}
 	}
+	l.pruneRematchesLocked(time.Now())
 	l.mu.Unlock()
 
 	for _, t := range idleTables {
@@ -251,6 +459,10 @@ func (l *Lobby) Stop() {
 		l.tables = make(map[string]*table.Table)
 		l.storySessions = make(map[string]*storySession)
 		l.pausedStories = make(map[uint64]*pausedStoryRef)
+		l.activeStories = make(map[uint64]string)
+		l.privateTables = make(map[string]*privateTable)
+		l.joinCodes = make(map[string]string)
+		l.rematches = make(map[uint64]*rematchOffer)
 		l.mu.Unlock()
 
 		for _, t := range tables {
@@ -0,0 +1,354 @@
+package lobby
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"holdem-lite/apps/server/internal/table"
+	"holdem-lite/holdem/npc"
+)
+
+const testPersonasJSON = `[
+	{"id":"p1","name":"Ace","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+	{"id":"p2","name":"Blaze","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+	{"id":"p3","name":"Cobra","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+	{"id":"p4","name":"Dice","tier":3,"brain":{"aggression":0.5,"tightness":0.5}}
+]`
+
+func newNPCTestLobby(t *testing.T) *Lobby {
+	t.Helper()
+
+	registry := npc.NewRegistry()
+	if err := registry.LoadFromJSON([]byte(testPersonasJSON)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	l := New(nil, nil, npc.NewManager(registry))
+	t.Cleanup(l.Stop)
+	return l
+}
+
+// newPausedQuickStartTable creates a Quick Join table and pauses it so that
+// seating humans does not deal a hand.
+func newPausedQuickStartTable(t *testing.T, l *Lobby) *table.Table {
+	t.Helper()
+
+	tbl, err := l.QuickStart(1, "", func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("QuickStart err: %v", err)
+	}
+	if err := tbl.SubmitEvent(table.Event{Type: table.EventPause, UserID: 1}); err != nil {
+		t.Fatalf("pause err: %v", err)
+	}
+	return tbl
+}
+
+// seatHuman joins a human, who is auto-seated at the first empty chair.
+func seatHuman(t *testing.T, tbl *table.Table, userID uint64) {
+	t.Helper()
+
+	if err := tbl.SubmitEvent(table.Event{Type: table.EventJoinTable, UserID: userID, Nickname: "human"}); err != nil {
+		t.Fatalf("join user=%d err: %v", userID, err)
+	}
+}
+
+func rebalance(l *Lobby, tbl *table.Table) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rebalanceNPCsLocked(tbl)
+}
+
+func TestRebalanceNPCs_HumanTakesLastSeat_RemovesNPC(t *testing.T) {
+	l := newNPCTestLobby(t)
+	tbl := newPausedQuickStartTable(t, l)
+
+	seatHuman(t, tbl, 1)
+	seatHuman(t, tbl, 2)
+	if humans, npcChairs := tbl.SeatComposition(); humans != 2 || len(npcChairs) != npcFillSeats {
+		t.Fatalf("expected full table with 2 humans and %d NPCs, got humans=%d npcs=%v", npcFillSeats, humans, npcChairs)
+	}
+
+	rebalance(l, tbl)
+
+	humans, npcChairs := tbl.SeatComposition()
+	if humans != 2 || len(npcChairs) != npcFillSeats-1 {
+		t.Fatalf("expected 2 humans and %d NPCs, got humans=%d npcs=%v", npcFillSeats-1, humans, npcChairs)
+	}
+	for _, chair := range npcChairs {
+		if chair == 4 {
+			t.Fatalf("expected NPC at highest chair 4 to be removed, got npcs=%v", npcChairs)
+		}
+	}
+	if got := len(tbl.Snapshot().Players); got != int(tbl.Config.MaxPlayers)-1 {
+		t.Fatalf("expected one open chair, got %d players", got)
+	}
+}
+
+func TestRebalanceNPCs_HumanLeaves_RegainsNPC(t *testing.T) {
+	l := newNPCTestLobby(t)
+	tbl := newPausedQuickStartTable(t, l)
+
+	seatHuman(t, tbl, 1)
+	seatHuman(t, tbl, 2)
+	rebalance(l, tbl)
+
+	if err := tbl.SubmitEvent(table.Event{Type: table.EventStandUp, UserID: 2}); err != nil {
+		t.Fatalf("stand up err: %v", err)
+	}
+	if _, npcChairs := tbl.SeatComposition(); len(npcChairs) != npcFillSeats-1 {
+		t.Fatalf("expected %d NPCs before rebalance, got %v", npcFillSeats-1, npcChairs)
+	}
+
+	rebalance(l, tbl)
+
+	humans, npcChairs := tbl.SeatComposition()
+	if humans != 1 || len(npcChairs) != npcFillSeats {
+		t.Fatalf("expected 1 human and %d NPCs, got humans=%d npcs=%v", npcFillSeats, humans, npcChairs)
+	}
+}
+
+func TestRebalanceNPCs_FillsToTargetTotalPlayers(t *testing.T) {
+	l := newNPCTestLobby(t)
+	l.SetTargetPlayers(4)
+	tbl := newPausedQuickStartTable(t, l)
+
+	seatHuman(t, tbl, 1)
+	if humans, npcChairs := tbl.SeatComposition(); humans != 1 || len(npcChairs) != 3 {
+		t.Fatalf("expected 1 human and 3 NPCs, got humans=%d npcs=%v", humans, npcChairs)
+	}
+
+	seatHuman(t, tbl, 2)
+	rebalance(l, tbl)
+	if humans, npcChairs := tbl.SeatComposition(); humans != 2 || len(npcChairs) != 2 {
+		t.Fatalf("expected 2 humans and 2 NPCs, got humans=%d npcs=%v", humans, npcChairs)
+	}
+
+	if err := tbl.SubmitEvent(table.Event{Type: table.EventStandUp, UserID: 2}); err != nil {
+		t.Fatalf("stand up err: %v", err)
+	}
+	rebalance(l, tbl)
+	if humans, npcChairs := tbl.SeatComposition(); humans != 1 || len(npcChairs) != 3 {
+		t.Fatalf("expected 3 NPCs back once the second human leaves, got humans=%d npcs=%v", humans, npcChairs)
+	}
+}
+
+func TestRebalanceNPCs_NoHumans_LeavesTableAlone(t *testing.T) {
+	l := newNPCTestLobby(t)
+	tbl := newPausedQuickStartTable(t, l)
+
+	if err := tbl.UnseatNPC(1); err != nil {
+		t.Fatalf("UnseatNPC err: %v", err)
+	}
+	rebalance(l, tbl)
+
+	if _, npcChairs := tbl.SeatComposition(); len(npcChairs) != npcFillSeats-1 {
+		t.Fatalf("expected no refill without humans, got npcs=%v", npcChairs)
+	}
+}
+
+func TestRotateNPC_ReplacesPersonaAndKeepsStack(t *testing.T) {
+	registry := npc.NewRegistry()
+	if err := registry.LoadFromJSON([]byte(`[
+		{"id":"p1","name":"Ace","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+		{"id":"p2","name":"Blaze","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+		{"id":"p3","name":"Cobra","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+		{"id":"p4","name":"Dice","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+		{"id":"p5","name":"Echo","tier":3,"brain":{"aggression":0.5,"tightness":0.5}}
+	]`)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	mgr := npc.NewManager(registry)
+	l := New(nil, nil, mgr)
+	t.Cleanup(l.Stop)
+	l.SetNPCRotation(10)
+	tbl := newPausedQuickStartTable(t, l)
+	seatHuman(t, tbl, 1)
+
+	personaAt := func(chair uint16) (string, int64) {
+		for _, ps := range tbl.Snapshot().Players {
+			if ps.Chair == chair {
+				if inst := mgr.GetInstance(ps.ID); inst != nil {
+					return inst.Persona.ID, ps.Stack
+				}
+			}
+		}
+		return "", 0
+	}
+	_, before := tbl.SeatComposition()
+	seatedBefore := make(map[string]bool)
+	for _, chair := range before {
+		id, _ := personaAt(chair)
+		seatedBefore[id] = true
+	}
+
+	l.mu.Lock()
+	l.maybeRotateNPCLocked(tbl, 9)
+	l.mu.Unlock()
+	if _, npcChairs := tbl.SeatComposition(); len(npcChairs) != len(before) {
+		t.Fatalf("expected no rotation before the interval, got npcs=%v", npcChairs)
+	}
+
+	chair := before[1%len(before)]
+	oldPersona, oldStack := personaAt(chair)
+	l.mu.Lock()
+	l.maybeRotateNPCLocked(tbl, 10)
+	l.mu.Unlock()
+
+	humans, after := tbl.SeatComposition()
+	if humans != 1 || len(after) != len(before) {
+		t.Fatalf("expected rotation to keep 1 human and %d NPCs, got humans=%d npcs=%v", len(before), humans, after)
+	}
+	newPersona, newStack := personaAt(chair)
+	if newPersona == "" || newPersona == oldPersona || seatedBefore[newPersona] {
+		t.Fatalf("expected chair %d to get an unseated persona, had %s now %s", chair, oldPersona, newPersona)
+	}
+	if newStack != oldStack {
+		t.Fatalf("expected rotated seat to keep stack %d, got %d", oldStack, newStack)
+	}
+}
+
+type profileMap map[uint64]PlayerProfile
+
+func (m profileMap) PlayerProfile(userID uint64) (PlayerProfile, bool) {
+	p, ok := m[userID]
+	return p, ok
+}
+
+func TestQuickStart_MatchmakingSeparatesBuckets(t *testing.T) {
+	l := New(nil, nil)
+	t.Cleanup(l.Stop)
+	l.SetMatchmaking(profileMap{
+		1: {Bankroll: 1000},
+		2: {Bankroll: 500000},
+		3: {Bankroll: 2000},
+	}, BankrollBuckets(10000, 100000))
+
+	quickStart := func(userID uint64) *table.Table {
+		t.Helper()
+		tbl, err := l.QuickStart(userID, "", func(uint64, []byte) {})
+		if err != nil {
+			t.Fatalf("QuickStart(%d) err: %v", userID, err)
+		}
+		return tbl
+	}
+	low := quickStart(1)
+	high := quickStart(2)
+	if high == low {
+		t.Fatalf("expected players in different bankroll buckets at different tables, both got %s", low.ID)
+	}
+	if got := quickStart(3); got != low {
+		t.Fatalf("expected user 3 to join the low-bankroll table %s, got %s", low.ID, got.ID)
+	}
+	// Players without a profile fall back to stakes-only matching.
+	if got := quickStart(4); got == low || got == high {
+		t.Fatalf("expected an unprofiled player at a stakes-only table, got %s", got.ID)
+	}
+
+	l.SetMatchmaking(nil, nil)
+	if got := quickStart(2); got == high {
+		t.Fatalf("expected matchmaking off to ignore buckets, got bucketed table %s", got.ID)
+	}
+}
+
+func TestQuickStart_MatchesByStake(t *testing.T) {
+	l := New(nil, nil)
+	t.Cleanup(l.Stop)
+	if err := l.LoadTableConfigJSON([]byte(`{
+		"default": {},
+		"stakes": {"high": {"small_blind": 500, "big_blind": 1000, "min_buy_in": 50000, "max_buy_in": 200000}}
+	}`)); err != nil {
+		t.Fatalf("LoadTableConfigJSON err: %v", err)
+	}
+
+	quickStart := func(userID uint64, stake string) *table.Table {
+		t.Helper()
+		tbl, err := l.QuickStart(userID, stake, func(uint64, []byte) {})
+		if err != nil {
+			t.Fatalf("QuickStart(%d, %q) err: %v", userID, stake, err)
+		}
+		return tbl
+	}
+	low := quickStart(1, "")
+	seatHuman(t, low, 1)
+	high := quickStart(2, "high")
+	if high == low {
+		t.Fatalf("expected different stakes at different tables, both got %s", low.ID)
+	}
+	if high.Config.BigBlind != 1000 || high.Config.MaxBuyIn != 200000 {
+		t.Fatalf("expected the new table to inherit the stake, got %+v", high.Config)
+	}
+	if got := quickStart(3, " high "); got != high {
+		t.Fatalf("expected user 3 to share the high-stakes table %s, got %s", high.ID, got.ID)
+	}
+	if got := quickStart(4, ""); got != low {
+		t.Fatalf("expected user 4 to share the default table %s, got %s", low.ID, got.ID)
+	}
+	// A seated player is sent back to their table whatever they pick.
+	if got := quickStart(1, "high"); got != low {
+		t.Fatalf("expected user 1 to resume at %s, got %s", low.ID, got.ID)
+	}
+	if _, err := l.QuickStart(5, "nosebleed", func(uint64, []byte) {}); !errors.Is(err, ErrUnknownStake) {
+		t.Fatalf("expected ErrUnknownStake, got %v", err)
+	}
+}
+
+func TestSkillBuckets_NeedsEnoughHands(t *testing.T) {
+	bucket := SkillBuckets(200, 0.35)
+	if got := bucket(PlayerProfile{HandsPlayed: 50, VPIP: 0.6, WinRateBB100: 10}); got != "" {
+		t.Fatalf("expected a short sample to stay stakes-only, got %q", got)
+	}
+	reg := bucket(PlayerProfile{HandsPlayed: 1000, VPIP: 0.22, WinRateBB100: 4})
+	fish := bucket(PlayerProfile{HandsPlayed: 1000, VPIP: 0.55, WinRateBB100: -12})
+	if reg == "" || fish == "" || reg == fish {
+		t.Fatalf("expected distinct buckets for a tight winner and a loose loser, got %q and %q", reg, fish)
+	}
+}
+
+func TestFillTableWithNPCs_SmallPackGetsDistinctNicknames(t *testing.T) {
+	registry := npc.NewRegistry()
+	if err := registry.LoadFromJSON([]byte(`[
+		{"id":"p1","name":"Ace","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+		{"id":"p2","name":"Blaze","tier":3,"brain":{"aggression":0.5,"tightness":0.5}},
+		{"id":"p3","name":"Cobra","tier":3,"brain":{"aggression":0.5,"tightness":0.5}}
+	]`)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	l := New(nil, nil, npc.NewManager(registry))
+	t.Cleanup(l.Stop)
+	tbl := newPausedQuickStartTable(t, l)
+
+	l.mu.Lock()
+	l.fillTableWithNPCs(tbl, 5)
+	l.mu.Unlock()
+
+	_, npcChairs := tbl.SeatComposition()
+	if len(npcChairs) != 5 {
+		t.Fatalf("expected 5 NPC seats, got %v", npcChairs)
+	}
+	nicknames := make(map[string]bool)
+	ids := make(map[uint64]bool)
+	perPersona := make(map[string]int)
+	for _, ps := range tbl.Snapshot().Players {
+		name := tbl.PlayerNickname(ps.ID)
+		if nicknames[name] || ids[ps.ID] {
+			t.Fatalf("duplicate seat name=%q id=%d", name, ps.ID)
+		}
+		nicknames[name], ids[ps.ID] = true, true
+		perPersona[l.npcManager.GetInstance(ps.ID).Persona.ID]++
+	}
+	// Every persona is used before any repeats.
+	for _, id := range []string{"p1", "p2", "p3"} {
+		if n := perPersona[id]; n < 1 || n > 2 {
+			t.Fatalf("expected persona %s seated once or twice, got %d (%v)", id, n, perPersona)
+		}
+	}
+	dupes := 0
+	for name := range nicknames {
+		if strings.HasSuffix(name, " (2)") {
+			dupes++
+		}
+	}
+	if dupes != 2 {
+		t.Fatalf("expected two suffixed duplicate nicknames, got %v", nicknames)
+	}
+}
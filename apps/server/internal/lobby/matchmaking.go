@@ -0,0 +1,84 @@
+package lobby
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PlayerProfile is what matchmaking knows about a player: their stored
+// stats and current bankroll.
+type PlayerProfile struct {
+	HandsPlayed int
+	// VPIP is the fraction of dealt hands the player voluntarily put chips in.
+	VPIP float64
+	// WinRateBB100 is big blinds won per 100 hands.
+	WinRateBB100 float64
+	Bankroll     int64
+}
+
+// ProfileSource looks up a player's profile; ok is false when nothing is
+// stored for them yet.
+type ProfileSource interface {
+	PlayerProfile(userID uint64) (profile PlayerProfile, ok bool)
+}
+
+// MatchBucket maps a profile to a matchmaking bucket. Players in different
+// buckets are not seated together by QuickStart; "" is the shared bucket
+// that plain stakes-only matching uses.
+type MatchBucket func(PlayerProfile) string
+
+// BankrollBuckets buckets players by bankroll tier: tier i holds bankrolls of
+// at least tiers[i-1] and below tiers[i].
+func BankrollBuckets(tiers ...int64) MatchBucket {
+	sorted := append([]int64(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return func(p PlayerProfile) string {
+		tier := sort.Search(len(sorted), func(i int) bool { return p.Bankroll < sorted[i] })
+		return fmt.Sprintf("bankroll-%d", tier)
+	}
+}
+
+// SkillBuckets splits players into winning and non-winning players, then
+// into loose and tight by VPIP. Players with fewer than minHands hands have
+// stats too noisy to trust and stay in the stakes-only bucket.
+func SkillBuckets(minHands int, looseVPIP float64) MatchBucket {
+	return func(p PlayerProfile) string {
+		if p.HandsPlayed < minHands {
+			return ""
+		}
+		skill := "skill-casual"
+		if p.WinRateBB100 > 0 {
+			skill = "skill-reg"
+		}
+		if p.VPIP >= looseVPIP {
+			return skill + "-loose"
+		}
+		return skill + "-tight"
+	}
+}
+
+// SetMatchmaking makes QuickStart seat players only at tables created for
+// their bucket. A nil source or bucket turns it off, back to stakes-only
+// matching. Tables created before the change keep their bucket.
+func (l *Lobby) SetMatchmaking(source ProfileSource, bucket MatchBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if source == nil || bucket == nil {
+		l.profiles, l.matchBucket = nil, nil
+		return
+	}
+	l.profiles, l.matchBucket = source, bucket
+}
+
+// matchBucketLocked returns userID's bucket, "" when matchmaking is off or
+// the player has no profile. Caller must hold l.mu.
+func (l *Lobby) matchBucketLocked(userID uint64) string {
+	if l.profiles == nil || l.matchBucket == nil {
+		return ""
+	}
+	profile, ok := l.profiles.PlayerProfile(userID)
+	if !ok {
+		return ""
+	}
+	return l.matchBucket(profile)
+}
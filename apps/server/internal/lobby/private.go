@@ -0,0 +1,125 @@
+package lobby
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+const (
+	// Join codes skip 0/O and 1/I so they survive being read out loud.
+	joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	joinCodeLength   = 6
+)
+
+// ErrUnknownJoinCode is returned by JoinByCode when no open private table
+// has the given code.
+var ErrUnknownJoinCode = errors.New("unknown join code")
+
+// privateTable records who opened a private table and the code that admits
+// players to it. Rematch tables are private without a code.
+type privateTable struct {
+	ownerID  uint64
+	joinCode string
+}
+
+// CreatePrivateTable opens a table that Quick Join never seats strangers at;
+// players reach it only through JoinByCode with the returned code. NPCs are
+// seated (and kept topped up between hands) only when fillNPCs is set.
+func (l *Lobby) CreatePrivateTable(ownerID uint64, cfg table.TableConfig, fillNPCs bool, broadcastFn func(userID uint64, data []byte)) (tableID, joinCode string, err error) {
+	if err := validateTableConfig(cfg); err != nil {
+		return "", "", err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	joinCode, err = l.newJoinCodeLocked()
+	if err != nil {
+		return "", "", err
+	}
+	l.nextID++
+	tableID = fmt.Sprintf("private_%d", l.nextID)
+	t := table.New(tableID, cfg, broadcastFn, l.ledger, l.npcManager)
+	if t == nil {
+		return "", "", fmt.Errorf("failed to create table")
+	}
+	if l.tagger != nil {
+		t.SetOpponentTagger(l.tagger)
+	}
+	l.tables[tableID] = t
+	l.privateTables[tableID] = &privateTable{ownerID: ownerID, joinCode: joinCode}
+	l.joinCodes[joinCode] = tableID
+	l.watchSessionEndLocked(t)
+
+	if fillNPCs {
+		l.fillTableWithNPCs(t, l.targetPlayersLocked(t)-1)
+		t.AddHandEndHook(func(table.HandEndInfo) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if l.tables[tableID] == t {
+				l.rebalanceNPCsLocked(t)
+			}
+		})
+	}
+
+	log.Printf("[Lobby] User %d created private table %s (npcs=%v)", ownerID, tableID, fillNPCs)
+	return tableID, joinCode, nil
+}
+
+// JoinByCode returns the private table behind code. Codes are matched
+// case-insensitively; seating the player is left to the caller, as with
+// QuickStart.
+func (l *Lobby) JoinByCode(userID uint64, code string) (*table.Table, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	t := l.tables[l.joinCodes[code]]
+	if t == nil || t.IsClosed() {
+		return nil, ErrUnknownJoinCode
+	}
+	log.Printf("[Lobby] User %d joining private table %s by code", userID, t.ID)
+	return t, nil
+}
+
+// isPrivateLocked reports whether tableID is a private table. Caller must
+// hold l.mu.
+func (l *Lobby) isPrivateLocked(tableID string) bool {
+	return l.privateTables[tableID] != nil
+}
+
+// dropPrivateTableLocked forgets tableID's join code once the table is
+// gone. Caller must hold l.mu.
+func (l *Lobby) dropPrivateTableLocked(tableID string) {
+	if p := l.privateTables[tableID]; p != nil {
+		if p.joinCode != "" {
+			delete(l.joinCodes, p.joinCode)
+		}
+		delete(l.privateTables, tableID)
+	}
+}
+
+// newJoinCodeLocked draws a join code not held by any open private table.
+// Caller must hold l.mu.
+func (l *Lobby) newJoinCodeLocked() (string, error) {
+	max := big.NewInt(int64(len(joinCodeAlphabet)))
+	buf := make([]byte, joinCodeLength)
+	for {
+		for i := range buf {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", fmt.Errorf("generate join code: %w", err)
+			}
+			buf[i] = joinCodeAlphabet[n.Int64()]
+		}
+		if code := string(buf); l.joinCodes[code] == "" {
+			return code, nil
+		}
+	}
+}
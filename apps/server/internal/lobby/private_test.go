@@ -0,0 +1,88 @@
+package lobby
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+func createPrivateTable(t *testing.T, l *Lobby, fillNPCs bool) (*table.Table, string) {
+	t.Helper()
+
+	tableID, code, err := l.CreatePrivateTable(1, l.defaultConfig, fillNPCs, func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("CreatePrivateTable err: %v", err)
+	}
+	tbl := l.GetTable(tableID)
+	if tbl == nil {
+		t.Fatalf("expected private table %s to be registered", tableID)
+	}
+	return tbl, code
+}
+
+func TestCreatePrivateTable_IssuesDistinctCodes(t *testing.T) {
+	l := newNPCTestLobby(t)
+
+	tbl, code := createPrivateTable(t, l, false)
+	if len(code) != joinCodeLength || strings.Trim(code, joinCodeAlphabet) != "" {
+		t.Fatalf("expected a %d-character code from the join alphabet, got %q", joinCodeLength, code)
+	}
+	if _, npcChairs := tbl.SeatComposition(); len(npcChairs) != 0 {
+		t.Fatalf("expected no NPCs without the owner asking, got %d", len(npcChairs))
+	}
+	if _, other := createPrivateTable(t, l, false); other == code {
+		t.Fatalf("expected a fresh code for the second table, got %q twice", code)
+	}
+
+	withNPCs, _ := createPrivateTable(t, l, true)
+	if _, npcChairs := withNPCs.SeatComposition(); len(npcChairs) == 0 {
+		t.Fatalf("expected NPCs when the owner asks for them")
+	}
+
+	cfg := l.defaultConfig
+	cfg.BigBlind = 0
+	if _, _, err := l.CreatePrivateTable(1, cfg, false, func(uint64, []byte) {}); err == nil {
+		t.Fatalf("expected an invalid config to be rejected")
+	}
+}
+
+func TestJoinByCode(t *testing.T) {
+	l := newNPCTestLobby(t)
+	tbl, code := createPrivateTable(t, l, false)
+
+	got, err := l.JoinByCode(2, " "+strings.ToLower(code)+" ")
+	if err != nil || got != tbl {
+		t.Fatalf("expected the code to lead to %s, got %v err=%v", tbl.ID, got, err)
+	}
+	seatHuman(t, tbl, 2)
+
+	if _, err := l.JoinByCode(3, "ZZZZZZ"); !errors.Is(err, ErrUnknownJoinCode) {
+		t.Fatalf("expected ErrUnknownJoinCode for a wrong code, got %v", err)
+	}
+
+	quick, err := l.QuickStart(3, "", func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("QuickStart err: %v", err)
+	}
+	if quick == tbl {
+		t.Fatalf("expected Quick Join to skip the private table")
+	}
+}
+
+func TestCleanupIdleTables_ExpiresJoinCode(t *testing.T) {
+	l := newNPCTestLobby(t)
+	tbl, code := createPrivateTable(t, l, false)
+
+	tbl.Stop()
+	if removed := l.CleanupIdleTables(); removed != 1 {
+		t.Fatalf("expected the closed private table to be removed, got %d", removed)
+	}
+	if _, err := l.JoinByCode(2, code); !errors.Is(err, ErrUnknownJoinCode) {
+		t.Fatalf("expected the code to expire with its table, got %v", err)
+	}
+	if len(l.joinCodes) != 0 || len(l.privateTables) != 0 {
+		t.Fatalf("expected private table bookkeeping to be cleared, got %v %v", l.joinCodes, l.privateTables)
+	}
+}
@@ -0,0 +1,152 @@
+package lobby
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+// defaultRematchWindow is how long after a session ends its players may ask
+// for a rematch.
+const defaultRematchWindow = 2 * time.Minute
+
+// ErrNoRematch is returned by Rematch when the player has no session to
+// replay, or its window has passed.
+var ErrNoRematch = errors.New("no rematch available")
+
+// rematchOffer is what a finished heads-up session or story chapter leaves
+// behind for a rematch. Every human of a heads-up session shares one offer,
+// so whoever opts in second joins the table the first one opened.
+type rematchOffer struct {
+	endedAt time.Time
+	// chapterID is set for a story chapter; the rest is for heads-up tables.
+	chapterID int
+	cfg       table.TableConfig
+	personas  map[uint16]string // chair -> NPC persona ID
+	tableID   string            // set once the first player opts in
+}
+
+// SetRematchWindow sets how long after a heads-up session or story chapter
+// ends its players may rematch. 0 turns rematches off.
+func (l *Lobby) SetRematchWindow(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	l.mu.Lock()
+	l.rematchWindow = d
+	l.mu.Unlock()
+}
+
+// Rematch replays userID's last heads-up session or story chapter. For a
+// story chapter it returns the chapter to restart (see StartStoryChapter)
+// and no table. For a heads-up session it returns a table with the same
+// config and NPC personas at their old chairs; the caller joins the player.
+// A human opponent gets the same table if they also ask for a rematch.
+func (l *Lobby) Rematch(userID uint64, broadcastFn func(userID uint64, data []byte)) (t *table.Table, chapterID int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	offer := l.rematches[userID]
+	delete(l.rematches, userID)
+	if offer == nil || l.rematchWindow <= 0 || time.Since(offer.endedAt) > l.rematchWindow {
+		return nil, 0, ErrNoRematch
+	}
+	if offer.chapterID > 0 {
+		return nil, offer.chapterID, nil
+	}
+	if t := l.tables[offer.tableID]; t != nil && !t.IsClosed() {
+		log.Printf("[Lobby] User %d joining rematch table %s", userID, t.ID)
+		return t, 0, nil
+	}
+
+	l.nextID++
+	tableID := fmt.Sprintf("rematch_%d", l.nextID)
+	t = table.New(tableID, offer.cfg, broadcastFn, l.ledger, l.npcManager)
+	if t == nil {
+		return nil, 0, fmt.Errorf("failed to create table")
+	}
+	if l.tagger != nil {
+		t.SetOpponentTagger(l.tagger)
+	}
+	l.tables[tableID] = t
+	// Only the players of the old session may sit here, so keep it out of
+	// Quick Join.
+	l.privateTables[tableID] = &privateTable{ownerID: userID}
+	l.watchSessionEndLocked(t)
+	offer.tableID = tableID
+	l.seatPersonasLocked(t, offer.personas)
+
+	log.Printf("[Lobby] User %d opened rematch table %s", userID, tableID)
+	return t, 0, nil
+}
+
+// seatPersonasLocked seats each chair's NPC persona with a fresh max buy-in.
+// Caller must hold l.mu.
+func (l *Lobby) seatPersonasLocked(t *table.Table, personas map[uint16]string) {
+	if l.npcManager == nil {
+		return
+	}
+	nicknames := make(map[string]bool)
+	for chair, personaID := range personas {
+		persona := l.npcManager.Registry().Get(personaID)
+		if persona == nil {
+			log.Printf("[Lobby] Rematch persona %s no longer exists", personaID)
+			continue
+		}
+		nickname := uniqueNickname(persona.Name, nicknames)
+		if err := t.SeatNPCAs(persona, chair, t.Config.MaxBuyIn, nickname); err != nil {
+			log.Printf("[Lobby] Failed to reseat NPC %s at chair %d: %v", nickname, chair, err)
+			continue
+		}
+		nicknames[nickname] = true
+	}
+}
+
+// watchSessionEndLocked offers a rematch to the humans of t when its session
+// ends, if t is heads-up. Caller must hold l.mu.
+func (l *Lobby) watchSessionEndLocked(t *table.Table) {
+	if t.Config.MaxPlayers != 2 {
+		return
+	}
+	t.SetSessionEndHook(l.onSessionEnd)
+}
+
+func (l *Lobby) onSessionEnd(t *table.Table, seats []table.SessionSeat) {
+	offer := &rematchOffer{
+		endedAt:  time.Now(),
+		cfg:      t.Config,
+		personas: make(map[uint16]string),
+	}
+	humans := make([]uint64, 0, len(seats))
+	for _, seat := range seats {
+		if seat.PersonaID != "" {
+			offer.personas[seat.Chair] = seat.PersonaID
+			continue
+		}
+		humans = append(humans, seat.UserID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, userID := range humans {
+		l.rematches[userID] = offer
+	}
+}
+
+// offerStoryRematchLocked lets userID replay chapterID. Caller must hold l.mu.
+func (l *Lobby) offerStoryRematchLocked(userID uint64, chapterID int) {
+	l.rematches[userID] = &rematchOffer{endedAt: time.Now(), chapterID: chapterID}
+}
+
+// pruneRematchesLocked drops offers whose window has passed. Caller must
+// hold l.mu.
+func (l *Lobby) pruneRematchesLocked(now time.Time) {
+	for userID, offer := range l.rematches {
+		if now.Sub(offer.endedAt) > l.rematchWindow {
+			delete(l.rematches, userID)
+		}
+	}
+}
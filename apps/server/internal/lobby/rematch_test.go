@@ -0,0 +1,112 @@
+package lobby
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+// newHeadsUpTable opens a heads-up private table with persona p2 at chair 1.
+func newHeadsUpTable(t *testing.T, l *Lobby) (*table.Table, uint64) {
+	t.Helper()
+
+	cfg := l.defaultConfig
+	cfg.MaxPlayers = 2
+	cfg.MaxHandsPerSession = 20
+	tbl, _ := createPrivateTableWithConfig(t, l, cfg)
+	persona := l.npcManager.Registry().Get("p2")
+	if err := tbl.SeatNPCAs(persona, 1, cfg.MaxBuyIn, persona.Name); err != nil {
+		t.Fatalf("SeatNPCAs err: %v", err)
+	}
+	for _, p := range tbl.Snapshot().Players {
+		if p.Chair == 1 {
+			return tbl, p.ID
+		}
+	}
+	t.Fatalf("expected the NPC to be seated")
+	return nil, 0
+}
+
+func createPrivateTableWithConfig(t *testing.T, l *Lobby, cfg table.TableConfig) (*table.Table, string) {
+	t.Helper()
+
+	tableID, code, err := l.CreatePrivateTable(1, cfg, false, func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("CreatePrivateTable err: %v", err)
+	}
+	return l.GetTable(tableID), code
+}
+
+func TestRematch_RecreatesTableWithSamePersonas(t *testing.T) {
+	l := newNPCTestLobby(t)
+	old, npcID := newHeadsUpTable(t, l)
+	l.onSessionEnd(old, []table.SessionSeat{
+		{Chair: 0, UserID: 1},
+		{Chair: 1, UserID: npcID, PersonaID: "p2"},
+	})
+	old.Stop()
+
+	tbl, chapterID, err := l.Rematch(1, func(uint64, []byte) {})
+	if err != nil || chapterID != 0 {
+		t.Fatalf("Rematch err=%v chapter=%d", err, chapterID)
+	}
+	if tbl == old || !reflect.DeepEqual(tbl.Config, old.Config) {
+		t.Fatalf("expected a new table with the same config, got %s %+v", tbl.ID, tbl.Config)
+	}
+	players := tbl.Snapshot().Players
+	if len(players) != 1 || players[0].Chair != 1 {
+		t.Fatalf("expected the NPC back at chair 1 and the human's chair open, got %+v", players)
+	}
+	inst := l.npcManager.GetInstance(players[0].ID)
+	if inst == nil || inst.Persona.ID != "p2" || players[0].Stack != old.Config.MaxBuyIn {
+		t.Fatalf("expected persona p2 with a fresh buy-in, got %+v stack=%d", inst, players[0].Stack)
+	}
+
+	if _, _, err := l.Rematch(1, func(uint64, []byte) {}); !errors.Is(err, ErrNoRematch) {
+		t.Fatalf("expected the offer to be spent, got %v", err)
+	}
+	if quick, err := l.QuickStart(3, "", func(uint64, []byte) {}); err != nil || quick == tbl {
+		t.Fatalf("expected Quick Join to skip the rematch table, got %v err=%v", quick, err)
+	}
+}
+
+func TestRematch_HumanOpponentsShareTheTable(t *testing.T) {
+	l := newNPCTestLobby(t)
+	cfg := l.defaultConfig
+	cfg.MaxPlayers = 2
+	old, _ := createPrivateTableWithConfig(t, l, cfg)
+	l.onSessionEnd(old, []table.SessionSeat{{Chair: 0, UserID: 1}, {Chair: 1, UserID: 2}})
+
+	first, _, err := l.Rematch(2, func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("Rematch(2) err: %v", err)
+	}
+	seatHuman(t, first, 2)
+	second, _, err := l.Rematch(1, func(uint64, []byte) {})
+	if err != nil || second != first {
+		t.Fatalf("expected user 1 to join rematch table %s, got %v err=%v", first.ID, second, err)
+	}
+	if _, _, err := l.Rematch(3, func(uint64, []byte) {}); !errors.Is(err, ErrNoRematch) {
+		t.Fatalf("expected no rematch for a user who was not there, got %v", err)
+	}
+}
+
+func TestRematch_StoryRestartsChapter(t *testing.T) {
+	l := newNPCTestLobby(t)
+	l.mu.Lock()
+	l.offerStoryRematchLocked(1, 3)
+	l.offerStoryRematchLocked(2, 4)
+	l.mu.Unlock()
+
+	tbl, chapterID, err := l.Rematch(1, func(uint64, []byte) {})
+	if err != nil || tbl != nil || chapterID != 3 {
+		t.Fatalf("expected chapter 3 to restart, got table=%v chapter=%d err=%v", tbl, chapterID, err)
+	}
+
+	l.SetRematchWindow(0)
+	if _, _, err := l.Rematch(2, func(uint64, []byte) {}); !errors.Is(err, ErrNoRematch) {
+		t.Fatalf("expected rematches to be off, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package lobby
+
+import (
+	"log"
+
+	"holdem-lite/apps/server/internal/table"
+	"holdem-lite/holdem/npc"
+)
+
+// SetNPCRotation makes Quick Join tables swap one NPC for a persona not yet
+// at the table every `hands` hands, so a long session keeps meeting new
+// opponents (0 disables).
+func (l *Lobby) SetNPCRotation(hands int) {
+	if hands < 0 {
+		hands = 0
+	}
+	l.mu.Lock()
+	l.npcRotateHands = hands
+	l.mu.Unlock()
+}
+
+// maybeRotateNPCLocked runs from the hand-end hook after rebalancing and
+// rotates on every npcRotateHands-th round. Caller must hold l.mu.
+func (l *Lobby) maybeRotateNPCLocked(t *table.Table, round uint32) {
+	if l.npcRotateHands <= 0 || round == 0 || round%uint32(l.npcRotateHands) != 0 {
+		return
+	}
+	l.rotateNPCLocked(t, int(round)/l.npcRotateHands)
+}
+
+// rotateNPCLocked replaces the NPC at one chair (picked round-robin by turn)
+// with a persona that is not seated, keeping the seat's stack. The NPC count
+// is unchanged, so the fill cap still holds. Caller must hold l.mu.
+func (l *Lobby) rotateNPCLocked(t *table.Table, turn int) {
+	mgr := l.npcManager
+	if mgr == nil || t.IsClosed() {
+		return
+	}
+	humans, npcChairs := t.SeatComposition()
+	if humans == 0 || len(npcChairs) == 0 {
+		return
+	}
+
+	seated := make(map[string]bool, len(npcChairs))
+	stacks := make(map[uint16]int64, len(npcChairs))
+	userIDs := make(map[uint16]uint64, len(npcChairs))
+	for _, ps := range t.Snapshot().Players {
+		userIDs[ps.Chair] = ps.ID
+		stacks[ps.Chair] = ps.Stack
+		if inst := mgr.GetInstance(ps.ID); inst != nil && inst.Persona != nil {
+			seated[inst.Persona.ID] = true
+		}
+	}
+	var candidates []*npc.NPCPersona
+	for _, persona := range mgr.Registry().All() {
+		if !seated[persona.ID] {
+			candidates = append(candidates, persona)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	chair := npcChairs[turn%len(npcChairs)]
+	stack := stacks[chair]
+	if stack <= 0 {
+		return
+	}
+	replacement := candidates[l.rng.Intn(len(candidates))]
+	old := mgr.GetInstance(userIDs[chair])
+	if err := t.UnseatNPC(chair); err != nil {
+		log.Printf("[Lobby] NPC rotation: unseat chair %d on table %s failed: %v", chair, t.ID, err)
+		return
+	}
+	if err := t.SeatNPC(replacement, chair, stack); err != nil {
+		log.Printf("[Lobby] NPC rotation: seat %s at chair %d on table %s failed: %v", replacement.Name, chair, t.ID, err)
+		return
+	}
+	if old != nil && old.Persona != nil {
+		log.Printf("[Lobby] Rotated NPC %s out for %s at chair %d on table %s", old.Persona.Name, replacement.Name, chair, t.ID)
+	}
+}
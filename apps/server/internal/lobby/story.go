@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -34,7 +35,10 @@ type storySession struct {
 	currentStack int64
 	potWins      int
 	completed    bool
-	paused       bool
+	// completing is set while a met objective is being persisted, so hands
+	// that end meanwhile do not complete the chapter a second time.
+	completing bool
+	paused     bool
 
 	broadcastFn func(userID uint64, data []byte)
 }
@@ -88,7 +92,7 @@ func (l *Lobby) StartStoryChapter(
 		supports = append(supports, p)
 	}
 
-	var stalePausedTable *table.Table
+	var staleTables []*table.Table
 	l.mu.Lock()
 	if ref := l.pausedStories[userID]; ref != nil {
 		if resumeRequested && ref.ChapterID == chapterID {
@@ -111,7 +115,18 @@ func (l *Lobby) StartStoryChapter(
 				return t, chapter, nil
 			}
 		}
-		stalePausedTable = l.detachPausedStoryLocked(userID)
+		if t := l.detachPausedStoryLocked(userID); t != nil {
+			staleTables = append(staleTables, t)
+		}
+	}
+	// One story session per user: a new chapter closes the one in progress.
+	if tableID, ok := l.activeStories[userID]; ok {
+		if t := l.tables[tableID]; t != nil {
+			staleTables = append(staleTables, t)
+		}
+		delete(l.tables, tableID)
+		l.dropStorySessionLocked(tableID)
+		log.Printf("[Lobby] Story table %s of user %d replaced by a new chapter", tableID, userID)
 	}
 
 	// Create a story mode table
@@ -130,11 +145,14 @@ func (l *Lobby) StartStoryChapter(
 	t := table.New(tableID, storyCfg, broadcastFn, l.ledger, l.npcManager)
 	if t == nil {
 		l.mu.Unlock()
-		if stalePausedTable != nil {
-			stalePausedTable.Stop()
+		for _, stale := range staleTables {
+			stale.Stop()
 		}
 		return nil, nil, fmt.Errorf("failed to create story table")
 	}
+	if l.tagger != nil {
+		t.SetOpponentTagger(l.tagger)
+	}
 	l.tables[tableID] = t
 
 	buyIn := storyCfg.MaxBuyIn
@@ -172,13 +190,14 @@ func (l *Lobby) StartStoryChapter(
 		broadcastFn:  broadcastFn,
 	}
 	l.storySessions[tableID] = session
+	l.activeStories[userID] = tableID
 	t.AddHandEndHook(func(info table.HandEndInfo) {
 		l.onStoryHandEnd(session, chapterCount, info)
 	})
 	l.mu.Unlock()
 
-	if stalePausedTable != nil {
-		stalePausedTable.Stop()
+	for _, stale := range staleTables {
+		stale.Stop()
 	}
 
 	return t, chapter, nil
@@ -237,7 +256,7 @@ func (l *Lobby) onStoryHandEnd(
 	}
 
 	session.mu.Lock()
-	if session.completed {
+	if session.completed || session.completing {
 		session.mu.Unlock()
 		return
 	}
@@ -270,16 +289,19 @@ func (l *Lobby) onStoryHandEnd(
 		chapterSession.HandsPlayed >= session.chapter.Objective.Target {
 		chapterSession.Completed = hasMostChips(info.Snapshot, session.userID)
 	}
+	l.sendChapterScoreboard(session, chapterSession, info.Snapshot)
 
 	if !chapterSession.IsChapterComplete(session.chapter.Objective, session.bigBlind) {
 		session.mu.Unlock()
 		return
 	}
+	session.completing = true
 	session.mu.Unlock()
 
 	if l.storyService == nil {
 		session.mu.Lock()
 		session.completed = true
+		session.completing = false
 		session.mu.Unlock()
 		return
 	}
@@ -299,6 +321,10 @@ func (l *Lobby) onStoryHandEnd(
 			return
 		}
 		log.Printf("[Lobby] persist story completion failed: user=%d chapter=%d err=%v", session.userID, session.chapterID, err)
+		// Let a later hand that still meets the objective try again.
+		session.mu.Lock()
+		session.completing = false
+		session.mu.Unlock()
 		return
 	}
 
@@ -308,6 +334,7 @@ func (l *Lobby) onStoryHandEnd(
 		return
 	}
 	session.completed = true
+	session.completing = false
 	session.paused = false
 	broadcastFn := session.broadcastFn
 	session.mu.Unlock()
@@ -316,6 +343,7 @@ func (l *Lobby) onStoryHandEnd(
 	if ref := l.pausedStories[session.userID]; ref != nil && ref.TableID == session.tableID {
 		delete(l.pausedStories, session.userID)
 	}
+	l.offerStoryRematchLocked(session.userID, session.chapterID)
 	l.mu.Unlock()
 
 	log.Printf("[Lobby] story chapter completed: user=%d chapter=%d unlocked=%d",
@@ -409,7 +437,7 @@ func (l *Lobby) PauseStorySession(userID uint64, tableID string) {
 	session.mu.Unlock()
 
 	if completed {
-		delete(l.storySessions, tableID)
+		l.dropStorySessionLocked(tableID)
 		delete(l.pausedStories, userID)
 		l.removePausedStoryByTableLocked(tableID)
 		t = l.tables[tableID]
@@ -452,12 +480,21 @@ func (l *Lobby) detachPausedStoryLocked(userID uint64) *table.Table {
 	if ref == nil {
 		return nil
 	}
-	delete(l.storySessions, ref.TableID)
+	l.dropStorySessionLocked(ref.TableID)
 	t := l.tables[ref.TableID]
 	delete(l.tables, ref.TableID)
 	return t
 }
 
+// dropStorySessionLocked forgets the story session on tableID, and the
+// owner's active story if it is this one.
+func (l *Lobby) dropStorySessionLocked(tableID string) {
+	if session := l.storySessions[tableID]; session != nil && l.activeStories[session.userID] == tableID {
+		delete(l.activeStories, session.userID)
+	}
+	delete(l.storySessions, tableID)
+}
+
 func (l *Lobby) sendStoryProgress(
 	tableID string,
 	userID uint64,
@@ -493,6 +530,66 @@ func (l *Lobby) sendStoryProgress(
 	broadcastFn(userID, data)
 }
 
+// sendChapterScoreboard pushes every seat's stack and standing, and progress
+// toward the objective, to the session's player after a hand while they are
+// still seated. Caller must hold session.mu.
+func (l *Lobby) sendChapterScoreboard(session *storySession, progress *npc.ChapterSession, snap holdem.Snapshot) {
+	if session.broadcastFn == nil {
+		return
+	}
+	if _, seated := findPlayerByID(snap, session.userID); !seated {
+		return
+	}
+
+	players := append([]holdem.PlayerSnapshot(nil), snap.Players...)
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Stack != players[j].Stack {
+			return players[i].Stack > players[j].Stack
+		}
+		return players[i].Chair < players[j].Chair
+	})
+	standings := make([]*pb.ChapterStanding, 0, len(players))
+	rank := int32(0)
+	for i, ps := range players {
+		if i == 0 || ps.Stack < players[i-1].Stack {
+			rank = int32(i + 1)
+		}
+		standings = append(standings, &pb.ChapterStanding{
+			Chair:  uint32(ps.Chair),
+			UserId: ps.ID,
+			Stack:  ps.Stack,
+			Net:    ps.Stack - session.startStack,
+			Rank:   rank,
+			IsHero: ps.ID == session.userID,
+			Busted: ps.Stack <= 0,
+		})
+	}
+
+	current, target := progress.Progress(session.chapter.Objective, session.bigBlind)
+
+	env := &pb.ServerEnvelope{
+		TableId:    session.tableID,
+		ServerTsMs: time.Now().UnixMilli(),
+		Payload: &pb.ServerEnvelope_ChapterScoreboard{
+			ChapterScoreboard: &pb.ChapterScoreboard{
+				ChapterId:   int32(session.chapterID),
+				HandsPlayed: int32(session.handsPlayed),
+				StartStack:  session.startStack,
+				Standings:   standings,
+
+				ObjectiveProgress: current,
+				ObjectiveTarget:   target,
+			},
+		},
+	}
+	data, err := proto.Marshal(env)
+	if err != nil {
+		log.Printf("[Lobby] marshal chapter scoreboard failed: user=%d err=%v", session.userID, err)
+		return
+	}
+	session.broadcastFn(session.userID, data)
+}
+
 func findPlayerByID(snap holdem.Snapshot, userID uint64) (holdem.PlayerSnapshot, bool) {
 	for _, ps := range snap.Players {
 		if ps.ID == userID {
@@ -0,0 +1,208 @@
+package lobby
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/apps/server/internal/table"
+	"holdem-lite/holdem"
+	"holdem-lite/holdem/npc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const testChaptersJSON = `[
+	{"id":1,"title":"ONE","bossId":"p1","supportIds":["p2"],"objective":{"type":"win_bb","target":10}}
+]`
+
+func newStoryTestLobby(t *testing.T) *Lobby {
+	t.Helper()
+
+	l := newNPCTestLobby(t)
+	chapters := npc.NewChapterRegistry()
+	if err := chapters.LoadFromJSON([]byte(testChaptersJSON)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	l.SetChapterRegistry(chapters)
+	return l
+}
+
+func TestStartStoryChapter_ReplacesTheUsersActiveSession(t *testing.T) {
+	l := newStoryTestLobby(t)
+	noop := func(uint64, []byte) {}
+
+	first, _, err := l.StartStoryChapter(1, 1, false, noop)
+	if err != nil {
+		t.Fatalf("first chapter err: %v", err)
+	}
+	other, _, err := l.StartStoryChapter(2, 1, false, noop)
+	if err != nil {
+		t.Fatalf("other user's chapter err: %v", err)
+	}
+	second, _, err := l.StartStoryChapter(1, 1, false, noop)
+	if err != nil {
+		t.Fatalf("second chapter err: %v", err)
+	}
+
+	if !first.IsClosed() {
+		t.Fatalf("expected the replaced story table to be closed")
+	}
+	if second.IsClosed() || other.IsClosed() {
+		t.Fatalf("expected the new table and the other user's table to stay open")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tables[first.ID] != nil || l.storySessions[first.ID] != nil {
+		t.Fatalf("expected the replaced story table to be dropped from the lobby")
+	}
+	if got := l.activeStories[1]; got != second.ID {
+		t.Fatalf("expected user 1's active story on %s, got %q", second.ID, got)
+	}
+	if len(l.storySessions) != 2 {
+		t.Fatalf("expected one story session per user, got %d", len(l.storySessions))
+	}
+}
+
+func TestChapterScoreboard_TracksStacksAfterEachHand(t *testing.T) {
+	l := newStoryTestLobby(t)
+	var boards []*pb.ChapterScoreboard
+	tbl, _, err := l.StartStoryChapter(1, 1, false, func(userID uint64, data []byte) {
+		var env pb.ServerEnvelope
+		if err := proto.Unmarshal(data, &env); err != nil {
+			t.Fatalf("unmarshal err: %v", err)
+		}
+		if board := env.GetChapterScoreboard(); board != nil && userID == 1 {
+			boards = append(boards, board)
+		}
+	})
+	if err != nil {
+		t.Fatalf("StartStoryChapter err: %v", err)
+	}
+	tbl.Stop()
+	l.mu.RLock()
+	session := l.storySessions[tbl.ID]
+	l.mu.RUnlock()
+	start := session.startStack
+
+	// Simulated hands: the boss takes 300 from the hero, then the support
+	// busts to the boss.
+	hands := [][]holdem.PlayerSnapshot{
+		{{ID: 1, Chair: 0, Stack: start - 300}, {ID: 100, Chair: 1, Stack: start + 300}, {ID: 101, Chair: 2, Stack: start}},
+		{{ID: 1, Chair: 0, Stack: start - 300}, {ID: 100, Chair: 1, Stack: 2*start + 300}, {ID: 101, Chair: 2, Stack: 0}},
+	}
+	for i, players := range hands {
+		l.onStoryHandEnd(session, 1, table.HandEndInfo{
+			TableID:  tbl.ID,
+			Round:    uint32(i + 1),
+			Snapshot: holdem.Snapshot{Players: players},
+			Result:   &holdem.SettlementResult{},
+		})
+	}
+
+	if len(boards) != 2 {
+		t.Fatalf("expected a scoreboard per hand, got %d", len(boards))
+	}
+	first := boards[0]
+	if first.GetChapterId() != 1 || first.GetHandsPlayed() != 1 || first.GetStartStack() != start {
+		t.Fatalf("unexpected first scoreboard header: %v", first)
+	}
+	leader, hero := first.GetStandings()[0], first.GetStandings()[2]
+	if leader.GetUserId() != 100 || leader.GetRank() != 1 || leader.GetNet() != 300 {
+		t.Fatalf("expected the boss to lead by 300, got %v", leader)
+	}
+	if !hero.GetIsHero() || hero.GetRank() != 3 || hero.GetNet() != -300 {
+		t.Fatalf("expected the hero last at -300, got %v", hero)
+	}
+
+	last := boards[1]
+	if last.GetHandsPlayed() != 2 {
+		t.Fatalf("expected two hands played, got %d", last.GetHandsPlayed())
+	}
+	busted := last.GetStandings()[2]
+	if busted.GetUserId() != 101 || !busted.GetBusted() || busted.GetNet() != -start {
+		t.Fatalf("expected the support busted in last place, got %v", busted)
+	}
+	if hero := last.GetStandings()[1]; !hero.GetIsHero() || hero.GetRank() != 2 || hero.GetStack() != start-300 {
+		t.Fatalf("expected the hero second, got %v", hero)
+	}
+}
+
+// playStoryHands starts a chapter with the given objective for user 1 and
+// settles a simulated hand per hero stack, returning the session and the
+// scoreboards sent.
+func playStoryHands(t *testing.T, objective string, heroStacks ...int64) (*storySession, []*pb.ChapterScoreboard) {
+	t.Helper()
+
+	l := newNPCTestLobby(t)
+	chapters := npc.NewChapterRegistry()
+	chapterJSON := `[{"id":1,"title":"ONE","bossId":"p1","supportIds":["p2"],"objective":` + objective + `}]`
+	if err := chapters.LoadFromJSON([]byte(chapterJSON)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	l.SetChapterRegistry(chapters)
+
+	var boards []*pb.ChapterScoreboard
+	tbl, _, err := l.StartStoryChapter(1, 1, false, func(_ uint64, data []byte) {
+		var env pb.ServerEnvelope
+		if err := proto.Unmarshal(data, &env); err != nil {
+			t.Fatalf("unmarshal err: %v", err)
+		}
+		if board := env.GetChapterScoreboard(); board != nil {
+			boards = append(boards, board)
+		}
+	})
+	if err != nil {
+		t.Fatalf("StartStoryChapter err: %v", err)
+	}
+	tbl.Stop()
+	l.mu.RLock()
+	session := l.storySessions[tbl.ID]
+	l.mu.RUnlock()
+
+	for i, stack := range heroStacks {
+		l.onStoryHandEnd(session, 1, table.HandEndInfo{
+			TableID:  tbl.ID,
+			Round:    uint32(i + 1),
+			Snapshot: holdem.Snapshot{Players: []holdem.PlayerSnapshot{{ID: 1, Chair: 0, Stack: stack}, {ID: 100, Chair: 1, Stack: 1}}},
+			Result:   &holdem.SettlementResult{},
+		})
+	}
+	return session, boards
+}
+
+func TestStoryObjective_Survive(t *testing.T) {
+	const survive = `{"type":"survive","target":2}`
+	session, boards := playStoryHands(t, survive, 500)
+	if session.completed || len(boards) != 1 || boards[0].GetObjectiveProgress() != 1 || boards[0].GetObjectiveTarget() != 2 {
+		t.Fatalf("expected 1 of 2 hands survived and no completion, got completed=%v boards=%v", session.completed, boards)
+	}
+
+	session, _ = playStoryHands(t, survive, 500, 0)
+	if session.completed {
+		t.Fatalf("expected busting on the last hand to miss the objective")
+	}
+
+	// Hands after completion neither count nor complete it again.
+	session, boards = playStoryHands(t, survive, 500, 1, 800)
+	if !session.completed || session.handsPlayed != 2 || len(boards) != 2 {
+		t.Fatalf("expected completion after 2 hands, got completed=%v hands=%d boards=%d", session.completed, session.handsPlayed, len(boards))
+	}
+}
+
+func TestStoryObjective_DoubleUp(t *testing.T) {
+	const doubleUp = `{"type":"double_up"}`
+	session, _ := playStoryHands(t, doubleUp)
+	start := session.startStack
+	session, boards := playStoryHands(t, doubleUp, start+start/2, 2*start-1)
+	if session.completed {
+		t.Fatalf("expected no completion one chip short of doubling")
+	}
+	if last := boards[len(boards)-1]; last.GetObjectiveProgress() != 2*start-1 || last.GetObjectiveTarget() != 2*start {
+		t.Fatalf("expected progress %d/%d, got %d/%d", 2*start-1, 2*start, last.GetObjectiveProgress(), last.GetObjectiveTarget())
+	}
+
+	session, boards = playStoryHands(t, doubleUp, 2*start, start)
+	if !session.completed || len(boards) != 1 || boards[0].GetObjectiveProgress() != boards[0].GetObjectiveTarget() {
+		t.Fatalf("expected completion on doubling up and nothing after, got completed=%v boards=%v", session.completed, boards)
+	}
+}
@@ -0,0 +1,74 @@
+package lobby
+
+import (
+	"sort"
+	"strings"
+
+	"holdem-lite/apps/server/internal/table"
+)
+
+// Table kinds reported by ListTableInfo.
+const (
+	TableKindQuickJoin = "quick"
+	TableKindStory     = "story"
+	TableKindPrivate   = "private"
+)
+
+// TableInfo is a table's listing in the lobby browser.
+type TableInfo struct {
+	ID   string
+	Kind string
+	// Stake is the named stake of a Quick Join table, "" for the default.
+	Stake          string
+	SmallBlind     int64
+	BigBlind       int64
+	Ante           int64
+	Seated         int
+	MaxPlayers     int
+	HandInProgress bool
+	// AveragePot is the mean pot of the hands settled so far (0 before any).
+	AveragePot int64
+	Closed     bool
+}
+
+// ListTableInfo describes the lobby's tables, ordered by ID. Only open Quick
+// Join tables are listed unless includeHidden is set, which adds closed,
+// story and private tables. Seat counts come from each table's game
+// snapshot, so listing never waits on a table's event queue.
+func (l *Lobby) ListTableInfo(includeHidden bool) []TableInfo {
+	l.mu.RLock()
+	infos := make([]TableInfo, 0, len(l.tables))
+	tables := make([]*table.Table, 0, len(l.tables))
+	for tableID, t := range l.tables {
+		kind := TableKindQuickJoin
+		switch {
+		case l.isPrivateLocked(tableID):
+			kind = TableKindPrivate
+		case strings.HasPrefix(tableID, "story_"):
+			kind = TableKindStory
+		}
+		infos = append(infos, TableInfo{ID: tableID, Kind: kind, Stake: l.tableStakes[tableID]})
+		tables = append(tables, t)
+	}
+	l.mu.RUnlock()
+
+	listed := infos[:0]
+	for i, info := range infos {
+		t := tables[i]
+		info.Closed = t.IsClosed()
+		if !includeHidden && (info.Closed || info.Kind != TableKindQuickJoin) {
+			continue
+		}
+		snap := t.Snapshot()
+		info.SmallBlind = t.Config.SmallBlind
+		info.BigBlind = t.Config.BigBlind
+		info.Ante = t.Config.Ante
+		info.Seated = len(snap.Players)
+		info.MaxPlayers = int(t.Config.MaxPlayers)
+		info.HandInProgress = snap.Round > 0 && !snap.Ended
+		info.AveragePot = t.AveragePot()
+		listed = append(listed, info)
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].ID < listed[j].ID })
+	return listed
+}
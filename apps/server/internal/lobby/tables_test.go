@@ -0,0 +1,80 @@
+package lobby
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListTableInfo_MatchesCreatedTables(t *testing.T) {
+	l := newNPCTestLobby(t)
+	quick := newPausedQuickStartTable(t, l)
+	seatHuman(t, quick, 1)
+
+	cfg := l.defaultConfig
+	cfg.MaxPlayers = 9
+	cfg.SmallBlind = 100
+	cfg.BigBlind = 200
+	privateID, _, err := l.CreatePrivateTable(2, cfg, false, func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("CreatePrivateTable err: %v", err)
+	}
+	closedID, _, err := l.CreatePrivateTable(3, l.defaultConfig, false, func(uint64, []byte) {})
+	if err != nil {
+		t.Fatalf("CreatePrivateTable err: %v", err)
+	}
+	l.GetTable(closedID).Stop()
+
+	listed := l.ListTableInfo(false)
+	if len(listed) != 1 || listed[0].ID != quick.ID {
+		t.Fatalf("expected only the Quick Join table listed, got %+v", listed)
+	}
+	humans, npcChairs := quick.SeatComposition()
+	got := listed[0]
+	if got.Kind != TableKindQuickJoin || got.Seated != humans+len(npcChairs) || got.MaxPlayers != 6 ||
+		got.SmallBlind != 50 || got.BigBlind != 100 || got.Closed {
+		t.Fatalf("unexpected Quick Join listing %+v (humans=%d npcs=%d)", got, humans, len(npcChairs))
+	}
+
+	all := l.ListTableInfo(true)
+	if len(all) != 3 {
+		t.Fatalf("expected all 3 tables with hidden ones included, got %+v", all)
+	}
+	byID := make(map[string]TableInfo, len(all))
+	for _, info := range all {
+		byID[info.ID] = info
+	}
+	if p := byID[privateID]; p.Kind != TableKindPrivate || p.Seated != 0 || p.MaxPlayers != 9 || p.BigBlind != 200 {
+		t.Fatalf("unexpected private listing %+v", p)
+	}
+	if c := byID[closedID]; !c.Closed {
+		t.Fatalf("expected the stopped table to be reported closed, got %+v", c)
+	}
+}
+
+func TestTablesHTTP(t *testing.T) {
+	l := newNPCTestLobby(t)
+	quick := newPausedQuickStartTable(t, l)
+	if _, _, err := l.CreatePrivateTable(2, l.defaultConfig, false, func(uint64, []byte) {}); err != nil {
+		t.Fatalf("CreatePrivateTable err: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewHTTPHandler(l).RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/lobby/tables", nil))
+	var resp tablesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("list failed: code=%d err=%v", rec.Code, err)
+	}
+	if len(resp.Tables) != 1 || resp.Tables[0].TableID != quick.ID || resp.Tables[0].BigBlind != 100 {
+		t.Fatalf("expected only the Quick Join table, got %+v", resp.Tables)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/lobby/tables", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for POST, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,173 @@
+package notes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"holdem-lite/apps/server/internal/auth"
+)
+
+type HTTPHandler struct {
+	auth  auth.Service
+	notes Service
+	// onColorChange, if set, runs after a write changed a color tag.
+	onColorChange func(userID uint64)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// setNoteRequest updates the fields that are present; omitted ones are kept.
+type setNoteRequest struct {
+	Note  *string `json:"note"`
+	Color *string `json:"color"`
+}
+
+func NewHTTPHandler(authService auth.Service, notesService Service) *HTTPHandler {
+	return &HTTPHandler{
+		auth:  authService,
+		notes: notesService,
+	}
+}
+
+// SetColorChangeHook registers fn to run with the user whose color tags a
+// note write changed, e.g. so tables refresh the tags they cache.
+func (h *HTTPHandler) SetColorChangeHook(fn func(userID uint64)) {
+	h.onColorChange = fn
+}
+
+func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/notes", h.handleList)
+	mux.HandleFunc("/api/notes/", h.handleNote)
+}
+
+func (h *HTTPHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID, ok := h.resolveUserID(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid session token")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	items, err := h.notes.GetPlayerNotes(ctx, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "query notes failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items": items,
+	})
+}
+
+func (h *HTTPHandler) handleNote(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.resolveUserID(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid session token")
+		return
+	}
+	raw := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/api/notes/"))
+	targetID, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || targetID == 0 {
+		writeError(w, http.StatusBadRequest, "invalid target id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetNote(w, r, userID, targetID)
+	case http.MethodPut:
+		h.handleSetNote(w, r, userID, targetID)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *HTTPHandler) handleGetNote(w http.ResponseWriter, r *http.Request, userID, targetID uint64) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	items, err := h.notes.GetPlayerNotes(ctx, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "query notes failed")
+		return
+	}
+	for _, n := range items {
+		if n.TargetUserID == targetID {
+			writeJSON(w, http.StatusOK, n)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "note not found")
+}
+
+func (h *HTTPHandler) handleSetNote(w http.ResponseWriter, r *http.Request, userID, targetID uint64) {
+	var req setNoteRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16<<10))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Note == nil && req.Color == nil {
+		writeError(w, http.StatusBadRequest, "nothing to update")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	note, err := h.notes.UpdatePlayerNote(ctx, userID, targetID, NoteUpdate{Note: req.Note, Color: req.Color})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNoteTooLong), errors.Is(err, ErrInvalidTarget), errors.Is(err, ErrInvalidColor):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "save note failed")
+		}
+		return
+	}
+	if req.Color != nil && h.onColorChange != nil {
+		h.onColorChange(userID)
+	}
+	writeJSON(w, http.StatusOK, note)
+}
+
+func (h *HTTPHandler) resolveUserID(r *http.Request) (uint64, bool) {
+	token := bearerToken(r.Header.Get("Authorization"))
+	if token == "" {
+		return 0, false
+	}
+	userID, _, ok := h.auth.ResolveSession(token)
+	if !ok {
+		return 0, false
+	}
+	return userID, true
+}
+
+func bearerToken(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(raw, "Bearer "))
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
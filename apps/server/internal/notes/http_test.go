@@ -0,0 +1,73 @@
+package notes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"holdem-lite/apps/server/internal/auth"
+)
+
+func TestHTTPHandler_PutAndGetNote(t *testing.T) {
+	authService := auth.NewManager()
+	aliceID, aliceToken, err := authService.Register("alice_01", "secret12", auth.ClientInfo{})
+	if err != nil {
+		t.Fatalf("register err: %v", err)
+	}
+	bobID, bobToken, err := authService.Register("bob_0001", "secret12", auth.ClientInfo{})
+	if err != nil {
+		t.Fatalf("register err: %v", err)
+	}
+	mux := http.NewServeMux()
+	handler := NewHTTPHandler(authService, NewMemoryService())
+	var recolored []uint64
+	handler.SetColorChangeHook(func(userID uint64) { recolored = append(recolored, userID) })
+	handler.RegisterRoutes(mux)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	target := "/api/notes/" + strconv.FormatUint(bobID, 10)
+
+	if rec := do(http.MethodPut, target, aliceToken, `{"note":"limps a lot"}`); rec.Code != http.StatusOK {
+		t.Fatalf("PUT status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec := do(http.MethodGet, target, aliceToken, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var got Note
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Note != "limps a lot" {
+		t.Fatalf("unexpected GET body %s (err %v)", rec.Body.String(), err)
+	}
+	if rec := do(http.MethodPut, target, aliceToken, `{"color":"green"}`); rec.Code != http.StatusOK {
+		t.Fatalf("PUT color status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodGet, target, aliceToken, "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Note != "limps a lot" || got.Color != "green" {
+		t.Fatalf("expected color stored next to the note, got %s (err %v)", rec.Body.String(), err)
+	}
+	if rec := do(http.MethodPut, target, aliceToken, `{"note":"changed","color":"magenta"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected invalid color to be rejected, status=%d", rec.Code)
+	}
+	if len(recolored) != 1 || recolored[0] != aliceID {
+		t.Fatalf("expected one color change hook call for alice, got %v", recolored)
+	}
+	if rec := do(http.MethodGet, target, bobToken, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected another user's GET to miss, status=%d", rec.Code)
+	}
+	long := `{"note":"` + strings.Repeat("x", MaxNoteLength+1) + `"}`
+	if rec := do(http.MethodPut, target, aliceToken, long); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected oversized note to be rejected, status=%d", rec.Code)
+	}
+	if rec := do(http.MethodGet, target, "bogus", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected invalid token to be rejected, status=%d", rec.Code)
+	}
+}
@@ -0,0 +1,171 @@
+package notes
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testServices(t *testing.T) map[string]Service {
+	t.Helper()
+
+	sqlite, err := NewSQLiteService(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteService err: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlite.Close() })
+	return map[string]Service{
+		"memory": NewMemoryService(),
+		"sqlite": sqlite,
+	}
+}
+
+func TestSetPlayerNote_SetUpdateAndList(t *testing.T) {
+	for name, svc := range testServices(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if _, err := svc.SetPlayerNote(ctx, 1, 20, "  calls too wide  "); err != nil {
+				t.Fatalf("set note err: %v", err)
+			}
+			if _, err := svc.SetPlayerNote(ctx, 1, 10, "tight"); err != nil {
+				t.Fatalf("set note err: %v", err)
+			}
+			updated, err := svc.SetPlayerNote(ctx, 1, 20, "bluffs rivers")
+			if err != nil {
+				t.Fatalf("update note err: %v", err)
+			}
+			if updated.Note != "bluffs rivers" || updated.TargetUserID != 20 {
+				t.Fatalf("unexpected updated note %+v", updated)
+			}
+
+			items, err := svc.GetPlayerNotes(ctx, 1)
+			if err != nil {
+				t.Fatalf("get notes err: %v", err)
+			}
+			if len(items) != 2 || items[0].TargetUserID != 10 || items[1].TargetUserID != 20 {
+				t.Fatalf("expected notes on 10 and 20, got %+v", items)
+			}
+			if items[0].Note != "tight" || items[1].Note != "bluffs rivers" {
+				t.Fatalf("unexpected note contents %+v", items)
+			}
+
+			// Notes are private to their author.
+			others, err := svc.GetPlayerNotes(ctx, 20)
+			if err != nil {
+				t.Fatalf("get notes err: %v", err)
+			}
+			if len(others) != 0 {
+				t.Fatalf("expected no notes for another user, got %+v", others)
+			}
+
+			// An empty note deletes it.
+			if _, err := svc.SetPlayerNote(ctx, 1, 10, ""); err != nil {
+				t.Fatalf("clear note err: %v", err)
+			}
+			items, _ = svc.GetPlayerNotes(ctx, 1)
+			if len(items) != 1 || items[0].TargetUserID != 20 {
+				t.Fatalf("expected cleared note to be removed, got %+v", items)
+			}
+		})
+	}
+}
+
+func TestSetPlayerNote_Validation(t *testing.T) {
+	for name, svc := range testServices(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if _, err := svc.SetPlayerNote(ctx, 1, 2, strings.Repeat("é", MaxNoteLength)); err != nil {
+				t.Fatalf("expected note at the limit to be accepted, got %v", err)
+			}
+			if _, err := svc.SetPlayerNote(ctx, 1, 2, strings.Repeat("x", MaxNoteLength+1)); !errors.Is(err, ErrNoteTooLong) {
+				t.Fatalf("expected ErrNoteTooLong, got %v", err)
+			}
+			if _, err := svc.SetPlayerNote(ctx, 1, 1, "me"); !errors.Is(err, ErrInvalidTarget) {
+				t.Fatalf("expected ErrInvalidTarget for a self note, got %v", err)
+			}
+			if _, err := svc.SetPlayerNote(ctx, 1, 0, "nobody"); !errors.Is(err, ErrInvalidTarget) {
+				t.Fatalf("expected ErrInvalidTarget for target 0, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetPlayerColor_StoredWithNote(t *testing.T) {
+	for name, svc := range testServices(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if _, err := svc.SetPlayerNote(ctx, 1, 2, "3-bets light"); err != nil {
+				t.Fatalf("set note err: %v", err)
+			}
+			n, err := svc.SetPlayerColor(ctx, 1, 2, " Red ")
+			if err != nil {
+				t.Fatalf("set color err: %v", err)
+			}
+			if n.Color != "red" || n.Note != "3-bets light" {
+				t.Fatalf("expected color next to the note, got %+v", n)
+			}
+			if _, err := svc.SetPlayerColor(ctx, 1, 3, "green"); err != nil {
+				t.Fatalf("set color without note err: %v", err)
+			}
+			if _, err := svc.SetPlayerColor(ctx, 1, 2, "magenta"); !errors.Is(err, ErrInvalidColor) {
+				t.Fatalf("expected ErrInvalidColor, got %v", err)
+			}
+
+			items, err := svc.GetPlayerNotes(ctx, 1)
+			if err != nil {
+				t.Fatalf("get notes err: %v", err)
+			}
+			if len(items) != 2 || items[0].Color != "red" || items[1].Color != "green" || items[1].Note != "" {
+				t.Fatalf("unexpected notes %+v", items)
+			}
+
+			// Clearing the color keeps the note; clearing both drops the entry.
+			if _, err := svc.SetPlayerColor(ctx, 1, 2, ""); err != nil {
+				t.Fatalf("clear color err: %v", err)
+			}
+			if _, err := svc.SetPlayerColor(ctx, 1, 3, ""); err != nil {
+				t.Fatalf("clear color err: %v", err)
+			}
+			items, _ = svc.GetPlayerNotes(ctx, 1)
+			if len(items) != 1 || items[0].TargetUserID != 2 || items[0].Color != "" || items[0].Note != "3-bets light" {
+				t.Fatalf("unexpected notes after clearing colors %+v", items)
+			}
+		})
+	}
+}
+
+func TestUpdatePlayerNote_WritesNoteAndColorTogether(t *testing.T) {
+	for name, svc := range testServices(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			note, color := "  floats flops ", "Blue"
+			n, err := svc.UpdatePlayerNote(ctx, 1, 2, NoteUpdate{Note: &note, Color: &color})
+			if err != nil {
+				t.Fatalf("update err: %v", err)
+			}
+			if n.Note != "floats flops" || n.Color != "blue" {
+				t.Fatalf("expected note and color together, got %+v", n)
+			}
+
+			// A bad color rejects the whole update, text included.
+			note, color = "changed", "magenta"
+			if _, err := svc.UpdatePlayerNote(ctx, 1, 2, NoteUpdate{Note: &note, Color: &color}); !errors.Is(err, ErrInvalidColor) {
+				t.Fatalf("expected ErrInvalidColor, got %v", err)
+			}
+			// A nil field keeps what is stored.
+			note = "floats flops, folds turns"
+			if _, err := svc.UpdatePlayerNote(ctx, 1, 2, NoteUpdate{Note: &note}); err != nil {
+				t.Fatalf("update note only err: %v", err)
+			}
+			items, err := svc.GetPlayerNotes(ctx, 1)
+			if err != nil {
+				t.Fatalf("get notes err: %v", err)
+			}
+			if len(items) != 1 || items[0].Note != note || items[0].Color != "blue" {
+				t.Fatalf("unexpected notes %+v", items)
+			}
+		})
+	}
+}
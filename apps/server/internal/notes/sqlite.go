@@ -0,0 +1,218 @@
+package notes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const defaultLocalDBName = "holdem_local.db"
+
+type sqliteService struct {
+	db *sql.DB
+}
+
+func NewSQLiteServiceFromEnv() (Service, string, error) {
+	dbPath, err := notesLocalDatabasePathFromEnv()
+	if err != nil {
+		return nil, "", err
+	}
+	service, err := NewSQLiteService(dbPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return service, "sqlite", nil
+}
+
+func NewSQLiteService(dbPath string) (Service, error) {
+	dbPath = strings.TrimSpace(dbPath)
+	if dbPath == "" {
+		return nil, fmt.Errorf("empty sqlite database path")
+	}
+	if dbPath != ":memory:" {
+		parent := filepath.Dir(dbPath)
+		if parent != "" && parent != "." {
+			if err := os.MkdirAll(parent, 0o755); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := db.ExecContext(ctx, `PRAGMA busy_timeout = 5000;`); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, `PRAGMA journal_mode = WAL;`); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := ensureSQLiteNotesSchema(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &sqliteService{db: db}, nil
+}
+
+func (s *sqliteService) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *sqliteService) SetPlayerNote(ctx context.Context, userID, targetUserID uint64, note string) (*Note, error) {
+	return s.UpdatePlayerNote(ctx, userID, targetUserID, NoteUpdate{Note: &note})
+}
+
+func (s *sqliteService) SetPlayerColor(ctx context.Context, userID, targetUserID uint64, color string) (*Note, error) {
+	return s.UpdatePlayerNote(ctx, userID, targetUserID, NoteUpdate{Color: &color})
+}
+
+// UpdatePlayerNote upserts the row with a single statement, keeping whichever
+// field is nil, reads it back and drops it if both note and color are now empty.
+func (s *sqliteService) UpdatePlayerNote(ctx context.Context, userID, targetUserID uint64, update NoteUpdate) (*Note, error) {
+	update, err := normalizeUpdate(userID, targetUserID, update)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO player_notes (user_id, target_user_id, note, color, updated_at_ms)
+VALUES (?1, ?2, COALESCE(?3, ''), COALESCE(?4, ''), ?5)
+ON CONFLICT(user_id, target_user_id) DO UPDATE
+SET note = COALESCE(?3, note), color = COALESCE(?4, color), updated_at_ms = excluded.updated_at_ms
+`, userID, targetUserID, update.Note, update.Color, time.Now().UTC().UnixMilli()); err != nil {
+		return nil, err
+	}
+	n := &Note{TargetUserID: targetUserID}
+	var updatedAtMs int64
+	if err := tx.QueryRowContext(ctx, `
+SELECT note, color, updated_at_ms
+FROM player_notes
+WHERE user_id = ? AND target_user_id = ?
+`, userID, targetUserID).Scan(&n.Note, &n.Color, &updatedAtMs); err != nil {
+		return nil, err
+	}
+	n.UpdatedAt = time.UnixMilli(updatedAtMs).UTC()
+	if _, err := tx.ExecContext(ctx, `
+DELETE FROM player_notes
+WHERE user_id = ? AND target_user_id = ? AND note = '' AND color = ''
+`, userID, targetUserID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (s *sqliteService) GetPlayerNotes(ctx context.Context, userID uint64) ([]Note, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, `
+SELECT target_user_id, note, color, updated_at_ms
+FROM player_notes
+WHERE user_id = ?
+ORDER BY target_user_id
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []Note{}
+	for rows.Next() {
+		var n Note
+		var updatedAtMs int64
+		if err := rows.Scan(&n.TargetUserID, &n.Note, &n.Color, &updatedAtMs); err != nil {
+			return nil, err
+		}
+		n.UpdatedAt = time.UnixMilli(updatedAtMs).UTC()
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
+
+func ensureSQLiteNotesSchema(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS player_notes (
+    user_id INTEGER NOT NULL,
+    target_user_id INTEGER NOT NULL,
+    note TEXT NOT NULL,
+    color TEXT NOT NULL DEFAULT '',
+    updated_at_ms INTEGER NOT NULL,
+    PRIMARY KEY (user_id, target_user_id)
+)`); err != nil {
+		return err
+	}
+
+	// Databases created before color tags lack the column.
+	var hasColor int
+	if err := db.QueryRowContext(ctx, `
+SELECT COUNT(*) FROM pragma_table_info('player_notes') WHERE name = 'color'
+`).Scan(&hasColor); err != nil {
+		return err
+	}
+	if hasColor == 0 {
+		if _, err := db.ExecContext(ctx, `ALTER TABLE player_notes ADD COLUMN color TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func notesLocalDatabasePathFromEnv() (string, error) {
+	candidates := []string{
+		strings.TrimSpace(os.Getenv("NOTES_LOCAL_DATABASE_PATH")),
+		strings.TrimSpace(os.Getenv("AUTH_LOCAL_DATABASE_PATH")),
+		strings.TrimSpace(os.Getenv("LOCAL_DATABASE_PATH")),
+	}
+	for _, candidate := range candidates {
+		if candidate != "" {
+			return filepath.Clean(candidate), nil
+		}
+	}
+
+	userConfigDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userConfigDir, "HoldemIJ", defaultLocalDBName), nil
+}
@@ -0,0 +1,38 @@
+package notes
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Tagger exposes a viewer's color tags to the table snapshot builder.
+type Tagger struct {
+	notes Service
+}
+
+func NewTagger(notesService Service) *Tagger {
+	return &Tagger{notes: notesService}
+}
+
+// OpponentTags returns the color tags viewerID has set, keyed by tagged user.
+// Lookup errors are logged and yield no tags.
+func (t *Tagger) OpponentTags(viewerID uint64) map[uint64]string {
+	if t == nil || t.notes == nil || viewerID == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	items, err := t.notes.GetPlayerNotes(ctx, viewerID)
+	if err != nil {
+		log.Printf("[Notes] tag lookup for user %d failed: %v", viewerID, err)
+		return nil
+	}
+	tags := make(map[uint64]string)
+	for _, n := range items {
+		if n.Color != "" {
+			tags[n.TargetUserID] = n.Color
+		}
+	}
+	return tags
+}
@@ -0,0 +1,121 @@
+package table
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// stackChanges records SeatUpdate stack changes by chair.
+func stackChanges(t *testing.T, tbl *Table) map[uint16][]int64 {
+	t.Helper()
+	got := make(map[uint16][]int64)
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if userID != 1 {
+			return
+		}
+		if su := decodeServerEnvelope(t, data).GetSeatUpdate(); su != nil {
+			if _, ok := su.GetUpdate().(*pb.SeatUpdate_StackChange); ok {
+				got[uint16(su.GetChair())] = append(got[uint16(su.GetChair())], su.GetStackChange())
+			}
+		}
+	}
+	return got
+}
+
+func engineStack(tbl *Table, chair uint16) int64 {
+	for _, ps := range tbl.game.Snapshot().Players {
+		if ps.Chair == chair {
+			return ps.Stack + ps.Bet
+		}
+	}
+	return -1
+}
+
+func TestBuyIn_QueuedUntilNextDealAndClampedToMax(t *testing.T) {
+	cfg := harnessTestConfig()
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	changes := stackChanges(t, tbl)
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.game.Snapshot()
+	sb, bb := snap.SmallBlindChair, snap.BigBlindChair
+	sbUser, bbUser := tbl.seats[sb], tbl.seats[bb]
+
+	// Mid-hand: queued, nothing changes yet.
+	if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: sbUser, Amount: 30}); err != nil {
+		t.Fatalf("mid-hand buy-in err: %v", err)
+	}
+	if got := engineStack(tbl, sb); got != cfg.MaxBuyIn {
+		t.Fatalf("expected the stack untouched mid-hand, got %d", got)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 1)
+
+	// Between hands: the winner is over the max, the loser is topped up to it.
+	if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: bbUser, Amount: 10}); err == nil {
+		t.Fatalf("expected a buy-in above max to be rejected")
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: sbUser, Amount: 500}); err != nil {
+		t.Fatalf("between-hands buy-in err: %v", err)
+	}
+	if got := engineStack(tbl, sb); got != 950 || len(changes[sb]) != 0 {
+		t.Fatalf("expected no change before the next deal, got stack %d updates %v", got, changes[sb])
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: sbUser, Amount: 1}); err == nil {
+		t.Fatalf("expected no room left once the queued buy-in reaches max")
+	}
+
+	tbl.AdvanceClock(foldHandDelay)
+	if got := tbl.game.Snapshot().Round; got != 2 {
+		t.Fatalf("expected hand 2 to be dealt, got round %d", got)
+	}
+	if got := changes[sb]; len(got) != 1 || got[0] != cfg.MaxBuyIn {
+		t.Fatalf("expected one stack update to %d, got %v", cfg.MaxBuyIn, got)
+	}
+	if got := engineStack(tbl, sb); got != cfg.MaxBuyIn {
+		t.Fatalf("expected the topped-up stack in play, got %d", got)
+	}
+}
+
+func TestBuyIn_BustedPlayerRejoinsNextDeal(t *testing.T) {
+	cfg := harnessTestConfig()
+	// SB: AA, BB: 72o, dry board.
+	deck := mustCards(t, "As", "7c", "Ah", "2d", "Kd", "9s", "4h", "3c", "Jd")
+	tbl, err := NewTableForTest(cfg, deck, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	bb := tbl.game.Snapshot().BigBlindChair
+	bbUser := tbl.seats[bb]
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 2)
+	if got := engineStack(tbl, bb); got != 0 {
+		t.Fatalf("expected the big blind to bust, got stack %d", got)
+	}
+
+	if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: bbUser, Amount: cfg.MaxBuyIn}); err != nil {
+		t.Fatalf("busted buy-in err: %v", err)
+	}
+	tbl.AdvanceClock(showdownHandDelay)
+	snap := tbl.game.Snapshot()
+	if snap.Round != 2 || snap.Ended {
+		t.Fatalf("expected hand 2 to be dealt, got round %d ended=%v", snap.Round, snap.Ended)
+	}
+	for _, ps := range snap.Players {
+		if ps.Chair == bb && (len(ps.HandCards) != 2 || ps.Stack+ps.Bet != cfg.MaxBuyIn) {
+			t.Fatalf("expected the rebought player dealt in with %d, got %+v", cfg.MaxBuyIn, ps)
+		}
+	}
+}
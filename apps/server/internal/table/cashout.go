@@ -0,0 +1,90 @@
+package table
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+)
+
+// handleCashOut records a cash-out opt-in for the current hand. It only takes
+// effect if the player is all-in when the board runs out.
+func (t *Table) handleCashOut(userID uint64) error {
+	if !t.Config.AllowCashOut {
+		return fmt.Errorf("cash out not allowed at this table")
+	}
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return fmt.Errorf("player not seated")
+	}
+	snap := t.game.Snapshot()
+	if snap.Round == 0 || snap.Ended || !liveInHand(snap, player.Chair) {
+		return fmt.Errorf("not in a live hand")
+	}
+	if t.cashOutUsers == nil {
+		t.cashOutUsers = make(map[uint64]bool)
+	}
+	t.cashOutUsers[userID] = true
+	log.Printf("[Table %s] User %d opted in to cash out this hand", t.ID, userID)
+	return nil
+}
+
+// applyCashOutsLocked settles opted-in all-in players at their equity on
+// board, the community cards when the last action closed betting, minus the
+// house margin. Hands that ended on the river or without a showdown have
+// nothing left to cash out.
+func (t *Table) applyCashOutsLocked(board []card.Card, result *holdem.SettlementResult) {
+	users := t.cashOutUsers
+	t.cashOutUsers = nil
+	if !t.Config.AllowCashOut || len(users) == 0 || len(board) >= 5 || !hasShowdownHands(result) {
+		return
+	}
+	margin := math.Min(math.Max(t.Config.CashOutMarginPercent, 0), 100) / 100
+	snap := t.game.Snapshot()
+	for _, ps := range snap.Players {
+		userID := t.seats[ps.Chair]
+		if !users[userID] || !ps.AllIn || ps.Folded {
+			continue
+		}
+		equity, err := holdem.ExpectedWinnings(result, board, ps.Chair, t.cashOutSeed(ps.Chair))
+		if err != nil {
+			log.Printf("[Table %s] cash-out equity for chair %d failed: %v", t.ID, ps.Chair, err)
+			continue
+		}
+		payout := int64(math.Floor(equity * (1 - margin)))
+		co, err := t.game.ApplyCashOut(ps.Chair, equity, payout)
+		if err != nil {
+			log.Printf("[Table %s] cash-out for chair %d failed: %v", t.ID, ps.Chair, err)
+			continue
+		}
+		log.Printf("[Table %s] User %d cashed out for %d (equity %.1f, runout paid %d)",
+			t.ID, userID, co.Payout, co.Equity, co.Actual)
+	}
+	t.syncPlayerStacksFromSnapshot(t.game.Snapshot())
+}
+
+// cashOutSeed pins the equity sample to the hand so it can be reproduced.
+func (t *Table) cashOutSeed(chair uint16) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s/%d", t.handID, chair)
+	return int64(h.Sum64())
+}
+
+func toCashOutResults(result *holdem.SettlementResult) []*pb.CashOutResult {
+	if result == nil || len(result.CashOuts) == 0 {
+		return nil
+	}
+	out := make([]*pb.CashOutResult, 0, len(result.CashOuts))
+	for _, co := range result.CashOuts {
+		out = append(out, &pb.CashOutResult{
+			Chair:        uint32(co.Chair),
+			Payout:       co.Payout,
+			RunoutAmount: co.Actual,
+		})
+	}
+	return out
+}
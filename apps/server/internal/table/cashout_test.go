@@ -0,0 +1,119 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// AA vs KK all-in on 2c 7d 9h: of the 990 turn/river runouts KK wins 83 (a
+// king without an ace), so the aces expect 2000 * 907/990 = 1832.3 chips and
+// a 5% margin pays 1740.
+const cashOutFlopPayout = 1740
+
+func runCashOutHand(t *testing.T, turn, river string) (*Table, uint16, uint16, *pb.HandEnd) {
+	t.Helper()
+
+	deck := mustCards(t, "As", "Kd", "Ah", "Kc", "2c", "7d", "9h", turn, river)
+	cfg := harnessTestConfig()
+	cfg.AllowCashOut = true
+	cfg.CashOutMarginPercent = 5
+	tbl, err := NewTableForTest(cfg, deck, NewManualClock(time.Unix(1_700_000_000, 0)), NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var handEnd *pb.HandEnd
+	tbl.broadcast = func(_ uint64, data []byte) {
+		if env := decodeServerEnvelope(t, data); env.GetHandEnd() != nil {
+			handEnd = env.GetHandEnd()
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.game.Snapshot()
+	aces, kings := snap.SmallBlindChair, snap.BigBlindChair
+
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 0)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, 1)
+	if got := len(tbl.game.Snapshot().CommunityCards); got != 3 {
+		t.Fatalf("expected the flop, got %d board cards", got)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, 900, 2)
+	if err := tbl.SubmitEvent(Event{Type: EventCashOut, UserID: tbl.seats[aces]}); err != nil {
+		t.Fatalf("cash out opt-in err: %v", err)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, 900, 3)
+	if !tbl.game.Snapshot().Ended {
+		t.Fatalf("expected the all-in to run out")
+	}
+	if handEnd == nil || len(handEnd.GetCashOuts()) != 1 {
+		t.Fatalf("expected one cash-out on hand end, got %v", handEnd)
+	}
+	return tbl, aces, kings, handEnd
+}
+
+func stackAt(tbl *Table, chair uint16) int64 {
+	for _, ps := range tbl.game.Snapshot().Players {
+		if ps.Chair == chair {
+			return ps.Stack
+		}
+	}
+	return -1
+}
+
+func TestCashOut_HeadsUpAllInWouldHaveWon(t *testing.T) {
+	tbl, aces, kings, handEnd := runCashOutHand(t, "3s", "4d")
+
+	co := handEnd.GetCashOuts()[0]
+	if co.GetChair() != uint32(aces) || co.GetPayout() != cashOutFlopPayout || co.GetRunoutAmount() != 2000 {
+		t.Fatalf("unexpected cash-out %v", co)
+	}
+	if got := stackAt(tbl, aces); got != cashOutFlopPayout {
+		t.Fatalf("expected aces to keep the cash-out payout, got stack %d", got)
+	}
+	if got := stackAt(tbl, kings); got != 0 {
+		t.Fatalf("expected kings to lose their stack, got %d", got)
+	}
+	if got := tbl.players[tbl.seats[aces]].Stack; got != cashOutFlopPayout {
+		t.Fatalf("expected table stack synced to payout, got %d", got)
+	}
+}
+
+func TestCashOut_HeadsUpAllInWouldHaveLost(t *testing.T) {
+	tbl, aces, kings, handEnd := runCashOutHand(t, "Ks", "4d")
+
+	co := handEnd.GetCashOuts()[0]
+	if co.GetChair() != uint32(aces) || co.GetPayout() != cashOutFlopPayout || co.GetRunoutAmount() != 0 {
+		t.Fatalf("unexpected cash-out %v", co)
+	}
+	if got := stackAt(tbl, aces); got != cashOutFlopPayout {
+		t.Fatalf("expected aces to be paid their equity despite losing, got stack %d", got)
+	}
+	// The opponent is settled on the runout as usual.
+	if got := stackAt(tbl, kings); got != 2000 {
+		t.Fatalf("expected kings to win the pot, got stack %d", got)
+	}
+	for _, d := range handEnd.GetStackDeltas() {
+		if d.GetChair() == uint32(aces) && d.GetNewStack() != cashOutFlopPayout {
+			t.Fatalf("expected stack delta to include the cash-out, got %v", d)
+		}
+	}
+}
+
+func TestCashOut_RequiresConfigAndLiveHand(t *testing.T) {
+	tbl := newPrivacyTestTable(t, 0)
+	if err := tbl.SubmitEvent(Event{Type: EventCashOut, UserID: 1}); err == nil {
+		t.Fatalf("expected cash out to be rejected when not allowed")
+	}
+	tbl.Config.AllowCashOut = true
+	folder := tbl.seats[tbl.game.Snapshot().ActionChair]
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	if err := tbl.SubmitEvent(Event{Type: EventCashOut, UserID: folder}); err == nil {
+		t.Fatalf("expected a folded player to be refused")
+	}
+}
@@ -0,0 +1,42 @@
+package table
+
+import (
+	"fmt"
+
+	"holdem-lite/holdem"
+)
+
+// snapBuyIn rounds a buy-in down to ChipUnit, or refuses it when
+// RejectUnalignedBuyIn is set.
+func (c TableConfig) snapBuyIn(amount int64) (int64, error) {
+	if c.ChipUnit <= 1 || amount%c.ChipUnit == 0 {
+		return amount, nil
+	}
+	if c.RejectUnalignedBuyIn {
+		return 0, fmt.Errorf("buy-in %d is not a multiple of the %d-chip unit", amount, c.ChipUnit)
+	}
+	return amount - amount%c.ChipUnit, nil
+}
+
+// snapBetLocked rounds the total a bet or raise goes to down to ChipUnit,
+// rounding up instead when that would turn a legal size into one below the
+// minimum raise. Sizes the engine would refuse or cap at all-in are left
+// alone.
+func (t *Table) snapBetLocked(chair uint16, action holdem.ActionType, amount int64) int64 {
+	unit := t.Config.ChipUnit
+	if unit <= 1 || (action != holdem.PlayerActionTypeBet && action != holdem.PlayerActionTypeRaise) {
+		return amount
+	}
+	_, minTo, maxTo, err := t.game.LegalActions(chair)
+	if err != nil || amount < minTo || amount >= maxTo {
+		return amount
+	}
+	snapped := amount - amount%unit
+	if snapped < minTo {
+		snapped = (minTo + unit - 1) / unit * unit
+	}
+	if snapped > maxTo {
+		snapped = maxTo
+	}
+	return snapped
+}
@@ -0,0 +1,89 @@
+package table
+
+import (
+	"testing"
+
+	"holdem-lite/holdem"
+)
+
+func chipUnitTable(t *testing.T, reject bool) *Table {
+	t.Helper()
+	cfg := harnessTestConfig()
+	cfg.MaxBuyIn = 10000
+	cfg.ChipUnit = 25
+	cfg.RejectUnalignedBuyIn = reject
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	return tbl
+}
+
+func TestChipUnit_SnapBuyIn(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.ChipUnit = 25
+	if got, err := cfg.snapBuyIn(5010); err != nil || got != 5000 {
+		t.Fatalf("expected 5010 to snap to 5000, got %d err=%v", got, err)
+	}
+	cfg.RejectUnalignedBuyIn = true
+	if _, err := cfg.snapBuyIn(5010); err == nil {
+		t.Fatalf("expected 5010 to be rejected")
+	}
+	if got, err := cfg.snapBuyIn(5025); err != nil || got != 5025 {
+		t.Fatalf("expected an aligned buy-in to pass, got %d err=%v", got, err)
+	}
+}
+
+func TestChipUnit_TopUpSnapsOrRejects(t *testing.T) {
+	for _, reject := range []bool{false, true} {
+		tbl := chipUnitTable(t, reject)
+		sb := tbl.game.Snapshot().SmallBlindChair
+		sbUser := tbl.seats[sb]
+		actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 1)
+
+		// The small blind is 50 short of the max; 30 is not a multiple of 25.
+		err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: sbUser, Amount: 30})
+		if reject {
+			if err == nil {
+				t.Fatalf("expected an unaligned top-up to be rejected")
+			}
+			if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: sbUser, Amount: 25}); err != nil {
+				t.Fatalf("aligned top-up err: %v", err)
+			}
+		} else if err != nil {
+			t.Fatalf("top-up err: %v", err)
+		}
+		tbl.AdvanceClock(foldHandDelay)
+		if got := engineStack(tbl, sb); got != 9975 {
+			t.Fatalf("reject=%v: expected the stack topped up to 9975, got %d", reject, got)
+		}
+	}
+}
+
+func TestChipUnit_SnapsBetSizes(t *testing.T) {
+	tbl := chipUnitTable(t, false)
+	opener := tbl.game.Snapshot().ActionChair
+
+	// Heads-up the button opens; a raise to 333 is played as 325.
+	actOnTable(t, tbl, holdem.PlayerActionTypeRaise, 333, 1)
+	if got := engineStack(tbl, opener); got != 10000 {
+		t.Fatalf("expected the opener's chips intact, got %d", got)
+	}
+	if got := tbl.game.Snapshot().CurBet; got != 325 {
+		t.Fatalf("expected the raise to snap to 325, got %d", got)
+	}
+
+	// A re-raise short of the 550 minimum is still refused, not rounded up.
+	if err := tbl.SubmitEvent(Event{Type: EventAction, UserID: tbl.seats[tbl.game.Snapshot().ActionChair], Action: holdem.PlayerActionTypeRaise, Amount: 540}); err == nil {
+		t.Fatalf("expected a raise below the minimum to be refused")
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeRaise, 560, 2)
+	if got := tbl.game.Snapshot().CurBet; got != 550 {
+		t.Fatalf("expected the re-raise to snap to 550, got %d", got)
+	}
+}
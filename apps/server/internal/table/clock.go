@@ -0,0 +1,161 @@
+package table
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the table's time source for timeouts, scheduling and timestamps.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	After(d time.Duration) <-chan time.Time
+}
+
+// Ticker is the subset of time.Ticker the table actor uses.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type realTicker struct{ t *time.Ticker }
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+// now returns the table clock's time. Tables built without a clock use the
+// wall clock.
+func (t *Table) now() time.Time {
+	if t.clock == nil {
+		return time.Now()
+	}
+	return t.clock.Now()
+}
+
+// after is the clock-aware time.After.
+func (t *Table) after(d time.Duration) <-chan time.Time {
+	if t.clock == nil {
+		return time.After(d)
+	}
+	return t.clock.After(d)
+}
+
+// ManualClock is a fake Clock that only moves when advanced. Timers and
+// tickers fire from Advance; a ticker that falls behind delivers one tick,
+// like time.Ticker.
+type ManualClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []manualWaiter
+	tickers []*manualTicker
+}
+
+type manualWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+type manualTicker struct {
+	clock  *ManualClock
+	period time.Duration
+	next   time.Time
+	ch     chan time.Time
+}
+
+// NewManualClock returns a clock frozen at start.
+func NewManualClock(start time.Time) *ManualClock {
+	c := &ManualClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, manualWaiter{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// BlockUntil waits until at least n After timers are pending, so a test can
+// advance the clock only once a goroutine is parked on it.
+func (c *ManualClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for ManualClock.NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tk := &manualTicker{clock: c, period: d, next: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, tk)
+	return tk
+}
+
+// Advance moves the clock forward by d, firing due timers and tickers.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+
+	for _, tk := range c.tickers {
+		if tk.next.After(c.now) {
+			continue
+		}
+		for !tk.next.After(c.now) {
+			tk.next = tk.next.Add(tk.period)
+		}
+		select {
+		case tk.ch <- c.now:
+		default:
+		}
+	}
+}
+
+func (tk *manualTicker) C() <-chan time.Time { return tk.ch }
+
+func (tk *manualTicker) Stop() {
+	c := tk.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.tickers {
+		if other == tk {
+			c.tickers = append(c.tickers[:i], c.tickers[i+1:]...)
+			return
+		}
+	}
+}
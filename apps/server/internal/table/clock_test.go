@@ -0,0 +1,116 @@
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestManualClock_AfterAndTickerFireOnAdvance(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	after := clock.After(2 * time.Second)
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	clock.Advance(time.Second)
+	select {
+	case <-after:
+		t.Fatalf("After fired before its deadline")
+	default:
+	}
+	select {
+	case <-ticker.C():
+	default:
+		t.Fatalf("expected ticker to fire after one period")
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case at := <-after:
+		if !at.Equal(time.Unix(2, 0)) {
+			t.Fatalf("expected After to fire at t=2s, got %v", at)
+		}
+	default:
+		t.Fatalf("expected After to fire at its deadline")
+	}
+}
+
+func TestActionTimeout_FiresOnFakeClockWithoutSleeping(t *testing.T) {
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.game.Snapshot()
+	actor := snap.ActionChair
+	if actor == holdem.InvalidChair {
+		t.Fatalf("expected a player to act")
+	}
+
+	tbl.AdvanceClock(time.Duration(actionTimeLimitSec)*time.Second - time.Millisecond)
+	if got := tbl.game.Snapshot(); got.Ended || got.ActionChair != actor {
+		t.Fatalf("expected chair %d still to act before the deadline", actor)
+	}
+
+	tbl.AdvanceClock(time.Millisecond)
+	snap = tbl.game.Snapshot()
+	if !snap.Ended {
+		t.Fatalf("expected timed-out small blind to fold and end the hand")
+	}
+	for _, ps := range snap.Players {
+		if ps.Chair == actor && !ps.Folded {
+			t.Fatalf("expected chair %d to be auto-folded", actor)
+		}
+	}
+}
+
+func TestActionTimeout_LateClientActionIsAlreadyResolved(t *testing.T) {
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	// Three-handed so the hand goes on after the auto-fold.
+	if err := tbl.SubmitEvent(Event{Type: EventPause, UserID: 1}); err != nil {
+		t.Fatalf("pause err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2, 3} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventResume, UserID: 1}); err != nil {
+		t.Fatalf("resume err: %v", err)
+	}
+	snap := tbl.game.Snapshot()
+	actor := snap.ActionChair
+	late := tbl.seats[actor]
+	other := tbl.seats[snap.BigBlindChair]
+
+	tbl.AdvanceClock(time.Duration(actionTimeLimitSec) * time.Second)
+	if got := tbl.game.Snapshot().ActionChair; got == actor {
+		t.Fatalf("expected the timeout to act for chair %d", actor)
+	}
+
+	// The client's call crossed the auto-fold in flight.
+	err = tbl.SubmitEvent(Event{Type: EventAction, UserID: late, Action: holdem.PlayerActionTypeCall})
+	if !errors.Is(err, ErrActionAlreadyResolved) {
+		t.Fatalf("expected ErrActionAlreadyResolved, got %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventAction, UserID: other, Action: holdem.PlayerActionTypeFold}); err == nil || errors.Is(err, ErrActionAlreadyResolved) {
+		t.Fatalf("expected a plain out-of-turn error for a player the timeout did not touch, got %v", err)
+	}
+
+	clock.Advance(lateActionWindow)
+	err = tbl.SubmitEvent(Event{Type: EventAction, UserID: late, Action: holdem.PlayerActionTypeCall})
+	if err == nil || errors.Is(err, ErrActionAlreadyResolved) {
+		t.Fatalf("expected a plain out-of-turn error once the window passed, got %v", err)
+	}
+}
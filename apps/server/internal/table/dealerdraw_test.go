@@ -0,0 +1,54 @@
+package table
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+// dealerDrawEnvelopes starts a heads-up table and returns, in order, the
+// DealerDraw and HandStart payloads user 1 received.
+func dealerDrawEnvelopes(t *testing.T, highCard bool) []*pb.ServerEnvelope {
+	t.Helper()
+	cfg := harnessTestConfig()
+	cfg.HighCardDraw = highCard
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var got []*pb.ServerEnvelope
+	tbl.broadcast = func(userID uint64, data []byte) {
+		env := decodeServerEnvelope(t, data)
+		if userID == 1 && (env.GetDealerDraw() != nil || env.GetHandStart() != nil) {
+			got = append(got, env)
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	return got
+}
+
+func TestHighCardDraw_BroadcastBeforeFirstHandStart(t *testing.T) {
+	got := dealerDrawEnvelopes(t, true)
+	if len(got) != 2 || got[0].GetDealerDraw() == nil || got[1].GetHandStart() == nil {
+		t.Fatalf("expected DealerDraw then HandStart, got %v", got)
+	}
+	draw, start := got[0].GetDealerDraw(), got[1].GetHandStart()
+	if len(draw.GetCards()) != 2 {
+		t.Fatalf("expected a card for each seat, got %v", draw.GetCards())
+	}
+	if draw.GetDealerChair() != start.GetDealerChair() {
+		t.Fatalf("draw gave the button to chair %d, hand started with chair %d", draw.GetDealerChair(), start.GetDealerChair())
+	}
+}
+
+func TestHighCardDraw_DisabledSendsNoDraw(t *testing.T) {
+	for _, env := range dealerDrawEnvelopes(t, false) {
+		if env.GetDealerDraw() != nil {
+			t.Fatalf("expected no dealer draw when disabled, got %v", env)
+		}
+	}
+}
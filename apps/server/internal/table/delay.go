@@ -0,0 +1,48 @@
+package table
+
+import (
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+// delayedMessage is a spectator message held back until due.
+type delayedMessage struct {
+	userID uint64
+	data   []byte
+	due    time.Time
+}
+
+// spectatorDelayedLocked reports whether messages to userID go on the
+// spectator tape instead of out right away.
+func (t *Table) spectatorDelayedLocked(userID uint64) bool {
+	if t.Config.SpectatorDelay <= 0 {
+		return false
+	}
+	p := t.players[userID]
+	return p != nil && p.Chair == holdem.InvalidChair
+}
+
+// releaseSpectatorTapeLocked sends every queued message that is due, or whose
+// user has since sat down, in queue order. Messages for users who left the
+// table are dropped, as they would be for a live broadcast.
+func (t *Table) releaseSpectatorTapeLocked(now time.Time) {
+	if len(t.spectatorTape) == 0 {
+		return
+	}
+	kept := t.spectatorTape[:0]
+	for _, m := range t.spectatorTape {
+		if t.players[m.userID] == nil {
+			continue
+		}
+		if now.Before(m.due) && t.spectatorDelayedLocked(m.userID) {
+			kept = append(kept, m)
+			continue
+		}
+		t.transmit(m.userID, m.data)
+	}
+	for i := len(kept); i < len(t.spectatorTape); i++ {
+		t.spectatorTape[i] = delayedMessage{}
+	}
+	t.spectatorTape = kept
+}
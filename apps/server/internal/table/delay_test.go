@@ -0,0 +1,67 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestSpectatorDelay_HoldsBackSpectatorBroadcasts(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.MaxPlayers = 2
+	cfg.SpectatorDelay = 3 * time.Second
+	clock := NewManualClock(time.Unix(1000, 0).UTC())
+	tbl, err := NewTableForTest(cfg, nil, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	const spectator = 3
+	// ActionResult arrival time by user and server seq.
+	got := make(map[uint64]map[uint64]time.Time)
+	tbl.broadcast = func(userID uint64, data []byte) {
+		env := decodeServerEnvelope(t, data)
+		if env.GetActionResult() == nil {
+			return
+		}
+		if got[userID] == nil {
+			got[userID] = make(map[uint64]time.Time)
+		}
+		got[userID][env.GetServerSeq()] = clock.Now()
+	}
+	for _, userID := range []uint64{1, 2, spectator} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if tbl.players[spectator].Chair != holdem.InvalidChair {
+		t.Fatalf("expected user %d to be watching", spectator)
+	}
+
+	actedAt := clock.Now()
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 0, 1)
+	if len(got[1]) != 1 || len(got[2]) != 1 {
+		t.Fatalf("expected seated players to see the call at once, got %v", got)
+	}
+	if len(got[spectator]) != 0 {
+		t.Fatalf("expected the spectator's copy to be held back")
+	}
+
+	tbl.AdvanceClock(2 * time.Second)
+	if len(got[spectator]) != 0 {
+		t.Fatalf("expected nothing for the spectator before the delay elapsed")
+	}
+	tbl.AdvanceClock(time.Second)
+	if len(got[spectator]) != 1 {
+		t.Fatalf("expected the spectator to get the call after the delay, got %v", got[spectator])
+	}
+	for seq, at := range got[spectator] {
+		live, ok := got[1][seq]
+		if !ok || !live.Equal(actedAt) {
+			t.Fatalf("expected seq %d live at %v, got %v", seq, actedAt, live)
+		}
+		if lag := at.Sub(live); lag != cfg.SpectatorDelay {
+			t.Fatalf("expected a %v lag, got %v", cfg.SpectatorDelay, lag)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package table
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// fairnessHistory bounds how many past hands keep their fairness proof.
+const fairnessHistory = 64
+
+// FairnessProof lets players check a hand was not dealt after the fact: the
+// commitment goes out with HandStart and the seed with HandEnd. Anyone can
+// then recompute SeedCommitment and rebuild the deck with holdem.ShuffledDeck.
+type FairnessProof struct {
+	Round      uint32
+	HandID     string
+	Commitment string
+	// Seed is only set once Revealed, i.e. after the hand settled.
+	Seed     int64
+	Revealed bool
+}
+
+// SeedCommitment is the hex sha256 of "<handID>:<seed>".
+func SeedCommitment(handID string, seed int64) string {
+	sum := sha256.Sum256([]byte(handID + ":" + strconv.FormatInt(seed, 10)))
+	return hex.EncodeToString(sum[:])
+}
+
+// FairnessProof returns the commitment/reveal pair for round. The seed of a
+// hand still in play is withheld.
+func (t *Table) FairnessProof(round uint32) (FairnessProof, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	p, ok := t.fairnessProofs[round]
+	if !ok {
+		return FairnessProof{}, false
+	}
+	if !p.Revealed {
+		p.Seed = 0
+	}
+	return p, true
+}
+
+// GameSeed returns the seed the table's game generator was created with, for
+// operators auditing a whole session.
+func (t *Table) GameSeed() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.game.Seed()
+}
+
+// commitHandSeedLocked records the commitment for the hand that just started.
+// Fixed test decks have no seed and get no proof.
+func (t *Table) commitHandSeedLocked() {
+	seed := t.game.HandSeed()
+	if seed == 0 || t.handID == "" {
+		return
+	}
+	if t.fairnessProofs == nil {
+		t.fairnessProofs = make(map[uint32]FairnessProof)
+	}
+	delete(t.fairnessProofs, t.round-fairnessHistory)
+	t.fairnessProofs[t.round] = FairnessProof{
+		Round:      t.round,
+		HandID:     t.handID,
+		Commitment: SeedCommitment(t.handID, seed),
+		Seed:       seed,
+	}
+}
+
+// revealHandSeedLocked marks the current hand's seed public and returns it.
+func (t *Table) revealHandSeedLocked() int64 {
+	p, ok := t.fairnessProofs[t.round]
+	if !ok {
+		return 0
+	}
+	p.Revealed = true
+	t.fairnessProofs[t.round] = p
+	return p.Seed
+}
@@ -0,0 +1,82 @@
+package table
+
+import (
+	"testing"
+
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+)
+
+func TestFairnessProof_RevealedSeedReproducesDeck(t *testing.T) {
+	cfg := harnessTestConfig()
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	// The harness pins the deck; deal this table from a real, unseeded shuffle.
+	tbl.game, err = holdem.NewGame(holdem.Config{
+		MaxPlayers: int(cfg.MaxPlayers),
+		MinPlayers: 2,
+		SmallBlind: cfg.SmallBlind,
+		BigBlind:   cfg.BigBlind,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	var commitment string
+	var revealed int64
+	tbl.broadcast = func(_ uint64, data []byte) {
+		env := decodeServerEnvelope(t, data)
+		if start := env.GetHandStart(); start != nil {
+			commitment = start.GetSeedCommitment()
+		}
+		if end := env.GetHandEnd(); end != nil {
+			revealed = end.GetDeckSeed()
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+
+	if commitment == "" {
+		t.Fatalf("expected a seed commitment in HandStart")
+	}
+	proof, ok := tbl.FairnessProof(1)
+	if !ok || proof.Commitment != commitment || proof.Revealed || proof.Seed != 0 {
+		t.Fatalf("expected an unrevealed proof matching HandStart, got %+v", proof)
+	}
+	snap := tbl.game.Snapshot()
+	dealt := make(map[uint16][]card.Card, len(snap.Players))
+	for _, ps := range snap.Players {
+		dealt[ps.Chair] = ps.HandCards
+	}
+	sbChair, bbChair := snap.SmallBlindChair, snap.BigBlindChair
+	handID := tbl.handID
+
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 1)
+
+	proof, ok = tbl.FairnessProof(1)
+	if !ok || !proof.Revealed || proof.Seed != revealed || revealed == 0 {
+		t.Fatalf("expected the HandEnd seed %d to be revealed, got %+v", revealed, proof)
+	}
+	if got := SeedCommitment(handID, revealed); got != commitment {
+		t.Fatalf("revealed seed does not match commitment: %s vs %s", got, commitment)
+	}
+	// Hole cards go out one at a time starting at the small blind.
+	deck := holdem.ShuffledDeck(revealed)
+	want := map[uint16][]card.Card{
+		sbChair: {deck[0], deck[2]},
+		bbChair: {deck[1], deck[3]},
+	}
+	for chair, cards := range want {
+		got := dealt[chair]
+		if len(got) != 2 || got[0] != cards[0] || got[1] != cards[1] {
+			t.Fatalf("chair %d: expected %v from the revealed seed, got %v", chair, cards, got)
+		}
+	}
+	if _, ok := tbl.FairnessProof(2); ok {
+		t.Fatalf("expected no proof for a hand not yet dealt")
+	}
+}
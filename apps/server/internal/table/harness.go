@@ -0,0 +1,126 @@
+package table
+
+import (
+	"fmt"
+	"time"
+
+	"holdem-lite/apps/server/internal/ledger"
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+	"holdem-lite/holdem/npc"
+)
+
+// NPCMode controls how NPC turns are driven.
+type NPCMode int
+
+const (
+	// NPCModeAsync decides NPC turns on a goroutine after the persona's think delay.
+	NPCModeAsync NPCMode = iota
+	// NPCModeStep queues the NPC turn until StepNPC is called.
+	NPCModeStep
+)
+
+// NewTableForTest builds a deterministic table for tests: no actor goroutine
+// or ticker (SubmitEvent runs on the caller's goroutine), every hand is dealt
+// from deck, time only moves through AdvanceClock, and NPC turns follow
+// npcMode. deck lists cards in deal order (hole cards round by round starting
+// at the small blind, then the board, no burns); the rest of the 52-card deck
+// is filled in canonical order.
+func NewTableForTest(cfg TableConfig, deck []card.Card, clock *ManualClock, npcMode NPCMode, npcMgr ...*npc.Manager) (*Table, error) {
+	fullDeck, err := completeDeck(deck)
+	if err != nil {
+		return nil, err
+	}
+	if clock == nil {
+		clock = NewManualClock(time.Unix(0, 0).UTC())
+	}
+	game, err := holdem.NewGame(holdem.Config{
+		MaxPlayers:      int(cfg.MaxPlayers),
+		MinPlayers:      2,
+		SmallBlind:      cfg.SmallBlind,
+		BigBlind:        cfg.BigBlind,
+		Ante:            cfg.Ante,
+		RakePercent:     cfg.RakePercent,
+		RakeCapBB:       cfg.RakeCapBB,
+		RakeOnlyAtFlop:  cfg.RakeOnlyAtFlop,
+		Seed:            1,
+		DeckOverride:    fullDeck,
+		DealerSelection: cfg.dealerSelection(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{
+		ID:                 "test_table",
+		Config:             cfg,
+		game:               game,
+		players:            make(map[uint64]*PlayerConn),
+		seats:              make(map[uint16]uint64),
+		handStartStacks:    make(map[uint16]int64),
+		done:               make(chan struct{}),
+		broadcast:          func(uint64, []byte) {},
+		clock:              clock,
+		actionTimeoutChair: holdem.InvalidChair,
+		emptySince:         clock.Now(),
+		userHandTape:       make(map[uint64][]ledger.EventItem),
+		pendingStandUps:    make(map[uint64]bool),
+		npcMode:            npcMode,
+		inline:             true,
+	}
+	if len(npcMgr) > 0 && npcMgr[0] != nil {
+		t.npcManager = npcMgr[0]
+	}
+	if cfg.MinBroadcastInterval > 0 {
+		t.pacer = newBroadcastPacer(cfg.MinBroadcastInterval, clock, func(userID uint64, data []byte) {
+			t.broadcast(userID, data)
+		}, t.done)
+	}
+	return t, nil
+}
+
+// AdvanceClock moves a test table's ManualClock forward by d and runs one
+// actor tick, firing any action timeout or scheduled hand start that is due.
+func (t *Table) AdvanceClock(d time.Duration) {
+	if c, ok := t.clock.(*ManualClock); ok {
+		c.Advance(d)
+	}
+	t.tick()
+}
+
+// StepNPC runs the queued NPC turn of an NPCModeStep table. It reports false
+// when no NPC is waiting to act.
+func (t *Table) StepNPC() (bool, error) {
+	t.mu.Lock()
+	step := t.pendingNPCStep
+	t.pendingNPCStep = nil
+	t.mu.Unlock()
+	if step == nil {
+		return false, nil
+	}
+	return true, t.SubmitEvent(step())
+}
+
+func completeDeck(prefix []card.Card) ([]card.Card, error) {
+	if len(prefix) == 0 {
+		return nil, nil
+	}
+	if len(prefix) > len(holdem.HoldemCards) {
+		return nil, fmt.Errorf("deck has %d cards, max %d", len(prefix), len(holdem.HoldemCards))
+	}
+	used := make(map[card.Card]bool, len(prefix))
+	deck := make([]card.Card, 0, len(holdem.HoldemCards))
+	for _, c := range prefix {
+		if used[c] {
+			return nil, fmt.Errorf("duplicate card %v in deck", c)
+		}
+		used[c] = true
+		deck = append(deck, c)
+	}
+	for _, c := range holdem.HoldemCards {
+		if !used[c] {
+			deck = append(deck, c)
+		}
+	}
+	return deck, nil
+}
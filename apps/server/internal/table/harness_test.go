@@ -0,0 +1,137 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func mustCards(t *testing.T, strs ...string) []card.Card {
+	t.Helper()
+
+	out := make([]card.Card, 0, len(strs))
+	for _, s := range strs {
+		c, err := card.ThdmStrToCard(s)
+		if err != nil {
+			t.Fatalf("ThdmStrToCard(%q) err: %v", s, err)
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func harnessTestConfig() TableConfig {
+	return TableConfig{
+		MaxPlayers: 6,
+		SmallBlind: 50,
+		BigBlind:   100,
+		MinBuyIn:   1000,
+		MaxBuyIn:   1000,
+	}
+}
+
+func TestNewTableForTest_ScriptedHeadsUpHandToShowdown(t *testing.T) {
+	// Heads-up deal order: small blind (button), big blind, small blind, big blind, board.
+	deck := mustCards(t, "As", "Kd", "Ah", "Kc", "2c", "7d", "9h", "3s", "4d")
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(harnessTestConfig(), deck, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+
+	var handEnds int
+	tbl.broadcast = func(_ uint64, data []byte) {
+		if env := decodeServerEnvelope(t, data); env.GetHandEnd() != nil {
+			handEnds++
+		}
+	}
+
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.game.Snapshot()
+	if snap.Round != 1 || snap.Phase != holdem.PhaseTypePreflop {
+		t.Fatalf("expected hand 1 preflop after two joins, got round=%d phase=%v", snap.Round, snap.Phase)
+	}
+	winner := snap.SmallBlindChair // dealt the aces
+
+	// Check or call down every street.
+	for steps := 0; !tbl.game.Snapshot().Ended; steps++ {
+		if steps > 20 {
+			t.Fatalf("hand did not reach showdown")
+		}
+		snap = tbl.game.Snapshot()
+		action := holdem.PlayerActionTypeCheck
+		if acts, _, _, _ := tbl.game.LegalActions(snap.ActionChair); !hasLegalAction(acts, holdem.PlayerActionTypeCheck) {
+			action = holdem.PlayerActionTypeCall
+		}
+		err := tbl.SubmitEvent(Event{
+			Type:   EventAction,
+			UserID: tbl.seats[snap.ActionChair],
+			Action: action,
+			Amount: snap.CurBet,
+		})
+		if err != nil {
+			t.Fatalf("action chair=%d err: %v", snap.ActionChair, err)
+		}
+	}
+
+	snap = tbl.game.Snapshot()
+	if len(snap.CommunityCards) != 5 {
+		t.Fatalf("expected full board at showdown, got %d cards", len(snap.CommunityCards))
+	}
+	for _, ps := range snap.Players {
+		want := int64(900)
+		if ps.Chair == winner {
+			want = 1100
+		}
+		if ps.Stack != want {
+			t.Fatalf("chair %d: expected stack %d, got %d", ps.Chair, want, ps.Stack)
+		}
+	}
+	if handEnds != 2 {
+		t.Fatalf("expected handEnd broadcast to both players, got %d", handEnds)
+	}
+
+	// The next hand starts only once the showdown delay has elapsed.
+	tbl.AdvanceClock(showdownHandDelay - time.Second)
+	if got := tbl.game.Snapshot().Round; got != 1 {
+		t.Fatalf("expected next hand to wait for the showdown delay, got round %d", got)
+	}
+	tbl.AdvanceClock(time.Second)
+	if got := tbl.game.Snapshot().Round; got != 2 {
+		t.Fatalf("expected hand 2 after the showdown delay, got round %d", got)
+	}
+}
+
+func TestNewTableForTest_RejectsDuplicateDeckCard(t *testing.T) {
+	if _, err := NewTableForTest(harnessTestConfig(), mustCards(t, "As", "As"), nil, NPCModeStep); err == nil {
+		t.Fatalf("expected duplicate deck card to be rejected")
+	}
+}
+
+func decodeServerEnvelope(t *testing.T, data []byte) *pb.ServerEnvelope {
+	t.Helper()
+
+	var env pb.ServerEnvelope
+	if err := proto.Unmarshal(data, &env); err != nil {
+		t.Fatalf("unmarshal server envelope err: %v", err)
+	}
+	return &env
+}
+
+func hasLegalAction(acts []holdem.ActionType, want holdem.ActionType) bool {
+	for _, a := range acts {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package table
+
+import "log"
+
+// holdHeadsUpForOfflineLocked reports whether HeadsUpDisconnectPause should
+// keep the next hand from starting.
+func (t *Table) holdHeadsUpForOfflineLocked() bool {
+	hold := false
+	var offline uint64
+	if t.Config.HeadsUpDisconnectPause && len(t.seats) == 2 {
+		for _, userID := range t.seats {
+			if player := t.players[userID]; player != nil && !player.Online {
+				hold = true
+				offline = userID
+				break
+			}
+		}
+	}
+	if hold && !t.headsUpHeld {
+		log.Printf("[Table %s] Heads-up: holding next hand while user %d is offline", t.ID, offline)
+	}
+	t.headsUpHeld = hold
+	return hold
+}
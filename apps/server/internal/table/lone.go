@@ -0,0 +1,106 @@
+package table
+
+import (
+	"log"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// LonePlayerPolicy decides what a table does when a single human has sat
+// without opponents for TableConfig.LonePlayerGrace.
+type LonePlayerPolicy uint8
+
+const (
+	// LonePlayerRefill runs the lone-player hook (e.g. lobby NPC refill) and
+	// deals as soon as an opponent is seated. It retries every grace period.
+	LonePlayerRefill LonePlayerPolicy = iota
+	// LonePlayerClose tells the player to return to the lobby and closes the table.
+	LonePlayerClose
+)
+
+// LonePlayerHook is called off the actor goroutine when a refill is needed.
+type LonePlayerHook func(t *Table)
+
+// SetLonePlayerHook registers the refill callback used by LonePlayerRefill.
+func (t *Table) SetLonePlayerHook(hook LonePlayerHook) {
+	t.mu.Lock()
+	t.lonePlayerHook = hook
+	t.mu.Unlock()
+}
+
+// checkLonePlayerLocked runs from tick. It tracks how long exactly one human
+// has been seated between hands and applies the configured policy once the
+// grace period has passed.
+func (t *Table) checkLonePlayerLocked(now time.Time) {
+	if t.loneRefillPending && len(t.seats) >= 2 {
+		t.loneRefillPending = false
+		t.loneSince = time.Time{}
+		if err := t.tryStartHand(now); err != nil {
+			log.Printf("[Table %s] hand start after lone-player refill failed: %v", t.ID, err)
+		}
+		return
+	}
+
+	userID, lone := t.lonePlayerLocked()
+	if !lone || t.Config.LonePlayerGrace <= 0 {
+		t.loneSince = time.Time{}
+		return
+	}
+	if t.loneSince.IsZero() {
+		t.loneSince = now
+		return
+	}
+	if now.Sub(t.loneSince) < t.Config.LonePlayerGrace {
+		return
+	}
+	t.loneSince = now
+
+	switch t.Config.LonePlayerPolicy {
+	case LonePlayerClose:
+		log.Printf("[Table %s] Closing: user %d had no opponents for %s", t.ID, userID, t.Config.LonePlayerGrace)
+		t.sendToUser(userID, &pb.ServerEnvelope{
+			TableId:    t.ID,
+			ServerSeq:  t.nextSeq(),
+			ServerTsMs: now.UnixMilli(),
+			Payload: &pb.ServerEnvelope_Error{
+				Error: &pb.ErrorResponse{Code: 3, Message: "table closed: no opponents, returning to lobby"},
+			},
+		})
+		t.stopLocked()
+	default:
+		hook := t.lonePlayerHook
+		if hook == nil {
+			return
+		}
+		t.loneRefillPending = true
+		log.Printf("[Table %s] Requesting refill for lone user %d", t.ID, userID)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[Table %s] lone player hook panic: %v", t.ID, r)
+				}
+			}()
+			hook(t)
+		}()
+	}
+}
+
+// lonePlayerLocked reports the only seated player when that player is human
+// and no hand is running.
+func (t *Table) lonePlayerLocked() (uint64, bool) {
+	if len(t.seats) != 1 {
+		return 0, false
+	}
+	snap := t.game.Snapshot()
+	if snap.Round > 0 && !snap.Ended && snap.Phase != holdem.PhaseTypeRoundEnd {
+		return 0, false
+	}
+	for _, userID := range t.seats {
+		if userID != 0 && !t.isNPC(userID) {
+			return userID, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,80 @@
+package table
+
+import (
+	"testing"
+	"time"
+)
+
+func newLonePlayerTestTable(t *testing.T, policy LonePlayerPolicy) (*Table, *ManualClock) {
+	t.Helper()
+
+	cfg := harnessTestConfig()
+	cfg.LonePlayerGrace = 10 * time.Second
+	cfg.LonePlayerPolicy = policy
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(cfg, nil, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 1}); err != nil {
+		t.Fatalf("join err: %v", err)
+	}
+	if len(tbl.seats) != 1 {
+		t.Fatalf("expected one seated player, got %d", len(tbl.seats))
+	}
+	return tbl, clock
+}
+
+func TestLonePlayer_RefillSeatsOpponentAndDeals(t *testing.T) {
+	tbl, _ := newLonePlayerTestTable(t, LonePlayerRefill)
+
+	refilled := make(chan struct{}, 1)
+	tbl.SetLonePlayerHook(func(lone *Table) {
+		if err := lone.SubmitEvent(Event{Type: EventJoinTable, UserID: 2}); err != nil {
+			t.Errorf("refill join err: %v", err)
+		}
+		refilled <- struct{}{}
+	})
+
+	tbl.AdvanceClock(time.Second) // starts the grace period
+	tbl.AdvanceClock(9 * time.Second)
+	select {
+	case <-refilled:
+		t.Fatalf("refill fired before the grace period elapsed")
+	default:
+	}
+	tbl.AdvanceClock(time.Second)
+	select {
+	case <-refilled:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected lone-player refill after the grace period")
+	}
+
+	tbl.AdvanceClock(500 * time.Millisecond)
+	if snap := tbl.game.Snapshot(); snap.Round != 1 || snap.Ended {
+		t.Fatalf("expected a hand to be dealt after refill, got round=%d ended=%v", snap.Round, snap.Ended)
+	}
+	if tbl.IsClosed() {
+		t.Fatalf("refill policy must not close the table")
+	}
+}
+
+func TestLonePlayer_CloseSendsPlayerBackToLobby(t *testing.T) {
+	tbl, _ := newLonePlayerTestTable(t, LonePlayerClose)
+
+	var closeCode int32
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if env := decodeServerEnvelope(t, data); userID == 1 && env.GetError() != nil {
+			closeCode = env.GetError().GetCode()
+		}
+	}
+
+	tbl.AdvanceClock(time.Second)
+	tbl.AdvanceClock(10 * time.Second)
+	if !tbl.IsClosed() {
+		t.Fatalf("expected lone-player table to close after the grace period")
+	}
+	if closeCode != 3 {
+		t.Fatalf("expected not-in-table error code 3 for the lone player, got %d", closeCode)
+	}
+}
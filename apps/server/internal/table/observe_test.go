@@ -0,0 +1,92 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestObserve_SeesPublicEventsButNoHoleCards(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.MaxPlayers = 2
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	const observer = 9
+	var boards, actions int
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if userID != observer {
+			return
+		}
+		env := decodeServerEnvelope(t, data)
+		if env.GetDealHoleCards() != nil {
+			t.Fatalf("observer was dealt hole cards: %v", env.GetDealHoleCards())
+		}
+		if ts := env.GetTableSnapshot(); ts != nil {
+			for _, p := range ts.GetPlayers() {
+				if len(p.GetHandCards()) > 0 {
+					t.Fatalf("observer saw user %d's hole cards", p.GetUserId())
+				}
+			}
+		}
+		if env.GetDealBoard() != nil {
+			boards++
+		}
+		if env.GetActionResult() != nil {
+			actions++
+		}
+	}
+
+	// Observing a table with free chairs still leaves them free.
+	if err := tbl.SubmitEvent(Event{Type: EventObserve, UserID: observer}); err != nil {
+		t.Fatalf("observe err: %v", err)
+	}
+	if p := tbl.players[observer]; p == nil || p.Chair != holdem.InvalidChair {
+		t.Fatalf("expected the observer at the table without a chair, got %+v", p)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if got := tbl.game.Snapshot().Round; got != 1 {
+		t.Fatalf("expected both players seated and a hand dealt, got round %d", got)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventAction, UserID: observer, Action: holdem.PlayerActionTypeFold}); err == nil {
+		t.Fatalf("expected the observer not to be able to act")
+	}
+
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 0, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, 2)
+	if err := tbl.SubmitEvent(Event{Type: EventRequestSnapshot, UserID: observer}); err != nil {
+		t.Fatalf("observer snapshot err: %v", err)
+	}
+	if boards != 1 || actions != 2 {
+		t.Fatalf("expected the observer to see the flop and both actions, got %d boards %d actions", boards, actions)
+	}
+	if got := tbl.SpectatorCount(); got != 1 {
+		t.Fatalf("expected one spectator, got %d", got)
+	}
+}
+
+func TestObserve_DoesNotKeepTableFromIdling(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 1}); err != nil {
+		t.Fatalf("join err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventObserve, UserID: 9}); err != nil {
+		t.Fatalf("observe err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventStandUp, UserID: 1}); err != nil {
+		t.Fatalf("stand up err: %v", err)
+	}
+	tbl.AdvanceClock(time.Minute)
+	if !tbl.IsIdleFor(time.Minute) {
+		t.Fatalf("expected a table with only an observer to count as idle")
+	}
+}
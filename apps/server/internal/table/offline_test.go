@@ -0,0 +1,150 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestOfflineSeat_HeldUntilHandEndsAndGraceElapses(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.OfflineSeatGrace = 2 * time.Second
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	snap := tbl.game.Snapshot()
+	actor := tbl.seats[snap.ActionChair]
+	offline := uint64(1)
+	if actor == offline {
+		offline = 2
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: offline}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+
+	// Well past the grace, but the player is still live in the hand.
+	tbl.AdvanceClock(5 * time.Second)
+	if tbl.players[offline].Chair == holdem.InvalidChair {
+		t.Fatalf("expected offline player to keep their seat during the hand")
+	}
+
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	if !tbl.game.Snapshot().Ended {
+		t.Fatalf("expected the fold to end the hand")
+	}
+	tbl.AdvanceClock(time.Second)
+	if tbl.players[offline].Chair == holdem.InvalidChair {
+		t.Fatalf("expected the grace to restart when the hand ended")
+	}
+	tbl.AdvanceClock(time.Second)
+	if chair := tbl.players[offline].Chair; chair != holdem.InvalidChair {
+		t.Fatalf("expected seat to be released once the grace elapsed, still at chair %d", chair)
+	}
+	if tbl.players[actor].Chair == holdem.InvalidChair {
+		t.Fatalf("expected the online player to stay seated")
+	}
+}
+
+func TestOfflineSeat_ReleasedBetweenHandsAfterGrace(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.OfflineSeatGrace = 10 * time.Second
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 1}); err != nil {
+		t.Fatalf("join err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: 1}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	tbl.AdvanceClock(9 * time.Second)
+	if tbl.players[1].Chair == holdem.InvalidChair {
+		t.Fatalf("expected seat to be kept within the configured grace")
+	}
+	tbl.AdvanceClock(time.Second)
+	if tbl.players[1].Chair != holdem.InvalidChair {
+		t.Fatalf("expected seat to be released after the configured grace")
+	}
+}
+
+func TestHeadsUpDisconnectPause_HoldsHandsUntilReconnect(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.OfflineSeatGrace = 30 * time.Second
+	cfg.HeadsUpDisconnectPause = true
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actor := tbl.seats[tbl.game.Snapshot().ActionChair]
+	offline := uint64(1)
+	if actor == offline {
+		offline = 2
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: offline}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	round := tbl.game.Snapshot().Round
+
+	tbl.AdvanceClock(10 * time.Second)
+	if snap := tbl.game.Snapshot(); snap.Round != round || !snap.Ended {
+		t.Fatalf("expected no new hand while heads-up opponent is offline, round %d -> %d", round, snap.Round)
+	}
+
+	if err := tbl.SubmitEvent(Event{Type: EventConnResume, UserID: offline}); err != nil {
+		t.Fatalf("conn resume err: %v", err)
+	}
+	if snap := tbl.game.Snapshot(); snap.Round != round+1 || snap.Ended {
+		t.Fatalf("expected reconnect to deal the next hand, round %d -> %d", round, snap.Round)
+	}
+}
+
+func TestHeadsUpDisconnectPause_GraceExpiryStandsUpAbsentPlayer(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.OfflineSeatGrace = 5 * time.Second
+	cfg.HeadsUpDisconnectPause = true
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actor := tbl.seats[tbl.game.Snapshot().ActionChair]
+	offline := uint64(1)
+	if actor == offline {
+		offline = 2
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: offline}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	round := tbl.game.Snapshot().Round
+
+	tbl.AdvanceClock(4 * time.Second)
+	if tbl.players[offline].Chair == holdem.InvalidChair || tbl.game.Snapshot().Round != round {
+		t.Fatalf("expected seat held and no new hand within the grace")
+	}
+	tbl.AdvanceClock(time.Second)
+	if tbl.players[offline].Chair != holdem.InvalidChair {
+		t.Fatalf("expected absent player to be stood up once the grace elapsed")
+	}
+	if tbl.game.Snapshot().Round != round {
+		t.Fatalf("expected no hand to be dealt to the absent player")
+	}
+}
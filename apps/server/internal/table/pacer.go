@@ -0,0 +1,88 @@
+package table
+
+import (
+	"sync"
+	"time"
+)
+
+// broadcastPacer releases messages to each user no faster than one per
+// interval, in the order they were queued. A message to an idle user goes out
+// immediately; a burst is drained by one goroutine per user.
+type broadcastPacer struct {
+	interval time.Duration
+	clock    Clock
+	send     func(userID uint64, data []byte)
+	done     <-chan struct{}
+
+	mu     sync.Mutex
+	queues map[uint64]*pacedQueue
+}
+
+type pacedQueue struct {
+	pending  [][]byte
+	lastSent time.Time
+	draining bool
+}
+
+func newBroadcastPacer(interval time.Duration, clock Clock, send func(userID uint64, data []byte), done <-chan struct{}) *broadcastPacer {
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &broadcastPacer{
+		interval: interval,
+		clock:    clock,
+		send:     send,
+		done:     done,
+		queues:   make(map[uint64]*pacedQueue),
+	}
+}
+
+func (p *broadcastPacer) enqueue(userID uint64, data []byte) {
+	p.mu.Lock()
+	q := p.queues[userID]
+	if q == nil {
+		q = &pacedQueue{}
+		p.queues[userID] = q
+	}
+	now := p.clock.Now()
+	if !q.draining && len(q.pending) == 0 && (q.lastSent.IsZero() || now.Sub(q.lastSent) >= p.interval) {
+		q.lastSent = now
+		p.mu.Unlock()
+		p.send(userID, data)
+		return
+	}
+	q.pending = append(q.pending, data)
+	if !q.draining {
+		q.draining = true
+		go p.drain(userID, q)
+	}
+	p.mu.Unlock()
+}
+
+func (p *broadcastPacer) drain(userID uint64, q *pacedQueue) {
+	for {
+		p.mu.Lock()
+		if len(q.pending) == 0 {
+			q.draining = false
+			p.mu.Unlock()
+			return
+		}
+		wait := q.lastSent.Add(p.interval).Sub(p.clock.Now())
+		p.mu.Unlock()
+
+		if wait > 0 {
+			select {
+			case <-p.clock.After(wait):
+			case <-p.done:
+				return
+			}
+		}
+
+		p.mu.Lock()
+		data := q.pending[0]
+		q.pending = q.pending[1:]
+		q.lastSent = p.clock.Now()
+		p.mu.Unlock()
+		p.send(userID, data)
+	}
+}
@@ -0,0 +1,96 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type pacedDelivery struct {
+	at  time.Time
+	seq uint64
+}
+
+func TestBroadcastPacer_BurstReleasedAtMinIntervalInOrder(t *testing.T) {
+	const interval = 400 * time.Millisecond
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	done := make(chan struct{})
+	defer close(done)
+
+	delivered := make(chan pacedDelivery, 16)
+	pacer := newBroadcastPacer(interval, clock, func(_ uint64, data []byte) {
+		var env pb.ServerEnvelope
+		if err := proto.Unmarshal(data, &env); err != nil {
+			t.Errorf("unmarshal err: %v", err)
+			return
+		}
+		delivered <- pacedDelivery{at: clock.Now(), seq: env.GetServerSeq()}
+	}, done)
+
+	// A burst of back-to-back NPC action results for one viewer.
+	const burst = 5
+	for seq := uint64(1); seq <= burst; seq++ {
+		data, err := proto.Marshal(&pb.ServerEnvelope{
+			ServerSeq: seq,
+			Payload:   &pb.ServerEnvelope_ActionResult{ActionResult: &pb.ActionResult{Chair: uint32(seq)}},
+		})
+		if err != nil {
+			t.Fatalf("marshal err: %v", err)
+		}
+		pacer.enqueue(7, data)
+	}
+
+	var got []pacedDelivery
+	receive := func() {
+		t.Helper()
+		select {
+		case d := <-delivered:
+			got = append(got, d)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for delivery %d", len(got)+1)
+		}
+	}
+
+	receive() // the first message is not delayed
+	for len(got) < burst {
+		clock.BlockUntil(1)
+		clock.Advance(interval - time.Millisecond)
+		select {
+		case d := <-delivered:
+			t.Fatalf("seq %d released before the minimum interval", d.seq)
+		default:
+		}
+		clock.Advance(time.Millisecond)
+		receive()
+	}
+
+	for i, d := range got {
+		if d.seq != uint64(i+1) {
+			t.Fatalf("delivery %d: expected seq %d, got %d", i, i+1, d.seq)
+		}
+		if i > 0 {
+			if gap := d.at.Sub(got[i-1].at); gap < interval {
+				t.Fatalf("delivery %d: gap %v shorter than %v", i, gap, interval)
+			}
+		}
+	}
+}
+
+func TestBroadcastPacer_IdleUserSendsImmediately(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	done := make(chan struct{})
+	defer close(done)
+
+	var sent int
+	pacer := newBroadcastPacer(time.Second, clock, func(uint64, []byte) { sent++ }, done)
+	pacer.enqueue(1, []byte{1})
+	pacer.enqueue(2, []byte{2}) // other users are paced independently
+	clock.Advance(time.Second)
+	pacer.enqueue(1, []byte{3})
+	if sent != 3 {
+		t.Fatalf("expected 3 immediate sends, got %d", sent)
+	}
+}
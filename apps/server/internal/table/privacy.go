@@ -0,0 +1,106 @@
+package table
+
+import (
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// SnapshotPrivacy selects per-player fields that table snapshots, action
+// results and pot broadcasts withhold from other players while a hand is live. They are revealed
+// at showdown and once the hand ends. A player always sees their own fields.
+type SnapshotPrivacy uint8
+
+const (
+	// PrivacyHideFolded hides fold status: folded players still show as in
+	// the hand and pot eligibility is withheld.
+	PrivacyHideFolded SnapshotPrivacy = 1 << iota
+	// PrivacyHideAllIn hides all-in status, and with it other players'
+	// stacks, since an empty stack would give it away.
+	PrivacyHideAllIn
+)
+
+func (p SnapshotPrivacy) has(flag SnapshotPrivacy) bool {
+	return p&flag != 0
+}
+
+// privacyLive reports whether the table's privacy settings apply to snap.
+func (t *Table) privacyLive(snap holdem.Snapshot) bool {
+	return t.Config.SnapshotPrivacy != 0 && !snap.Ended &&
+		snap.Phase != holdem.PhaseTypeShowdown && snap.Phase != holdem.PhaseTypeRoundEnd
+}
+
+// applySnapshotPrivacy withholds the configured fields of ts for userID.
+func (t *Table) applySnapshotPrivacy(ts *pb.TableSnapshot, snap holdem.Snapshot, userID uint64) {
+	if !t.privacyLive(snap) {
+		return
+	}
+	privacy := t.Config.SnapshotPrivacy
+	for _, p := range ts.Players {
+		if p.UserId == userID {
+			continue
+		}
+		hidden := false
+		if privacy.has(PrivacyHideFolded) && p.Folded {
+			p.Folded = false
+			p.LastAction = pb.ActionType_ACTION_UNSPECIFIED
+			hidden = true
+		}
+		if privacy.has(PrivacyHideAllIn) {
+			p.Stack = 0
+			if p.AllIn {
+				p.AllIn = false
+				if p.LastAction == pb.ActionType_ACTION_ALLIN {
+					p.LastAction = pb.ActionType_ACTION_UNSPECIFIED
+				}
+				hidden = true
+			}
+		}
+		// A zero call amount would single the seat out.
+		if hidden {
+			p.ToCall = callAmountFor(snap.CurBet, p.Bet)
+		}
+	}
+	if privacy.has(PrivacyHideFolded) {
+		for _, pot := range ts.Pots {
+			pot.EligibleChairs = nil
+		}
+	}
+}
+
+// hidesPotEligibility reports whether pots broadcast while snap is live must
+// leave out their eligible chairs: the engine drops folded players from them.
+func (t *Table) hidesPotEligibility(snap holdem.Snapshot) bool {
+	return t.privacyLive(snap) && t.Config.SnapshotPrivacy.has(PrivacyHideFolded)
+}
+
+// withholdPotEligibility copies pots with only their amounts.
+func withholdPotEligibility(pots []*pb.Pot) []*pb.Pot {
+	hidden := make([]*pb.Pot, 0, len(pots))
+	for _, pot := range pots {
+		hidden = append(hidden, &pb.Pot{Amount: pot.Amount})
+	}
+	return hidden
+}
+
+// applyActionResultPrivacy withholds from ar what applySnapshotPrivacy
+// withholds from snapshots. before is the table as the action was taken: a
+// hidden all-in shows as the call, bet or raise it amounted to.
+func (t *Table) applyActionResultPrivacy(ar *pb.ActionResult, before holdem.Snapshot) {
+	privacy := t.Config.SnapshotPrivacy
+	switch {
+	case privacy.has(PrivacyHideFolded) && ar.Action == pb.ActionType_ACTION_FOLD:
+		ar.Action = pb.ActionType_ACTION_UNSPECIFIED
+	case privacy.has(PrivacyHideAllIn) && ar.Action == pb.ActionType_ACTION_ALLIN:
+		switch {
+		case ar.Amount <= before.CurBet:
+			ar.Action = pb.ActionType_ACTION_CALL
+		case before.CurBet == 0:
+			ar.Action = pb.ActionType_ACTION_BET
+		default:
+			ar.Action = pb.ActionType_ACTION_RAISE
+		}
+	}
+	if privacy.has(PrivacyHideAllIn) {
+		ar.NewStack = 0
+	}
+}
@@ -0,0 +1,209 @@
+package table
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+func newPrivacyTestTable(t *testing.T, privacy SnapshotPrivacy) *Table {
+	t.Helper()
+
+	cfg := harnessTestConfig()
+	cfg.SnapshotPrivacy = privacy
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	// Pause while seating so the first hand is dealt three-handed.
+	if err := tbl.SubmitEvent(Event{Type: EventPause, UserID: 1}); err != nil {
+		t.Fatalf("pause err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2, 3} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventResume, UserID: 1}); err != nil {
+		t.Fatalf("resume err: %v", err)
+	}
+	return tbl
+}
+
+func snapshotPlayer(ts *pb.TableSnapshot, userID uint64) *pb.PlayerState {
+	for _, p := range ts.GetPlayers() {
+		if p.GetUserId() == userID {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestSnapshotPrivacy_HidesFoldAndAllInUntilShowdown(t *testing.T) {
+	tbl := newPrivacyTestTable(t, PrivacyHideFolded|PrivacyHideAllIn)
+
+	folder := tbl.seats[tbl.game.Snapshot().ActionChair]
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	snap := tbl.game.Snapshot()
+	shover := tbl.seats[snap.ActionChair]
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			actOnTable(t, tbl, holdem.PlayerActionTypeAllin, ps.Stack+ps.Bet, 1)
+		}
+	}
+	var observer uint64
+	for _, userID := range []uint64{1, 2, 3} {
+		if userID != folder && userID != shover {
+			observer = userID
+		}
+	}
+
+	ts := tbl.buildTableSnapshotForUser(observer)
+	if p := snapshotPlayer(ts, folder); p.GetFolded() || p.GetLastAction() == pb.ActionType_ACTION_FOLD || !p.GetHasCards() {
+		t.Fatalf("expected fold to be withheld from user %d, got %v", observer, p)
+	}
+	if p := snapshotPlayer(ts, shover); p.GetAllIn() || p.GetLastAction() == pb.ActionType_ACTION_ALLIN {
+		t.Fatalf("expected all-in to be withheld from user %d, got %v", observer, p)
+	}
+	for _, pot := range ts.GetPots() {
+		if len(pot.GetEligibleChairs()) != 0 {
+			t.Fatalf("expected pot eligibility to be withheld, got %v", pot.GetEligibleChairs())
+		}
+	}
+	if p := snapshotPlayer(tbl.buildTableSnapshotForUser(folder), folder); !p.GetFolded() {
+		t.Fatalf("expected folder to see their own fold")
+	}
+
+	// Equal stacks, so calling the shove is an all-in too.
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, tbl.game.Snapshot().CurBet, 2)
+	if !tbl.game.Snapshot().Ended {
+		t.Fatalf("expected the hand to run out after the call")
+	}
+	ts = tbl.buildTableSnapshotForUser(observer)
+	if p := snapshotPlayer(ts, folder); !p.GetFolded() {
+		t.Fatalf("expected fold to be revealed after showdown, got %v", p)
+	}
+	if p := snapshotPlayer(ts, shover); !p.GetAllIn() {
+		t.Fatalf("expected all-in to be revealed after showdown, got %v", p)
+	}
+}
+
+func TestSnapshotPrivacy_FiltersActionResults(t *testing.T) {
+	tbl := newPrivacyTestTable(t, PrivacyHideFolded|PrivacyHideAllIn)
+	seen := make(map[uint64][]*pb.ActionResult)
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if ar := decodeServerEnvelope(t, data).GetActionResult(); ar != nil {
+			seen[userID] = append(seen[userID], ar)
+		}
+	}
+
+	folder := tbl.seats[tbl.game.Snapshot().ActionChair]
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	snap := tbl.game.Snapshot()
+	shover := tbl.seats[snap.ActionChair]
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			actOnTable(t, tbl, holdem.PlayerActionTypeAllin, ps.Stack+ps.Bet, 1)
+		}
+	}
+	var observer uint64
+	for _, userID := range []uint64{1, 2, 3} {
+		if userID != folder && userID != shover {
+			observer = userID
+		}
+	}
+
+	got := seen[observer]
+	if len(got) != 2 {
+		t.Fatalf("expected two action results for user %d, got %v", observer, got)
+	}
+	if got[0].GetAction() != pb.ActionType_ACTION_UNSPECIFIED {
+		t.Fatalf("expected the fold to be withheld, got %v", got[0])
+	}
+	if got[1].GetAction() != pb.ActionType_ACTION_RAISE || got[1].GetNewStack() != 0 || got[1].GetAmount() == 0 {
+		t.Fatalf("expected the shove to show as a raise without a stack, got %v", got[1])
+	}
+	if own := seen[folder]; len(own) != 2 || own[0].GetAction() != pb.ActionType_ACTION_FOLD {
+		t.Fatalf("expected the folder to see their own fold, got %v", own)
+	}
+	if own := seen[shover]; len(own) != 2 || own[1].GetAction() != pb.ActionType_ACTION_ALLIN {
+		t.Fatalf("expected the shover to see their own all-in, got %v", own)
+	}
+	ts := tbl.buildTableSnapshotForUser(observer)
+	if p := snapshotPlayer(ts, shover); p.GetStack() != 0 || p.GetToCall() != 0 {
+		t.Fatalf("expected the shover's stack to be withheld, got %v", p)
+	}
+	if p := snapshotPlayer(ts, folder); p.GetToCall() == 0 {
+		t.Fatalf("expected the folder to show a call amount like a live seat, got %v", p)
+	}
+}
+
+func TestSnapshotPrivacy_WithholdsPotEligibility(t *testing.T) {
+	tbl := newPrivacyTestTable(t, PrivacyHideFolded)
+	var updates, phases int
+	tbl.broadcast = func(userID uint64, data []byte) {
+		env := decodeServerEnvelope(t, data)
+		var pots []*pb.Pot
+		switch {
+		case env.GetPotUpdate() != nil:
+			updates++
+			pots = env.GetPotUpdate().GetPots()
+		case env.GetPhaseChange() != nil:
+			phases++
+			pots = env.GetPhaseChange().GetPots()
+		default:
+			return
+		}
+		for _, pot := range pots {
+			if pot.GetAmount() == 0 || len(pot.GetEligibleChairs()) != 0 {
+				t.Fatalf("user %d: expected pot amounts without eligibility, got %v", userID, pot)
+			}
+		}
+	}
+
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, 2)
+	if updates == 0 || phases == 0 {
+		t.Fatalf("expected the flop to broadcast pots, got %d pot updates %d phase changes", updates, phases)
+	}
+}
+
+func TestSnapshotPrivacy_DefaultShowsEverything(t *testing.T) {
+	tbl := newPrivacyTestTable(t, 0)
+
+	folder := tbl.seats[tbl.game.Snapshot().ActionChair]
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 0)
+	for _, userID := range []uint64{1, 2, 3} {
+		if p := snapshotPlayer(tbl.buildTableSnapshotForUser(userID), folder); !p.GetFolded() {
+			t.Fatalf("expected user %d to see the fold by default", userID)
+		}
+	}
+}
+
+func TestSnapshot_ToCallForViewerFacingBet(t *testing.T) {
+	tbl := newPrivacyTestTable(t, 0)
+
+	raiser := tbl.seats[tbl.game.Snapshot().ActionChair]
+	actOnTable(t, tbl, holdem.PlayerActionTypeRaise, 300, 0)
+	snap := tbl.game.Snapshot()
+	viewer := tbl.seats[snap.ActionChair]
+	var viewerBet int64
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			viewerBet = ps.Bet
+		}
+	}
+
+	ts := tbl.buildTableSnapshotForUser(viewer)
+	if got, want := snapshotPlayer(ts, viewer).GetToCall(), 300-viewerBet; got != want {
+		t.Fatalf("expected viewer to_call=%d, got %d", want, got)
+	}
+	if got := snapshotPlayer(ts, raiser).GetToCall(); got != 0 {
+		t.Fatalf("expected raiser to owe nothing, got %d", got)
+	}
+	if viewerBet == 0 {
+		t.Fatalf("expected the next seat to be a blind with chips already in")
+	}
+}
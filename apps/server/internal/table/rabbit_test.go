@@ -0,0 +1,52 @@
+package table
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// rabbitHunts plays a scripted heads-up hand the button folds preflop and
+// returns the rabbit hunts each user received.
+func rabbitHunts(t *testing.T, enabled bool) map[uint64][]*pb.RabbitHunt {
+	t.Helper()
+	cfg := harnessTestConfig()
+	cfg.RabbitHunt = enabled
+	deck := mustCards(t, "As", "Kd", "Ah", "Kc", "2c", "7d", "9h", "3s", "4d")
+	tbl, err := NewTableForTest(cfg, deck, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	got := make(map[uint64][]*pb.RabbitHunt)
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if rh := decodeServerEnvelope(t, data).GetRabbitHunt(); rh != nil {
+			got[userID] = append(got[userID], rh)
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 1)
+	if !tbl.game.Snapshot().Ended {
+		t.Fatal("expected the fold to end the hand")
+	}
+	return got
+}
+
+func TestRabbitHunt_RevealsUndealtBoardAfterFold(t *testing.T) {
+	want := &pb.RabbitHunt{Cards: cardsToProto(mustCards(t, "2c", "7d", "9h", "3s", "4d"))}
+	got := rabbitHunts(t, true)
+	for _, userID := range []uint64{1, 2} {
+		if len(got[userID]) != 1 || !proto.Equal(got[userID][0], want) {
+			t.Fatalf("user %d: expected one rabbit hunt %v, got %v", userID, want, got[userID])
+		}
+	}
+	if got := rabbitHunts(t, false); len(got) != 0 {
+		t.Fatalf("expected no rabbit hunt when disabled, got %v", got)
+	}
+}
@@ -0,0 +1,153 @@
+package table
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+)
+
+// runItTwiceOffer is an all-in hand whose runout waits on its players'
+// answers (see TableConfig.RunItTwiceOfferTimeout).
+type runItTwiceOffer struct {
+	before, after holdem.Snapshot
+	result        *holdem.SettlementResult
+	due           time.Time
+	// pending are the users yet to answer.
+	pending map[uint64]bool
+}
+
+// handleRunItTwice records that a player agrees to run the current hand's
+// board twice. It only takes effect if the hand runs out all-in and every
+// player left in it agreed.
+func (t *Table) handleRunItTwice(userID uint64) error {
+	if !t.Config.AllowRunItTwice {
+		return fmt.Errorf("run it twice not allowed at this table")
+	}
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return fmt.Errorf("player not seated")
+	}
+	snap := t.game.Snapshot()
+	if snap.Round == 0 || snap.Ended || !liveInHand(snap, player.Chair) {
+		return fmt.Errorf("not in a live hand")
+	}
+	if t.runItTwiceUsers == nil {
+		t.runItTwiceUsers = make(map[uint64]bool)
+	}
+	t.runItTwiceUsers[userID] = true
+	log.Printf("[Table %s] User %d agreed to run it twice this hand", t.ID, userID)
+	return nil
+}
+
+// applyRunItTwiceLocked re-settles result over two boards when betting closed
+// before the river, board being the community cards at that point, and
+// every hand at showdown agreed. Otherwise result is returned unchanged.
+func (t *Table) applyRunItTwiceLocked(board []card.Card, result *holdem.SettlementResult) *holdem.SettlementResult {
+	users := t.runItTwiceUsers
+	t.runItTwiceUsers = nil
+	if !t.Config.AllowRunItTwice || len(users) == 0 || len(board) >= 5 || !hasShowdownHands(result) {
+		return result
+	}
+	for _, pr := range result.PlayerResults {
+		if !users[t.seats[pr.Chair]] {
+			return result
+		}
+	}
+	twice, err := t.game.SettleRunItTwice(2)
+	if err != nil {
+		log.Printf("[Table %s] run it twice failed: %v", t.ID, err)
+		return result
+	}
+	t.syncPlayerStacksFromSnapshot(t.game.Snapshot())
+	log.Printf("[Table %s] Ran the board twice", t.ID)
+	return twice
+}
+
+// offerRunItTwiceLocked holds back an action that closed betting all-in
+// before the river and asks everyone at showdown whether to run it twice.
+// It reports false, leaving the action to continue, when the table does not
+// negotiate, an NPC is in the hand, or everyone already agreed.
+func (t *Table) offerRunItTwiceLocked(before, after holdem.Snapshot, result *holdem.SettlementResult) bool {
+	if !t.Config.AllowRunItTwice || t.Config.RunItTwiceOfferTimeout <= 0 || result == nil ||
+		len(before.CommunityCards) >= 5 || !hasShowdownHands(result) {
+		return false
+	}
+	pending := make(map[uint64]bool, len(result.PlayerResults))
+	chairs := make([]uint32, 0, len(result.PlayerResults))
+	for _, pr := range result.PlayerResults {
+		userID := t.seats[pr.Chair]
+		if t.isNPC(userID) {
+			return false
+		}
+		chairs = append(chairs, uint32(pr.Chair))
+		if !t.runItTwiceUsers[userID] {
+			pending[userID] = true
+		}
+	}
+	if len(pending) == 0 {
+		return false
+	}
+
+	due := t.now().Add(t.Config.RunItTwiceOfferTimeout)
+	t.runItTwiceOffer = &runItTwiceOffer{
+		before:  before,
+		after:   after,
+		result:  result,
+		due:     due,
+		pending: pending,
+	}
+	for userID := range pending {
+		t.sendToUser(userID, &pb.ServerEnvelope{
+			TableId:    t.ID,
+			ServerSeq:  t.nextSeq(),
+			ServerTsMs: t.now().UnixMilli(),
+			Payload: &pb.ServerEnvelope_RunItTwiceOffer{
+				RunItTwiceOffer: &pb.RunItTwiceOffer{Chairs: chairs, DeadlineMs: due.UnixMilli()},
+			},
+		})
+	}
+	log.Printf("[Table %s] Offered to run it twice to chairs %v", t.ID, chairs)
+	return true
+}
+
+// handleRunItTwiceAccept records a player's answer to the pending offer. The
+// runout goes ahead as soon as anyone declines or the last player accepts.
+func (t *Table) handleRunItTwiceAccept(userID uint64, accept bool) error {
+	offer := t.runItTwiceOffer
+	if offer == nil {
+		return fmt.Errorf("no run it twice offer pending")
+	}
+	if !offer.pending[userID] {
+		return fmt.Errorf("no run it twice answer expected from this player")
+	}
+	delete(offer.pending, userID)
+	if !accept {
+		log.Printf("[Table %s] User %d declined to run it twice", t.ID, userID)
+		t.resolveRunItTwiceOfferLocked(false)
+		return nil
+	}
+	if t.runItTwiceUsers == nil {
+		t.runItTwiceUsers = make(map[uint64]bool)
+	}
+	t.runItTwiceUsers[userID] = true
+	log.Printf("[Table %s] User %d accepted to run it twice", t.ID, userID)
+	if len(offer.pending) == 0 {
+		t.resolveRunItTwiceOfferLocked(true)
+	}
+	return nil
+}
+
+// resolveRunItTwiceOfferLocked closes the pending offer and resumes the
+// hand, over two boards only if everyone agreed.
+func (t *Table) resolveRunItTwiceOfferLocked(agreed bool) {
+	offer := t.runItTwiceOffer
+	t.runItTwiceOffer = nil
+	if !agreed {
+		t.runItTwiceUsers = nil
+	}
+	t.continueAfterActionLocked(offer.before, offer.after, offer.result)
+}
@@ -0,0 +1,199 @@
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// runTwiceHand gets AA and KK all-in on a 2c 7d 9h flop. The first runout
+// (3s 4d) holds for the aces, the second (Ks 8c) fills the kings' set. agree
+// lists the users who ask to run it twice.
+func runTwiceHand(t *testing.T, agree ...uint64) (*Table, uint16, *pb.Showdown) {
+	t.Helper()
+
+	deck := mustCards(t, "As", "Kd", "Ah", "Kc", "2c", "7d", "9h", "3s", "4d", "Ks", "8c")
+	cfg := harnessTestConfig()
+	cfg.AllowRunItTwice = true
+	tbl, err := NewTableForTest(cfg, deck, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var showdown *pb.Showdown
+	tbl.broadcast = func(_ uint64, data []byte) {
+		if sd := decodeServerEnvelope(t, data).GetShowdown(); sd != nil {
+			showdown = sd
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	aces := tbl.game.Snapshot().SmallBlindChair
+	for _, userID := range agree {
+		if err := tbl.SubmitEvent(Event{Type: EventRunItTwice, UserID: userID}); err != nil {
+			t.Fatalf("run it twice user=%d err: %v", userID, err)
+		}
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 0)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, 900, 2)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, 900, 3)
+	if !tbl.game.Snapshot().Ended || showdown == nil {
+		t.Fatalf("expected the all-in to run out to a showdown")
+	}
+	return tbl, aces, showdown
+}
+
+func TestRunItTwice_SplitsThePotWhenEveryoneAgrees(t *testing.T) {
+	tbl, _, showdown := runTwiceHand(t, 1, 2)
+	for _, userID := range []uint64{1, 2} {
+		if got := tbl.players[userID].Stack; got != 1000 {
+			t.Fatalf("user %d: expected each run to pay 1000, got stack %d", userID, got)
+		}
+	}
+	if len(showdown.GetRuns()) != 2 {
+		t.Fatalf("expected the showdown to carry two runs, got %v", showdown.GetRuns())
+	}
+	for i, run := range showdown.GetRuns() {
+		if len(run.GetBoard()) != 5 || run.GetPotResults()[0].GetPotAmount() != 1000 {
+			t.Fatalf("run %d: expected a full board paying 1000, got %v", i+1, run)
+		}
+	}
+
+	// One holdout keeps the single runout.
+	tbl, aces, showdown := runTwiceHand(t, 1)
+	if got := stackAt(tbl, aces); got != 2000 || len(showdown.GetRuns()) != 0 {
+		t.Fatalf("expected the aces to scoop a single run, got stack %d runs %v", got, showdown.GetRuns())
+	}
+}
+
+func TestRunItTwice_RequiresTheTableOption(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventRunItTwice, UserID: 1}); err == nil {
+		t.Fatal("expected run it twice to be refused without AllowRunItTwice")
+	}
+}
+
+// offeredRunTwiceHand plays runTwiceHand's all-in at a table that asks at
+// the all-in, then gives each user's answer in user order. Users without an
+// answer let the offer time out.
+func offeredRunTwiceHand(t *testing.T, answers map[uint64]bool) (*Table, uint16, *pb.Showdown) {
+	t.Helper()
+
+	deck := mustCards(t, "As", "Kd", "Ah", "Kc", "2c", "7d", "9h", "3s", "4d", "Ks", "8c")
+	cfg := harnessTestConfig()
+	cfg.AllowRunItTwice = true
+	cfg.RunItTwiceOfferTimeout = 10 * time.Second
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(cfg, deck, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var showdown *pb.Showdown
+	offers := make(map[uint64]*pb.RunItTwiceOffer)
+	var closing *pb.ActionResult
+	tbl.broadcast = func(userID uint64, data []byte) {
+		env := decodeServerEnvelope(t, data)
+		if sd := env.GetShowdown(); sd != nil {
+			showdown = sd
+		}
+		if offer := env.GetRunItTwiceOffer(); offer != nil {
+			offers[userID] = offer
+		}
+		if ar := env.GetActionResult(); ar != nil {
+			closing = ar
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	aces := tbl.game.Snapshot().SmallBlindChair
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 0)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, 900, 2)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, 900, 3)
+
+	if showdown != nil {
+		t.Fatalf("expected the runout to wait for answers")
+	}
+	for _, userID := range []uint64{1, 2} {
+		offer := offers[userID]
+		if offer == nil || len(offer.GetChairs()) != 2 || offer.GetDeadlineMs() != clock.Now().Add(cfg.RunItTwiceOfferTimeout).UnixMilli() {
+			t.Fatalf("user %d: expected an offer naming both chairs, got %v", userID, offer)
+		}
+	}
+	// While the players answer, the table still looks like the flop all-in.
+	ts := tbl.buildTableSnapshotForUser(1)
+	if len(ts.GetCommunityCards()) != 3 || len(ts.GetPots()) != 1 || ts.GetPots()[0].GetAmount() != 2000 {
+		t.Fatalf("expected the flop and a 2000 pot during the offer, got board %v pots %v", ts.GetCommunityCards(), ts.GetPots())
+	}
+	for _, p := range ts.GetPlayers() {
+		if p.GetStack() != 0 {
+			t.Fatalf("expected chair %d unpaid during the offer, got stack %d", p.GetChair(), p.GetStack())
+		}
+	}
+	if closing.GetNewStack() != 0 || closing.GetNewPotTotal() != 2000 {
+		t.Fatalf("expected the closing all-in to leave 0 behind and 2000 in the pot, got %v", closing)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventStandUp, UserID: 1}); !errors.Is(err, ErrHandRunningOut) {
+		t.Fatalf("expected stand-up to wait for the runout, got %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		accept, ok := answers[userID]
+		if !ok {
+			continue
+		}
+		if err := tbl.SubmitEvent(Event{Type: EventRunItTwiceAccept, UserID: userID, Accept: accept}); err != nil {
+			t.Fatalf("answer user=%d err: %v", userID, err)
+		}
+	}
+	if len(answers) < 2 {
+		tbl.AdvanceClock(cfg.RunItTwiceOfferTimeout)
+	}
+	if showdown == nil {
+		t.Fatalf("expected the all-in to run out once the offer closed")
+	}
+	return tbl, aces, showdown
+}
+
+func TestRunItTwiceOffer_RunsTwiceWhenEveryoneAccepts(t *testing.T) {
+	tbl, _, showdown := offeredRunTwiceHand(t, map[uint64]bool{1: true, 2: true})
+	if len(showdown.GetRuns()) != 2 {
+		t.Fatalf("expected two runs, got %v", showdown.GetRuns())
+	}
+	for _, userID := range []uint64{1, 2} {
+		if got := tbl.players[userID].Stack; got != 1000 {
+			t.Fatalf("user %d: expected each run to pay 1000, got stack %d", userID, got)
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventRunItTwiceAccept, UserID: 1, Accept: true}); err == nil {
+		t.Fatalf("expected an answer with no offer pending to be refused")
+	}
+}
+
+func TestRunItTwiceOffer_RunsOnceOnDeclineOrTimeout(t *testing.T) {
+	tbl, aces, showdown := offeredRunTwiceHand(t, map[uint64]bool{1: true, 2: false})
+	if got := stackAt(tbl, aces); got != 2000 || len(showdown.GetRuns()) != 0 {
+		t.Fatalf("decline: expected the aces to scoop a single run, got stack %d runs %v", got, showdown.GetRuns())
+	}
+
+	tbl, aces, showdown = offeredRunTwiceHand(t, map[uint64]bool{1: true})
+	if got := stackAt(tbl, aces); got != 2000 || len(showdown.GetRuns()) != 0 {
+		t.Fatalf("timeout: expected the aces to scoop a single run, got stack %d runs %v", got, showdown.GetRuns())
+	}
+}
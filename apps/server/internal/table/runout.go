@@ -0,0 +1,119 @@
+package table
+
+import (
+	"time"
+
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+)
+
+// stagedRunOut is an all-in run-out dealt one street per
+// Config.RunOutStreetDelay. The hand is already settled in the engine.
+type stagedRunOut struct {
+	before holdem.Snapshot // when the closing action was taken
+	after  holdem.Snapshot // with the full board
+	result *holdem.SettlementResult
+	shown  int // community cards broadcast so far
+	due    time.Time
+}
+
+// stageRunOutLocked takes over the street broadcasts for a hand that ran out
+// more than one street at once, when RunOutStreetDelay is set.
+func (t *Table) stageRunOutLocked(before, after holdem.Snapshot, result *holdem.SettlementResult) bool {
+	if t.Config.RunOutStreetDelay <= 0 || result == nil {
+		return false
+	}
+	if nextStreetCount(len(before.CommunityCards)) >= len(after.CommunityCards) {
+		return false
+	}
+	t.runOut = &stagedRunOut{
+		before: before,
+		after:  after,
+		result: result,
+		shown:  len(before.CommunityCards),
+	}
+	return true
+}
+
+// advanceRunOutLocked deals the next street of the staged run-out, settling
+// the hand with the last one.
+func (t *Table) advanceRunOutLocked() {
+	r := t.runOut
+	shown := nextStreetCount(r.shown)
+	t.broadcastStreetStateTransitions(r.view(r.shown), r.view(shown))
+	r.shown = shown
+	if shown < len(r.after.CommunityCards) {
+		r.due = t.now().Add(t.Config.RunOutStreetDelay)
+		return
+	}
+	t.runOut = nil
+	t.finishHandLocked(r.before.CommunityCards, r.result)
+}
+
+// nextStreetCount is how many community cards are out after the street that
+// follows shown of them.
+func nextStreetCount(shown int) int {
+	if shown < 3 {
+		return 3
+	}
+	return shown + 1
+}
+
+// view is the table with the first n cards of the run-out dealt.
+func (r *stagedRunOut) view(n int) holdem.Snapshot {
+	return closedView(r.after, r.result, r.after.CommunityCards[:n])
+}
+
+// closedView rolls after, the engine's snapshot of a hand it already
+// settled, back to when betting closed: the winnings come off the stacks and
+// go back into the pots, and only board is dealt. Clients see this until the
+// run-out reaches the showdown.
+func closedView(after holdem.Snapshot, result *holdem.SettlementResult, board []card.Card) holdem.Snapshot {
+	view := after
+	view.Ended = false
+	view.ActionChair = holdem.InvalidChair
+	view.CurBet = 0
+	view.CommunityCards = append([]card.Card(nil), board...)
+	switch {
+	case len(board) >= 5:
+		view.Phase = holdem.PhaseTypeRiver
+	case len(board) == 4:
+		view.Phase = holdem.PhaseTypeTurn
+	case len(board) >= 3:
+		view.Phase = holdem.PhaseTypeFlop
+	default:
+		view.Phase = holdem.PhaseTypePreflop
+	}
+
+	won := make(map[uint16]int64, len(result.PlayerResults))
+	for _, pr := range result.PlayerResults {
+		won[pr.Chair] += pr.WinAmount
+	}
+	view.Players = make([]holdem.PlayerSnapshot, len(after.Players))
+	for i, ps := range after.Players {
+		ps.Stack -= won[ps.Chair]
+		ps.Bet = 0
+		view.Players[i] = ps
+	}
+	view.Pots = make([]holdem.PotSnapshot, 0, len(result.PotResults))
+	for _, pot := range result.PotResults {
+		view.Pots = append(view.Pots, holdem.PotSnapshot{
+			Amount:          pot.Amount,
+			EligiblePlayers: append([]uint16(nil), pot.Eligible...),
+		})
+	}
+	return view
+}
+
+// visibleSnapshotLocked is the game as clients may see it: a hand still
+// running out, or waiting on a run it twice offer, shows only the streets
+// dealt so far and its unsettled stacks.
+func (t *Table) visibleSnapshotLocked() holdem.Snapshot {
+	if offer := t.runItTwiceOffer; offer != nil {
+		return closedView(offer.after, offer.result, offer.before.CommunityCards)
+	}
+	if r := t.runOut; r != nil {
+		return r.view(r.shown)
+	}
+	return t.game.Snapshot()
+}
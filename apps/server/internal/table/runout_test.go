@@ -0,0 +1,139 @@
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+func TestRunOut_StreetsSpacedByDelay(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.RunOutStreetDelay = 2 * time.Second
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var streets []pb.Phase
+	var dealtAt []int64
+	var handEndAt int64
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if userID != 1 {
+			return
+		}
+		env := decodeServerEnvelope(t, data)
+		if db := env.GetDealBoard(); db != nil {
+			streets = append(streets, db.GetPhase())
+			dealtAt = append(dealtAt, env.GetServerTsMs())
+		}
+		if env.GetHandEnd() != nil {
+			handEndAt = env.GetServerTsMs()
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 2)
+
+	if len(streets) != 1 || streets[0] != pb.Phase_PHASE_FLOP || handEndAt != 0 {
+		t.Fatalf("expected only the flop at the all-in, got %v hand end %d", streets, handEndAt)
+	}
+	// Nothing new can be dealt while the board is still running out.
+	if err := tbl.SubmitEvent(Event{Type: EventStartHand}); err != nil {
+		t.Fatalf("start hand err: %v", err)
+	}
+	if got := tbl.game.Snapshot().Round; got != 1 {
+		t.Fatalf("expected the run-out to hold the next hand, got round %d", got)
+	}
+
+	tbl.AdvanceClock(time.Second)
+	if len(streets) != 1 {
+		t.Fatalf("expected the turn to wait out the delay, got %v", streets)
+	}
+	tbl.AdvanceClock(time.Second)
+	tbl.AdvanceClock(2 * time.Second)
+	want := []pb.Phase{pb.Phase_PHASE_FLOP, pb.Phase_PHASE_TURN, pb.Phase_PHASE_RIVER}
+	if len(streets) != len(want) {
+		t.Fatalf("expected streets %v, got %v", want, streets)
+	}
+	for i := range want {
+		if streets[i] != want[i] {
+			t.Fatalf("expected streets %v, got %v", want, streets)
+		}
+		if i > 0 && dealtAt[i]-dealtAt[i-1] != cfg.RunOutStreetDelay.Milliseconds() {
+			t.Fatalf("expected streets %v apart, got times %v", cfg.RunOutStreetDelay, dealtAt)
+		}
+	}
+	if handEndAt != dealtAt[2] {
+		t.Fatalf("expected the hand to end with the river, at %d not %d", dealtAt[2], handEndAt)
+	}
+}
+
+func TestRunOut_SnapshotsShowOnlyDealtStreets(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.RunOutStreetDelay = 2 * time.Second
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var closing *pb.ActionResult
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if userID != 1 {
+			return
+		}
+		if ar := decodeServerEnvelope(t, data).GetActionResult(); ar != nil {
+			closing = ar
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	var total int64
+	for _, ps := range tbl.game.Snapshot().Players {
+		total += ps.Stack + ps.Bet
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 2)
+
+	if closing == nil || closing.GetNewStack() != 0 || closing.GetNewPotTotal() != total {
+		t.Fatalf("expected the closing all-in to leave 0 behind and %d in the pot, got %+v", total, closing)
+	}
+	check := func(board int) {
+		t.Helper()
+		tbl.mu.Lock()
+		ts := tbl.buildTableSnapshotForUser(1)
+		tbl.mu.Unlock()
+		if len(ts.GetCommunityCards()) != board {
+			t.Fatalf("expected %d community cards, got %d", board, len(ts.GetCommunityCards()))
+		}
+		var pot int64
+		for _, p := range ts.GetPots() {
+			pot += p.GetAmount()
+		}
+		if pot != total {
+			t.Fatalf("expected %d in the pots until showdown, got %d", total, pot)
+		}
+		for _, p := range ts.GetPlayers() {
+			if p.GetStack() != 0 {
+				t.Fatalf("expected chair %d unpaid until showdown, got stack %d", p.GetChair(), p.GetStack())
+			}
+		}
+	}
+	check(3)
+	if err := tbl.SubmitEvent(Event{Type: EventStandUp, UserID: 2}); !errors.Is(err, ErrHandRunningOut) {
+		t.Fatalf("expected stand-up to wait for the run-out, got %v", err)
+	}
+	tbl.AdvanceClock(2 * time.Second)
+	check(4)
+	tbl.AdvanceClock(2 * time.Second)
+	if got := len(tbl.game.Snapshot().CommunityCards); got != 5 || tbl.runOut != nil {
+		t.Fatalf("expected the river to settle the run-out, board %d", got)
+	}
+}
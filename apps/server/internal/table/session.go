@@ -0,0 +1,87 @@
+package table
+
+import (
+	"log"
+	"sort"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+// SessionSeat is a player who was seated when a table's session ended.
+type SessionSeat struct {
+	Chair  uint16
+	UserID uint64
+	// PersonaID is the NPC persona at the chair, "" for a human.
+	PersonaID string
+}
+
+// SessionEndHook is called off the actor goroutine once a table has ended
+// its session, with the players seated at the end in chair order.
+type SessionEndHook func(t *Table, seats []SessionSeat)
+
+// SetSessionEndHook registers the callback run when the table ends its
+// session (see TableConfig.MaxHandsPerSession).
+func (t *Table) SetSessionEndHook(hook SessionEndHook) {
+	t.mu.Lock()
+	t.sessionEndHook = hook
+	t.mu.Unlock()
+}
+
+// endSessionLocked concludes a table that reached MaxHandsPerSession: it
+// broadcasts the final stacks, stands everyone up (chips go back to their
+// wallets, NPCs are despawned) and closes the table.
+func (t *Table) endSessionLocked() {
+	chairs := make([]uint16, 0, len(t.seats))
+	for chair := range t.seats {
+		chairs = append(chairs, chair)
+	}
+	sort.Slice(chairs, func(i, j int) bool { return chairs[i] < chairs[j] })
+
+	end := &pb.SessionEnd{HandsPlayed: t.round}
+	seats := make([]SessionSeat, 0, len(chairs))
+	for _, chair := range chairs {
+		userID := t.seats[chair]
+		if player := t.players[userID]; player != nil {
+			end.Stacks = append(end.Stacks, &pb.SessionStack{UserId: userID, Chair: uint32(chair), Stack: player.Stack})
+		}
+		seat := SessionSeat{Chair: chair, UserID: userID}
+		if t.isNPC(userID) {
+			if inst := t.npcManager.GetInstance(userID); inst != nil && inst.Persona != nil {
+				seat.PersonaID = inst.Persona.ID
+			}
+		}
+		seats = append(seats, seat)
+	}
+	t.broadcastToAll(&pb.ServerEnvelope{
+		TableId:    t.ID,
+		ServerSeq:  t.nextSeq(),
+		ServerTsMs: t.now().UnixMilli(),
+		Payload:    &pb.ServerEnvelope_SessionEnd{SessionEnd: end},
+	})
+
+	for _, chair := range chairs {
+		userID := t.seats[chair]
+		npc := t.isNPC(userID)
+		if err := t.handleStandUp(userID); err != nil {
+			log.Printf("[Table %s] session end: cash out user %d failed: %v", t.ID, userID, err)
+			continue
+		}
+		if npc {
+			delete(t.players, userID)
+			t.npcManager.DespawnNPC(userID)
+		}
+	}
+	log.Printf("[Table %s] Session ended after %d hands", t.ID, t.round)
+	t.stopLocked()
+
+	if hook := t.sessionEndHook; hook != nil {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[Table %s] session end hook panic: %v", t.ID, r)
+				}
+			}()
+			hook(t, seats)
+		}()
+	}
+}
@@ -0,0 +1,95 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestMaxHandsPerSession_ClosesAfterLastHandSettles(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.MaxHandsPerSession = 3
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var sessionEnds int
+	var handsPlayed uint32
+	tbl.broadcast = func(_ uint64, data []byte) {
+		if end := decodeServerEnvelope(t, data).GetSessionEnd(); end != nil {
+			sessionEnds++
+			handsPlayed = end.GetHandsPlayed()
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+
+	for hand := 1; hand <= 3; hand++ {
+		if got := tbl.game.Snapshot().Round; got != uint16(hand) {
+			t.Fatalf("expected hand %d to be dealt, got round %d", hand, got)
+		}
+		if tbl.IsClosed() {
+			t.Fatalf("table closed before hand %d", hand)
+		}
+		actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, hand)
+		if hand < 3 {
+			tbl.AdvanceClock(foldHandDelay)
+		}
+	}
+
+	if !tbl.IsClosed() {
+		t.Fatalf("expected the table to close after the third hand settled")
+	}
+	if sessionEnds == 0 || handsPlayed != 3 {
+		t.Fatalf("expected a session end after 3 hands, got %d broadcasts, hands=%d", sessionEnds, handsPlayed)
+	}
+	var chips int64
+	for _, userID := range []uint64{1, 2} {
+		player := tbl.players[userID]
+		if player.Chair != holdem.InvalidChair || player.Stack != 0 {
+			t.Fatalf("expected user %d cashed out, got chair=%d stack=%d", userID, player.Chair, player.Stack)
+		}
+		chips += player.Wallet
+	}
+	if chips != 2*cfg.MaxBuyIn {
+		t.Fatalf("expected all chips back in wallets, got %d", chips)
+	}
+	tbl.AdvanceClock(time.Minute)
+	if got := tbl.game.Snapshot().Round; got != 3 {
+		t.Fatalf("expected no hand after the session ended, got round %d", got)
+	}
+}
+
+func TestSessionEndHook_ReportsFinalSeats(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.MaxHandsPerSession = 1
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	got := make(chan []SessionSeat, 1)
+	tbl.SetSessionEndHook(func(ended *Table, seats []SessionSeat) {
+		if ended == tbl {
+			got <- seats
+		}
+	})
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 1)
+
+	select {
+	case seats := <-got:
+		if len(seats) != 2 || seats[0].Chair != 0 || seats[0].UserID != 1 || seats[1].UserID != 2 || seats[1].PersonaID != "" {
+			t.Fatalf("expected both humans in chair order, got %+v", seats)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the session end hook to run")
+	}
+}
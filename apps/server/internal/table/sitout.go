@@ -0,0 +1,109 @@
+package table
+
+import (
+	"fmt"
+	"log"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// handleSitOut keeps the player's chair but deals them out from the next
+// hand. A hand they are still in plays on: their turns are taken by the
+// timeout action straight away.
+func (t *Table) handleSitOut(userID uint64) error {
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return fmt.Errorf("player not seated")
+	}
+	if player.SittingOut {
+		return nil
+	}
+	if err := t.game.SetSittingOut(player.Chair, true); err != nil {
+		return err
+	}
+	player.SittingOut = true
+	if t.actionTimeoutChair == player.Chair {
+		t.actionDeadline = t.now()
+	}
+	log.Printf("[Table %s] User %d sat out at chair %d", t.ID, userID, player.Chair)
+	t.broadcastSitOutLocked(player)
+	return nil
+}
+
+// handleSitIn deals a sitting-out player back in from the next hand.
+func (t *Table) handleSitIn(userID uint64) error {
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return fmt.Errorf("player not seated")
+	}
+	if !player.SittingOut {
+		return nil
+	}
+	if err := t.game.SetSittingOut(player.Chair, false); err != nil {
+		return err
+	}
+	player.SittingOut = false
+	player.LastSeen = t.now()
+	log.Printf("[Table %s] User %d sat back in at chair %d", t.ID, userID, player.Chair)
+	t.broadcastSitOutLocked(player)
+	if err := t.tryStartHand(player.LastSeen); err != nil {
+		log.Printf("[Table %s] tryStartHand after sit-in failed: %v", t.ID, err)
+	}
+	return nil
+}
+
+func (t *Table) sittingOut(userID uint64) bool {
+	p := t.players[userID]
+	return p != nil && p.SittingOut
+}
+
+// seatsInLocked counts seated players who are not sitting out.
+func (t *Table) seatsInLocked() int {
+	n := 0
+	for _, userID := range t.seats {
+		if !t.sittingOut(userID) {
+			n++
+		}
+	}
+	return n
+}
+
+// broadcastSitOutLocked re-announces the player's seat with its sit-out
+// flag, keeping the rest of their public hand state.
+func (t *Table) broadcastSitOutLocked(player *PlayerConn) {
+	state := &pb.PlayerState{
+		UserId:     player.UserID,
+		Nickname:   t.playerNickname(player.UserID),
+		Chair:      uint32(player.Chair),
+		Stack:      player.Stack,
+		AvatarKey:  t.playerAvatarKey(player.UserID),
+		SittingOut: player.SittingOut,
+	}
+	snap := t.game.Snapshot()
+	for _, ps := range snap.Players {
+		if ps.Chair != player.Chair {
+			continue
+		}
+		state.Stack = ps.Stack
+		state.Bet = ps.Bet
+		state.Folded = ps.Folded
+		state.AllIn = ps.AllIn
+		state.LastAction = actionToProto(ps.LastAction)
+		state.HasCards = len(ps.HandCards) > 0
+		if !ps.Folded && !ps.AllIn {
+			state.ToCall = callAmountFor(snap.CurBet, ps.Bet)
+		}
+	}
+	t.broadcastToAll(&pb.ServerEnvelope{
+		TableId:    t.ID,
+		ServerSeq:  t.nextSeq(),
+		ServerTsMs: t.now().UnixMilli(),
+		Payload: &pb.ServerEnvelope_SeatUpdate{
+			SeatUpdate: &pb.SeatUpdate{
+				Chair:  uint32(player.Chair),
+				Update: &pb.SeatUpdate_PlayerJoined{PlayerJoined: state},
+			},
+		},
+	})
+}
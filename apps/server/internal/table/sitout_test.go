@@ -0,0 +1,146 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestSitOut_DealtOutButKeepsChairAcrossHands(t *testing.T) {
+	tbl := newPrivacyTestTable(t, 0)
+	const away = 3
+	chair := tbl.players[away].Chair
+
+	if err := tbl.SubmitEvent(Event{Type: EventSitOut, UserID: away}); err != nil {
+		t.Fatalf("sit out err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: away}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	// finish folds every turn; the away player's turns go to the timeout.
+	finish := func(hand int) {
+		t.Helper()
+		for step := 0; !tbl.game.Snapshot().Ended; step++ {
+			if step > 10 {
+				t.Fatalf("hand %d did not finish", hand)
+			}
+			if tbl.seats[tbl.game.Snapshot().ActionChair] == away {
+				tbl.AdvanceClock(0)
+				continue
+			}
+			actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, step)
+		}
+	}
+	finish(1)
+
+	for hand := 2; hand <= 4; hand++ {
+		tbl.AdvanceClock(offlineSeatTTL)
+		snap := tbl.game.Snapshot()
+		if int(snap.Round) != hand || snap.Ended {
+			t.Fatalf("expected hand %d to be dealt, got round %d ended=%v", hand, snap.Round, snap.Ended)
+		}
+		for _, ps := range snap.Players {
+			if ps.Chair == chair && len(ps.HandCards) != 0 {
+				t.Fatalf("hand %d: expected the away player dealt out, got %v", hand, ps.HandCards)
+			}
+		}
+		if tbl.players[away].Chair != chair || tbl.seats[chair] != away {
+			t.Fatalf("hand %d: expected user %d to keep chair %d", hand, away, chair)
+		}
+		if p := snapshotPlayer(tbl.buildTableSnapshotForUser(1), away); p == nil || !p.GetSittingOut() {
+			t.Fatalf("hand %d: expected the snapshot to show user %d sitting out, got %v", hand, away, p)
+		}
+		finish(hand)
+	}
+
+	if err := tbl.SubmitEvent(Event{Type: EventSitIn, UserID: away}); err != nil {
+		t.Fatalf("sit in err: %v", err)
+	}
+	tbl.AdvanceClock(foldHandDelay)
+	for _, ps := range tbl.game.Snapshot().Players {
+		if ps.Chair == chair && len(ps.HandCards) != 2 {
+			t.Fatalf("expected user %d dealt back in, got %v", away, ps.HandCards)
+		}
+	}
+}
+
+func chairFolded(tbl *Table, chair uint16) bool {
+	for _, ps := range tbl.game.Snapshot().Players {
+		if ps.Chair == chair {
+			return ps.Folded
+		}
+	}
+	return false
+}
+
+func TestSitOut_TurnPlayedWithoutTimer(t *testing.T) {
+	tbl := newPrivacyTestTable(t, 0)
+
+	// Facing the big blind: fold at once.
+	chair := tbl.game.Snapshot().ActionChair
+	if err := tbl.SubmitEvent(Event{Type: EventSitOut, UserID: tbl.seats[chair]}); err != nil {
+		t.Fatalf("sit out err: %v", err)
+	}
+	tbl.AdvanceClock(0)
+	if !chairFolded(tbl, chair) {
+		t.Fatalf("expected sitting-out chair %d to fold to the bet without waiting", chair)
+	}
+
+	// The big blind's option is free: check at once.
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 0)
+	chair = tbl.game.Snapshot().ActionChair
+	if err := tbl.SubmitEvent(Event{Type: EventSitOut, UserID: tbl.seats[chair]}); err != nil {
+		t.Fatalf("sit out err: %v", err)
+	}
+	tbl.AdvanceClock(0)
+	snap := tbl.game.Snapshot()
+	if chairFolded(tbl, chair) || snap.Phase != holdem.PhaseTypeFlop {
+		t.Fatalf("expected sitting-out chair %d to check its option, got folded=%v phase=%v", chair, chairFolded(tbl, chair), snap.Phase)
+	}
+}
+
+func TestAutoActOffline_FoldsOfflineButTimesOnlinePlayers(t *testing.T) {
+	tbl := newPrivacyTestTable(t, 0)
+	chair := tbl.game.Snapshot().ActionChair
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: tbl.seats[chair]}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	tbl.AdvanceClock(0)
+	if chairFolded(tbl, chair) || tbl.game.Snapshot().ActionChair != chair {
+		t.Fatalf("expected an offline player to keep their timer without AutoActOffline")
+	}
+
+	tbl = newPrivacyTestTable(t, 0)
+	tbl.Config.AutoActOffline = true
+	chair = tbl.game.Snapshot().ActionChair
+	tbl.AdvanceClock(time.Duration(actionTimeLimitSec-1) * time.Second)
+	if tbl.game.Snapshot().ActionChair != chair {
+		t.Fatalf("expected an online idle player to get the full timer")
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: tbl.seats[chair]}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	tbl.AdvanceClock(0)
+	if !chairFolded(tbl, chair) {
+		t.Fatalf("expected chair %d to fold once offline", chair)
+	}
+
+	// An offline player's later turn does not start a timer at all; their
+	// free option is checked.
+	snap := tbl.game.Snapshot()
+	var bb uint16
+	for _, ps := range snap.Players {
+		if ps.Chair != snap.ActionChair && !ps.Folded {
+			bb = ps.Chair
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: tbl.seats[bb]}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 0)
+	tbl.AdvanceClock(0)
+	if snap := tbl.game.Snapshot(); chairFolded(tbl, bb) || snap.Phase != holdem.PhaseTypeFlop {
+		t.Fatalf("expected offline chair %d to check its option straight away, got phase %v", bb, snap.Phase)
+	}
+}
@@ -0,0 +1,79 @@
+package table
+
+import "holdem-lite/holdem"
+
+// SizingPreset lets a BET/RAISE reference a pot fraction instead of an
+// absolute amount; the table resolves it at action time.
+type SizingPreset uint8
+
+const (
+	SizingPresetNone SizingPreset = iota
+	SizingPresetHalfPot
+	SizingPresetThreeQuarterPot
+	SizingPresetPot
+)
+
+// fraction returns the preset as num/den of the pot.
+func (p SizingPreset) fraction() (num, den int64, ok bool) {
+	switch p {
+	case SizingPresetHalfPot:
+		return 1, 2, true
+	case SizingPresetThreeQuarterPot:
+		return 3, 4, true
+	case SizingPresetPot:
+		return 1, 1, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// resolveActionAmount replaces the client amount with the preset amount for
+// BET/RAISE. Other actions and unknown presets keep the client amount.
+func (t *Table) resolveActionAmount(userID uint64, action holdem.ActionType, amount int64, preset SizingPreset) int64 {
+	if action != holdem.PlayerActionTypeBet && action != holdem.PlayerActionTypeRaise {
+		return amount
+	}
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return amount
+	}
+	_, minRaiseTo, _, err := t.game.LegalActions(player.Chair)
+	if err != nil {
+		return amount
+	}
+	snap := t.game.Snapshot()
+	var potTotal, myBet int64
+	for _, pot := range snap.Pots {
+		potTotal += pot.Amount
+	}
+	for _, ps := range snap.Players {
+		potTotal += ps.Bet
+		if ps.Chair == player.Chair {
+			myBet = ps.Bet
+		}
+	}
+	if resolved, ok := presetRaiseTo(preset, potTotal, snap.CurBet, myBet, minRaiseTo); ok {
+		return resolved
+	}
+	return amount
+}
+
+// presetRaiseTo computes the street total for a pot-fraction bet or raise.
+// A raise is sized on the pot after calling: curBet + frac*(pot + toCall).
+// Chips round down and the result never drops below the minimum raise; an
+// amount beyond the stack is turned into an all-in by the engine.
+func presetRaiseTo(preset SizingPreset, potTotal, curBet, myBet, minRaiseTo int64) (int64, bool) {
+	num, den, ok := preset.fraction()
+	if !ok {
+		return 0, false
+	}
+	toCall := curBet - myBet
+	if toCall < 0 {
+		toCall = 0
+	}
+	raiseTo := curBet + (potTotal+toCall)*num/den
+	if raiseTo < minRaiseTo {
+		raiseTo = minRaiseTo
+	}
+	return raiseTo, true
+}
@@ -0,0 +1,61 @@
+package table
+
+import (
+	"testing"
+
+	"holdem-lite/holdem"
+)
+
+func TestPresetRaiseTo_HalfPot(t *testing.T) {
+	cases := []struct {
+		name       string
+		potTotal   int64
+		curBet     int64
+		myBet      int64
+		minRaiseTo int64
+		want       int64
+	}{
+		{name: "bet into 600 pot", potTotal: 600, curBet: 0, myBet: 0, minRaiseTo: 100, want: 300},
+		{name: "bet into odd pot rounds down", potTotal: 1250, curBet: 0, myBet: 0, minRaiseTo: 100, want: 625},
+		{name: "small pot clamps to min bet", potTotal: 150, curBet: 0, myBet: 0, minRaiseTo: 100, want: 100},
+		{name: "raise facing bet", potTotal: 900, curBet: 300, myBet: 0, minRaiseTo: 600, want: 900},
+		{name: "raise with chips committed", potTotal: 1150, curBet: 400, myBet: 100, minRaiseTo: 700, want: 1125},
+	}
+	for _, tc := range cases {
+		got, ok := presetRaiseTo(SizingPresetHalfPot, tc.potTotal, tc.curBet, tc.myBet, tc.minRaiseTo)
+		if !ok {
+			t.Fatalf("%s: expected preset to resolve", tc.name)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected raise to %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestResolveActionAmount_HalfPotRaisePreflop(t *testing.T) {
+	tbl := newStandUpTestTable(t)
+	snap := tbl.game.Snapshot()
+	userID := tbl.seats[snap.ActionChair]
+
+	// Blinds 50/100: pot 150, calling 100 makes 250, half of that on top of 100.
+	amount := tbl.resolveActionAmount(userID, holdem.PlayerActionTypeRaise, 0, SizingPresetHalfPot)
+	if amount != 225 {
+		t.Fatalf("expected half-pot raise to 225, got %d", amount)
+	}
+	if _, err := tbl.game.Act(snap.ActionChair, holdem.PlayerActionTypeRaise, amount); err != nil {
+		t.Fatalf("Act raise err: %v", err)
+	}
+	after := tbl.game.Snapshot()
+	if after.CurBet != 225 {
+		t.Fatalf("expected current bet 225, got %d", after.CurBet)
+	}
+}
+
+func TestResolveActionAmount_IgnoresPresetForCall(t *testing.T) {
+	tbl := newStandUpTestTable(t)
+	userID := tbl.seats[tbl.game.Snapshot().ActionChair]
+
+	if amount := tbl.resolveActionAmount(userID, holdem.PlayerActionTypeCall, 100, SizingPresetPot); amount != 100 {
+		t.Fatalf("expected call amount to stay 100, got %d", amount)
+	}
+}
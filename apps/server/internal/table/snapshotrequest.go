@@ -0,0 +1,33 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// snapshotRequestInterval is how often a user may ask for a fresh snapshot.
+const snapshotRequestInterval = time.Second
+
+// ErrSnapshotRateLimited refuses a snapshot request made within
+// snapshotRequestInterval of the user's last one.
+var ErrSnapshotRateLimited = errors.New("snapshot requested too soon")
+
+// handleRequestSnapshot resends userID the table as they see it, plus their
+// action prompt if it is their turn, so a client can resync in place.
+func (t *Table) handleRequestSnapshot(userID uint64) error {
+	if t.players[userID] == nil {
+		return fmt.Errorf("player not in table")
+	}
+	now := t.now()
+	if last, ok := t.snapshotRequests[userID]; ok && now.Sub(last) < snapshotRequestInterval {
+		return ErrSnapshotRateLimited
+	}
+	if t.snapshotRequests == nil {
+		t.snapshotRequests = make(map[uint64]time.Time)
+	}
+	t.snapshotRequests[userID] = now
+	t.sendSnapshot(userID)
+	t.sendPromptIfActingUser(userID)
+	return nil
+}
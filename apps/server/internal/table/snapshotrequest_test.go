@@ -0,0 +1,58 @@
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRequestSnapshot_SendsOneSnapshotWithOwnCards(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+
+	snapshots := map[uint64]int{}
+	var own int
+	tbl.broadcast = func(userID uint64, data []byte) {
+		ts := decodeServerEnvelope(t, data).GetTableSnapshot()
+		if ts == nil {
+			return
+		}
+		snapshots[userID]++
+		for _, p := range ts.GetPlayers() {
+			if p.GetUserId() == userID {
+				own = len(p.GetHandCards())
+			}
+		}
+	}
+
+	if err := tbl.SubmitEvent(Event{Type: EventRequestSnapshot, UserID: 2}); err != nil {
+		t.Fatalf("request snapshot err: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[2] != 1 {
+		t.Fatalf("expected exactly one snapshot to user 2, got %v", snapshots)
+	}
+	if own != 2 {
+		t.Fatalf("expected the snapshot to carry the caller's 2 hole cards, got %d", own)
+	}
+
+	if err := tbl.SubmitEvent(Event{Type: EventRequestSnapshot, UserID: 2}); !errors.Is(err, ErrSnapshotRateLimited) {
+		t.Fatalf("expected an immediate repeat to be rate-limited, got %v", err)
+	}
+	tbl.AdvanceClock(snapshotRequestInterval + time.Millisecond)
+	if err := tbl.SubmitEvent(Event{Type: EventRequestSnapshot, UserID: 2}); err != nil {
+		t.Fatalf("request after the interval err: %v", err)
+	}
+	if snapshots[2] != 2 {
+		t.Fatalf("expected a second snapshot after the interval, got %d", snapshots[2])
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventRequestSnapshot, UserID: 9}); err == nil {
+		t.Fatalf("expected a request from outside the table to fail")
+	}
+}
@@ -0,0 +1,191 @@
+package table
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+)
+
+// BlindLevel is one step of a Sit-N-Go blind schedule. A level lasts Hands
+// hands or Duration, whichever runs out first (0 leaves that limit off); the
+// last level lasts until the tournament ends.
+type BlindLevel struct {
+	SmallBlind int64
+	BigBlind   int64
+	Ante       int64
+	Hands      uint32
+	Duration   time.Duration
+}
+
+var (
+	// ErrNoRebuys answers a buy-in at a Sit-N-Go table.
+	ErrNoRebuys = errors.New("no re-buys in a tournament")
+	// ErrTournamentStarted answers a sit-down once a Sit-N-Go has dealt.
+	ErrTournamentStarted = errors.New("tournament already started")
+)
+
+// TournamentEndHook is called off the actor goroutine when a Sit-N-Go has
+// one player left, with every entrant in finishing order, winner first.
+type TournamentEndHook func(t *Table, finish []uint64)
+
+// SetTournamentEndHook registers the callback run when a Sit-N-Go ends.
+func (t *Table) SetTournamentEndHook(hook TournamentEndHook) {
+	t.mu.Lock()
+	t.tournamentEndHook = hook
+	t.mu.Unlock()
+}
+
+// sngState tracks a Sit-N-Go (see TableConfig.BlindSchedule).
+type sngState struct {
+	started    bool
+	finished   bool
+	level      int
+	levelHands uint32
+	levelStart time.Time
+	// busted lists eliminated players, first out first.
+	busted []uint64
+}
+
+func (c TableConfig) tournament() bool {
+	return len(c.BlindSchedule) > 0
+}
+
+// sngLocked returns the Sit-N-Go state, or nil at a cash table.
+func (t *Table) sngLocked() *sngState {
+	if !t.Config.tournament() {
+		return nil
+	}
+	if t.sng == nil {
+		t.sng = &sngState{}
+	}
+	return t.sng
+}
+
+// blindsLocked returns the forced bets in effect: the current blind level at
+// a Sit-N-Go, otherwise the configured blinds.
+func (t *Table) blindsLocked() (smallBlind, bigBlind, ante int64) {
+	if !t.Config.tournament() {
+		return t.Config.SmallBlind, t.Config.BigBlind, t.Config.Ante
+	}
+	level := t.Config.BlindSchedule[0]
+	if t.sng != nil {
+		level = t.Config.BlindSchedule[t.sng.level]
+	}
+	return level.SmallBlind, level.BigBlind, level.Ante
+}
+
+// waitingForEntrantsLocked holds a Sit-N-Go's first deal until every seat is
+// taken.
+func (t *Table) waitingForEntrantsLocked() bool {
+	s := t.sngLocked()
+	return s != nil && !s.started && len(t.seats) < int(t.Config.MaxPlayers)
+}
+
+// advanceBlindLevelLocked runs before each Sit-N-Go deal: the first deal
+// starts the clock on level one, and later deals move up a level once the
+// current one has used up its hands or its time.
+func (t *Table) advanceBlindLevelLocked(now time.Time) {
+	s := t.sngLocked()
+	if s == nil {
+		return
+	}
+	schedule := t.Config.BlindSchedule
+	switch {
+	case !s.started:
+		s.started = true
+		s.levelStart = now
+	case s.level+1 < len(schedule):
+		level := schedule[s.level]
+		if (level.Hands == 0 || s.levelHands < level.Hands) &&
+			(level.Duration == 0 || now.Sub(s.levelStart) < level.Duration) {
+			break
+		}
+		s.level++
+		s.levelHands = 0
+		s.levelStart = now
+		log.Printf("[Table %s] Blinds up to level %d", t.ID, s.level+1)
+	}
+	s.levelHands++
+
+	sb, bb, ante := t.blindsLocked()
+	if err := t.game.SetBlinds(sb, bb, ante); err != nil {
+		log.Printf("[Table %s] set blinds for level %d failed: %v", t.ID, s.level+1, err)
+	}
+}
+
+// eliminateBustedLocked stands up Sit-N-Go players left without chips after
+// a hand. Of several busted in the same hand, the one who started it with
+// fewer chips finishes lower. With one player left the tournament ends.
+func (t *Table) eliminateBustedLocked() {
+	s := t.sngLocked()
+	if s == nil || !s.started || s.finished {
+		return
+	}
+	var out []uint16
+	for chair, userID := range t.seats {
+		if player := t.players[userID]; player != nil && player.Stack == 0 {
+			out = append(out, chair)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := t.handStartStacks[out[i]], t.handStartStacks[out[j]]
+		if a != b {
+			return a < b
+		}
+		return out[i] < out[j]
+	})
+	for _, chair := range out {
+		userID := t.seats[chair]
+		if err := t.handleStandUp(userID); err != nil {
+			log.Printf("[Table %s] eliminate user %d failed: %v", t.ID, userID, err)
+		}
+	}
+	t.checkTournamentOverLocked()
+}
+
+// recordEliminationLocked notes that userID left a running Sit-N-Go, by
+// busting or by standing up.
+func (t *Table) recordEliminationLocked(userID uint64) {
+	s := t.sngLocked()
+	if s == nil || !s.started || s.finished {
+		return
+	}
+	s.busted = append(s.busted, userID)
+	log.Printf("[Table %s] User %d eliminated, %d left", t.ID, userID, len(t.seats))
+}
+
+// checkTournamentOverLocked ends a running Sit-N-Go once one player is left.
+func (t *Table) checkTournamentOverLocked() {
+	if s := t.sngLocked(); s != nil && s.started && !s.finished && len(t.seats) <= 1 {
+		t.finishTournamentLocked()
+	}
+}
+
+// finishTournamentLocked reports the finishing order and ends the session.
+func (t *Table) finishTournamentLocked() {
+	s := t.sngLocked()
+	s.finished = true
+	finish := make([]uint64, 0, len(s.busted)+1)
+	for _, userID := range t.seats {
+		finish = append(finish, userID)
+	}
+	for i := len(s.busted) - 1; i >= 0; i-- {
+		finish = append(finish, s.busted[i])
+	}
+	if len(finish) > 0 {
+		log.Printf("[Table %s] Tournament won by user %d", t.ID, finish[0])
+	}
+
+	if hook := t.tournamentEndHook; hook != nil {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[Table %s] tournament end hook panic: %v", t.ID, r)
+				}
+			}()
+			hook(t, finish)
+		}()
+	}
+	t.endSessionLocked()
+}
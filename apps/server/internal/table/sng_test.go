@@ -0,0 +1,135 @@
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func sngTestConfig(maxPlayers uint16) TableConfig {
+	cfg := harnessTestConfig()
+	cfg.MaxPlayers = maxPlayers
+	cfg.BlindSchedule = []BlindLevel{
+		{SmallBlind: 10, BigBlind: 20, Hands: 2},
+		{SmallBlind: 25, BigBlind: 50, Ante: 5, Duration: time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Ante: 10},
+	}
+	return cfg
+}
+
+func TestSitNGo_WaitsForFullTable(t *testing.T) {
+	tbl, err := NewTableForTest(sngTestConfig(3), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if got := tbl.game.Snapshot().Round; got != 0 {
+		t.Fatalf("expected no deal with a seat open, got round %d", got)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 3}); err != nil {
+		t.Fatalf("join user=3 err: %v", err)
+	}
+	snap := tbl.game.Snapshot()
+	if snap.Round != 1 {
+		t.Fatalf("expected the first hand once the table filled, got round %d", snap.Round)
+	}
+	for _, player := range snap.Players {
+		if player.Stack+player.Bet != tbl.Config.MaxBuyIn {
+			t.Fatalf("expected every entrant to start with %d, got %+v", tbl.Config.MaxBuyIn, player)
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventBuyIn, UserID: 1, Amount: 100}); !errors.Is(err, ErrNoRebuys) {
+		t.Fatalf("expected ErrNoRebuys, got %v", err)
+	}
+}
+
+func TestSitNGo_BlindsFollowSchedule(t *testing.T) {
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(sngTestConfig(2), nil, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var bigBlinds, antes []int64
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if start := decodeServerEnvelope(t, data).GetHandStart(); start != nil && userID == 1 {
+			bigBlinds = append(bigBlinds, start.GetBigBlindAmount())
+			antes = append(antes, start.GetAnteAmount())
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+
+	// Level one lasts two hands; level two lasts a minute.
+	for hand := 1; hand <= 3; hand++ {
+		actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, hand)
+		tbl.AdvanceClock(foldHandDelay)
+	}
+	if got := tbl.game.Snapshot().CurBet; got != 50 {
+		t.Fatalf("expected a 50 big blind on hand 4, got bet %d", got)
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 4)
+	tbl.AdvanceClock(time.Minute)
+
+	want := []int64{20, 20, 50, 50, 100}
+	if len(bigBlinds) != len(want) {
+		t.Fatalf("expected %d hand starts, got big blinds %v", len(want), bigBlinds)
+	}
+	for i := range want {
+		if bigBlinds[i] != want[i] {
+			t.Fatalf("expected big blinds %v, got %v", want, bigBlinds)
+		}
+	}
+	if antes[2] != 5 || antes[4] != 10 {
+		t.Fatalf("expected antes to follow the schedule, got %v", antes)
+	}
+}
+
+func TestSitNGo_LastPlayerStandingWins(t *testing.T) {
+	// Heads-up deal order: button, big blind, button, big blind, board.
+	deck := mustCards(t, "As", "Kd", "Ah", "Kc", "2c", "7d", "9h", "3s", "4d")
+	tbl, err := NewTableForTest(sngTestConfig(2), deck, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	got := make(chan []uint64, 1)
+	tbl.SetTournamentEndHook(func(ended *Table, finish []uint64) {
+		if ended == tbl {
+			got <- finish
+		}
+	})
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	button := tbl.seats[tbl.game.Snapshot().DealerChair]
+	loser := uint64(3) - button
+
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, tbl.Config.MaxBuyIn, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, tbl.Config.MaxBuyIn, 2)
+	tbl.AdvanceClock(time.Minute)
+
+	select {
+	case finish := <-got:
+		if len(finish) != 2 || finish[0] != button || finish[1] != loser {
+			t.Fatalf("expected finish order [%d %d], got %v", button, loser, finish)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the tournament end hook to run")
+	}
+	if !tbl.IsClosed() {
+		t.Fatalf("expected the table to close once the tournament ended")
+	}
+	if wallet := tbl.players[button].Wallet; wallet != 2*tbl.Config.MaxBuyIn {
+		t.Fatalf("expected the winner to cash out every chip, got %d", wallet)
+	}
+}
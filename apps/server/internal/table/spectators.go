@@ -0,0 +1,38 @@
+package table
+
+import (
+	"errors"
+
+	"holdem-lite/holdem"
+)
+
+// ErrSpectatorsFull refuses a viewer once Config.MaxSpectators are watching.
+var ErrSpectatorsFull = errors.New("table has no spectator slots left")
+
+// spectatorCountLocked counts the connected humans at the table without a
+// chair, leaving out skip. A spectator who disconnects gives up their slot.
+func (t *Table) spectatorCountLocked(skip uint64) int {
+	n := 0
+	for userID, p := range t.players {
+		if userID == skip || !p.Online || p.Chair != holdem.InvalidChair || t.isNPC(userID) {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// spectatorsFullLocked reports whether userID would push the spectator count
+// past Config.MaxSpectators.
+func (t *Table) spectatorsFullLocked(userID uint64) bool {
+	max := t.Config.MaxSpectators
+	return max > 0 && t.spectatorCountLocked(userID) >= max
+}
+
+// SpectatorCount returns how many connected viewers are watching without a
+// seat.
+func (t *Table) SpectatorCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.spectatorCountLocked(0)
+}
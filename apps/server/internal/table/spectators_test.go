@@ -0,0 +1,50 @@
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMaxSpectators_RefusesBeyondLimitAndFreesOnLeave(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.MaxPlayers = 2
+	cfg.MaxSpectators = 2
+	tbl, err := NewTableForTest(cfg, nil, NewManualClock(time.Unix(1000, 0).UTC()), NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	// Users 1 and 2 take the seats; 3 and 4 fill the spectator slots.
+	for _, userID := range []uint64{1, 2, 3, 4} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if got := tbl.SpectatorCount(); got != 2 {
+		t.Fatalf("expected 2 spectators, got %d", got)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 5}); !errors.Is(err, ErrSpectatorsFull) {
+		t.Fatalf("expected ErrSpectatorsFull for a third spectator, got %v", err)
+	}
+	if tbl.players[5] != nil {
+		t.Fatalf("expected the refused spectator not to be kept at the table")
+	}
+
+	// A spectator dropping off frees their slot, and cannot take it back
+	// once someone else has.
+	if err := tbl.SubmitEvent(Event{Type: EventConnLost, UserID: 3}); err != nil {
+		t.Fatalf("conn lost err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 5}); err != nil {
+		t.Fatalf("expected user 5 to join the freed slot, got %v", err)
+	}
+	if got := tbl.buildTableSnapshotForUser(5).GetSpectatorCount(); got != 2 {
+		t.Fatalf("expected the snapshot to report 2 spectators, got %d", got)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventConnResume, UserID: 3}); !errors.Is(err, ErrSpectatorsFull) {
+		t.Fatalf("expected ErrSpectatorsFull on resume into a full gallery, got %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: 3}); !errors.Is(err, ErrSpectatorsFull) {
+		t.Fatalf("expected ErrSpectatorsFull on rejoin into a full gallery, got %v", err)
+	}
+}
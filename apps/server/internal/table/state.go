@@ -0,0 +1,337 @@
+package table
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"holdem-lite/apps/server/internal/ledger"
+	"holdem-lite/holdem"
+	"holdem-lite/holdem/npc"
+)
+
+// TableState is a serializable copy of a table: seats, stacks, the engine
+// state of any hand in progress, timers and the per-user hand tape. It
+// round-trips through encoding/json so a table can survive a restart.
+type TableState struct {
+	ID        string
+	Config    TableConfig
+	Round     uint32
+	HandID    string
+	ServerSeq uint64
+	Paused    bool
+
+	Players         []PlayerConn
+	Seats           map[uint16]uint64
+	HandStartStacks map[uint16]int64
+	Game            holdem.GameState
+
+	ActionTimeoutChair uint16
+	ActionDeadline     time.Time
+	NextHandAt         time.Time
+	EmptySince         time.Time
+
+	UserHandTape    map[uint64][]ledger.EventItem
+	PendingStandUps []uint64
+	PendingBuyIns   map[uint16]int64
+	StraddleUserID  uint64
+	// CashOutUsers and RunItTwiceUsers opted in for the current hand.
+	CashOutUsers    []uint64
+	RunItTwiceUsers []uint64
+	ActionTimings   []ActionTiming
+	FairnessProofs  []FairnessProof
+	// Tournament is the Sit-N-Go's progress, nil at a cash table.
+	Tournament *TournamentState
+
+	// NPCPersonas maps NPC user IDs to persona IDs so RestoreTable can
+	// re-attach their brains.
+	NPCPersonas map[uint64]string
+}
+
+// TournamentState is the exported form of a Sit-N-Go's progress.
+type TournamentState struct {
+	Started    bool
+	Finished   bool
+	Level      int
+	LevelHands uint32
+	LevelStart time.Time
+	// Busted lists eliminated players, first out first.
+	Busted []uint64
+}
+
+// ExportState captures the table state. Hand-end hooks, the lone-player hook
+// and connections are not part of it; callers register them again. A hand
+// running out or waiting on a run it twice offer is already settled in the
+// engine but not yet at the table, so it cannot be exported until it ends.
+func (t *Table) ExportState() (TableState, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.runOut != nil || t.runItTwiceOffer != nil {
+		return TableState{}, ErrHandRunningOut
+	}
+	game, err := t.game.Export()
+	if err != nil {
+		return TableState{}, err
+	}
+	s := TableState{
+		ID:                 t.ID,
+		Config:             t.Config,
+		Round:              t.round,
+		HandID:             t.handID,
+		ServerSeq:          t.serverSeq,
+		Paused:             t.paused,
+		Seats:              make(map[uint16]uint64, len(t.seats)),
+		HandStartStacks:    make(map[uint16]int64, len(t.handStartStacks)),
+		Game:               game,
+		ActionTimeoutChair: t.actionTimeoutChair,
+		ActionDeadline:     t.actionDeadline,
+		NextHandAt:         t.nextHandAt,
+		EmptySince:         t.emptySince,
+		UserHandTape:       make(map[uint64][]ledger.EventItem, len(t.userHandTape)),
+		StraddleUserID:     t.straddleUserID,
+		CashOutUsers:       userSet(t.cashOutUsers),
+		RunItTwiceUsers:    userSet(t.runItTwiceUsers),
+		ActionTimings:      append([]ActionTiming(nil), t.actionTimings...),
+		NPCPersonas:        make(map[uint64]string),
+	}
+	for _, proof := range t.fairnessProofs {
+		s.FairnessProofs = append(s.FairnessProofs, proof)
+	}
+	sort.Slice(s.FairnessProofs, func(i, j int) bool { return s.FairnessProofs[i].Round < s.FairnessProofs[j].Round })
+	if sng := t.sng; sng != nil {
+		s.Tournament = &TournamentState{
+			Started:    sng.started,
+			Finished:   sng.finished,
+			Level:      sng.level,
+			LevelHands: sng.levelHands,
+			LevelStart: sng.levelStart,
+			Busted:     append([]uint64(nil), sng.busted...),
+		}
+	}
+	for _, p := range t.players {
+		s.Players = append(s.Players, *p)
+		if inst := t.npcInstance(p.UserID); inst != nil {
+			s.NPCPersonas[p.UserID] = inst.Persona.ID
+		}
+	}
+	sort.Slice(s.Players, func(i, j int) bool { return s.Players[i].UserID < s.Players[j].UserID })
+	for chair, userID := range t.seats {
+		s.Seats[chair] = userID
+	}
+	for chair, stack := range t.handStartStacks {
+		s.HandStartStacks[chair] = stack
+	}
+	for userID, tape := range t.userHandTape {
+		s.UserHandTape[userID] = append([]ledger.EventItem{}, tape...)
+	}
+	for userID := range t.pendingStandUps {
+		s.PendingStandUps = append(s.PendingStandUps, userID)
+	}
+	if len(t.pendingBuyIn) > 0 {
+		s.PendingBuyIns = make(map[uint16]int64, len(t.pendingBuyIn))
+		for chair, amount := range t.pendingBuyIn {
+			s.PendingBuyIns[chair] = amount
+		}
+	}
+	return s, nil
+}
+
+// userSet lists the users in set in ascending order.
+func userSet(set map[uint64]bool) []uint64 {
+	var out []uint64
+	for userID, ok := range set {
+		if ok {
+			out = append(out, userID)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// RestoreTable rebuilds a table from an exported state and starts its actor.
+// Timers keep their absolute deadlines, so a turn that expired while the table
+// was down times out on the first tick. An NPC due to act is scheduled again.
+// Returns nil if the state is inconsistent.
+func RestoreTable(
+	state TableState,
+	broadcastFn func(userID uint64, data []byte),
+	ledgerService ledger.Service,
+	npcMgr ...*npc.Manager,
+) *Table {
+	t := &Table{
+		events:    make(chan Event, 256),
+		done:      make(chan struct{}),
+		broadcast: broadcastFn,
+		ledger:    ledgerService,
+		clock:     realClock{},
+	}
+	if len(npcMgr) > 0 && npcMgr[0] != nil {
+		t.npcManager = npcMgr[0]
+	}
+	if err := t.restoreState(state); err != nil {
+		log.Printf("[Table %s] Failed to restore: %v", state.ID, err)
+		return nil
+	}
+	if state.Config.MinBroadcastInterval > 0 {
+		t.pacer = newBroadcastPacer(state.Config.MinBroadcastInterval, t.clock, broadcastFn, t.done)
+	}
+
+	t.mu.Lock()
+	t.resumeNPCTurnLocked()
+	t.mu.Unlock()
+
+	go t.run()
+
+	log.Printf("[Table %s] Restored (round=%d, seats=%d)", t.ID, t.round, len(t.seats))
+	return t
+}
+
+// RestoreTableForTest is RestoreTable for the deterministic harness: events
+// run inline and time only moves through AdvanceClock (see NewTableForTest).
+func RestoreTableForTest(state TableState, clock *ManualClock, npcMode NPCMode, npcMgr ...*npc.Manager) (*Table, error) {
+	if clock == nil {
+		clock = NewManualClock(time.Unix(0, 0).UTC())
+	}
+	t := &Table{
+		done:      make(chan struct{}),
+		broadcast: func(uint64, []byte) {},
+		clock:     clock,
+		npcMode:   npcMode,
+		inline:    true,
+	}
+	if len(npcMgr) > 0 && npcMgr[0] != nil {
+		t.npcManager = npcMgr[0]
+	}
+	if err := t.restoreState(state); err != nil {
+		return nil, err
+	}
+	if state.Config.MinBroadcastInterval > 0 {
+		t.pacer = newBroadcastPacer(state.Config.MinBroadcastInterval, clock, func(userID uint64, data []byte) {
+			t.broadcast(userID, data)
+		}, t.done)
+	}
+	t.resumeNPCTurnLocked()
+	return t, nil
+}
+
+func (t *Table) restoreState(state TableState) error {
+	game, err := holdem.LoadGame(state.Game)
+	if err != nil {
+		return err
+	}
+	t.ID = state.ID
+	t.Config = state.Config
+	t.game = game
+	t.round = state.Round
+	t.handID = state.HandID
+	for _, proof := range state.FairnessProofs {
+		if t.fairnessProofs == nil {
+			t.fairnessProofs = make(map[uint32]FairnessProof, len(state.FairnessProofs))
+		}
+		t.fairnessProofs[proof.Round] = proof
+	}
+	if _, ok := t.fairnessProofs[t.round]; !ok {
+		t.commitHandSeedLocked()
+	}
+	t.serverSeq = state.ServerSeq
+	t.paused = state.Paused
+	t.actionTimeoutChair = state.ActionTimeoutChair
+	t.actionDeadline = state.ActionDeadline
+	t.nextHandAt = state.NextHandAt
+	t.emptySince = state.EmptySince
+	t.straddleUserID = state.StraddleUserID
+	t.actionTimings = append([]ActionTiming(nil), state.ActionTimings...)
+	if st := state.Tournament; st != nil {
+		t.sng = &sngState{
+			started:    st.Started,
+			finished:   st.Finished,
+			level:      st.Level,
+			levelHands: st.LevelHands,
+			levelStart: st.LevelStart,
+			busted:     append([]uint64(nil), st.Busted...),
+		}
+		if t.sng.level < 0 || t.sng.level >= len(t.Config.BlindSchedule) {
+			return fmt.Errorf("blind level %d outside the schedule", t.sng.level+1)
+		}
+	}
+
+	t.players = make(map[uint64]*PlayerConn, len(state.Players))
+	for i := range state.Players {
+		p := state.Players[i]
+		t.players[p.UserID] = &p
+	}
+	t.seats = make(map[uint16]uint64, len(state.Seats))
+	for chair, userID := range state.Seats {
+		if t.players[userID] == nil {
+			return fmt.Errorf("chair %d has unknown user %d", chair, userID)
+		}
+		t.seats[chair] = userID
+	}
+	t.handStartStacks = make(map[uint16]int64, len(state.HandStartStacks))
+	for chair, stack := range state.HandStartStacks {
+		t.handStartStacks[chair] = stack
+	}
+	t.userHandTape = make(map[uint64][]ledger.EventItem, len(state.UserHandTape))
+	for userID, tape := range state.UserHandTape {
+		t.userHandTape[userID] = append([]ledger.EventItem{}, tape...)
+	}
+	t.pendingStandUps = make(map[uint64]bool, len(state.PendingStandUps))
+	for _, userID := range state.PendingStandUps {
+		t.pendingStandUps[userID] = true
+	}
+	t.pendingBuyIn = make(map[uint16]int64, len(state.PendingBuyIns))
+	for chair, amount := range state.PendingBuyIns {
+		t.pendingBuyIn[chair] = amount
+	}
+	for _, userID := range state.CashOutUsers {
+		if t.cashOutUsers == nil {
+			t.cashOutUsers = make(map[uint64]bool, len(state.CashOutUsers))
+		}
+		t.cashOutUsers[userID] = true
+	}
+	for _, userID := range state.RunItTwiceUsers {
+		if t.runItTwiceUsers == nil {
+			t.runItTwiceUsers = make(map[uint64]bool, len(state.RunItTwiceUsers))
+		}
+		t.runItTwiceUsers[userID] = true
+	}
+
+	for userID, personaID := range state.NPCPersonas {
+		if t.npcManager == nil {
+			return fmt.Errorf("NPC %d needs an NPC manager", userID)
+		}
+		persona := t.npcManager.Registry().Get(personaID)
+		if persona == nil {
+			return fmt.Errorf("unknown persona %q", personaID)
+		}
+		p := t.players[userID]
+		if p == nil {
+			return fmt.Errorf("NPC %d is not at the table", userID)
+		}
+		if _, err := t.npcManager.AdoptNPC(userID, p.Chair, persona); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resumeNPCTurnLocked schedules the acting NPC of a restored hand; its
+// pending decision was lost with the old table.
+func (t *Table) resumeNPCTurnLocked() {
+	snap := t.game.Snapshot()
+	if snap.Ended || snap.ActionChair == holdem.InvalidChair {
+		return
+	}
+	if userID := t.seats[snap.ActionChair]; userID != 0 && t.isNPC(userID) {
+		t.scheduleNPCAction(snap.ActionChair, userID)
+	}
+}
+
+func (t *Table) npcInstance(userID uint64) *npc.NPCInstance {
+	if t.npcManager == nil {
+		return nil
+	}
+	return t.npcManager.GetInstance(userID)
+}
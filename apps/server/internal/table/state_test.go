@@ -0,0 +1,232 @@
+package table
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExportState_RestoreMidHandContinuesIdentically(t *testing.T) {
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	deck := mustCards(t,
+		"As", "Kd", "Qh", "Ah", "Kc", "Qs", // hole cards
+		"2c", "7d", "9h", "3s", "4d", // board
+	)
+	orig, err := NewTableForTest(harnessTestConfig(), deck, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	// Pause while seating so the first hand is dealt three-handed.
+	if err := orig.SubmitEvent(Event{Type: EventPause, UserID: 1}); err != nil {
+		t.Fatalf("pause err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2, 3} {
+		if err := orig.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if err := orig.SubmitEvent(Event{Type: EventResume, UserID: 1}); err != nil {
+		t.Fatalf("resume err: %v", err)
+	}
+
+	// Raise preflop, call, fold, then bet the flop.
+	script := []struct {
+		action holdem.ActionType
+		amount int64
+	}{
+		{holdem.PlayerActionTypeRaise, 300},
+		{holdem.PlayerActionTypeCall, 300},
+		{holdem.PlayerActionTypeFold, 0},
+		{holdem.PlayerActionTypeBet, 200},
+	}
+	for i, step := range script {
+		actOnTable(t, orig, step.action, step.amount, i)
+	}
+	clock.Advance(5 * time.Second)
+
+	exported, err := orig.ExportState()
+	if err != nil {
+		t.Fatalf("ExportState err: %v", err)
+	}
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("marshal state err: %v", err)
+	}
+	var state TableState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("unmarshal state err: %v", err)
+	}
+	restored, err := RestoreTableForTest(state, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("RestoreTableForTest err: %v", err)
+	}
+
+	assertSameTable(t, orig, restored)
+	if !restored.actionDeadline.Equal(orig.actionDeadline) || !restored.actionDeadline.After(clock.Now()) {
+		t.Fatalf("expected action deadline %v to survive restore, got %v", orig.actionDeadline, restored.actionDeadline)
+	}
+
+	// Call down to showdown on both tables.
+	for steps := 0; !orig.game.Snapshot().Ended; steps++ {
+		if steps > 20 {
+			t.Fatalf("hand did not reach showdown")
+		}
+		snap := orig.game.Snapshot()
+		action := holdem.PlayerActionTypeCheck
+		if acts, _, _, _ := orig.game.LegalActions(snap.ActionChair); !hasLegalAction(acts, holdem.PlayerActionTypeCheck) {
+			action = holdem.PlayerActionTypeCall
+		}
+		actOnTable(t, orig, action, snap.CurBet, steps)
+		actOnTable(t, restored, action, snap.CurBet, steps)
+	}
+	assertSameTable(t, orig, restored)
+	if got := restored.game.Snapshot(); !got.Ended || len(got.CommunityCards) != 5 {
+		t.Fatalf("expected restored hand to finish at showdown, got ended=%v board=%d", got.Ended, len(got.CommunityCards))
+	}
+	if len(restored.userHandTape[1]) == 0 || len(restored.userHandTape[1]) != len(orig.userHandTape[1]) {
+		t.Fatalf("expected hand tape to carry over, got %d vs %d events", len(restored.userHandTape[1]), len(orig.userHandTape[1]))
+	}
+}
+
+func TestExportState_KeepsOptInsProofsAndTournament(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.BlindSchedule = []BlindLevel{{SmallBlind: 50, BigBlind: 100}, {SmallBlind: 100, BigBlind: 200}}
+	orig, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	orig.cashOutUsers = map[uint64]bool{2: true}
+	orig.runItTwiceUsers = map[uint64]bool{1: true, 3: true}
+	orig.actionTimings = []ActionTiming{{Chair: 1, Phase: holdem.PhaseTypeFlop, Action: holdem.PlayerActionTypeBet, Elapsed: 3 * time.Second}}
+	orig.fairnessProofs = map[uint32]FairnessProof{
+		4: {Round: 4, HandID: "t_r4", Commitment: "c4", Seed: 44, Revealed: true},
+		5: {Round: 5, HandID: "t_r5", Commitment: "c5", Seed: 55},
+	}
+	orig.round = 5
+	orig.sng = &sngState{started: true, level: 1, levelHands: 2, levelStart: time.Unix(1_700_000_000, 0).UTC(), busted: []uint64{7}}
+
+	exported, err := orig.ExportState()
+	if err != nil {
+		t.Fatalf("ExportState err: %v", err)
+	}
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("marshal state err: %v", err)
+	}
+	var state TableState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("unmarshal state err: %v", err)
+	}
+	restored, err := RestoreTableForTest(state, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("RestoreTableForTest err: %v", err)
+	}
+
+	if !reflect.DeepEqual(restored.cashOutUsers, orig.cashOutUsers) || !reflect.DeepEqual(restored.runItTwiceUsers, orig.runItTwiceUsers) {
+		t.Fatalf("expected opt-ins to carry over, got cash out %v run twice %v", restored.cashOutUsers, restored.runItTwiceUsers)
+	}
+	if !reflect.DeepEqual(restored.actionTimings, orig.actionTimings) {
+		t.Fatalf("expected action timings to carry over, got %v", restored.actionTimings)
+	}
+	if !reflect.DeepEqual(restored.fairnessProofs, orig.fairnessProofs) {
+		t.Fatalf("expected fairness proofs to carry over, got %v", restored.fairnessProofs)
+	}
+	if got := restored.sng; got == nil || !got.started || got.level != 1 || got.levelHands != 2 ||
+		!got.levelStart.Equal(orig.sng.levelStart) || !reflect.DeepEqual(got.busted, orig.sng.busted) {
+		t.Fatalf("expected the tournament to carry over, got %+v", got)
+	}
+}
+
+func TestExportState_RefusedWhileRunningOut(t *testing.T) {
+	cfg := harnessTestConfig()
+	cfg.RunOutStreetDelay = 2 * time.Second
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 0)
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 1)
+
+	if _, err := tbl.ExportState(); !errors.Is(err, ErrHandRunningOut) {
+		t.Fatalf("expected export to wait for the run-out, got %v", err)
+	}
+	tbl.AdvanceClock(cfg.RunOutStreetDelay)
+	tbl.AdvanceClock(cfg.RunOutStreetDelay)
+	if _, err := tbl.ExportState(); err != nil {
+		t.Fatalf("expected export once the hand ended, got %v", err)
+	}
+}
+
+func TestRestoreTable_RejectsUnknownSeatUser(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	state, err := tbl.ExportState()
+	if err != nil {
+		t.Fatalf("ExportState err: %v", err)
+	}
+	state.Seats = map[uint16]uint64{0: 42}
+	if _, err := RestoreTableForTest(state, nil, NPCModeStep); err == nil {
+		t.Fatalf("expected seat without a player to be rejected")
+	}
+	if got := RestoreTable(state, func(uint64, []byte) {}, nil); got != nil {
+		t.Fatalf("expected RestoreTable to return nil for an inconsistent state")
+	}
+}
+
+func actOnTable(t *testing.T, tbl *Table, action holdem.ActionType, amount int64, step int) {
+	t.Helper()
+
+	chair := tbl.game.Snapshot().ActionChair
+	err := tbl.SubmitEvent(Event{
+		Type:   EventAction,
+		UserID: tbl.seats[chair],
+		Action: action,
+		Amount: amount,
+	})
+	if err != nil {
+		t.Fatalf("step %d: action %v chair=%d err: %v", step, action, chair, err)
+	}
+}
+
+func assertSameTable(t *testing.T, want, got *Table) {
+	t.Helper()
+
+	wantSnap, gotSnap := sortedSnapshot(want.game.Snapshot()), sortedSnapshot(got.game.Snapshot())
+	if !reflect.DeepEqual(wantSnap, gotSnap) {
+		t.Fatalf("engine snapshots differ:\nwant %+v\n got %+v", wantSnap, gotSnap)
+	}
+	for userID := range want.players {
+		w, g := want.buildTableSnapshotForUser(userID), got.buildTableSnapshotForUser(userID)
+		for _, pots := range [][]*pb.Pot{w.GetPots(), g.GetPots()} {
+			for _, pot := range pots {
+				sort.Slice(pot.EligibleChairs, func(i, j int) bool { return pot.EligibleChairs[i] < pot.EligibleChairs[j] })
+			}
+		}
+		if !proto.Equal(w, g) {
+			t.Fatalf("user %d table snapshots differ:\nwant %v\n got %v", userID, w, g)
+		}
+	}
+}
+
+// sortedSnapshot orders pot eligibility, which the engine builds from a map.
+func sortedSnapshot(s holdem.Snapshot) holdem.Snapshot {
+	for _, pot := range s.Pots {
+		sort.Slice(pot.EligiblePlayers, func(i, j int) bool { return pot.EligiblePlayers[i] < pot.EligiblePlayers[j] })
+	}
+	return s
+}
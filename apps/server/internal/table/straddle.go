@@ -0,0 +1,70 @@
+package table
+
+import (
+	"fmt"
+	"log"
+
+	"holdem-lite/holdem"
+)
+
+// straddleChairLocked returns the chair allowed to straddle the next hand.
+func (t *Table) straddleChairLocked() (uint16, bool) {
+	dealer, utg, ok := t.game.NextHandPositions()
+	if !ok {
+		return holdem.InvalidChair, false
+	}
+	if t.Config.StraddleOnButton {
+		return dealer, true
+	}
+	return utg, true
+}
+
+// handleStraddle records a straddle opt-in for the next hand. Only the
+// configured straddle seat may opt in, and it must be known ahead of the deal.
+func (t *Table) handleStraddle(userID uint64, chair uint16) error {
+	if !t.Config.AllowStraddle {
+		return fmt.Errorf("straddle not allowed at this table")
+	}
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return fmt.Errorf("player not seated")
+	}
+	if player.Chair != chair {
+		return fmt.Errorf("chair %d is not yours", chair)
+	}
+	want, ok := t.straddleChairLocked()
+	if !ok {
+		return fmt.Errorf("straddle seat for next hand is not known yet")
+	}
+	if chair != want {
+		seat := "UTG"
+		if t.Config.StraddleOnButton {
+			seat = "button"
+		}
+		return fmt.Errorf("straddle only allowed from the %s (chair %d)", seat, want)
+	}
+	if _, bigBlind, _ := t.blindsLocked(); player.Stack <= 2*bigBlind {
+		return fmt.Errorf("stack too short to straddle")
+	}
+	t.straddleUserID = userID
+	log.Printf("[Table %s] User %d will straddle next hand from chair %d", t.ID, userID, chair)
+	return nil
+}
+
+// applyStraddleIntentLocked hands a still-valid straddle opt-in to the engine
+// for the hand about to start. The opt-in covers one hand only.
+func (t *Table) applyStraddleIntentLocked() {
+	userID := t.straddleUserID
+	t.straddleUserID = 0
+	if userID == 0 || !t.Config.AllowStraddle {
+		return
+	}
+	player := t.players[userID]
+	if player == nil || player.Chair == holdem.InvalidChair {
+		return
+	}
+	if want, ok := t.straddleChairLocked(); !ok || want != player.Chair {
+		return
+	}
+	t.game.SetStraddle(player.Chair)
+}
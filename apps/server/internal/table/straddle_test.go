@@ -0,0 +1,179 @@
+package table
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// newStraddleTestTable seats four players and plays one hand to the end so
+// the next button (and therefore the straddle seat) is known.
+func newStraddleTestTable(t *testing.T, cfg TableConfig) *Table {
+	t.Helper()
+
+	game, err := holdem.NewGame(holdem.Config{
+		MaxPlayers: int(cfg.MaxPlayers),
+		MinPlayers: 2,
+		SmallBlind: cfg.SmallBlind,
+		BigBlind:   cfg.BigBlind,
+		Ante:       cfg.Ante,
+		Seed:       7,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+
+	tbl := &Table{
+		ID:                 "straddle_test",
+		Config:             cfg,
+		game:               game,
+		players:            make(map[uint64]*PlayerConn),
+		seats:              make(map[uint16]uint64),
+		handStartStacks:    make(map[uint16]int64),
+		pendingStandUps:    make(map[uint64]bool),
+		actionTimeoutChair: holdem.InvalidChair,
+		broadcast:          func(uint64, []byte) {},
+	}
+	for chair := uint16(0); chair < 4; chair++ {
+		userID := uint64(chair + 1)
+		if err := tbl.game.SitDown(chair, userID, 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+		tbl.players[userID] = &PlayerConn{UserID: userID, Chair: chair, Stack: 1000, Online: true}
+		tbl.seats[chair] = userID
+	}
+
+	if err := tbl.game.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	for {
+		_, result := foldCurrentActor(t, tbl)
+		if result != nil {
+			break
+		}
+	}
+	return tbl
+}
+
+func straddleTestConfig() TableConfig {
+	return TableConfig{
+		MaxPlayers:    6,
+		SmallBlind:    50,
+		BigBlind:      100,
+		MinBuyIn:      100,
+		MaxBuyIn:      1000,
+		AllowStraddle: true,
+	}
+}
+
+func TestHandleStraddle_UTGOptInPostsOnNextHand(t *testing.T) {
+	tbl := newStraddleTestTable(t, straddleTestConfig())
+
+	_, utg, ok := tbl.game.NextHandPositions()
+	if !ok {
+		t.Fatalf("expected next hand positions to be known")
+	}
+	if err := tbl.handleStraddle(tbl.seats[utg], utg); err != nil {
+		t.Fatalf("handleStraddle err: %v", err)
+	}
+	if err := tbl.handleStartHand(); err != nil {
+		t.Fatalf("handleStartHand err: %v", err)
+	}
+
+	snap := tbl.game.Snapshot()
+	if snap.StraddleChair != utg {
+		t.Fatalf("expected straddle from chair %d, got %d", utg, snap.StraddleChair)
+	}
+	if snap.CurBet != 200 {
+		t.Fatalf("expected current bet 200 after straddle, got %d", snap.CurBet)
+	}
+	for _, ps := range snap.Players {
+		if ps.Chair == utg && ps.Bet != 200 {
+			t.Fatalf("expected straddler bet 200, got %d", ps.Bet)
+		}
+	}
+	if snap.ActionChair == utg || snap.ActionChair == holdem.InvalidChair {
+		t.Fatalf("expected action left of the straddler, got chair %d", snap.ActionChair)
+	}
+	if tbl.straddleUserID != 0 {
+		t.Fatalf("expected straddle intent to be consumed")
+	}
+}
+
+func TestHandleStraddle_RejectsWrongSeatAndDisabledTable(t *testing.T) {
+	tbl := newStraddleTestTable(t, straddleTestConfig())
+
+	dealer, utg, ok := tbl.game.NextHandPositions()
+	if !ok {
+		t.Fatalf("expected next hand positions to be known")
+	}
+	if dealer == utg {
+		t.Fatalf("expected distinct button and UTG with four players")
+	}
+	if err := tbl.handleStraddle(tbl.seats[dealer], dealer); err == nil {
+		t.Fatalf("expected straddle from non-UTG chair %d to be rejected", dealer)
+	}
+	if err := tbl.handleStraddle(tbl.seats[dealer], utg); err == nil {
+		t.Fatalf("expected straddle for another player's chair to be rejected")
+	}
+
+	tbl.Config.AllowStraddle = false
+	if err := tbl.handleStraddle(tbl.seats[utg], utg); err == nil {
+		t.Fatalf("expected straddle to be rejected when the table disallows it")
+	}
+	if tbl.straddleUserID != 0 {
+		t.Fatalf("expected no straddle intent after rejections")
+	}
+}
+
+func TestHandleStraddle_ButtonSeatWhenConfigured(t *testing.T) {
+	cfg := straddleTestConfig()
+	cfg.StraddleOnButton = true
+	tbl := newStraddleTestTable(t, cfg)
+
+	dealer, utg, _ := tbl.game.NextHandPositions()
+	if err := tbl.handleStraddle(tbl.seats[utg], utg); err == nil {
+		t.Fatalf("expected UTG straddle to be rejected on a button-straddle table")
+	}
+	if err := tbl.handleStraddle(tbl.seats[dealer], dealer); err != nil {
+		t.Fatalf("handleStraddle err: %v", err)
+	}
+	if err := tbl.handleStartHand(); err != nil {
+		t.Fatalf("handleStartHand err: %v", err)
+	}
+	if snap := tbl.game.Snapshot(); snap.StraddleChair != dealer || snap.DealerChair != dealer {
+		t.Fatalf("expected button %d to straddle, got dealer=%d straddle=%d", dealer, snap.DealerChair, snap.StraddleChair)
+	}
+}
+
+func TestHandStart_ReportsAnteStraddleAndForcedTotal(t *testing.T) {
+	cfg := straddleTestConfig()
+	cfg.Ante = 10
+	tbl := newStraddleTestTable(t, cfg)
+	var starts []*pb.HandStart
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if hs := decodeServerEnvelope(t, data).GetHandStart(); hs != nil && userID == 1 {
+			starts = append(starts, hs)
+		}
+	}
+
+	_, utg, ok := tbl.game.NextHandPositions()
+	if !ok {
+		t.Fatalf("expected next hand positions to be known")
+	}
+	if err := tbl.handleStraddle(tbl.seats[utg], utg); err != nil {
+		t.Fatalf("handleStraddle err: %v", err)
+	}
+	if err := tbl.handleStartHand(); err != nil {
+		t.Fatalf("handleStartHand err: %v", err)
+	}
+	if len(starts) != 1 {
+		t.Fatalf("expected one HandStart, got %d", len(starts))
+	}
+	hs := starts[0]
+	// Four antes of 10, blinds of 50 and 100, and a 200 straddle.
+	if hs.GetAnteAmount() != 10 || hs.GetStraddleChair() != uint32(utg) || hs.GetStraddleAmount() != 200 || hs.GetForcedTotal() != 390 {
+		t.Fatalf("expected ante 10, straddle 200 from chair %d and 390 forced, got %+v", utg, hs)
+	}
+}
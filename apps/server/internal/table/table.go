@@ -43,26 +43,89 @@ type Table struct {
 	serverSeq uint64
 
 	// Timers and lifecycle metadata.
+	clock              Clock
 	actionTimeoutChair uint16
 	actionDeadline     time.Time
 	nextHandAt         time.Time
 	emptySince         time.Time
 
+	// Pot totals of settled hands, for lobby listings.
+	settledPots  int64
+	settledHands int64
+
 	// Callback to broadcast messages
 	broadcast    func(userID uint64, data []byte)
+	pacer        *broadcastPacer
 	ledger       ledger.Service
 	handID       string
 	userHandTape map[uint64][]ledger.EventItem
 
 	// NPC support
-	npcManager *npc.Manager
+	npcManager     *npc.Manager
+	npcMode        NPCMode
+	pendingNPCStep func() Event
+
+	// inline tables handle events on the caller's goroutine (no actor loop).
+	inline bool
 
 	// Optional callbacks invoked after each hand settles.
 	handEndHooks []HandEndHook
+	// Optional callback invoked once the session ends.
+	sessionEndHook SessionEndHook
+
+	// Sit-N-Go progress and result callback (see TableConfig.BlindSchedule).
+	sng               *sngState
+	tournamentEndHook TournamentEndHook
 
 	// Users who requested stand-up after folding in an active hand.
 	// These are executed right after the hand settles.
 	pendingStandUps map[uint64]bool
+
+	// User who opted in to straddle the next hand (0 if none).
+	straddleUserID uint64
+
+	// Users who opted in to cash out if the current hand runs out all-in.
+	cashOutUsers map[uint64]bool
+	// runItTwiceUsers agreed to run the current hand's board twice.
+	runItTwiceUsers map[uint64]bool
+	// runOut is the all-in run-out still being dealt street by street.
+	runOut *stagedRunOut
+	// runItTwiceOffer holds an all-in hand back until its players have
+	// answered whether to run it twice.
+	runItTwiceOffer *runItTwiceOffer
+	// snapshotRequests is when each user last asked for a fresh snapshot.
+	snapshotRequests map[uint64]time.Time
+
+	// Messages to spectators waiting out Config.SpectatorDelay, oldest first.
+	spectatorTape []delayedMessage
+
+	// Last turn the action timeout played, to recognise late client actions.
+	lastAutoAction autoActionMark
+
+	// Turn timing for the current hand (see ActionTiming).
+	promptChair   uint16
+	promptedAt    time.Time
+	actionTimings []ActionTiming
+
+	// Buy-ins requested by seated players, added at the next hand start.
+	pendingBuyIn map[uint16]int64
+
+	// Seed commitments of recent hands by round.
+	fairnessProofs map[uint32]FairnessProof
+
+	// Optional per-viewer color tags for table snapshots, cached by viewer
+	// so the actor never waits on the tagger.
+	tagger           OpponentTagger
+	tagGeneration    uint64
+	opponentTagCache map[uint64]map[uint64]string
+
+	// Lone-player tracking (see LonePlayerPolicy).
+	loneSince         time.Time
+	loneRefillPending bool
+	lonePlayerHook    LonePlayerHook
+
+	// Set while HeadsUpDisconnectPause is holding the next hand.
+	headsUpHeld bool
 }
 
 // TableConfig contains table settings
@@ -73,6 +136,105 @@ type TableConfig struct {
 	Ante       int64
 	MinBuyIn   int64
 	MaxBuyIn   int64
+
+	// ChipUnit is the smallest chip in play: buy-ins and bet or raise sizes
+	// are rounded down to a multiple of it, and up when that would fall short
+	// of the minimum raise (0 or 1 disables). With RejectUnalignedBuyIn a
+	// buy-in that is not a multiple is refused instead of rounded.
+	ChipUnit             int64
+	RejectUnalignedBuyIn bool
+
+	// MinBroadcastInterval spaces consecutive messages to each user by at
+	// least this long, preserving order (0 sends immediately).
+	MinBroadcastInterval time.Duration
+
+	// SpectatorDelay holds back everything sent to unseated viewers by this
+	// long so they cannot relay the live action to a player (0 disables).
+	// Seated players are unaffected.
+	SpectatorDelay time.Duration
+
+	// BlindSchedule makes the table a Sit-N-Go: everyone starts with
+	// MaxBuyIn, the first hand waits until every seat is taken, blinds follow
+	// the schedule instead of SmallBlind/BigBlind/Ante, there are no re-buys
+	// or late seats, and busted players are eliminated until one is left.
+	BlindSchedule []BlindLevel
+
+	// RunOutStreetDelay stages an all-in run-out: the first street still to
+	// come is dealt at once and each later one this long after the previous,
+	// with the showdown alongside the river (0 deals them all at once).
+	RunOutStreetDelay time.Duration
+
+	// MaxSpectators caps how many connected viewers may watch without a seat;
+	// joins beyond it fail with ErrSpectatorsFull (0 for no limit).
+	MaxSpectators int
+
+	// LonePlayerGrace is how long a single seated human may wait without
+	// opponents before LonePlayerPolicy applies (0 disables).
+	LonePlayerGrace  time.Duration
+	LonePlayerPolicy LonePlayerPolicy
+
+	// AllowStraddle lets a player opt in to a 2x BB blind straddle for the
+	// next hand; StraddleOnButton moves the straddle seat from UTG to the button.
+	AllowStraddle    bool
+	StraddleOnButton bool
+
+	// OfflineSeatGrace is how long a disconnected player keeps their seat
+	// between hands before being stood up (0 uses the 30s default). The timer
+	// is held while the player is still live in the current hand.
+	OfflineSeatGrace time.Duration
+
+	// HeadsUpDisconnectPause holds new hands while one of exactly two seated
+	// players is offline, instead of dealing them in to be blinded and
+	// auto-folded. Play resumes on reconnect; once OfflineSeatGrace runs out
+	// the absent player is stood up as usual.
+	HeadsUpDisconnectPause bool
+
+	// AutoActOffline plays a disconnected player's turns as soon as they come
+	// up, like a sitting-out player's: check when it is free, otherwise fold.
+	// Online players always get the full action timer.
+	AutoActOffline bool
+
+	// RabbitHunt follows a fold win with the board cards that were never
+	// dealt, for display only.
+	RabbitHunt bool
+
+	// HighCardDraw places the first button by dealing every seat a card face
+	// up, high card wins, instead of picking a seat at random. The draw is
+	// broadcast as DealerDraw ahead of the first HandStart.
+	HighCardDraw bool
+
+	// SnapshotPrivacy withholds selected per-player fields from table
+	// snapshots until showdown (0 shows everything).
+	SnapshotPrivacy SnapshotPrivacy
+
+	// AllowCashOut lets a player opt in to be paid their all-in equity, less
+	// CashOutMarginPercent for the house, instead of the runout.
+	AllowCashOut         bool
+	CashOutMarginPercent float64
+
+	// AllowRunItTwice deals the rest of an all-in board twice, splitting each
+	// pot between the runs, when every player left in the hand agrees.
+	AllowRunItTwice bool
+	// RunItTwiceOfferTimeout asks at the all-in instead of relying on players
+	// agreeing beforehand: the runout waits while a RunItTwiceOffer goes to
+	// everyone left in the hand, and the board runs twice only if all of them
+	// accept within this long. Earlier EventRunItTwice agreements still count.
+	RunItTwiceOfferTimeout time.Duration
+
+	// MaxHandsPerSession ends the session once this many hands have settled:
+	// every player is cashed out, SessionEnd is broadcast and the table
+	// closes (0 for no limit).
+	MaxHandsPerSession uint32
+
+	// Rake kept by the house from each contested pot, capped per hand at
+	// RakeCapBB big blinds; see holdem.Config.
+	RakePercent    float64
+	RakeCapBB      int64
+	RakeOnlyAtFlop bool
+	// Seed for the table's shuffle generator; 0 picks a time-based one. Only
+	// seeded tables derive their deck seeds from it; otherwise each hand's
+	// seed comes from crypto/rand (see FairnessProof).
+	Seed int64
 }
 
 // PlayerConn represents a connected player at the table
@@ -83,8 +245,10 @@ type PlayerConn struct {
 	Chair     uint16
 	Stack     int64
 	Wallet    int64 // Chips not yet at table
-	Online    bool
-	LastSeen  time.Time
+	// SittingOut keeps the chair but deals the player out of new hands.
+	SittingOut bool
+	Online     bool
+	LastSeen   time.Time
 }
 
 // Event types for the actor message queue
@@ -103,6 +267,14 @@ const (
 	EventPause
 	EventResume
 	EventClose
+	EventStraddle
+	EventCashOut
+	EventSitOut
+	EventSitIn
+	EventRunItTwice
+	EventRequestSnapshot
+	EventObserve
+	EventRunItTwiceAccept
 )
 
 // Event represents a message to the table actor
@@ -113,6 +285,8 @@ type Event struct {
 	Chair     uint16
 	Amount    int64
 	Action    holdem.ActionType
+	Preset    SizingPreset
+	Accept    bool
 	Timestamp time.Time
 	Response  chan error
 }
@@ -130,11 +304,22 @@ type HandEndHook func(info HandEndInfo)
 
 var ErrTableClosed = errors.New("table closed")
 
+// ErrHandRunningOut answers a stand-up or a state export while an all-in
+// hand is still running out or waiting on a run it twice offer.
+var ErrHandRunningOut = errors.New("hand is still running out")
+
+// ErrActionAlreadyResolved answers an action that lost the race with the
+// timeout auto-acting for the same player.
+var ErrActionAlreadyResolved = errors.New("action already resolved")
+
 const (
 	actionTimeLimitSec = int32(30)
 	showdownHandDelay  = 8 * time.Second
 	foldHandDelay      = 3 * time.Second
 	offlineSeatTTL     = 30 * time.Second
+	// lateActionWindow is how long after a timeout auto-action a late client
+	// action for that turn is reported as already resolved.
+	lateActionWindow = 2 * time.Second
 )
 
 // New creates a new table
@@ -156,6 +341,7 @@ func New(
 		broadcast:          broadcastFn,
 		ledger:             ledgerService,
 		actionTimeoutChair: holdem.InvalidChair,
+		clock:              realClock{},
 		emptySince:         time.Now(),
 		userHandTape:       make(map[uint64][]ledger.EventItem),
 		pendingStandUps:    make(map[uint64]bool),
@@ -166,17 +352,25 @@ func New(
 
 	// Create game engine
 	game, err := holdem.NewGame(holdem.Config{
-		MaxPlayers: int(cfg.MaxPlayers),
-		MinPlayers: 2,
-		SmallBlind: cfg.SmallBlind,
-		BigBlind:   cfg.BigBlind,
-		Ante:       cfg.Ante,
+		MaxPlayers:      int(cfg.MaxPlayers),
+		MinPlayers:      2,
+		SmallBlind:      cfg.SmallBlind,
+		BigBlind:        cfg.BigBlind,
+		Ante:            cfg.Ante,
+		RakePercent:     cfg.RakePercent,
+		RakeCapBB:       cfg.RakeCapBB,
+		RakeOnlyAtFlop:  cfg.RakeOnlyAtFlop,
+		Seed:            cfg.Seed,
+		DealerSelection: cfg.dealerSelection(),
 	})
 	if err != nil {
 		log.Printf("[Table %s] Failed to create game: %v", id, err)
 		return nil
 	}
 	t.game = game
+	if cfg.MinBroadcastInterval > 0 {
+		t.pacer = newBroadcastPacer(cfg.MinBroadcastInterval, t.clock, broadcastFn, t.done)
+	}
 
 	// Start actor goroutine
 	go t.run()
@@ -188,7 +382,11 @@ func New(
 // run is the main actor loop
 func (t *Table) run() {
 	// Sub-second heartbeat for action timeout and inter-hand scheduling.
-	ticker := time.NewTicker(500 * time.Millisecond)
+	clock := t.clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	ticker := clock.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
@@ -198,7 +396,7 @@ func (t *Table) run() {
 			if event.Response != nil {
 				event.Response <- err
 			}
-		case <-ticker.C:
+		case <-ticker.C():
 			t.tick()
 		case <-t.done:
 			log.Printf("[Table %s] Actor stopped", t.ID)
@@ -226,7 +424,7 @@ func (t *Table) handleEvent(e Event) error {
 	case EventBuyIn:
 		return t.handleBuyIn(e.UserID, e.Amount)
 	case EventAction:
-		return t.handleAction(e.UserID, e.Action, e.Amount)
+		return t.handleAction(e.UserID, e.Action, t.resolveActionAmount(e.UserID, e.Action, e.Amount, e.Preset))
 	case EventTimeout:
 		return t.handleTimeout(e.Timestamp)
 	case EventStartHand:
@@ -242,15 +440,45 @@ func (t *Table) handleEvent(e Event) error {
 	case EventClose:
 		t.stopLocked()
 		return nil
+	case EventStraddle:
+		return t.handleStraddle(e.UserID, e.Chair)
+	case EventCashOut:
+		return t.handleCashOut(e.UserID)
+	case EventRunItTwice:
+		return t.handleRunItTwice(e.UserID)
+	case EventRunItTwiceAccept:
+		return t.handleRunItTwiceAccept(e.UserID, e.Accept)
+	case EventSitOut:
+		return t.handleSitOut(e.UserID)
+	case EventSitIn:
+		return t.handleSitIn(e.UserID)
+	case EventRequestSnapshot:
+		return t.handleRequestSnapshot(e.UserID)
+	case EventObserve:
+		return t.handleObserve(e.UserID, e.Nickname)
 	default:
 		return fmt.Errorf("unknown event type: %d", e.Type)
 	}
 }
 
 func (t *Table) handleJoinTable(userID uint64, nickname string) error {
-	now := time.Now()
+	return t.joinLocked(userID, nickname, true)
+}
+
+// handleObserve joins userID as a spectator: they get the public snapshot and
+// every table broadcast but are not seated, so they take no chair and cannot
+// act until they sit down.
+func (t *Table) handleObserve(userID uint64, nickname string) error {
+	return t.joinLocked(userID, nickname, false)
+}
+
+func (t *Table) joinLocked(userID uint64, nickname string, autoSit bool) error {
+	now := t.now()
 	resolvedNickname := normalizeNickname(nickname, userID)
 	if player, exists := t.players[userID]; exists {
+		if !player.Online && player.Chair == holdem.InvalidChair && t.spectatorsFullLocked(userID) {
+			return ErrSpectatorsFull
+		}
 		player.Online = true
 		player.LastSeen = now
 		player.Nickname = resolvedNickname
@@ -269,7 +497,7 @@ func (t *Table) handleJoinTable(userID uint64, nickname string) error {
 	log.Printf("[Table %s] Player %d joined", t.ID, userID)
 
 	// Automatic sit-down if not seated
-	for i := uint16(0); i < t.Config.MaxPlayers; i++ {
+	for i := uint16(0); autoSit && i < t.Config.MaxPlayers; i++ {
 		if t.seats[i] == 0 {
 			// Found empty seat
 			log.Printf("[Table %s] Auto-sitting player %d at chair %d", t.ID, userID, i)
@@ -279,9 +507,15 @@ func (t *Table) handleJoinTable(userID uint64, nickname string) error {
 			break
 		}
 	}
+	if t.players[userID].Chair == holdem.InvalidChair && t.spectatorsFullLocked(userID) {
+		delete(t.players, userID)
+		log.Printf("[Table %s] Player %d refused: spectator limit %d reached", t.ID, userID, t.Config.MaxSpectators)
+		return ErrSpectatorsFull
+	}
 
 	t.sendSnapshot(userID)
 	t.sendPromptIfActingUser(userID)
+	t.loadOpponentTagsLocked(userID)
 	return nil
 }
 
@@ -299,6 +533,16 @@ func (t *Table) handleSitDown(userID uint64, chair uint16, buyIn int64) error {
 	if t.seats[chair] != 0 {
 		return fmt.Errorf("chair %d is occupied", chair)
 	}
+	if s := t.sngLocked(); s != nil {
+		if s.started {
+			return ErrTournamentStarted
+		}
+		buyIn = t.Config.MaxBuyIn
+	}
+	buyIn, err := t.Config.snapBuyIn(buyIn)
+	if err != nil {
+		return err
+	}
 	if buyIn < t.Config.MinBuyIn || buyIn > t.Config.MaxBuyIn {
 		return fmt.Errorf("invalid buy-in amount: %d (range: %d-%d)", buyIn, t.Config.MinBuyIn, t.Config.MaxBuyIn)
 	}
@@ -311,7 +555,7 @@ func (t *Table) handleSitDown(userID uint64, chair uint16, buyIn int64) error {
 	player.Chair = chair
 	player.Stack = buyIn
 	player.Online = true
-	player.LastSeen = time.Now()
+	player.LastSeen = t.now()
 	t.seats[chair] = userID
 	delete(t.pendingStandUps, userID)
 	t.updateEmptySinceLocked(player.LastSeen)
@@ -335,6 +579,11 @@ func (t *Table) handleStandUp(userID uint64) error {
 		delete(t.pendingStandUps, userID)
 		return nil
 	}
+	// The engine has already settled the hand, so it would let the seat
+	// leave with chips the table has yet to show being won.
+	if t.runOut != nil || t.runItTwiceOffer != nil {
+		return ErrHandRunningOut
+	}
 
 	chair := player.Chair
 	if err := t.game.StandUp(chair); err != nil {
@@ -354,12 +603,14 @@ func (t *Table) handleStandUp(userID uint64) error {
 		return err
 	}
 	delete(t.pendingStandUps, userID)
+	delete(t.pendingBuyIn, chair)
 
 	delete(t.seats, chair)
 	player.Chair = holdem.InvalidChair
 	player.Wallet += player.Stack
 	player.Stack = 0
-	player.LastSeen = time.Now()
+	player.SittingOut = false
+	player.LastSeen = t.now()
 	t.updateEmptySinceLocked(player.LastSeen)
 	if len(t.seats) < 2 {
 		t.nextHandAt = time.Time{}
@@ -367,18 +618,76 @@ func (t *Table) handleStandUp(userID uint64) error {
 
 	log.Printf("[Table %s] Player %d stood up from chair %d", t.ID, userID, chair)
 	t.broadcastSeatLeft(chair, userID)
+	t.recordEliminationLocked(userID)
+	t.checkTournamentOverLocked()
 	return nil
 }
 
+// handleBuyIn queues chips for a seated player. They are added at the next
+// hand start so a stack never changes mid-hand; the total is clamped to
+// MaxBuyIn.
 func (t *Table) handleBuyIn(userID uint64, amount int64) error {
 	player := t.players[userID]
 	if player == nil {
 		return fmt.Errorf("player not in table")
 	}
-	// TODO: Implement pending buy-in for mid-hand
+	if player.Chair == holdem.InvalidChair {
+		return fmt.Errorf("player not seated")
+	}
+	if t.Config.tournament() {
+		return ErrNoRebuys
+	}
+	if amount <= 0 {
+		return fmt.Errorf("invalid buy-in amount: %d", amount)
+	}
+	room := t.Config.MaxBuyIn - player.Stack - t.pendingBuyIn[player.Chair]
+	if room <= 0 {
+		return fmt.Errorf("stack already at max buy-in %d", t.Config.MaxBuyIn)
+	}
+	if amount > room {
+		amount = room
+	}
+	amount, err := t.Config.snapBuyIn(amount)
+	if err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return fmt.Errorf("buy-in below the %d-chip unit", t.Config.ChipUnit)
+	}
+	if t.pendingBuyIn == nil {
+		t.pendingBuyIn = make(map[uint16]int64)
+	}
+	t.pendingBuyIn[player.Chair] += amount
+	log.Printf("[Table %s] User %d queued buy-in of %d at chair %d", t.ID, userID, amount, player.Chair)
 	return nil
 }
 
+// applyPendingBuyInsLocked adds queued buy-ins to the engine stacks before a
+// deal. Winnings since the request count against MaxBuyIn.
+func (t *Table) applyPendingBuyInsLocked() {
+	for chair, amount := range t.pendingBuyIn {
+		delete(t.pendingBuyIn, chair)
+		userID := t.seats[chair]
+		player := t.players[userID]
+		if player == nil {
+			continue
+		}
+		if room := t.Config.MaxBuyIn - player.Stack; amount > room {
+			amount = room
+		}
+		if amount <= 0 {
+			continue
+		}
+		if err := t.game.AddStack(chair, amount); err != nil {
+			log.Printf("[Table %s] buy-in for chair %d failed: %v", t.ID, chair, err)
+			continue
+		}
+		player.Stack += amount
+		log.Printf("[Table %s] User %d bought in for %d at chair %d", t.ID, userID, amount, chair)
+		t.broadcastStackChange(chair, player.Stack)
+	}
+}
+
 func (t *Table) handleAction(userID uint64, action holdem.ActionType, amount int64) error {
 	if t.paused {
 		return fmt.Errorf("table is paused")
@@ -391,6 +700,9 @@ func (t *Table) handleAction(userID uint64, action holdem.ActionType, amount int
 
 	before := t.game.Snapshot()
 	if before.ActionChair != player.Chair {
+		if t.lateForAutoActionLocked(player.Chair, before.Round) {
+			return ErrActionAlreadyResolved
+		}
 		return fmt.Errorf("not your turn")
 	}
 	// Client call amount may arrive as either total-to amount or delta-to-call.
@@ -398,62 +710,105 @@ func (t *Table) handleAction(userID uint64, action holdem.ActionType, amount int
 	if action == holdem.PlayerActionTypeCall {
 		amount = before.CurBet
 	}
+	amount = t.snapBetLocked(player.Chair, action, amount)
 
 	result, err := t.game.Act(player.Chair, action, amount)
 	if err != nil {
 		return err
 	}
+	t.recordActionTimingLocked(player.Chair, before.Phase, action)
 	if t.actionTimeoutChair == player.Chair {
 		t.clearActionTimeoutLocked()
 	}
 	after := t.game.Snapshot()
-	t.syncPlayerStacksFromSnapshot(after)
+	if result != nil {
+		// Payouts reach the seats with the hand end, not with the action.
+		t.syncPlayerStacksFromSnapshot(closedView(after, result, before.CommunityCards))
+	} else {
+		t.syncPlayerStacksFromSnapshot(after)
+	}
 
 	log.Printf("[Table %s] Player %d action: %v amount: %d", t.ID, userID, action, amount)
 
 	// Broadcast action result
 	t.broadcastActionResult(player.Chair, action, before, after, result)
-	t.broadcastStreetStateTransitions(before, after)
-	if potsChanged(before.Pots, after.Pots) {
-		t.broadcastPotUpdate(after.Pots)
+	if bet, ok := t.game.UncalledReturn(); ok {
+		t.broadcastUncalledReturn(bet)
+	}
+	if t.offerRunItTwiceLocked(before, after, result) {
+		return nil
+	}
+	t.continueAfterActionLocked(before, after, result)
+	return nil
+}
+
+// continueAfterActionLocked deals the streets an action closed, then settles
+// the hand or prompts the next player.
+func (t *Table) continueAfterActionLocked(before, after holdem.Snapshot, result *holdem.SettlementResult) {
+	staged := t.stageRunOutLocked(before, after, result)
+	if !staged {
+		t.broadcastStreetStateTransitions(before, after)
+	}
+	if staged {
+		// The engine has already paid the pots out; show them as collected.
+		if view := t.runOut.view(t.runOut.shown); potsChanged(before.Pots, view.Pots) {
+			t.broadcastPotUpdate(view.Pots, view)
+		}
+	} else if potsChanged(before.Pots, after.Pots) {
+		t.broadcastPotUpdate(after.Pots, after)
 	}
 
 	// Check if hand ended
-	if result != nil {
-		t.handleHandEnd(result)
+	if staged {
+		t.advanceRunOutLocked()
+	} else if result != nil {
+		t.finishHandLocked(before.CommunityCards, result)
 	} else {
 		// Prompt next player
 		if after.ActionChair != holdem.InvalidChair {
 			t.sendActionPrompt(after.ActionChair)
 		}
 	}
+}
 
-	return nil
+// finishHandLocked settles a hand whose action closed on board, the
+// community cards at that point.
+func (t *Table) finishHandLocked(board []card.Card, result *holdem.SettlementResult) {
+	result = t.applyRunItTwiceLocked(board, result)
+	t.applyCashOutsLocked(board, result)
+	t.handleHandEnd(result)
 }
 
 func (t *Table) handleStartHand() error {
 	if t.closed {
 		return ErrTableClosed
 	}
-	if len(t.seats) < 2 {
+	if len(t.seats) < 2 || t.runOut != nil || t.runItTwiceOffer != nil {
 		return nil
 	}
 	t.nextHandAt = time.Time{}
 	t.clearActionTimeoutLocked()
 
 	log.Printf("[Table %s] handleStartHand called, seats=%d", t.ID, len(t.seats))
+	t.applyPendingBuyInsLocked()
 	before := t.game.Snapshot()
 	t.handStartStacks = make(map[uint16]int64, len(before.Players))
 	for _, ps := range before.Players {
 		t.handStartStacks[ps.Chair] = ps.Stack
 	}
 
+	t.applyStraddleIntentLocked()
+	t.cashOutUsers = nil
+	t.runItTwiceUsers = nil
+	t.actionTimings = nil
+	t.advanceBlindLevelLocked(t.now())
 	if err := t.game.StartHand(); err != nil {
 		log.Printf("[Table %s] StartHand failed: %v", t.ID, err)
 		return err
 	}
 	t.round++
 	t.handID = t.buildHandID()
+	t.commitHandSeedLocked()
 	t.userHandTape = make(map[uint64][]ledger.EventItem, len(t.seats))
 	t.appendReplayBootstrapSnapshots()
 
@@ -461,7 +816,8 @@ func (t *Table) handleStartHand() error {
 	t.syncPlayerStacksFromSnapshot(snap)
 	log.Printf("[Table %s] Hand %d started. Dealer: %d, Action: %d", t.ID, t.round, snap.DealerChair, snap.ActionChair)
 
-	// Broadcast hand start
+	// Broadcast hand start, after the draw for the button if there was one
+	t.broadcastDealerDraw(snap.DealerChair)
 	t.broadcastHandStart()
 
 	// Send hole cards to each player
@@ -477,16 +833,21 @@ func (t *Table) handleStartHand() error {
 
 func (t *Table) handleHandEnd(result *holdem.SettlementResult) {
 	log.Printf("[Table %s] Hand ended. Winners: %v", t.ID, result)
-	endedAt := time.Now().UTC()
+	endedAt := t.now().UTC()
 	handID := t.handID
 
 	// Broadcast showdown/hand end
 	t.broadcastHandEnd(result)
 	t.clearActionTimeoutLocked()
+	t.recordPotLocked(result)
 	t.persistLiveHandHistory(handID, endedAt, result)
 	t.dispatchHandEndHooks(result)
 	t.handID = ""
 	t.processDeferredStandUpsLocked()
+	t.eliminateBustedLocked()
+	if t.closed {
+		return
+	}
 
 	// Schedule next hand from actor tick (no goroutine self-submit).
 	if len(t.seats) >= 2 {
@@ -494,10 +855,13 @@ func (t *Table) handleHandEnd(result *holdem.SettlementResult) {
 		if hasShowdownHands(result) {
 			delay = showdownHandDelay
 		}
-		t.nextHandAt = time.Now().Add(delay)
+		t.nextHandAt = t.now().Add(delay)
 	} else {
 		t.nextHandAt = time.Time{}
 	}
+	if limit := t.Config.MaxHandsPerSession; limit > 0 && t.round >= limit {
+		t.endSessionLocked()
+	}
 }
 
 func (t *Table) canDeferStandUpLocked(chair uint16) bool {
@@ -560,27 +924,45 @@ func (t *Table) tick() {
 	if t.closed {
 		return
 	}
+	now := t.now()
+	t.releaseSpectatorTapeLocked(now)
 	if t.paused {
 		return
 	}
-	now := time.Now()
 	if err := t.handleTimeout(now); err != nil {
 		log.Printf("[Table %s] timeout handler failed: %v", t.ID, err)
 	}
+	if t.runItTwiceOffer != nil && !now.Before(t.runItTwiceOffer.due) {
+		log.Printf("[Table %s] Run it twice offer timed out", t.ID)
+		t.resolveRunItTwiceOfferLocked(false)
+	}
+	if t.runOut != nil && !now.Before(t.runOut.due) {
+		t.advanceRunOutLocked()
+	}
 	t.releaseOfflineSeats(now)
 	if !t.nextHandAt.IsZero() && !now.Before(t.nextHandAt) {
 		if err := t.tryStartHand(now); err != nil {
 			log.Printf("[Table %s] delayed hand start failed: %v", t.ID, err)
 		}
 	}
+	t.checkLonePlayerLocked(now)
 }
 
 func (t *Table) releaseOfflineSeats(now time.Time) {
+	grace := t.offlineSeatGrace()
+	snap := t.game.Snapshot()
+	handLive := snap.Round > 0 && !snap.Ended && snap.Phase != holdem.PhaseTypeRoundEnd
 	for userID, player := range t.players {
-		if player == nil || player.Online || player.Chair == holdem.InvalidChair {
+		if player == nil || player.Online || player.Chair == holdem.InvalidChair || player.SittingOut {
+			continue
+		}
+		if handLive && liveInHand(snap, player.Chair) {
+			// Chips still in play: hold the seat and restart the grace once
+			// the hand is over.
+			player.LastSeen = now
 			continue
 		}
-		if now.Sub(player.LastSeen) < offlineSeatTTL {
+		if now.Sub(player.LastSeen) < grace {
 			continue
 		}
 		if err := t.handleStandUp(userID); err != nil {
@@ -589,8 +971,25 @@ func (t *Table) releaseOfflineSeats(now time.Time) {
 			log.Printf("[Table %s] auto-standup failed for offline user %d: %v", t.ID, userID, err)
 			continue
 		}
-		log.Printf("[Table %s] Auto-stood offline user %d after %s", t.ID, userID, offlineSeatTTL)
+		log.Printf("[Table %s] Auto-stood offline user %d after %s", t.ID, userID, grace)
+	}
+}
+
+func (t *Table) offlineSeatGrace() time.Duration {
+	if t.Config.OfflineSeatGrace > 0 {
+		return t.Config.OfflineSeatGrace
+	}
+	return offlineSeatTTL
+}
+
+// liveInHand reports whether chair was dealt into the hand and has not folded.
+func liveInHand(snap holdem.Snapshot, chair uint16) bool {
+	for _, ps := range snap.Players {
+		if ps.Chair == chair {
+			return len(ps.HandCards) > 0 && !ps.Folded
+		}
 	}
+	return false
 }
 
 func (t *Table) handleTimeout(now time.Time) error {
@@ -618,11 +1017,29 @@ func (t *Table) handleTimeout(now time.Time) error {
 		return err
 	}
 	log.Printf("[Table %s] Action timeout chair=%d user=%d -> auto %v amount=%d", t.ID, chair, userID, autoAction, autoAmount)
-	return t.handleAction(userID, autoAction, autoAmount)
+	if err := t.handleAction(userID, autoAction, autoAmount); err != nil {
+		return err
+	}
+	t.lastAutoAction = autoActionMark{chair: chair, round: snap.Round, at: now}
+	return nil
+}
+
+// autoActionMark remembers the turn the timeout last played for a player.
+type autoActionMark struct {
+	chair uint16
+	round uint16
+	at    time.Time
+}
+
+// lateForAutoActionLocked reports whether chair's turn in round was just
+// taken by the timeout, so an action arriving now crossed it in flight.
+func (t *Table) lateForAutoActionLocked(chair uint16, round uint16) bool {
+	m := t.lastAutoAction
+	return !m.at.IsZero() && m.chair == chair && m.round == round && t.now().Sub(m.at) < lateActionWindow
 }
 
 func (t *Table) pickTimeoutAction(chair uint16, snap holdem.Snapshot) (holdem.ActionType, int64, error) {
-	legalActions, _, err := t.game.LegalActions(chair)
+	legalActions, _, _, err := t.game.LegalActions(chair)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -651,10 +1068,13 @@ func (t *Table) handleConnLost(userID uint64, ts time.Time) error {
 		return nil
 	}
 	if ts.IsZero() {
-		ts = time.Now()
+		ts = t.now()
 	}
 	player.Online = false
 	player.LastSeen = ts
+	if t.Config.AutoActOffline && t.actionTimeoutChair == player.Chair && !t.actionDeadline.IsZero() {
+		t.actionDeadline = ts
+	}
 	log.Printf("[Table %s] Player %d connection lost", t.ID, userID)
 	return nil
 }
@@ -664,15 +1084,23 @@ func (t *Table) handleConnResume(userID uint64, nickname string, ts time.Time) e
 	if player == nil {
 		return nil
 	}
+	if !player.Online && player.Chair == holdem.InvalidChair && t.spectatorsFullLocked(userID) {
+		return ErrSpectatorsFull
+	}
 	player.Nickname = normalizeNickname(nickname, userID)
 	if ts.IsZero() {
-		ts = time.Now()
+		ts = t.now()
 	}
 	player.Online = true
 	player.LastSeen = ts
 	t.sendSnapshot(userID)
 	t.sendPromptIfActingUser(userID)
 	log.Printf("[Table %s] Player %d connection resumed", t.ID, userID)
+	if t.headsUpHeld {
+		if err := t.tryStartHand(ts); err != nil {
+			log.Printf("[Table %s] hand start after heads-up reconnect failed: %v", t.ID, err)
+		}
+	}
 	return nil
 }
 
@@ -695,7 +1123,7 @@ func (t *Table) handleResume(userID uint64) error {
 	log.Printf("[Table %s] Resumed (requested by user %d)", t.ID, userID)
 
 	before := t.game.Snapshot()
-	now := time.Now()
+	now := t.now()
 	if err := t.tryStartHand(now); err != nil {
 		return err
 	}
@@ -708,12 +1136,15 @@ func (t *Table) handleResume(userID uint64) error {
 }
 
 func (t *Table) tryStartHand(now time.Time) error {
-	if len(t.seats) < 2 {
+	if t.paused || t.seatsInLocked() < 2 {
 		return nil
 	}
 	if !t.nextHandAt.IsZero() && now.Before(t.nextHandAt) {
 		return nil
 	}
+	if t.holdHeadsUpForOfflineLocked() || t.waitingForEntrantsLocked() {
+		return nil
+	}
 	snap := t.game.Snapshot()
 	// Start if: no hands played yet (Round==0), OR previous hand ended.
 	if snap.Round == 0 || snap.Ended || snap.Phase == holdem.PhaseTypeRoundEnd {
@@ -726,7 +1157,10 @@ func (t *Table) tryStartHand(now time.Time) error {
 
 // SubmitEvent sends an event to the actor
 func (t *Table) SubmitEvent(e Event) error {
-	e.Timestamp = time.Now()
+	e.Timestamp = t.now()
+	if t.inline {
+		return t.handleEvent(e)
+	}
 	if e.Response == nil {
 		e.Response = make(chan error, 1)
 	}
@@ -771,6 +1205,20 @@ func (t *Table) stopLocked() {
 func (t *Table) setActionTimeoutLocked(chair uint16, now time.Time) {
 	t.actionTimeoutChair = chair
 	t.actionDeadline = now.Add(time.Duration(actionTimeLimitSec) * time.Second)
+	if t.absentLocked(t.seats[chair]) {
+		// Nobody is there to act; the next tick plays the turn.
+		t.actionDeadline = now
+	}
+}
+
+// absentLocked reports whether userID's turns should not wait on the timer:
+// they sat out, or they are offline and Config.AutoActOffline is set.
+func (t *Table) absentLocked(userID uint64) bool {
+	p := t.players[userID]
+	if p == nil {
+		return false
+	}
+	return p.SittingOut || (t.Config.AutoActOffline && !p.Online)
 }
 
 func (t *Table) clearActionTimeoutLocked() {
@@ -799,6 +1247,13 @@ func (t *Table) playerNickname(userID uint64) string {
 	return fmt.Sprintf("user_%d", userID)
 }
 
+// PlayerNickname returns the name userID is shown under at the table.
+func (t *Table) PlayerNickname(userID uint64) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.playerNickname(userID)
+}
+
 func (t *Table) playerAvatarKey(userID uint64) string {
 	player := t.players[userID]
 	if player == nil {
@@ -828,7 +1283,28 @@ func (t *Table) IsIdleFor(ttl time.Duration) bool {
 	if t.emptySince.IsZero() {
 		return false
 	}
-	return time.Since(t.emptySince) >= ttl
+	return t.now().Sub(t.emptySince) >= ttl
+}
+
+// AveragePot is the mean total pot of the hands settled at this table, or 0
+// before the first one.
+func (t *Table) AveragePot() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.settledHands == 0 {
+		return 0
+	}
+	return t.settledPots / t.settledHands
+}
+
+func (t *Table) recordPotLocked(result *holdem.SettlementResult) {
+	if result == nil {
+		return
+	}
+	for _, pot := range result.PotResults {
+		t.settledPots += pot.Amount
+	}
+	t.settledHands++
 }
 
 func (t *Table) IsClosed() bool {
@@ -843,6 +1319,37 @@ func (t *Table) IsPaused() bool {
 	return t.paused
 }
 
+// ActionOptions reports whose turn it is and, when it is userID's, their
+// legal actions. It returns nil when no hand is waiting on an action.
+func (t *Table) ActionOptions(userID uint64) *pb.ActionOptions {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snap := t.game.Snapshot()
+	if snap.Round == 0 || snap.Ended || snap.ActionChair == holdem.InvalidChair {
+		return nil
+	}
+	opts := &pb.ActionOptions{ActionChair: uint32(snap.ActionChair)}
+	player := t.players[userID]
+	if player == nil || player.Chair != snap.ActionChair {
+		return opts
+	}
+	actions, minRaise, _, err := t.game.LegalActions(player.Chair)
+	if err != nil {
+		return opts
+	}
+	for _, a := range actions {
+		opts.LegalActions = append(opts.LegalActions, actionToProto(a))
+	}
+	opts.MinRaiseTo = minRaise
+	for _, ps := range snap.Players {
+		if ps.Chair == player.Chair {
+			opts.CallAmount = callAmountFor(snap.CurBet, ps.Bet)
+		}
+	}
+	return opts
+}
+
 // Snapshot returns current game state (thread-safe)
 func (t *Table) Snapshot() holdem.Snapshot {
 	return t.game.Snapshot()
@@ -877,7 +1384,7 @@ func (t *Table) scheduleNPCAction(chair uint16, userID uint64) {
 	}
 
 	// Get legal actions for the NPC so the brain can use them.
-	legalActions, minRaise, err := t.game.LegalActions(chair)
+	legalActions, minRaise, _, err := t.game.LegalActions(chair)
 	if err != nil {
 		log.Printf("[Table %s] NPC LegalActions failed chair=%d: %v", t.ID, chair, err)
 		return
@@ -893,10 +1400,7 @@ func (t *Table) scheduleNPCAction(chair uint16, userID uint64) {
 		return
 	}
 
-	go func() {
-		// Simulate thinking
-		time.Sleep(thinkDelay)
-
+	decide := func() Event {
 		view := npc.GameView{
 			Phase:      snap.Phase,
 			Community:  snap.CommunityCards,
@@ -916,9 +1420,11 @@ func (t *Table) scheduleNPCAction(chair uint16, userID uint64) {
 				view.HoleCards = ps.HandCards
 				view.MyBet = ps.Bet
 				view.MyStack = ps.Stack
+				view.FacingReraise = (ps.LastAction == holdem.PlayerActionTypeBet || ps.LastAction == holdem.PlayerActionTypeRaise) && snap.CurBet > ps.Bet
 				break
 			}
 		}
+		view.PreflopAggressor = snap.PreflopAggressor == chair
 		// Active count
 		for _, ps := range snap.Players {
 			if !ps.Folded {
@@ -942,18 +1448,35 @@ func (t *Table) scheduleNPCAction(chair uint16, userID uint64) {
 		log.Printf("[Table %s] NPC %s (chair=%d) decides: %v amount=%d",
 			t.ID, inst.Persona.Name, chair, decision.Action, decision.Amount)
 
-		// Inject the decision back into the actor queue.
-		_ = t.SubmitEvent(Event{
+		return Event{
 			Type:   EventAction,
 			UserID: userID,
 			Action: decision.Action,
 			Amount: decision.Amount,
-		})
+		}
+	}
+	if t.npcMode == NPCModeStep {
+		t.pendingNPCStep = decide
+		return
+	}
+
+	go func() {
+		// Simulate thinking
+		<-t.after(thinkDelay)
+
+		// Inject the decision back into the actor queue.
+		_ = t.SubmitEvent(decide())
 	}()
 }
 
 // SeatNPC spawns an NPC at a specific chair. Must be called before hand starts.
 func (t *Table) SeatNPC(persona *npc.NPCPersona, chair uint16, buyIn int64) error {
+	return t.SeatNPCAs(persona, chair, buyIn, persona.Name)
+}
+
+// SeatNPCAs is SeatNPC with the nickname shown at the table, e.g. to tell
+// apart two seats played by the same persona.
+func (t *Table) SeatNPCAs(persona *npc.NPCPersona, chair uint16, buyIn int64, nickname string) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -975,20 +1498,68 @@ func (t *Table) SeatNPC(persona *npc.NPCPersona, chair uint16, buyIn int64) erro
 	// Register the NPC in the table's player/seat tracking
 	t.players[inst.PlayerID] = &PlayerConn{
 		UserID:    inst.PlayerID,
-		Nickname:  inst.Persona.Name,
+		Nickname:  nickname,
 		AvatarKey: inst.Persona.AvatarKey,
 		Chair:     chair,
 		Stack:     buyIn,
 		Online:    true,
-		LastSeen:  time.Now(),
+		LastSeen:  t.now(),
 	}
 	t.seats[chair] = inst.PlayerID
-	t.updateEmptySinceLocked(time.Now())
+	t.updateEmptySinceLocked(t.now())
 
-	log.Printf("[Table %s] NPC %s seated at chair %d with %d", t.ID, persona.Name, chair, buyIn)
+	log.Printf("[Table %s] NPC %s seated at chair %d with %d", t.ID, nickname, chair, buyIn)
 	return nil
 }
 
+// UnseatNPC removes an NPC from its chair between hands and despawns it.
+func (t *Table) UnseatNPC(chair uint16) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	userID := t.seats[chair]
+	if userID == 0 {
+		return fmt.Errorf("chair %d is empty", chair)
+	}
+	if !t.isNPC(userID) {
+		return fmt.Errorf("chair %d is not an NPC", chair)
+	}
+	if err := t.game.StandUp(chair); err != nil {
+		return err
+	}
+	delete(t.seats, chair)
+	delete(t.players, userID)
+	delete(t.pendingStandUps, userID)
+	t.npcManager.DespawnNPC(userID)
+	t.updateEmptySinceLocked(t.now())
+	if len(t.seats) < 2 {
+		t.nextHandAt = time.Time{}
+	}
+
+	log.Printf("[Table %s] NPC %d unseated from chair %d", t.ID, userID, chair)
+	t.broadcastSeatLeft(chair, userID)
+	return nil
+}
+
+// SeatComposition returns the number of seated humans and the chairs held by NPCs.
+func (t *Table) SeatComposition() (humans int, npcChairs []uint16) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for chair, userID := range t.seats {
+		if userID == 0 {
+			continue
+		}
+		if t.isNPC(userID) {
+			npcChairs = append(npcChairs, chair)
+		} else {
+			humans++
+		}
+	}
+	sort.Slice(npcChairs, func(i, j int) bool { return npcChairs[i] < npcChairs[j] })
+	return humans, npcChairs
+}
+
 // NPCManager returns the table's NPC manager (may be nil).
 func (t *Table) NPCManager() *npc.Manager {
 	return t.npcManager
@@ -1059,7 +1630,7 @@ func (t *Table) appendReplayBootstrapSnapshots() {
 		env := &pb.ServerEnvelope{
 			TableId:    t.ID,
 			ServerSeq:  0,
-			ServerTsMs: time.Now().UnixMilli(),
+			ServerTsMs: t.now().UnixMilli(),
 			Payload: &pb.ServerEnvelope_TableSnapshot{
 				TableSnapshot: snapshot,
 			},
@@ -1073,13 +1644,20 @@ func (t *Table) appendReplayBootstrapSnapshots() {
 }
 
 func (t *Table) buildTableSnapshotForUser(userID uint64) *pb.TableSnapshot {
-	snap := t.game.Snapshot()
+	return t.buildTableSnapshot(userID, t.opponentTags(userID))
+}
+
+// buildTableSnapshot is the table as userID sees it, with tags as their color
+// tags on other players.
+func (t *Table) buildTableSnapshot(userID uint64, tags map[uint64]string) *pb.TableSnapshot {
+	snap := t.visibleSnapshotLocked()
+	smallBlind, bigBlind, ante := t.blindsLocked()
 	ts := &pb.TableSnapshot{
 		Config: &pb.TableConfig{
 			MaxPlayers: uint32(t.Config.MaxPlayers),
-			SmallBlind: t.Config.SmallBlind,
-			BigBlind:   t.Config.BigBlind,
-			Ante:       t.Config.Ante,
+			SmallBlind: smallBlind,
+			BigBlind:   bigBlind,
+			Ante:       ante,
 			MinBuyIn:   t.Config.MinBuyIn,
 			MaxBuyIn:   t.Config.MaxBuyIn,
 		},
@@ -1091,6 +1669,7 @@ func (t *Table) buildTableSnapshotForUser(userID uint64) *pb.TableSnapshot {
 		ActionChair:     uint32(snap.ActionChair),
 		CurBet:          snap.CurBet,
 		MinRaiseDelta:   snap.MinRaiseDelta,
+		SpectatorCount:  uint32(t.spectatorCountLocked(0)),
 	}
 	for _, c := range snap.CommunityCards {
 		ts.CommunityCards = append(ts.CommunityCards, cardToProto(c))
@@ -1114,6 +1693,12 @@ func (t *Table) buildTableSnapshotForUser(userID uint64) *pb.TableSnapshot {
 			LastAction: actionToProto(ps.LastAction),
 			HasCards:   len(ps.HandCards) > 0,
 			AvatarKey:  t.playerAvatarKey(ps.ID),
+			ColorTag:   tags[ps.ID],
+			SittingOut: t.sittingOut(ps.ID),
+		}
+		// Bets are public, so every seat's call amount is safe to send.
+		if !ps.Folded && !ps.AllIn {
+			player.ToCall = callAmountFor(snap.CurBet, ps.Bet)
 		}
 		// Only expose hole cards for the current user.
 		if ps.ID == userID {
@@ -1123,6 +1708,7 @@ func (t *Table) buildTableSnapshotForUser(userID uint64) *pb.TableSnapshot {
 		}
 		ts.Players = append(ts.Players, player)
 	}
+	t.applySnapshotPrivacy(ts, snap, userID)
 	return ts
 }
 
@@ -1130,13 +1716,15 @@ func (t *Table) buildReplayBootstrapSnapshotForUser(userID uint64) *pb.TableSnap
 	// Bootstrap snapshot should represent pre-hand state:
 	// no private cards, no live bet commitments. This prevents replay from
 	// visually "dealing twice" when handStart/dealHoleCards events arrive.
-	ts := t.buildTableSnapshotForUser(userID)
+	// Color tags are private and left out.
+	ts := t.buildTableSnapshot(userID, nil)
 	for _, p := range ts.Players {
 		p.HandCards = nil
 		p.HasCards = false
 		p.Folded = false
 		p.AllIn = false
 		p.Bet = 0
+		p.ToCall = 0
 		if startStack, ok := t.handStartStacks[uint16(p.Chair)]; ok {
 			p.Stack = startStack
 		}
@@ -1168,6 +1756,12 @@ func serverEnvelopeType(env *pb.ServerEnvelope) string {
 		return "phaseChange"
 	case *pb.ServerEnvelope_WinByFold:
 		return "winByFold"
+	case *pb.ServerEnvelope_RabbitHunt:
+		return "rabbitHunt"
+	case *pb.ServerEnvelope_DealerDraw:
+		return "dealerDraw"
+	case *pb.ServerEnvelope_UncalledReturn:
+		return "uncalledReturn"
 	case *pb.ServerEnvelope_Showdown:
 		return "showdown"
 	case *pb.ServerEnvelope_HandEnd:
@@ -1188,7 +1782,7 @@ func (t *Table) sendToUser(userID uint64, env *pb.ServerEnvelope) {
 		return
 	}
 	t.appendUserHandTape(userID, env, data)
-	t.broadcast(userID, data)
+	t.deliver(userID, data)
 }
 
 func (t *Table) broadcastToAll(env *pb.ServerEnvelope) {
@@ -1200,8 +1794,34 @@ func (t *Table) broadcastToAll(env *pb.ServerEnvelope) {
 	t.appendLiveLedgerEvent(env, data)
 	for userID := range t.players {
 		t.appendUserHandTape(userID, env, data)
-		t.broadcast(userID, data)
+		t.deliver(userID, data)
+	}
+}
+
+// deliver hands an encoded message to the transport, after the spectator
+// delay for unseated viewers.
+func (t *Table) deliver(userID uint64, data []byte) {
+	if t.spectatorDelayedLocked(userID) {
+		t.spectatorTape = append(t.spectatorTape, delayedMessage{
+			userID: userID,
+			data:   data,
+			due:    t.now().Add(t.Config.SpectatorDelay),
+		})
+		return
+	}
+	// Anything still queued from before the user sat down goes out first.
+	t.releaseSpectatorTapeLocked(t.now())
+	t.transmit(userID, data)
+}
+
+// transmit sends through the pacer when the table has a minimum broadcast
+// interval.
+func (t *Table) transmit(userID uint64, data []byte) {
+	if t.pacer != nil {
+		t.pacer.enqueue(userID, data)
+		return
 	}
+	t.broadcast(userID, data)
 }
 
 func (t *Table) sendSnapshot(userID uint64) {
@@ -1211,7 +1831,7 @@ func (t *Table) sendSnapshot(userID uint64) {
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload:    &pb.ServerEnvelope_TableSnapshot{TableSnapshot: ts},
 	}
 	t.sendToUser(userID, env)
@@ -1225,7 +1845,7 @@ func (t *Table) broadcastSeatUpdate(chair uint16, userID uint64, stack int64) {
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_SeatUpdate{
 			SeatUpdate: &pb.SeatUpdate{
 				Chair: uint32(chair),
@@ -1249,7 +1869,7 @@ func (t *Table) broadcastSeatLeft(chair uint16, userID uint64) {
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_SeatUpdate{
 			SeatUpdate: &pb.SeatUpdate{
 				Chair: uint32(chair),
@@ -1262,28 +1882,97 @@ func (t *Table) broadcastSeatLeft(chair uint16, userID uint64) {
 	t.broadcastToAll(env)
 }
 
+// broadcastStackChange announces a seated player's new stack outside a hand.
+func (t *Table) broadcastStackChange(chair uint16, stack int64) {
+	env := &pb.ServerEnvelope{
+		TableId:    t.ID,
+		ServerSeq:  t.nextSeq(),
+		ServerTsMs: t.now().UnixMilli(),
+		Payload: &pb.ServerEnvelope_SeatUpdate{
+			SeatUpdate: &pb.SeatUpdate{
+				Chair: uint32(chair),
+				Update: &pb.SeatUpdate_StackChange{
+					StackChange: stack,
+				},
+			},
+		},
+	}
+	t.broadcastToAll(env)
+}
+
 func (t *Table) broadcastHandStart() {
 	snap := t.game.Snapshot()
+	smallBlind, bigBlind, ante := t.blindsLocked()
 	log.Printf("[Table %s] Broadcasting hand start", t.ID)
+	straddleChair := uint32(0)
+	if snap.StraddleBet() > 0 {
+		straddleChair = uint32(snap.StraddleChair)
+	}
 
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_HandStart{
 			HandStart: &pb.HandStart{
 				Round:            uint32(snap.Round),
 				DealerChair:      uint32(snap.DealerChair),
 				SmallBlindChair:  uint32(snap.SmallBlindChair),
 				BigBlindChair:    uint32(snap.BigBlindChair),
-				SmallBlindAmount: t.Config.SmallBlind,
-				BigBlindAmount:   t.Config.BigBlind,
+				SmallBlindAmount: smallBlind,
+				BigBlindAmount:   bigBlind,
+				SeedCommitment:   t.fairnessProofs[t.round].Commitment,
+				AnteAmount:       ante,
+				StraddleChair:    straddleChair,
+				StraddleAmount:   snap.StraddleBet(),
+				ForcedTotal:      snap.PotTotal(),
 			},
 		},
 	}
 	t.broadcastToAll(env)
 }
 
+func (c TableConfig) dealerSelection() holdem.DealerSelection {
+	if c.HighCardDraw {
+		return holdem.DealerHighCard
+	}
+	return holdem.DealerRandom
+}
+
+// broadcastDealerDraw shows the high-card draw that placed this hand's
+// button. Hands whose button was not drawn send nothing.
+func (t *Table) broadcastDealerDraw(dealer uint16) {
+	draw := t.game.DealerDraw()
+	if len(draw) == 0 {
+		return
+	}
+	cards := make([]*pb.DealerDrawCard, len(draw))
+	for i, d := range draw {
+		cards[i] = &pb.DealerDrawCard{Chair: uint32(d.Chair), Card: cardToProto(d.Card)}
+	}
+	env := &pb.ServerEnvelope{
+		TableId:    t.ID,
+		ServerSeq:  t.nextSeq(),
+		ServerTsMs: t.now().UnixMilli(),
+		Payload: &pb.ServerEnvelope_DealerDraw{
+			DealerDraw: &pb.DealerDraw{Cards: cards, DealerChair: uint32(dealer)},
+		},
+	}
+	t.broadcastToAll(env)
+}
+
+func (t *Table) broadcastUncalledReturn(bet holdem.UncalledBet) {
+	env := &pb.ServerEnvelope{
+		TableId:    t.ID,
+		ServerSeq:  t.nextSeq(),
+		ServerTsMs: t.now().UnixMilli(),
+		Payload: &pb.ServerEnvelope_UncalledReturn{
+			UncalledReturn: &pb.UncalledReturn{Chair: uint32(bet.Chair), Amount: bet.Amount},
+		},
+	}
+	t.broadcastToAll(env)
+}
+
 func (t *Table) sendHoleCards() {
 	snap := t.game.Snapshot()
 	for _, ps := range snap.Players {
@@ -1298,7 +1987,7 @@ func (t *Table) sendHoleCards() {
 			env := &pb.ServerEnvelope{
 				TableId:    t.ID,
 				ServerSeq:  t.nextSeq(),
-				ServerTsMs: time.Now().UnixMilli(),
+				ServerTsMs: t.now().UnixMilli(),
 				Payload: &pb.ServerEnvelope_DealHoleCards{
 					DealHoleCards: &pb.DealHoleCards{
 						Cards: cards,
@@ -1328,10 +2017,11 @@ func (t *Table) sendActionPromptWithTTL(chair uint16, timeLimitSec int32, resetT
 		timeLimitSec = 1
 	}
 	if resetTimeout {
-		t.setActionTimeoutLocked(chair, time.Now())
+		t.setActionTimeoutLocked(chair, t.now())
 	}
+	t.markActionPromptLocked(chair)
 
-	actions, minRaise, err := t.game.LegalActions(chair)
+	actions, minRaise, _, err := t.game.LegalActions(chair)
 	if err != nil {
 		log.Printf("[Table %s] Failed to build action prompt for chair %d: %v", t.ID, chair, err)
 		return
@@ -1353,10 +2043,7 @@ func (t *Table) sendActionPromptWithTTL(chair uint16, timeLimitSec int32, resetT
 			break
 		}
 	}
-	callAmount := snap.CurBet - playerBet
-	if callAmount < 0 {
-		callAmount = 0
-	}
+	callAmount := callAmountFor(snap.CurBet, playerBet)
 
 	legalActions := make([]pb.ActionType, len(actions))
 	for i, a := range actions {
@@ -1365,13 +2052,13 @@ func (t *Table) sendActionPromptWithTTL(chair uint16, timeLimitSec int32, resetT
 
 	deadline := t.actionDeadline
 	if t.actionTimeoutChair != chair || deadline.IsZero() {
-		deadline = time.Now().Add(time.Duration(timeLimitSec) * time.Second)
+		deadline = t.now().Add(time.Duration(timeLimitSec) * time.Second)
 	}
 
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_ActionPrompt{
 			ActionPrompt: &pb.ActionPrompt{
 				Chair:            uint32(chair),
@@ -1424,6 +2111,11 @@ func (t *Table) broadcastActionResult(
 	after holdem.Snapshot,
 	result *holdem.SettlementResult,
 ) {
+	// A hand the action settled is reported as it stood when betting
+	// closed; the run-out and payout follow in their own messages.
+	if result != nil {
+		after = closedView(after, result, before.CommunityCards)
+	}
 	var newStack int64
 	var finalBet int64
 	for _, ps := range after.Players {
@@ -1444,21 +2136,46 @@ func (t *Table) broadcastActionResult(
 		}
 	}
 
+	msg := &pb.ActionResult{
+		Chair:       uint32(chair),
+		Action:      actionToProto(action),
+		Amount:      finalBet,
+		NewStack:    newStack,
+		NewPotTotal: potTotal,
+	}
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_ActionResult{
-			ActionResult: &pb.ActionResult{
-				Chair:       uint32(chair),
-				Action:      actionToProto(action),
-				Amount:      finalBet,
-				NewStack:    newStack,
-				NewPotTotal: potTotal,
-			},
+			ActionResult: msg,
 		},
 	}
-	t.broadcastToAll(env)
+	if !t.privacyLive(after) {
+		t.broadcastToAll(env)
+		return
+	}
+
+	// The ledger keeps the real action; other players get it filtered.
+	if data, err := proto.Marshal(env); err == nil {
+		t.appendLiveLedgerEvent(env, data)
+	}
+	hidden := proto.Clone(msg).(*pb.ActionResult)
+	t.applyActionResultPrivacy(hidden, before)
+	for userID := range t.players {
+		if userID == t.seats[chair] {
+			t.sendToUser(userID, env)
+			continue
+		}
+		t.sendToUser(userID, &pb.ServerEnvelope{
+			TableId:    env.TableId,
+			ServerSeq:  env.ServerSeq,
+			ServerTsMs: env.ServerTsMs,
+			Payload: &pb.ServerEnvelope_ActionResult{
+				ActionResult: hidden,
+			},
+		})
+	}
 }
 
 func (t *Table) broadcastHandEnd(result *holdem.SettlementResult) {
@@ -1477,7 +2194,7 @@ func (t *Table) broadcastHandEnd(result *holdem.SettlementResult) {
 			envShowdown := &pb.ServerEnvelope{
 				TableId:    t.ID,
 				ServerSeq:  t.nextSeq(),
-				ServerTsMs: time.Now().UnixMilli(),
+				ServerTsMs: t.now().UnixMilli(),
 				Payload: &pb.ServerEnvelope_Showdown{
 					Showdown: showdown,
 				},
@@ -1486,19 +2203,25 @@ func (t *Table) broadcastHandEnd(result *holdem.SettlementResult) {
 		}
 	} else {
 		t.broadcastWinByFold(result, excessRefund)
+		if t.Config.RabbitHunt {
+			t.broadcastRabbitHunt()
+		}
 	}
 
 	// Send HandEnd
 	envEnd := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_HandEnd{
 			HandEnd: &pb.HandEnd{
 				Round:        t.round,
 				StackDeltas:  stackDeltas,
 				ExcessRefund: excessRefund,
 				NetResults:   netResults,
+				CashOuts:     toCashOutResults(result),
+				RakeAmount:   result.RakeAmount,
+				DeckSeed:     t.revealHandSeedLocked(),
 			},
 		},
 	}
@@ -1546,7 +2269,7 @@ func (t *Table) broadcastDealBoard(phase pb.Phase, cards []card.Card) {
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_DealBoard{
 			DealBoard: board,
 		},
@@ -1554,19 +2277,41 @@ func (t *Table) broadcastDealBoard(phase pb.Phase, cards []card.Card) {
 	t.broadcastToAll(env)
 }
 
-func (t *Table) broadcastPotUpdate(pots []holdem.PotSnapshot) {
+func (t *Table) broadcastPotUpdate(pots []holdem.PotSnapshot, snap holdem.Snapshot) {
 	update := &pb.PotUpdate{
 		Pots: potsToProto(pots),
 	}
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_PotUpdate{
 			PotUpdate: update,
 		},
 	}
-	t.broadcastToAll(env)
+	if !t.hidesPotEligibility(snap) {
+		t.broadcastToAll(env)
+		return
+	}
+	t.broadcastWithheld(env, &pb.ServerEnvelope{
+		TableId:    env.TableId,
+		ServerSeq:  env.ServerSeq,
+		ServerTsMs: env.ServerTsMs,
+		Payload: &pb.ServerEnvelope_PotUpdate{
+			PotUpdate: &pb.PotUpdate{Pots: withholdPotEligibility(update.Pots)},
+		},
+	})
+}
+
+// broadcastWithheld logs canonical to the ledger and sends every player
+// shown, its copy with private fields withheld.
+func (t *Table) broadcastWithheld(canonical, shown *pb.ServerEnvelope) {
+	if data, err := proto.Marshal(canonical); err == nil {
+		t.appendLiveLedgerEvent(canonical, data)
+	}
+	for userID := range t.players {
+		t.sendToUser(userID, shown)
+	}
 }
 
 func (t *Table) broadcastPhaseChange(phase holdem.Phase, board []card.Card, pots []holdem.PotSnapshot, snap holdem.Snapshot) {
@@ -1578,17 +2323,38 @@ func (t *Table) broadcastPhaseChange(phase holdem.Phase, board []card.Card, pots
 		Pots:           potProtos,
 	}
 
+	shownPots := base.Pots
+	hidePots := t.hidesPotEligibility(snap)
+	if hidePots {
+		shownPots = withholdPotEligibility(base.Pots)
+	}
+
 	// my_hand_rank/my_hand_value are only meaningful when 5 board cards are available.
 	if len(board) < 5 {
 		env := &pb.ServerEnvelope{
 			TableId:    t.ID,
 			ServerSeq:  t.nextSeq(),
-			ServerTsMs: time.Now().UnixMilli(),
+			ServerTsMs: t.now().UnixMilli(),
 			Payload: &pb.ServerEnvelope_PhaseChange{
 				PhaseChange: base,
 			},
 		}
-		t.broadcastToAll(env)
+		if !hidePots {
+			t.broadcastToAll(env)
+			return
+		}
+		t.broadcastWithheld(env, &pb.ServerEnvelope{
+			TableId:    env.TableId,
+			ServerSeq:  env.ServerSeq,
+			ServerTsMs: env.ServerTsMs,
+			Payload: &pb.ServerEnvelope_PhaseChange{
+				PhaseChange: &pb.PhaseChange{
+					Phase:          base.Phase,
+					CommunityCards: base.CommunityCards,
+					Pots:           shownPots,
+				},
+			},
+		})
 		return
 	}
 
@@ -1597,7 +2363,7 @@ func (t *Table) broadcastPhaseChange(phase holdem.Phase, board []card.Card, pots
 		msg := &pb.PhaseChange{
 			Phase:          base.Phase,
 			CommunityCards: base.CommunityCards,
-			Pots:           base.Pots,
+			Pots:           shownPots,
 		}
 		if pc != nil && pc.Chair != holdem.InvalidChair {
 			if rank, value, ok := evaluateMyHand(snap, pc.Chair); ok {
@@ -1608,7 +2374,7 @@ func (t *Table) broadcastPhaseChange(phase holdem.Phase, board []card.Card, pots
 		env := &pb.ServerEnvelope{
 			TableId:    t.ID,
 			ServerSeq:  t.nextSeq(),
-			ServerTsMs: time.Now().UnixMilli(),
+			ServerTsMs: t.now().UnixMilli(),
 			Payload: &pb.ServerEnvelope_PhaseChange{
 				PhaseChange: msg,
 			},
@@ -1655,7 +2421,7 @@ func (t *Table) broadcastWinByFold(result *holdem.SettlementResult, excessRefund
 	env := &pb.ServerEnvelope{
 		TableId:    t.ID,
 		ServerSeq:  t.nextSeq(),
-		ServerTsMs: time.Now().UnixMilli(),
+		ServerTsMs: t.now().UnixMilli(),
 		Payload: &pb.ServerEnvelope_WinByFold{
 			WinByFold: &pb.WinByFold{
 				WinnerChair:  uint32(winnerChair),
@@ -1667,6 +2433,24 @@ func (t *Table) broadcastWinByFold(result *holdem.SettlementResult, excessRefund
 	t.broadcastToAll(env)
 }
 
+// broadcastRabbitHunt reveals the rest of the board after a fold win. The
+// cards are peeked, not dealt, so the next hand is unaffected.
+func (t *Table) broadcastRabbitHunt() {
+	cards := t.game.PeekRemainingBoard()
+	if len(cards) == 0 {
+		return
+	}
+	env := &pb.ServerEnvelope{
+		TableId:    t.ID,
+		ServerSeq:  t.nextSeq(),
+		ServerTsMs: t.now().UnixMilli(),
+		Payload: &pb.ServerEnvelope_RabbitHunt{
+			RabbitHunt: &pb.RabbitHunt{Cards: cardsToProto(cards)},
+		},
+	}
+	t.broadcastToAll(env)
+}
+
 func (t *Table) buildStackDeltas(snap holdem.Snapshot) []*pb.StackDelta {
 	stackDeltas := make([]*pb.StackDelta, 0, len(snap.Players))
 	for _, ps := range snap.Players {
@@ -1726,6 +2510,16 @@ func (t *Table) persistLiveHandHistory(handID string, playedAt time.Time, result
 			"stack_start": startStack,
 			"stack_end":   ps.Stack,
 		}
+		for _, co := range result.CashOuts {
+			if co.Chair == ps.Chair {
+				summary["cash_out"] = co.Payout
+				summary["cash_out_equity"] = co.Equity
+				summary["cash_out_runout"] = co.Actual
+			}
+		}
+		if timings := t.actionTimingSummary(ps.Chair); len(timings) > 0 {
+			summary["action_timings"] = timings
+		}
 		userEvents := append([]ledger.EventItem(nil), t.userHandTape[userID]...)
 		go t.ledger.UpsertLiveHistoryWithEvents(userID, handID, playedAt, summary, userEvents)
 	}
@@ -1737,33 +2531,25 @@ func buildShowdown(result *holdem.SettlementResult, excessRefund *pb.ExcessRefun
 		NetResults:   netResults,
 	}
 
-	for _, pr := range result.PotResults {
-		winners := make([]*pb.Winner, 0, len(pr.Winners))
-		for i, chair := range pr.Winners {
-			amount := int64(0)
-			if i < len(pr.WinAmounts) {
-				amount = pr.WinAmounts[i]
-			}
-			winners = append(winners, &pb.Winner{
-				Chair:     uint32(chair),
-				WinAmount: amount,
-			})
-		}
-		showdown.PotResults = append(showdown.PotResults, &pb.PotResult{
-			PotAmount: pr.Amount,
-			Winners:   winners,
+	showdown.PotResults = potResultsToProto(result.PotResults)
+	for _, run := range result.Runs {
+		showdown.Runs = append(showdown.Runs, &pb.BoardRun{
+			Board:      cardsToProto(run.Board),
+			PotResults: potResultsToProto(run.PotResults),
 		})
 	}
 
+	ranks := holdem.ShowdownRanks(result.PlayerResults)
 	for _, pr := range result.PlayerResults {
 		if pr.HandType == 0 {
 			continue
 		}
 		showdown.Hands = append(showdown.Hands, &pb.ShowdownHand{
-			Chair:     uint32(pr.Chair),
-			HoleCards: cardsToProto(pr.HandCards),
-			BestFive:  cardsToProto(pr.BestFiveCards),
-			Rank:      handRankToProto(pr.HandType),
+			Chair:        uint32(pr.Chair),
+			HoleCards:    cardsToProto(pr.HandCards),
+			BestFive:     cardsToProto(pr.BestFiveCards),
+			Rank:         handRankToProto(pr.HandType),
+			ShowdownRank: uint32(ranks[pr.Chair]),
 		})
 	}
 
@@ -1773,6 +2559,29 @@ func buildShowdown(result *holdem.SettlementResult, excessRefund *pb.ExcessRefun
 	return showdown
 }
 
+func potResultsToProto(results []holdem.PotResult) []*pb.PotResult {
+	var out []*pb.PotResult
+	for _, pr := range results {
+		winners := make([]*pb.Winner, 0, len(pr.Winners))
+		for i, chair := range pr.Winners {
+			amount := int64(0)
+			if i < len(pr.WinAmounts) {
+				amount = pr.WinAmounts[i]
+			}
+			winners = append(winners, &pb.Winner{
+				Chair:     uint32(chair),
+				WinAmount: amount,
+			})
+		}
+		out = append(out, &pb.PotResult{
+			PotAmount: pr.Amount,
+			Winners:   winners,
+			Rake:      pr.Rake,
+		})
+	}
+	return out
+}
+
 func buildNetResults(result *holdem.SettlementResult, snap holdem.Snapshot) []*pb.NetResult {
 	perChair := make(map[uint16]holdem.ShowdownPlayerResult, len(result.PlayerResults))
 	for _, pr := range result.PlayerResults {
@@ -1948,6 +2757,14 @@ func handRankToProto(r byte) pb.HandRank {
 	}
 }
 
+// callAmountFor is what a seat with bet must add to match curBet.
+func callAmountFor(curBet, bet int64) int64 {
+	if curBet <= bet {
+		return 0
+	}
+	return curBet - bet
+}
+
 func phaseToProto(p holdem.Phase) pb.Phase {
 	switch p {
 	case holdem.PhaseTypeAnte:
@@ -0,0 +1,74 @@
+package table
+
+// OpponentTagger supplies the color tags a viewer keeps on other players. It
+// may block on storage, so tables only call it off the actor.
+type OpponentTagger interface {
+	OpponentTags(viewerID uint64) map[uint64]string
+}
+
+// SetOpponentTagger makes table snapshots carry each viewer's own color tags
+// on seated opponents. Tags of viewers already at the table load in the
+// background.
+func (t *Table) SetOpponentTagger(tagger OpponentTagger) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tagger = tagger
+	t.tagGeneration++
+	t.opponentTagCache = nil
+	for userID := range t.players {
+		t.loadOpponentTagsLocked(userID)
+	}
+}
+
+// RefreshOpponentTags reloads viewerID's tags, e.g. after they edited a
+// note, and sends them a fresh snapshot. It does nothing if viewerID is not
+// at the table.
+func (t *Table) RefreshOpponentTags(viewerID uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.players[viewerID] == nil {
+		return
+	}
+	t.loadOpponentTagsLocked(viewerID)
+}
+
+// opponentTags returns viewerID's cached tags; none until they have loaded.
+func (t *Table) opponentTags(viewerID uint64) map[uint64]string {
+	if t.tagger == nil || viewerID == 0 || t.isNPC(viewerID) {
+		return nil
+	}
+	return t.opponentTagCache[viewerID]
+}
+
+// loadOpponentTagsLocked fetches viewerID's tags off the actor, caches them
+// and resends the viewer's snapshot with them.
+func (t *Table) loadOpponentTagsLocked(viewerID uint64) {
+	tagger, generation := t.tagger, t.tagGeneration
+	if tagger == nil || viewerID == 0 || t.isNPC(viewerID) {
+		return
+	}
+	if t.inline {
+		// The test harness has no actor to keep the lookup off.
+		t.storeOpponentTagsLocked(generation, viewerID, tagger.OpponentTags(viewerID))
+		return
+	}
+	go func() {
+		tags := tagger.OpponentTags(viewerID)
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.storeOpponentTagsLocked(generation, viewerID, tags)
+	}()
+}
+
+func (t *Table) storeOpponentTagsLocked(generation uint64, viewerID uint64, tags map[uint64]string) {
+	// The viewer left or the tagger was replaced while the lookup ran.
+	if t.closed || t.tagGeneration != generation || t.players[viewerID] == nil {
+		return
+	}
+	delete(tags, viewerID)
+	if t.opponentTagCache == nil {
+		t.opponentTagCache = make(map[uint64]map[uint64]string)
+	}
+	t.opponentTagCache[viewerID] = tags
+	t.sendSnapshot(viewerID)
+}
@@ -0,0 +1,88 @@
+package table
+
+import "testing"
+
+type fakeTagger map[uint64]map[uint64]string
+
+func (f fakeTagger) OpponentTags(viewerID uint64) map[uint64]string {
+	out := make(map[uint64]string)
+	for target, color := range f[viewerID] {
+		out[target] = color
+	}
+	return out
+}
+
+func TestSnapshot_ColorTagsArePrivateToViewer(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2, 3} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	tbl.SetOpponentTagger(fakeTagger{1: {2: "red", 1: "blue"}})
+
+	own := tbl.buildTableSnapshotForUser(1)
+	if got := snapshotPlayer(own, 2).GetColorTag(); got != "red" {
+		t.Fatalf("expected viewer 1 to see their red tag on user 2, got %q", got)
+	}
+	if got := snapshotPlayer(own, 1).GetColorTag(); got != "" {
+		t.Fatalf("expected no tag on the viewer themselves, got %q", got)
+	}
+	for _, viewer := range []uint64{2, 3} {
+		for _, p := range tbl.buildTableSnapshotForUser(viewer).GetPlayers() {
+			if p.GetColorTag() != "" {
+				t.Fatalf("viewer %d saw tag %q on user %d", viewer, p.GetColorTag(), p.GetUserId())
+			}
+		}
+	}
+	for _, p := range tbl.buildReplayBootstrapSnapshotForUser(1).GetPlayers() {
+		if p.GetColorTag() != "" {
+			t.Fatalf("expected replay bootstrap snapshot to omit tags, got %q on user %d", p.GetColorTag(), p.GetUserId())
+		}
+	}
+}
+
+// countingTagger is fakeTagger that counts lookups.
+type countingTagger struct {
+	tags  fakeTagger
+	calls int
+}
+
+func (c *countingTagger) OpponentTags(viewerID uint64) map[uint64]string {
+	c.calls++
+	return c.tags.OpponentTags(viewerID)
+}
+
+func TestOpponentTags_ServedFromCacheUntilRefreshed(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	tagger := &countingTagger{tags: fakeTagger{1: {2: "red"}}}
+	tbl.SetOpponentTagger(tagger)
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	// One lookup per join; dealing the hand and its snapshots add none.
+	if tagger.calls != 2 || tbl.game.Snapshot().Round != 1 {
+		t.Fatalf("expected one lookup per joining viewer, got %d", tagger.calls)
+	}
+	if got := snapshotPlayer(tbl.buildTableSnapshotForUser(1), 2).GetColorTag(); got != "red" || tagger.calls != 2 {
+		t.Fatalf("expected the cached red tag without a lookup, got %q after %d lookups", got, tagger.calls)
+	}
+
+	tagger.tags[1][2] = "green"
+	tbl.RefreshOpponentTags(1)
+	if got := snapshotPlayer(tbl.buildTableSnapshotForUser(1), 2).GetColorTag(); got != "green" {
+		t.Fatalf("expected the refreshed green tag, got %q", got)
+	}
+	tbl.RefreshOpponentTags(99)
+	if tagger.calls != 3 {
+		t.Fatalf("expected no lookup for a user not at the table, got %d lookups", tagger.calls)
+	}
+}
@@ -0,0 +1,62 @@
+package table
+
+import (
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+// ActionTiming is how long a player took over one action, from the prompt to
+// the action being applied. Turns played by the action timeout show the full
+// time limit.
+type ActionTiming struct {
+	Chair   uint16
+	Phase   holdem.Phase
+	Action  holdem.ActionType
+	Elapsed time.Duration
+}
+
+// ActionTimings returns the per-action timings of the current hand, or of the
+// last one once it ended.
+func (t *Table) ActionTimings() []ActionTiming {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]ActionTiming(nil), t.actionTimings...)
+}
+
+// markActionPromptLocked starts the clock on chair's turn.
+func (t *Table) markActionPromptLocked(chair uint16) {
+	t.promptChair = chair
+	t.promptedAt = t.now()
+}
+
+// recordActionTimingLocked stops the clock on chair's turn. Turns prompted
+// before a restart have no start time and are not recorded.
+func (t *Table) recordActionTimingLocked(chair uint16, phase holdem.Phase, action holdem.ActionType) {
+	if t.promptChair != chair || t.promptedAt.IsZero() {
+		return
+	}
+	t.actionTimings = append(t.actionTimings, ActionTiming{
+		Chair:   chair,
+		Phase:   phase,
+		Action:  action,
+		Elapsed: t.now().Sub(t.promptedAt),
+	})
+	t.promptedAt = time.Time{}
+}
+
+// actionTimingSummary is chair's timings in hand-history summary form.
+func (t *Table) actionTimingSummary(chair uint16) []map[string]any {
+	var out []map[string]any
+	for _, at := range t.actionTimings {
+		if at.Chair != chair {
+			continue
+		}
+		out = append(out, map[string]any{
+			"phase":      holdem.PhaseTypeDictionary[at.Phase],
+			"action":     holdem.PlayerActionTypeDictionary[at.Action],
+			"elapsed_ms": at.Elapsed.Milliseconds(),
+		})
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"holdem-lite/holdem"
+)
+
+func TestActionTiming_RecordsTimeToAct(t *testing.T) {
+	clock := NewManualClock(time.Unix(1_700_000_000, 0))
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, clock, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventPause, UserID: 1}); err != nil {
+		t.Fatalf("pause err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2, 3} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	if err := tbl.SubmitEvent(Event{Type: EventResume, UserID: 1}); err != nil {
+		t.Fatalf("resume err: %v", err)
+	}
+	first := tbl.game.Snapshot().ActionChair
+
+	// The first player tanks, the next acts straight away.
+	tbl.AdvanceClock(7 * time.Second)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 0, 1)
+	second := tbl.game.Snapshot().ActionChair
+	clock.Advance(300 * time.Millisecond)
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 2)
+
+	timings := tbl.ActionTimings()
+	want := []ActionTiming{
+		{Chair: first, Phase: holdem.PhaseTypePreflop, Action: holdem.PlayerActionTypeCall, Elapsed: 7 * time.Second},
+		{Chair: second, Phase: holdem.PhaseTypePreflop, Action: holdem.PlayerActionTypeFold, Elapsed: 300 * time.Millisecond},
+	}
+	if len(timings) != len(want) {
+		t.Fatalf("expected %d timings, got %+v", len(want), timings)
+	}
+	for i := range want {
+		if timings[i] != want[i] {
+			t.Fatalf("timing %d: expected %+v, got %+v", i, want[i], timings[i])
+		}
+	}
+
+	summary := tbl.actionTimingSummary(first)
+	if len(summary) != 1 || summary[0]["elapsed_ms"] != int64(7000) || summary[0]["phase"] != "preflop" {
+		t.Fatalf("expected the tank in the hand summary, got %+v", summary)
+	}
+}
@@ -0,0 +1,60 @@
+package table
+
+import (
+	"testing"
+
+	"holdem-lite/holdem"
+)
+
+func TestUncalledReturn_SentBeforeHandEnd(t *testing.T) {
+	cfg := harnessTestConfig()
+	tbl, err := NewTableForTest(cfg, nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	var order []string
+	var returned int64
+	tbl.broadcast = func(userID uint64, data []byte) {
+		if userID != 1 {
+			return
+		}
+		env := decodeServerEnvelope(t, data)
+		switch {
+		case env.GetUncalledReturn() != nil:
+			order = append(order, "uncalledReturn")
+			returned = env.GetUncalledReturn().GetAmount()
+		case env.GetHandEnd() != nil:
+			order = append(order, "handEnd")
+		}
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	shover := tbl.game.Snapshot().ActionChair
+	actOnTable(t, tbl, holdem.PlayerActionTypeAllin, cfg.MaxBuyIn, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeFold, 0, 2)
+
+	if len(order) != 2 || order[0] != "uncalledReturn" || order[1] != "handEnd" {
+		t.Fatalf("expected the uncalled return before the hand end, got %v", order)
+	}
+	// The big blind's 100 is all that was called.
+	if returned != cfg.MaxBuyIn-cfg.BigBlind {
+		t.Fatalf("expected %d returned, got %d", cfg.MaxBuyIn-cfg.BigBlind, returned)
+	}
+	if got := tbl.players[tbl.seats[shover]].Stack; got != cfg.MaxBuyIn+cfg.BigBlind {
+		t.Fatalf("expected the shover to net the big blind, got stack %d", got)
+	}
+	var total int64
+	for _, p := range tbl.players {
+		total += p.Stack
+	}
+	if total != 2*cfg.MaxBuyIn {
+		t.Fatalf("expected chips to be conserved, got %d", total)
+	}
+	// The returned chips never made it into the pot.
+	if got := tbl.AveragePot(); got != 2*cfg.BigBlind {
+		t.Fatalf("expected an average pot of %d, got %d", 2*cfg.BigBlind, got)
+	}
+}
@@ -0,0 +1,61 @@
+package table
+
+import (
+	"encoding/base64"
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+	"holdem-lite/replay"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// A hand tape recorded by a live table must replay cleanly, or the audit
+// verify endpoint reports real hands as tampered.
+func TestHandTape_VerifiesAgainstReplay(t *testing.T) {
+	tbl, err := NewTableForTest(harnessTestConfig(), nil, nil, NPCModeStep)
+	if err != nil {
+		t.Fatalf("NewTableForTest err: %v", err)
+	}
+	for _, userID := range []uint64{1, 2} {
+		if err := tbl.SubmitEvent(Event{Type: EventJoinTable, UserID: userID}); err != nil {
+			t.Fatalf("join user=%d err: %v", userID, err)
+		}
+	}
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 100, 0)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, 1)
+	actOnTable(t, tbl, holdem.PlayerActionTypeBet, 200, 2)
+	actOnTable(t, tbl, holdem.PlayerActionTypeCall, 200, 3)
+	for step := 4; step < 8; step++ {
+		actOnTable(t, tbl, holdem.PlayerActionTypeCheck, 0, step)
+	}
+	if !tbl.game.Snapshot().Ended {
+		t.Fatalf("expected the hand to reach showdown")
+	}
+
+	for _, userID := range []uint64{1, 2} {
+		var envs []*pb.ServerEnvelope
+		for _, item := range tbl.userHandTape[userID] {
+			raw, err := base64.StdEncoding.DecodeString(item.EnvelopeB64)
+			if err != nil {
+				t.Fatalf("user %d: decode %s err: %v", userID, item.EventType, err)
+			}
+			env := &pb.ServerEnvelope{}
+			if err := proto.Unmarshal(raw, env); err != nil {
+				t.Fatalf("user %d: unmarshal %s err: %v", userID, item.EventType, err)
+			}
+			envs = append(envs, env)
+		}
+		if envs[0].GetTableSnapshot() == nil {
+			t.Fatalf("user %d: expected the tape to open with the bootstrap snapshot", userID)
+		}
+		discrepancies, err := replay.VerifyEvents(envs, userID)
+		if err != nil {
+			t.Fatalf("user %d: VerifyEvents err: %v", userID, err)
+		}
+		if len(discrepancies) != 0 {
+			t.Fatalf("user %d: expected the live tape to verify, got %+v", userID, discrepancies)
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"errors"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"holdem-lite/apps/server/internal/agent"
 	"holdem-lite/apps/server/internal/auth"
 	"holdem-lite/apps/server/internal/gateway"
 	"holdem-lite/apps/server/internal/ledger"
 	"holdem-lite/apps/server/internal/lobby"
+	"holdem-lite/apps/server/internal/notes"
 	"holdem-lite/apps/server/internal/story"
 	"holdem-lite/holdem/npc"
 )
@@ -31,6 +36,11 @@ func main() {
 		log.Fatalf("[Server] Failed to init story service: %v", err)
 	}
 	defer storyService.Close()
+	notesService, notesMode, err := notes.NewServiceFromEnv(authMode)
+	if err != nil {
+		log.Fatalf("[Server] Failed to init notes service: %v", err)
+	}
+	defer notesService.Close()
 
 	// Initialize NPC subsystem
 	npcRegistry := npc.NewRegistry()
@@ -60,9 +70,69 @@ func main() {
 
 	lby := lobby.New(ledgerService, storyService, npcManager)
 	lby.SetChapterRegistry(chapterRegistry)
+	tableConfigPaths := []string{"data/table_config.json", "../../data/table_config.json"}
+	tableConfigLoaded := false
+	for _, p := range tableConfigPaths {
+		err := lby.LoadTableConfigFile(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			log.Fatalf("[Server] Invalid table config %s: %v", p, err)
+		}
+		log.Printf("[Server] Table config loaded from %s", p)
+		tableConfigLoaded = true
+		break
+	}
+	if !tableConfigLoaded {
+		log.Printf("[Server] Table config not found, using built-in defaults, tried: %v", tableConfigPaths)
+	}
+	lby.SetOpponentTagger(notes.NewTagger(notesService))
+	if raw := strings.TrimSpace(os.Getenv("NPC_ROTATE_HANDS")); raw != "" {
+		hands, err := strconv.Atoi(raw)
+		if err != nil || hands < 0 {
+			log.Fatalf("[Server] Invalid NPC_ROTATE_HANDS %q", raw)
+		}
+		lby.SetNPCRotation(hands)
+	}
+	if raw := strings.TrimSpace(os.Getenv("NPC_TARGET_PLAYERS")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			log.Fatalf("[Server] Invalid NPC_TARGET_PLAYERS %q", raw)
+		}
+		lby.SetTargetPlayers(n)
+	}
+	if raw := strings.TrimSpace(os.Getenv("REMATCH_WINDOW")); raw != "" {
+		window, err := time.ParseDuration(raw)
+		if err != nil || window < 0 {
+			log.Fatalf("[Server] Invalid REMATCH_WINDOW %q", raw)
+		}
+		lby.SetRematchWindow(window)
+	}
 	gw := gateway.New(lby, authService)
+	multiDevice, err := gateway.ParseMultiDevicePolicy(strings.TrimSpace(os.Getenv("MULTI_DEVICE_POLICY")))
+	if err != nil {
+		log.Fatalf("[Server] Invalid MULTI_DEVICE_POLICY: %v", err)
+	}
+	gw.SetMultiDevicePolicy(multiDevice)
+	payloadPolicy, err := gateway.ParsePayloadPolicy(strings.TrimSpace(os.Getenv("PAYLOAD_VALIDATION")))
+	if err != nil {
+		log.Fatalf("[Server] Invalid PAYLOAD_VALIDATION: %v", err)
+	}
+	gw.SetPayloadPolicy(payloadPolicy)
+	gw.SetLedger(ledgerService)
 	authHTTP := auth.NewHTTPHandler(authService)
+	if verifier := auth.NewGoogleVerifierFromEnv(); verifier != nil {
+		authHTTP.SetGoogleVerifier(verifier)
+	}
+	if sender := auth.NewResetSenderFromEnv(); sender != nil {
+		authHTTP.SetResetSender(sender)
+	}
 	auditHTTP := ledger.NewHTTPHandler(authService, ledgerService)
+	adminHTTP := ledger.NewAdminHTTPHandler(os.Getenv("ADMIN_TOKEN"), ledgerService)
+	notesHTTP := notes.NewHTTPHandler(authService, notesService)
+	notesHTTP.SetColorChangeHook(lby.RefreshOpponentTags)
+	lobbyHTTP := lobby.NewHTTPHandler(lby)
 
 	// Initialize LLM Agent subsystem
 	agentConfig := agent.DefaultProviderConfig()
@@ -77,6 +147,9 @@ func main() {
 	})
 	authHTTP.RegisterRoutes(mux)
 	auditHTTP.RegisterRoutes(mux)
+	adminHTTP.RegisterRoutes(mux)
+	notesHTTP.RegisterRoutes(mux)
+	lobbyHTTP.RegisterRoutes(mux)
 	agentHTTP.RegisterRoutes(mux)
 
 	addr := strings.TrimSpace(os.Getenv("SERVER_ADDR"))
@@ -86,6 +159,7 @@ func main() {
 	log.Printf("[Server] Auth mode: %s", authMode)
 	log.Printf("[Server] Ledger mode: %s", ledgerMode)
 	log.Printf("[Server] Story mode: %s", storyMode)
+	log.Printf("[Server] Notes mode: %s", notesMode)
 	log.Printf("[Server] Starting WebSocket server on %s", addr)
 	if err := http.ListenAndServe(addr, withCORS(mux)); err != nil {
 		log.Fatalf("[Server] Failed to start: %v", err)
@@ -95,7 +169,7 @@ func main() {
 func withCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"holdem-lite/replay"
+)
+
+type initRequest struct {
+	Spec replay.HandSpec `json:"spec"`
+	// HeroChair replays the spec from this seat's perspective without
+	// editing its hero flags.
+	HeroChair *uint16 `json:"heroChair,omitempty"`
+}
+
+type initResponse struct {
+	OK bool `json:"ok"`
+	// SchemaVersion is the tape format of Tape, so the frontend can refuse
+	// tapes it does not know how to decode.
+	SchemaVersion int                    `json:"schemaVersion,omitempty"`
+	Tape          *replay.WireReplayTape `json:"tape,omitempty"`
+	Error         *replay.ReplayError    `json:"error,omitempty"`
+}
+
+func handleInit(raw string) initResponse {
+	var req initRequest
+	if err := json.Unmarshal([]byte(raw), &req); err != nil {
+		return initResponse{
+			OK:    false,
+			Error: &replay.ReplayError{StepIndex: -1, Reason: "invalid_json", Message: err.Error()},
+		}
+	}
+
+	if req.HeroChair != nil {
+		req.Spec.HeroChair = req.HeroChair
+	}
+	tape, err := replay.GenerateReplayTape(req.Spec)
+	if err != nil {
+		var replayErr *replay.ReplayError
+		if errors.As(err, &replayErr) {
+			return initResponse{OK: false, Error: replayErr}
+		}
+		return initResponse{
+			OK:    false,
+			Error: &replay.ReplayError{StepIndex: -1, Reason: "replay_generation_failed", Message: err.Error()},
+		}
+	}
+	if tape.TapeVersion != replay.CurrentTapeVersion {
+		return initResponse{
+			OK: false,
+			Error: &replay.ReplayError{
+				StepIndex: -1,
+				Reason:    "unsupported_schema_version",
+				Message:   fmt.Sprintf("tape version %d, expected %d", tape.TapeVersion, replay.CurrentTapeVersion),
+			},
+		}
+	}
+	wire := replay.ToWireReplayTape(tape)
+	return initResponse{
+		OK:            true,
+		SchemaVersion: wire.SchemaVersion,
+		Tape:          wire,
+	}
+}
+
+func mustJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		fallback := initResponse{
+			OK:    false,
+			Error: &replay.ReplayError{StepIndex: -1, Reason: "marshal_failed", Message: err.Error()},
+		}
+		b2, _ := json.Marshal(fallback)
+		return string(b2)
+	}
+	return string(b)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"holdem-lite/replay"
+)
+
+const headsUpFoldSpec = `{
+  "spec": {
+    "variant": "NLH",
+    "table": {"max_players": 6, "sb": 50, "bb": 100},
+    "dealer_chair": 0,
+    "seats": [
+      {"chair": 0, "stack": 5000, "is_hero": true, "hole": ["As", "Kd"]},
+      {"chair": 1, "stack": 5000, "hole": ["7h", "7c"]}
+    ],
+    "actions": [
+      {"phase": "PREFLOP", "chair": 0, "type": "FOLD"}
+    ],
+    "rng": {"seed": 7}
+  }
+}`
+
+func TestHandleInit_ReportsSchemaVersion(t *testing.T) {
+	resp := handleInit(headsUpFoldSpec)
+	if !resp.OK {
+		t.Fatalf("expected ok response, got error %+v", resp.Error)
+	}
+	if resp.SchemaVersion != replay.CurrentTapeVersion {
+		t.Fatalf("expected schema version %d, got %d", replay.CurrentTapeVersion, resp.SchemaVersion)
+	}
+	if resp.Tape == nil || resp.Tape.SchemaVersion != replay.CurrentTapeVersion {
+		t.Fatalf("expected tape schema version %d, got %+v", replay.CurrentTapeVersion, resp.Tape)
+	}
+
+	var wire map[string]any
+	if err := json.Unmarshal([]byte(mustJSON(resp)), &wire); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if got, ok := wire["schemaVersion"].(float64); !ok || int(got) != replay.CurrentTapeVersion {
+		t.Fatalf("expected schemaVersion in the JSON response, got %v", wire["schemaVersion"])
+	}
+}
+
+func TestHandleInit_InvalidJSONHasNoSchemaVersion(t *testing.T) {
+	resp := handleInit("{")
+	if resp.OK || resp.Error == nil || resp.Error.Reason != "invalid_json" {
+		t.Fatalf("expected invalid_json error, got %+v", resp)
+	}
+	if resp.SchemaVersion != 0 {
+		t.Fatalf("expected no schema version on error, got %d", resp.SchemaVersion)
+	}
+}
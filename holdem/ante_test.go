@@ -0,0 +1,152 @@
+package holdem
+
+import (
+	"errors"
+	"testing"
+)
+
+// anteGame seats four players with dealer at chair 0, so chair 1 is the small
+// blind and chair 2 the big blind. bbStack overrides the big blind's stack.
+func anteGame(t *testing.T, mode AnteMode, bbStack int64) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        4,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Ante:              100,
+		AnteMode:          mode,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 4; chair++ {
+		stack := int64(1000)
+		if chair == 2 {
+			stack = bbStack
+		}
+		if err := g.SitDown(chair, uint64(10001+chair), stack, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+func potTotal(s Snapshot) int64 {
+	var total int64
+	for _, p := range s.Pots {
+		total += p.Amount
+	}
+	return total
+}
+
+func stackOf(s Snapshot, chair uint16) int64 {
+	for _, p := range s.Players {
+		if p.Chair == chair {
+			return p.Stack
+		}
+	}
+	return -1
+}
+
+func TestAnte_BigBlindAnteVersusPerPlayer(t *testing.T) {
+	per := anteGame(t, AntePerPlayer, 1000).Snapshot()
+	bb := anteGame(t, AnteBigBlind, 1000).Snapshot()
+
+	if got := potTotal(per); got != 400 {
+		t.Fatalf("per-player ante: expected 400 in the pot, got %d", got)
+	}
+	if got := potTotal(bb); got != 100 {
+		t.Fatalf("big blind ante: expected 100 in the pot, got %d", got)
+	}
+	for chair, want := range map[uint16]int64{0: 1000, 1: 950, 2: 800, 3: 1000} {
+		if got := stackOf(bb, chair); got != want {
+			t.Fatalf("big blind ante: chair %d expected stack %d, got %d", chair, want, got)
+		}
+	}
+	if len(bb.Pots) != 1 || len(bb.Pots[0].EligiblePlayers) != 4 || bb.Pots[0].FormedPhase != PhaseTypeAnte {
+		t.Fatalf("expected one dead ante pot open to all four players, got %+v", bb.Pots)
+	}
+	// The ante is dead money, not a bet: the big blind still only owes the blind.
+	if bb.CurBet != 100 || bb.ActionChair != 3 {
+		t.Fatalf("expected UTG to face the 100 blind, got curBet=%d action=%d", bb.CurBet, bb.ActionChair)
+	}
+}
+
+func TestAnte_BigBlindShortPaysBlindFirst(t *testing.T) {
+	g := anteGame(t, AnteBigBlind, 150)
+	snap := g.Snapshot()
+	if got := potTotal(snap); got != 50 {
+		t.Fatalf("expected the short big blind to ante only 50, got %d", got)
+	}
+	if got := stackOf(snap, 2); got != 0 {
+		t.Fatalf("expected the big blind all-in after blind and ante, got stack %d", got)
+	}
+
+	for _, chair := range []uint16{3, 0, 1} {
+		if _, err := g.Act(chair, PlayerActionTypeCall, 100); err != nil {
+			t.Fatalf("call chair=%d err: %v", chair, err)
+		}
+	}
+	var result *SettlementResult
+	var err error
+	for result == nil {
+		result, err = g.Act(g.Snapshot().ActionChair, PlayerActionTypeCheck, 0)
+		if err != nil {
+			t.Fatalf("check down err: %v", err)
+		}
+	}
+	var total int64
+	for _, pot := range result.PotResults {
+		total += pot.Amount
+	}
+	if total != 450 || len(result.PotResults) != 1 || len(result.PotResults[0].Eligible) != 4 {
+		t.Fatalf("expected a single 450 pot all four contest, got %+v", result.PotResults)
+	}
+
+	tiny := anteGame(t, AnteBigBlind, 80).Snapshot()
+	if got := potTotal(tiny); got != 0 {
+		t.Fatalf("expected no ante from a big blind that cannot cover the blind, got %d", got)
+	}
+	if got := stackOf(tiny, 2); got != 0 {
+		t.Fatalf("expected the big blind all-in for the blind, got stack %d", got)
+	}
+}
+
+func TestSetBlinds_TakesEffectNextHand(t *testing.T) {
+	g := anteGame(t, AntePerPlayer, 1000)
+	if err := g.SetBlinds(100, 200, 25); !errors.Is(err, ErrHandInProgress) {
+		t.Fatalf("expected ErrHandInProgress mid-hand, got %v", err)
+	}
+	for {
+		end, err := g.Act(g.Snapshot().ActionChair, PlayerActionTypeFold, 0)
+		if err != nil {
+			t.Fatalf("fold err: %v", err)
+		}
+		if end != nil {
+			break
+		}
+	}
+	if err := g.SetBlinds(300, 200, 0); err == nil {
+		t.Fatalf("expected a small blind above the big blind to be refused")
+	}
+	if err := g.SetBlinds(100, 200, 25); err != nil {
+		t.Fatalf("SetBlinds err: %v", err)
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	snap := g.Snapshot()
+	if snap.CurBet != 200 {
+		t.Fatalf("expected the new 200 big blind, got cur bet %d", snap.CurBet)
+	}
+	if got := potTotal(snap); got != 4*25 {
+		t.Fatalf("expected the new 25 antes in the pot, got %d", got)
+	}
+}
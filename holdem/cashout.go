@@ -0,0 +1,139 @@
+package holdem
+
+import (
+	"fmt"
+
+	"holdem-lite/card"
+)
+
+// cashOutSamples bounds the Monte Carlo run used when more than two board
+// cards were still to come at the all-in.
+const cashOutSamples = 5000
+
+// CashOut is a post-settlement adjustment for an all-in player who took their
+// equity instead of the runout. The difference Actual-Payout goes to (or, when
+// negative, comes from) the house.
+type CashOut struct {
+	Chair uint16
+	// Equity is the chips the player expected from the pots on the board
+	// they were all-in on.
+	Equity float64
+	Payout int64
+	Actual int64
+}
+
+// ExpectedWinnings returns the chips chair expects from the settled pots over
+// every runout of board, the community cards when action closed. Only the
+// hands still live at showdown are known; folded hands stay in the deck. With
+// at most two cards to come the result is exact, otherwise it is sampled with
+// seed so the same hand always gives the same number.
+func ExpectedWinnings(result *SettlementResult, board []card.Card, chair uint16, seed int64) (float64, error) {
+	if result == nil {
+		return 0, fmt.Errorf("no settlement")
+	}
+	if len(board) > 5 {
+		return 0, fmt.Errorf("invalid board size %d", len(board))
+	}
+	byChair := make(map[uint16][]card.Card, len(result.PlayerResults))
+	var hands [][]card.Card
+	for _, pr := range result.PlayerResults {
+		if len(pr.HandCards) != 2 {
+			continue
+		}
+		byChair[pr.Chair] = pr.HandCards
+		hands = append(hands, pr.HandCards)
+	}
+	if byChair[chair] == nil {
+		return 0, fmt.Errorf("chair %d has no hand at showdown", chair)
+	}
+	// Every live hand is known, so only the board is dealt.
+	return averageDeals(nil, hands, board, cashOutSamples, seed, func(_ [][]card.Card, runout []card.Card) float64 {
+		return runoutWinnings(result.PotResults, byChair, runout, chair)
+	}), nil
+}
+
+// runoutWinnings is what chair would win from the pots on a complete board.
+// Pots are counted net of rake.
+func runoutWinnings(pots []PotResult, hands map[uint16][]card.Card, board []card.Card, chair uint16) float64 {
+	scores := make(map[uint16]uint32, len(hands))
+	for c, hole := range hands {
+		all := make(card.CardList, 0, 7)
+		all = append(all, hole...)
+		all = append(all, board...)
+		if eval := EvalBestOf7(all); eval != nil {
+			scores[c] = eval.Score
+		}
+	}
+	var won float64
+	for _, pot := range pots {
+		net := pot.Amount
+		if len(pot.WinAmounts) > 0 {
+			net = 0
+			for _, amt := range pot.WinAmounts {
+				net += amt
+			}
+		}
+		contested := false
+		var best uint32
+		tied := 0
+		for _, c := range pot.Eligible {
+			s, ok := scores[c]
+			if !ok {
+				continue
+			}
+			if c == chair {
+				contested = true
+			}
+			switch {
+			case s > best:
+				best, tied = s, 1
+			case s == best:
+				tied++
+			}
+		}
+		if contested && scores[chair] == best {
+			won += float64(net) / float64(tied)
+		}
+	}
+	return won
+}
+
+// ApplyCashOut replaces chair's winnings from the hand that just settled with
+// payout and records the adjustment on the settlement. Each player can cash
+// out once per hand.
+func (g *Game) ApplyCashOut(chair uint16, equity float64, payout int64) (CashOut, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.ended || g.lastSettlement == nil {
+		return CashOut{}, fmt.Errorf("hand not settled")
+	}
+	if payout < 0 {
+		return CashOut{}, fmt.Errorf("invalid cash-out payout %d", payout)
+	}
+	settle := g.lastSettlement
+	for _, co := range settle.CashOuts {
+		if co.Chair == chair {
+			return CashOut{}, fmt.Errorf("chair %d already cashed out", chair)
+		}
+	}
+	var actual int64
+	found := false
+	for _, pr := range settle.PlayerResults {
+		if pr.Chair == chair {
+			actual = pr.WinAmount
+			found = true
+			break
+		}
+	}
+	p := g.playersByChair[chair]
+	if !found || p == nil {
+		return CashOut{}, fmt.Errorf("chair %d not in showdown", chair)
+	}
+	if err := p.addStack(payout - actual); err != nil {
+		return CashOut{}, err
+	}
+	co := CashOut{Chair: chair, Equity: equity, Payout: payout, Actual: actual}
+	settle.CashOuts = append(settle.CashOuts, co)
+	return co, nil
+}
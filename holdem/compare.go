@@ -0,0 +1,55 @@
+package holdem
+
+import (
+	"fmt"
+
+	"holdem-lite/card"
+)
+
+// CompareHands ranks two Hold'em hands on a shared board: 1 when a wins, -1
+// when b wins, 0 when they split. Each hand is two hole cards and the board
+// three to five community cards, all distinct cards of the standard deck;
+// anything else returns an error wrapping ErrInvalidHand.
+func CompareHands(a, b, board []card.Card) (int, error) {
+	if len(a) != 2 || len(b) != 2 {
+		return 0, fmt.Errorf("%w: need 2 hole cards per hand, got %d and %d", ErrInvalidHand, len(a), len(b))
+	}
+	if len(board) < 3 || len(board) > 5 {
+		return 0, fmt.Errorf("%w: board must have 3 to 5 cards, got %d", ErrInvalidHand, len(board))
+	}
+	if _, err := deadCards(a, b, board); err != nil {
+		return 0, err
+	}
+
+	ea := EvalBest(VariantHoldem, DeckStandard, a, board)
+	eb := EvalBest(VariantHoldem, DeckStandard, b, board)
+	switch {
+	case ea.Score > eb.Score:
+		return 1, nil
+	case ea.Score < eb.Score:
+		return -1, nil
+	}
+	return 0, nil
+}
+
+// deadCards checks that every card is a distinct card of the standard deck
+// and returns them as a set.
+func deadCards(sets ...[]card.Card) (map[card.Card]struct{}, error) {
+	valid := make(map[card.Card]struct{}, len(HoldemCards))
+	for _, c := range HoldemCards {
+		valid[c] = struct{}{}
+	}
+	seen := make(map[card.Card]struct{}, 9)
+	for _, cards := range sets {
+		for _, c := range cards {
+			if _, ok := valid[c]; !ok {
+				return nil, fmt.Errorf("%w: unknown card %v", ErrInvalidHand, c)
+			}
+			if _, ok := seen[c]; ok {
+				return nil, fmt.Errorf("%w: card %v appears twice", ErrInvalidHand, c)
+			}
+			seen[c] = struct{}{}
+		}
+	}
+	return seen, nil
+}
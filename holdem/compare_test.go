@@ -0,0 +1,52 @@
+package holdem
+
+import (
+	"errors"
+	"testing"
+
+	"holdem-lite/card"
+)
+
+func TestCompareHands(t *testing.T) {
+	board := []card.Card{card.CardSpadeK, card.CardHeart9, card.CardClub4, card.CardDiamond2, card.CardSpade7}
+	cases := []struct {
+		name  string
+		a, b  []card.Card
+		board []card.Card
+		want  int
+	}{
+		{"set beats overpair", []card.Card{card.CardHeartK, card.CardClubK}, []card.Card{card.CardSpadeA, card.CardHeartA}, board, 1},
+		{"kicker decides", []card.Card{card.CardHeartK, card.CardClub3}, []card.Card{card.CardClubK, card.CardHeartQ}, board, -1},
+		{"board plays", []card.Card{card.CardHeart3, card.CardClub2},
+			[]card.Card{card.CardDiamond3, card.CardSpade2},
+			[]card.Card{card.CardSpadeA, card.CardHeartK, card.CardClubQ, card.CardDiamondJ, card.CardSpadeT}, 0},
+		{"wheel beats trips", []card.Card{card.CardHeartA, card.CardClub5}, []card.Card{card.CardSpade4, card.CardHeart4},
+			[]card.Card{card.CardSpade2, card.CardDiamond3, card.CardClub4, card.CardHeartK}, 1},
+		{"six-high straight beats the wheel", []card.Card{card.CardHeartA, card.CardClubK},
+			[]card.Card{card.CardHeart6, card.CardClub9},
+			[]card.Card{card.CardSpade2, card.CardDiamond3, card.CardClub4, card.CardHeart5}, -1},
+	}
+	for _, tc := range cases {
+		got, err := CompareHands(tc.a, tc.b, tc.board)
+		if err != nil {
+			t.Fatalf("%s: err %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCompareHands_RejectsInvalidInput(t *testing.T) {
+	board := []card.Card{card.CardSpadeK, card.CardHeart9, card.CardClub4}
+	for name, tc := range map[string][3][]card.Card{
+		"one hole card":  {{card.CardHeartA}, {card.CardClubA, card.CardClubK}, board},
+		"short board":    {{card.CardHeartA, card.CardHeartQ}, {card.CardClubA, card.CardClubK}, board[:2]},
+		"duplicate card": {{card.CardSpadeK, card.CardHeartQ}, {card.CardClubA, card.CardClubK}, board},
+		"unknown card":   {{card.CardRear, card.CardHeartQ}, {card.CardClubA, card.CardClubK}, board},
+	} {
+		if _, err := CompareHands(tc[0], tc[1], tc[2]); !errors.Is(err, ErrInvalidHand) {
+			t.Fatalf("%s: expected ErrInvalidHand, got %v", name, err)
+		}
+	}
+}
@@ -7,27 +7,122 @@ import (
 	"holdem-lite/card"
 )
 
+// AnteMode selects how the ante is collected.
+type AnteMode uint8
+
+const (
+	AntePerPlayer AnteMode = iota
+	// AnteBigBlind has the big blind post a single ante of Ante chips as dead
+	// money. The blind comes first: a big blind short of both pays the blind
+	// in full and only what is left toward the ante.
+	AnteBigBlind
+)
+
+// BettingStructure limits how much a player may bet or raise.
+type BettingStructure uint8
+
+const (
+	NoLimit BettingStructure = iota
+	// PotLimit caps every bet and raise at the size of the pot, counting the
+	// chips the player needs to call first.
+	PotLimit
+)
+
+// Variant selects the game's hand rules.
+type Variant uint8
+
+const (
+	// VariantHoldem makes the best five of the hole and board cards.
+	VariantHoldem Variant = iota
+	// VariantOmaha must use exactly two hole cards and three board cards.
+	VariantOmaha
+)
+
+// defaultHoleCards is the hole-card count a variant deals when
+// Config.HoleCardCount is 0.
+var defaultHoleCards = map[Variant]int{
+	VariantHoldem: 2,
+	VariantOmaha:  4,
+}
+
+// DeckType selects the cards in play.
+type DeckType uint8
+
+const (
+	DeckStandard DeckType = iota
+	// DeckShort removes the 2s through 5s, leaving 36 cards. Flushes beat
+	// full houses and A-6-7-8-9 is the lowest straight.
+	DeckShort
+)
+
+// DealerSelection picks how the first button of a game is placed.
+type DealerSelection uint8
+
+const (
+	// DealerRandom puts the button on a random dealt-in seat.
+	DealerRandom DealerSelection = iota
+	// DealerHighCard deals each dealt-in seat one card face up from a fresh
+	// deck; the highest card takes the button, ties broken by suit (spades,
+	// hearts, diamonds, clubs). The draw is kept in Game.DealerDraw.
+	DealerHighCard
+)
+
+// Cards lists the deck's cards in a fixed order.
+func (d DeckType) Cards() []card.Card {
+	if d == DeckShort {
+		return ShortDeckCards
+	}
+	return HoldemCards
+}
+
 type Config struct {
 	// Table
 	MaxPlayers int
 	MinPlayers int
 
-	// Blinds / Ante
+	// Blinds / Ante. SmallBlind 0 runs a big-blind-only table: the seat left
+	// of the button posts the big blind and there is no small blind seat.
 	SmallBlind int64
 	BigBlind   int64
 	Ante       int64
+	// AnteMode picks who posts the ante: every dealt-in player, or only the
+	// big blind on behalf of the table.
+	AnteMode AnteMode
+
+	// BettingStructure defaults to NoLimit.
+	BettingStructure BettingStructure
+
+	// Variant picks the showdown rule; HoleCardCount the cards dealt to each
+	// player (0 => the variant's default: 2 for Hold'em, 4 for Omaha).
+	Variant       Variant
+	HoleCardCount int
+	// Deck defaults to DeckStandard.
+	Deck DeckType
+	// DealerSelection places the first button; defaults to DealerRandom.
+	DealerSelection DealerSelection
 
 	// Optional: action timeout (0 disables internal timeout)
 	ActionTimeout time.Duration
 	AutoTimeout   time.Duration
 
-	// RNG seed (0 => time-based)
+	// Rake taken from each pot before it is split. RakeCapBB caps the rake
+	// per hand in big blinds (0 => no cap); RakeRounding turns fractional
+	// chips into whole ones. With RakeOnlyAtFlop ("no flop, no drop") hands
+	// that end before the flop is dealt are not raked.
+	RakePercent    float64
+	RakeCapBB      int64
+	RakeRounding   RakeRounding
+	RakeOnlyAtFlop bool
+
+	// RNG seed (0 => time-based). A non-zero seed also makes the per-hand
+	// deck seeds deterministic, which is only meant for tests and replays;
+	// unseeded games draw each hand seed from crypto/rand.
 	Seed int64
 
 	// Optional replay controls.
 	// ForcedDealerChair pins button seat for deterministic reconstruction.
 	ForcedDealerChair *uint16
-	// DeckOverride pins full deck order (52 cards), consumed from index 0 upward.
+	// DeckOverride pins full deck order (every card of Deck), consumed from index 0 upward.
 	DeckOverride []card.Card
 }
 
@@ -47,28 +142,61 @@ func (c Config) validate() error {
 	if c.Ante < 0 {
 		return fmt.Errorf("Ante must be >= 0")
 	}
+	if c.AnteMode > AnteBigBlind {
+		return fmt.Errorf("invalid AnteMode %d", c.AnteMode)
+	}
+	if c.BettingStructure > PotLimit {
+		return fmt.Errorf("invalid BettingStructure %d", c.BettingStructure)
+	}
+	if c.Variant > VariantOmaha {
+		return fmt.Errorf("invalid Variant %d", c.Variant)
+	}
+	if c.Deck > DeckShort {
+		return fmt.Errorf("invalid Deck %d", c.Deck)
+	}
+	if c.DealerSelection > DealerHighCard {
+		return fmt.Errorf("invalid DealerSelection %d", c.DealerSelection)
+	}
+	if c.HoleCardCount != 0 && c.HoleCardCount < 2 {
+		return fmt.Errorf("HoleCardCount must be >= 2, got %d", c.HoleCardCount)
+	}
+	if need := c.MaxPlayers*c.holeCards() + 5; need > len(c.Deck.Cards()) {
+		return fmt.Errorf("%d players with %d hole cards need %d cards, the deck has %d",
+			c.MaxPlayers, c.holeCards(), need, len(c.Deck.Cards()))
+	}
 	if c.AutoTimeout < 0 || c.ActionTimeout < 0 {
 		return fmt.Errorf("timeouts must be >= 0")
 	}
+	if err := c.validateRake(); err != nil {
+		return err
+	}
 	if c.ForcedDealerChair != nil && int(*c.ForcedDealerChair) >= c.MaxPlayers {
 		return fmt.Errorf("forced dealer chair out of range: %d", *c.ForcedDealerChair)
 	}
-	if err := validateDeckOverride(c.DeckOverride); err != nil {
+	if err := validateDeckOverride(c.DeckOverride, c.Deck.Cards()); err != nil {
 		return err
 	}
 	return nil
 }
 
-func validateDeckOverride(deck []card.Card) error {
+// holeCards is the number of hole cards dealt to each player.
+func (c Config) holeCards() int {
+	if c.HoleCardCount > 0 {
+		return c.HoleCardCount
+	}
+	return defaultHoleCards[c.Variant]
+}
+
+func validateDeckOverride(deck, cards []card.Card) error {
 	if len(deck) == 0 {
 		return nil
 	}
-	if len(deck) != len(HoldemCards) {
-		return fmt.Errorf("deck override must contain %d cards, got %d", len(HoldemCards), len(deck))
+	if len(deck) != len(cards) {
+		return fmt.Errorf("deck override must contain %d cards, got %d", len(cards), len(deck))
 	}
 
-	valid := make(map[card.Card]struct{}, len(HoldemCards))
-	for _, c := range HoldemCards {
+	valid := make(map[card.Card]struct{}, len(cards))
+	for _, c := range cards {
 		valid[c] = struct{}{}
 	}
 	seen := make(map[card.Card]struct{}, len(deck))
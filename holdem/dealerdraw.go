@@ -0,0 +1,53 @@
+package holdem
+
+import "holdem-lite/card"
+
+// DealerDrawCard is the card one seat drew for the button.
+type DealerDrawCard struct {
+	Chair uint16
+	Card  card.Card
+}
+
+// drawSuitOrder ranks suits for breaking high-card ties: spades, hearts,
+// diamonds, clubs.
+var drawSuitOrder = map[card.Suit]int{
+	card.Spade:   3,
+	card.Heart:   2,
+	card.Diamond: 1,
+	card.Club:    0,
+}
+
+func drawBeats(a, b card.Card) bool {
+	if a.HandRealVal() != b.HandRealVal() {
+		return a.HandRealVal() > b.HandRealVal()
+	}
+	return drawSuitOrder[a.Suit()] > drawSuitOrder[b.Suit()]
+}
+
+// drawForDealer deals one card to each of nodes, in chair order, from a deck
+// shuffled apart from the hand's own and returns the node that drew highest.
+func (g *Game) drawForDealer(nodes []*PlayerNode) *PlayerNode {
+	deck := ShuffledDeckOf(g.cfg.Deck, g.rng.Int63())
+	g.dealerDraw = make([]DealerDrawCard, len(nodes))
+	best := 0
+	for i, n := range nodes {
+		g.dealerDraw[i] = DealerDrawCard{Chair: n.ChairID, Card: deck[i]}
+		if drawBeats(deck[i], deck[best]) {
+			best = i
+		}
+	}
+	return nodes[best]
+}
+
+// DealerDraw returns the high-card draw that placed the current hand's
+// button, or nil when the button was not drawn for this hand.
+func (g *Game) DealerDraw() []DealerDrawCard {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.dealerDraw == nil {
+		return nil
+	}
+	out := make([]DealerDrawCard, len(g.dealerDraw))
+	copy(out, g.dealerDraw)
+	return out
+}
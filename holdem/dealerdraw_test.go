@@ -0,0 +1,96 @@
+package holdem
+
+import (
+	"reflect"
+	"testing"
+
+	"holdem-lite/card"
+)
+
+func highCardGame(t *testing.T, seed int64) *Game {
+	t.Helper()
+	g, err := NewGame(Config{
+		MaxPlayers:      6,
+		MinPlayers:      2,
+		SmallBlind:      50,
+		BigBlind:        100,
+		Seed:            seed,
+		DealerSelection: DealerHighCard,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for _, chair := range []uint16{0, 2, 3, 5} {
+		if err := g.SitDown(chair, uint64(10001+chair), 5000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+func TestDealerHighCard_HighestCardTakesButton(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		g := highCardGame(t, seed)
+		draw := g.DealerDraw()
+		if len(draw) != 4 {
+			t.Fatalf("seed %d: expected 4 drawn cards, got %d", seed, len(draw))
+		}
+		best := draw[0]
+		seen := map[uint16]bool{}
+		for _, d := range draw {
+			seen[d.Chair] = true
+			if drawBeats(d.Card, best.Card) {
+				best = d
+			}
+		}
+		if len(seen) != 4 {
+			t.Fatalf("seed %d: expected one card per seat, got %+v", seed, draw)
+		}
+		if got := g.Snapshot().DealerChair; got != best.Chair {
+			t.Fatalf("seed %d: button on chair %d, high card %v drawn by chair %d", seed, got, best.Card, best.Chair)
+		}
+
+		again := highCardGame(t, seed)
+		if !reflect.DeepEqual(again.DealerDraw(), draw) || again.Snapshot().DealerChair != best.Chair {
+			t.Fatalf("seed %d: draw is not deterministic", seed)
+		}
+	}
+}
+
+func TestDealerHighCard_SuitBreaksTies(t *testing.T) {
+	if !drawBeats(card.CardSpadeK, card.CardHeartK) ||
+		!drawBeats(card.CardHeartK, card.CardDiamondK) ||
+		!drawBeats(card.CardDiamondK, card.CardClubK) {
+		t.Fatalf("expected spades > hearts > diamonds > clubs among kings")
+	}
+	if !drawBeats(card.CardClubA, card.CardSpadeK) {
+		t.Fatalf("expected the ace of clubs to beat the king of spades")
+	}
+}
+
+func TestDealerHighCard_OnlyFirstHand(t *testing.T) {
+	g := highCardGame(t, 3)
+	button := g.Snapshot().DealerChair
+	for {
+		chair := g.Snapshot().ActionChair
+		end, err := g.Act(chair, PlayerActionTypeFold, 0)
+		if err != nil {
+			t.Fatalf("fold chair=%d err: %v", chair, err)
+		}
+		if end != nil {
+			break
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	if draw := g.DealerDraw(); draw != nil {
+		t.Fatalf("expected no draw on the second hand, got %+v", draw)
+	}
+	if g.Snapshot().DealerChair == button {
+		t.Fatalf("expected the button to move on from chair %d", button)
+	}
+}
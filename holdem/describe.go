@@ -0,0 +1,77 @@
+package holdem
+
+import (
+	"fmt"
+
+	"holdem-lite/card"
+)
+
+// rankNames and rankPlurals are indexed like rankToIndex: Two=0 .. Ace=12.
+var (
+	rankNames   = [13]string{"Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"}
+	rankPlurals = [13]string{"Twos", "Threes", "Fours", "Fives", "Sixes", "Sevens", "Eights", "Nines", "Tens", "Jacks", "Queens", "Kings", "Aces"}
+)
+
+// DescribeHand names an evaluated hand for showdown text and hand histories,
+// e.g. "Full House, Kings full of Tens" or "Ace-high Flush". cards are the
+// cards eval was scored from; its HandType picks the wording and the five
+// cards at BestIndex supply the ranks. A nil eval describes as "".
+func DescribeHand(eval *bestHandResult, cards card.CardList) string {
+	if eval == nil {
+		return ""
+	}
+	var counts [13]int
+	for _, i := range eval.BestIndex {
+		if i < 0 || i >= len(cards) {
+			return ""
+		}
+		counts[rankToIndex(cards[i])]++
+	}
+	// Ranks with the larger groups first, then higher ranks.
+	order := make([]int, 0, 5)
+	for n := 4; n >= 1; n-- {
+		for r := 12; r >= 0; r-- {
+			if counts[r] == n {
+				order = append(order, r)
+			}
+		}
+	}
+
+	switch eval.HandType {
+	case HandRoyalFlush:
+		return "Royal Flush"
+	case HandStraightFlush:
+		return fmt.Sprintf("%s-high Straight Flush", rankNames[straightHigh(counts)])
+	case HandFourOfKind:
+		return "Four of a Kind, " + rankPlurals[order[0]]
+	case HandFullHouse:
+		return fmt.Sprintf("Full House, %s full of %s", rankPlurals[order[0]], rankPlurals[order[1]])
+	case HandFlush:
+		return fmt.Sprintf("%s-high Flush", rankNames[order[0]])
+	case HandStraight:
+		return fmt.Sprintf("%s-high Straight", rankNames[straightHigh(counts)])
+	case HandThreeOfKind:
+		return "Three of a Kind, " + rankPlurals[order[0]]
+	case HandTwoPair:
+		return fmt.Sprintf("Two Pair, %s and %s", rankPlurals[order[0]], rankPlurals[order[1]])
+	case HandOnePair:
+		return "Pair of " + rankPlurals[order[0]]
+	case HandHighCard:
+		return rankNames[order[0]] + "-high"
+	}
+	return ""
+}
+
+// straightHigh is the top card of a straight's ranks. An ace without a king
+// plays low, so the wheel is Five-high (and short deck's A-6-7-8-9 Nine-high).
+func straightHigh(counts [13]int) int {
+	high := 12
+	for high > 0 && counts[high] == 0 {
+		high--
+	}
+	if high == 12 && counts[11] == 0 {
+		for high = 11; high > 0 && counts[high] == 0; high-- {
+		}
+	}
+	return high
+}
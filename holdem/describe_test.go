@@ -0,0 +1,45 @@
+package holdem
+
+import (
+	"testing"
+
+	"holdem-lite/card"
+)
+
+func TestDescribeHand(t *testing.T) {
+	cases := []struct {
+		cards card.CardList
+		want  string
+	}{
+		{card.CardList{card.CardSpadeA, card.CardSpadeK, card.CardSpadeQ, card.CardSpadeJ, card.CardSpadeT, card.CardHeart2, card.CardClub3}, "Royal Flush"},
+		{card.CardList{card.CardHeart9, card.CardHeart8, card.CardHeart7, card.CardHeart6, card.CardHeart5, card.CardSpadeA, card.CardClubA}, "Nine-high Straight Flush"},
+		{card.CardList{card.CardClubA, card.CardClub2, card.CardClub3, card.CardClub4, card.CardClub5, card.CardSpadeK, card.CardHeartQ}, "Five-high Straight Flush"},
+		{card.CardList{card.CardSpadeK, card.CardHeartK, card.CardClubK, card.CardDiamondK, card.CardSpade2, card.CardHeart3, card.CardClub7}, "Four of a Kind, Kings"},
+		{card.CardList{card.CardSpadeK, card.CardHeartK, card.CardClubK, card.CardDiamondT, card.CardSpadeT, card.CardHeart3, card.CardClub3}, "Full House, Kings full of Tens"},
+		{card.CardList{card.CardDiamondA, card.CardDiamond9, card.CardDiamond7, card.CardDiamond4, card.CardDiamond2, card.CardSpadeK, card.CardHeartQ}, "Ace-high Flush"},
+		{card.CardList{card.CardSpadeT, card.CardHeart9, card.CardClub8, card.CardDiamond7, card.CardSpade6, card.CardHeart2, card.CardClub2}, "Ten-high Straight"},
+		{card.CardList{card.CardSpadeA, card.CardHeart2, card.CardClub3, card.CardDiamond4, card.CardSpade5, card.CardHeartJ, card.CardClub9}, "Five-high Straight"},
+		{card.CardList{card.CardSpadeA, card.CardHeartK, card.CardClubQ, card.CardDiamondJ, card.CardSpadeT, card.CardHeart2, card.CardClub4}, "Ace-high Straight"},
+		{card.CardList{card.CardSpade7, card.CardHeart7, card.CardClub7, card.CardDiamondA, card.CardSpade9, card.CardHeart2, card.CardClub4}, "Three of a Kind, Sevens"},
+		{card.CardList{card.CardSpadeA, card.CardHeartA, card.CardClub8, card.CardDiamond8, card.CardSpade3, card.CardHeart3, card.CardClubK}, "Two Pair, Aces and Eights"},
+		{card.CardList{card.CardSpadeJ, card.CardHeartJ, card.CardClub8, card.CardDiamond6, card.CardSpade3, card.CardHeart2, card.CardClubK}, "Pair of Jacks"},
+		{card.CardList{card.CardSpadeA, card.CardHeartJ, card.CardClub8, card.CardDiamond6, card.CardSpade3, card.CardHeart2, card.CardClubK}, "Ace-high"},
+	}
+	for _, tc := range cases {
+		if got := DescribeHand(EvalBestOf7(tc.cards), tc.cards); got != tc.want {
+			t.Fatalf("%v: expected %q, got %q", tc.cards, tc.want, got)
+		}
+	}
+	if got := DescribeHand(nil, nil); got != "" {
+		t.Fatalf("expected an empty description for a nil eval, got %q", got)
+	}
+}
+
+func TestDescribeHand_ShortDeckWheel(t *testing.T) {
+	hole := card.CardList{card.CardSpadeA, card.CardHeart6}
+	board := card.CardList{card.CardClub7, card.CardDiamond8, card.CardSpade9, card.CardHeartK, card.CardClubK}
+	eval := EvalBest(VariantHoldem, DeckShort, hole, board)
+	if got := DescribeHand(eval, append(hole, board...)); got != "Nine-high Straight" {
+		t.Fatalf("expected a Nine-high Straight, got %q", got)
+	}
+}
@@ -0,0 +1,186 @@
+package holdem
+
+import (
+	"fmt"
+	"math/rand"
+
+	"holdem-lite/card"
+)
+
+// equitySeed fixes the Monte Carlo stream so the same spot always gives the
+// same estimate.
+const equitySeed = 1
+
+// EvalEquity estimates hole's share of the pot at showdown against opponents
+// random hands, running iterations deals of their cards and the rest of the
+// board. Ties split the share. board holds 0 to 5 cards. Invalid input (hole
+// not two cards, duplicate or unknown cards, too many opponents for the deck,
+// iterations < 1) has no equity and returns 0.
+func EvalEquity(hole []card.Card, board []card.Card, opponents int, iterations int) float64 {
+	if _, err := holeAndBoard(hole, board); err != nil {
+		return 0
+	}
+	if opponents < 1 || 2*opponents+5-len(board) > len(HoldemCards)-len(hole)-len(board) || iterations < 1 {
+		return 0
+	}
+	return ShowdownEquity(hole, make([][]card.Card, opponents), board, iterations, equitySeed)
+}
+
+// ShowdownEquity returns hero's share of the pot at showdown against
+// opponents, splitting ties. A nil opponent hand is unknown and dealt from the
+// unseen cards. With every opponent known and at most two board cards to come
+// the result is exact; otherwise it averages samples deals drawn with seed.
+// All cards must be distinct.
+func ShowdownEquity(hero []card.Card, opponents [][]card.Card, board []card.Card, samples int, seed int64) float64 {
+	return averageDeals(hero, opponents, board, samples, seed, func(hands [][]card.Card, runout []card.Card) float64 {
+		return showdownShare(hero, hands, runout)
+	})
+}
+
+// averageDeals averages score over the ways the unseen cards complete board
+// and fill every nil entry of hands with two cards. Cards in dead, board and
+// the known hands are not dealt. With no unknown hands and at most two board
+// cards to come every completion is scored once; otherwise samples deals are
+// drawn from a generator seeded with seed.
+func averageDeals(dead []card.Card, hands [][]card.Card, board []card.Card, samples int, seed int64, score func(hands [][]card.Card, runout []card.Card) float64) float64 {
+	used := make(map[card.Card]struct{}, len(dead)+5+2*len(hands))
+	for _, c := range dead {
+		used[c] = struct{}{}
+	}
+	for _, c := range board {
+		used[c] = struct{}{}
+	}
+	unknown := 0
+	for _, hand := range hands {
+		if hand == nil {
+			unknown++
+		}
+		for _, c := range hand {
+			used[c] = struct{}{}
+		}
+	}
+	deck := liveCards(used)
+	missing := 5 - len(board)
+	runout := make([]card.Card, 0, 5)
+
+	if unknown == 0 && missing <= 2 {
+		var total float64
+		runs := 0
+		switch missing {
+		case 0:
+			return score(hands, board)
+		case 1:
+			for _, a := range deck {
+				runout = append(append(runout[:0], board...), a)
+				total += score(hands, runout)
+				runs++
+			}
+		case 2:
+			for i := 0; i < len(deck); i++ {
+				for j := i + 1; j < len(deck); j++ {
+					runout = append(append(runout[:0], board...), deck[i], deck[j])
+					total += score(hands, runout)
+					runs++
+				}
+			}
+		}
+		return total / float64(runs)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	dealt := make([][]card.Card, len(hands))
+	var total float64
+	for s := 0; s < samples; s++ {
+		// Partial Fisher-Yates: only the cards this trial needs are drawn.
+		next := 0
+		draw := func() card.Card {
+			k := next + rng.Intn(len(deck)-next)
+			deck[next], deck[k] = deck[k], deck[next]
+			next++
+			return deck[next-1]
+		}
+		for i, hand := range hands {
+			if hand == nil {
+				dealt[i] = []card.Card{draw(), draw()}
+			} else {
+				dealt[i] = hand
+			}
+		}
+		runout = append(runout[:0], board...)
+		for len(runout) < 5 {
+			runout = append(runout, draw())
+		}
+		total += score(dealt, runout)
+	}
+	return total / float64(samples)
+}
+
+// showdownShare is hero's fraction of the pot on a complete board.
+func showdownShare(hero []card.Card, opponents [][]card.Card, board []card.Card) float64 {
+	best := handScore(hero, board)
+	tied := 1
+	for _, opp := range opponents {
+		score := handScore(opp, board)
+		if score > best {
+			return 0
+		}
+		if score == best {
+			tied++
+		}
+	}
+	return 1 / float64(tied)
+}
+
+func handScore(hole, board []card.Card) uint32 {
+	all := make(card.CardList, 0, 7)
+	all = append(all, hole...)
+	all = append(all, board...)
+	if eval := EvalBestOf7(all); eval != nil {
+		return eval.Score
+	}
+	return 0
+}
+
+// CountOuts returns the unseen cards that, dealt as the next board card,
+// give hole a better hand type than it holds now. Outs only exist on the
+// flop and turn; other board sizes, and input EvalEquity would reject,
+// return none.
+func CountOuts(hole, board []card.Card) []card.Card {
+	dead, err := holeAndBoard(hole, board)
+	if err != nil || len(board) < 3 || len(board) > 4 {
+		return nil
+	}
+	current := EvalBest(VariantHoldem, DeckStandard, hole, board).HandType
+	next := make([]card.Card, len(board)+1)
+	copy(next, board)
+	var outs []card.Card
+	for _, c := range liveCards(dead) {
+		next[len(board)] = c
+		if EvalBest(VariantHoldem, DeckStandard, hole, next).HandType > current {
+			outs = append(outs, c)
+		}
+	}
+	return outs
+}
+
+// holeAndBoard validates a hand and returns its cards as a set.
+func holeAndBoard(hole, board []card.Card) (map[card.Card]struct{}, error) {
+	if len(hole) != 2 {
+		return nil, fmt.Errorf("%w: need 2 hole cards, got %d", ErrInvalidHand, len(hole))
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("%w: board must have at most 5 cards, got %d", ErrInvalidHand, len(board))
+	}
+	return deadCards(hole, board)
+}
+
+// liveCards is the standard deck minus dead, in deck order.
+func liveCards(dead map[card.Card]struct{}) []card.Card {
+	deck := make([]card.Card, 0, len(HoldemCards)-len(dead))
+	for _, c := range HoldemCards {
+		if _, ok := dead[c]; !ok {
+			deck = append(deck, c)
+		}
+	}
+	return deck
+}
@@ -0,0 +1,78 @@
+package holdem
+
+import (
+	"math"
+	"testing"
+
+	"holdem-lite/card"
+)
+
+func TestCountOuts_FlushDraw(t *testing.T) {
+	hole := []card.Card{card.CardHeartA, card.CardHeartK}
+	board := []card.Card{card.CardHeart2, card.CardHeart7, card.CardClub9}
+	outs := CountOuts(hole, board)
+	hearts := 0
+	for _, c := range outs {
+		if c.Suit() == card.CardHeartA.Suit() {
+			hearts++
+		}
+	}
+	// Nine hearts make the flush; the other fourteen pair a hole or board card.
+	if hearts != 9 || len(outs) != 23 {
+		t.Fatalf("expected 9 flush outs of 23, got %d of %d: %v", hearts, len(outs), outs)
+	}
+
+	// On the turn a made pair only improves through the flush or trips/two pair.
+	board = append(board, card.CardSpade9)
+	outs = CountOuts(hole, board)
+	if len(outs) != 9+3+3+3+3+1 {
+		t.Fatalf("expected 22 turn outs, got %d: %v", len(outs), outs)
+	}
+
+	if outs := CountOuts(hole, append(board, card.CardClub3)); outs != nil {
+		t.Fatalf("expected no outs on the river, got %v", outs)
+	}
+}
+
+func TestEvalEquity_PreflopMatchups(t *testing.T) {
+	cases := []struct {
+		name      string
+		hole      []card.Card
+		opponents int
+		want      float64
+	}{
+		{"aces vs one", []card.Card{card.CardSpadeA, card.CardHeartA}, 1, 0.852},
+		{"aces vs two", []card.Card{card.CardSpadeA, card.CardHeartA}, 2, 0.735},
+		{"seven-deuce vs one", []card.Card{card.CardSpade7, card.CardHeart2}, 1, 0.346},
+	}
+	for _, tc := range cases {
+		if got := EvalEquity(tc.hole, nil, tc.opponents, 20000); math.Abs(got-tc.want) > 0.015 {
+			t.Fatalf("%s: expected equity near %.3f, got %.3f", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestEvalEquity_RejectsInvalidInput(t *testing.T) {
+	hole := []card.Card{card.CardSpadeA, card.CardHeartA}
+	if got := EvalEquity(hole, []card.Card{card.CardSpadeA}, 1, 100); got != 0 {
+		t.Fatalf("expected no equity with a duplicate card, got %.3f", got)
+	}
+	flop := []card.Card{card.CardHeart2, card.CardHeart7, card.CardClub9}
+	if outs := CountOuts(hole[:1], flop); outs != nil {
+		t.Fatalf("expected no outs for one hole card, got %v", outs)
+	}
+	if got := EvalEquity(hole, nil, 0, 100); got != 0 {
+		t.Fatalf("expected no equity without opponents, got %.3f", got)
+	}
+}
+
+func TestShowdownEquity_ExactWithKnownHands(t *testing.T) {
+	// Kings against aces with one card to come: only the two kings left win.
+	hero := []card.Card{card.CardSpadeK, card.CardHeartK}
+	villain := []card.Card{card.CardSpadeA, card.CardHeartA}
+	board := []card.Card{card.CardClub2, card.CardDiamond7, card.CardClub9, card.CardSpade4}
+	got := ShowdownEquity(hero, [][]card.Card{villain}, board, 1, 1)
+	if want := 2.0 / 44; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected exact equity %.4f, got %.4f", want, got)
+	}
+}
@@ -6,6 +6,10 @@ var (
 	ErrHandEnded      = errors.New("hand already ended")
 	ErrOutOfTurn      = errors.New("action out of turn")
 	ErrHandInProgress = errors.New("hand in progress")
+	ErrInvalidHand    = errors.New("invalid hand")
+	// ErrChipOverflow is returned when moving chips would take a stack, bet
+	// or pot past the int64 range.
+	ErrChipOverflow = errors.New("chip count overflow")
 )
 
 type InvalidStateError string
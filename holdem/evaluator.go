@@ -19,21 +19,44 @@ func EvalBestOf7(cards card.CardList) *bestHandResult {
 		return nil
 	}
 
-	var best *bestHandResult
-	idx := [5]int{}
+	// Per-card lookups are done once and shared by all 21 combos.
+	var ranks, primes [7]int
+	var suits [7]card.Suit
+	var suitCounts [4]int
+	flushSuit := card.Suit(0xff)
+	for i, c := range cards {
+		ranks[i] = rankToIndex(c)
+		primes[i] = kevPrimes[ranks[i]]
+		suits[i] = c.Suit()
+		if s := int(suits[i]); s < len(suitCounts) {
+			if suitCounts[s]++; suitCounts[s] >= 5 {
+				flushSuit = suits[i]
+			}
+		}
+	}
 
+	best := bestHandResult{}
 	for a := 0; a < 3; a++ {
 		for b := a + 1; b < 4; b++ {
 			for c := b + 1; c < 5; c++ {
 				for d := c + 1; d < 6; d++ {
 					for e := d + 1; e < 7; e++ {
-						idx[0], idx[1], idx[2], idx[3], idx[4] = a, b, c, d, e
-						score, handType := eval5(cards[a], cards[b], cards[c], cards[d], cards[e])
-						if best == nil || score > best.Score {
-							best = &bestHandResult{
+						bitmask := 1<<ranks[a] | 1<<ranks[b] | 1<<ranks[c] | 1<<ranks[d] | 1<<ranks[e]
+						var handRank uint16
+						if suits[a] == flushSuit && suits[b] == flushSuit && suits[c] == flushSuit &&
+							suits[d] == flushSuit && suits[e] == flushSuit {
+							handRank = kevFlushRanks[bitmask]
+						} else if handRank = kevUnique5Ranks[bitmask]; handRank == 0 {
+							handRank = kevProductRank(primes[a] * primes[b] * primes[c] * primes[d] * primes[e])
+						}
+						if handRank == 0 {
+							continue
+						}
+						if score := uint32(kevMaxHandRank + 1 - int(handRank)); score > best.Score {
+							best = bestHandResult{
 								Score:     score,
-								HandType:  handType,
-								BestIndex: idx,
+								HandType:  handTypeFromKevRank(int(handRank)),
+								BestIndex: [5]int{a, b, c, d, e},
 							}
 						}
 					}
@@ -41,43 +64,247 @@ func EvalBestOf7(cards card.CardList) *bestHandResult {
 			}
 		}
 	}
+	if best.Score == 0 {
+		return nil
+	}
+	return &best
+}
+
+// EvalBest evaluates the best 5-card hand the variant allows from hole and
+// board, ranked by deck's rules. BestIndex refers to hole followed by board.
+func EvalBest(v Variant, deck DeckType, hole, board card.CardList) *bestHandResult {
+	all := make(card.CardList, 0, len(hole)+len(board))
+	all = append(all, hole...)
+	all = append(all, board...)
+	eval := eval5
+	if deck == DeckShort {
+		eval = eval5Short
+	}
+	if v != VariantOmaha {
+		if len(all) == 7 && deck == DeckStandard {
+			return EvalBestOf7(all)
+		}
+		return evalBestCombo(eval, all, nil, 0)
+	}
+	if len(hole) < 2 || len(board) < 3 {
+		return nil
+	}
+	// Omaha: every pair of hole cards with every three board cards.
+	var best *bestHandResult
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			if r := evalBestCombo(eval, all, []int{i, j}, len(hole)); r != nil && (best == nil || r.Score > best.Score) {
+				best = r
+			}
+		}
+	}
+	return best
+}
+
+// EvalOmaha evaluates a standard-deck Omaha hand: the best of the 60 hands
+// made of exactly two hole cards and three board cards. BestIndex refers to
+// hole followed by board.
+func EvalOmaha(hole [4]card.Card, board [5]card.Card) *bestHandResult {
+	return EvalBest(VariantOmaha, DeckStandard, hole[:], board[:])
+}
+
+// evalBestCombo scores every 5-card hand made of the fixed indices of cards
+// plus cards drawn from index from onward.
+func evalBestCombo(eval func(a, b, c, d, e card.Card) (uint32, byte), cards card.CardList, fixed []int, from int) *bestHandResult {
+	pool := make([]int, 0, len(cards))
+	for i := from; i < len(cards); i++ {
+		pool = append(pool, i)
+	}
+	pick := 5 - len(fixed)
+	if pick < 0 || len(pool) < pick {
+		return nil
+	}
+
+	var best *bestHandResult
+	idx := make([]int, 0, 5)
+	var walk func(from int)
+	walk = func(from int) {
+		if len(idx) == pick {
+			var hand [5]int
+			copy(hand[:], fixed)
+			copy(hand[len(fixed):], idx)
+			score, handType := eval(cards[hand[0]], cards[hand[1]], cards[hand[2]], cards[hand[3]], cards[hand[4]])
+			if best == nil || score > best.Score {
+				best = &bestHandResult{Score: score, HandType: handType, BestIndex: hand}
+			}
+			return
+		}
+		for i := from; i < len(pool); i++ {
+			idx = append(idx, pool[i])
+			walk(i + 1)
+			idx = idx[:len(idx)-1]
+		}
+	}
+	walk(0)
 	return best
 }
 
 func eval5(a, b, c, d, e card.Card) (score uint32, handType byte) {
+	ra, rb, rc, rd, re := rankToIndex(a), rankToIndex(b), rankToIndex(c), rankToIndex(d), rankToIndex(e)
+	bitmask := 1<<ra | 1<<rb | 1<<rc | 1<<rd | 1<<re
+
+	var handRank uint16
+	if s := a.Suit(); b.Suit() == s && c.Suit() == s && d.Suit() == s && e.Suit() == s {
+		handRank = kevFlushRanks[bitmask]
+	} else if handRank = kevUnique5Ranks[bitmask]; handRank == 0 {
+		handRank = kevProductRank(kevPrimes[ra] * kevPrimes[rb] * kevPrimes[rc] * kevPrimes[rd] * kevPrimes[re])
+	}
+	if handRank == 0 {
+		return 0, 0
+	}
+
+	// Convert Kev rank (1 best .. 7462 worst) to "bigger is better".
+	score = uint32(kevMaxHandRank + 1 - int(handRank))
+	handType = handTypeFromKevRank(int(handRank))
+	return score, handType
+}
+
+// The generated Kev tables are maps; eval5 reads these copies instead so a
+// lookup is an index (flushes and five distinct ranks, by rank bitmask) or a
+// short linear probe (the rest, by prime product) rather than a map access.
+var (
+	kevFlushRanks   [1 << 13]uint16
+	kevUnique5Ranks [1 << 13]uint16
+	kevProductSlots [1 << kevProductBits]kevProductSlot
+)
+
+// kevProductBits sizes the open-addressed product table to under a third
+// full for the 4888 products.
+const kevProductBits = 14
+
+type kevProductSlot struct {
+	product uint32
+	rank    uint16
+}
+
+func init() {
+	for bitmask, rank := range kevFlushesTable {
+		kevFlushRanks[bitmask] = uint16(rank)
+	}
+	for bitmask, rank := range kevUnique5Table {
+		kevUnique5Ranks[bitmask] = uint16(rank)
+	}
+	for product, rank := range kevProductsTable {
+		i := kevProductHash(product)
+		for kevProductSlots[i].product != 0 {
+			i = (i + 1) & (len(kevProductSlots) - 1)
+		}
+		kevProductSlots[i] = kevProductSlot{product: uint32(product), rank: uint16(rank)}
+	}
+}
+
+func kevProductHash(product int) int {
+	return int(uint32(product) * 2654435761 >> (32 - kevProductBits))
+}
+
+// kevProductRank is the Kev rank of a hand with a repeated rank, or 0.
+func kevProductRank(product int) uint16 {
+	for i := kevProductHash(product); ; i = (i + 1) & (len(kevProductSlots) - 1) {
+		switch kevProductSlots[i].product {
+		case uint32(product):
+			return kevProductSlots[i].rank
+		case 0:
+			return 0
+		}
+	}
+}
+
+// shortDeckOrder ranks hand types under short-deck rules, where a flush
+// beats a full house.
+var shortDeckOrder = map[byte]uint32{
+	HandHighCard:      0,
+	HandOnePair:       1,
+	HandTwoPair:       2,
+	HandThreeOfKind:   3,
+	HandStraight:      4,
+	HandFullHouse:     5,
+	HandFlush:         6,
+	HandFourOfKind:    7,
+	HandStraightFlush: 8,
+	HandRoyalFlush:    9,
+}
+
+// eval5Short scores a hand of short-deck cards (no 2s through 5s). The
+// hand type sits in the top bits and the ranks that break ties below it, so
+// scores only compare with other eval5Short scores.
+func eval5Short(a, b, c, d, e card.Card) (score uint32, handType byte) {
 	cards := [5]card.Card{a, b, c, d, e}
-	suit0 := cards[0].Suit()
+	var counts [13]int
 	flush := true
 	bitmask := 0
-	product := 1
-
 	for _, cc := range cards {
 		rankIdx := rankToIndex(cc)
+		counts[rankIdx]++
 		bitmask |= 1 << rankIdx
-		product *= kevPrimes[rankIdx]
-		if cc.Suit() != suit0 {
+		if cc.Suit() != cards[0].Suit() {
 			flush = false
 		}
 	}
 
-	var handRank int
-	if flush {
-		handRank = kevFlushesTable[bitmask]
-	} else if v, ok := kevUnique5Table[bitmask]; ok {
-		handRank = v
-	} else {
-		handRank = kevProductsTable[product]
+	// Tie-break ranks: bigger groups first, then higher ranks.
+	order := make([]int, 0, 5)
+	for n := 4; n >= 1; n-- {
+		for r := 12; r >= 0; r-- {
+			if counts[r] == n {
+				order = append(order, r)
+			}
+		}
 	}
-	if handRank == 0 {
-		return 0, 0
+	high := shortStraightHigh(bitmask)
+	if high >= 0 {
+		order = []int{high}
 	}
 
-	// Convert Kev rank (1 best .. 7462 worst) to "bigger is better".
-	score = uint32(kevMaxHandRank + 1 - handRank)
-	handType = handTypeFromKevRank(handRank)
+	switch top := counts[order[0]]; {
+	case high >= 0 && flush && high == 12:
+		handType = HandRoyalFlush
+	case high >= 0 && flush:
+		handType = HandStraightFlush
+	case top == 4:
+		handType = HandFourOfKind
+	case flush:
+		handType = HandFlush
+	case top == 3 && counts[order[1]] == 2:
+		handType = HandFullHouse
+	case high >= 0:
+		handType = HandStraight
+	case top == 3:
+		handType = HandThreeOfKind
+	case top == 2 && counts[order[1]] == 2:
+		handType = HandTwoPair
+	case top == 2:
+		handType = HandOnePair
+	default:
+		handType = HandHighCard
+	}
+
+	score = shortDeckOrder[handType] << 20
+	for i, r := range order {
+		score |= uint32(r+1) << (16 - 4*i)
+	}
 	return score, handType
 }
 
+// shortStraightHigh returns the rank index of a straight's top card, or -1.
+// A-6-7-8-9 is the lowest straight and plays 9-high.
+func shortStraightHigh(bitmask int) int {
+	for high := 12; high >= 4; high-- {
+		window := 0x1f << (high - 4)
+		if bitmask&window == window {
+			return high
+		}
+	}
+	if wheel := 1<<12 | 0xf<<4; bitmask&wheel == wheel {
+		return 7
+	}
+	return -1
+}
+
 func handTypeFromKevRank(rank int) byte {
 	switch {
 	case rank == 1:
@@ -1,6 +1,7 @@
 package holdem
 
 import (
+	"math/rand"
 	"testing"
 
 	"holdem-lite/card"
@@ -78,3 +79,197 @@ func TestEval5_TableCoverage_NoMissingRank(t *testing.T) {
 		}
 	}
 }
+
+func TestEvalBest_OmahaUsesExactlyTwoHoleCards(t *testing.T) {
+	hole := card.CardList{card.CardHeartA, card.CardSpadeK, card.CardClub7, card.CardDiamond2}
+	board := card.CardList{card.CardHeartK, card.CardHeart9, card.CardHeart5, card.CardHeart3, card.CardSpade8}
+
+	// Hold'em may play four board hearts with the ace.
+	if res := EvalBest(VariantHoldem, DeckStandard, hole, board); res == nil || res.HandType != HandFlush {
+		t.Fatalf("hold'em: expected a flush, got %+v", res)
+	}
+	// Omaha needs two hearts in hand, so the best is a pair of kings.
+	res := EvalBest(VariantOmaha, DeckStandard, hole, board)
+	if res == nil || res.HandType != HandOnePair {
+		t.Fatalf("omaha: expected one pair, got %+v", res)
+	}
+	fromHole := 0
+	for _, i := range res.BestIndex {
+		if i < len(hole) {
+			fromHole++
+		}
+	}
+	if fromHole != 2 {
+		t.Fatalf("omaha: expected exactly two hole cards in %v", res.BestIndex)
+	}
+}
+
+func TestEvalOmaha_BoardFlushNeedsTwoSuitedHoleCards(t *testing.T) {
+	board := [5]card.Card{card.CardHeartK, card.CardHeart9, card.CardHeart5, card.CardHeart3, card.CardHeart2}
+
+	// Five hearts on board and one in hand: no flush, the kings pair plays.
+	one := [4]card.Card{card.CardHeartA, card.CardSpadeK, card.CardClub7, card.CardDiamond8}
+	if res := EvalOmaha(one, board); res == nil || res.HandType != HandOnePair {
+		t.Fatalf("expected one pair with a single heart, got %+v", res)
+	}
+	// With no heart at all the board flush does not play either.
+	none := [4]card.Card{card.CardSpadeA, card.CardClubQ, card.CardClub7, card.CardDiamond8}
+	if res := EvalOmaha(none, board); res == nil || res.HandType != HandHighCard {
+		t.Fatalf("expected high card without hearts, got %+v", res)
+	}
+	// Two hearts in hand make the flush, ace-high from the hand.
+	two := [4]card.Card{card.CardHeartA, card.CardHeartQ, card.CardClub7, card.CardDiamond8}
+	res := EvalOmaha(two, board)
+	if res == nil || res.HandType != HandFlush {
+		t.Fatalf("expected a flush with two hearts, got %+v", res)
+	}
+	if res.BestIndex[0] != 0 || res.BestIndex[1] != 1 {
+		t.Fatalf("expected the two hole hearts to play, got %v", res.BestIndex)
+	}
+}
+
+func TestEval5Short_NineHighWheelIsLowestStraight(t *testing.T) {
+	wheelScore, wheelType := eval5Short(
+		card.CardSpade9, card.CardHeart6, card.CardClub7, card.CardDiamond8, card.CardSpadeA,
+	)
+	if wheelType != HandStraight {
+		t.Fatalf("expected A-6-7-8-9 to be a straight, got %d", wheelType)
+	}
+	if _, stdType := eval5(card.CardSpade9, card.CardHeart6, card.CardClub7, card.CardDiamond8, card.CardSpadeA); stdType != HandHighCard {
+		t.Fatalf("expected A-6-7-8-9 to be high card on a full deck, got %d", stdType)
+	}
+
+	tenHighScore, _ := eval5Short(
+		card.CardSpade6, card.CardHeart7, card.CardClub8, card.CardDiamond9, card.CardSpadeT,
+	)
+	tripsScore, _ := eval5Short(
+		card.CardSpadeA, card.CardHeartA, card.CardClubA, card.CardDiamondK, card.CardSpadeQ,
+	)
+	if !(tripsScore < wheelScore && wheelScore < tenHighScore) {
+		t.Fatalf("expected trips < 9-high wheel < 10-high straight, got %d, %d, %d", tripsScore, wheelScore, tenHighScore)
+	}
+
+	hole := card.CardList{card.CardHeartA, card.CardClub6}
+	board := card.CardList{card.CardSpade7, card.CardDiamond8, card.CardClub9, card.CardHeartK, card.CardSpadeQ}
+	if res := EvalBest(VariantHoldem, DeckShort, hole, board); res == nil || res.HandType != HandStraight {
+		t.Fatalf("expected the short deck to play the wheel, got %+v", res)
+	}
+	if res := EvalBest(VariantHoldem, DeckStandard, hole, board); res == nil || res.HandType != HandHighCard {
+		t.Fatalf("expected a full deck to play ace high, got %+v", res)
+	}
+}
+
+func TestEval5Short_FlushBeatsFullHouse(t *testing.T) {
+	flushScore, flushType := eval5Short(
+		card.CardHeart6, card.CardHeart7, card.CardHeart8, card.CardHeartT, card.CardHeartQ,
+	)
+	boatScore, boatType := eval5Short(
+		card.CardSpadeA, card.CardHeartA, card.CardClubA, card.CardDiamondK, card.CardSpadeK,
+	)
+	if flushType != HandFlush || boatType != HandFullHouse {
+		t.Fatalf("expected flush and full house, got %d and %d", flushType, boatType)
+	}
+	if flushScore <= boatScore {
+		t.Fatalf("expected a queen-high flush to beat aces full: %d <= %d", flushScore, boatScore)
+	}
+
+	// The standard evaluator keeps the usual order.
+	stdFlush, _ := eval5(card.CardHeart6, card.CardHeart7, card.CardHeart8, card.CardHeartT, card.CardHeartQ)
+	stdBoat, _ := eval5(card.CardSpadeA, card.CardHeartA, card.CardClubA, card.CardDiamondK, card.CardSpadeK)
+	if stdFlush >= stdBoat {
+		t.Fatalf("expected a full house to beat a flush on a full deck: %d >= %d", stdFlush, stdBoat)
+	}
+}
+
+// evalBestOf7Reference is the original map-based evaluator, kept to check
+// the lookup arrays against.
+func evalBestOf7Reference(cards card.CardList) *bestHandResult {
+	var best *bestHandResult
+	for a := 0; a < 3; a++ {
+		for b := a + 1; b < 4; b++ {
+			for c := b + 1; c < 5; c++ {
+				for d := c + 1; d < 6; d++ {
+					for e := d + 1; e < 7; e++ {
+						score, handType := eval5Reference(cards[a], cards[b], cards[c], cards[d], cards[e])
+						if best == nil || score > best.Score {
+							best = &bestHandResult{Score: score, HandType: handType, BestIndex: [5]int{a, b, c, d, e}}
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+func eval5Reference(a, b, c, d, e card.Card) (uint32, byte) {
+	cards := [5]card.Card{a, b, c, d, e}
+	flush := true
+	bitmask := 0
+	product := 1
+	for _, cc := range cards {
+		rankIdx := rankToIndex(cc)
+		bitmask |= 1 << rankIdx
+		product *= kevPrimes[rankIdx]
+		if cc.Suit() != cards[0].Suit() {
+			flush = false
+		}
+	}
+	var handRank int
+	if flush {
+		handRank = kevFlushesTable[bitmask]
+	} else if v, ok := kevUnique5Table[bitmask]; ok {
+		handRank = v
+	} else {
+		handRank = kevProductsTable[product]
+	}
+	if handRank == 0 {
+		return 0, 0
+	}
+	return uint32(kevMaxHandRank + 1 - handRank), handTypeFromKevRank(handRank)
+}
+
+func randomSevens(n int) []card.CardList {
+	rng := rand.New(rand.NewSource(1))
+	hands := make([]card.CardList, n)
+	for i := range hands {
+		perm := rng.Perm(len(HoldemCards))
+		hand := make(card.CardList, 7)
+		for j := range hand {
+			hand[j] = HoldemCards[perm[j]]
+		}
+		hands[i] = hand
+	}
+	return hands
+}
+
+func TestEvalBestOf7_MatchesReference(t *testing.T) {
+	n := 200000
+	if testing.Short() {
+		n = 20000
+	}
+	for _, hand := range randomSevens(n) {
+		got, want := EvalBestOf7(hand), evalBestOf7Reference(hand)
+		if *got != *want {
+			t.Fatalf("%v: expected %+v, got %+v", hand, *want, *got)
+		}
+	}
+}
+
+func BenchmarkEvalBestOf7(b *testing.B) {
+	hands := randomSevens(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EvalBestOf7(hands[i&1023])
+	}
+}
+
+func BenchmarkEvalBestOf7Reference(b *testing.B) {
+	hands := randomSevens(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		evalBestOf7Reference(hands[i&1023])
+	}
+}
@@ -0,0 +1,60 @@
+package holdem
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+
+	"holdem-lite/card"
+)
+
+// ShuffledDeck returns the deck order a hand dealt from seed uses, so a revealed
+// hand seed can be checked against the cards that came out.
+func ShuffledDeck(seed int64) []card.Card {
+	return ShuffledDeckOf(DeckStandard, seed)
+}
+
+// ShuffledDeckOf is ShuffledDeck for a table playing deck d.
+func ShuffledDeckOf(d DeckType, seed int64) []card.Card {
+	src := d.Cards()
+	cards := make([]card.Card, len(src))
+	copy(cards, src)
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	return cards
+}
+
+// Seed returns the seed the game's generator was created with.
+func (g *Game) Seed() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rngSrc.seed
+}
+
+// HandSeed returns the seed the current (or last) hand's deck was shuffled
+// from. It is 0 when the deck came from Config.DeckOverride.
+func (g *Game) HandSeed() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.handSeed
+}
+
+// nextHandSeed draws the seed for the next hand's deck. Hand seeds are
+// published once a hand ends, so unless the game was explicitly seeded they
+// come from crypto/rand: seeds drawn from g.rng would let a player recover the
+// generator seed and predict every later deck.
+func (g *Game) nextHandSeed() int64 {
+	if g.cfg.Seed != 0 {
+		return g.rng.Int63()
+	}
+	var b [8]byte
+	for {
+		if _, err := crand.Read(b[:]); err != nil {
+			panic("holdem: crypto/rand unavailable: " + err.Error())
+		}
+		// 0 means "no seed" (DeckOverride), so draw again on the rare zero.
+		if seed := int64(binary.BigEndian.Uint64(b[:]) >> 1); seed != 0 {
+			return seed
+		}
+	}
+}
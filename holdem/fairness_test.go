@@ -0,0 +1,45 @@
+package holdem
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func startSeedTestHand(t *testing.T, seed int64) *Game {
+	t.Helper()
+	g, err := NewGame(Config{MaxPlayers: 2, MinPlayers: 2, SmallBlind: 1, BigBlind: 2, Seed: seed})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 2; chair++ {
+		if err := g.SitDown(chair, uint64(chair+1), 100, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+func TestHandSeed_SeededGamesAreDeterministic(t *testing.T) {
+	a, b := startSeedTestHand(t, 7), startSeedTestHand(t, 7)
+	if a.HandSeed() == 0 || a.HandSeed() != b.HandSeed() {
+		t.Fatalf("expected equal non-zero hand seeds, got %d and %d", a.HandSeed(), b.HandSeed())
+	}
+}
+
+func TestHandSeed_UnseededGamesDoNotLeakTheGenerator(t *testing.T) {
+	g := startSeedTestHand(t, 0)
+	if g.HandSeed() == 0 {
+		t.Fatalf("expected a hand seed")
+	}
+	// A revealed hand seed must not be an output of the generator whose seed
+	// a player could brute-force from the join time.
+	rng := rand.New(rand.NewSource(g.Seed()))
+	for i := 0; i < 8; i++ {
+		if rng.Int63() == g.HandSeed() {
+			t.Fatalf("hand seed is draw %d of the game generator", i)
+		}
+	}
+}
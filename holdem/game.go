@@ -11,8 +11,9 @@ import (
 )
 
 type Game struct {
-	cfg Config
-	rng *rand.Rand
+	cfg    Config
+	rng    *rand.Rand
+	rngSrc *countingSource
 
 	mu sync.Mutex
 
@@ -25,11 +26,21 @@ type Game struct {
 	phase          Phase
 	communityCards card.CardList
 	stockCards     card.CardList
+	// handSeed is drawn for every shuffled hand (see nextHandSeed); the deck
+	// is a pure function of it (see ShuffledDeck).
+	handSeed int64
 
 	dealerNode     *PlayerNode
+	dealerDraw     []DealerDrawCard // high-card draw that placed this hand's button, if any
+	uncalled       UncalledBet      // bet handed back during the last Act, if any
 	smallBlindNode *PlayerNode
 	bigBlindNode   *PlayerNode
 	curNode        *PlayerNode
+	straddleNode   *PlayerNode // posted straddle for the current hand (nil if none)
+
+	// straddle and re-straddle requested for the next hand (InvalidChair if none)
+	pendingStraddle   uint16
+	pendingReStraddle uint16
 
 	activeCount int
 	allinCount  int
@@ -38,6 +49,12 @@ type Game struct {
 	NeedActionCount int    // 剩余必须表态人数
 	MinRaise        int64  // 当前合法加注底线（delta）
 	CurrentRaiser   uint16 // 触发轮次重置的玩家（chair）
+	// raiseLevel 每条街开始及每次完整加注时自增；玩家在当前 level 已表态则不能再加注，
+	// 不足最小加注的 all-in 不会 reopen。
+	raiseLevel int
+	// preflopAggressor is the chair that made the last full preflop raise this
+	// hand (InvalidChair if none); unlike CurrentRaiser it survives later streets.
+	preflopAggressor uint16
 
 	curBet           int64
 	lastPlayerAction ActionType
@@ -45,6 +62,9 @@ type Game struct {
 
 	noShowDown bool
 	ended      bool
+	// runoutFrom is the board size when betting closed and the rest of the
+	// board was dealt out; 5 when the hand reached the river.
+	runoutFrom int
 
 	potManager potManager
 
@@ -59,13 +79,18 @@ func NewGame(cfg Config) (*Game, error) {
 	if seed == 0 {
 		seed = time.Now().UnixNano()
 	}
+	src := newCountingSource(seed)
 	g := &Game{
-		cfg:            cfg,
-		rng:            rand.New(rand.NewSource(seed)),
-		playersByChair: make(map[uint16]*Player, cfg.MaxPlayers),
-		chairIDNodes:   make(map[uint16]*PlayerNode, cfg.MaxPlayers),
-		phase:          PhaseTypeAnte,
-		CurrentRaiser:  InvalidChair,
+		cfg:               cfg,
+		rng:               rand.New(src),
+		rngSrc:            src,
+		playersByChair:    make(map[uint16]*Player, cfg.MaxPlayers),
+		chairIDNodes:      make(map[uint16]*PlayerNode, cfg.MaxPlayers),
+		phase:             PhaseTypeAnte,
+		CurrentRaiser:     InvalidChair,
+		preflopAggressor:  InvalidChair,
+		pendingStraddle:   InvalidChair,
+		pendingReStraddle: InvalidChair,
 	}
 	g.potManager.resetPots()
 	return g, nil
@@ -85,6 +110,9 @@ func (g *Game) SitDown(chair uint16, playerID uint64, stack int64, robot bool) e
 	if g.playersByChair[chair] != nil {
 		return fmt.Errorf("chair %d already occupied", chair)
 	}
+	if err := g.checkChipsInPlayLocked(stack); err != nil {
+		return err
+	}
 	g.playersByChair[chair] = &Player{
 		ID:    playerID,
 		Chair: chair,
@@ -129,6 +157,85 @@ func (g *Game) StandUp(chair uint16) error {
 	return nil
 }
 
+// AddStack adds chips to a seated player's stack between hands.
+func (g *Game) AddStack(chair uint16, amount int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if amount <= 0 {
+		return fmt.Errorf("amount must be > 0")
+	}
+	p := g.playersByChair[chair]
+	if p == nil {
+		return fmt.Errorf("chair %d is empty", chair)
+	}
+	if g.round > 0 && !g.ended {
+		return ErrHandInProgress
+	}
+	if err := g.checkChipsInPlayLocked(amount); err != nil {
+		return err
+	}
+	return p.addStack(amount)
+}
+
+// checkChipsInPlayLocked returns ErrChipOverflow if adding amount would take
+// the chips at the table (stacks, bets and pots) past the int64 range. Every
+// stack, bet and pot is part of that total, so while it fits none of them
+// can overflow.
+func (g *Game) checkChipsInPlayLocked(amount int64) error {
+	total := amount
+	var err error
+	for _, p := range g.playersByChair {
+		if p == nil {
+			continue
+		}
+		if total, err = addChips(total, p.stack); err != nil {
+			return err
+		}
+		if total, err = addChips(total, p.bet); err != nil {
+			return err
+		}
+	}
+	for _, pot := range g.potManager.pots {
+		if total, err = addChips(total, pot.amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetBlinds changes the forced bets from the next StartHand on, e.g. for a
+// tournament blind level. It is refused while a hand is in progress.
+func (g *Game) SetBlinds(smallBlind, bigBlind, ante int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if bigBlind <= 0 || smallBlind < 0 || smallBlind > bigBlind || ante < 0 {
+		return fmt.Errorf("invalid blinds %d/%d ante %d", smallBlind, bigBlind, ante)
+	}
+	if g.round > 0 && !g.ended {
+		return ErrHandInProgress
+	}
+	g.cfg.SmallBlind = smallBlind
+	g.cfg.BigBlind = bigBlind
+	g.cfg.Ante = ante
+	return nil
+}
+
+// SetSittingOut marks a seated player as sitting out (or back in). It takes
+// effect at the next StartHand; a hand in progress is not changed.
+func (g *Game) SetSittingOut(chair uint16, out bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	p := g.playersByChair[chair]
+	if p == nil {
+		return fmt.Errorf("chair %d is empty", chair)
+	}
+	p.sittingOut = out
+	return nil
+}
+
 func (g *Game) Player(chair uint16) *Player {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -143,7 +250,10 @@ func (g *Game) StartHand() error {
 	g.ended = false
 	g.lastSettlement = nil
 	g.noShowDown = false
+	g.runoutFrom = 0
 	g.communityCards = nil
+	g.dealerDraw = nil
+	g.uncalled = UncalledBet{}
 
 	// Build active players list (stack > 0)
 	active := make([]*Player, 0, g.cfg.MaxPlayers)
@@ -154,7 +264,7 @@ func (g *Game) StartHand() error {
 		}
 		// Always clear per-hand state, including busted seats that stay at table.
 		p.ResetForNewHand()
-		if p.stack <= 0 {
+		if !p.dealtIn() {
 			continue
 		}
 		active = append(active, p)
@@ -173,14 +283,18 @@ func (g *Game) StartHand() error {
 	g.MinRaise = 0
 	g.NeedActionCount = 0
 	g.CurrentRaiser = InvalidChair
+	g.preflopAggressor = InvalidChair
 	g.lastPlayerAction = PlayerActionTypeNone
+	g.straddleNode = nil
+	straddleChair, reStraddleChair := g.pendingStraddle, g.pendingReStraddle
+	g.pendingStraddle, g.pendingReStraddle = InvalidChair, InvalidChair
 
 	// Rebuild ring list nodes in chair order
 	g.chairIDNodes = make(map[uint16]*PlayerNode, len(active))
 	var first, last *PlayerNode
 	for chair := uint16(0); chair < uint16(g.cfg.MaxPlayers); chair++ {
 		p := g.playersByChair[chair]
-		if p == nil || p.stack <= 0 {
+		if p == nil || !p.dealtIn() {
 			continue
 		}
 		node := &PlayerNode{ChairID: chair, Player: p}
@@ -213,7 +327,11 @@ func (g *Game) StartHand() error {
 
 	// Antes
 	g.phase = PhaseTypeAnte
-	if g.autoBetAntes() {
+	allIn, err := g.autoBetAntes()
+	if err != nil {
+		return err
+	}
+	if allIn {
 		if err := g.advanceToShowdownLocked(); err != nil {
 			return err
 		}
@@ -229,6 +347,9 @@ func (g *Game) StartHand() error {
 		_, err := g.endHandLocked()
 		return err
 	}
+	if g.postStraddleLocked(straddleChair, 2) {
+		g.postStraddleLocked(reStraddleChair, 4)
+	}
 
 	// Skip players with 0 stack (all-in)
 	g.curNode = g.curNode.WalkOnce(func(cur *PlayerNode) bool {
@@ -240,25 +361,56 @@ func (g *Game) StartHand() error {
 	return nil
 }
 
-// LegalActions is a pure projection of current state.
-func (g *Game) LegalActions(chair uint16) ([]ActionType, int64, error) {
+// LegalActions is a pure projection of current state. minRaiseTo and
+// maxRaiseTo bound the total bet a bet or raise may make; maxRaiseTo is the
+// player's all-in amount unless the pot limit is lower.
+func (g *Game) LegalActions(chair uint16) (acts []ActionType, minRaiseTo, maxRaiseTo int64, err error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
 	if g.ended {
-		return nil, 0, ErrHandEnded
+		return nil, 0, 0, ErrHandEnded
 	}
 	p := g.playersByChair[chair]
 	if p == nil {
-		return nil, 0, fmt.Errorf("player not found")
+		return nil, 0, 0, fmt.Errorf("player not found")
 	}
-	acts := g.calcNextValidActions(p)
-	minTotalRaiseTo := g.curBet + g.MinRaise
+	acts = g.calcNextValidActions(p)
+	minRaiseTo = g.curBet + g.MinRaise
 	if g.lastPlayerAction == PlayerActionTypeNone || g.lastPlayerAction == PlayerActionTypeCheck {
 		// min bet is big blind when no bet yet
-		minTotalRaiseTo = g.cfg.BigBlind
+		minRaiseTo = g.cfg.BigBlind
+	}
+	return acts, minRaiseTo, g.maxRaiseToLocked(p), nil
+}
+
+// maxRaiseToLocked is the largest total bet p may make this street: their
+// whole stack, capped under PotLimit by potLimitToLocked.
+func (g *Game) maxRaiseToLocked(p *Player) int64 {
+	available := p.stack + p.bet
+	if g.cfg.BettingStructure == PotLimit {
+		if limit := g.potLimitToLocked(p); limit < available {
+			return limit
+		}
 	}
-	return acts, minTotalRaiseTo, nil
+	return available
+}
+
+// potLimitToLocked is the pot-limit cap on p's total bet: the current bet
+// plus the pot (collected pots and every bet in front) after p calls.
+func (g *Game) potLimitToLocked(p *Player) int64 {
+	var pot int64
+	for _, pt := range g.potManager.pots {
+		pot += pt.amount
+	}
+	for _, other := range g.playersByChair {
+		pot += other.bet
+	}
+	toCall := g.curBet - p.bet
+	if toCall < 0 {
+		toCall = 0
+	}
+	return g.curBet + pot + toCall
 }
 
 // Act applies an action for the current player.
@@ -277,6 +429,7 @@ func (g *Game) Act(chair uint16, action ActionType, amount int64) (handEnd *Sett
 	if chair != g.curNode.ChairID {
 		return nil, ErrOutOfTurn
 	}
+	g.uncalled = UncalledBet{}
 
 	player := g.curNode.Player
 
@@ -301,6 +454,12 @@ func (g *Game) Act(chair uint16, action ActionType, amount int64) (handEnd *Sett
 		amount = player.bet
 	}
 
+	if g.cfg.BettingStructure == PotLimit && (action == PlayerActionTypeBet || action == PlayerActionTypeRaise) {
+		if limit := g.potLimitToLocked(player); amount > limit {
+			return nil, fmt.Errorf("%s to %d exceeds the pot limit of %d", PlayerActionTypeDictionary[action], amount, limit)
+		}
+	}
+
 	// Overbet => All-in
 	if amount-player.bet > player.stack {
 		amount = player.stack + player.bet
@@ -330,6 +489,10 @@ func (g *Game) Act(chair uint16, action ActionType, amount int64) (handEnd *Sett
 		if validRaise {
 			g.MinRaise = amount - g.curBet
 			g.CurrentRaiser = chair
+			if g.phase == PhaseTypePreflop {
+				g.preflopAggressor = chair
+			}
+			g.raiseLevel++
 		}
 		g.curBet = amount
 		g.setNeedActionCountLocked()
@@ -376,6 +539,7 @@ func (g *Game) Act(chair uint16, action ActionType, amount int64) (handEnd *Sett
 
 	if action != PlayerActionTypeFold {
 		g.lastPlayerAction = action
+		player.actedLevel = g.raiseLevel
 	}
 
 	g.NeedActionCount--
@@ -384,7 +548,9 @@ func (g *Game) Act(chair uint16, action ActionType, amount int64) (handEnd *Sett
 
 	if bettingEnd {
 		g.validActions = nil
-		g.collectBetsLocked()
+		if err := g.collectBetsLocked(); err != nil {
+			return nil, err
+		}
 
 		if g.checkDirectShowdownLocked() || g.phase == PhaseTypeRiver {
 			if err := g.advanceToShowdownLocked(); err != nil {
@@ -412,6 +578,7 @@ func (g *Game) onPhaseStartLocked() {
 	// Reset per-phase betting state
 	g.setNeedActionCountLocked()
 	g.CurrentRaiser = InvalidChair
+	g.raiseLevel++
 	for _, p := range g.playersByChair {
 		if p != nil {
 			p.setLastAction(PlayerActionTypeNone)
@@ -435,13 +602,12 @@ func (g *Game) onPhaseStartLocked() {
 
 func (g *Game) shuffle() {
 	if len(g.cfg.DeckOverride) > 0 {
+		g.handSeed = 0
 		g.stockCards.Init(g.cfg.DeckOverride)
 		return
 	}
-	cards := make([]card.Card, len(HoldemCards))
-	copy(cards, HoldemCards)
-	g.rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
-	g.stockCards.Init(cards)
+	g.handSeed = g.nextHandSeed()
+	g.stockCards.Init(ShuffledDeckOf(g.cfg.Deck, g.handSeed))
 }
 
 func (g *Game) selectDealer() error {
@@ -463,8 +629,12 @@ func (g *Game) selectDealer() error {
 		return fmt.Errorf("forced dealer chair %d is not active", *g.cfg.ForcedDealerChair)
 	}
 
-	// first hand: random dealer
+	// first hand: random dealer, or the high card of a draw
 	if g.round == 1 || g.dealerNode == nil {
+		if g.cfg.DealerSelection == DealerHighCard {
+			g.dealerNode = g.drawForDealer(nodes)
+			return nil
+		}
 		g.dealerNode = nodes[g.rng.Intn(len(nodes))]
 		return nil
 	}
@@ -485,6 +655,16 @@ func (g *Game) selectBlindsByDealer(dealer *PlayerNode) {
 	if dealer == nil {
 		return
 	}
+	if g.cfg.SmallBlind == 0 {
+		// Big-blind-only: the seat that would post the small blind posts the
+		// big blind instead and there is no small blind seat. Heads-up the
+		// button still acts first preflop.
+		g.dealerNode = dealer
+		g.smallBlindNode = nil
+		g.bigBlindNode = dealer.Next
+		g.curNode = g.bigBlindNode.Next
+		return
+	}
 	if g.activeCount == 2 {
 		// Heads-Up
 		g.dealerNode = dealer
@@ -500,11 +680,15 @@ func (g *Game) selectBlindsByDealer(dealer *PlayerNode) {
 }
 
 func (g *Game) dealHoleCards() {
-	if g.smallBlindNode == nil {
+	start := g.smallBlindNode
+	if start == nil && g.dealerNode != nil {
+		start = g.dealerNode.Next
+	}
+	if start == nil {
 		return
 	}
-	for i := 0; i < 2; i++ {
-		g.smallBlindNode.WalkAll(func(cur *PlayerNode) {
+	for i := 0; i < g.cfg.holeCards(); i++ {
+		start.WalkAll(func(cur *PlayerNode) {
 			cards, ok := g.stockCards.PopCards(1)
 			if !ok {
 				panic("deck underflow")
@@ -514,6 +698,24 @@ func (g *Game) dealHoleCards() {
 	}
 }
 
+// PeekRemainingBoard returns the community cards still to come, in the order
+// they would be dealt, without taking them from the stock. It is empty once
+// the board is complete. After a fold win this is the rabbit hunt; the next
+// hand reshuffles, so peeking never changes what it deals.
+func (g *Game) PeekRemainingBoard() []card.Card {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	n := 5 - len(g.communityCards)
+	if n <= 0 {
+		return nil
+	}
+	if n > len(g.stockCards) {
+		n = len(g.stockCards)
+	}
+	return append([]card.Card{}, g.stockCards[:n]...)
+}
+
 func (g *Game) dealCommunityCardsLocked() {
 	shouldDeal := 0
 	switch g.phase {
@@ -532,13 +734,16 @@ func (g *Game) dealCommunityCardsLocked() {
 	}
 }
 
-func (g *Game) autoBetAntes() bool {
+func (g *Game) autoBetAntes() (bool, error) {
 	if g.cfg.Ante == 0 {
-		return false
+		return false, nil
+	}
+	if g.cfg.AnteMode == AnteBigBlind {
+		return false, g.postBigBlindAnteLocked()
 	}
 	notAllIn := 0
 	for _, p := range g.playersByChair {
-		if p == nil || p.stack <= 0 {
+		if p == nil || !p.dealtIn() {
 			continue
 		}
 		p.placeBet(g.cfg.Ante)
@@ -547,8 +752,37 @@ func (g *Game) autoBetAntes() bool {
 		}
 	}
 	g.allinCount = g.activeCount - notAllIn
-	g.collectBetsLocked()
-	return notAllIn <= 1
+	if err := g.collectBetsLocked(); err != nil {
+		return false, err
+	}
+	return notAllIn <= 1, nil
+}
+
+// postBigBlindAnteLocked takes the big blind ante straight into a dead pot
+// every dealt-in player can win. It is not a bet, so it is never refunded as
+// uncalled and does not change what anyone has to call. Only the stack above
+// one big blind is used, so the blind itself is always posted first.
+func (g *Game) postBigBlindAnteLocked() error {
+	if g.bigBlindNode == nil {
+		return nil
+	}
+	bb := g.bigBlindNode.Player
+	ante := g.cfg.Ante
+	if spare := bb.stack - g.cfg.BigBlind; ante > spare {
+		ante = spare
+	}
+	if ante <= 0 {
+		return nil
+	}
+	if err := bb.addStack(-ante); err != nil {
+		return err
+	}
+	eligible := make(map[uint16]bool, len(g.chairIDNodes))
+	for chair := range g.chairIDNodes {
+		eligible[chair] = true
+	}
+	g.potManager.addPot(pot{amount: ante, eligiblePlayers: eligible, formedPhase: PhaseTypeAnte})
+	return nil
 }
 
 func (g *Game) autoBetBlinds() bool {
@@ -575,7 +809,7 @@ func (g *Game) autoBetBlinds() bool {
 	return false
 }
 
-func (g *Game) collectBetsLocked() {
+func (g *Game) collectBetsLocked() error {
 	playersWithBets := make([]*Player, 0, g.activeCount)
 	for chair := uint16(0); chair < uint16(g.cfg.MaxPlayers); chair++ {
 		p := g.playersByChair[chair]
@@ -586,11 +820,17 @@ func (g *Game) collectBetsLocked() {
 			playersWithBets = append(playersWithBets, p)
 		}
 	}
-	g.potManager.calcPotsByPlayerBets(playersWithBets)
+	if err := g.potManager.calcPotsByPlayerBets(playersWithBets, g.phase); err != nil {
+		return err
+	}
+	if g.potManager.excessAmount > 0 {
+		g.uncalled = UncalledBet{Chair: g.potManager.excessChair, Amount: g.potManager.excessAmount}
+	}
 	for _, p := range playersWithBets {
 		p.resetBet()
 	}
 	g.curBet = 0
+	return nil
 }
 
 func (g *Game) setNeedActionCountLocked() {
@@ -620,18 +860,27 @@ func (g *Game) calcNextValidActions(nextPlayer *Player) []ActionType {
 		}
 
 		canRaise := available > g.curBet+g.MinRaise
-		isReopen := g.CurrentRaiser != nextPlayer.ChairID()
+		// Only a full raise since this player last acted reopens betting for them.
+		isReopen := nextPlayer.actedLevel != g.raiseLevel
 		if canRaise && isReopen && g.activeCount-g.allinCount > 1 {
 			nextValid = append(nextValid, PlayerActionTypeRaise)
 		}
 
 		// remove all-in option if action is locked
-		if (canCall && g.activeCount-g.allinCount <= 1) || (canRaise && !isReopen) {
+		if (canCall && g.activeCount-g.allinCount <= 1) || (!isReopen && available > g.curBet) {
 			if len(nextValid) > 0 {
 				nextValid = nextValid[1:]
 			}
 		}
 	}
+	// A shove bigger than the pot is not allowed under the pot limit; calling
+	// all-in for less stays legal.
+	if g.cfg.BettingStructure == PotLimit && len(nextValid) > 0 && nextValid[0] == PlayerActionTypeAllin {
+		available := nextPlayer.stack + nextPlayer.bet
+		if available > g.curBet && available > g.potLimitToLocked(nextPlayer) {
+			nextValid = nextValid[1:]
+		}
+	}
 	return nextValid
 }
 
@@ -644,7 +893,8 @@ func (g *Game) calcNextActionPosAndBettingEndLocked() (*PlayerNode, bool) {
 		var first *PlayerNode
 		// Heads-Up 特殊规则只取决于“开局人数”，不能用 activeCount（有人弃牌后会变 2）
 		// 对齐原始实现：len(chairIDNodes)==2 才算 Heads-Up
-		if len(g.chairIDNodes) == 2 {
+		// Without a small blind seat the big blind is first left of the button.
+		if len(g.chairIDNodes) == 2 || g.smallBlindNode == nil {
 			first = g.bigBlindNode
 		} else {
 			first = g.smallBlindNode
@@ -672,6 +922,7 @@ func (g *Game) checkDirectShowdownLocked() bool {
 }
 
 func (g *Game) advanceToShowdownLocked() error {
+	g.runoutFrom = len(g.communityCards)
 	g.phase = PhaseTypeShowdown
 	g.dealCommunityCardsLocked()
 	return nil
@@ -18,6 +18,9 @@ type GameView struct {
 	MinRaise     int64
 	ActiveCount  int
 	Street       int // 0=preflop, 1=flop, 2=turn, 3=river
+
+	PreflopAggressor bool // we made the last full raise preflop
+	FacingReraise    bool // we bet or raised this street and were raised again
 }
 
 // Decision is what a BrainDecider returns.
@@ -33,3 +36,10 @@ type BrainDecider interface {
 	// Name returns a human-readable identifier for debugging.
 	Name() string
 }
+
+// Preparer is implemented by brains with a warm-up cost, such as sizing
+// sample buffers for an equity estimate. The manager calls Prepare once when
+// the NPC is seated so the first in-hand Decide stays off the slow path.
+type Preparer interface {
+	Prepare()
+}
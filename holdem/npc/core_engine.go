@@ -6,6 +6,10 @@ import (
 	"holdem-lite/holdem"
 )
 
+// premiumStrength is the estimateHandStrength level at which reraises are
+// never folded.
+const premiumStrength = 0.9
+
 // DeterministicCorePolicyEngine is the unified decision executor used by RuleBrain.
 type DeterministicCorePolicyEngine struct{}
 
@@ -46,6 +50,21 @@ func (e *DeterministicCorePolicyEngine) Decide(view GameView, runtime PolicyRunt
 		}
 	}
 
+	// Our raise got reraised: non-premium hands give up at the persona's rate.
+	if view.FacingReraise && canFold && strength < premiumStrength {
+		if randFloat(rng) < profile.FoldTo3Bet() {
+			return Decision{Action: holdem.PlayerActionTypeFold}
+		}
+	}
+
+	// Flop continuation bet as the preflop aggressor.
+	if view.Street == 1 && view.PreflopAggressor && canBet {
+		if randFloat(rng) < profile.ContBet() {
+			betAmount := calcBetAmount(view, 0.3+aggression*0.3, runtime.Plan)
+			return Decision{Action: holdem.PlayerActionTypeBet, Amount: betAmount}
+		}
+	}
+
 	aggressivePlay := strength > (1.0-aggression)*0.5
 
 	if aggressivePlay {
@@ -85,8 +104,8 @@ func (e *DeterministicCorePolicyEngine) Decide(view GameView, runtime PolicyRunt
 	}
 
 	if canCall {
-		callThreshold := tightness * 0.4
-		if strength > callThreshold || randFloat(rng) < (1.0-tightness)*0.5 {
+		// Call whenever the hand is worth the price.
+		if strength >= requiredEquity(view) {
 			return Decision{Action: holdem.PlayerActionTypeCall, Amount: view.CurrentBet}
 		}
 		if canFold {
@@ -145,6 +164,20 @@ func estimateHandStrength(view GameView, rng *rand.Rand) float64 {
 	return clamp01(strength)
 }
 
+// requiredEquity is the share of the final pot a call must win to break even:
+// callAmount/(pot+callAmount). view.Pot already includes the bets in front of
+// the players, and the call is capped by what is left in the stack.
+func requiredEquity(view GameView) float64 {
+	callAmount := view.CurrentBet - view.MyBet
+	if callAmount > view.MyStack {
+		callAmount = view.MyStack
+	}
+	if callAmount <= 0 {
+		return 0
+	}
+	return float64(callAmount) / float64(view.Pot+callAmount)
+}
+
 func calcBetAmount(view GameView, aggression float64, plan PolicyPlan) int64 {
 	fraction := 0.33 + aggression*0.67
 	if len(plan.BetSizeFractions) >= 2 {
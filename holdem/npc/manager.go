@@ -19,10 +19,18 @@ type NPCInstance struct {
 	ThinkDelay time.Duration
 }
 
+// DefaultBrainType is the brain used for personas that do not name one.
+const DefaultBrainType = "rule"
+
+// BrainFactory builds the brain for one seated NPC from its persona and a
+// per-instance random seed.
+type BrainFactory func(persona *NPCPersona, seed int64) BrainDecider
+
 // Manager manages NPC lifecycle and decision-making at tables.
 type Manager struct {
 	registry   *PersonaRegistry
 	instances  map[uint64]*NPCInstance // keyed by PlayerID
+	brains     map[string]BrainFactory
 	coreEngine CorePolicyEngine
 	ruleSource RuleProvider
 	guard      PolicyGuard
@@ -33,15 +41,29 @@ type Manager struct {
 
 // NewManager creates an NPC manager with the given persona registry.
 func NewManager(registry *PersonaRegistry) *Manager {
-	return &Manager{
+	m := &Manager{
 		registry:   registry,
 		instances:  make(map[uint64]*NPCInstance),
+		brains:     make(map[string]BrainFactory),
 		coreEngine: NewDeterministicCorePolicyEngine(),
 		ruleSource: NewDefaultRuleProvider(),
 		guard:      NewDefaultPolicyGuard(),
 		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
 		nextID:     9_000_000, // NPC IDs start from 9M to avoid collision with real users
 	}
+	m.brains[DefaultBrainType] = func(persona *NPCPersona, seed int64) BrainDecider {
+		return NewRuleBrainWithDeps(persona, seed, m.ruleSource, m.coreEngine, m.guard)
+	}
+	return m
+}
+
+// RegisterBrain makes factory available to personas whose brain_type is
+// name. Registering an existing name, including "rule", replaces it; NPCs
+// already seated keep their brain.
+func (m *Manager) RegisterBrain(name string, factory func(*NPCPersona, int64) BrainDecider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.brains[name] = factory
 }
 
 // Registry returns the underlying PersonaRegistry.
@@ -60,35 +82,74 @@ func (m *Manager) SpawnNPC(
 	m.mu.Lock()
 	m.nextID++
 	playerID := m.nextID
-	seed := m.rng.Int63()
 	m.mu.Unlock()
 
-	brain := NewRuleBrainWithDeps(persona, seed, m.ruleSource, m.coreEngine, m.guard)
+	inst, err := m.newInstance(playerID, chair, persona)
+	if err != nil {
+		return nil, fmt.Errorf("spawn NPC %s: %w", persona.Name, err)
+	}
+	if err := game.SitDown(chair, playerID, stack, true); err != nil {
+		return nil, fmt.Errorf("spawn NPC %s at chair %d: %w", persona.Name, chair, err)
+	}
+
+	m.mu.Lock()
+	m.instances[playerID] = inst
+	m.mu.Unlock()
+
+	log.Printf("[NPC] Spawned %s (ID=%d) at chair %d", persona.Name, playerID, chair)
+	return inst, nil
+}
+
+// AdoptNPC registers an NPC that is already seated in a game, e.g. one
+// restored from a saved table state. Later spawns never reuse its ID.
+func (m *Manager) AdoptNPC(playerID uint64, chair uint16, persona *NPCPersona) (*NPCInstance, error) {
+	inst, err := m.newInstance(playerID, chair, persona)
+	if err != nil {
+		return nil, fmt.Errorf("adopt NPC %s: %w", persona.Name, err)
+	}
+
+	m.mu.Lock()
+	m.instances[playerID] = inst
+	if playerID > m.nextID {
+		m.nextID = playerID
+	}
+	m.mu.Unlock()
+
+	log.Printf("[NPC] Adopted %s (ID=%d) at chair %d", persona.Name, playerID, chair)
+	return inst, nil
+}
+
+func (m *Manager) newInstance(playerID uint64, chair uint16, persona *NPCPersona) (*NPCInstance, error) {
+	brainType := persona.BrainType
+	if brainType == "" {
+		brainType = DefaultBrainType
+	}
 
+	m.mu.Lock()
+	factory := m.brains[brainType]
+	if factory == nil {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("unknown brain type %q", brainType)
+	}
+	seed := m.rng.Int63()
 	// Think delay: 2–5 seconds base, plus random jitter.
 	// This makes NPC pacing feel natural, especially in multi-NPC sequences.
 	baseMs := 2000 + int(persona.Brain.Randomness*3000)
 	jitterMs := m.rng.Intn(2000)
-	thinkDelay := time.Duration(baseMs+jitterMs) * time.Millisecond
+	m.mu.Unlock()
 
-	if err := game.SitDown(chair, playerID, stack, true); err != nil {
-		return nil, fmt.Errorf("spawn NPC %s at chair %d: %w", persona.Name, chair, err)
+	brain := factory(persona, seed)
+	if p, ok := brain.(Preparer); ok {
+		p.Prepare()
 	}
 
-	inst := &NPCInstance{
+	return &NPCInstance{
 		PlayerID:   playerID,
 		Chair:      chair,
 		Persona:    persona,
 		Brain:      brain,
-		ThinkDelay: thinkDelay,
-	}
-
-	m.mu.Lock()
-	m.instances[playerID] = inst
-	m.mu.Unlock()
-
-	log.Printf("[NPC] Spawned %s (ID=%d) at chair %d", persona.Name, playerID, chair)
-	return inst, nil
+		ThinkDelay: time.Duration(baseMs+jitterMs) * time.Millisecond,
+	}, nil
 }
 
 // OnTurn is called when it's an NPC's turn to act.
@@ -170,9 +231,11 @@ func buildGameView(inst *NPCInstance, snap holdem.Snapshot) GameView {
 			view.HoleCards = ps.HandCards
 			view.MyBet = ps.Bet
 			view.MyStack = ps.Stack
+			view.FacingReraise = isAggressiveAction(ps.LastAction) && snap.CurBet > ps.Bet
 			break
 		}
 	}
+	view.PreflopAggressor = snap.PreflopAggressor == inst.Chair
 
 	// Count active players
 	for _, ps := range snap.Players {
@@ -199,3 +262,12 @@ func buildGameView(inst *NPCInstance, snap holdem.Snapshot) GameView {
 
 	return view
 }
+
+// isAggressiveAction reports whether a street action put in a bet or raise.
+func isAggressiveAction(action holdem.ActionType) bool {
+	switch action {
+	case holdem.PlayerActionTypeBet, holdem.PlayerActionTypeRaise:
+		return true
+	}
+	return false
+}
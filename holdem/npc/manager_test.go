@@ -0,0 +1,133 @@
+package npc
+
+import (
+	"runtime"
+	"testing"
+
+	"holdem-lite/holdem"
+)
+
+type stubBrain struct {
+	persona *NPCPersona
+	seed    int64
+}
+
+func (b *stubBrain) Decide(GameView) Decision { return Decision{Action: holdem.PlayerActionTypeCheck} }
+func (b *stubBrain) Name() string             { return "stub" }
+
+func TestManagerRegisterBrainSelectsPersonaBrainType(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.LoadFromJSON([]byte(`[
+		{"id": "stubby", "name": "Stubby", "brain_type": "stub"},
+		{"id": "plain", "name": "Plain"},
+		{"id": "lost", "name": "Lost", "brain_type": "missing"}
+	]`)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	m := NewManager(registry)
+	m.RegisterBrain("stub", func(persona *NPCPersona, seed int64) BrainDecider {
+		return &stubBrain{persona: persona, seed: seed}
+	})
+
+	game, err := holdem.NewGame(holdem.Config{MaxPlayers: 6, MinPlayers: 2, SmallBlind: 50, BigBlind: 100, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+
+	inst, err := m.SpawnNPC(game, 0, registry.Get("stubby"), 5000)
+	if err != nil {
+		t.Fatalf("SpawnNPC stub err: %v", err)
+	}
+	stub, ok := inst.Brain.(*stubBrain)
+	if !ok || stub.persona != registry.Get("stubby") {
+		t.Fatalf("expected the stub brain built for its persona, got %T", inst.Brain)
+	}
+
+	inst, err = m.SpawnNPC(game, 1, registry.Get("plain"), 5000)
+	if err != nil {
+		t.Fatalf("SpawnNPC default err: %v", err)
+	}
+	if _, ok := inst.Brain.(*RuleBrain); !ok {
+		t.Fatalf("expected a persona without brain_type to get the rule brain, got %T", inst.Brain)
+	}
+
+	if _, err := m.SpawnNPC(game, 2, registry.Get("lost"), 5000); err == nil {
+		t.Fatal("expected an unknown brain_type to fail the spawn")
+	}
+	if snap := game.Snapshot(); len(snap.Players) != 2 {
+		t.Fatalf("expected the failed spawn to leave the seat empty, got %d players", len(snap.Players))
+	}
+	if _, err := m.AdoptNPC(9_500_000, 3, registry.Get("lost")); err == nil {
+		t.Fatal("expected adopting an NPC with an unknown brain_type to fail")
+	}
+}
+
+// equityStubBrain stands in for an equity brain: Decide scores a fixed number
+// of samples into buffers that are sized lazily unless Prepare ran first.
+type equityStubBrain struct {
+	scores   []uint32
+	prepared int
+}
+
+const equityStubSamples = 1024
+
+func (b *equityStubBrain) Prepare() {
+	b.prepared++
+	b.scores = make([]uint32, equityStubSamples)
+}
+
+func (b *equityStubBrain) Decide(view GameView) Decision {
+	if b.scores == nil {
+		b.scores = make([]uint32, equityStubSamples)
+	}
+	var wins int
+	for i := range b.scores {
+		b.scores[i] = uint32(i) * uint32(len(view.Community)+1)
+		if b.scores[i]%2 == 0 {
+			wins++
+		}
+	}
+	if wins*2 >= len(b.scores) {
+		return Decision{Action: holdem.PlayerActionTypeCall}
+	}
+	return Decision{Action: holdem.PlayerActionTypeFold}
+}
+
+func (b *equityStubBrain) Name() string { return "equity-stub" }
+
+func TestManagerPreparesBrainOnSpawn(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.LoadFromJSON([]byte(`[{"id": "eq", "name": "Eq", "brain_type": "equity"}]`)); err != nil {
+		t.Fatalf("LoadFromJSON err: %v", err)
+	}
+	m := NewManager(registry)
+	m.RegisterBrain("equity", func(*NPCPersona, int64) BrainDecider { return &equityStubBrain{} })
+
+	game, err := holdem.NewGame(holdem.Config{MaxPlayers: 6, MinPlayers: 2, SmallBlind: 50, BigBlind: 100, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	inst, err := m.SpawnNPC(game, 0, registry.Get("eq"), 5000)
+	if err != nil {
+		t.Fatalf("SpawnNPC err: %v", err)
+	}
+	brain := inst.Brain.(*equityStubBrain)
+	if brain.prepared != 1 {
+		t.Fatalf("expected Prepare to run once on spawn, ran %d times", brain.prepared)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	brain.Decide(GameView{})
+	runtime.ReadMemStats(&after)
+	if allocs := after.Mallocs - before.Mallocs; allocs != 0 {
+		t.Fatalf("expected the first Decide of a prepared brain not to allocate, got %d allocations", allocs)
+	}
+
+	if _, err := m.AdoptNPC(9_500_000, 1, registry.Get("eq")); err != nil {
+		t.Fatalf("AdoptNPC err: %v", err)
+	}
+	if adopted := m.GetInstance(9_500_000).Brain.(*equityStubBrain); adopted.prepared != 1 {
+		t.Fatalf("expected an adopted NPC's brain to be prepared too, ran %d times", adopted.prepared)
+	}
+}
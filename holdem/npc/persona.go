@@ -7,6 +7,27 @@ type PersonalityProfile struct {
 	Bluffing   float64 `json:"bluffing"`   // 0.0–1.0: bluff frequency
 	Positional float64 `json:"positional"` // 0.0–1.0: how much position affects play
 	Randomness float64 `json:"randomness"` // 0.0–1.0: decision noise
+
+	// Optional overrides; nil derives the value from the params above so
+	// existing persona files keep their behavior.
+	ContBetFreq    *float64 `json:"contBetFreq,omitempty"`    // 0.0–1.0: flop c-bet rate as preflop aggressor
+	FoldTo3BetFreq *float64 `json:"foldTo3BetFreq,omitempty"` // 0.0–1.0: fold rate when a raise gets reraised
+}
+
+// ContBet returns the flop continuation-bet frequency.
+func (p PersonalityProfile) ContBet() float64 {
+	if p.ContBetFreq != nil {
+		return clamp01(*p.ContBetFreq)
+	}
+	return clampRange(0.35+clamp01(p.Aggression)*0.4+clamp01(p.Bluffing)*0.1, 0, 0.9)
+}
+
+// FoldTo3Bet returns how often a non-premium hand folds to a reraise.
+func (p PersonalityProfile) FoldTo3Bet() float64 {
+	if p.FoldTo3BetFreq != nil {
+		return clamp01(*p.FoldTo3BetFreq)
+	}
+	return clampRange(0.3+clamp01(p.Tightness)*0.4-clamp01(p.Aggression)*0.2, 0.05, 0.85)
 }
 
 // NPCPersona defines a named NPC character.
@@ -20,4 +41,7 @@ type NPCPersona struct {
 	Brain     PersonalityProfile `json:"brain"`
 	ReiIntro  string             `json:"reiIntro"`
 	ReiStyle  string             `json:"reiStyle"`
+	// BrainType names the brain factory registered on the Manager; empty
+	// means DefaultBrainType.
+	BrainType string `json:"brain_type,omitempty"`
 }
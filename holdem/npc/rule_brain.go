@@ -86,6 +86,9 @@ func (b *RuleBrain) Name() string {
 	return b.Persona.Name
 }
 
+// Prepare implements Preparer. A RuleBrain is ready as soon as it is built.
+func (b *RuleBrain) Prepare() {}
+
 // Decide implements BrainDecider.
 func (b *RuleBrain) Decide(view GameView) Decision {
 	if b.provider != nil {
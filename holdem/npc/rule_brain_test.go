@@ -93,3 +93,121 @@ func TestRuleBrainLAGPreflopRaiseRateModerated(t *testing.T) {
 		t.Fatalf("LAG profile still too raise-heavy: raises=%d calls=%d", raises, calls)
 	}
 }
+
+func freq(v float64) *float64 { return &v }
+
+func actionRate(brain *RuleBrain, view GameView, action holdem.ActionType, rounds int) float64 {
+	hits := 0
+	for i := 0; i < rounds; i++ {
+		if brain.Decide(view).Action == action {
+			hits++
+		}
+	}
+	return float64(hits) / float64(rounds)
+}
+
+func TestRuleBrainContBetFreqRaisesFlopBetsAsAggressor(t *testing.T) {
+	base := PersonalityProfile{Aggression: 0.40, Tightness: 0.45, Bluffing: 0.20, Positional: 0.40}
+	low, high := base, base
+	low.ContBetFreq = freq(0.10)
+	high.ContBetFreq = freq(0.90)
+
+	view := GameView{
+		Street:           1,
+		HoleCards:        []card.Card{card.CardSpade7, card.CardHeart2},
+		Community:        []card.Card{card.CardClubK, card.CardDiamond9, card.CardSpade4},
+		Pot:              650,
+		MyStack:          19700,
+		MinRaise:         100,
+		PreflopAggressor: true,
+		LegalActions:     []holdem.ActionType{holdem.PlayerActionTypeCheck, holdem.PlayerActionTypeBet},
+	}
+
+	const rounds = 4000
+	lowRate := actionRate(NewRuleBrain(&NPCPersona{ID: "cbet_low", Brain: low}, 7), view, holdem.PlayerActionTypeBet, rounds)
+	highRate := actionRate(NewRuleBrain(&NPCPersona{ID: "cbet_high", Brain: high}, 7), view, holdem.PlayerActionTypeBet, rounds)
+	if highRate < lowRate+0.4 {
+		t.Fatalf("high c-bet persona should bet the flop far more: low=%.3f high=%.3f", lowRate, highRate)
+	}
+
+	view.PreflopAggressor = false
+	notAggressor := actionRate(NewRuleBrain(&NPCPersona{ID: "cbet_high", Brain: high}, 7), view, holdem.PlayerActionTypeBet, rounds)
+	if notAggressor > highRate-0.4 {
+		t.Fatalf("c-bet frequency should only apply as preflop aggressor: aggressor=%.3f caller=%.3f", highRate, notAggressor)
+	}
+}
+
+func TestRuleBrainFoldTo3BetFreqFoldsMoreToReraises(t *testing.T) {
+	base := PersonalityProfile{Aggression: 0.50, Tightness: 0.30, Bluffing: 0.20, Positional: 0.40}
+	low, high := base, base
+	low.FoldTo3BetFreq = freq(0.05)
+	high.FoldTo3BetFreq = freq(0.85)
+
+	view := GameView{
+		Street:        0,
+		HoleCards:     []card.Card{card.CardSpadeT, card.CardHeart9},
+		Pot:           1350,
+		CurrentBet:    900,
+		MyBet:         300,
+		MyStack:       19700,
+		MinRaise:      600,
+		FacingReraise: true,
+		LegalActions:  []holdem.ActionType{holdem.PlayerActionTypeFold, holdem.PlayerActionTypeCall, holdem.PlayerActionTypeRaise},
+	}
+
+	const rounds = 4000
+	lowRate := actionRate(NewRuleBrain(&NPCPersona{ID: "f3b_low", Brain: low}, 11), view, holdem.PlayerActionTypeFold, rounds)
+	highRate := actionRate(NewRuleBrain(&NPCPersona{ID: "f3b_high", Brain: high}, 11), view, holdem.PlayerActionTypeFold, rounds)
+	if highRate < lowRate+0.5 {
+		t.Fatalf("high fold-to-3bet persona should fold reraises far more: low=%.3f high=%.3f", lowRate, highRate)
+	}
+
+	// Premium hands never fold to the reraise.
+	view.HoleCards = []card.Card{card.CardSpadeK, card.CardHeartK}
+	if rate := actionRate(NewRuleBrain(&NPCPersona{ID: "f3b_high", Brain: high}, 11), view, holdem.PlayerActionTypeFold, rounds); rate != 0 {
+		t.Fatalf("premium hand folded to a reraise at rate %.3f", rate)
+	}
+}
+
+func TestPersonalityProfileDerivesDefaults(t *testing.T) {
+	tag := PersonalityProfile{Aggression: 0.8, Tightness: 0.7}
+	nit := PersonalityProfile{Aggression: 0.2, Tightness: 0.9}
+	if tag.ContBet() <= nit.ContBet() {
+		t.Fatalf("aggressive profile should c-bet more: tag=%.3f nit=%.3f", tag.ContBet(), nit.ContBet())
+	}
+	if nit.FoldTo3Bet() <= tag.FoldTo3Bet() {
+		t.Fatalf("tight passive profile should fold to 3-bets more: nit=%.3f tag=%.3f", nit.FoldTo3Bet(), tag.FoldTo3Bet())
+	}
+	tag.ContBetFreq = freq(0.25)
+	if got := tag.ContBet(); got != 0.25 {
+		t.Fatalf("explicit ContBetFreq ignored: got %.3f", got)
+	}
+}
+
+func TestRuleBrainCallsOnPotOdds(t *testing.T) {
+	persona := &NPCPersona{
+		ID:    "tight_odds",
+		Brain: PersonalityProfile{Aggression: 0.10, Tightness: 0.80, Bluffing: 0.0, Positional: 0.40},
+	}
+
+	view := GameView{
+		Street:       2,
+		HoleCards:    []card.Card{card.CardSpade8, card.CardHeart2},
+		Community:    []card.Card{card.CardClubK, card.CardDiamond6, card.CardSpade2, card.CardHeart4},
+		Pot:          5300,
+		CurrentBet:   5000,
+		MyStack:      19700,
+		MinRaise:     5000,
+		LegalActions: []holdem.ActionType{holdem.PlayerActionTypeFold, holdem.PlayerActionTypeCall},
+	}
+
+	const rounds = 2000
+	if rate := actionRate(NewRuleBrain(persona, 5), view, holdem.PlayerActionTypeFold, rounds); rate != 1 {
+		t.Fatalf("tight persona should fold a marginal hand to an overbet: fold rate %.3f", rate)
+	}
+
+	view.Pot, view.CurrentBet, view.MinRaise = 1120, 120, 120
+	if rate := actionRate(NewRuleBrain(persona, 5), view, holdem.PlayerActionTypeCall, rounds); rate != 1 {
+		t.Fatalf("tight persona should call a tiny bet with the same hand: call rate %.3f", rate)
+	}
+}
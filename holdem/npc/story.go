@@ -16,7 +16,7 @@ type ChapterConfig struct {
 
 // ChapterObjective defines the win condition for a chapter.
 type ChapterObjective struct {
-	Type   string `json:"type"`   // "win_bb", "survive", "win_pots", "eliminate", "most_chips"
+	Type   string `json:"type"`   // "win_bb", "survive", "win_pots", "eliminate", "most_chips", "double_up"
 	Target int    `json:"target"` // e.g. 10 BB, 30 hands, 3 pots, etc.
 	Desc   string `json:"desc"`   // human-readable description
 }
@@ -48,7 +48,9 @@ func (s *ChapterSession) IsChapterComplete(obj ChapterObjective, bigBlind int64)
 		gained := s.CurrentStack - s.StartStack
 		return gained >= int64(obj.Target)*bigBlind
 	case "survive":
-		return s.HandsPlayed >= obj.Target && s.CurrentStack >= s.StartStack
+		return s.HandsPlayed >= obj.Target && s.CurrentStack > 0
+	case "double_up":
+		return s.CurrentStack >= 2*s.StartStack
 	case "win_pots":
 		return s.PotWins >= obj.Target
 	case "eliminate":
@@ -61,3 +63,28 @@ func (s *ChapterSession) IsChapterComplete(obj ChapterObjective, bigBlind int64)
 		return false
 	}
 }
+
+// Progress reports how far the session is toward the objective, in the
+// objective's own unit (big blinds won, hands, pots or chips), capped at
+// target. Objectives checked externally report 0 or 1 of 1.
+func (s *ChapterSession) Progress(obj ChapterObjective, bigBlind int64) (current, target int64) {
+	switch obj.Type {
+	case "win_bb":
+		current, target = 0, int64(obj.Target)
+		if bigBlind > 0 {
+			current = (s.CurrentStack - s.StartStack) / bigBlind
+		}
+	case "survive", "most_chips":
+		current, target = int64(s.HandsPlayed), int64(obj.Target)
+	case "win_pots":
+		current, target = int64(s.PotWins), int64(obj.Target)
+	case "double_up":
+		current, target = s.CurrentStack, 2*s.StartStack
+	default:
+		target = 1
+		if s.IsChapterComplete(obj, bigBlind) {
+			current = 1
+		}
+	}
+	return min(max(current, 0), target), target
+}
@@ -0,0 +1,52 @@
+package npc
+
+import "testing"
+
+func TestChapterSession_Survive(t *testing.T) {
+	obj := ChapterObjective{Type: "survive", Target: 30}
+	cases := []struct {
+		name   string
+		hands  int
+		stack  int64
+		want   bool
+		wantAt int64
+	}{
+		{"short of target", 29, 5000, false, 29},
+		{"down but alive", 30, 1, true, 30},
+		{"busted", 30, 0, false, 30},
+		{"past target", 45, 20000, true, 30},
+	}
+	for _, tc := range cases {
+		s := &ChapterSession{HandsPlayed: tc.hands, StartStack: 10000, CurrentStack: tc.stack}
+		if got := s.IsChapterComplete(obj, 100); got != tc.want {
+			t.Fatalf("%s: expected complete=%v, got %v", tc.name, tc.want, got)
+		}
+		if current, target := s.Progress(obj, 100); current != tc.wantAt || target != 30 {
+			t.Fatalf("%s: expected progress %d/30, got %d/%d", tc.name, tc.wantAt, current, target)
+		}
+	}
+}
+
+func TestChapterSession_DoubleUp(t *testing.T) {
+	obj := ChapterObjective{Type: "double_up"}
+	cases := []struct {
+		name   string
+		stack  int64
+		want   bool
+		wantAt int64
+	}{
+		{"lost chips", 4000, false, 4000},
+		{"one chip short", 19999, false, 19999},
+		{"doubled", 20000, true, 20000},
+		{"more than doubled", 35000, true, 20000},
+	}
+	for _, tc := range cases {
+		s := &ChapterSession{HandsPlayed: 3, StartStack: 10000, CurrentStack: tc.stack}
+		if got := s.IsChapterComplete(obj, 100); got != tc.want {
+			t.Fatalf("%s: expected complete=%v, got %v", tc.name, tc.want, got)
+		}
+		if current, target := s.Progress(obj, 100); current != tc.wantAt || target != 20000 {
+			t.Fatalf("%s: expected progress %d/20000, got %d/%d", tc.name, tc.wantAt, current, target)
+		}
+	}
+}
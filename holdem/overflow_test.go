@@ -0,0 +1,111 @@
+package holdem
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func headsUpOverflowGame(t *testing.T) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        2,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	return g
+}
+
+func TestAddChips_DetectsOverflow(t *testing.T) {
+	if _, err := addChips(math.MaxInt64, 1); !errors.Is(err, ErrChipOverflow) {
+		t.Fatalf("expected ErrChipOverflow past MaxInt64, got %v", err)
+	}
+	if _, err := addChips(math.MinInt64, -1); !errors.Is(err, ErrChipOverflow) {
+		t.Fatalf("expected ErrChipOverflow past MinInt64, got %v", err)
+	}
+	if got, err := addChips(math.MaxInt64-1, 1); err != nil || got != math.MaxInt64 {
+		t.Fatalf("expected MaxInt64, got %d err=%v", got, err)
+	}
+}
+
+func TestChipsInPlay_RejectsStacksThatCouldOverflow(t *testing.T) {
+	g := headsUpOverflowGame(t)
+	if err := g.SitDown(0, 1, math.MaxInt64-1000, false); err != nil {
+		t.Fatalf("SitDown chair=0 err: %v", err)
+	}
+	if err := g.SitDown(1, 2, 1001, false); !errors.Is(err, ErrChipOverflow) {
+		t.Fatalf("expected ErrChipOverflow seating past the table limit, got %v", err)
+	}
+	if err := g.SitDown(1, 2, 1000, false); err != nil {
+		t.Fatalf("SitDown chair=1 err: %v", err)
+	}
+	if err := g.AddStack(1, 1); !errors.Is(err, ErrChipOverflow) {
+		t.Fatalf("expected ErrChipOverflow topping up past the table limit, got %v", err)
+	}
+	if got := stackOf(g.Snapshot(), 1); got != 1000 {
+		t.Fatalf("expected a refused top-up to leave the stack at 1000, got %d", got)
+	}
+}
+
+func TestChipsInPlay_NearMaxAllInSettles(t *testing.T) {
+	g := headsUpOverflowGame(t)
+	half := int64(math.MaxInt64 / 2)
+	for chair := uint16(0); chair < 2; chair++ {
+		if err := g.SitDown(chair, uint64(chair+1), half, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	for step := 0; step < 2; step++ {
+		chair := g.Snapshot().ActionChair
+		if _, err := g.Act(chair, PlayerActionTypeAllin, half); err != nil {
+			t.Fatalf("step %d: all-in chair=%d err: %v", step, chair, err)
+		}
+	}
+	snap := g.Snapshot()
+	if !snap.Ended {
+		t.Fatalf("expected the all-in to settle")
+	}
+	total := stackOf(snap, 0) + stackOf(snap, 1)
+	if stackOf(snap, 0) < 0 || stackOf(snap, 1) < 0 || total != 2*half {
+		t.Fatalf("expected %d chips conserved, got stacks %d and %d", 2*half, stackOf(snap, 0), stackOf(snap, 1))
+	}
+}
+
+func TestCalcPots_ReportsOverflow(t *testing.T) {
+	// Bets this large never get past the table limit; build them directly.
+	players := []*Player{
+		{Chair: 0, bet: math.MaxInt64 - 10},
+		{Chair: 1, bet: math.MaxInt64 - 10},
+	}
+	var pm potManager
+	pm.resetPots()
+	if err := pm.calcPotsByPlayerBets(players, PhaseTypePreflop); !errors.Is(err, ErrChipOverflow) {
+		t.Fatalf("expected ErrChipOverflow, got %v", err)
+	}
+	for _, p := range pm.pots {
+		if p.amount < 0 {
+			t.Fatalf("expected no pot to wrap negative, got %d", p.amount)
+		}
+	}
+}
+
+func TestPlaceBet_ClampsAtMaxInt64(t *testing.T) {
+	p := &Player{stack: 100, bet: math.MaxInt64 - 40}
+	p.placeBet(100)
+	if p.bet != math.MaxInt64 || p.stack != 60 || p.allIn {
+		t.Fatalf("expected the bet clamped at MaxInt64 with 60 left behind, got bet=%d stack=%d allIn=%v", p.bet, p.stack, p.allIn)
+	}
+	if err := p.addStack(math.MaxInt64); !errors.Is(err, ErrChipOverflow) || p.stack != 60 {
+		t.Fatalf("expected ErrChipOverflow and an unchanged stack, got err=%v stack=%d", err, p.stack)
+	}
+}
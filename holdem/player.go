@@ -1,6 +1,10 @@
 package holdem
 
-import "holdem-lite/card"
+import (
+	"math"
+
+	"holdem-lite/card"
+)
 
 type Player struct {
 	ID    uint64
@@ -13,9 +17,13 @@ type Player struct {
 	allIn      bool
 	folded     bool
 	lastAction ActionType
+	actedLevel int // Game.raiseLevel at this player's last non-fold action
 
 	handCards card.CardList
 	evalRes   *bestHandResult
+
+	// sittingOut keeps the seat but leaves the player out of new deals.
+	sittingOut bool
 }
 
 func (p *Player) ChairID() uint16 { return p.Chair }
@@ -29,6 +37,11 @@ func (p *Player) Hand() []card.Card {
 	return p.handCards
 }
 
+// dealtIn reports whether the player takes part in the next deal.
+func (p *Player) dealtIn() bool {
+	return p.stack > 0 && !p.sittingOut
+}
+
 func (p *Player) ResetForNewHand() {
 	p.bet = 0
 	p.allIn = false
@@ -51,10 +64,16 @@ func (p *Player) HandCards() card.CardList { return p.handCards }
 func (p *Player) setLastAction(a ActionType) { p.lastAction = a }
 func (p *Player) getLastAction() ActionType  { return p.lastAction }
 
+// placeBet moves up to amount from the stack into the bet, going all-in when
+// the stack runs out. The bet is clamped at math.MaxInt64 instead of
+// wrapping; the chips that do not fit stay in the stack.
 func (p *Player) placeBet(amount int64) {
 	if amount <= 0 {
 		return
 	}
+	if room := math.MaxInt64 - p.bet; amount > room {
+		amount = room
+	}
 	if p.stack <= amount {
 		p.allIn = true
 		amount = p.stack
@@ -71,8 +90,15 @@ func (p *Player) resetBet() {
 	p.bet = 0
 }
 
-func (p *Player) addStack(amount int64) {
-	p.stack += amount
+// addStack adds amount (negative to take chips away) to the stack. On
+// overflow it returns ErrChipOverflow and leaves the stack unchanged.
+func (p *Player) addStack(amount int64) error {
+	stack, err := addChips(p.stack, amount)
+	if err != nil {
+		return err
+	}
+	p.stack = stack
+	return nil
 }
 
 func (p *Player) setFolded(v bool) { p.folded = v }
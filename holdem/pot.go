@@ -5,6 +5,9 @@ import "sort"
 type pot struct {
 	amount          int64
 	eligiblePlayers map[uint16]bool
+	// formedPhase is the street whose bets opened this pot; later streets
+	// that merge chips into it keep the original phase.
+	formedPhase Phase
 }
 
 type potManager struct {
@@ -23,7 +26,10 @@ func (pm *potManager) addPot(p ...pot) {
 	pm.pots = append(pm.pots, p...)
 }
 
-func (pm *potManager) calcPotsByPlayerBets(playersWithBets []*Player) {
+// calcPotsByPlayerBets moves the players' bets into main and side pots and
+// hands back the uncalled part of the largest bet. It returns
+// ErrChipOverflow if a pot would exceed the int64 range.
+func (pm *potManager) calcPotsByPlayerBets(playersWithBets []*Player, phase Phase) error {
 	// 按照玩家下注金额排序
 	sort.Slice(playersWithBets, func(i, j int) bool {
 		return playersWithBets[i].Bet() < playersWithBets[j].Bet()
@@ -42,6 +48,7 @@ func (pm *potManager) calcPotsByPlayerBets(playersWithBets []*Player) {
 		newPot := pot{
 			amount:          0,
 			eligiblePlayers: make(map[uint16]bool),
+			formedPhase:     phase,
 		}
 
 		// 为这个边池添加参与者和金额
@@ -52,7 +59,11 @@ func (pm *potManager) calcPotsByPlayerBets(playersWithBets []*Player) {
 				actualContribution = playerJ.Bet() - totalContributed
 			}
 
-			newPot.amount += actualContribution
+			amount, err := addChips(newPot.amount, actualContribution)
+			if err != nil {
+				return err
+			}
+			newPot.amount = amount
 			if !playerJ.Folded() {
 				newPot.eligiblePlayers[playerJ.ChairID()] = true
 			}
@@ -71,7 +82,11 @@ func (pm *potManager) calcPotsByPlayerBets(playersWithBets []*Player) {
 					}
 				}
 				if samePlayers {
-					lastPot.amount += newPot.amount
+					amount, err := addChips(lastPot.amount, newPot.amount)
+					if err != nil {
+						return err
+					}
+					lastPot.amount = amount
 					merged = true
 				}
 			}
@@ -99,12 +114,15 @@ func (pm *potManager) calcPotsByPlayerBets(playersWithBets []*Player) {
 
 		excess := maxBet - secondMaxBet
 		if excess > 0 {
-			lastPlayer.addStack(excess)
+			if err := lastPlayer.addStack(excess); err != nil {
+				return err
+			}
 			lastPlayer.addBet(-excess)
 
 			pm.excessChair = lastPlayer.ChairID()
 			pm.excessAmount = excess
 		}
 	}
+	return nil
 }
 
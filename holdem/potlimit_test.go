@@ -0,0 +1,108 @@
+package holdem
+
+import (
+	"strings"
+	"testing"
+)
+
+// potLimitGame seats four 10000 stacks (dealer chair 0, blinds 50/100 on
+// chairs 1 and 2) and deals a hand, so chair 3 opens.
+func potLimitGame(t *testing.T, structure BettingStructure) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        4,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		BettingStructure:  structure,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 4; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 10000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+func raiseBounds(t *testing.T, g *Game, chair uint16) ([]ActionType, int64, int64) {
+	t.Helper()
+	acts, minTo, maxTo, err := g.LegalActions(chair)
+	if err != nil {
+		t.Fatalf("LegalActions chair=%d err: %v", chair, err)
+	}
+	return acts, minTo, maxTo
+}
+
+func TestPotLimit_MaxRaiseIsPotAfterCalling(t *testing.T) {
+	g := potLimitGame(t, PotLimit)
+
+	// UTG: call 100, then raise the 250 pot: 100 + 150 + 100 = 350.
+	acts, minTo, maxTo := raiseBounds(t, g, 3)
+	if minTo != 200 || maxTo != 350 {
+		t.Fatalf("UTG: expected raise to 200..350, got %d..%d", minTo, maxTo)
+	}
+	if hasAction(acts, PlayerActionTypeAllin) {
+		t.Fatalf("UTG: a 10000 shove is over the pot and must not be offered, got %v", acts)
+	}
+	if _, err := g.Act(3, PlayerActionTypeRaise, 351); err == nil || !strings.Contains(err.Error(), "pot limit of 350") {
+		t.Fatalf("expected an over-pot raise to be rejected, got %v", err)
+	}
+	if _, err := g.Act(3, PlayerActionTypeRaise, 350); err != nil {
+		t.Fatalf("pot-sized raise err: %v", err)
+	}
+
+	// Button faces 350 with 500 in the middle: 350 + 500 + 350 = 1200.
+	if _, minTo, maxTo := raiseBounds(t, g, 0); minTo != 600 || maxTo != 1200 {
+		t.Fatalf("button: expected raise to 600..1200, got %d..%d", minTo, maxTo)
+	}
+	if _, err := g.Act(0, PlayerActionTypeRaise, 1200); err != nil {
+		t.Fatalf("pot-sized reraise err: %v", err)
+	}
+}
+
+func TestPotLimit_PostflopBetCappedAtPot(t *testing.T) {
+	g := potLimitGame(t, PotLimit)
+	for _, chair := range []uint16{3, 0, 1} {
+		if _, err := g.Act(chair, PlayerActionTypeCall, 100); err != nil {
+			t.Fatalf("call chair=%d err: %v", chair, err)
+		}
+	}
+	if _, err := g.Act(2, PlayerActionTypeCheck, 100); err != nil {
+		t.Fatalf("big blind check err: %v", err)
+	}
+	snap := g.Snapshot()
+	if snap.Phase != PhaseTypeFlop {
+		t.Fatalf("expected the flop, got %v", snap.Phase)
+	}
+
+	// Nothing to call: the bet is capped at the 400 pot.
+	if _, minTo, maxTo := raiseBounds(t, g, snap.ActionChair); minTo != 100 || maxTo != 400 {
+		t.Fatalf("flop: expected bet 100..400, got %d..%d", minTo, maxTo)
+	}
+	if _, err := g.Act(snap.ActionChair, PlayerActionTypeBet, 500); err == nil {
+		t.Fatal("expected an over-pot bet to be rejected")
+	}
+	if _, err := g.Act(snap.ActionChair, PlayerActionTypeBet, 400); err != nil {
+		t.Fatalf("pot-sized bet err: %v", err)
+	}
+}
+
+func TestPotLimit_NoLimitMaxIsAllIn(t *testing.T) {
+	g := potLimitGame(t, NoLimit)
+	acts, _, maxTo := raiseBounds(t, g, 3)
+	if maxTo != 10000 || !hasAction(acts, PlayerActionTypeAllin) {
+		t.Fatalf("no-limit: expected a 10000 max with all-in offered, got %d %v", maxTo, acts)
+	}
+	if _, err := g.Act(3, PlayerActionTypeRaise, 5000); err != nil {
+		t.Fatalf("no-limit overbet raise err: %v", err)
+	}
+}
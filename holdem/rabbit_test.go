@@ -0,0 +1,81 @@
+package holdem
+
+import (
+	"reflect"
+	"testing"
+
+	"holdem-lite/card"
+)
+
+// foldedOutGame plays a seeded three-handed hand that ends preflop.
+func foldedOutGame(t *testing.T) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        3,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              42,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 3; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	for _, chair := range []uint16{0, 1} {
+		if _, err := g.Act(chair, PlayerActionTypeFold, 0); err != nil {
+			t.Fatalf("fold chair=%d err: %v", chair, err)
+		}
+	}
+	if !g.Snapshot().Ended {
+		t.Fatal("expected the hand to end on the folds")
+	}
+	return g
+}
+
+func TestPeekRemainingBoard_DeterministicAndHarmless(t *testing.T) {
+	g := foldedOutGame(t)
+	rabbit := g.PeekRemainingBoard()
+	if len(rabbit) != 5 {
+		t.Fatalf("expected the whole board after a preflop fold, got %v", rabbit)
+	}
+	if again := g.PeekRemainingBoard(); !reflect.DeepEqual(again, rabbit) {
+		t.Fatalf("expected repeat peeks to match: %v vs %v", rabbit, again)
+	}
+	if other := foldedOutGame(t).PeekRemainingBoard(); !reflect.DeepEqual(other, rabbit) {
+		t.Fatalf("expected the same seed to reveal the same cards: %v vs %v", rabbit, other)
+	}
+	dealt := make(map[card.Card]bool)
+	for _, p := range g.Snapshot().Players {
+		for _, c := range p.HandCards {
+			dealt[c] = true
+		}
+	}
+	for _, c := range rabbit {
+		if dealt[c] {
+			t.Fatalf("rabbit card %v was already dealt", c)
+		}
+	}
+
+	// The next hand deals exactly what it would have without the peek.
+	control := foldedOutGame(t)
+	for _, game := range []*Game{g, control} {
+		if err := game.StartHand(); err != nil {
+			t.Fatalf("StartHand err: %v", err)
+		}
+	}
+	got, want := g.Snapshot(), control.Snapshot()
+	for i := range want.Players {
+		if !reflect.DeepEqual(got.Players[i].HandCards, want.Players[i].HandCards) {
+			t.Fatalf("chair %d: next hand dealt %v, expected %v", want.Players[i].Chair, got.Players[i].HandCards, want.Players[i].HandCards)
+		}
+	}
+}
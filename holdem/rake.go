@@ -0,0 +1,81 @@
+package holdem
+
+import (
+	"fmt"
+	"math"
+)
+
+// RakeRounding picks how the fractional part of pot*rate becomes whole chips.
+// Whatever the policy, the rake is a whole number of chips taken out of the
+// pot before it is split, so winnings plus rake always equal the pot.
+type RakeRounding uint8
+
+const (
+	RakeRoundFloor RakeRounding = iota
+	RakeRoundNearest
+	RakeRoundCeil
+)
+
+// rakeScale expresses RakePercent in hundredths of a percent so the rake is
+// computed in integers; floats drift (5% of 100 is not exactly 5).
+const rakeScale = 10000
+
+func (r RakeRounding) valid() bool {
+	return r <= RakeRoundCeil
+}
+
+func (c Config) validateRake() error {
+	if c.RakePercent < 0 || c.RakePercent > 100 || math.IsNaN(c.RakePercent) {
+		return fmt.Errorf("RakePercent must be within 0..100, got %v", c.RakePercent)
+	}
+	if c.RakeCapBB < 0 {
+		return fmt.Errorf("RakeCapBB must be >= 0")
+	}
+	if !c.RakeRounding.valid() {
+		return fmt.Errorf("invalid RakeRounding %d", c.RakeRounding)
+	}
+	return nil
+}
+
+// rakeAllowedLocked applies the no-flop-no-drop rule for the hand being
+// settled.
+func (g *Game) rakeAllowedLocked() bool {
+	return !g.cfg.RakeOnlyAtFlop || len(g.communityCards) >= 3
+}
+
+// potRake returns the rake for one pot. taken is the rake already collected
+// this hand; the per-hand cap (RakeCapBB big blinds, 0 for none) limits what
+// is left for later pots.
+func (c Config) potRake(amount, taken int64) int64 {
+	rate := int64(math.Round(c.RakePercent * rakeScale / 100))
+	if rate <= 0 || amount <= 0 {
+		return 0
+	}
+	rake := amount / rakeScale * rate
+	rem := amount % rakeScale * rate
+	rake += rem / rakeScale
+	frac := rem % rakeScale
+	switch c.RakeRounding {
+	case RakeRoundNearest:
+		if frac*2 >= rakeScale {
+			rake++
+		}
+	case RakeRoundCeil:
+		if frac > 0 {
+			rake++
+		}
+	}
+	if c.RakeCapBB > 0 {
+		left := c.RakeCapBB*c.BigBlind - taken
+		if left < 0 {
+			left = 0
+		}
+		if rake > left {
+			rake = left
+		}
+	}
+	if rake > amount {
+		rake = amount
+	}
+	return rake
+}
@@ -0,0 +1,246 @@
+package holdem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPotRake_RoundingPolicies(t *testing.T) {
+	cases := []struct {
+		amount               int64
+		floor, nearest, ceil int64
+	}{
+		{amount: 100, floor: 5, nearest: 5, ceil: 5},
+		{amount: 105, floor: 5, nearest: 5, ceil: 6}, // 5.25
+		{amount: 110, floor: 5, nearest: 6, ceil: 6}, // 5.5
+		{amount: 119, floor: 5, nearest: 6, ceil: 6}, // 5.95
+		{amount: 7, floor: 0, nearest: 0, ceil: 1},   // 0.35
+		{amount: 1, floor: 0, nearest: 0, ceil: 1},   // 0.05
+	}
+	for _, tc := range cases {
+		for rounding, want := range map[RakeRounding]int64{RakeRoundFloor: tc.floor, RakeRoundNearest: tc.nearest, RakeRoundCeil: tc.ceil} {
+			cfg := Config{BigBlind: 100, RakePercent: 5, RakeRounding: rounding}
+			if got := cfg.potRake(tc.amount, 0); got != want {
+				t.Fatalf("pot %d rounding %d: expected rake %d, got %d", tc.amount, rounding, want, got)
+			}
+		}
+	}
+
+	capped := Config{BigBlind: 10, RakePercent: 10, RakeCapBB: 3, RakeRounding: RakeRoundCeil}
+	if got := capped.potRake(1000, 0); got != 30 {
+		t.Fatalf("expected the cap to hold rake at 30, got %d", got)
+	}
+	if got := capped.potRake(1000, 25); got != 5 {
+		t.Fatalf("expected only the cap remainder 5 for a later pot, got %d", got)
+	}
+}
+
+func TestNewGame_RejectsInvalidRake(t *testing.T) {
+	for _, cfg := range []Config{
+		{RakePercent: -1},
+		{RakePercent: 101},
+		{RakePercent: 5, RakeCapBB: -1},
+		{RakePercent: 5, RakeRounding: RakeRoundCeil + 1},
+	} {
+		cfg.MaxPlayers, cfg.MinPlayers, cfg.SmallBlind, cfg.BigBlind = 3, 2, 5, 10
+		if _, err := NewGame(cfg); err == nil {
+			t.Fatalf("expected config %+v to be rejected", cfg)
+		}
+	}
+}
+
+// TestSettlement_RakeConservesChips plays all-in hands with uneven stacks, so
+// pots of awkward sizes split into side pots, and checks every chip is either
+// paid out or raked under each rounding policy.
+func TestSettlement_RakeConservesChips(t *testing.T) {
+	stackSets := [][]int64{
+		{137, 1000, 555},
+		{101, 203, 999},
+		{333, 333, 334},
+		{58, 77, 1013},
+	}
+	for rounding := RakeRoundFloor; rounding <= RakeRoundCeil; rounding++ {
+		for _, capBB := range []int64{0, 2} {
+			for si, stacks := range stackSets {
+				for seed := int64(1); seed <= 6; seed++ {
+					for _, foldOut := range []bool{false, true} {
+						cfg := Config{
+							MaxPlayers:   3,
+							MinPlayers:   2,
+							SmallBlind:   5,
+							BigBlind:     10,
+							Seed:         seed,
+							RakePercent:  4.5,
+							RakeCapBB:    capBB,
+							RakeRounding: rounding,
+						}
+						result, final := playRakeHand(t, cfg, stacks, foldOut)
+						var start, paid, potTotal int64
+						for _, s := range stacks {
+							start += s
+						}
+						for _, pr := range result.PotResults {
+							potTotal += pr.Amount
+							for _, w := range pr.WinAmounts {
+								paid += w
+							}
+						}
+						name := fmt.Sprintf("rounding=%d cap=%d stacks#%d seed=%d fold=%v", rounding, capBB, si, seed, foldOut)
+						if paid+result.RakeAmount != potTotal {
+							t.Fatalf("%s: paid %d + rake %d != pots %d", name, paid, result.RakeAmount, potTotal)
+						}
+						if final+result.RakeAmount != start {
+							t.Fatalf("%s: stacks %d + rake %d != starting %d", name, final, result.RakeAmount, start)
+						}
+						if capBB > 0 && result.RakeAmount > capBB*cfg.BigBlind {
+							t.Fatalf("%s: rake %d above cap", name, result.RakeAmount)
+						}
+						if result.RakeAmount == 0 && potTotal >= 100 && rounding != RakeRoundFloor {
+							t.Fatalf("%s: expected rake on a %d pot", name, potTotal)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// playRakeHand shoves with the first actor; the others call all-in, or fold
+// when foldOut is set. It returns the settlement and the summed final stacks.
+func playRakeHand(t *testing.T, cfg Config, stacks []int64, foldOut bool) (*SettlementResult, int64) {
+	t.Helper()
+	g, err := NewGame(cfg)
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for i, s := range stacks {
+		if err := g.SitDown(uint16(i), uint64(1000+i), s, false); err != nil {
+			t.Fatalf("SitDown err: %v", err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	var result *SettlementResult
+	for i := 0; result == nil; i++ {
+		if i > 10 {
+			t.Fatalf("hand did not finish")
+		}
+		snap := g.Snapshot()
+		chair := snap.ActionChair
+		var stack, bet int64
+		for _, ps := range snap.Players {
+			if ps.Chair == chair {
+				stack, bet = ps.Stack, ps.Bet
+			}
+		}
+		action, amount := PlayerActionTypeAllin, stack+bet
+		legal, _, _, err := g.LegalActions(chair)
+		if err != nil {
+			t.Fatalf("LegalActions err: %v", err)
+		}
+		if !hasAction(legal, action) {
+			// Already covered by every other stack: a call puts it all in.
+			action, amount = PlayerActionTypeCall, snap.CurBet
+		}
+		if foldOut && i > 0 {
+			action, amount = PlayerActionTypeFold, 0
+		}
+		result, err = g.Act(chair, action, amount)
+		if err != nil {
+			t.Fatalf("Act(%d, %v) err: %v", chair, action, err)
+		}
+	}
+	var final int64
+	for _, ps := range g.Snapshot().Players {
+		final += ps.Stack
+	}
+	return result, final
+}
+
+func TestSettlement_RakeCappedAcrossSidePots(t *testing.T) {
+	cfg := Config{MaxPlayers: 3, MinPlayers: 2, SmallBlind: 5, BigBlind: 10, Seed: 1, RakePercent: 5}
+	// Main pot 3x100 and one side pot 2x200; the 700 over the second stack is refunded.
+	stacks := []int64{100, 300, 1000}
+
+	result, _ := playRakeHand(t, cfg, stacks, false)
+	if len(result.PotResults) != 2 {
+		t.Fatalf("expected main and side pot, got %+v", result.PotResults)
+	}
+	if got := []int64{result.PotResults[0].Rake, result.PotResults[1].Rake}; got[0] != 15 || got[1] != 20 {
+		t.Fatalf("expected 5%% of 300 and 400, got %v", got)
+	}
+	if result.RakeAmount != 35 {
+		t.Fatalf("expected total rake 35, got %d", result.RakeAmount)
+	}
+
+	cfg.RakeCapBB = 3
+	result, _ = playRakeHand(t, cfg, stacks, false)
+	if got := []int64{result.PotResults[0].Rake, result.PotResults[1].Rake}; got[0] != 15 || got[1] != 15 {
+		t.Fatalf("expected the 30 chip cap to cut the side pot rake, got %v", got)
+	}
+	if result.RakeAmount != 30 {
+		t.Fatalf("expected capped rake 30, got %d", result.RakeAmount)
+	}
+	for i, pr := range result.PotResults {
+		var paid int64
+		for _, w := range pr.WinAmounts {
+			paid += w
+		}
+		if paid+pr.Rake != pr.Amount {
+			t.Fatalf("pot %d: paid %d + rake %d != %d", i, paid, pr.Rake, pr.Amount)
+		}
+	}
+}
+
+func TestSettlement_NoShowdownRakeAndNoFlopNoDrop(t *testing.T) {
+	play := func(onlyAtFlop, seeFlop bool) *SettlementResult {
+		t.Helper()
+		g, err := NewGame(Config{
+			MaxPlayers: 2, MinPlayers: 2, SmallBlind: 50, BigBlind: 100, Seed: 1,
+			RakePercent: 10, RakeOnlyAtFlop: onlyAtFlop,
+		})
+		if err != nil {
+			t.Fatalf("NewGame err: %v", err)
+		}
+		for chair := uint16(0); chair < 2; chair++ {
+			if err := g.SitDown(chair, uint64(1000+chair), 10000, false); err != nil {
+				t.Fatalf("SitDown err: %v", err)
+			}
+		}
+		if err := g.StartHand(); err != nil {
+			t.Fatalf("StartHand err: %v", err)
+		}
+		act := func(action ActionType, amount int64) *SettlementResult {
+			t.Helper()
+			result, err := g.Act(g.Snapshot().ActionChair, action, amount)
+			if err != nil {
+				t.Fatalf("Act(%v) err: %v", action, err)
+			}
+			return result
+		}
+		if !seeFlop {
+			// SB raises, BB folds: the uncalled raise goes back, leaving a 200 pot.
+			act(PlayerActionTypeRaise, 300)
+			return act(PlayerActionTypeFold, 0)
+		}
+		act(PlayerActionTypeCall, 100)
+		act(PlayerActionTypeCheck, 0)
+		act(PlayerActionTypeBet, 200)
+		return act(PlayerActionTypeFold, 0)
+	}
+
+	if r := play(false, false); r.RakeAmount != 20 || r.PotResults[0].Rake != 20 {
+		t.Fatalf("expected 10%% of the 200 preflop pot without no-flop-no-drop, got %d", r.RakeAmount)
+	}
+	if r := play(true, false); r.RakeAmount != 0 {
+		t.Fatalf("expected no rake when the hand ends before the flop, got %d", r.RakeAmount)
+	}
+	r := play(true, true)
+	if r.RakeAmount != 20 || r.PotResults[0].Amount != 200 {
+		t.Fatalf("expected 10%% of the 200 pot once the flop was dealt, got rake %d pot %+v", r.RakeAmount, r.PotResults)
+	}
+	if r.PlayerResults[0].WinAmount != 180 {
+		t.Fatalf("expected the winner to be paid the pot less rake, got %d", r.PlayerResults[0].WinAmount)
+	}
+}
@@ -0,0 +1,139 @@
+package holdem
+
+import "testing"
+
+func hasAction(acts []ActionType, want ActionType) bool {
+	for _, a := range acts {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+// A raises, B calls, then C and D each go all-in for less than a full raise.
+// The short all-ins raise curBet but must not reopen betting for A and B, and
+// the street closes once A and B match the larger all-in.
+func TestMinRaiseReopen_MultipleShortAllinsDoNotReopen(t *testing.T) {
+	dealer := uint16(3)
+	g, err := NewGame(Config{
+		MaxPlayers:        4,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	// chair 2 = UTG (A), chair 3 = BTN (B), chair 0 = SB (C), chair 1 = BB (D)
+	stacks := map[uint16]int64{0: 400, 1: 500, 2: 10000, 3: 10000}
+	for chair := uint16(0); chair < 4; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), stacks[chair], false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+
+	act := func(chair uint16, action ActionType, amount int64) {
+		t.Helper()
+		if snap := g.Snapshot(); snap.ActionChair != chair {
+			t.Fatalf("expected chair %d to act, got %d", chair, snap.ActionChair)
+		}
+		if _, err := g.Act(chair, action, amount); err != nil {
+			t.Fatalf("chair %d %s %d err: %v", chair, PlayerActionTypeDictionary[action], amount, err)
+		}
+	}
+
+	act(2, PlayerActionTypeRaise, 300) // A: full raise, min raise delta = 200
+	act(3, PlayerActionTypeCall, 300)  // B
+	act(0, PlayerActionTypeAllin, 400) // C: +100, short
+	act(1, PlayerActionTypeAllin, 500) // D: +100, short
+
+	snap := g.Snapshot()
+	if snap.CurBet != 500 {
+		t.Fatalf("expected curBet=500, got %d", snap.CurBet)
+	}
+	if snap.CurrentRaiser != 2 {
+		t.Fatalf("expected short all-ins to keep raiser at chair 2, got %d", snap.CurrentRaiser)
+	}
+	if snap.NeedActionCount != 2 {
+		t.Fatalf("expected 2 players left to act, got %d", snap.NeedActionCount)
+	}
+
+	for _, chair := range []uint16{2, 3} {
+		acts, _, _, err := g.LegalActions(chair)
+		if err != nil {
+			t.Fatalf("LegalActions chair=%d err: %v", chair, err)
+		}
+		if hasAction(acts, PlayerActionTypeRaise) || hasAction(acts, PlayerActionTypeAllin) {
+			t.Fatalf("chair %d already acted on the last full raise, got re-raise option: %v", chair, acts)
+		}
+		if !hasAction(acts, PlayerActionTypeCall) {
+			t.Fatalf("chair %d expected call option, got %v", chair, acts)
+		}
+	}
+	if _, err := g.Act(2, PlayerActionTypeRaise, 1000); err == nil {
+		t.Fatalf("expected re-raise by chair 2 to be rejected")
+	}
+
+	act(2, PlayerActionTypeCall, 500)
+	act(3, PlayerActionTypeCall, 500)
+
+	snap = g.Snapshot()
+	if snap.Phase != PhaseTypeFlop {
+		t.Fatalf("expected street to close into flop, got %v", snap.Phase)
+	}
+}
+
+// A full raise after a short all-in still reopens betting for earlier actors.
+func TestMinRaiseReopen_FullRaiseAfterShortAllinReopens(t *testing.T) {
+	dealer := uint16(3)
+	g, err := NewGame(Config{
+		MaxPlayers:        4,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	stacks := map[uint16]int64{0: 400, 1: 10000, 2: 10000, 3: 10000}
+	for chair := uint16(0); chair < 4; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), stacks[chair], false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+
+	steps := []struct {
+		chair  uint16
+		action ActionType
+		amount int64
+	}{
+		{2, PlayerActionTypeRaise, 300},
+		{3, PlayerActionTypeCall, 300},
+		{0, PlayerActionTypeAllin, 400},
+		{1, PlayerActionTypeRaise, 1000}, // full raise over the all-in
+	}
+	for _, s := range steps {
+		if _, err := g.Act(s.chair, s.action, s.amount); err != nil {
+			t.Fatalf("chair %d %s %d err: %v", s.chair, PlayerActionTypeDictionary[s.action], s.amount, err)
+		}
+	}
+
+	acts, _, _, err := g.LegalActions(2)
+	if err != nil {
+		t.Fatalf("LegalActions err: %v", err)
+	}
+	if !hasAction(acts, PlayerActionTypeRaise) {
+		t.Fatalf("expected full raise to reopen betting for chair 2, got %v", acts)
+	}
+}
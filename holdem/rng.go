@@ -0,0 +1,41 @@
+package holdem
+
+import "math/rand"
+
+// countingSource is a seeded rand source that counts draws so the generator
+// position can be saved and replayed (see Game.Export).
+type countingSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+// restoreCountingSource reseeds and skips the first draws values.
+func restoreCountingSource(seed int64, draws uint64) *countingSource {
+	s := newCountingSource(seed)
+	for i := uint64(0); i < draws; i++ {
+		s.src.Uint64()
+	}
+	s.draws = draws
+	return s
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
@@ -0,0 +1,72 @@
+package holdem
+
+import (
+	"fmt"
+
+	"holdem-lite/card"
+)
+
+// SettleRunItTwice re-settles a hand that ended in an all-in runout by
+// dealing the rest of the board times times and splitting every pot evenly
+// across the runs. The board already dealt is the first run; the others
+// come from the stock. It must be called before any cash-out is applied and
+// replaces the hand's settlement, which it returns.
+func (g *Game) SettleRunItTwice(times int) (*SettlementResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.ended || g.lastSettlement == nil {
+		return nil, fmt.Errorf("hand not settled")
+	}
+	if g.noShowDown || g.runoutFrom >= 5 {
+		return nil, fmt.Errorf("hand did not run out from an all-in")
+	}
+	if times < 2 {
+		return nil, fmt.Errorf("invalid run count %d", times)
+	}
+	prev := g.lastSettlement
+	if len(prev.Runs) > 0 {
+		return nil, fmt.Errorf("hand already run %d times", len(prev.Runs))
+	}
+	if len(prev.CashOuts) > 0 {
+		return nil, fmt.Errorf("hand has cash-outs")
+	}
+	perRun := 5 - g.runoutFrom
+	if need := (times - 1) * perRun; need > len(g.stockCards) {
+		return nil, fmt.Errorf("%d runs need %d more cards, %d left", times, need, len(g.stockCards))
+	}
+
+	// Take back the single run's payouts before paying every run.
+	refund := func(sign int64) error {
+		for _, pr := range prev.PotResults {
+			for i, w := range pr.Winners {
+				if p := g.playersByChair[w]; p != nil {
+					if err := p.addStack(sign * pr.WinAmounts[i]); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	if err := refund(-1); err != nil {
+		return nil, err
+	}
+
+	boards := [][]card.Card{append([]card.Card{}, g.communityCards...)}
+	stock := append(card.CardList{}, g.stockCards...)
+	for run := 1; run < times; run++ {
+		cards, _ := stock.PopCards(perRun)
+		board := append([]card.Card{}, g.communityCards[:g.runoutFrom]...)
+		boards = append(boards, append(board, cards...))
+	}
+	settle, err := g.settleBoardsLocked(boards)
+	if err != nil {
+		// Paying back what was just taken back cannot overflow.
+		_ = refund(1)
+		return nil, err
+	}
+	g.stockCards = stock
+	g.lastSettlement = settle
+	return settle, nil
+}
@@ -0,0 +1,126 @@
+package holdem
+
+import (
+	"reflect"
+	"testing"
+
+	"holdem-lite/card"
+)
+
+// allInRunoutGame deals a three-handed hand where the button's aces and the
+// big blind's kings get it in preflop over the small blind's dead 25. The
+// first board pairs nobody, the second gives the kings a set.
+func allInRunoutGame(t *testing.T) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	prefix := []card.Card{
+		card.CardSpade2, card.CardSpadeK, card.CardSpadeA, // small blind, big blind, button
+		card.CardHeart3, card.CardHeartK, card.CardHeartA,
+		card.CardClub2, card.CardDiamond7, card.CardHeart9, card.CardSpade3, card.CardDiamond4,
+		card.CardClubK, card.CardDiamond8, card.CardClub7, card.CardSpade9, card.CardHeart5,
+	}
+	g, err := NewGame(Config{
+		MaxPlayers:        3,
+		MinPlayers:        2,
+		SmallBlind:        25,
+		BigBlind:          100,
+		ForcedDealerChair: &dealer,
+		DeckOverride:      deckWithPrefix(prefix),
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 3; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	if _, err := g.Act(0, PlayerActionTypeAllin, 1000); err != nil {
+		t.Fatalf("button shove err: %v", err)
+	}
+	if _, err := g.Act(1, PlayerActionTypeFold, 0); err != nil {
+		t.Fatalf("small blind fold err: %v", err)
+	}
+	result, err := g.Act(2, PlayerActionTypeAllin, 1000)
+	if err != nil || result == nil {
+		t.Fatalf("big blind call: expected the hand to settle, got %v err=%v", result, err)
+	}
+	return g
+}
+
+func totalStacks(s Snapshot) int64 {
+	var total int64
+	for _, p := range s.Players {
+		total += p.Stack
+	}
+	return total
+}
+
+func TestSettleRunItTwice_SplitsEachPotAcrossBoards(t *testing.T) {
+	g := allInRunoutGame(t)
+	if got := stackOf(g.Snapshot(), 0); got != 2025 {
+		t.Fatalf("expected the aces to scoop 2025 on one board, got %d", got)
+	}
+
+	result, err := g.SettleRunItTwice(2)
+	if err != nil {
+		t.Fatalf("SettleRunItTwice err: %v", err)
+	}
+	snap := g.Snapshot()
+	// 2025 splits 1013 / 1012: the first run takes the odd chip.
+	for chair, want := range map[uint16]int64{0: 1013, 1: 975, 2: 1012} {
+		if got := stackOf(snap, chair); got != want {
+			t.Fatalf("chair %d: expected stack %d, got %d", chair, want, got)
+		}
+	}
+	if got := totalStacks(snap); got != 3000 {
+		t.Fatalf("expected 3000 chips on the table, got %d", got)
+	}
+
+	if len(result.Runs) != 2 {
+		t.Fatalf("expected two runs, got %d", len(result.Runs))
+	}
+	wantBoards := [][]card.Card{
+		{card.CardClub2, card.CardDiamond7, card.CardHeart9, card.CardSpade3, card.CardDiamond4},
+		{card.CardClubK, card.CardDiamond8, card.CardClub7, card.CardSpade9, card.CardHeart5},
+	}
+	for i, run := range result.Runs {
+		if !reflect.DeepEqual(run.Board, wantBoards[i]) {
+			t.Fatalf("run %d: expected board %v, got %v", i+1, wantBoards[i], run.Board)
+		}
+	}
+	if w := result.Runs[0].PotResults[0]; !reflect.DeepEqual(w.Winners, []uint16{0}) || w.WinAmounts[0] != 1013 {
+		t.Fatalf("run 1: expected the aces to win 1013, got %+v", w)
+	}
+	if w := result.Runs[1].PotResults[0]; !reflect.DeepEqual(w.Winners, []uint16{2}) || w.WinAmounts[0] != 1012 {
+		t.Fatalf("run 2: expected the kings to win 1012, got %+v", w)
+	}
+	pot := result.PotResults[0]
+	if pot.Amount != 2025 || !reflect.DeepEqual(pot.Winners, []uint16{0, 2}) || !reflect.DeepEqual(pot.WinAmounts, []int64{1013, 1012}) {
+		t.Fatalf("expected the combined pot to pay both hands, got %+v", pot)
+	}
+	for _, pr := range result.PlayerResults {
+		if want := map[uint16]int64{0: 1013, 2: 1012}[pr.Chair]; pr.WinAmount != want || !pr.IsWinner {
+			t.Fatalf("chair %d: expected to win %d overall, got %+v", pr.Chair, want, pr)
+		}
+	}
+	if g.lastSettlement != result {
+		t.Fatal("expected the run-it-twice result to replace the hand's settlement")
+	}
+
+	if _, err := g.SettleRunItTwice(2); err == nil {
+		t.Fatal("expected a second run-it-twice to be rejected")
+	}
+}
+
+func TestSettleRunItTwice_RejectsHandsWithoutARunout(t *testing.T) {
+	if _, err := foldedOutGame(t).SettleRunItTwice(2); err == nil {
+		t.Fatal("expected a fold win to be rejected")
+	}
+	if _, err := allInRunoutGame(t).SettleRunItTwice(1); err == nil {
+		t.Fatal("expected a single run to be rejected")
+	}
+}
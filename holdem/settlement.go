@@ -9,9 +9,9 @@ type ShowdownPlayerResult struct {
 	Chair             uint16
 	HandType          byte
 	HandScore         uint32
-	HandCards         []card.Card // 2 张手牌
+	HandCards         []card.Card // 手牌
 	BestFiveCards     []card.Card // 5 张最佳牌
-	AllCards          []card.Card // 7 张（手牌+公共牌）
+	AllCards          []card.Card // 手牌+公共牌
 	IsWinner          bool
 	WinAmount         int64
 	BestFiveCardIndex [5]int
@@ -21,6 +21,10 @@ type PotResult struct {
 	Amount     int64
 	Winners    []uint16
 	WinAmounts []int64
+	// Eligible lists the chairs that contested the pot at showdown.
+	Eligible []uint16
+	// Rake is the part of Amount kept by the house.
+	Rake int64
 }
 
 type SettlementResult struct {
@@ -28,6 +32,24 @@ type SettlementResult struct {
 	PotResults    []PotResult
 	ExcessChair   uint16
 	ExcessAmount  int64
+	// RakeAmount is the total rake kept by the house; pot Amounts are before
+	// rake and WinAmounts after it.
+	RakeAmount int64
+	// CashOuts records all-in players who took their equity instead of the
+	// runout (see Game.ApplyCashOut).
+	CashOuts []CashOut
+	// Runs holds each board of a hand run more than once (see
+	// Game.SettleRunItTwice); it is nil for a single board. PlayerResults keep
+	// the first board's hands with WinAmount summed over every run.
+	Runs []RunResult
+}
+
+// RunResult is one board of a multi-run showdown. Its pot Amounts are the
+// share of each pot, after rake, that the run paid out.
+type RunResult struct {
+	Board         []card.Card
+	PlayerResults []ShowdownPlayerResult
+	PotResults    []PotResult
 }
 
 // SettleShowdown 需要在 communityCards 已经补齐到 5 张之后调用
@@ -39,21 +61,48 @@ func (g *Game) SettleShowdown() (*SettlementResult, error) {
 	return g.settleByEval()
 }
 
+// ShowdownRanks orders evaluated hands by HandScore: 1 is best, tied hands
+// share a rank and the next rank skips past them (1, 1, 3). Results without
+// an evaluated hand (HandType 0) are not ranked.
+func ShowdownRanks(results []ShowdownPlayerResult) map[uint16]int {
+	shown := make([]ShowdownPlayerResult, 0, len(results))
+	for _, pr := range results {
+		if pr.HandType != 0 {
+			shown = append(shown, pr)
+		}
+	}
+	sort.SliceStable(shown, func(i, j int) bool { return shown[i].HandScore > shown[j].HandScore })
+
+	ranks := make(map[uint16]int, len(shown))
+	for i, pr := range shown {
+		if i > 0 && pr.HandScore == shown[i-1].HandScore {
+			ranks[pr.Chair] = ranks[shown[i-1].Chair]
+			continue
+		}
+		ranks[pr.Chair] = i + 1
+	}
+	return ranks
+}
+
 func (g *Game) settleByEval() (*SettlementResult, error) {
-	// Evaluate all hands
+	return g.settleBoardsLocked([][]card.Card{g.communityCards})
+}
+
+// evalHandsLocked evaluates every live hand on board.
+func (g *Game) evalHandsLocked(board []card.Card) (map[uint16]*ShowdownPlayerResult, error) {
 	results := make(map[uint16]*ShowdownPlayerResult, 8)
 	for chair, p := range g.playersByChair {
 		// Only players who were actually dealt this hand can participate in showdown.
-		if p == nil || p.folded || len(p.HandCards()) != 2 {
+		if p == nil || p.folded || len(p.HandCards()) != g.cfg.holeCards() {
 			continue
 		}
-		all := make(card.CardList, 0, 7)
-		all = append(all, p.HandCards()...)
-		all = append(all, g.communityCards...)
-		if len(all) != 7 {
-			return nil, ErrInvalidState("need 7 cards to evaluate")
+		if len(board) != 5 {
+			return nil, ErrInvalidState("need 5 board cards to evaluate")
 		}
-		eval := EvalBestOf7(all)
+		all := make(card.CardList, 0, len(p.HandCards())+5)
+		all = append(all, p.HandCards()...)
+		all = append(all, board...)
+		eval := EvalBest(g.cfg.Variant, g.cfg.Deck, p.HandCards(), board)
 		if eval == nil {
 			return nil, ErrInvalidState("eval failed")
 		}
@@ -71,9 +120,14 @@ func (g *Game) settleByEval() (*SettlementResult, error) {
 			BestFiveCardIndex: eval.BestIndex,
 		}
 	}
+	return results, nil
+}
 
-	// Determine winners per pot
-	potWinners := make([][]uint16, 0, len(g.potManager.pots))
+// potWinnersLocked returns each pot's eligible chairs, sorted, and the best
+// hands among them.
+func (g *Game) potWinnersLocked(results map[uint16]*ShowdownPlayerResult) (potWinners, potEligible [][]uint16) {
+	potWinners = make([][]uint16, 0, len(g.potManager.pots))
+	potEligible = make([][]uint16, 0, len(g.potManager.pots))
 	for _, pot := range g.potManager.pots {
 		group := make([]uint16, 0, len(pot.eligiblePlayers))
 		for chair := range pot.eligiblePlayers {
@@ -81,9 +135,11 @@ func (g *Game) settleByEval() (*SettlementResult, error) {
 		}
 		if len(group) == 0 {
 			potWinners = append(potWinners, nil)
+			potEligible = append(potEligible, nil)
 			continue
 		}
 		sort.Slice(group, func(i, j int) bool { return group[i] < group[j] })
+		potEligible = append(potEligible, group)
 
 		winners := []uint16{group[0]}
 		for gi := 1; gi < len(group); gi++ {
@@ -116,6 +172,34 @@ func (g *Game) settleByEval() (*SettlementResult, error) {
 		}
 		potWinners = append(potWinners, winners)
 	}
+	return potWinners, potEligible
+}
+
+// settleBoardsLocked settles the showdown over one or more boards. Each pot
+// is raked once, then split evenly across the boards, the first boards
+// taking one odd chip each; with several boards the result records every
+// run in Runs.
+func (g *Game) settleBoardsLocked(boards [][]card.Card) (*SettlementResult, error) {
+	runResults := make([]map[uint16]*ShowdownPlayerResult, len(boards))
+	runWinners := make([][][]uint16, len(boards))
+	var potEligible [][]uint16
+	for i, board := range boards {
+		results, err := g.evalHandsLocked(board)
+		if err != nil {
+			return nil, err
+		}
+		runResults[i] = results
+		runWinners[i], potEligible = g.potWinnersLocked(results)
+	}
+	// The overall results keep the first board's hands and total every run.
+	results := runResults[0]
+	if len(boards) > 1 {
+		results = make(map[uint16]*ShowdownPlayerResult, len(runResults[0]))
+		for chair, r := range runResults[0] {
+			total := *r
+			results[chair] = &total
+		}
+	}
 
 	// Distribute pots
 	out := &SettlementResult{
@@ -123,48 +207,143 @@ func (g *Game) settleByEval() (*SettlementResult, error) {
 		ExcessChair:  g.potManager.excessChair,
 		ExcessAmount: g.potManager.excessAmount,
 	}
+	var runs []RunResult
+	if len(boards) > 1 {
+		runs = make([]RunResult, len(boards))
+		for i, board := range boards {
+			runs[i].Board = append([]card.Card{}, board...)
+		}
+	}
 
+	rakeAllowed := g.rakeAllowedLocked()
+	times := int64(len(boards))
 	for potIdx, pot := range g.potManager.pots {
-		winners := potWinners[potIdx]
-		if len(winners) == 0 || pot.amount <= 0 {
-			out.PotResults = append(out.PotResults, PotResult{Amount: pot.amount})
+		if len(runWinners[0][potIdx]) == 0 || pot.amount <= 0 {
+			out.PotResults = append(out.PotResults, PotResult{Amount: pot.amount, Eligible: potEligible[potIdx]})
+			for i := range runs {
+				runs[i].PotResults = append(runs[i].PotResults, PotResult{Eligible: potEligible[potIdx]})
+			}
 			continue
 		}
 
-		winAmount := pot.amount / int64(len(winners))
-		remainder := pot.amount % int64(len(winners))
+		// Rake first so the odd chip comes out of what is actually paid. A pot
+		// only one live hand could win was never contested and is not raked.
+		var rake int64
+		if rakeAllowed && contenders(pot, results) > 1 {
+			rake = g.cfg.potRake(pot.amount, out.RakeAmount)
+		}
+		out.RakeAmount += rake
+		net := pot.amount - rake
 
 		pr := PotResult{
-			Amount:  pot.amount,
-			Winners: append([]uint16{}, winners...),
+			Amount:   pot.amount,
+			Eligible: potEligible[potIdx],
+			Rake:     rake,
 		}
-
-		for i, w := range winners {
-			amt := winAmount
-			if i == 0 {
-				amt += remainder
+		paidAt := make(map[uint16]int, len(potEligible[potIdx]))
+		for i := range boards {
+			share := net / times
+			if int64(i) < net%times {
+				share++
+			}
+			winners := runWinners[i][potIdx]
+			winAmount := share / int64(len(winners))
+			remainder := share % int64(len(winners))
+			run := PotResult{
+				Amount:   share,
+				Winners:  append([]uint16{}, winners...),
+				Eligible: potEligible[potIdx],
 			}
-			pr.WinAmounts = append(pr.WinAmounts, amt)
 
-			if p := g.playersByChair[w]; p != nil {
-				p.addStack(amt)
+			oddChips := g.oddChipWinnersLocked(winners, remainder)
+			for _, w := range winners {
+				amt := winAmount
+				if oddChips[w] {
+					amt++
+				}
+				run.WinAmounts = append(run.WinAmounts, amt)
+				if idx, ok := paidAt[w]; ok {
+					pr.WinAmounts[idx] += amt
+				} else {
+					paidAt[w] = len(pr.Winners)
+					pr.Winners = append(pr.Winners, w)
+					pr.WinAmounts = append(pr.WinAmounts, amt)
+				}
+
+				if p := g.playersByChair[w]; p != nil {
+					if err := p.addStack(amt); err != nil {
+						return nil, err
+					}
+				}
+				if r := results[w]; r != nil {
+					r.IsWinner = true
+					r.WinAmount += amt
+				}
+				if runs != nil {
+					if r := runResults[i][w]; r != nil {
+						r.IsWinner = true
+						r.WinAmount += amt
+					}
+				}
 			}
-			if r := results[w]; r != nil {
-				r.IsWinner = true
-				r.WinAmount += amt
+			if runs != nil {
+				runs[i].PotResults = append(runs[i].PotResults, run)
 			}
 		}
 		out.PotResults = append(out.PotResults, pr)
 	}
 
 	// Flatten + stable sort
-	for _, r := range results {
-		out.PlayerResults = append(out.PlayerResults, *r)
+	out.PlayerResults = sortedPlayerResults(results)
+	for i := range runs {
+		runs[i].PlayerResults = sortedPlayerResults(runResults[i])
 	}
-	sort.Slice(out.PlayerResults, func(i, j int) bool { return out.PlayerResults[i].Chair < out.PlayerResults[j].Chair })
+	out.Runs = runs
 	return out, nil
 }
 
+func sortedPlayerResults(results map[uint16]*ShowdownPlayerResult) []ShowdownPlayerResult {
+	var out []ShowdownPlayerResult
+	for _, r := range results {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Chair < out[j].Chair })
+	return out
+}
+
+// oddChipWinnersLocked picks which of the tied winners get the n chips a pot
+// does not split evenly into: one each, starting with the first winner to the
+// left of the button. Without a button the lowest chairs get them.
+func (g *Game) oddChipWinnersLocked(winners []uint16, n int64) map[uint16]bool {
+	if n <= 0 {
+		return nil
+	}
+	order := append([]uint16{}, winners...)
+	if g.dealerNode != nil {
+		button := int(g.dealerNode.ChairID)
+		seats := g.cfg.MaxPlayers
+		// The button itself is last in line, so seat button+1 is distance 0.
+		dist := func(chair uint16) int { return (int(chair) - button - 1 + seats) % seats }
+		sort.SliceStable(order, func(i, j int) bool { return dist(order[i]) < dist(order[j]) })
+	}
+	out := make(map[uint16]bool, n)
+	for _, chair := range order[:n] {
+		out[chair] = true
+	}
+	return out
+}
+
+// contenders counts the pot's eligible chairs that still hold a live hand.
+func contenders(p pot, results map[uint16]*ShowdownPlayerResult) int {
+	n := 0
+	for chair := range p.eligiblePlayers {
+		if results[chair] != nil {
+			n++
+		}
+	}
+	return n
+}
+
 func (g *Game) settleNoShowdown() (*SettlementResult, error) {
 	// winner = only not folded
 	var winner *Player
@@ -200,22 +379,41 @@ func (g *Game) settleNoShowdown() (*SettlementResult, error) {
 	excess := int64(0)
 	if winner.Bet() == maxBet && maxBet > secondMax {
 		excess = maxBet - secondMax
-		winner.addStack(excess)
+		if err := winner.addStack(excess); err != nil {
+			return nil, err
+		}
 		winner.addBet(-excess)
+		g.uncalled = UncalledBet{Chair: winner.ChairID(), Amount: excess}
 	}
 
 	total := int64(0)
+	contested := len(g.potManager.pots) > 0
+	var err error
 	for _, p := range g.playersByChair {
 		if p == nil {
 			continue
 		}
-		total += p.Bet()
+		if total, err = addChips(total, p.Bet()); err != nil {
+			return nil, err
+		}
+		if p != winner && p.Bet() > 0 {
+			contested = true
+		}
 	}
 	for _, pot := range g.potManager.pots {
-		total += pot.amount
+		if total, err = addChips(total, pot.amount); err != nil {
+			return nil, err
+		}
 	}
 
-	winner.addStack(total)
+	var rake int64
+	if contested && g.rakeAllowedLocked() {
+		rake = g.cfg.potRake(total, 0)
+	}
+	won := total - rake
+	if err := winner.addStack(won); err != nil {
+		return nil, err
+	}
 	for _, p := range g.playersByChair {
 		if p != nil {
 			p.resetBet()
@@ -227,18 +425,21 @@ func (g *Game) settleNoShowdown() (*SettlementResult, error) {
 			{
 				Chair:     winner.ChairID(),
 				IsWinner:  true,
-				WinAmount: total,
+				WinAmount: won,
 			},
 		},
 		PotResults: []PotResult{
 			{
 				Amount:     total,
 				Winners:    []uint16{winner.ChairID()},
-				WinAmounts: []int64{total},
+				WinAmounts: []int64{won},
+				Eligible:   []uint16{winner.ChairID()},
+				Rake:       rake,
 			},
 		},
 		ExcessChair:  winner.ChairID(),
 		ExcessAmount: excess,
+		RakeAmount:   rake,
 	}
 	return out, nil
 }
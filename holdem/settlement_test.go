@@ -0,0 +1,116 @@
+package holdem
+
+import (
+	"testing"
+
+	"holdem-lite/card"
+)
+
+// playBoardSplit deals a royal flush on the board so every hand left at
+// showdown ties, then plays steps (chair offsets from the button).
+func playBoardSplit(t *testing.T, dealer uint16, steps []struct {
+	seat   uint16
+	action ActionType
+	amount int64
+}) *SettlementResult {
+	t.Helper()
+	prefix := []card.Card{
+		card.CardHeart2, card.CardHeart3, card.CardHeart4, card.CardHeart5,
+		card.CardDiamond2, card.CardDiamond3, card.CardDiamond4, card.CardDiamond5,
+		card.CardSpadeA, card.CardSpadeK, card.CardSpadeQ, card.CardSpadeJ, card.CardSpadeT,
+	}
+	g, err := NewGame(Config{
+		MaxPlayers:        4,
+		MinPlayers:        2,
+		SmallBlind:        5,
+		BigBlind:          10,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+		DeckOverride:      deckWithPrefix(prefix),
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 4; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	var result *SettlementResult
+	for i, step := range steps {
+		chair := (dealer + step.seat) % 4
+		result, err = g.Act(chair, step.action, step.amount)
+		if err != nil {
+			t.Fatalf("step %d chair=%d err: %v", i, chair, err)
+		}
+	}
+	for result == nil {
+		result, err = g.Act(g.Snapshot().ActionChair, PlayerActionTypeCheck, 0)
+		if err != nil {
+			t.Fatalf("check down err: %v", err)
+		}
+	}
+	return result
+}
+
+func TestSettlement_OddChipGoesLeftOfButton(t *testing.T) {
+	const btn, sb, bb, utg = 0, 1, 2, 3
+	type step = struct {
+		seat   uint16
+		action ActionType
+		amount int64
+	}
+	// Everyone limps, then on the flop the SB checks and folds to a bet:
+	// 40 + 3x10 = 70 split three ways leaves one odd chip.
+	oneChip := []step{
+		{utg, PlayerActionTypeCall, 10},
+		{btn, PlayerActionTypeCall, 10},
+		{sb, PlayerActionTypeCall, 10},
+		{bb, PlayerActionTypeCheck, 0},
+		{sb, PlayerActionTypeCheck, 0},
+		{bb, PlayerActionTypeBet, 10},
+		{utg, PlayerActionTypeCall, 10},
+		{btn, PlayerActionTypeCall, 10},
+		{sb, PlayerActionTypeFold, 0},
+	}
+	// The SB folds preflop: 35 split three ways leaves two odd chips.
+	twoChips := []step{
+		{utg, PlayerActionTypeCall, 10},
+		{btn, PlayerActionTypeCall, 10},
+		{sb, PlayerActionTypeFold, 0},
+		{bb, PlayerActionTypeCheck, 0},
+	}
+
+	cases := []struct {
+		name   string
+		dealer uint16
+		steps  []step
+		want   map[uint16]int64
+	}{
+		// Winners 0 (BTN), 2 (BB), 3 (UTG): BB is first left of the button.
+		{"button 0", 0, oneChip, map[uint16]int64{2: 24, 3: 23, 0: 23}},
+		// Winners 3 (BB), 0 (UTG), 1 (BTN): chair 3 is first, not chair 0.
+		{"button 1", 1, oneChip, map[uint16]int64{3: 24, 0: 23, 1: 23}},
+		// Winners 1 (BB), 2 (UTG), 3 (BTN): the button is last in line.
+		{"two chips", 3, twoChips, map[uint16]int64{1: 12, 2: 12, 3: 11}},
+	}
+	for _, tc := range cases {
+		result := playBoardSplit(t, tc.dealer, tc.steps)
+		if len(result.PotResults) != 1 {
+			t.Fatalf("%s: expected one pot, got %+v", tc.name, result.PotResults)
+		}
+		pr := result.PotResults[0]
+		if len(pr.Winners) != 3 {
+			t.Fatalf("%s: expected a three-way split, got winners %v", tc.name, pr.Winners)
+		}
+		for i, w := range pr.Winners {
+			if pr.WinAmounts[i] != tc.want[w] {
+				t.Fatalf("%s: chair %d expected %d, got %d (winners %v amounts %v)",
+					tc.name, w, tc.want[w], pr.WinAmounts[i], pr.Winners, pr.WinAmounts)
+			}
+		}
+	}
+}
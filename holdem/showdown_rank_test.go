@@ -0,0 +1,42 @@
+package holdem
+
+import "testing"
+
+func TestShowdownRanks_ThreeShownHandsWithTies(t *testing.T) {
+	cases := []struct {
+		name    string
+		results []ShowdownPlayerResult
+		want    map[uint16]int
+	}{
+		{
+			name: "tie for second",
+			results: []ShowdownPlayerResult{
+				{Chair: 0, HandType: 2, HandScore: 500},
+				{Chair: 3, HandType: 5, HandScore: 900},
+				{Chair: 4, HandType: 2, HandScore: 500},
+			},
+			want: map[uint16]int{3: 1, 0: 2, 4: 2},
+		},
+		{
+			name: "tie for first skips second",
+			results: []ShowdownPlayerResult{
+				{Chair: 1, HandType: 5, HandScore: 900},
+				{Chair: 2, HandType: 2, HandScore: 300},
+				{Chair: 5, HandType: 5, HandScore: 900},
+				{Chair: 4}, // folded, not shown
+			},
+			want: map[uint16]int{1: 1, 5: 1, 2: 3},
+		},
+	}
+	for _, tc := range cases {
+		got := ShowdownRanks(tc.results)
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected %d ranked hands, got %v", tc.name, len(tc.want), got)
+		}
+		for chair, rank := range tc.want {
+			if got[chair] != rank {
+				t.Fatalf("%s: chair %d expected rank %d, got %d", tc.name, chair, rank, got[chair])
+			}
+		}
+	}
+}
@@ -17,6 +17,8 @@ type PlayerSnapshot struct {
 type PotSnapshot struct {
 	Amount          int64
 	EligiblePlayers []uint16
+	// FormedPhase is the street on which the pot was first collected.
+	FormedPhase Phase
 }
 
 type Snapshot struct {
@@ -28,11 +30,14 @@ type Snapshot struct {
 	SmallBlindChair uint16
 	BigBlindChair   uint16
 	ActionChair     uint16
+	StraddleChair   uint16
 
 	CurBet          int64
 	MinRaiseDelta   int64
 	NeedActionCount int
 	CurrentRaiser   uint16
+	// PreflopAggressor is the last full preflop raiser this hand, or InvalidChair.
+	PreflopAggressor uint16
 
 	CommunityCards []card.Card
 	Pots           []PotSnapshot
@@ -47,20 +52,22 @@ func (g *Game) Snapshot() Snapshot {
 	defer g.mu.Unlock()
 
 	s := Snapshot{
-		Round:           g.round,
-		Phase:           g.phase,
-		Ended:           g.ended,
-		DealerChair:     InvalidChair,
-		SmallBlindChair: InvalidChair,
-		BigBlindChair:   InvalidChair,
-		ActionChair:     InvalidChair,
-		CurBet:          g.curBet,
-		MinRaiseDelta:   g.MinRaise,
-		NeedActionCount: g.NeedActionCount,
-		CurrentRaiser:   g.CurrentRaiser,
-		CommunityCards:  append([]card.Card{}, g.communityCards...),
-		ExcessChair:     g.potManager.excessChair,
-		ExcessAmount:    g.potManager.excessAmount,
+		Round:            g.round,
+		Phase:            g.phase,
+		Ended:            g.ended,
+		DealerChair:      InvalidChair,
+		SmallBlindChair:  InvalidChair,
+		BigBlindChair:    InvalidChair,
+		ActionChair:      InvalidChair,
+		StraddleChair:    InvalidChair,
+		CurBet:           g.curBet,
+		MinRaiseDelta:    g.MinRaise,
+		NeedActionCount:  g.NeedActionCount,
+		CurrentRaiser:    g.CurrentRaiser,
+		PreflopAggressor: g.preflopAggressor,
+		CommunityCards:   append([]card.Card{}, g.communityCards...),
+		ExcessChair:      g.potManager.excessChair,
+		ExcessAmount:     g.potManager.excessAmount,
 	}
 	if g.dealerNode != nil {
 		s.DealerChair = g.dealerNode.ChairID
@@ -74,6 +81,9 @@ func (g *Game) Snapshot() Snapshot {
 	if g.curNode != nil {
 		s.ActionChair = g.curNode.ChairID
 	}
+	if g.straddleNode != nil {
+		s.StraddleChair = g.straddleNode.ChairID
+	}
 
 	// players
 	for chair := uint16(0); chair < uint16(g.cfg.MaxPlayers); chair++ {
@@ -97,7 +107,8 @@ func (g *Game) Snapshot() Snapshot {
 	// pots
 	for _, pot := range g.potManager.pots {
 		ps := PotSnapshot{
-			Amount: pot.amount,
+			Amount:      pot.amount,
+			FormedPhase: pot.formedPhase,
 		}
 		for chair := range pot.eligiblePlayers {
 			ps.EligiblePlayers = append(ps.EligiblePlayers, chair)
@@ -107,3 +118,30 @@ func (g *Game) Snapshot() Snapshot {
 
 	return s
 }
+
+// PotTotal is every chip committed to the hand: the collected pots plus the
+// bets still in front of players. Right after StartHand it is the forced
+// money (antes, blinds and straddles).
+func (s Snapshot) PotTotal() int64 {
+	var total int64
+	for _, pot := range s.Pots {
+		total += pot.Amount
+	}
+	for _, ps := range s.Players {
+		total += ps.Bet
+	}
+	return total
+}
+
+// StraddleBet is the straddler's current bet, 0 when nobody straddled.
+func (s Snapshot) StraddleBet() int64 {
+	if s.StraddleChair == InvalidChair {
+		return 0
+	}
+	for _, ps := range s.Players {
+		if ps.Chair == s.StraddleChair {
+			return ps.Bet
+		}
+	}
+	return 0
+}
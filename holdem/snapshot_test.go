@@ -28,3 +28,127 @@ func TestSnapshot_BeforeHand_HasInvalidActionChair(t *testing.T) {
 		t.Fatalf("expected invalid action chair before first hand, got %d", snap.ActionChair)
 	}
 }
+
+func TestSnapshot_PreflopAggressorSurvivesStreetChange(t *testing.T) {
+	dealer := uint16(2)
+	g, err := NewGame(Config{
+		MaxPlayers:        3,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              3,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 3; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 10000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	if snap := g.Snapshot(); snap.PreflopAggressor != InvalidChair {
+		t.Fatalf("expected no preflop aggressor after blinds, got %d", snap.PreflopAggressor)
+	}
+
+	// chair 2 = BTN opens, chair 0 = SB 3-bets, BTN calls, BB folds.
+	steps := []struct {
+		chair  uint16
+		action ActionType
+		amount int64
+	}{
+		{2, PlayerActionTypeRaise, 300},
+		{0, PlayerActionTypeRaise, 900},
+		{1, PlayerActionTypeFold, 0},
+		{2, PlayerActionTypeCall, 900},
+	}
+	for _, s := range steps {
+		if _, err := g.Act(s.chair, s.action, s.amount); err != nil {
+			t.Fatalf("chair %d %s %d err: %v", s.chair, PlayerActionTypeDictionary[s.action], s.amount, err)
+		}
+	}
+
+	snap := g.Snapshot()
+	if snap.Phase != PhaseTypeFlop {
+		t.Fatalf("expected flop, got %v", snap.Phase)
+	}
+	if snap.CurrentRaiser != InvalidChair {
+		t.Fatalf("expected raiser reset on the flop, got %d", snap.CurrentRaiser)
+	}
+	if snap.PreflopAggressor != 0 {
+		t.Fatalf("expected SB (chair 0) as preflop aggressor, got %d", snap.PreflopAggressor)
+	}
+}
+
+func TestSnapshot_PotsRecordFormedPhase(t *testing.T) {
+	dealer := uint16(2)
+	g, err := NewGame(Config{
+		MaxPlayers:        3,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              5,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair, stack := range []int64{300, 1000, 1000} {
+		if err := g.SitDown(uint16(chair), uint64(10001+chair), stack, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+
+	// Limped preflop pot, then on the flop the short SB shoves 200, the BB
+	// raises to 500 and the BTN calls: 600 joins the preflop pot and the
+	// other 600 opens a side pot.
+	steps := []struct {
+		chair  uint16
+		action ActionType
+		amount int64
+	}{
+		{2, PlayerActionTypeCall, 100},
+		{0, PlayerActionTypeCall, 100},
+		{1, PlayerActionTypeCheck, 0},
+		{0, PlayerActionTypeAllin, 0},
+		{1, PlayerActionTypeRaise, 500},
+		{2, PlayerActionTypeCall, 500},
+	}
+	for i, step := range steps {
+		if _, err := g.Act(step.chair, step.action, step.amount); err != nil {
+			t.Fatalf("step %d chair=%d err: %v", i, step.chair, err)
+		}
+	}
+
+	snap := g.Snapshot()
+	if snap.Phase != PhaseTypeTurn {
+		t.Fatalf("expected turn, got phase %d", snap.Phase)
+	}
+	if len(snap.Pots) != 2 {
+		t.Fatalf("expected main and side pot, got %+v", snap.Pots)
+	}
+	if p := snap.Pots[0]; p.Amount != 900 || p.FormedPhase != PhaseTypePreflop {
+		t.Fatalf("expected 900 main pot formed preflop, got %+v", p)
+	}
+	if p := snap.Pots[1]; p.Amount != 600 || p.FormedPhase != PhaseTypeFlop {
+		t.Fatalf("expected 600 side pot formed on the flop, got %+v", p)
+	}
+
+	state, err := g.Export()
+	if err != nil {
+		t.Fatalf("Export err: %v", err)
+	}
+	loaded, err := LoadGame(state)
+	if err != nil {
+		t.Fatalf("LoadGame err: %v", err)
+	}
+	if got := loaded.Snapshot().Pots[1].FormedPhase; got != PhaseTypeFlop {
+		t.Fatalf("expected formed phase to survive export, got %d", got)
+	}
+}
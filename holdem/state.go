@@ -0,0 +1,357 @@
+package holdem
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"holdem-lite/card"
+)
+
+// GameState is a serializable copy of a Game: seats, the in-progress hand and
+// all betting bookkeeping. It round-trips through encoding/json.
+type GameState struct {
+	Config Config
+
+	Round      uint16
+	Phase      Phase
+	Ended      bool
+	NoShowDown bool
+	RunoutFrom int
+
+	Players []PlayerState
+	// RingChairs lists the chairs dealt into the current hand, in ring order.
+	RingChairs []uint16
+
+	DealerChair     uint16
+	SmallBlindChair uint16
+	BigBlindChair   uint16
+	ActionChair     uint16
+	StraddleChair   uint16
+	PendingStraddle uint16
+
+	// PendingReStraddle is InvalidChair when no re-straddle is queued.
+	PendingReStraddle uint16
+
+	CommunityCards []card.Card
+	StockCards     []card.Card
+
+	ActiveCount      int
+	AllinCount       int
+	NeedActionCount  int
+	MinRaise         int64
+	CurrentRaiser    uint16
+	PreflopAggressor uint16
+	RaiseLevel       int
+	CurBet           int64
+	LastPlayerAction ActionType
+	ValidActions     []ActionType
+
+	Pots         []PotState
+	ExcessChair  uint16
+	ExcessAmount int64
+
+	LastSettlement *SettlementResult
+	// DealerDraw is the high-card draw that placed this hand's button, if any.
+	DealerDraw []DealerDrawCard
+	// Uncalled is the bet the last Act handed back (zero Amount if none).
+	Uncalled UncalledBet
+
+	// RNGSeed and RNGDraws pin the shuffle generator position.
+	RNGSeed  int64
+	RNGDraws uint64
+	HandSeed int64
+}
+
+// PlayerState is the serializable per-seat state of a Game.
+type PlayerState struct {
+	ID         uint64
+	Chair      uint16
+	Robot      bool
+	Stack      int64
+	Bet        int64
+	AllIn      bool
+	Folded     bool
+	LastAction ActionType
+	ActedLevel int
+	HandCards  []card.Card
+	SittingOut bool
+}
+
+// PotState is a collected pot and the chairs eligible to win it.
+type PotState struct {
+	Amount          int64
+	EligiblePlayers []uint16
+	FormedPhase     Phase
+}
+
+func nodeChair(n *PlayerNode) uint16 {
+	if n == nil {
+		return InvalidChair
+	}
+	return n.ChairID
+}
+
+// Export captures the full game state, including the RNG position, so that
+// LoadGame continues with identical shuffles. The game can keep running; the
+// state is a deep copy.
+func (g *Game) Export() (GameState, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, n := range []*PlayerNode{g.dealerNode, g.smallBlindNode, g.bigBlindNode, g.curNode, g.straddleNode} {
+		if n != nil && g.chairIDNodes[n.ChairID] != n {
+			return GameState{}, fmt.Errorf("chair %d is no longer in the hand ring", n.ChairID)
+		}
+	}
+
+	s := GameState{
+		Config:           g.cfg,
+		Round:            g.round,
+		Phase:            g.phase,
+		Ended:            g.ended,
+		NoShowDown:       g.noShowDown,
+		RunoutFrom:       g.runoutFrom,
+		DealerChair:      nodeChair(g.dealerNode),
+		SmallBlindChair:  nodeChair(g.smallBlindNode),
+		BigBlindChair:    nodeChair(g.bigBlindNode),
+		ActionChair:      nodeChair(g.curNode),
+		StraddleChair:    nodeChair(g.straddleNode),
+		PendingStraddle:  g.pendingStraddle,
+		CommunityCards:   append([]card.Card{}, g.communityCards...),
+		StockCards:       append([]card.Card{}, g.stockCards...),
+		ActiveCount:      g.activeCount,
+		AllinCount:       g.allinCount,
+		NeedActionCount:  g.NeedActionCount,
+		MinRaise:         g.MinRaise,
+		CurrentRaiser:    g.CurrentRaiser,
+		PreflopAggressor: g.preflopAggressor,
+		RaiseLevel:       g.raiseLevel,
+		CurBet:           g.curBet,
+		LastPlayerAction: g.lastPlayerAction,
+		ValidActions:     append([]ActionType{}, g.validActions...),
+		ExcessChair:      g.potManager.excessChair,
+		ExcessAmount:     g.potManager.excessAmount,
+		LastSettlement:   cloneSettlement(g.lastSettlement),
+		DealerDraw:       append([]DealerDrawCard(nil), g.dealerDraw...),
+		Uncalled:         g.uncalled,
+		RNGSeed:          g.rngSrc.seed,
+		RNGDraws:         g.rngSrc.draws,
+		HandSeed:         g.handSeed,
+	}
+	s.PendingReStraddle = g.pendingReStraddle
+	if g.cfg.DeckOverride != nil {
+		s.Config.DeckOverride = append([]card.Card{}, g.cfg.DeckOverride...)
+	}
+
+	for chair := uint16(0); chair < uint16(g.cfg.MaxPlayers); chair++ {
+		if p := g.playersByChair[chair]; p != nil {
+			s.Players = append(s.Players, PlayerState{
+				ID:         p.ID,
+				Chair:      p.Chair,
+				Robot:      p.Robot,
+				Stack:      p.stack,
+				Bet:        p.bet,
+				AllIn:      p.allIn,
+				Folded:     p.folded,
+				LastAction: p.lastAction,
+				ActedLevel: p.actedLevel,
+				HandCards:  append([]card.Card{}, p.handCards...),
+				SittingOut: p.sittingOut,
+			})
+		}
+		if g.chairIDNodes[chair] != nil {
+			s.RingChairs = append(s.RingChairs, chair)
+		}
+	}
+
+	for _, pot := range g.potManager.pots {
+		ps := PotState{Amount: pot.amount, FormedPhase: pot.formedPhase}
+		for chair := range pot.eligiblePlayers {
+			ps.EligiblePlayers = append(ps.EligiblePlayers, chair)
+		}
+		sort.Slice(ps.EligiblePlayers, func(i, j int) bool { return ps.EligiblePlayers[i] < ps.EligiblePlayers[j] })
+		s.Pots = append(s.Pots, ps)
+	}
+	return s, nil
+}
+
+// LoadGame rebuilds a Game from an exported state so that it continues
+// exactly where the exported game stopped.
+func LoadGame(state GameState) (*Game, error) {
+	if err := state.Config.validate(); err != nil {
+		return nil, err
+	}
+	src := restoreCountingSource(state.RNGSeed, state.RNGDraws)
+	g := &Game{
+		cfg:              state.Config,
+		rng:              rand.New(src),
+		rngSrc:           src,
+		playersByChair:   make(map[uint16]*Player, state.Config.MaxPlayers),
+		chairIDNodes:     make(map[uint16]*PlayerNode, state.Config.MaxPlayers),
+		round:            state.Round,
+		phase:            state.Phase,
+		ended:            state.Ended,
+		noShowDown:       state.NoShowDown,
+		runoutFrom:       state.RunoutFrom,
+		pendingStraddle:  state.PendingStraddle,
+		communityCards:   append(card.CardList{}, state.CommunityCards...),
+		stockCards:       append(card.CardList{}, state.StockCards...),
+		handSeed:         state.HandSeed,
+		activeCount:      state.ActiveCount,
+		allinCount:       state.AllinCount,
+		NeedActionCount:  state.NeedActionCount,
+		MinRaise:         state.MinRaise,
+		CurrentRaiser:    state.CurrentRaiser,
+		preflopAggressor: state.PreflopAggressor,
+		raiseLevel:       state.RaiseLevel,
+		curBet:           state.CurBet,
+		lastPlayerAction: state.LastPlayerAction,
+		validActions:     append([]ActionType{}, state.ValidActions...),
+		lastSettlement:   cloneSettlement(state.LastSettlement),
+		dealerDraw:       append([]DealerDrawCard(nil), state.DealerDraw...),
+		uncalled:         state.Uncalled,
+	}
+	g.pendingReStraddle = state.PendingReStraddle
+
+	for _, ps := range state.Players {
+		if ps.Chair >= uint16(g.cfg.MaxPlayers) {
+			return nil, fmt.Errorf("invalid chair %d", ps.Chair)
+		}
+		if g.playersByChair[ps.Chair] != nil {
+			return nil, fmt.Errorf("chair %d appears twice", ps.Chair)
+		}
+		g.playersByChair[ps.Chair] = &Player{
+			ID:         ps.ID,
+			Chair:      ps.Chair,
+			Robot:      ps.Robot,
+			stack:      ps.Stack,
+			bet:        ps.Bet,
+			allIn:      ps.AllIn,
+			folded:     ps.Folded,
+			lastAction: ps.LastAction,
+			actedLevel: ps.ActedLevel,
+			handCards:  append(card.CardList{}, ps.HandCards...),
+			sittingOut: ps.SittingOut,
+		}
+	}
+
+	var first, last *PlayerNode
+	for _, chair := range state.RingChairs {
+		p := g.playersByChair[chair]
+		if p == nil {
+			return nil, fmt.Errorf("ring chair %d has no player", chair)
+		}
+		if g.chairIDNodes[chair] != nil {
+			return nil, fmt.Errorf("ring chair %d appears twice", chair)
+		}
+		node := &PlayerNode{ChairID: chair, Player: p}
+		g.chairIDNodes[chair] = node
+		if first == nil {
+			first = node
+		}
+		if last != nil {
+			last.Next = node
+		}
+		last = node
+	}
+	if first != nil && last != nil {
+		last.Next = first
+	}
+
+	var err error
+	pick := func(chair uint16) *PlayerNode {
+		if chair == InvalidChair || err != nil {
+			return nil
+		}
+		node := g.chairIDNodes[chair]
+		if node == nil {
+			err = fmt.Errorf("chair %d is not in the hand ring", chair)
+		}
+		return node
+	}
+	g.dealerNode = pick(state.DealerChair)
+	g.smallBlindNode = pick(state.SmallBlindChair)
+	g.bigBlindNode = pick(state.BigBlindChair)
+	g.curNode = pick(state.ActionChair)
+	g.straddleNode = pick(state.StraddleChair)
+	if err != nil {
+		return nil, err
+	}
+
+	g.potManager.resetPots()
+	g.potManager.excessChair = state.ExcessChair
+	g.potManager.excessAmount = state.ExcessAmount
+	for _, ps := range state.Pots {
+		eligible := make(map[uint16]bool, len(ps.EligiblePlayers))
+		for _, chair := range ps.EligiblePlayers {
+			eligible[chair] = true
+		}
+		g.potManager.addPot(pot{amount: ps.Amount, eligiblePlayers: eligible, formedPhase: ps.FormedPhase})
+	}
+	return g, nil
+}
+
+// MarshalState encodes Export as JSON, the form crash-recovery snapshots are
+// stored in.
+func (g *Game) MarshalState() ([]byte, error) {
+	state, err := g.Export()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(state)
+}
+
+// UnmarshalGame rebuilds a Game from MarshalState output.
+func UnmarshalGame(data []byte) (*Game, error) {
+	var state GameState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decode game state: %w", err)
+	}
+	return LoadGame(state)
+}
+
+func cloneSettlement(r *SettlementResult) *SettlementResult {
+	if r == nil {
+		return nil
+	}
+	out := &SettlementResult{
+		ExcessChair:  r.ExcessChair,
+		ExcessAmount: r.ExcessAmount,
+		RakeAmount:   r.RakeAmount,
+		CashOuts:     append([]CashOut(nil), r.CashOuts...),
+	}
+	out.PlayerResults = clonePlayerResults(r.PlayerResults)
+	out.PotResults = clonePotResults(r.PotResults)
+	for _, run := range r.Runs {
+		out.Runs = append(out.Runs, RunResult{
+			Board:         append([]card.Card{}, run.Board...),
+			PlayerResults: clonePlayerResults(run.PlayerResults),
+			PotResults:    clonePotResults(run.PotResults),
+		})
+	}
+	return out
+}
+
+func clonePlayerResults(in []ShowdownPlayerResult) []ShowdownPlayerResult {
+	var out []ShowdownPlayerResult
+	for _, pr := range in {
+		pr.HandCards = append([]card.Card{}, pr.HandCards...)
+		pr.BestFiveCards = append([]card.Card{}, pr.BestFiveCards...)
+		pr.AllCards = append([]card.Card{}, pr.AllCards...)
+		out = append(out, pr)
+	}
+	return out
+}
+
+func clonePotResults(in []PotResult) []PotResult {
+	var out []PotResult
+	for _, pr := range in {
+		pr.Winners = append([]uint16{}, pr.Winners...)
+		pr.WinAmounts = append([]int64{}, pr.WinAmounts...)
+		pr.Eligible = append([]uint16(nil), pr.Eligible...)
+		out = append(out, pr)
+	}
+	return out
+}
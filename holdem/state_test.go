@@ -0,0 +1,241 @@
+package holdem
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func newStateTestGame(t *testing.T) *Game {
+	t.Helper()
+
+	g, err := NewGame(Config{MaxPlayers: 6, MinPlayers: 2, SmallBlind: 50, BigBlind: 100, Seed: 42, DealerSelection: DealerHighCard})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair, stack := range []int64{1000, 2500, 800, 1500} {
+		if err := g.SitDown(uint16(chair), uint64(100+chair), stack, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+// stateTestAction picks a deterministic action from the legal set: the first
+// raiser raises small, everyone else checks or calls.
+func stateTestAction(t *testing.T, g *Game, step int) (uint16, ActionType, int64) {
+	t.Helper()
+
+	snap := g.Snapshot()
+	acts, minRaiseTo, _, err := g.LegalActions(snap.ActionChair)
+	if err != nil {
+		t.Fatalf("step %d: LegalActions err: %v", step, err)
+	}
+	switch {
+	case step == 1 && hasAction(acts, PlayerActionTypeRaise):
+		return snap.ActionChair, PlayerActionTypeRaise, minRaiseTo
+	case hasAction(acts, PlayerActionTypeCheck):
+		return snap.ActionChair, PlayerActionTypeCheck, 0
+	default:
+		return snap.ActionChair, PlayerActionTypeCall, snap.CurBet
+	}
+}
+
+func sortedPots(s Snapshot) Snapshot {
+	for _, pot := range s.Pots {
+		sort.Slice(pot.EligiblePlayers, func(i, j int) bool { return pot.EligiblePlayers[i] < pot.EligiblePlayers[j] })
+	}
+	return s
+}
+
+func TestExportLoadGame_MidHandMatchesReference(t *testing.T) {
+	orig := newStateTestGame(t)
+	ref := newStateTestGame(t)
+
+	// Play into the flop on both games.
+	for step := 0; orig.Snapshot().Phase == PhaseTypePreflop; step++ {
+		chair, action, amount := stateTestAction(t, orig, step)
+		if _, err := orig.Act(chair, action, amount); err != nil {
+			t.Fatalf("orig step %d err: %v", step, err)
+		}
+		if _, err := ref.Act(chair, action, amount); err != nil {
+			t.Fatalf("ref step %d err: %v", step, err)
+		}
+	}
+
+	state, err := orig.Export()
+	if err != nil {
+		t.Fatalf("Export err: %v", err)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshal err: %v", err)
+	}
+	var decoded GameState
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal err: %v", err)
+	}
+	loaded, err := LoadGame(decoded)
+	if err != nil {
+		t.Fatalf("LoadGame err: %v", err)
+	}
+	if draw := loaded.DealerDraw(); len(draw) == 0 || !reflect.DeepEqual(draw, ref.DealerDraw()) {
+		t.Fatalf("expected the dealer draw %+v to survive, got %+v", ref.DealerDraw(), draw)
+	}
+
+	// Finish this hand and play two more; the later shuffles and dealer
+	// choice depend on the restored RNG position.
+	for hand := 0; hand < 3; hand++ {
+		for step := 0; ; step++ {
+			want, got := sortedPots(ref.Snapshot()), sortedPots(loaded.Snapshot())
+			if !reflect.DeepEqual(want, got) {
+				t.Fatalf("hand %d step %d: snapshots differ:\nwant %+v\n got %+v", hand, step, want, got)
+			}
+			if want.Ended {
+				break
+			}
+			if step > 40 {
+				t.Fatalf("hand %d did not finish", hand)
+			}
+			wantActs, wantMin, _, wantErr := ref.LegalActions(want.ActionChair)
+			gotActs, gotMin, _, gotErr := loaded.LegalActions(got.ActionChair)
+			if !reflect.DeepEqual(wantActs, gotActs) || wantMin != gotMin || (wantErr == nil) != (gotErr == nil) {
+				t.Fatalf("hand %d step %d: legal actions differ: want %v/%d/%v got %v/%d/%v",
+					hand, step, wantActs, wantMin, wantErr, gotActs, gotMin, gotErr)
+			}
+
+			chair, action, amount := stateTestAction(t, ref, step)
+			wantRes, err := ref.Act(chair, action, amount)
+			if err != nil {
+				t.Fatalf("hand %d step %d: ref Act err: %v", hand, step, err)
+			}
+			gotRes, err := loaded.Act(chair, action, amount)
+			if err != nil {
+				t.Fatalf("hand %d step %d: loaded Act err: %v", hand, step, err)
+			}
+			if !reflect.DeepEqual(wantRes, gotRes) {
+				t.Fatalf("hand %d step %d: results differ:\nwant %+v\n got %+v", hand, step, wantRes, gotRes)
+			}
+		}
+		if hand < 2 {
+			if err := ref.StartHand(); err != nil {
+				t.Fatalf("ref StartHand err: %v", err)
+			}
+			if err := loaded.StartHand(); err != nil {
+				t.Fatalf("loaded StartHand err: %v", err)
+			}
+		}
+	}
+}
+
+func TestLoadGame_RejectsRingChairWithoutPlayer(t *testing.T) {
+	state, err := newStateTestGame(t).Export()
+	if err != nil {
+		t.Fatalf("Export err: %v", err)
+	}
+	state.RingChairs = append(state.RingChairs, 5)
+	if _, err := LoadGame(state); err == nil {
+		t.Fatalf("expected ring chair without a player to be rejected")
+	}
+}
+
+// randomLegalAction picks any legal action, with a random size for bets and
+// raises.
+func randomLegalAction(t *testing.T, g *Game, rng *rand.Rand) (uint16, ActionType, int64) {
+	t.Helper()
+
+	snap := g.Snapshot()
+	acts, minRaiseTo, _, err := g.LegalActions(snap.ActionChair)
+	if err != nil {
+		t.Fatalf("LegalActions err: %v", err)
+	}
+	var me PlayerSnapshot
+	for _, ps := range snap.Players {
+		if ps.Chair == snap.ActionChair {
+			me = ps
+		}
+	}
+	action := acts[rng.Intn(len(acts))]
+	switch action {
+	case PlayerActionTypeCall:
+		return snap.ActionChair, action, snap.CurBet
+	case PlayerActionTypeBet, PlayerActionTypeRaise:
+		amount := minRaiseTo
+		if top := me.Stack + me.Bet; top > amount {
+			amount += rng.Int63n(top - amount + 1)
+		}
+		return snap.ActionChair, action, amount
+	case PlayerActionTypeAllin:
+		return snap.ActionChair, action, me.Stack + me.Bet
+	}
+	return snap.ActionChair, action, 0
+}
+
+func TestMarshalState_RandomCutPointReachesSameSettlement(t *testing.T) {
+	for seed := int64(1); seed <= 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		ref := newStateTestGame(t)
+		cut := rng.Intn(12)
+
+		var loaded *Game
+		for step := 0; ; step++ {
+			if step == cut {
+				data, err := ref.MarshalState()
+				if err != nil {
+					t.Fatalf("seed %d: MarshalState err: %v", seed, err)
+				}
+				if loaded, err = UnmarshalGame(data); err != nil {
+					t.Fatalf("seed %d: UnmarshalGame err: %v", seed, err)
+				}
+			}
+			if ref.Snapshot().Ended {
+				break
+			}
+			chair, action, amount := randomLegalAction(t, ref, rng)
+			if loaded != nil {
+				wantActs, wantMin, _, _ := ref.LegalActions(chair)
+				gotActs, gotMin, _, _ := loaded.LegalActions(chair)
+				if !reflect.DeepEqual(wantActs, gotActs) || wantMin != gotMin {
+					t.Fatalf("seed %d step %d: legal actions differ: want %v/%d got %v/%d", seed, step, wantActs, wantMin, gotActs, gotMin)
+				}
+			}
+			wantRes, wantErr := ref.Act(chair, action, amount)
+			if wantErr != nil {
+				t.Fatalf("seed %d step %d: Act(%d, %v, %d) err: %v", seed, step, chair, action, amount, wantErr)
+			}
+			if loaded == nil {
+				continue
+			}
+			gotRes, gotErr := loaded.Act(chair, action, amount)
+			if gotErr != nil || !reflect.DeepEqual(wantRes, gotRes) {
+				t.Fatalf("seed %d step %d: resumed game diverged: err=%v\nwant %+v\n got %+v", seed, step, gotErr, wantRes, gotRes)
+			}
+		}
+		if loaded == nil {
+			// The hand ended before the cut; serialize the finished hand instead.
+			data, err := ref.MarshalState()
+			if err != nil {
+				t.Fatalf("seed %d: MarshalState err: %v", seed, err)
+			}
+			if loaded, err = UnmarshalGame(data); err != nil {
+				t.Fatalf("seed %d: UnmarshalGame err: %v", seed, err)
+			}
+		}
+		if want, got := sortedPots(ref.Snapshot()), sortedPots(loaded.Snapshot()); !reflect.DeepEqual(want, got) {
+			t.Fatalf("seed %d: final snapshots differ:\nwant %+v\n got %+v", seed, want, got)
+		}
+		wantBet, wantOK := ref.UncalledReturn()
+		gotBet, gotOK := loaded.UncalledReturn()
+		if wantBet != gotBet || wantOK != gotOK {
+			t.Fatalf("seed %d: uncalled bet differs: want %+v got %+v", seed, wantBet, gotBet)
+		}
+		if want, got := ref.DealerDraw(), loaded.DealerDraw(); !reflect.DeepEqual(want, got) {
+			t.Fatalf("seed %d: dealer draw differs: want %+v got %+v", seed, want, got)
+		}
+	}
+}
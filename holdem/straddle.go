@@ -0,0 +1,89 @@
+package holdem
+
+// SetStraddle queues a blind straddle of 2x BB by chair for the next
+// StartHand. It is dropped at StartHand if the chair is not dealt in, holds a
+// blind, cannot cover the straddle, or fewer than three players are dealt.
+func (g *Game) SetStraddle(chair uint16) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pendingStraddle = chair
+}
+
+// NextHandPositions predicts the button and UTG chairs of the next StartHand.
+// ok is false when the button will be drawn at random (first hand, or the
+// previous button can no longer be dealt in) or fewer than three players can
+// be dealt in.
+func (g *Game) NextHandPositions() (dealer, utg uint16, ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	chairs := make([]uint16, 0, len(g.playersByChair))
+	for chair := uint16(0); chair < uint16(g.cfg.MaxPlayers); chair++ {
+		if p := g.playersByChair[chair]; p != nil && p.dealtIn() {
+			chairs = append(chairs, chair)
+		}
+	}
+	if len(chairs) < 3 {
+		return InvalidChair, InvalidChair, false
+	}
+
+	idx := -1
+	for i, chair := range chairs {
+		switch {
+		case g.cfg.ForcedDealerChair != nil:
+			if chair == *g.cfg.ForcedDealerChair {
+				idx = i
+			}
+		case g.round > 0 && g.dealerNode != nil:
+			if chair == g.dealerNode.ChairID {
+				idx = (i + 1) % len(chairs)
+			}
+		}
+	}
+	if idx < 0 {
+		return InvalidChair, InvalidChair, false
+	}
+	blinds := 2
+	if g.cfg.SmallBlind == 0 {
+		blinds = 1
+	}
+	return chairs[idx], chairs[(idx+1+blinds)%len(chairs)], true
+}
+
+// SetReStraddle queues a 4x BB re-straddle by chair on top of the straddle
+// queued with SetStraddle. It only posts if that straddle does and chair sits
+// directly left of the straddler; the re-straddler then holds the option.
+func (g *Game) SetReStraddle(chair uint16) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pendingReStraddle = chair
+}
+
+// postStraddleLocked posts a blind straddle of mul big blinds after the
+// blinds and reports whether it did. The straddle counts as the opening bet:
+// action starts left of the straddler, who keeps the option to raise when
+// action returns. A second call re-straddles over the first and must come
+// from the next seat, the one that would otherwise act first. Heads-up there
+// is no seat outside the blinds, so nobody can straddle.
+func (g *Game) postStraddleLocked(chair uint16, mul int64) bool {
+	if chair == InvalidChair || g.activeCount < 3 {
+		return false
+	}
+	node := g.chairIDNodes[chair]
+	if node == nil || node == g.smallBlindNode || node == g.bigBlindNode {
+		return false
+	}
+	if g.straddleNode != nil && g.straddleNode.Next != node {
+		return false
+	}
+	amount := mul * g.cfg.BigBlind
+	if node.Player.stack <= amount {
+		return false
+	}
+	node.Player.placeBet(amount)
+	g.curBet = amount
+	g.MinRaise = amount
+	g.straddleNode = node
+	g.curNode = node.Next
+	return true
+}
@@ -0,0 +1,105 @@
+package holdem
+
+import "testing"
+
+// straddleGame seats n players with dealer at chair 0 and queues the given
+// straddles before the deal.
+func straddleGame(t *testing.T, n int, straddle, reStraddle uint16) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        6,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < uint16(n); chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 5000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	g.SetStraddle(straddle)
+	g.SetReStraddle(reStraddle)
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+// callAround calls in turn, checking each actor against order.
+func callAround(t *testing.T, g *Game, order ...uint16) {
+	t.Helper()
+	for _, want := range order {
+		snap := g.Snapshot()
+		if snap.ActionChair != want {
+			t.Fatalf("expected chair %d to act, got %d", want, snap.ActionChair)
+		}
+		if _, err := g.Act(want, PlayerActionTypeCall, snap.CurBet); err != nil {
+			t.Fatalf("call chair=%d err: %v", want, err)
+		}
+	}
+}
+
+func TestStraddle_StraddlerActsLastAndCanRaise(t *testing.T) {
+	g := straddleGame(t, 5, 3, InvalidChair)
+	snap := g.Snapshot()
+	if snap.StraddleChair != 3 || snap.CurBet != 200 {
+		t.Fatalf("expected a 200 straddle from chair 3, got chair=%d curBet=%d", snap.StraddleChair, snap.CurBet)
+	}
+	callAround(t, g, 4, 0, 1, 2)
+
+	snap = g.Snapshot()
+	if snap.ActionChair != 3 || snap.Phase != PhaseTypePreflop {
+		t.Fatalf("expected the straddler to get the option, got chair=%d phase=%v", snap.ActionChair, snap.Phase)
+	}
+	actions, minRaiseTo, _, err := g.LegalActions(3)
+	if err != nil {
+		t.Fatalf("LegalActions err: %v", err)
+	}
+	if !hasAction(actions, PlayerActionTypeRaise) || !hasAction(actions, PlayerActionTypeCheck) {
+		t.Fatalf("expected the straddler to check or raise, got %v", actions)
+	}
+	if _, err := g.Act(3, PlayerActionTypeRaise, minRaiseTo); err != nil {
+		t.Fatalf("straddler raise err: %v", err)
+	}
+	if got := g.Snapshot().ActionChair; got != 4 {
+		t.Fatalf("expected action back on chair 4 after the raise, got %d", got)
+	}
+}
+
+func TestStraddle_ReStraddleTakesTheOption(t *testing.T) {
+	g := straddleGame(t, 5, 3, 4)
+	snap := g.Snapshot()
+	if snap.StraddleChair != 4 || snap.CurBet != 400 {
+		t.Fatalf("expected a 400 re-straddle from chair 4, got chair=%d curBet=%d", snap.StraddleChair, snap.CurBet)
+	}
+	callAround(t, g, 0, 1, 2, 3)
+	if got := g.Snapshot().ActionChair; got != 4 {
+		t.Fatalf("expected the re-straddler to act last, got %d", got)
+	}
+
+	// A re-straddle must come from the seat left of the straddler.
+	g = straddleGame(t, 5, 3, 0)
+	if snap := g.Snapshot(); snap.StraddleChair != 3 || snap.CurBet != 200 {
+		t.Fatalf("expected only the straddle to post, got chair=%d curBet=%d", snap.StraddleChair, snap.CurBet)
+	}
+	// Without a straddle there is nothing to re-straddle.
+	g = straddleGame(t, 5, InvalidChair, 4)
+	if snap := g.Snapshot(); snap.StraddleChair != InvalidChair || snap.CurBet != 100 {
+		t.Fatalf("expected no straddle, got chair=%d curBet=%d", snap.StraddleChair, snap.CurBet)
+	}
+}
+
+func TestStraddle_DroppedHeadsUp(t *testing.T) {
+	for _, chair := range []uint16{0, 1} {
+		g := straddleGame(t, 2, chair, InvalidChair)
+		if snap := g.Snapshot(); snap.StraddleChair != InvalidChair || snap.CurBet != 100 {
+			t.Fatalf("chair %d: expected no straddle heads-up, got chair=%d curBet=%d", chair, snap.StraddleChair, snap.CurBet)
+		}
+	}
+}
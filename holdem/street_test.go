@@ -72,3 +72,95 @@ func TestStreetProgression_FlopFirstActionAfterBBFolds(t *testing.T) {
 	}
 }
 
+
+// bigBlindOnlyGame seats n players on a SmallBlind == 0 table with the button
+// on chair 0.
+func bigBlindOnlyGame(t *testing.T, n int) *Game {
+	t.Helper()
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        n,
+		MinPlayers:        2,
+		SmallBlind:        0,
+		BigBlind:          100,
+		Seed:              1,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := 0; chair < n; chair++ {
+		if err := g.SitDown(uint16(chair), uint64(10001+chair), 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	return g
+}
+
+func TestStreetProgression_BigBlindOnlyPreflopOrder(t *testing.T) {
+	g := bigBlindOnlyGame(t, 4)
+	snap := g.Snapshot()
+	if snap.SmallBlindChair != InvalidChair || snap.BigBlindChair != 1 {
+		t.Fatalf("expected no small blind and BB on chair 1, got sb=%d bb=%d", snap.SmallBlindChair, snap.BigBlindChair)
+	}
+	for _, ps := range snap.Players {
+		want := int64(0)
+		if ps.Chair == 1 {
+			want = 100
+		}
+		if ps.Bet != want || len(ps.HandCards) != 2 {
+			t.Fatalf("chair %d: expected bet %d and two cards, got bet %d cards %v", ps.Chair, want, ps.Bet, ps.HandCards)
+		}
+	}
+
+	// UTG (left of the BB), then around to the button; the BB keeps its option.
+	for _, chair := range []uint16{2, 3, 0} {
+		if got := g.Snapshot().ActionChair; got != chair {
+			t.Fatalf("expected chair %d to act, got %d", chair, got)
+		}
+		if _, err := g.Act(chair, PlayerActionTypeCall, 100); err != nil {
+			t.Fatalf("chair %d call err: %v", chair, err)
+		}
+	}
+	snap = g.Snapshot()
+	if snap.ActionChair != 1 || snap.Phase != PhaseTypePreflop {
+		t.Fatalf("expected the BB option preflop, got chair %d phase %d", snap.ActionChair, snap.Phase)
+	}
+	acts, _, _, err := g.LegalActions(1)
+	if err != nil || !hasAction(acts, PlayerActionTypeCheck) || !hasAction(acts, PlayerActionTypeRaise) {
+		t.Fatalf("expected the BB to be able to check or raise, got %v err=%v", acts, err)
+	}
+	if _, err := g.Act(1, PlayerActionTypeCheck, 0); err != nil {
+		t.Fatalf("BB check err: %v", err)
+	}
+
+	snap = g.Snapshot()
+	if snap.Phase != PhaseTypeFlop || snap.ActionChair != 1 {
+		t.Fatalf("expected the BB first on the flop, got chair %d phase %d", snap.ActionChair, snap.Phase)
+	}
+	if snap.Pots[0].Amount != 400 {
+		t.Fatalf("expected a 400 pot, got %d", snap.Pots[0].Amount)
+	}
+}
+
+func TestStreetProgression_BigBlindOnlyHeadsUp(t *testing.T) {
+	g := bigBlindOnlyGame(t, 2)
+	snap := g.Snapshot()
+	if snap.SmallBlindChair != InvalidChair || snap.BigBlindChair != 1 || snap.ActionChair != 0 {
+		t.Fatalf("expected the button to act first against the BB, got sb=%d bb=%d action=%d",
+			snap.SmallBlindChair, snap.BigBlindChair, snap.ActionChair)
+	}
+	if _, err := g.Act(0, PlayerActionTypeCall, 100); err != nil {
+		t.Fatalf("button call err: %v", err)
+	}
+	if _, err := g.Act(1, PlayerActionTypeCheck, 0); err != nil {
+		t.Fatalf("BB check err: %v", err)
+	}
+	snap = g.Snapshot()
+	if snap.Phase != PhaseTypeFlop || snap.ActionChair != 1 {
+		t.Fatalf("expected the BB first on the flop, got chair %d phase %d", snap.ActionChair, snap.Phase)
+	}
+}
@@ -91,3 +91,14 @@ var HoldemCards = []card.Card{
 	card.CardDiamond7, card.CardDiamond8, card.CardDiamond9, card.CardDiamondT, card.CardDiamondJ, card.CardDiamondQ, card.CardDiamondK,
 }
 
+
+// ShortDeckCards is HoldemCards without the 2s through 5s.
+var ShortDeckCards = func() []card.Card {
+	cards := make([]card.Card, 0, 36)
+	for _, c := range HoldemCards {
+		if r := c.Rank(); r == 1 || r >= 6 {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}()
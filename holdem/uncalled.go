@@ -0,0 +1,17 @@
+package holdem
+
+// UncalledBet is the part of a bet no one matched, handed back to its owner.
+type UncalledBet struct {
+	Chair  uint16
+	Amount int64
+}
+
+// UncalledReturn reports the bet the last Act handed back, if any: the
+// excess of a bet everyone folded to, or of an all-in no one could match
+// once that street's betting closed. The chips are already back in the
+// owner's stack and are not part of any pot or winnings.
+func (g *Game) UncalledReturn() (UncalledBet, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.uncalled, g.uncalled.Amount > 0
+}
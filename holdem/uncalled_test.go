@@ -0,0 +1,63 @@
+package holdem
+
+import "testing"
+
+func TestUncalledReturn_ShoveOverShorterAllIn(t *testing.T) {
+	g, err := NewGame(Config{MaxPlayers: 6, MinPlayers: 2, SmallBlind: 50, BigBlind: 100, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	stacks := map[uint16]int64{0: 5000, 1: 2000}
+	for chair, stack := range stacks {
+		if err := g.SitDown(chair, uint64(10001+chair), stack, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	if _, ok := g.UncalledReturn(); ok {
+		t.Fatalf("expected nothing returned before any action")
+	}
+
+	// Chair 0 shoves at its first turn; chair 1 limps or checks until then
+	// and calls all in for less.
+	var result *SettlementResult
+	for result == nil {
+		snap := g.Snapshot()
+		chair := snap.ActionChair
+		var action ActionType
+		var amount int64
+		switch {
+		case chair == 0:
+			action, amount = PlayerActionTypeAllin, stacks[0]
+		case snap.CurBet > 100:
+			action, amount = PlayerActionTypeAllin, stacks[1]
+		case snap.Phase == PhaseTypePreflop:
+			action, amount = PlayerActionTypeCall, snap.CurBet
+		default:
+			action = PlayerActionTypeCheck
+		}
+		if result, err = g.Act(chair, action, amount); err != nil {
+			t.Fatalf("chair %d %v err: %v", chair, action, err)
+		}
+		if _, ok := g.UncalledReturn(); ok && result == nil {
+			t.Fatalf("expected nothing returned while the shove can still be called")
+		}
+	}
+
+	bet, ok := g.UncalledReturn()
+	if !ok || bet.Chair != 0 || bet.Amount != 3000 {
+		t.Fatalf("expected 3000 returned to chair 0, got %+v ok=%v", bet, ok)
+	}
+	var won, total int64
+	for _, pr := range result.PlayerResults {
+		won += pr.WinAmount
+	}
+	for _, ps := range g.Snapshot().Players {
+		total += ps.Stack
+	}
+	if won != 4000 || total != 7000 {
+		t.Fatalf("expected a 4000 pot and 7000 chips in play, got pot %d chips %d", won, total)
+	}
+}
@@ -1,6 +1,7 @@
 package holdem
 
 import (
+	"fmt"
 	"math/rand"
 
 	"holdem-lite/card"
@@ -14,6 +15,15 @@ func getMapKeys(m map[uint16]bool) []uint16 {
 	return keys
 }
 
+// addChips returns a+b, or ErrChipOverflow instead of a sum that wrapped.
+func addChips(a, b int64) (int64, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrChipOverflow, a, b)
+	}
+	return sum, nil
+}
+
 func randInt64(min, max int64) int64 {
 	if min >= max {
 		return min
@@ -0,0 +1,103 @@
+package holdem
+
+import (
+	"testing"
+
+	"holdem-lite/card"
+)
+
+func TestVariant_OmahaDealsFourAndShowsDownWithTwo(t *testing.T) {
+	dealer := uint16(0)
+	g, err := NewGame(Config{
+		MaxPlayers:        3,
+		MinPlayers:        2,
+		SmallBlind:        50,
+		BigBlind:          100,
+		Variant:           VariantOmaha,
+		Seed:              7,
+		ForcedDealerChair: &dealer,
+	})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 3; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	seen := make(map[card.Card]bool)
+	for _, p := range g.Snapshot().Players {
+		if len(p.HandCards) != 4 {
+			t.Fatalf("chair %d: expected 4 hole cards, got %v", p.Chair, p.HandCards)
+		}
+		for _, c := range p.HandCards {
+			if seen[c] {
+				t.Fatalf("card %v dealt twice", c)
+			}
+			seen[c] = true
+		}
+	}
+
+	callAround(t, g, 0, 1)
+	var result *SettlementResult
+	for result == nil {
+		result, err = g.Act(g.Snapshot().ActionChair, PlayerActionTypeCheck, 0)
+		if err != nil {
+			t.Fatalf("check down err: %v", err)
+		}
+	}
+	if len(result.PlayerResults) != 3 {
+		t.Fatalf("expected three hands at showdown, got %d", len(result.PlayerResults))
+	}
+	for _, pr := range result.PlayerResults {
+		fromHole := 0
+		for _, i := range pr.BestFiveCardIndex {
+			if i < len(pr.HandCards) {
+				fromHole++
+			}
+		}
+		if fromHole != 2 {
+			t.Fatalf("chair %d: expected the best five to use two hole cards, got %v", pr.Chair, pr.BestFiveCardIndex)
+		}
+	}
+}
+
+func TestVariant_HoleCardsMustFitTheDeck(t *testing.T) {
+	if _, err := NewGame(Config{MaxPlayers: 9, MinPlayers: 2, SmallBlind: 1, BigBlind: 2, HoleCardCount: 6}); err == nil {
+		t.Fatal("expected 9 players with 6 hole cards each to be rejected")
+	}
+	if _, err := NewGame(Config{MaxPlayers: 9, MinPlayers: 2, SmallBlind: 1, BigBlind: 2, HoleCardCount: 5, Variant: VariantOmaha}); err != nil {
+		t.Fatalf("expected 9-handed 5-card Omaha to fit the deck, got %v", err)
+	}
+}
+
+func TestVariant_ShortDeckDealsSixUp(t *testing.T) {
+	g, err := NewGame(Config{MaxPlayers: 6, MinPlayers: 2, SmallBlind: 50, BigBlind: 100, Deck: DeckShort, Seed: 3})
+	if err != nil {
+		t.Fatalf("NewGame err: %v", err)
+	}
+	for chair := uint16(0); chair < 6; chair++ {
+		if err := g.SitDown(chair, uint64(10001+chair), 1000, false); err != nil {
+			t.Fatalf("SitDown chair=%d err: %v", chair, err)
+		}
+	}
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("StartHand err: %v", err)
+	}
+	for _, p := range g.Snapshot().Players {
+		for _, c := range p.HandCards {
+			if r := c.Rank(); r >= 2 && r <= 5 {
+				t.Fatalf("chair %d was dealt %v from a short deck", p.Chair, c)
+			}
+		}
+	}
+	if got := len(ShuffledDeckOf(DeckShort, 1)); got != 36 {
+		t.Fatalf("expected a 36-card short deck, got %d", got)
+	}
+	if _, err := NewGame(Config{MaxPlayers: 9, MinPlayers: 2, SmallBlind: 1, BigBlind: 2, Deck: DeckShort, Variant: VariantOmaha}); err == nil {
+		t.Fatal("expected 9-handed Omaha not to fit a short deck")
+	}
+}
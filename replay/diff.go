@@ -0,0 +1,108 @@
+package replay
+
+import (
+	pb "holdem-lite/apps/server/gen"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// TapeDiscrepancy is one semantic difference between a generated tape and a
+// recorded event stream. Index is the position among comparable hand events.
+type TapeDiscrepancy struct {
+	Index     int    `json:"index"`
+	EventType string `json:"event_type"`
+	Reason    string `json:"reason"`
+	Expected  string `json:"expected,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+}
+
+// DiffTape compares the hand events of a tape against recorded envelopes.
+// Transport metadata (table id, seq, timestamps), prompt timers, round
+// counters and the deck seed commitment and reveal are ignored, as are events the generator never emits (snapshots,
+// seat updates, errors). Repeated identical prompts, e.g. re-sent on
+// reconnect, count once.
+func DiffTape(tape *ReplayTape, recorded []*pb.ServerEnvelope) []TapeDiscrepancy {
+	var generated []*pb.ServerEnvelope
+	if tape != nil {
+		for _, e := range tape.Events {
+			generated = append(generated, e.Value)
+		}
+	}
+	expected := comparableEvents(generated)
+	actual := comparableEvents(recorded)
+
+	var out []TapeDiscrepancy
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			out = append(out, TapeDiscrepancy{
+				Index:     i,
+				EventType: payloadType(expected[i]),
+				Reason:    "missing",
+				Expected:  envelopeText(expected[i]),
+			})
+		case i >= len(expected):
+			out = append(out, TapeDiscrepancy{
+				Index:     i,
+				EventType: payloadType(actual[i]),
+				Reason:    "unexpected",
+				Actual:    envelopeText(actual[i]),
+			})
+		case !proto.Equal(expected[i], actual[i]):
+			out = append(out, TapeDiscrepancy{
+				Index:     i,
+				EventType: payloadType(expected[i]),
+				Reason:    "mismatch",
+				Expected:  envelopeText(expected[i]),
+				Actual:    envelopeText(actual[i]),
+			})
+		}
+	}
+	return out
+}
+
+func comparableEvents(envs []*pb.ServerEnvelope) []*pb.ServerEnvelope {
+	out := make([]*pb.ServerEnvelope, 0, len(envs))
+	for _, env := range envs {
+		if env == nil {
+			continue
+		}
+		switch env.GetPayload().(type) {
+		case *pb.ServerEnvelope_HandStart, *pb.ServerEnvelope_DealHoleCards, *pb.ServerEnvelope_ActionPrompt,
+			*pb.ServerEnvelope_ActionResult, *pb.ServerEnvelope_DealBoard, *pb.ServerEnvelope_PotUpdate,
+			*pb.ServerEnvelope_PhaseChange, *pb.ServerEnvelope_Showdown, *pb.ServerEnvelope_WinByFold,
+			*pb.ServerEnvelope_HandEnd:
+		default:
+			continue
+		}
+		c := proto.Clone(env).(*pb.ServerEnvelope)
+		c.TableId = ""
+		c.ServerSeq = 0
+		c.ServerTsMs = 0
+		switch p := c.GetPayload().(type) {
+		case *pb.ServerEnvelope_ActionPrompt:
+			p.ActionPrompt.TimeLimitSec = 0
+			p.ActionPrompt.ActionDeadlineMs = 0
+		case *pb.ServerEnvelope_HandStart:
+			p.HandStart.Round = 0
+			p.HandStart.SeedCommitment = ""
+		case *pb.ServerEnvelope_HandEnd:
+			p.HandEnd.Round = 0
+			p.HandEnd.DeckSeed = 0
+		}
+		if _, ok := c.GetPayload().(*pb.ServerEnvelope_ActionPrompt); ok && len(out) > 0 && proto.Equal(out[len(out)-1], c) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func envelopeText(env *pb.ServerEnvelope) string {
+	b, err := protojson.Marshal(env)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
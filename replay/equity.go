@@ -0,0 +1,19 @@
+package replay
+
+import (
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+)
+
+// equitySamples bounds the Monte Carlo run used when exact enumeration of the
+// unseen cards would be too large.
+const equitySamples = 5000
+
+// estimateEquity returns hero's share of the pot at showdown against the given
+// opponents. A nil opponent hand is unknown and dealt from the remaining deck.
+// Ties split the share. With every opponent known and at most two board cards
+// to come the result is exact; otherwise it is sampled with a fixed seed so the
+// same spot always gives the same number.
+func estimateEquity(hero []card.Card, opponents [][]card.Card, board []card.Card, seed int64) float64 {
+	return holdem.ShowdownEquity(hero, opponents, board, equitySamples, seed)
+}
@@ -0,0 +1,437 @@
+package replay
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "holdem-lite/apps/server/gen"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ExportHandHistory renders a tape as PokerStars-style hand history text, the
+// format most trackers import. Seats and names come from the tape's table
+// snapshot, hole cards only for the hero and for hands shown at showdown.
+// The tape carries no wall-clock time, so the header has no date.
+func ExportHandHistory(tape *ReplayTape) (string, error) {
+	if tape == nil {
+		return "", fmt.Errorf("export hand history: nil tape")
+	}
+	envs := make([]*pb.ServerEnvelope, 0, len(tape.Events))
+	for i, e := range tape.Events {
+		env := e.Value
+		if env == nil && e.EnvelopeB64 != "" {
+			raw, err := base64.StdEncoding.DecodeString(e.EnvelopeB64)
+			if err != nil {
+				return "", fmt.Errorf("export hand history: event %d: %w", i, err)
+			}
+			env = &pb.ServerEnvelope{}
+			if err := proto.Unmarshal(raw, env); err != nil {
+				return "", fmt.Errorf("export hand history: event %d: %w", i, err)
+			}
+		}
+		if env != nil {
+			envs = append(envs, env)
+		}
+	}
+
+	x := &hhExport{tableID: tape.TableID, hero: uint32(tape.HeroChair)}
+	for _, env := range envs {
+		if err := x.add(env); err != nil {
+			return "", fmt.Errorf("export hand history: %w", err)
+		}
+	}
+	if !x.ended {
+		return "", fmt.Errorf("export hand history: tape has no hand end")
+	}
+	x.summary()
+	return x.out.String(), nil
+}
+
+// hhSeat is one player as the export tracks them through the hand.
+type hhSeat struct {
+	chair    uint32
+	name     string
+	stack    int64 // chips behind
+	bet      int64 // in front this street
+	folded   bool
+	foldedOn string // street of the fold, "" preflop
+	shown    *pb.ShowdownHand
+	won      int64
+}
+
+type hhExport struct {
+	tableID string
+	hero    uint32
+	out     strings.Builder
+
+	snapshot *pb.TableSnapshot
+	start    *pb.HandStart
+	seats    map[uint32]*hhSeat
+	order    []uint32
+
+	street   string // "" preflop, then "Flop", "Turn", "River"
+	curBet   int64
+	board    []string
+	pots     []*pb.PotResult
+	potTotal int64
+	refunded bool
+	rake     int64
+	ended    bool
+}
+
+func (x *hhExport) add(env *pb.ServerEnvelope) error {
+	switch {
+	case env.GetTableSnapshot() != nil:
+		if x.start == nil {
+			x.snapshot = env.GetTableSnapshot()
+		}
+	case env.GetHandStart() != nil:
+		if x.snapshot == nil {
+			return fmt.Errorf("hand starts before any table snapshot")
+		}
+		x.start = env.GetHandStart()
+		x.header()
+	case x.start == nil:
+		// Nothing before the hand starts is part of the history.
+	case env.GetDealHoleCards() != nil:
+		cards, err := protoCardStrings(env.GetDealHoleCards().GetCards())
+		if err != nil {
+			return err
+		}
+		x.linef("Dealt to %s [%s]", x.name(x.hero), strings.Join(cards, " "))
+	case env.GetActionResult() != nil:
+		x.action(env.GetActionResult())
+	case env.GetDealBoard() != nil:
+		return x.deal(env.GetDealBoard())
+	case env.GetShowdown() != nil:
+		return x.showdown(env.GetShowdown())
+	case env.GetWinByFold() != nil:
+		wf := env.GetWinByFold()
+		x.refund(wf.GetExcessRefund())
+		x.potTotal = wf.GetPotTotal()
+		if seat := x.seats[wf.GetWinnerChair()]; seat != nil {
+			seat.won = wf.GetPotTotal()
+		}
+		x.linef("%s collected %d from pot", x.name(wf.GetWinnerChair()), wf.GetPotTotal())
+	case env.GetHandEnd() != nil:
+		x.refund(env.GetHandEnd().GetExcessRefund())
+		x.rake = env.GetHandEnd().GetRakeAmount()
+		x.ended = true
+	}
+	return nil
+}
+
+// header writes the hand and table lines, the seats and the forced bets.
+func (x *hhExport) header() {
+	cfg := x.snapshot.GetConfig()
+	x.linef("PokerStars Hand #%d: Hold'em No Limit (%d/%d)", x.start.GetRound(), x.start.GetSmallBlindAmount(), x.start.GetBigBlindAmount())
+	x.linef("Table '%s' %d-max Seat #%d is the button", x.tableID, cfg.GetMaxPlayers(), x.start.GetDealerChair()+1)
+
+	x.seats = make(map[uint32]*hhSeat, len(x.snapshot.GetPlayers()))
+	for _, ps := range x.snapshot.GetPlayers() {
+		name := ps.GetNickname()
+		if name == "" {
+			name = fmt.Sprintf("Player%d", ps.GetChair()+1)
+		}
+		x.seats[ps.GetChair()] = &hhSeat{chair: ps.GetChair(), name: name, stack: ps.GetStack() + ps.GetBet()}
+		x.order = append(x.order, ps.GetChair())
+	}
+	sort.Slice(x.order, func(i, j int) bool { return x.order[i] < x.order[j] })
+	for _, chair := range x.order {
+		seat := x.seats[chair]
+		x.linef("Seat %d: %s (%d in chips)", chair+1, seat.name, seat.stack)
+	}
+
+	if ante := x.start.GetAnteAmount(); ante > 0 {
+		for _, chair := range x.order {
+			x.post(chair, "the ante", ante, false)
+		}
+	}
+	x.post(x.start.GetSmallBlindChair(), "small blind", x.start.GetSmallBlindAmount(), true)
+	x.post(x.start.GetBigBlindChair(), "big blind", x.start.GetBigBlindAmount(), true)
+	if x.start.GetStraddleAmount() > 0 {
+		x.post(x.start.GetStraddleChair(), "straddle", x.start.GetStraddleAmount(), true)
+	}
+	x.linef("*** HOLE CARDS ***")
+}
+
+// post takes a forced bet, capped by the player's stack. Antes go straight
+// to the pot; blinds and straddles count as the player's bet.
+func (x *hhExport) post(chair uint32, what string, amount int64, live bool) {
+	seat := x.seats[chair]
+	if seat == nil || amount <= 0 {
+		return
+	}
+	if amount > seat.stack {
+		amount = seat.stack
+	}
+	seat.stack -= amount
+	line := fmt.Sprintf("%s: posts %s %d", seat.name, what, amount)
+	if seat.stack == 0 {
+		line += " and is all-in"
+	}
+	x.linef("%s", line)
+	if live {
+		seat.bet += amount
+		if seat.bet > x.curBet {
+			x.curBet = seat.bet
+		}
+	}
+}
+
+func (x *hhExport) action(ar *pb.ActionResult) {
+	seat := x.seats[ar.GetChair()]
+	if seat == nil {
+		return
+	}
+	// Amount reads 0 once an action closes the street and bets are swept
+	// into the pot, so the chips put in come from the stack change.
+	put := seat.stack - ar.GetNewStack()
+	if put < 0 {
+		put = 0
+	}
+	to := seat.bet + put
+	line := ""
+	switch ar.GetAction() {
+	case pb.ActionType_ACTION_FOLD:
+		seat.folded, seat.foldedOn = true, x.street
+		line = "folds"
+	case pb.ActionType_ACTION_CHECK:
+		line = "checks"
+	case pb.ActionType_ACTION_CALL:
+		line = fmt.Sprintf("calls %d", put)
+	case pb.ActionType_ACTION_BET:
+		line = fmt.Sprintf("bets %d", to)
+	case pb.ActionType_ACTION_RAISE:
+		line = fmt.Sprintf("raises %d to %d", to-x.curBet, to)
+	case pb.ActionType_ACTION_ALLIN:
+		switch {
+		case to <= x.curBet:
+			line = fmt.Sprintf("calls %d", put)
+		case x.curBet == 0:
+			line = fmt.Sprintf("bets %d", to)
+		default:
+			line = fmt.Sprintf("raises %d to %d", to-x.curBet, to)
+		}
+	}
+	seat.stack -= put
+	seat.bet = to
+	if to > x.curBet {
+		x.curBet = to
+	}
+	if seat.stack == 0 && put > 0 {
+		line += " and is all-in"
+	}
+	x.linef("%s: %s", seat.name, line)
+}
+
+func (x *hhExport) deal(db *pb.DealBoard) error {
+	cards, err := protoCardStrings(db.GetCards())
+	if err != nil {
+		return err
+	}
+	prev := strings.Join(x.board, " ")
+	x.board = append(x.board, cards...)
+	switch db.GetPhase() {
+	case pb.Phase_PHASE_FLOP:
+		x.street = "Flop"
+		x.linef("*** FLOP *** [%s]", strings.Join(x.board, " "))
+	case pb.Phase_PHASE_TURN:
+		x.street = "Turn"
+		x.linef("*** TURN *** [%s] [%s]", prev, strings.Join(cards, " "))
+	case pb.Phase_PHASE_RIVER:
+		x.street = "River"
+		x.linef("*** RIVER *** [%s] [%s]", prev, strings.Join(cards, " "))
+	}
+	x.curBet = 0
+	for _, seat := range x.seats {
+		seat.bet = 0
+	}
+	return nil
+}
+
+func (x *hhExport) showdown(sd *pb.Showdown) error {
+	x.refund(sd.GetExcessRefund())
+	x.linef("*** SHOW DOWN ***")
+	for _, hand := range sd.GetHands() {
+		seat := x.seats[hand.GetChair()]
+		if seat == nil {
+			continue
+		}
+		cards, err := protoCardStrings(hand.GetHoleCards())
+		if err != nil {
+			return err
+		}
+		seat.shown = hand
+		x.linef("%s: shows [%s] (%s)", seat.name, strings.Join(cards, " "), describeHand(hand))
+	}
+	x.pots = sd.GetPotResults()
+	for i, pot := range x.pots {
+		x.potTotal += pot.GetPotAmount()
+		for _, w := range pot.GetWinners() {
+			if seat := x.seats[w.GetChair()]; seat != nil {
+				seat.won += w.GetWinAmount()
+			}
+			x.linef("%s collected %d from %s", x.name(w.GetChair()), w.GetWinAmount(), potName(i, len(x.pots)))
+		}
+	}
+	return nil
+}
+
+// refund reports the uncalled part of the last bet once, wherever the tape
+// first carries it.
+func (x *hhExport) refund(r *pb.ExcessRefund) {
+	if r == nil || r.GetAmount() <= 0 || x.refunded {
+		return
+	}
+	x.refunded = true
+	if seat := x.seats[r.GetChair()]; seat != nil {
+		seat.stack += r.GetAmount()
+	}
+	x.linef("Uncalled bet (%d) returned to %s", r.GetAmount(), x.name(r.GetChair()))
+}
+
+func (x *hhExport) summary() {
+	x.linef("*** SUMMARY ***")
+	total := fmt.Sprintf("Total pot %d", x.potTotal)
+	if len(x.pots) > 1 {
+		for i, pot := range x.pots {
+			total += fmt.Sprintf(" %s %d.", upperFirst(potName(i, len(x.pots))), pot.GetPotAmount())
+		}
+	}
+	x.linef("%s | Rake %d", total, x.rake)
+	if len(x.board) > 0 {
+		x.linef("Board [%s]", strings.Join(x.board, " "))
+	}
+
+	for _, chair := range x.order {
+		seat := x.seats[chair]
+		line := fmt.Sprintf("Seat %d: %s", chair+1, seat.name)
+		switch chair {
+		case x.start.GetDealerChair():
+			line += " (button)"
+		case x.start.GetSmallBlindChair():
+			line += " (small blind)"
+		case x.start.GetBigBlindChair():
+			line += " (big blind)"
+		}
+		switch {
+		case seat.folded && seat.foldedOn == "":
+			line += " folded before Flop"
+		case seat.folded:
+			line += " folded on the " + seat.foldedOn
+		case seat.shown != nil:
+			cards, _ := protoCardStrings(seat.shown.GetHoleCards())
+			line += fmt.Sprintf(" showed [%s] and ", strings.Join(cards, " "))
+			if seat.won > 0 {
+				line += fmt.Sprintf("won (%d) with %s", seat.won, describeHand(seat.shown))
+			} else {
+				line += "lost with " + describeHand(seat.shown)
+			}
+		case seat.won > 0:
+			line += fmt.Sprintf(" collected (%d)", seat.won)
+		default:
+			line += " mucked"
+		}
+		x.linef("%s", line)
+	}
+}
+
+func (x *hhExport) name(chair uint32) string {
+	if seat := x.seats[chair]; seat != nil {
+		return seat.name
+	}
+	return fmt.Sprintf("Player%d", chair+1)
+}
+
+func (x *hhExport) linef(format string, args ...any) {
+	fmt.Fprintf(&x.out, format, args...)
+	x.out.WriteByte('\n')
+}
+
+func potName(i, n int) string {
+	switch {
+	case n == 1:
+		return "pot"
+	case i == 0:
+		return "main pot"
+	case n == 2:
+		return "side pot"
+	}
+	return fmt.Sprintf("side pot-%d", i)
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var (
+	rankSingular = [...]string{2: "Deuce", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"}
+	rankPlural   = [...]string{2: "Deuces", "Threes", "Fours", "Fives", "Sixes", "Sevens", "Eights", "Nines", "Tens", "Jacks", "Queens", "Kings", "Aces"}
+)
+
+// describeHand names a shown hand the way PokerStars does, e.g. "two pair,
+// Aces and Sevens", from its rank and best five cards.
+func describeHand(hand *pb.ShowdownHand) string {
+	counts := make(map[int]int, 5)
+	var ranks []int
+	for _, c := range hand.GetBestFive() {
+		r := int(c.GetRank())
+		if r < 2 || r > 14 {
+			continue
+		}
+		if counts[r] == 0 {
+			ranks = append(ranks, r)
+		}
+		counts[r]++
+	}
+	// Most copies first, then highest rank.
+	sort.Slice(ranks, func(i, j int) bool {
+		if counts[ranks[i]] != counts[ranks[j]] {
+			return counts[ranks[i]] > counts[ranks[j]]
+		}
+		return ranks[i] > ranks[j]
+	})
+	if len(ranks) == 0 {
+		return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(hand.GetRank().String(), "HAND_RANK_"), "_", " "))
+	}
+	top := ranks[0]
+	straightHigh := top
+	if len(ranks) == 5 && ranks[0] == 14 && ranks[1] == 5 {
+		straightHigh = 5 // the wheel
+	}
+	switch hand.GetRank() {
+	case pb.HandRank_HAND_RANK_ONE_PAIR:
+		return "a pair of " + rankPlural[top]
+	case pb.HandRank_HAND_RANK_TWO_PAIR:
+		return fmt.Sprintf("two pair, %s and %s", rankPlural[ranks[0]], rankPlural[ranks[1]])
+	case pb.HandRank_HAND_RANK_THREE_OF_KIND:
+		return "three of a kind, " + rankPlural[top]
+	case pb.HandRank_HAND_RANK_STRAIGHT:
+		return fmt.Sprintf("a straight, %s to %s", straightLow(straightHigh), rankSingular[straightHigh])
+	case pb.HandRank_HAND_RANK_FLUSH:
+		return fmt.Sprintf("a flush, %s high", rankSingular[top])
+	case pb.HandRank_HAND_RANK_FULL_HOUSE:
+		return fmt.Sprintf("a full house, %s full of %s", rankPlural[ranks[0]], rankPlural[ranks[1]])
+	case pb.HandRank_HAND_RANK_FOUR_OF_KIND:
+		return "four of a kind, " + rankPlural[top]
+	case pb.HandRank_HAND_RANK_STRAIGHT_FLUSH:
+		return fmt.Sprintf("a straight flush, %s to %s", straightLow(straightHigh), rankSingular[straightHigh])
+	case pb.HandRank_HAND_RANK_ROYAL_FLUSH:
+		return "a Royal Flush"
+	}
+	return "high card " + rankSingular[top]
+}
+
+func straightLow(high int) string {
+	if high == 5 {
+		return rankSingular[14]
+	}
+	return rankSingular[high-4]
+}
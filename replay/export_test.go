@@ -0,0 +1,93 @@
+package replay
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// sidePotHandSpec plays three streets: the short stack is all-in on the
+// flop, the other two build a side pot and check the river down.
+func sidePotHandSpec() HandSpec {
+	spec := baseHandSpec()
+	spec.Seats[1].Stack = 3000
+	spec.Actions = []ActionSpec{
+		{Phase: "PREFLOP", Chair: 0, Type: "RAISE", AmountTo: 300},
+		{Phase: "PREFLOP", Chair: 2, Type: "CALL", AmountTo: 300},
+		{Phase: "PREFLOP", Chair: 4, Type: "CALL", AmountTo: 300},
+		{Phase: "FLOP", Chair: 2, Type: "CHECK", AmountTo: 0},
+		{Phase: "FLOP", Chair: 4, Type: "BET", AmountTo: 600},
+		{Phase: "FLOP", Chair: 0, Type: "RAISE", AmountTo: 1800},
+		{Phase: "FLOP", Chair: 2, Type: "ALLIN", AmountTo: 2700},
+		{Phase: "FLOP", Chair: 4, Type: "CALL", AmountTo: 2700},
+		{Phase: "FLOP", Chair: 0, Type: "CALL", AmountTo: 2700},
+		{Phase: "TURN", Chair: 4, Type: "BET", AmountTo: 3000},
+		{Phase: "TURN", Chair: 0, Type: "CALL", AmountTo: 3000},
+		{Phase: "RIVER", Chair: 4, Type: "CHECK", AmountTo: 0},
+		{Phase: "RIVER", Chair: 0, Type: "CHECK", AmountTo: 0},
+	}
+	return spec
+}
+
+func TestExportHandHistory_MatchesGolden(t *testing.T) {
+	tape, err := GenerateReplayTape(sidePotHandSpec())
+	if err != nil {
+		t.Fatalf("GenerateReplayTape failed: %v", err)
+	}
+	got, err := ExportHandHistory(tape)
+	if err != nil {
+		t.Fatalf("ExportHandHistory failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "side_pot_showdown.txt")
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatalf("mkdir testdata: %v", err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Fatalf("hand history differs from %s:\n--- got ---\n%s--- want ---\n%s", golden, got, want)
+	}
+}
+
+func TestExportHandHistory_WinByFold(t *testing.T) {
+	tape, err := GenerateReplayTape(baseHandSpec())
+	if err != nil {
+		t.Fatalf("GenerateReplayTape failed: %v", err)
+	}
+	got, err := ExportHandHistory(tape)
+	if err != nil {
+		t.Fatalf("ExportHandHistory failed: %v", err)
+	}
+	for _, line := range []string{
+		"P2: bets 150",
+		"Uncalled bet (150) returned to P2",
+		"P2 collected 300 from pot",
+		"Total pot 300 | Rake 0",
+		"Seat 1: YOU (button) folded on the Flop",
+		"Seat 5: P2 (big blind) collected (300)",
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Fatalf("expected line %q in:\n%s", line, got)
+		}
+	}
+	if strings.Contains(got, "SHOW DOWN") {
+		t.Fatalf("expected no showdown for a hand won by a fold:\n%s", got)
+	}
+
+	tape.Events = tape.Events[:len(tape.Events)-1]
+	if _, err := ExportHandHistory(tape); err == nil {
+		t.Fatal("expected an error for a tape cut before the hand end")
+	}
+}
@@ -14,28 +14,28 @@ import (
 const defaultTableID = "replay_local"
 
 func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
+	tape, _, err := generateTape(spec)
+	return tape, err
+}
+
+// generateTape builds the tape and also returns the engine, left at the stop
+// prompt for StopAtStep specs.
+func generateTape(spec HandSpec) (*ReplayTape, *holdem.Game, error) {
 	ns, err := normalizeSpec(spec)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	game, err := holdem.NewGame(holdem.Config{
-		MaxPlayers:        int(ns.table.MaxPlayers),
-		MinPlayers:        2,
-		SmallBlind:        ns.table.SB,
-		BigBlind:          ns.table.BB,
-		Ante:              ns.table.Ante,
-		Seed:              seedFromSpec(spec.RNG),
-		ForcedDealerChair: &ns.dealerChair,
-		DeckOverride:      ns.deck,
-	})
+	game, err := newReplayGame(ns, seedFromSpec(spec.RNG))
 	if err != nil {
-		return nil, &ReplayError{StepIndex: -1, Reason: "engine_init_failed", Message: err.Error()}
+		return nil, nil, err
 	}
 
-	for _, seat := range ns.seats {
-		if err := game.SitDown(seat.chair, seat.userID, seat.stack, false); err != nil {
-			return nil, &ReplayError{StepIndex: -1, Reason: "seat_init_failed", Message: err.Error()}
+	if spec.StopAtStep != nil && (*spec.StopAtStep < 0 || *spec.StopAtStep > len(ns.actions)) {
+		return nil, nil, &ReplayError{
+			StepIndex: -1,
+			Reason:    "invalid_stop_step",
+			Message:   fmt.Sprintf("stop_at_step %d is outside 0..%d", *spec.StopAtStep, len(ns.actions)),
 		}
 	}
 
@@ -48,9 +48,15 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 	builder.addSnapshot(toTableSnapshot(beforeStart, ns))
 
 	if err := game.StartHand(); err != nil {
-		return nil, &ReplayError{StepIndex: -1, Reason: "start_hand_failed", Message: err.Error()}
+		return nil, nil, &ReplayError{StepIndex: -1, Reason: "start_hand_failed", Message: err.Error()}
 	}
 	afterStart := game.Snapshot()
+	straddleChair := uint32(0)
+	if afterStart.StraddleBet() > 0 {
+		straddleChair = uint32(afterStart.StraddleChair)
+	} else if ns.straddleChair != holdem.InvalidChair {
+		return nil, nil, &ReplayError{StepIndex: -1, Reason: "invalid_straddle", Message: fmt.Sprintf("chair %d cannot straddle this hand", ns.straddleChair)}
+	}
 	builder.addHandStart(&pb.HandStart{
 		Round:            uint32(afterStart.Round),
 		DealerChair:      uint32(afterStart.DealerChair),
@@ -58,6 +64,10 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 		BigBlindChair:    uint32(afterStart.BigBlindChair),
 		SmallBlindAmount: ns.table.SB,
 		BigBlindAmount:   ns.table.BB,
+		AnteAmount:       ns.table.Ante,
+		StraddleChair:    straddleChair,
+		StraddleAmount:   afterStart.StraddleBet(),
+		ForcedTotal:      afterStart.PotTotal(),
 	})
 	if heroCards := heroHoleCards(afterStart, ns.heroChair); len(heroCards) == 2 {
 		builder.addHoleCards(&pb.DealHoleCards{Cards: cardsToProto(heroCards)})
@@ -65,22 +75,26 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 	if afterStart.ActionChair != holdem.InvalidChair {
 		prompt, err := buildActionPrompt(game, afterStart.ActionChair)
 		if err != nil {
-			return nil, &ReplayError{StepIndex: -1, Reason: "prompt_build_failed", Message: err.Error()}
+			return nil, nil, &ReplayError{StepIndex: -1, Reason: "prompt_build_failed", Message: err.Error()}
 		}
 		builder.addActionPrompt(prompt)
 	}
 
 	for stepIdx, action := range ns.actions {
+		if spec.StopAtStep != nil && *spec.StopAtStep == stepIdx {
+			tape, err := stopAtDecision(builder, game, spec, stepIdx)
+			return tape, game, err
+		}
 		before := game.Snapshot()
 		if before.ActionChair == holdem.InvalidChair {
-			return nil, &ReplayError{
+			return nil, nil, &ReplayError{
 				StepIndex: int32(stepIdx),
 				Reason:    "no_action_expected",
 				Message:   "hand is already complete; no further actions are allowed",
 			}
 		}
 		if before.Phase != action.phase {
-			return nil, &ReplayError{
+			return nil, nil, &ReplayError{
 				StepIndex: int32(stepIdx),
 				Reason:    "phase_mismatch",
 				Message:   fmt.Sprintf("expected phase %s, got %s", phaseName(before.Phase), phaseName(action.phase)),
@@ -93,7 +107,7 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 		if before.ActionChair != action.chair {
 			expected := expectedStateForChair(game, before.ActionChair)
 			expected.Phase = phaseName(before.Phase)
-			return nil, &ReplayError{
+			return nil, nil, &ReplayError{
 				StepIndex: int32(stepIdx),
 				Reason:    "out_of_turn",
 				Message:   fmt.Sprintf("expected action chair %d, got %d", before.ActionChair, action.chair),
@@ -103,7 +117,7 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 		if !isLegalAction(game, action.chair, action.action) {
 			expected := expectedStateForChair(game, action.chair)
 			expected.Phase = phaseName(before.Phase)
-			return nil, &ReplayError{
+			return nil, nil, &ReplayError{
 				StepIndex: int32(stepIdx),
 				Reason:    "illegal_action",
 				Message:   fmt.Sprintf("action %s is not legal for chair %d", actionName(action.action), action.chair),
@@ -115,7 +129,7 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 		if err != nil {
 			expected := expectedStateForChair(game, action.chair)
 			expected.Phase = phaseName(before.Phase)
-			return nil, &ReplayError{
+			return nil, nil, &ReplayError{
 				StepIndex: int32(stepIdx),
 				Reason:    "action_apply_failed",
 				Message:   err.Error(),
@@ -138,7 +152,7 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 		if after.ActionChair != holdem.InvalidChair {
 			prompt, err := buildActionPrompt(game, after.ActionChair)
 			if err != nil {
-				return nil, &ReplayError{
+				return nil, nil, &ReplayError{
 					StepIndex: int32(stepIdx),
 					Reason:    "prompt_build_failed",
 					Message:   err.Error(),
@@ -148,16 +162,87 @@ func GenerateReplayTape(spec HandSpec) (*ReplayTape, error) {
 		}
 	}
 
-	return &ReplayTape{
-		TapeVersion: 1,
-		TableID:     builder.tableID,
-		HeroChair:   ns.heroChair,
-		Events:      builder.events,
-	}, nil
+	if spec.StopAtStep != nil {
+		if *spec.StopAtStep == len(ns.actions) {
+			tape, err := stopAtDecision(builder, game, spec, len(ns.actions))
+			return tape, game, err
+		}
+		return nil, nil, &ReplayError{
+			StepIndex: int32(*spec.StopAtStep),
+			Reason:    "not_a_decision_point",
+			Message:   "hand ended before the stop step",
+		}
+	}
+
+	return builder.tape(), game, nil
+}
+
+// stopAtDecision ends a StopAtStep tape at the pending prompt for stepIdx.
+func stopAtDecision(builder *tapeBuilder, game *holdem.Game, spec HandSpec, stepIdx int) (*ReplayTape, error) {
+	snap := game.Snapshot()
+	if snap.Ended || snap.ActionChair == holdem.InvalidChair {
+		return nil, &ReplayError{
+			StepIndex: int32(stepIdx),
+			Reason:    "not_a_decision_point",
+			Message:   "no player is to act at the stop step",
+		}
+	}
+	expected := expectedStateForChair(game, snap.ActionChair)
+	expected.Phase = phaseName(snap.Phase)
+	decision := &DecisionPoint{
+		StepIndex:     int32(stepIdx),
+		ExpectedState: *expected,
+		CurBet:        snap.CurBet,
+	}
+	for _, pot := range snap.Pots {
+		decision.Pot += pot.Amount
+	}
+	for _, ps := range snap.Players {
+		decision.Pot += ps.Bet
+	}
+	board, err := protoCardStrings(cardsToProto(snap.CommunityCards))
+	if err != nil {
+		return nil, &ReplayError{StepIndex: int32(stepIdx), Reason: "invalid_board_card", Message: err.Error()}
+	}
+	decision.Board = board
+	if stepIdx < len(spec.Actions) {
+		recorded := spec.Actions[stepIdx]
+		decision.Recorded = &recorded
+	}
+
+	tape := builder.tape()
+	tape.Decision = decision
+	return tape, nil
+}
+
+func newReplayGame(ns normalizedSpec, seed int64) (*holdem.Game, error) {
+	game, err := holdem.NewGame(holdem.Config{
+		MaxPlayers:        int(ns.table.MaxPlayers),
+		MinPlayers:        2,
+		SmallBlind:        ns.table.SB,
+		BigBlind:          ns.table.BB,
+		Ante:              ns.table.Ante,
+		Seed:              seed,
+		ForcedDealerChair: &ns.dealerChair,
+		DeckOverride:      ns.deck,
+	})
+	if err != nil {
+		return nil, &ReplayError{StepIndex: -1, Reason: "engine_init_failed", Message: err.Error()}
+	}
+
+	for _, seat := range ns.seats {
+		if err := game.SitDown(seat.chair, seat.userID, seat.stack, false); err != nil {
+			return nil, &ReplayError{StepIndex: -1, Reason: "seat_init_failed", Message: err.Error()}
+		}
+	}
+	if ns.straddleChair != holdem.InvalidChair {
+		game.SetStraddle(ns.straddleChair)
+	}
+	return game, nil
 }
 
 func isLegalAction(g *holdem.Game, chair uint16, action holdem.ActionType) bool {
-	actions, _, err := g.LegalActions(chair)
+	actions, _, _, err := g.LegalActions(chair)
 	if err != nil {
 		return false
 	}
@@ -170,7 +255,7 @@ func isLegalAction(g *holdem.Game, chair uint16, action holdem.ActionType) bool
 }
 
 func expectedStateForChair(g *holdem.Game, chair uint16) *ExpectedState {
-	actions, minRaiseTo, err := g.LegalActions(chair)
+	actions, minRaiseTo, _, err := g.LegalActions(chair)
 	if err != nil {
 		return &ExpectedState{ActionChair: chair}
 	}
@@ -198,7 +283,7 @@ func expectedStateForChair(g *holdem.Game, chair uint16) *ExpectedState {
 }
 
 func buildActionPrompt(g *holdem.Game, chair uint16) (*pb.ActionPrompt, error) {
-	actions, minRaiseTo, err := g.LegalActions(chair)
+	actions, minRaiseTo, _, err := g.LegalActions(chair)
 	if err != nil {
 		return nil, err
 	}
@@ -272,6 +357,15 @@ func newTapeBuilder(tableID string, hero uint16) *tapeBuilder {
 	}
 }
 
+func (b *tapeBuilder) tape() *ReplayTape {
+	return &ReplayTape{
+		TapeVersion: CurrentTapeVersion,
+		TableID:     b.tableID,
+		HeroChair:   b.hero,
+		Events:      b.events,
+	}
+}
+
 func (b *tapeBuilder) addSnapshot(snapshot *pb.TableSnapshot) {
 	b.pushEnvelope(&pb.ServerEnvelope{Payload: &pb.ServerEnvelope_TableSnapshot{TableSnapshot: snapshot}})
 }
@@ -93,3 +93,48 @@ func baseHandSpec() HandSpec {
 		RNG: &RNGSpec{Seed: 42},
 	}
 }
+
+func TestGenerateReplayTape_HeroChairOverrideRevealsOnlyThatSeat(t *testing.T) {
+	spec := baseHandSpec()
+	holes := map[uint16][]string{}
+	for _, seat := range spec.Seats {
+		holes[seat.Chair] = seat.Hole
+	}
+
+	for _, hero := range []uint16{2, 4} {
+		hero := hero
+		spec.HeroChair = &hero
+		tape, err := GenerateReplayTape(spec)
+		if err != nil {
+			t.Fatalf("hero %d: GenerateReplayTape failed: %v", hero, err)
+		}
+		if tape.HeroChair != hero {
+			t.Fatalf("expected tape hero chair %d, got %d", hero, tape.HeroChair)
+		}
+
+		// Hole cards reach the tape through the snapshot and DealHoleCards.
+		var revealed []string
+		for _, e := range tape.Events {
+			if snap := e.Value.GetTableSnapshot(); snap != nil {
+				for _, ps := range snap.GetPlayers() {
+					cards, _ := protoCardStrings(ps.GetHandCards())
+					revealed = append(revealed, cards...)
+				}
+			}
+			if hole := e.Value.GetDealHoleCards(); hole != nil {
+				cards, _ := protoCardStrings(hole.GetCards())
+				revealed = append(revealed, cards...)
+			}
+		}
+		if !reflect.DeepEqual(revealed, holes[hero]) {
+			t.Fatalf("hero %d: tape revealed %v, want only %v", hero, revealed, holes[hero])
+		}
+	}
+
+	unseated := uint16(3)
+	spec.HeroChair = &unseated
+	_, err := GenerateReplayTape(spec)
+	if replayErr, ok := err.(*ReplayError); !ok || replayErr.Reason != "invalid_hero" {
+		t.Fatalf("expected an unseated hero_chair to fail with invalid_hero, got %v", err)
+	}
+}
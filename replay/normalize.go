@@ -32,6 +32,7 @@ type normalizedSpec struct {
 	seats          []normalizedSeat
 	seatByChair    map[uint16]normalizedSeat
 	heroChair      uint16
+	straddleChair  uint16
 	deck           []card.Card
 	actions        []normalizedAction
 	handStartStack map[uint16]int64
@@ -112,10 +113,24 @@ func normalizeSpec(spec HandSpec) (normalizedSpec, error) {
 	} else if heroCount > 1 {
 		return out, &ReplayError{StepIndex: -1, Reason: "invalid_hero", Message: "multiple seats marked as hero"}
 	}
+	if spec.HeroChair != nil {
+		if _, ok := out.seatByChair[*spec.HeroChair]; !ok {
+			return out, &ReplayError{StepIndex: -1, Reason: "invalid_hero", Message: fmt.Sprintf("hero_chair %d has no seat", *spec.HeroChair)}
+		}
+		out.heroChair = *spec.HeroChair
+	}
 	if !containsChair(activeChairs, out.heroChair) {
 		return out, &ReplayError{StepIndex: -1, Reason: "invalid_hero", Message: "hero seat must be active"}
 	}
 
+	out.straddleChair = holdem.InvalidChair
+	if spec.StraddleChair != nil {
+		if !containsChair(activeChairs, *spec.StraddleChair) {
+			return out, &ReplayError{StepIndex: -1, Reason: "invalid_straddle", Message: fmt.Sprintf("straddle_chair %d has no active seat", *spec.StraddleChair)}
+		}
+		out.straddleChair = *spec.StraddleChair
+	}
+
 	boardCards, err := parseBoard(spec.Board)
 	if err != nil {
 		return out, err
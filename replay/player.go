@@ -0,0 +1,247 @@
+package replay
+
+import (
+	"fmt"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Player steps through a ReplayTape event by event, keeping the table as the
+// hero saw it so far: the hero's hole cards from the tape, everyone else's
+// only once shown down. It lets a UI scrub a tape without regenerating it.
+type Player struct {
+	tape  *ReplayTape
+	next  int // index of the next event to apply
+	state *pb.TableSnapshot
+}
+
+// NewPlayer returns a Player positioned before the tape's first event. The
+// tape must open with a table snapshot, as GenerateReplayTape's do.
+func NewPlayer(tape *ReplayTape) (*Player, error) {
+	if tape == nil || len(tape.Events) == 0 || tape.Events[0].Value.GetTableSnapshot() == nil {
+		return nil, &ReplayError{StepIndex: -1, Reason: "missing_snapshot", Message: "tape does not open with a table snapshot"}
+	}
+	return &Player{tape: tape}, nil
+}
+
+// Seq is the seq of the last event applied, 0 before the first.
+func (p *Player) Seq() uint64 {
+	if p.next == 0 {
+		return 0
+	}
+	return p.tape.Events[p.next-1].Seq
+}
+
+// StepForward applies the next event and returns it, or returns false at the
+// end of the tape.
+func (p *Player) StepForward() (ReplayEvent, bool) {
+	if p.next >= len(p.tape.Events) {
+		return ReplayEvent{}, false
+	}
+	event := p.tape.Events[p.next]
+	p.apply(event.Value)
+	p.next++
+	return event, true
+}
+
+// StepTo moves to just after the event numbered seq, going forward from the
+// current position or, for an earlier seq, replaying from the start. Seq 0
+// rewinds to before the first event.
+func (p *Player) StepTo(seq uint64) error {
+	if seq == 0 {
+		p.next, p.state = 0, nil
+		return nil
+	}
+	last := p.tape.Events[len(p.tape.Events)-1].Seq
+	if seq > last {
+		return &ReplayError{StepIndex: -1, Reason: "invalid_seq", Message: fmt.Sprintf("seq %d is past the tape end at %d", seq, last)}
+	}
+	if seq < p.Seq() {
+		p.next, p.state = 0, nil
+	}
+	for p.Seq() < seq {
+		if _, ok := p.StepForward(); !ok {
+			break
+		}
+	}
+	return nil
+}
+
+// CurrentState returns a copy of the table as of the last applied event, or
+// nil before the first.
+func (p *Player) CurrentState() *pb.TableSnapshot {
+	if p.state == nil {
+		return nil
+	}
+	return proto.Clone(p.state).(*pb.TableSnapshot)
+}
+
+func (p *Player) apply(env *pb.ServerEnvelope) {
+	if snap := env.GetTableSnapshot(); snap != nil {
+		p.state = proto.Clone(snap).(*pb.TableSnapshot)
+		return
+	}
+	s := p.state
+	if s == nil {
+		return
+	}
+	switch payload := env.GetPayload().(type) {
+	case *pb.ServerEnvelope_HandStart:
+		p.startHand(payload.HandStart)
+	case *pb.ServerEnvelope_DealHoleCards:
+		if hero := p.player(uint32(p.tape.HeroChair)); hero != nil {
+			hero.HandCards = cloneCards(payload.DealHoleCards.GetCards())
+		}
+	case *pb.ServerEnvelope_ActionPrompt:
+		prompt := payload.ActionPrompt
+		s.ActionChair = prompt.GetChair()
+		if prompt.GetMinRaiseTo() > s.CurBet {
+			s.MinRaiseDelta = prompt.GetMinRaiseTo() - s.CurBet
+		}
+	case *pb.ServerEnvelope_ActionResult:
+		p.applyAction(payload.ActionResult)
+	case *pb.ServerEnvelope_DealBoard:
+		s.CommunityCards = append(s.CommunityCards, cloneCards(payload.DealBoard.GetCards())...)
+	case *pb.ServerEnvelope_PhaseChange:
+		change := payload.PhaseChange
+		s.Phase = change.GetPhase()
+		s.CommunityCards = cloneCards(change.GetCommunityCards())
+		s.Pots = clonePots(change.GetPots())
+		p.collectBets()
+	case *pb.ServerEnvelope_PotUpdate:
+		s.Pots = clonePots(payload.PotUpdate.GetPots())
+	case *pb.ServerEnvelope_Showdown:
+		for _, hand := range payload.Showdown.GetHands() {
+			if ps := p.player(hand.GetChair()); ps != nil {
+				ps.HandCards = cloneCards(hand.GetHoleCards())
+			}
+		}
+	case *pb.ServerEnvelope_HandEnd:
+		for _, delta := range payload.HandEnd.GetStackDeltas() {
+			if ps := p.player(delta.GetChair()); ps != nil {
+				ps.Stack = delta.GetNewStack()
+			}
+		}
+		p.collectBets()
+		s.Pots = nil
+		s.ActionChair = uint32(holdem.InvalidChair)
+	}
+	p.updateToCall()
+}
+
+// startHand posts the forced bets HandStart announces: antes straight into
+// the pot, then the blinds and any straddle in front of their chairs.
+func (p *Player) startHand(start *pb.HandStart) {
+	s := p.state
+	s.Round = start.GetRound()
+	s.Phase = pb.Phase_PHASE_PREFLOP
+	s.DealerChair = start.GetDealerChair()
+	s.SmallBlindChair = start.GetSmallBlindChair()
+	s.BigBlindChair = start.GetBigBlindChair()
+	s.CommunityCards = nil
+	s.Pots = nil
+
+	var antes int64
+	ante := &pb.Pot{}
+	for _, ps := range s.Players {
+		ps.Bet, ps.Folded, ps.AllIn = 0, false, false
+		ps.LastAction = pb.ActionType_ACTION_UNSPECIFIED
+		ps.HasCards = ps.Stack > 0 && !ps.SittingOut
+		if !ps.HasCards {
+			continue
+		}
+		ante.EligibleChairs = append(ante.EligibleChairs, ps.Chair)
+		if amount := min(start.GetAnteAmount(), ps.Stack); amount > 0 {
+			ps.Stack -= amount
+			antes += amount
+			ps.AllIn = ps.Stack == 0
+		}
+	}
+	if antes > 0 {
+		ante.Amount = antes
+		s.Pots = []*pb.Pot{ante}
+	}
+
+	post := func(chair uint32, amount int64) {
+		ps := p.player(chair)
+		if ps == nil || amount <= 0 || ps.Stack == 0 {
+			return
+		}
+		amount = min(amount, ps.Stack)
+		ps.Stack -= amount
+		ps.Bet += amount
+		ps.AllIn = ps.Stack == 0
+		s.CurBet = max(s.CurBet, ps.Bet)
+	}
+	s.CurBet = 0
+	post(s.SmallBlindChair, start.GetSmallBlindAmount())
+	post(s.BigBlindChair, start.GetBigBlindAmount())
+	if start.GetStraddleAmount() > 0 {
+		post(start.GetStraddleChair(), start.GetStraddleAmount())
+	}
+	s.MinRaiseDelta = start.GetBigBlindAmount()
+}
+
+func (p *Player) applyAction(result *pb.ActionResult) {
+	s := p.state
+	ps := p.player(result.GetChair())
+	if ps == nil {
+		return
+	}
+	ps.LastAction = result.GetAction()
+	ps.Stack = result.GetNewStack()
+	ps.Bet = result.GetAmount()
+	switch result.GetAction() {
+	case pb.ActionType_ACTION_FOLD:
+		ps.Folded = true
+	default:
+		ps.AllIn = ps.Stack == 0
+	}
+	s.CurBet = max(s.CurBet, ps.Bet)
+	s.ActionChair = uint32(holdem.InvalidChair)
+}
+
+// collectBets clears the bets once a street's chips have gone to the pots.
+func (p *Player) collectBets() {
+	for _, ps := range p.state.Players {
+		ps.Bet = 0
+	}
+	p.state.CurBet = 0
+}
+
+func (p *Player) updateToCall() {
+	for _, ps := range p.state.Players {
+		ps.ToCall = 0
+		if !ps.Folded && !ps.AllIn && p.state.CurBet > ps.Bet {
+			ps.ToCall = min(p.state.CurBet-ps.Bet, ps.Stack)
+		}
+	}
+}
+
+func (p *Player) player(chair uint32) *pb.PlayerState {
+	for _, ps := range p.state.Players {
+		if ps.GetChair() == chair {
+			return ps
+		}
+	}
+	return nil
+}
+
+func cloneCards(cards []*pb.Card) []*pb.Card {
+	out := make([]*pb.Card, 0, len(cards))
+	for _, c := range cards {
+		out = append(out, proto.Clone(c).(*pb.Card))
+	}
+	return out
+}
+
+func clonePots(pots []*pb.Pot) []*pb.Pot {
+	out := make([]*pb.Pot, 0, len(pots))
+	for _, pot := range pots {
+		out = append(out, proto.Clone(pot).(*pb.Pot))
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package replay
+
+import (
+	"reflect"
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+func statePot(s *pb.TableSnapshot) int64 {
+	var total int64
+	for _, pot := range s.GetPots() {
+		total += pot.GetAmount()
+	}
+	for _, ps := range s.GetPlayers() {
+		total += ps.GetBet()
+	}
+	return total
+}
+
+func TestPlayer_StepToMatchesGeneratedDecisionPoints(t *testing.T) {
+	spec := baseHandSpec()
+	full, err := GenerateReplayTape(spec)
+	if err != nil {
+		t.Fatalf("GenerateReplayTape err: %v", err)
+	}
+	player, err := NewPlayer(full)
+	if err != nil {
+		t.Fatalf("NewPlayer err: %v", err)
+	}
+
+	// Seek forward and back: step 5 is mid-flop after a bet, step 1 preflop.
+	for _, step := range []int{5, 1, 4} {
+		stop := step
+		spec.StopAtStep = &stop
+		partial, err := GenerateReplayTape(spec)
+		if err != nil {
+			t.Fatalf("step %d: GenerateReplayTape err: %v", step, err)
+		}
+		seq := partial.Events[len(partial.Events)-1].Seq
+		if err := player.StepTo(seq); err != nil {
+			t.Fatalf("step %d: StepTo(%d) err: %v", step, seq, err)
+		}
+		if player.Seq() != seq {
+			t.Fatalf("step %d: expected to stop at seq %d, got %d", step, seq, player.Seq())
+		}
+
+		state := player.CurrentState()
+		want := partial.Decision
+		board, err := protoCardStrings(state.GetCommunityCards())
+		if err != nil {
+			t.Fatalf("step %d: board err: %v", step, err)
+		}
+		if got := statePot(state); got != want.Pot {
+			t.Fatalf("step %d: expected pot %d, got %d", step, want.Pot, got)
+		}
+		if len(board) != len(want.Board) || (len(board) > 0 && !reflect.DeepEqual(board, want.Board)) {
+			t.Fatalf("step %d: expected board %v, got %v", step, want.Board, board)
+		}
+		if state.GetCurBet() != want.CurBet || state.GetActionChair() != uint32(want.ActionChair) {
+			t.Fatalf("step %d: expected cur bet %d to chair %d, got %d to chair %d",
+				step, want.CurBet, want.ActionChair, state.GetCurBet(), state.GetActionChair())
+		}
+		for _, ps := range state.GetPlayers() {
+			if ps.GetChair() == uint32(want.ActionChair) && ps.GetToCall() != want.CallAmount {
+				t.Fatalf("step %d: expected chair %d to call %d, got %d", step, ps.GetChair(), want.CallAmount, ps.GetToCall())
+			}
+			if hasCards := len(ps.GetHandCards()) > 0; hasCards != (ps.GetChair() == uint32(full.HeroChair)) {
+				t.Fatalf("step %d: expected only the hero's hole cards, chair %d has %v", step, ps.GetChair(), ps.GetHandCards())
+			}
+		}
+	}
+}
+
+func TestPlayer_StepsToTheHandEnd(t *testing.T) {
+	spec := baseHandSpec()
+	tape, err := GenerateReplayTape(spec)
+	if err != nil {
+		t.Fatalf("GenerateReplayTape err: %v", err)
+	}
+	player, err := NewPlayer(tape)
+	if err != nil {
+		t.Fatalf("NewPlayer err: %v", err)
+	}
+	if player.CurrentState() != nil {
+		t.Fatalf("expected no state before the first event")
+	}
+	steps := 0
+	for {
+		if _, ok := player.StepForward(); !ok {
+			break
+		}
+		steps++
+	}
+	if steps != len(tape.Events) {
+		t.Fatalf("expected %d steps, got %d", len(tape.Events), steps)
+	}
+
+	var handEnd *pb.HandEnd
+	for _, e := range tape.Events {
+		if end := e.Value.GetHandEnd(); end != nil {
+			handEnd = end
+		}
+	}
+	state := player.CurrentState()
+	var chips int64
+	for _, ps := range state.GetPlayers() {
+		chips += ps.GetStack()
+		for _, delta := range handEnd.GetStackDeltas() {
+			if delta.GetChair() == ps.GetChair() && delta.GetNewStack() != ps.GetStack() {
+				t.Fatalf("chair %d: expected stack %d, got %d", ps.GetChair(), delta.GetNewStack(), ps.GetStack())
+			}
+		}
+	}
+	var seated int64
+	for _, seat := range spec.Seats {
+		seated += seat.Stack
+	}
+	if chips != seated || statePot(state) != 0 {
+		t.Fatalf("expected all %d chips back in stacks, got %d with %d still in play", seated, chips, statePot(state))
+	}
+
+	if err := player.StepTo(player.Seq() + 1); err == nil {
+		t.Fatalf("expected seeking past the tape end to fail")
+	}
+	if err := player.StepTo(0); err != nil || player.CurrentState() != nil {
+		t.Fatalf("expected StepTo(0) to rewind, got err=%v", err)
+	}
+	if _, err := NewPlayer(&ReplayTape{}); err == nil {
+		t.Fatalf("expected an empty tape to be refused")
+	}
+}
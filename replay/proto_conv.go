@@ -68,6 +68,52 @@ func actionName(a holdem.ActionType) string {
 	return "UNKNOWN"
 }
 
+func actionFromProto(a pb.ActionType) (holdem.ActionType, error) {
+	switch a {
+	case pb.ActionType_ACTION_CHECK:
+		return holdem.PlayerActionTypeCheck, nil
+	case pb.ActionType_ACTION_BET:
+		return holdem.PlayerActionTypeBet, nil
+	case pb.ActionType_ACTION_CALL:
+		return holdem.PlayerActionTypeCall, nil
+	case pb.ActionType_ACTION_RAISE:
+		return holdem.PlayerActionTypeRaise, nil
+	case pb.ActionType_ACTION_FOLD:
+		return holdem.PlayerActionTypeFold, nil
+	case pb.ActionType_ACTION_ALLIN:
+		return holdem.PlayerActionTypeAllin, nil
+	default:
+		return 0, fmt.Errorf("unsupported action type %s", a)
+	}
+}
+
+// protoCardStrings converts proto cards to the "As"/"Td" notation used by HandSpec.
+func protoCardStrings(cards []*pb.Card) ([]string, error) {
+	const ranks = "..23456789TJQKA"
+	out := make([]string, 0, len(cards))
+	for _, c := range cards {
+		r := int(c.GetRank())
+		if r < int(pb.Rank_RANK_2) || r > int(pb.Rank_RANK_A) {
+			return nil, fmt.Errorf("invalid card rank %s", c.GetRank())
+		}
+		var suit byte
+		switch c.GetSuit() {
+		case pb.Suit_SUIT_SPADE:
+			suit = 's'
+		case pb.Suit_SUIT_HEART:
+			suit = 'h'
+		case pb.Suit_SUIT_CLUB:
+			suit = 'c'
+		case pb.Suit_SUIT_DIAMOND:
+			suit = 'd'
+		default:
+			return nil, fmt.Errorf("invalid card suit %s", c.GetSuit())
+		}
+		out = append(out, string([]byte{ranks[r], suit}))
+	}
+	return out, nil
+}
+
 func heroHoleCards(snap holdem.Snapshot, heroChair uint16) []card.Card {
 	for _, ps := range snap.Players {
 		if ps.Chair == heroChair {
@@ -167,15 +213,17 @@ func buildShowdown(result *holdem.SettlementResult, snap holdem.Snapshot) *pb.Sh
 			Winners:   winners,
 		})
 	}
+	ranks := holdem.ShowdownRanks(result.PlayerResults)
 	for _, pr := range result.PlayerResults {
 		if pr.HandType == 0 {
 			continue
 		}
 		showdown.Hands = append(showdown.Hands, &pb.ShowdownHand{
-			Chair:     uint32(pr.Chair),
-			HoleCards: cardsToProto(pr.HandCards),
-			BestFive:  cardsToProto(pr.BestFiveCards),
-			Rank:      handRankToProto(pr.HandType),
+			Chair:        uint32(pr.Chair),
+			HoleCards:    cardsToProto(pr.HandCards),
+			BestFive:     cardsToProto(pr.BestFiveCards),
+			Rank:         handRankToProto(pr.HandType),
+			ShowdownRank: uint32(ranks[pr.Chair]),
 		})
 	}
 	if len(showdown.Hands) == 0 && len(showdown.PotResults) == 0 && len(showdown.NetResults) == 0 && showdown.ExcessRefund == nil {
@@ -0,0 +1,206 @@
+package replay
+
+import (
+	"fmt"
+
+	pb "holdem-lite/apps/server/gen"
+	"holdem-lite/holdem"
+)
+
+// ReconstructHandSpec rebuilds a HandSpec from a recorded per-user event
+// stream (bootstrap snapshot, handStart, hole cards, board, action results,
+// showdown). Opponent hole cards are only known when shown down; unknown
+// cards are filled by the deck builder, which does not change a hand that
+// ended without showing them. Blinds, antes and any straddle are taken from
+// handStart, so big-blind-only hands and tournament levels replay as dealt.
+// Live tables are always no-limit with per-player antes; a stream whose
+// forced bets do not add up to that (a big-blind ante, say) is rejected
+// rather than rebuilt with the wrong pots.
+func ReconstructHandSpec(events []*pb.ServerEnvelope, heroUserID uint64) (HandSpec, error) {
+	var (
+		snapshot  *pb.TableSnapshot
+		handStart *pb.HandStart
+		heroHole  []*pb.Card
+		board     []*pb.Card
+		results   []*pb.ActionResult
+	)
+	shown := make(map[uint32][]*pb.Card)
+	for _, env := range events {
+		switch p := env.GetPayload().(type) {
+		case *pb.ServerEnvelope_TableSnapshot:
+			if snapshot == nil {
+				snapshot = p.TableSnapshot
+			}
+		case *pb.ServerEnvelope_HandStart:
+			if handStart == nil {
+				handStart = p.HandStart
+			}
+		case *pb.ServerEnvelope_DealHoleCards:
+			heroHole = p.DealHoleCards.GetCards()
+		case *pb.ServerEnvelope_DealBoard:
+			board = append(board, p.DealBoard.GetCards()...)
+		case *pb.ServerEnvelope_ActionResult:
+			results = append(results, p.ActionResult)
+		case *pb.ServerEnvelope_Showdown:
+			for _, h := range p.Showdown.GetHands() {
+				shown[h.GetChair()] = h.GetHoleCards()
+			}
+		}
+	}
+	if snapshot == nil {
+		return HandSpec{}, &ReplayError{StepIndex: -1, Reason: "missing_snapshot", Message: "event stream has no table snapshot"}
+	}
+	if handStart == nil {
+		return HandSpec{}, &ReplayError{StepIndex: -1, Reason: "missing_hand_start", Message: "event stream has no handStart"}
+	}
+
+	cfg := snapshot.GetConfig()
+	spec := HandSpec{
+		Variant: "NLH",
+		Table: TableSpec{
+			MaxPlayers: uint16(cfg.GetMaxPlayers()),
+			SB:         handStart.GetSmallBlindAmount(),
+			BB:         handStart.GetBigBlindAmount(),
+			Ante:       handStart.GetAnteAmount(),
+		},
+		DealerChair: uint16(handStart.GetDealerChair()),
+	}
+	if handStart.GetStraddleAmount() > 0 {
+		if handStart.GetStraddleAmount() != 2*handStart.GetBigBlindAmount() {
+			return HandSpec{}, &ReplayError{StepIndex: -1, Reason: "unsupported_straddle", Message: fmt.Sprintf("straddle of %d is not 2x the big blind", handStart.GetStraddleAmount())}
+		}
+		chair := uint16(handStart.GetStraddleChair())
+		spec.StraddleChair = &chair
+	}
+
+	heroFound := false
+	for _, ps := range snapshot.GetPlayers() {
+		seat := SeatSpec{
+			Chair:  uint16(ps.GetChair()),
+			Name:   ps.GetNickname(),
+			UserID: ps.GetUserId(),
+			Stack:  ps.GetStack(),
+		}
+		hole := shown[ps.GetChair()]
+		if heroUserID != 0 && ps.GetUserId() == heroUserID {
+			seat.IsHero = true
+			heroFound = true
+			if len(heroHole) > 0 {
+				hole = heroHole
+			}
+		}
+		if len(hole) > 0 {
+			strs, err := protoCardStrings(hole)
+			if err != nil {
+				return HandSpec{}, &ReplayError{StepIndex: -1, Reason: "invalid_hole_cards", Message: fmt.Sprintf("chair %d: %v", seat.Chair, err)}
+			}
+			seat.Hole = strs
+		}
+		spec.Seats = append(spec.Seats, seat)
+	}
+	if !heroFound {
+		return HandSpec{}, &ReplayError{StepIndex: -1, Reason: "invalid_hero", Message: fmt.Sprintf("user %d is not seated in the snapshot", heroUserID)}
+	}
+
+	if len(board) > 0 {
+		strs, err := protoCardStrings(board)
+		if err != nil {
+			return HandSpec{}, &ReplayError{StepIndex: -1, Reason: "invalid_board_card", Message: err.Error()}
+		}
+		spec.Board = &BoardSpec{}
+		if len(strs) >= 3 {
+			spec.Board.Flop = strs[:3]
+		}
+		if len(strs) >= 4 {
+			spec.Board.Turn = &strs[3]
+		}
+		if len(strs) >= 5 {
+			spec.Board.River = &strs[4]
+		}
+	}
+
+	actions, err := reconstructActions(spec, handStart, results)
+	if err != nil {
+		return HandSpec{}, err
+	}
+	spec.Actions = actions
+	return spec, nil
+}
+
+// reconstructActions turns action results into ActionSpecs. Action results
+// carry the post-action street bet, which is zeroed when the action closes the
+// street, so the amounts are recovered by stepping an engine alongside. The
+// engine must post the forced bets handStart recorded.
+func reconstructActions(spec HandSpec, handStart *pb.HandStart, results []*pb.ActionResult) ([]ActionSpec, error) {
+	ns, err := normalizeSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	game, err := newReplayGame(ns, seedFromSpec(spec.RNG))
+	if err != nil {
+		return nil, err
+	}
+	if err := game.StartHand(); err != nil {
+		return nil, &ReplayError{StepIndex: -1, Reason: "start_hand_failed", Message: err.Error()}
+	}
+	// Recordings from before forced_total existed carry 0 and are not checked.
+	if forced := game.Snapshot().PotTotal(); handStart.GetForcedTotal() != 0 && forced != handStart.GetForcedTotal() {
+		return nil, &ReplayError{StepIndex: -1, Reason: "unsupported_forced_bets", Message: fmt.Sprintf(
+			"recorded forced bets total %d, a no-limit hand with these blinds and antes posts %d", handStart.GetForcedTotal(), forced)}
+	}
+
+	actions := make([]ActionSpec, 0, len(results))
+	for i, r := range results {
+		chair := uint16(r.GetChair())
+		action, err := actionFromProto(r.GetAction())
+		if err != nil {
+			return nil, &ReplayError{StepIndex: int32(i), Reason: "invalid_action", Message: err.Error()}
+		}
+		snap := game.Snapshot()
+		var stack, bet int64
+		for _, ps := range snap.Players {
+			if ps.Chair == chair {
+				stack, bet = ps.Stack, ps.Bet
+				break
+			}
+		}
+		amountTo := int64(0)
+		switch action {
+		case holdem.PlayerActionTypeCheck, holdem.PlayerActionTypeCall:
+			amountTo = snap.CurBet
+		case holdem.PlayerActionTypeBet, holdem.PlayerActionTypeRaise:
+			amountTo = r.GetAmount()
+		case holdem.PlayerActionTypeAllin:
+			amountTo = stack + bet
+		}
+		actions = append(actions, ActionSpec{
+			Phase:    phaseName(snap.Phase),
+			Chair:    chair,
+			Type:     actionName(action),
+			AmountTo: amountTo,
+		})
+		result, err := game.Act(chair, action, amountTo)
+		if err != nil {
+			return nil, &ReplayError{StepIndex: int32(i), Reason: "action_apply_failed", Message: err.Error()}
+		}
+		if result != nil {
+			break
+		}
+	}
+	return actions, nil
+}
+
+// VerifyEvents reconstructs a HandSpec from recorded events, regenerates the
+// tape and reports semantic differences. An empty result means the recording
+// replays cleanly on the current engine.
+func VerifyEvents(events []*pb.ServerEnvelope, heroUserID uint64) ([]TapeDiscrepancy, error) {
+	spec, err := ReconstructHandSpec(events, heroUserID)
+	if err != nil {
+		return nil, err
+	}
+	tape, err := GenerateReplayTape(spec)
+	if err != nil {
+		return nil, err
+	}
+	return DiffTape(tape, events), nil
+}
@@ -0,0 +1,286 @@
+package replay
+
+import (
+	"fmt"
+
+	"holdem-lite/card"
+	"holdem-lite/holdem"
+)
+
+// Qualitative grades for a scored decision, best first.
+const (
+	ScoreBest       = "best"
+	ScoreGood       = "good"
+	ScoreInaccuracy = "inaccuracy"
+	ScoreMistake    = "mistake"
+	ScoreBlunder    = "blunder"
+)
+
+// ActionInput is the action a user picks at a study decision point. AmountTo
+// is the street total for BET/RAISE and is ignored for other actions.
+type ActionInput struct {
+	Type     string `json:"type"`
+	AmountTo int64  `json:"amount_to"`
+}
+
+// DecisionScore grades a chosen action against the EV-best line at a decision
+// point. EVs are in chips relative to folding now; EVLoss is how far the chosen
+// action falls short of the best alternative.
+type DecisionScore struct {
+	StepIndex   int32       `json:"step_index"`
+	Chair       uint16      `json:"chair"`
+	Score       string      `json:"score"`
+	Chosen      ActionInput `json:"chosen"`
+	Recommended ActionInput `json:"recommended"`
+	Equity      float64     `json:"equity"`
+	PotOdds     float64     `json:"pot_odds"`
+	ChosenEV    float64     `json:"chosen_ev"`
+	BestEV      float64     `json:"best_ev"`
+	EVLoss      float64     `json:"ev_loss"`
+}
+
+// ScoreDecision replays spec up to Actions[step] and grades chosen for the
+// player to act there. Hero equity comes from the known hole cards of the
+// acting seat against every live opponent (unknown opponents are dealt at
+// random). The EV model has no fold equity and assumes a bet or raise gets
+// exactly one call, so aggression pays only above 50% equity; the recommended
+// bet or raise is sized at three quarters of the pot.
+func ScoreDecision(spec HandSpec, step int, chosen ActionInput) (DecisionScore, error) {
+	ns, err := normalizeSpec(spec)
+	if err != nil {
+		return DecisionScore{}, err
+	}
+	spec.StopAtStep = &step
+	tape, game, err := generateTape(spec)
+	if err != nil {
+		return DecisionScore{}, err
+	}
+	decision := tape.Decision
+	chair := decision.ActionChair
+
+	hero := ns.seatByChair[chair].hole
+	if len(hero) != 2 {
+		return DecisionScore{}, &ReplayError{
+			StepIndex: int32(step),
+			Reason:    "unknown_hole_cards",
+			Message:   fmt.Sprintf("chair %d has no known hole cards to score", chair),
+		}
+	}
+
+	snap := game.Snapshot()
+	var myStack, myBet, maxOpponentTotal int64
+	var opponents [][]card.Card
+	for _, ps := range snap.Players {
+		if ps.Chair == chair {
+			myStack, myBet = ps.Stack, ps.Bet
+			continue
+		}
+		if ps.Folded || len(ps.HandCards) == 0 {
+			continue
+		}
+		// Only spec-given hole cards are known; the rest were dealt as filler.
+		opponents = append(opponents, ns.seatByChair[ps.Chair].hole)
+		if total := ps.Stack + ps.Bet; total > maxOpponentTotal {
+			maxOpponentTotal = total
+		}
+	}
+
+	s := spotEV{
+		equity:     estimateEquity(hero, opponents, snap.CommunityCards, seedFromSpec(spec.RNG)+int64(step)),
+		pot:        decision.Pot,
+		curBet:     decision.CurBet,
+		toCall:     decision.CallAmount,
+		myBet:      myBet,
+		allInTo:    myStack + myBet,
+		coveredTo:  maxOpponentTotal,
+		minRaiseTo: decision.MinRaiseTo,
+	}
+
+	chosenAction, err := parseActionName(chosen.Type)
+	if err != nil {
+		return DecisionScore{}, &ReplayError{StepIndex: int32(step), Reason: "invalid_action", Message: err.Error()}
+	}
+	if !isLegalAction(game, chair, chosenAction) {
+		expected := expectedStateForChair(game, chair)
+		expected.Phase = decision.Phase
+		return DecisionScore{}, &ReplayError{
+			StepIndex: int32(step),
+			Reason:    "illegal_action",
+			Message:   fmt.Sprintf("action %s is not legal for chair %d", actionName(chosenAction), chair),
+			Expected:  expected,
+		}
+	}
+	chosenTo := s.amountTo(chosenAction, chosen.AmountTo)
+	if (chosenAction == holdem.PlayerActionTypeBet || chosenAction == holdem.PlayerActionTypeRaise) &&
+		(chosenTo < s.minRaiseTo || chosenTo > s.allInTo) {
+		return DecisionScore{}, &ReplayError{
+			StepIndex: int32(step),
+			Reason:    "invalid_amount",
+			Message:   fmt.Sprintf("%s to %d must be within %d..%d", actionName(chosenAction), chosenTo, s.minRaiseTo, s.allInTo),
+		}
+	}
+
+	// Candidates in passive-first order so ties recommend the cheaper line.
+	legal, _, _, _ := game.LegalActions(chair)
+	var aggressive holdem.ActionType
+	candidates := make([]holdem.ActionType, 0, 3)
+	for _, want := range []holdem.ActionType{holdem.PlayerActionTypeFold, holdem.PlayerActionTypeCheck, holdem.PlayerActionTypeCall} {
+		if containsAction(legal, want) {
+			candidates = append(candidates, want)
+		}
+	}
+	for _, want := range []holdem.ActionType{holdem.PlayerActionTypeBet, holdem.PlayerActionTypeRaise, holdem.PlayerActionTypeAllin} {
+		if containsAction(legal, want) {
+			aggressive = want
+			candidates = append(candidates, want)
+			break
+		}
+	}
+	sizedTo := s.sizedRaiseTo()
+	if sizedTo >= s.allInTo && containsAction(legal, holdem.PlayerActionTypeAllin) {
+		sizedTo = s.allInTo
+	}
+
+	best := ActionInput{Type: actionName(holdem.PlayerActionTypeFold)}
+	bestEV, alternativeEV := 0.0, 0.0
+	haveBest, haveAlternative := false, false
+	for _, a := range candidates {
+		to := s.amountTo(a, 0)
+		if a == aggressive && a != holdem.PlayerActionTypeAllin {
+			to = sizedTo
+			if to == s.allInTo {
+				a = holdem.PlayerActionTypeAllin
+			}
+		}
+		ev := s.ev(a, to)
+		if !haveBest || ev > bestEV {
+			best, bestEV, haveBest = ActionInput{Type: actionName(a), AmountTo: to}, ev, true
+		}
+		// Sizing is advice, not grading: the chosen bet is compared against
+		// the other action classes, not against the recommended size.
+		if isAggressive(a) && isAggressive(chosenAction) {
+			continue
+		}
+		if !haveAlternative || ev > alternativeEV {
+			alternativeEV, haveAlternative = ev, true
+		}
+	}
+
+	chosenEV := s.ev(chosenAction, chosenTo)
+	loss := alternativeEV - chosenEV
+	if loss < 0 {
+		loss = 0
+	}
+	out := DecisionScore{
+		StepIndex:   int32(step),
+		Chair:       chair,
+		Score:       gradeLoss(loss, s.pot),
+		Chosen:      ActionInput{Type: actionName(chosenAction), AmountTo: chosenTo},
+		Recommended: best,
+		Equity:      s.equity,
+		ChosenEV:    chosenEV,
+		BestEV:      bestEV,
+		EVLoss:      loss,
+	}
+	if s.toCall > 0 {
+		out.PotOdds = float64(s.toCall) / float64(s.pot+s.toCall)
+	}
+	return out, nil
+}
+
+// spotEV holds the pot context for one decision.
+type spotEV struct {
+	equity     float64
+	pot        int64
+	curBet     int64
+	toCall     int64
+	myBet      int64
+	allInTo    int64
+	coveredTo  int64
+	minRaiseTo int64
+}
+
+// amountTo fills in the street total an action implies, matching the spec's
+// amount_to conventions.
+func (s spotEV) amountTo(a holdem.ActionType, requested int64) int64 {
+	switch a {
+	case holdem.PlayerActionTypeCheck, holdem.PlayerActionTypeCall:
+		return s.curBet
+	case holdem.PlayerActionTypeBet, holdem.PlayerActionTypeRaise:
+		return requested
+	case holdem.PlayerActionTypeAllin:
+		return s.allInTo
+	default:
+		return 0
+	}
+}
+
+// sizedRaiseTo is a three-quarter-pot bet or raise, sized on the pot after
+// calling and never below the minimum raise.
+func (s spotEV) sizedRaiseTo() int64 {
+	to := s.curBet + (s.pot+s.toCall)*3/4
+	if to < s.minRaiseTo {
+		to = s.minRaiseTo
+	}
+	return to
+}
+
+// ev is the chip EV of an action relative to folding now.
+func (s spotEV) ev(a holdem.ActionType, to int64) float64 {
+	switch a {
+	case holdem.PlayerActionTypeCheck:
+		return s.equity * float64(s.pot)
+	case holdem.PlayerActionTypeCall:
+		call := s.toCall
+		if call > s.allInTo-s.myBet {
+			call = s.allInTo - s.myBet
+		}
+		return s.equity*float64(s.pot+call) - float64(call)
+	case holdem.PlayerActionTypeBet, holdem.PlayerActionTypeRaise, holdem.PlayerActionTypeAllin:
+		// Chips nobody can match come back, so only the covered part counts.
+		if to > s.coveredTo && s.coveredTo > s.curBet {
+			to = s.coveredTo
+		}
+		put := to - s.myBet
+		called := to - s.curBet
+		if called < 0 {
+			called = 0
+		}
+		return s.equity*float64(s.pot+put+called) - float64(put)
+	default:
+		return 0
+	}
+}
+
+func isAggressive(a holdem.ActionType) bool {
+	return a == holdem.PlayerActionTypeBet || a == holdem.PlayerActionTypeRaise || a == holdem.PlayerActionTypeAllin
+}
+
+func containsAction(actions []holdem.ActionType, want holdem.ActionType) bool {
+	for _, a := range actions {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+// gradeLoss turns an EV shortfall into a grade, measured against the pot so the
+// same mistake grades alike at any stake.
+func gradeLoss(loss float64, pot int64) string {
+	if pot <= 0 {
+		pot = 1
+	}
+	switch frac := loss / float64(pot); {
+	case frac <= 0.01:
+		return ScoreBest
+	case frac <= 0.05:
+		return ScoreGood
+	case frac <= 0.15:
+		return ScoreInaccuracy
+	case frac <= 0.35:
+		return ScoreMistake
+	default:
+		return ScoreBlunder
+	}
+}
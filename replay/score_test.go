@@ -0,0 +1,80 @@
+package replay
+
+import "testing"
+
+func TestScoreDecision_ValueRaiseWithSetScoresWell(t *testing.T) {
+	spec := baseHandSpec()
+	spec.Actions = []ActionSpec{
+		{Phase: "PREFLOP", Chair: 0, Type: "CALL", AmountTo: 100},
+		{Phase: "PREFLOP", Chair: 2, Type: "CALL", AmountTo: 100},
+		{Phase: "PREFLOP", Chair: 4, Type: "CHECK", AmountTo: 100},
+		{Phase: "FLOP", Chair: 2, Type: "BET", AmountTo: 200},
+	}
+
+	// Chair 4 holds a set of sevens on Ah7d2c facing top pair's bet.
+	score, err := ScoreDecision(spec, 4, ActionInput{Type: "RAISE", AmountTo: 700})
+	if err != nil {
+		t.Fatalf("ScoreDecision failed: %v", err)
+	}
+	if score.Chair != 4 {
+		t.Fatalf("expected chair 4 to be scored, got %d", score.Chair)
+	}
+	if score.Equity < 0.8 {
+		t.Fatalf("expected a set to be a big favorite, got equity %.3f", score.Equity)
+	}
+	if score.Score != ScoreBest {
+		t.Fatalf("expected the value raise to score %q, got %+v", ScoreBest, score)
+	}
+	if score.Recommended.Type != "RAISE" || score.Recommended.AmountTo != 725 {
+		t.Fatalf("expected a three-quarter-pot raise to 725, got %+v", score.Recommended)
+	}
+
+	passive, err := ScoreDecision(spec, 4, ActionInput{Type: "CALL"})
+	if err != nil {
+		t.Fatalf("ScoreDecision failed: %v", err)
+	}
+	if passive.EVLoss <= 0 || passive.Score == ScoreBest {
+		t.Fatalf("expected flatting the set to lose EV, got %+v", passive)
+	}
+}
+
+func TestScoreDecision_LooseCallOfPotBetScoresPoorly(t *testing.T) {
+	spec := baseHandSpec()
+	spec.Actions = []ActionSpec{
+		{Phase: "PREFLOP", Chair: 0, Type: "CALL", AmountTo: 100},
+		{Phase: "PREFLOP", Chair: 2, Type: "CALL", AmountTo: 100},
+		{Phase: "PREFLOP", Chair: 4, Type: "CHECK", AmountTo: 100},
+		{Phase: "FLOP", Chair: 2, Type: "CHECK", AmountTo: 0},
+		{Phase: "FLOP", Chair: 4, Type: "BET", AmountTo: 300},
+	}
+
+	// Hero's queen-high faces a pot-sized bet with top pair still behind.
+	score, err := ScoreDecision(spec, 5, ActionInput{Type: "CALL"})
+	if err != nil {
+		t.Fatalf("ScoreDecision failed: %v", err)
+	}
+	if score.Chair != 0 {
+		t.Fatalf("expected the hero chair to be scored, got %d", score.Chair)
+	}
+	if score.PotOdds < 0.33 || score.PotOdds > 0.34 {
+		t.Fatalf("expected 1/3 pot odds for a pot-sized bet, got %.3f", score.PotOdds)
+	}
+	if score.Equity >= score.PotOdds {
+		t.Fatalf("expected equity %.3f below pot odds %.3f", score.Equity, score.PotOdds)
+	}
+	if score.Score != ScoreMistake && score.Score != ScoreBlunder {
+		t.Fatalf("expected the loose call to score poorly, got %+v", score)
+	}
+	if score.Recommended.Type != "FOLD" {
+		t.Fatalf("expected fold to be recommended, got %+v", score.Recommended)
+	}
+}
+
+func TestScoreDecision_RejectsIllegalChoice(t *testing.T) {
+	spec := baseHandSpec()
+	_, err := ScoreDecision(spec, 5, ActionInput{Type: "CHECK"})
+	replayErr, ok := err.(*ReplayError)
+	if !ok || replayErr.Reason != "illegal_action" {
+		t.Fatalf("expected illegal_action for checking into a bet, got %v", err)
+	}
+}
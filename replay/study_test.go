@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"reflect"
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+)
+
+func TestGenerateReplayTape_StopAtFlopDecision(t *testing.T) {
+	spec := baseHandSpec()
+	stop := 4 // chair 4 to act on the flop after chair 2 checks
+	spec.StopAtStep = &stop
+
+	tape, err := GenerateReplayTape(spec)
+	if err != nil {
+		t.Fatalf("GenerateReplayTape failed: %v", err)
+	}
+	last := tape.Events[len(tape.Events)-1]
+	prompt := last.Value.GetActionPrompt()
+	if prompt == nil {
+		t.Fatalf("expected tape to end with an action prompt, got %s", last.Type)
+	}
+	if prompt.GetChair() != 4 {
+		t.Fatalf("expected prompt for chair 4, got %d", prompt.GetChair())
+	}
+	results := 0
+	for _, e := range tape.Events {
+		if e.Type == "actionResult" {
+			results++
+		}
+	}
+	if results != stop {
+		t.Fatalf("expected %d action results before the stop, got %d", stop, results)
+	}
+
+	d := tape.Decision
+	if d == nil {
+		t.Fatalf("expected decision metadata on a stopped tape")
+	}
+	if d.StepIndex != 4 || d.ActionChair != 4 || d.Phase != "FLOP" {
+		t.Fatalf("unexpected decision point %+v", d)
+	}
+	wantLegal := []pb.ActionType{pb.ActionType_ACTION_CHECK, pb.ActionType_ACTION_BET, pb.ActionType_ACTION_ALLIN, pb.ActionType_ACTION_FOLD}
+	if !sameActionSet(d.LegalActions, wantLegal) || !sameActionSet(prompt.GetLegalActions(), wantLegal) {
+		t.Fatalf("expected legal actions %v, got decision %v prompt %v", wantLegal, d.LegalActions, prompt.GetLegalActions())
+	}
+	if d.CallAmount != 0 || d.CurBet != 0 || d.Pot != 300 {
+		t.Fatalf("unexpected decision amounts call=%d curBet=%d pot=%d", d.CallAmount, d.CurBet, d.Pot)
+	}
+	if !reflect.DeepEqual(d.Board, []string{"Ah", "7d", "2c"}) {
+		t.Fatalf("unexpected decision board %v", d.Board)
+	}
+	if d.Recorded == nil || *d.Recorded != spec.Actions[4] {
+		t.Fatalf("expected recorded action %+v, got %+v", spec.Actions[4], d.Recorded)
+	}
+}
+
+func TestGenerateReplayTape_StopAtStepMustBeDecisionPoint(t *testing.T) {
+	for _, stop := range []int{-1, 8} {
+		spec := baseHandSpec()
+		spec.StopAtStep = &stop
+		_, err := GenerateReplayTape(spec)
+		replayErr, ok := err.(*ReplayError)
+		if !ok || replayErr.Reason != "invalid_stop_step" {
+			t.Fatalf("stop %d: expected invalid_stop_step, got %v", stop, err)
+		}
+	}
+
+	// The spec's last action ends the hand, so nobody acts at len(Actions).
+	spec := baseHandSpec()
+	stop := len(spec.Actions)
+	spec.StopAtStep = &stop
+	_, err := GenerateReplayTape(spec)
+	replayErr, ok := err.(*ReplayError)
+	if !ok || replayErr.Reason != "not_a_decision_point" {
+		t.Fatalf("expected not_a_decision_point, got %v", err)
+	}
+}
+
+func sameActionSet(got, want []pb.ActionType) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[pb.ActionType]bool, len(got))
+	for _, a := range got {
+		seen[a] = true
+	}
+	for _, a := range want {
+		if !seen[a] {
+			return false
+		}
+	}
+	return true
+}
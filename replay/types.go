@@ -11,6 +11,16 @@ type HandSpec struct {
 	Deck        []string     `json:"deck,omitempty"`
 	Actions     []ActionSpec `json:"actions"`
 	RNG         *RNGSpec     `json:"rng,omitempty"`
+	// StopAtStep ends the tape at the prompt for Actions[StopAtStep] (or the
+	// prompt after the last action when it equals len(Actions)) and reports the
+	// decision context instead of playing the hand out.
+	StopAtStep *int `json:"stop_at_step,omitempty"`
+	// HeroChair, when set, overrides the seat flagged IsHero so the same hand
+	// can be replayed from another player's perspective.
+	HeroChair *uint16 `json:"hero_chair,omitempty"`
+	// StraddleChair posts a 2x BB blind straddle from that seat. The hand is
+	// rejected if the engine would not accept the straddle.
+	StraddleChair *uint16 `json:"straddle_chair,omitempty"`
 }
 
 type TableSpec struct {
@@ -46,11 +56,27 @@ type RNGSpec struct {
 	Seed int64 `json:"seed"`
 }
 
+// CurrentTapeVersion is the ReplayTape format GenerateReplayTape produces.
+// Bump it whenever the events or their encoding change incompatibly.
+const CurrentTapeVersion = 1
+
 type ReplayTape struct {
-	TapeVersion int           `json:"tape_version"`
-	TableID     string        `json:"table_id"`
-	HeroChair   uint16        `json:"hero_chair"`
-	Events      []ReplayEvent `json:"events"`
+	TapeVersion int            `json:"tape_version"`
+	TableID     string         `json:"table_id"`
+	HeroChair   uint16         `json:"hero_chair"`
+	Events      []ReplayEvent  `json:"events"`
+	Decision    *DecisionPoint `json:"decision,omitempty"`
+}
+
+// DecisionPoint is the spot a StopAtStep tape stops at: who acts, what they
+// may do, and the action the spec recorded there, if any.
+type DecisionPoint struct {
+	StepIndex int32 `json:"step_index"`
+	ExpectedState
+	CurBet   int64       `json:"cur_bet"`
+	Pot      int64       `json:"pot"`
+	Board    []string    `json:"board,omitempty"`
+	Recorded *ActionSpec `json:"recorded,omitempty"`
 }
 
 type ReplayEvent struct {
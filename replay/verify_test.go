@@ -0,0 +1,137 @@
+package replay
+
+import (
+	"testing"
+
+	pb "holdem-lite/apps/server/gen"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func recordedEvents(t *testing.T, spec HandSpec) []*pb.ServerEnvelope {
+	t.Helper()
+	tape, err := GenerateReplayTape(spec)
+	if err != nil {
+		t.Fatalf("GenerateReplayTape failed: %v", err)
+	}
+	out := make([]*pb.ServerEnvelope, 0, len(tape.Events))
+	for _, e := range tape.Events {
+		out = append(out, proto.Clone(e.Value).(*pb.ServerEnvelope))
+	}
+	return out
+}
+
+func TestVerifyEvents_CleanHandHasNoDiscrepancies(t *testing.T) {
+	events := recordedEvents(t, baseHandSpec())
+
+	discrepancies, err := VerifyEvents(events, 100000)
+	if err != nil {
+		t.Fatalf("VerifyEvents failed: %v", err)
+	}
+	if len(discrepancies) != 0 {
+		t.Fatalf("expected clean hand to verify, got %+v", discrepancies)
+	}
+}
+
+func TestVerifyEvents_IgnoresDeckSeedProof(t *testing.T) {
+	// Live tables commit to the deck seed at hand start and reveal it at
+	// hand end; the generator deals from the spec and has neither.
+	events := recordedEvents(t, baseHandSpec())
+	for _, env := range events {
+		if start := env.GetHandStart(); start != nil {
+			start.SeedCommitment = "5d41402abc4b2a76b9719d911017c592"
+		}
+		if end := env.GetHandEnd(); end != nil {
+			end.DeckSeed = 42
+		}
+	}
+
+	discrepancies, err := VerifyEvents(events, 100000)
+	if err != nil {
+		t.Fatalf("VerifyEvents failed: %v", err)
+	}
+	if len(discrepancies) != 0 {
+		t.Fatalf("expected the seed proof to be ignored, got %+v", discrepancies)
+	}
+}
+
+func TestVerifyEvents_ReportsTamperedEvent(t *testing.T) {
+	events := recordedEvents(t, baseHandSpec())
+	tampered := false
+	for _, env := range events {
+		if r := env.GetActionResult(); r != nil {
+			r.NewStack += 500
+			tampered = true
+			break
+		}
+	}
+	if !tampered {
+		t.Fatalf("expected an actionResult to tamper with")
+	}
+
+	discrepancies, err := VerifyEvents(events, 100000)
+	if err != nil {
+		t.Fatalf("VerifyEvents failed: %v", err)
+	}
+	if len(discrepancies) != 1 {
+		t.Fatalf("expected exactly one discrepancy, got %+v", discrepancies)
+	}
+	if d := discrepancies[0]; d.Reason != "mismatch" || d.EventType != "actionResult" {
+		t.Fatalf("unexpected discrepancy: %+v", d)
+	}
+}
+
+func TestVerifyEvents_ForcedBetVariants(t *testing.T) {
+	ante := baseHandSpec()
+	ante.Table.Ante = 10
+
+	straddle := baseHandSpec()
+	utg := uint16(0)
+	straddle.StraddleChair = &utg
+	straddle.Actions = []ActionSpec{
+		{Phase: "PREFLOP", Chair: 2, Type: "FOLD"},
+		{Phase: "PREFLOP", Chair: 4, Type: "FOLD"},
+	}
+
+	bbOnly := baseHandSpec()
+	bbOnly.Table.SB = 0
+	bbOnly.Actions = []ActionSpec{
+		{Phase: "PREFLOP", Chair: 4, Type: "FOLD"},
+		{Phase: "PREFLOP", Chair: 0, Type: "FOLD"},
+	}
+
+	for name, spec := range map[string]HandSpec{"ante": ante, "straddle": straddle, "big blind only": bbOnly} {
+		events := recordedEvents(t, spec)
+		rebuilt, err := ReconstructHandSpec(events, 100000)
+		if err != nil {
+			t.Fatalf("%s: ReconstructHandSpec failed: %v", name, err)
+		}
+		if rebuilt.Table.Ante != spec.Table.Ante || rebuilt.Table.SB != spec.Table.SB ||
+			(spec.StraddleChair == nil) != (rebuilt.StraddleChair == nil) {
+			t.Fatalf("%s: expected forced bets from handStart, got %+v straddle=%v", name, rebuilt.Table, rebuilt.StraddleChair)
+		}
+		discrepancies, err := VerifyEvents(events, 100000)
+		if err != nil {
+			t.Fatalf("%s: VerifyEvents failed: %v", name, err)
+		}
+		if len(discrepancies) != 0 {
+			t.Fatalf("%s: expected the hand to verify, got %+v", name, discrepancies)
+		}
+	}
+}
+
+func TestVerifyEvents_RejectsUnsupportedForcedBets(t *testing.T) {
+	spec := baseHandSpec()
+	spec.Table.Ante = 10
+	events := recordedEvents(t, spec)
+	// A big-blind ante posts one ante instead of one per player.
+	for _, env := range events {
+		if start := env.GetHandStart(); start != nil {
+			start.ForcedTotal -= 2 * start.GetAnteAmount()
+		}
+	}
+	_, err := VerifyEvents(events, 100000)
+	if re, ok := err.(*ReplayError); !ok || re.Reason != "unsupported_forced_bets" {
+		t.Fatalf("expected unsupported_forced_bets, got %v", err)
+	}
+}
@@ -1,10 +1,14 @@
 package replay
 
 type WireReplayTape struct {
-	TapeVersion int               `json:"tapeVersion"`
-	TableID     string            `json:"tableId"`
-	HeroChair   uint16            `json:"heroChair"`
-	Events      []WireReplayEvent `json:"events"`
+	TapeVersion int `json:"tapeVersion"`
+	// SchemaVersion is the tape format the frontend decodes; it follows
+	// TapeVersion.
+	SchemaVersion int               `json:"schemaVersion"`
+	TableID       string            `json:"tableId"`
+	HeroChair     uint16            `json:"heroChair"`
+	Events        []WireReplayEvent `json:"events"`
+	Decision      *DecisionPoint    `json:"decision,omitempty"`
 }
 
 type WireReplayEvent struct {
@@ -18,10 +22,12 @@ func ToWireReplayTape(tape *ReplayTape) *WireReplayTape {
 		return nil
 	}
 	out := &WireReplayTape{
-		TapeVersion: tape.TapeVersion,
-		TableID:     tape.TableID,
-		HeroChair:   tape.HeroChair,
-		Events:      make([]WireReplayEvent, 0, len(tape.Events)),
+		TapeVersion:   tape.TapeVersion,
+		SchemaVersion: tape.TapeVersion,
+		TableID:       tape.TableID,
+		HeroChair:     tape.HeroChair,
+		Events:        make([]WireReplayEvent, 0, len(tape.Events)),
+		Decision:      tape.Decision,
 	}
 	for _, e := range tape.Events {
 		out.Events = append(out.Events, WireReplayEvent{